@@ -2,20 +2,58 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// Quiet hours resolve users' time zones even where the host has no zoneinfo
+	_ "time/tzdata"
 
 	"github.com/emotab87/vibe_coding/backend/internal/config"
 	"github.com/emotab87/vibe_coding/backend/internal/server"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
 )
 
 func main() {
+	printConfig := flag.Bool("print-config", false, "print the effective configuration (secrets redacted) and exit")
+	generateVAPIDKeys := flag.Bool("generate-vapid-keys", false, "print a new Web Push key pair and exit")
+	generatePIIKey := flag.Bool("generate-pii-key", false, "print a new email encryption key and exit")
+	rotatePII := flag.Bool("rotate-pii", false, "encrypt stored emails with the current key and exit")
+	demo := flag.Bool("demo", false, "serve sample content from an in-memory database that resets periodically")
+	flag.Parse()
+
+	if *generateVAPIDKeys {
+		keys, privateKey, err := services.GenerateVAPIDKeys()
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Printf("VAPID_PRIVATE_KEY=%s\n# public key: %s\n", privateKey, keys.PublicKey)
+		return
+	}
+
+	if *generatePIIKey {
+		key, err := services.GeneratePIIKey()
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Println(key)
+		return
+	}
+
 	// Load configuration from environment variables
 	cfg := config.LoadConfig()
+	if *demo {
+		cfg.DemoMode = true
+	}
+
+	if *printConfig {
+		cfg.Print(os.Stdout)
+		return
+	}
 
 	// Create and configure the server
 	srv, err := server.NewServer(cfg)
@@ -24,6 +62,15 @@ func main() {
 	}
 	defer srv.Close()
 
+	if *rotatePII {
+		rotated, err := srv.RotatePII()
+		if err != nil {
+			log.Fatalf("❌ Email encryption rotation failed after %d users: %v", rotated, err)
+		}
+		log.Printf("🔐 Re-encrypted the emails of %d users", rotated)
+		return
+	}
+
 	// Create HTTP server with configured settings
 	httpServer := &http.Server{
 		Addr:         cfg.ServerAddress(),
@@ -31,14 +78,62 @@ func main() {
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		ConnState:    srv.TrackConnection,
 	}
 
+	// Once draining starts, idle connections are closed and no more are kept alive
+	go func() {
+		<-srv.DrainStarted()
+		httpServer.SetKeepAlivesEnabled(false)
+	}()
+
+	// Background jobs stop when main returns
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// Send daily digest emails in the background, at each user's digest hour
+	go srv.RunDigests(backgroundCtx)
+
+	// Send what was held during quiet hours once they end
+	go srv.RunQuietHoursRelease(backgroundCtx)
+
+	// Report suspected abuse to admins
+	go srv.RunAnomalyScans(backgroundCtx)
+
+	// Notify search engines about published articles
+	go srv.RunSearchPings(backgroundCtx)
+
+	// Render articles and books queued for export to PDF and EPUB
+	go srv.RunExports(backgroundCtx)
+
+	// Keep exported business KPIs current
+	go srv.RunKPIRefresh(backgroundCtx)
+
+	// Alert operators about error, login failure and backlog spikes
+	go srv.RunAlerts(backgroundCtx)
+
+	// Re-check degraded subsystems so they recover automatically
+	go srv.RunHealthChecks(backgroundCtx)
+
+	// Repair favorite counts that drifted from the favorites themselves
+	go srv.RunFavoriteReconciliation(backgroundCtx)
+
+	// Put demo data back to the sample content
+	go srv.RunDemoResets(backgroundCtx)
+
+	// Rotate the key pair access tokens are signed with
+	go srv.RunKeyRotation(backgroundCtx)
+
 	// Start server in a goroutine
 	serverErrors := make(chan error, 1)
 	go func() {
 		log.Printf("🚀 Server starting on %s", cfg.ServerAddress())
 		log.Printf("📖 Environment: %s", cfg.Environment)
-		log.Printf("🔧 Database: %s", cfg.DatabasePath)
+		if cfg.DemoMode {
+			log.Printf("🎭 Database: in-memory demo data")
+		} else {
+			log.Printf("🔧 Database: %s", cfg.DatabasePath)
+		}
 		
 		serverErrors <- httpServer.ListenAndServe()
 	}()
@@ -54,10 +149,20 @@ func main() {
 	case sig := <-shutdown:
 		log.Printf("🔄 Server shutting down due to signal: %v", sig)
 
+		// Keep serving while load balancers stop routing here; a second
+		// signal cuts the wait short
+		drainCtx, stopDrain := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		srv.Drain(drainCtx)
+		stopDrain()
+
 		// Give outstanding requests 30 seconds to complete
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
+		if _, active := srv.Connections(); active > 0 {
+			log.Printf("⏳ Waiting for %d active connections to finish", active)
+		}
+
 		// Attempt graceful shutdown
 		if err := httpServer.Shutdown(ctx); err != nil {
 			log.Printf("⚠️  Graceful shutdown failed, forcing shutdown: %v", err)
@@ -2,43 +2,447 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
-// Config holds all configuration for our application
+// Config holds all configuration for our application.
+// Each field is read from the environment variable named in its env tag;
+// fields tagged secret are redacted when the configuration is described.
 type Config struct {
-	Environment     string
-	Port            string
-	Host            string
-	DatabasePath    string
-	JWTSecret       string
-	JWTExpiryHours  int
-	CORSOrigins     string
-	LogLevel        string
-	LogFormat       string
-	BcryptRounds    int
-	DebugSQL        bool
-	DebugCORS       bool
-	AIREnabled      bool
+	Environment        string `env:"ENV"`
+	Port               string `env:"PORT"`
+	Host               string `env:"HOST"`
+	PublicURL          string `env:"PUBLIC_URL"`
+	SiteURL            string `env:"SITE_URL"`
+	DatabasePath       string `env:"DB_PATH"`
+	JWTSecret          string `env:"JWT_SECRET" secret:"true"`
+	JWTIssuer          string `env:"JWT_ISSUER"`
+	JWTAudience        string `env:"JWT_AUDIENCE"`
+	JWTAlgorithm       string `env:"JWT_ALGORITHM"`
+	JWTKeyRotationDays int    `env:"JWT_KEY_ROTATION_DAYS"`
+	JWTKeyEncryptKeys  string `env:"JWT_KEY_ENCRYPT_KEYS" secret:"true"`
+	CORSOrigins        string `env:"CORS_ORIGINS"`
+	LogLevel           string `env:"LOG_LEVEL"`
+	LogFormat          string `env:"LOG_FORMAT"`
+	BcryptRounds       int    `env:"BCRYPT_ROUNDS"`
+	DebugSQL           bool   `env:"DEBUG_SQL"`
+	DebugCORS          bool   `env:"DEBUG_CORS"`
+	AIREnabled         bool   `env:"AIR_ENABLED"`
+	AdminUsernames     string `env:"ADMIN_USERNAMES"`
+	SMTPHost           string `env:"SMTP_HOST"`
+	SMTPPort           int    `env:"SMTP_PORT"`
+	SMTPUser           string `env:"SMTP_USER"`
+	SMTPPass           string `env:"SMTP_PASS" secret:"true"`
+	EmailFrom          string `env:"EMAIL_FROM"`
+	DigestHour         int    `env:"DIGEST_HOUR"`
+	ReplyEmailDomain   string `env:"REPLY_EMAIL_DOMAIN"`
+	InboundEmailSecret string `env:"INBOUND_EMAIL_SECRET" secret:"true"`
+	CommentDeleteMode  string `env:"COMMENT_DELETE_MODE"`
+
+	// Token lifetimes by kind, and the clock skew tolerated when checking a
+	// token's nbf and exp
+	AccessTokenTTLMinutes   int `env:"ACCESS_TOKEN_TTL_MINUTES"`
+	RefreshTokenTTLDays     int `env:"REFRESH_TOKEN_TTL_DAYS"`
+	MagicLinkTTLMinutes     int `env:"MAGIC_LINK_TTL_MINUTES"`
+	PasswordResetTTLMinutes int `env:"PASSWORD_RESET_TTL_MINUTES"`
+	ReplyTokenTTLDays       int `env:"REPLY_TOKEN_TTL_DAYS"`
+	TokenClockSkewSeconds   int `env:"TOKEN_CLOCK_SKEW_SECONDS"`
+
+	// Web Push; pushes are logged when no VAPID key is set
+	VAPIDPrivateKey string `env:"VAPID_PRIVATE_KEY" secret:"true"`
+	VAPIDSubject    string `env:"VAPID_SUBJECT"`
+
+	// Comment rate limits; established accounts get the higher limits
+	CommentMinIntervalSeconds            int `env:"COMMENT_MIN_INTERVAL_SECONDS"`
+	CommentHourlyLimit                   int `env:"COMMENT_HOURLY_LIMIT"`
+	EstablishedCommentMinIntervalSeconds int `env:"ESTABLISHED_COMMENT_MIN_INTERVAL_SECONDS"`
+	EstablishedCommentHourlyLimit        int `env:"ESTABLISHED_COMMENT_HOURLY_LIMIT"`
+	EstablishedAccountDays               int `env:"ESTABLISHED_ACCOUNT_DAYS"`
+
+	// Daily article and comment quotas per account tier; moderators and admins have none
+	QuotaArticlesPerDay            int `env:"QUOTA_ARTICLES_PER_DAY"`
+	QuotaCommentsPerDay            int `env:"QUOTA_COMMENTS_PER_DAY"`
+	EstablishedQuotaArticlesPerDay int `env:"ESTABLISHED_QUOTA_ARTICLES_PER_DAY"`
+	EstablishedQuotaCommentsPerDay int `env:"ESTABLISHED_QUOTA_COMMENTS_PER_DAY"`
+
+	// Days a new account's articles stay out of listings, tags and feeds unless
+	// an admin approves it; 0 disables quarantine
+	QuarantineDays int `env:"QUARANTINE_DAYS"`
+
+	AnalyticsSalt string `env:"ANALYTICS_SALT" secret:"true"`
+
+	// Encryption of email addresses at rest: comma-separated id:base64 keys,
+	// current key first, and the key for blind index lookups
+	PIIEncryptionKeys string `env:"PII_ENCRYPTION_KEYS" secret:"true"`
+	PIIIndexKey       string `env:"PII_INDEX_KEY" secret:"true"`
+
+	HealthCheckIntervalSeconds int `env:"HEALTH_CHECK_INTERVAL_SECONDS"`
+
+	// Flag every response with X-Incident-Notice while an incident or
+	// maintenance posted by an admin is active
+	IncidentHeader bool `env:"INCIDENT_HEADER"`
+
+	// Comma-separated CIDRs of clients, such as gateways, whose X-Request-ID
+	// and traceparent headers are kept; everyone else gets a fresh request ID
+	CorrelationTrustedNetworks string `env:"CORRELATION_TRUSTED_NETWORKS"`
+
+	// Shutdown draining: readiness fails and connections stop being kept alive
+	// for this long before the listener closes; POST /internal/drain starts it
+	// early and is only served when a drain token is set
+	DrainSeconds int    `env:"DRAIN_SECONDS"`
+	DrainToken   string `env:"DRAIN_TOKEN" secret:"true"`
+
+	// Business KPI metrics; /metrics is only served when a scrape token is set
+	MetricsToken              string `env:"METRICS_TOKEN" secret:"true"`
+	KPIRefreshIntervalSeconds int    `env:"KPI_REFRESH_INTERVAL_SECONDS"`
+
+	// Alerting on error rate, login failure and queue backlog spikes; alerts
+	// are logged when neither a webhook nor email recipients are set
+	AlertIntervalSeconds      int    `env:"ALERT_INTERVAL_SECONDS"`
+	AlertCooldownMinutes      int    `env:"ALERT_COOLDOWN_MINUTES"`
+	AlertErrorRatePercent     int    `env:"ALERT_ERROR_RATE_PERCENT"`
+	AlertErrorRateMinRequests int    `env:"ALERT_ERROR_RATE_MIN_REQUESTS"`
+	AlertLoginFailures        int    `env:"ALERT_LOGIN_FAILURES"`
+	AlertQueueBacklog         int    `env:"ALERT_QUEUE_BACKLOG"`
+	AlertWebhookURL           string `env:"ALERT_WEBHOOK_URL" secret:"true"`
+	AlertEmails               string `env:"ALERT_EMAILS"`
+
+	StartupIntegrityCheck bool `env:"STARTUP_INTEGRITY_CHECK"`
+
+	SyndicationCacheTTLMinutes int `env:"SYNDICATION_CACHE_TTL_MINUTES"`
+
+	// Search engine notifications for published articles; pings are queued
+	// only for the engines configured here
+	IndexNowKey               string `env:"INDEXNOW_KEY"`
+	IndexNowEndpoint          string `env:"INDEXNOW_ENDPOINT"`
+	SitemapPingURLs           string `env:"SITEMAP_PING_URLS"`
+	SearchPingIntervalSeconds int    `env:"SEARCH_PING_INTERVAL_SECONDS"`
+
+	// robots.txt: paths no crawler may fetch, crawlers shut out entirely, or
+	// every crawler shut out (staging and private deployments)
+	RobotsDisallow      string `env:"ROBOTS_DISALLOW"`
+	RobotsBlockedAgents string `env:"ROBOTS_BLOCKED_AGENTS"`
+	RobotsDisallowAll   bool   `env:"ROBOTS_DISALLOW_ALL"`
+
+	// Sanitization of article and comment bodies
+	SanitizeAllowHTML       bool   `env:"SANITIZE_ALLOW_HTML"`
+	SanitizeExternalLinkRel string `env:"SANITIZE_EXTERNAL_LINK_REL"`
+	SanitizeImageProxyURL   string `env:"SANITIZE_IMAGE_PROXY_URL"`
+	SanitizeEmbedProviders  string `env:"SANITIZE_EMBED_PROVIDERS"`
+
+	// Image proxy for external images in article bodies
+	ImageProxyEnabled        bool   `env:"IMAGE_PROXY_ENABLED"`
+	ImageProxyAllowedHosts   string `env:"IMAGE_PROXY_ALLOWED_HOSTS"`
+	ImageProxyDeniedHosts    string `env:"IMAGE_PROXY_DENIED_HOSTS"`
+	ImageProxyMaxBytes       int    `env:"IMAGE_PROXY_MAX_BYTES"`
+	ImageProxyMaxPixels      int    `env:"IMAGE_PROXY_MAX_PIXELS"`
+	ImageProxyMaxWidth       int    `env:"IMAGE_PROXY_MAX_WIDTH"`
+	ImageProxyCacheMB        int    `env:"IMAGE_PROXY_CACHE_MB"`
+	ImageProxyTimeoutSeconds int    `env:"IMAGE_PROXY_TIMEOUT_SECONDS"`
+
+	LinkPreviewTimeoutSeconds int `env:"LINK_PREVIEW_TIMEOUT_SECONDS"`
+	LinkPreviewMaxAgeHours    int `env:"LINK_PREVIEW_MAX_AGE_HOURS"`
+
+	// Per-client limits shared by the routes in each rate limit class; a class
+	// with a zero per-minute limit is not limited
+	RateLimitAuthPerMinute  int `env:"RATE_LIMIT_AUTH_PER_MINUTE"`
+	RateLimitAuthBurst      int `env:"RATE_LIMIT_AUTH_BURST"`
+	RateLimitWritePerMinute int `env:"RATE_LIMIT_WRITE_PER_MINUTE"`
+	RateLimitWriteBurst     int `env:"RATE_LIMIT_WRITE_BURST"`
+	RateLimitReadPerMinute  int `env:"RATE_LIMIT_READ_PER_MINUTE"`
+	RateLimitReadBurst      int `env:"RATE_LIMIT_READ_BURST"`
+	RateLimitAdminPerMinute int `env:"RATE_LIMIT_ADMIN_PER_MINUTE"`
+	RateLimitAdminBurst     int `env:"RATE_LIMIT_ADMIN_BURST"`
+
+	// Replay protection for account changes: "off", "nonce" (unique nonce and
+	// fresh timestamp) or "signed" (additionally an HMAC signature)
+	ReplayProtection    string `env:"REPLAY_PROTECTION"`
+	ReplayWindowSeconds int    `env:"REPLAY_WINDOW_SECONDS"`
+
+	// Days a deactivated account can be reactivated by logging in again
+	DeactivationGraceDays int `env:"DEACTIVATION_GRACE_DAYS"`
+
+	// Account lockout: failed logins for one account within the window lock
+	// it for the lockout period, and failed logins from one network within
+	// the window refuse further attempts from it; 0 disables either limit
+	LoginMaxFailuresPerAccount int `env:"LOGIN_MAX_FAILURES_PER_ACCOUNT"`
+	LoginMaxFailuresPerNetwork int `env:"LOGIN_MAX_FAILURES_PER_NETWORK"`
+	LoginFailureWindowMinutes  int `env:"LOGIN_FAILURE_WINDOW_MINUTES"`
+	LoginLockoutMinutes        int `env:"LOGIN_LOCKOUT_MINUTES"`
+
+	// Abuse detection: activity within the window at or above a threshold is
+	// reported to admins; a zero threshold disables that signal
+	AnomalyScanIntervalMinutes     int `env:"ANOMALY_SCAN_INTERVAL_MINUTES"`
+	AnomalyWindowMinutes           int `env:"ANOMALY_WINDOW_MINUTES"`
+	AnomalyRegistrationsPerNetwork int `env:"ANOMALY_REGISTRATIONS_PER_NETWORK"`
+	AnomalyFavoritesPerUser        int `env:"ANOMALY_FAVORITES_PER_USER"`
+	AnomalyCommentsPerUser         int `env:"ANOMALY_COMMENTS_PER_USER"`
+
+	// Private beta: reading stays public, but registration needs an invite and
+	// articles by non-moderators wait for approval
+	BetaMode bool `env:"BETA_MODE"`
+
+	// First-time authors: articles by accounts younger than the age limit wait
+	// for moderator approval until this many have been published; 0 disables
+	ReviewFirstArticles     int `env:"REVIEW_FIRST_ARTICLES"`
+	ReviewNewAccountMaxDays int `env:"REVIEW_NEW_ACCOUNT_MAX_DAYS"`
+
+	// Demo mode: an in-memory database seeded with sample content, reset to it
+	// on the interval (0 never resets); responses carry the X-Demo-Mode header
+	DemoMode         bool `env:"DEMO_MODE"`
+	DemoResetMinutes int  `env:"DEMO_RESET_MINUTES"`
+
+	// Minutes between recounts that repair articles' favorite counts if they
+	// ever drift from the favorites themselves (0 disables)
+	FavoriteReconcileMinutes int `env:"FAVORITE_RECONCILE_MINUTES"`
+
+	// Seconds between runs that render queued article exports (0 disables),
+	// and hours exports are kept for download (0 keeps them)
+	ExportIntervalSeconds int `env:"EXPORT_INTERVAL_SECONDS"`
+	ExportRetentionHours  int `env:"EXPORT_RETENTION_HOURS"`
+
+	// Articles an author can pin to the top of their profile
+	MaxPinnedArticles int `env:"MAX_PINNED_ARTICLES"`
+
+	// Claps one user can give a single article in total
+	MaxClapsPerUser int `env:"MAX_CLAPS_PER_USER"`
+
+	// Text attachments (code snippets, small files) an article can carry, and
+	// the size limit of each
+	MaxAttachmentsPerArticle int `env:"MAX_ATTACHMENTS_PER_ARTICLE"`
+	MaxAttachmentBytes       int `env:"MAX_ATTACHMENT_BYTES"`
+
+	// Minutes over which a burst of the same notification, such as favorites
+	// of one article, coalesces into a single notification with a count (0
+	// disables)
+	NotificationCoalesceMinutes int `env:"NOTIFICATION_COALESCE_MINUTES"`
+
+	// Soft bounces in a row, reported to /api/webhooks/email/events, after
+	// which email to an address is suppressed (0 only suppresses on hard
+	// bounces and complaints)
+	EmailSoftBounceLimit int `env:"EMAIL_SOFT_BOUNCE_LIMIT"`
+
+	// Branding HTML emails are dressed in; links in them point at SiteURL
+	EmailBrandName  string `env:"EMAIL_BRAND_NAME"`
+	EmailBrandColor string `env:"EMAIL_BRAND_COLOR"`
+	EmailLogoURL    string `env:"EMAIL_LOGO_URL"`
+	EmailFooterText string `env:"EMAIL_FOOTER_TEXT"`
+
+	// Compliance rules for the deployment's region. ComplianceRegion picks a
+	// preset for the others (see compliancePresets), which can still be set
+	// individually.
+	ComplianceRegion string `env:"COMPLIANCE_REGION"`
+	// Record analytics events only for visitors who accepted analytics cookies
+	CookieConsentRequired bool `env:"COOKIE_CONSENT_REQUIRED"`
+	// Keep user data in the region by switching off features that send it to
+	// third-party services elsewhere (web push)
+	DataResidency bool `env:"DATA_RESIDENCY"`
+	// Age new users must confirm they are at registration (0 disables)
+	MinimumAge int `env:"MINIMUM_AGE"`
+	// Ask for a birthdate at registration, stored encrypted, and check
+	// MinimumAge against it instead of the user's confirmation
+	RequireBirthdate bool `env:"REQUIRE_BIRTHDATE"`
+	// Users younger than this, going by their birthdate, cannot make their
+	// profile public or searchable (0 disables)
+	AdultAge int `env:"ADULT_AGE"`
+}
+
+// compliancePreset holds the compliance defaults of a region
+type compliancePreset struct {
+	cookieConsent bool
+	dataResidency bool
+	minimumAge    int
+}
+
+// compliancePresets are the regions COMPLIANCE_REGION accepts. The empty
+// region applies no rules.
+var compliancePresets = map[string]compliancePreset{
+	"":   {},
+	"eu": {cookieConsent: true, dataResidency: true, minimumAge: 16},
+	"uk": {cookieConsent: true, minimumAge: 13},
+	"us": {minimumAge: 13},
+	"kr": {cookieConsent: true, minimumAge: 14},
 }
 
 // LoadConfig loads configuration from environment variables with sensible defaults
 func LoadConfig() *Config {
+	region := getEnvOrDefault("COMPLIANCE_REGION", "")
+	preset := compliancePresets[region]
+
 	return &Config{
-		Environment:     getEnvOrDefault("ENV", "development"),
-		Port:            getEnvOrDefault("PORT", "8080"),
-		Host:            getEnvOrDefault("HOST", "localhost"),
-		DatabasePath:    getEnvOrDefault("DB_PATH", "./data/conduit.db"),
-		JWTSecret:       getEnvOrDefault("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
-		JWTExpiryHours:  getEnvIntOrDefault("JWT_EXPIRY_HOURS", 72),
-		CORSOrigins:     getEnvOrDefault("CORS_ORIGINS", "http://localhost:3000"),
-		LogLevel:        getEnvOrDefault("LOG_LEVEL", "debug"),
-		LogFormat:       getEnvOrDefault("LOG_FORMAT", "json"),
-		BcryptRounds:    getEnvIntOrDefault("BCRYPT_ROUNDS", 12),
-		DebugSQL:        getEnvBoolOrDefault("DEBUG_SQL", true),
-		DebugCORS:       getEnvBoolOrDefault("DEBUG_CORS", true),
-		AIREnabled:      getEnvBoolOrDefault("AIR_ENABLED", true),
+		Environment:        getEnvOrDefault("ENV", "development"),
+		Port:               getEnvOrDefault("PORT", "8080"),
+		Host:               getEnvOrDefault("HOST", "localhost"),
+		PublicURL:          getEnvOrDefault("PUBLIC_URL", "http://localhost:8080"),
+		SiteURL:            getEnvOrDefault("SITE_URL", "http://localhost:3000"),
+		DatabasePath:       getEnvOrDefault("DB_PATH", "./data/conduit.db"),
+		JWTSecret:          getEnvOrDefault("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
+		JWTIssuer:          getEnvOrDefault("JWT_ISSUER", "conduit-api"),
+		JWTAudience:        getEnvOrDefault("JWT_AUDIENCE", "conduit"),
+		JWTAlgorithm:       getEnvOrDefault("JWT_ALGORITHM", "HS256"),
+		JWTKeyRotationDays: getEnvIntOrDefault("JWT_KEY_ROTATION_DAYS", 30),
+		JWTKeyEncryptKeys:  getEnvOrDefault("JWT_KEY_ENCRYPT_KEYS", ""),
+		CORSOrigins:        getEnvOrDefault("CORS_ORIGINS", "http://localhost:3000"),
+		LogLevel:           getEnvOrDefault("LOG_LEVEL", "debug"),
+		LogFormat:          getEnvOrDefault("LOG_FORMAT", "json"),
+		BcryptRounds:       getEnvIntOrDefault("BCRYPT_ROUNDS", 12),
+		DebugSQL:           getEnvBoolOrDefault("DEBUG_SQL", true),
+		DebugCORS:          getEnvBoolOrDefault("DEBUG_CORS", true),
+		AIREnabled:         getEnvBoolOrDefault("AIR_ENABLED", true),
+		AdminUsernames:     getEnvOrDefault("ADMIN_USERNAMES", ""),
+		SMTPHost:           getEnvOrDefault("SMTP_HOST", ""),
+		SMTPPort:           getEnvIntOrDefault("SMTP_PORT", 587),
+		SMTPUser:           getEnvOrDefault("SMTP_USER", ""),
+		SMTPPass:           getEnvOrDefault("SMTP_PASS", ""),
+		EmailFrom:          getEnvOrDefault("EMAIL_FROM", "noreply@conduit.local"),
+		DigestHour:         getEnvIntOrDefault("DIGEST_HOUR", 8),
+		ReplyEmailDomain:   getEnvOrDefault("REPLY_EMAIL_DOMAIN", ""),
+		InboundEmailSecret: getEnvOrDefault("INBOUND_EMAIL_SECRET", ""),
+		CommentDeleteMode:  getEnvOrDefault("COMMENT_DELETE_MODE", "hard"),
+
+		AccessTokenTTLMinutes:   getEnvIntOrDefault("ACCESS_TOKEN_TTL_MINUTES", 24*60),
+		RefreshTokenTTLDays:     getEnvIntOrDefault("REFRESH_TOKEN_TTL_DAYS", 30),
+		MagicLinkTTLMinutes:     getEnvIntOrDefault("MAGIC_LINK_TTL_MINUTES", 15),
+		PasswordResetTTLMinutes: getEnvIntOrDefault("PASSWORD_RESET_TTL_MINUTES", 60),
+		ReplyTokenTTLDays:       getEnvIntOrDefault("REPLY_TOKEN_TTL_DAYS", 30),
+		TokenClockSkewSeconds:   getEnvIntOrDefault("TOKEN_CLOCK_SKEW_SECONDS", 60),
+
+		VAPIDPrivateKey: getEnvOrDefault("VAPID_PRIVATE_KEY", ""),
+		VAPIDSubject:    getEnvOrDefault("VAPID_SUBJECT", ""),
+
+		CommentMinIntervalSeconds:            getEnvIntOrDefault("COMMENT_MIN_INTERVAL_SECONDS", 15),
+		CommentHourlyLimit:                   getEnvIntOrDefault("COMMENT_HOURLY_LIMIT", 20),
+		EstablishedCommentMinIntervalSeconds: getEnvIntOrDefault("ESTABLISHED_COMMENT_MIN_INTERVAL_SECONDS", 5),
+		EstablishedCommentHourlyLimit:        getEnvIntOrDefault("ESTABLISHED_COMMENT_HOURLY_LIMIT", 60),
+		EstablishedAccountDays:               getEnvIntOrDefault("ESTABLISHED_ACCOUNT_DAYS", 30),
+
+		QuotaArticlesPerDay:            getEnvIntOrDefault("QUOTA_ARTICLES_PER_DAY", 5),
+		QuotaCommentsPerDay:            getEnvIntOrDefault("QUOTA_COMMENTS_PER_DAY", 50),
+		EstablishedQuotaArticlesPerDay: getEnvIntOrDefault("ESTABLISHED_QUOTA_ARTICLES_PER_DAY", 20),
+		EstablishedQuotaCommentsPerDay: getEnvIntOrDefault("ESTABLISHED_QUOTA_COMMENTS_PER_DAY", 300),
+
+		QuarantineDays: getEnvIntOrDefault("QUARANTINE_DAYS", 0),
+
+		AnalyticsSalt: getEnvOrDefault("ANALYTICS_SALT", "change-this-analytics-salt"),
+
+		PIIEncryptionKeys: getEnvOrDefault("PII_ENCRYPTION_KEYS", ""),
+		PIIIndexKey:       getEnvOrDefault("PII_INDEX_KEY", ""),
+
+		HealthCheckIntervalSeconds: getEnvIntOrDefault("HEALTH_CHECK_INTERVAL_SECONDS", 30),
+
+		IncidentHeader: getEnvBoolOrDefault("INCIDENT_HEADER", false),
+
+		CorrelationTrustedNetworks: getEnvOrDefault("CORRELATION_TRUSTED_NETWORKS", ""),
+
+		DrainSeconds: getEnvIntOrDefault("DRAIN_SECONDS", 0),
+		DrainToken:   getEnvOrDefault("DRAIN_TOKEN", ""),
+
+		MetricsToken:              getEnvOrDefault("METRICS_TOKEN", ""),
+		KPIRefreshIntervalSeconds: getEnvIntOrDefault("KPI_REFRESH_INTERVAL_SECONDS", 60),
+
+		AlertIntervalSeconds:      getEnvIntOrDefault("ALERT_INTERVAL_SECONDS", 60),
+		AlertCooldownMinutes:      getEnvIntOrDefault("ALERT_COOLDOWN_MINUTES", 30),
+		AlertErrorRatePercent:     getEnvIntOrDefault("ALERT_ERROR_RATE_PERCENT", 5),
+		AlertErrorRateMinRequests: getEnvIntOrDefault("ALERT_ERROR_RATE_MIN_REQUESTS", 50),
+		AlertLoginFailures:        getEnvIntOrDefault("ALERT_LOGIN_FAILURES", 50),
+		AlertQueueBacklog:         getEnvIntOrDefault("ALERT_QUEUE_BACKLOG", 500),
+		AlertWebhookURL:           getEnvOrDefault("ALERT_WEBHOOK_URL", ""),
+		AlertEmails:               getEnvOrDefault("ALERT_EMAILS", ""),
+
+		StartupIntegrityCheck: getEnvBoolOrDefault("STARTUP_INTEGRITY_CHECK", true),
+
+		SyndicationCacheTTLMinutes: getEnvIntOrDefault("SYNDICATION_CACHE_TTL_MINUTES", 60),
+
+		IndexNowKey:               getEnvOrDefault("INDEXNOW_KEY", ""),
+		IndexNowEndpoint:          getEnvOrDefault("INDEXNOW_ENDPOINT", "https://api.indexnow.org/indexnow"),
+		SitemapPingURLs:           getEnvOrDefault("SITEMAP_PING_URLS", ""),
+		SearchPingIntervalSeconds: getEnvIntOrDefault("SEARCH_PING_INTERVAL_SECONDS", 60),
+
+		RobotsDisallow:      getEnvOrDefault("ROBOTS_DISALLOW", "/api/"),
+		RobotsBlockedAgents: getEnvOrDefault("ROBOTS_BLOCKED_AGENTS", ""),
+		RobotsDisallowAll:   getEnvBoolOrDefault("ROBOTS_DISALLOW_ALL", false),
+
+		SanitizeAllowHTML:       getEnvBoolOrDefault("SANITIZE_ALLOW_HTML", true),
+		SanitizeExternalLinkRel: getEnvOrDefault("SANITIZE_EXTERNAL_LINK_REL", "nofollow ugc"),
+		SanitizeImageProxyURL:   getEnvOrDefault("SANITIZE_IMAGE_PROXY_URL", ""),
+		SanitizeEmbedProviders:  getEnvOrDefault("SANITIZE_EMBED_PROVIDERS", "youtube,vimeo,twitter"),
+
+		ImageProxyEnabled:        getEnvBoolOrDefault("IMAGE_PROXY_ENABLED", true),
+		ImageProxyAllowedHosts:   getEnvOrDefault("IMAGE_PROXY_ALLOWED_HOSTS", ""),
+		ImageProxyDeniedHosts:    getEnvOrDefault("IMAGE_PROXY_DENIED_HOSTS", ""),
+		ImageProxyMaxBytes:       getEnvIntOrDefault("IMAGE_PROXY_MAX_BYTES", 5*1024*1024),
+		ImageProxyMaxPixels:      getEnvIntOrDefault("IMAGE_PROXY_MAX_PIXELS", 25000000),
+		ImageProxyMaxWidth:       getEnvIntOrDefault("IMAGE_PROXY_MAX_WIDTH", 1600),
+		ImageProxyCacheMB:        getEnvIntOrDefault("IMAGE_PROXY_CACHE_MB", 64),
+		ImageProxyTimeoutSeconds: getEnvIntOrDefault("IMAGE_PROXY_TIMEOUT_SECONDS", 5),
+
+		LinkPreviewTimeoutSeconds: getEnvIntOrDefault("LINK_PREVIEW_TIMEOUT_SECONDS", 5),
+		LinkPreviewMaxAgeHours:    getEnvIntOrDefault("LINK_PREVIEW_MAX_AGE_HOURS", 24),
+
+		RateLimitAuthPerMinute:  getEnvIntOrDefault("RATE_LIMIT_AUTH_PER_MINUTE", 30),
+		RateLimitAuthBurst:      getEnvIntOrDefault("RATE_LIMIT_AUTH_BURST", 10),
+		RateLimitWritePerMinute: getEnvIntOrDefault("RATE_LIMIT_WRITE_PER_MINUTE", 120),
+		RateLimitWriteBurst:     getEnvIntOrDefault("RATE_LIMIT_WRITE_BURST", 30),
+		RateLimitReadPerMinute:  getEnvIntOrDefault("RATE_LIMIT_READ_PER_MINUTE", 600),
+		RateLimitReadBurst:      getEnvIntOrDefault("RATE_LIMIT_READ_BURST", 100),
+		RateLimitAdminPerMinute: getEnvIntOrDefault("RATE_LIMIT_ADMIN_PER_MINUTE", 120),
+		RateLimitAdminBurst:     getEnvIntOrDefault("RATE_LIMIT_ADMIN_BURST", 30),
+
+		ReplayProtection:    getEnvOrDefault("REPLAY_PROTECTION", "off"),
+		ReplayWindowSeconds: getEnvIntOrDefault("REPLAY_WINDOW_SECONDS", 300),
+
+		DeactivationGraceDays: getEnvIntOrDefault("DEACTIVATION_GRACE_DAYS", 30),
+
+		LoginMaxFailuresPerAccount: getEnvIntOrDefault("LOGIN_MAX_FAILURES_PER_ACCOUNT", 10),
+		LoginMaxFailuresPerNetwork: getEnvIntOrDefault("LOGIN_MAX_FAILURES_PER_NETWORK", 50),
+		LoginFailureWindowMinutes:  getEnvIntOrDefault("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+		LoginLockoutMinutes:        getEnvIntOrDefault("LOGIN_LOCKOUT_MINUTES", 15),
+
+		AnomalyScanIntervalMinutes:     getEnvIntOrDefault("ANOMALY_SCAN_INTERVAL_MINUTES", 15),
+		AnomalyWindowMinutes:           getEnvIntOrDefault("ANOMALY_WINDOW_MINUTES", 60),
+		AnomalyRegistrationsPerNetwork: getEnvIntOrDefault("ANOMALY_REGISTRATIONS_PER_NETWORK", 5),
+		AnomalyFavoritesPerUser:        getEnvIntOrDefault("ANOMALY_FAVORITES_PER_USER", 100),
+		AnomalyCommentsPerUser:         getEnvIntOrDefault("ANOMALY_COMMENTS_PER_USER", 40),
+
+		BetaMode: getEnvBoolOrDefault("BETA_MODE", false),
+
+		ReviewFirstArticles:     getEnvIntOrDefault("REVIEW_FIRST_ARTICLES", 0),
+		ReviewNewAccountMaxDays: getEnvIntOrDefault("REVIEW_NEW_ACCOUNT_MAX_DAYS", 30),
+
+		DemoMode:         getEnvBoolOrDefault("DEMO_MODE", false),
+		DemoResetMinutes: getEnvIntOrDefault("DEMO_RESET_MINUTES", 60),
+
+		FavoriteReconcileMinutes: getEnvIntOrDefault("FAVORITE_RECONCILE_MINUTES", 60),
+
+		ExportIntervalSeconds: getEnvIntOrDefault("EXPORT_INTERVAL_SECONDS", 5),
+		ExportRetentionHours:  getEnvIntOrDefault("EXPORT_RETENTION_HOURS", 24),
+
+		MaxPinnedArticles: getEnvIntOrDefault("MAX_PINNED_ARTICLES", 3),
+		MaxClapsPerUser:   getEnvIntOrDefault("MAX_CLAPS_PER_USER", 50),
+
+		MaxAttachmentsPerArticle: getEnvIntOrDefault("MAX_ATTACHMENTS_PER_ARTICLE", 10),
+		MaxAttachmentBytes:       getEnvIntOrDefault("MAX_ATTACHMENT_BYTES", 64*1024),
+
+		NotificationCoalesceMinutes: getEnvIntOrDefault("NOTIFICATION_COALESCE_MINUTES", 60),
+
+		EmailSoftBounceLimit: getEnvIntOrDefault("EMAIL_SOFT_BOUNCE_LIMIT", 3),
+
+		EmailBrandName:  getEnvOrDefault("EMAIL_BRAND_NAME", "Conduit"),
+		EmailBrandColor: getEnvOrDefault("EMAIL_BRAND_COLOR", "#5cb85c"),
+		EmailLogoURL:    getEnvOrDefault("EMAIL_LOGO_URL", ""),
+		EmailFooterText: getEnvOrDefault("EMAIL_FOOTER_TEXT", ""),
+
+		ComplianceRegion:      region,
+		CookieConsentRequired: getEnvBoolOrDefault("COOKIE_CONSENT_REQUIRED", preset.cookieConsent),
+		DataResidency:         getEnvBoolOrDefault("DATA_RESIDENCY", preset.dataResidency),
+		MinimumAge:            getEnvIntOrDefault("MINIMUM_AGE", preset.minimumAge),
+		RequireBirthdate:      getEnvBoolOrDefault("REQUIRE_BIRTHDATE", false),
+		AdultAge:              getEnvIntOrDefault("ADULT_AGE", 18),
 	}
 }
 
@@ -57,6 +461,12 @@ func (c *Config) IsProduction() bool {
 	return c.Environment == "production"
 }
 
+// indexNowKeyPattern matches the key format IndexNow accepts
+var indexNowKeyPattern = regexp.MustCompile(`^[A-Za-z0-9-]{8,128}$`)
+
+// hexColorPattern matches #rrggbb colors
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
 // Validate checks if all required configuration is present
 func (c *Config) Validate() error {
 	if c.JWTSecret == "" || c.JWTSecret == "your-super-secret-jwt-key-change-this-in-production" {
@@ -65,6 +475,168 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	switch c.JWTAlgorithm {
+	case "", "HS256", "RS256", "EdDSA":
+	default:
+		return fmt.Errorf("JWT_ALGORITHM must be one of: HS256, RS256, EdDSA")
+	}
+	if (c.JWTAlgorithm == "RS256" || c.JWTAlgorithm == "EdDSA") && c.JWTKeyEncryptKeys == "" {
+		return fmt.Errorf("JWT_KEY_ENCRYPT_KEYS must be set when JWT_ALGORITHM is RS256 or EdDSA")
+	}
+
+	if c.IsProduction() && (c.AnalyticsSalt == "" || c.AnalyticsSalt == "change-this-analytics-salt") {
+		return fmt.Errorf("ANALYTICS_SALT must be set in production")
+	}
+
+	if c.PIIEncryptionKeys != "" && c.PIIIndexKey == "" {
+		return fmt.Errorf("PII_INDEX_KEY must be set when PII_ENCRYPTION_KEYS is set")
+	}
+
+	if c.CommentDeleteMode != "" && c.CommentDeleteMode != "hard" && c.CommentDeleteMode != "placeholder" {
+		return fmt.Errorf("COMMENT_DELETE_MODE must be 'hard' or 'placeholder'")
+	}
+
+	// bcrypt accepts costs from 4 to 31; 0 uses its default
+	if c.BcryptRounds != 0 && (c.BcryptRounds < 4 || c.BcryptRounds > 31) {
+		return fmt.Errorf("BCRYPT_ROUNDS must be between 4 and 31")
+	}
+
+	switch c.ReplayProtection {
+	case "", "off", "nonce", "signed":
+	default:
+		return fmt.Errorf("REPLAY_PROTECTION must be 'off', 'nonce' or 'signed'")
+	}
+	if c.ReplayProtection != "" && c.ReplayProtection != "off" && c.ReplayWindowSeconds <= 0 {
+		return fmt.Errorf("REPLAY_WINDOW_SECONDS must be positive when replay protection is enabled")
+	}
+
+	if (c.LoginMaxFailuresPerAccount > 0 || c.LoginMaxFailuresPerNetwork > 0) && c.LoginFailureWindowMinutes <= 0 {
+		return fmt.Errorf("LOGIN_FAILURE_WINDOW_MINUTES must be positive when login failures are limited")
+	}
+	if c.LoginMaxFailuresPerAccount > 0 && c.LoginLockoutMinutes <= 0 {
+		return fmt.Errorf("LOGIN_LOCKOUT_MINUTES must be positive when LOGIN_MAX_FAILURES_PER_ACCOUNT is set")
+	}
+
+	if _, ok := compliancePresets[c.ComplianceRegion]; !ok {
+		return fmt.Errorf("COMPLIANCE_REGION must be empty or one of: eu, uk, us, kr")
+	}
+
+	// Birthdates are only ever stored encrypted
+	if c.RequireBirthdate && c.PIIEncryptionKeys == "" {
+		return fmt.Errorf("PII_ENCRYPTION_KEYS must be set when REQUIRE_BIRTHDATE is enabled")
+	}
+
+	// Digests go out at an hour of each user's day, or never
+	if c.DigestHour < -1 || c.DigestHour > 23 {
+		return fmt.Errorf("DIGEST_HOUR must be between 0 and 23, or -1 to disable digests")
+	}
+
+	// Push services require a contact for the sender
+	if c.VAPIDPrivateKey != "" && !strings.HasPrefix(c.VAPIDSubject, "mailto:") && !strings.HasPrefix(c.VAPIDSubject, "https://") {
+		return fmt.Errorf("VAPID_SUBJECT must be a mailto: or https: URL when VAPID_PRIVATE_KEY is set")
+	}
+
+	// The brand color is inlined into email CSS
+	if c.EmailBrandColor != "" && !hexColorPattern.MatchString(c.EmailBrandColor) {
+		return fmt.Errorf("EMAIL_BRAND_COLOR must be a #rrggbb color")
+	}
+	if c.EmailLogoURL != "" {
+		if u, err := url.Parse(c.EmailLogoURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("EMAIL_LOGO_URL must be an http(s) URL")
+		}
+	}
+
+	// IndexNow keys are 8 to 128 letters, digits and dashes
+	if c.IndexNowKey != "" && !indexNowKeyPattern.MatchString(c.IndexNowKey) {
+		return fmt.Errorf("INDEXNOW_KEY must be 8 to 128 letters, digits or dashes")
+	}
+
+	for _, pingURL := range strings.Split(c.SitemapPingURLs, ",") {
+		if pingURL = strings.TrimSpace(pingURL); pingURL == "" {
+			continue
+		}
+		if u, err := url.Parse(pingURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("SITEMAP_PING_URLS must be a comma-separated list of http(s) URLs")
+		}
+	}
+
+	if c.AlertWebhookURL != "" {
+		if u, err := url.Parse(c.AlertWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("ALERT_WEBHOOK_URL must be an http(s) URL")
+		}
+	}
+
+	for _, cidr := range strings.Split(c.CorrelationTrustedNetworks, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("CORRELATION_TRUSTED_NETWORKS must be a comma-separated list of CIDRs")
+		}
+	}
+
+	for _, path := range strings.Split(c.RobotsDisallow, ",") {
+		if path = strings.TrimSpace(path); path != "" && !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("ROBOTS_DISALLOW paths must start with /")
+		}
+	}
+
+	for _, provider := range strings.Split(c.SanitizeEmbedProviders, ",") {
+		switch strings.ToLower(strings.TrimSpace(provider)) {
+		case "", "youtube", "vimeo", "twitter":
+		default:
+			return fmt.Errorf("SANITIZE_EMBED_PROVIDERS may only list youtube, vimeo and twitter")
+		}
+	}
+
+	for env, limit := range map[string]int{
+		"RATE_LIMIT_AUTH_PER_MINUTE":  c.RateLimitAuthPerMinute,
+		"RATE_LIMIT_WRITE_PER_MINUTE": c.RateLimitWritePerMinute,
+		"RATE_LIMIT_READ_PER_MINUTE":  c.RateLimitReadPerMinute,
+		"RATE_LIMIT_ADMIN_PER_MINUTE": c.RateLimitAdminPerMinute,
+
+		"QUOTA_ARTICLES_PER_DAY":             c.QuotaArticlesPerDay,
+		"QUOTA_COMMENTS_PER_DAY":             c.QuotaCommentsPerDay,
+		"ESTABLISHED_QUOTA_ARTICLES_PER_DAY": c.EstablishedQuotaArticlesPerDay,
+		"ESTABLISHED_QUOTA_COMMENTS_PER_DAY": c.EstablishedQuotaCommentsPerDay,
+
+		"QUARANTINE_DAYS": c.QuarantineDays,
+
+		"JWT_KEY_ROTATION_DAYS": c.JWTKeyRotationDays,
+
+		"ACCESS_TOKEN_TTL_MINUTES":   c.AccessTokenTTLMinutes,
+		"REFRESH_TOKEN_TTL_DAYS":     c.RefreshTokenTTLDays,
+		"MAGIC_LINK_TTL_MINUTES":     c.MagicLinkTTLMinutes,
+		"PASSWORD_RESET_TTL_MINUTES": c.PasswordResetTTLMinutes,
+		"REPLY_TOKEN_TTL_DAYS":       c.ReplyTokenTTLDays,
+		"TOKEN_CLOCK_SKEW_SECONDS":   c.TokenClockSkewSeconds,
+
+		"DEMO_RESET_MINUTES": c.DemoResetMinutes,
+
+		"DRAIN_SECONDS": c.DrainSeconds,
+
+		"FAVORITE_RECONCILE_MINUTES": c.FavoriteReconcileMinutes,
+
+		"EXPORT_INTERVAL_SECONDS": c.ExportIntervalSeconds,
+		"EXPORT_RETENTION_HOURS":  c.ExportRetentionHours,
+
+		"NOTIFICATION_COALESCE_MINUTES": c.NotificationCoalesceMinutes,
+
+		"LOGIN_MAX_FAILURES_PER_ACCOUNT": c.LoginMaxFailuresPerAccount,
+		"LOGIN_MAX_FAILURES_PER_NETWORK": c.LoginMaxFailuresPerNetwork,
+		"LOGIN_FAILURE_WINDOW_MINUTES":   c.LoginFailureWindowMinutes,
+		"LOGIN_LOCKOUT_MINUTES":          c.LoginLockoutMinutes,
+
+		"EMAIL_SOFT_BOUNCE_LIMIT": c.EmailSoftBounceLimit,
+
+		"MINIMUM_AGE": c.MinimumAge,
+		"ADULT_AGE":   c.AdultAge,
+	} {
+		if limit < 0 {
+			return fmt.Errorf("%s must not be negative", env)
+		}
+	}
+
 	if c.Port == "" {
 		return fmt.Errorf("PORT must be set")
 	}
@@ -97,4 +669,4 @@ func getEnvBoolOrDefault(key string, defaultValue bool) bool {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
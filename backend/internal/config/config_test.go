@@ -79,6 +79,24 @@ func TestLoadConfig(t *testing.T) {
 	})
 }
 
+func TestLoadConfig_CompliancePresets(t *testing.T) {
+	t.Setenv("COMPLIANCE_REGION", "eu")
+	t.Setenv("MINIMUM_AGE", "18")
+
+	cfg := LoadConfig()
+	if !cfg.CookieConsentRequired || !cfg.DataResidency {
+		t.Errorf("Expected the eu preset to require consent and data residency, got %+v", cfg)
+	}
+	if cfg.MinimumAge != 18 {
+		t.Errorf("Expected MINIMUM_AGE to override the preset, got %d", cfg.MinimumAge)
+	}
+
+	cfg.ComplianceRegion = "mars"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an unknown compliance region to be rejected")
+	}
+}
+
 func TestServerAddress(t *testing.T) {
 	cfg := &Config{
 		Host: "localhost",
@@ -147,4 +165,118 @@ func TestValidate(t *testing.T) {
 			t.Error("Expected validation error for missing port")
 		}
 	})
-}
\ No newline at end of file
+	t.Run("InvalidCommentDeleteMode", func(t *testing.T) {
+		cfg := &Config{
+			Environment:       "development",
+			Port:              "8080",
+			JWTSecret:         "test-secret",
+			CommentDeleteMode: "archive",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for unknown comment delete mode")
+		}
+	})
+
+	t.Run("DefaultAnalyticsSaltInProduction", func(t *testing.T) {
+		cfg := &Config{
+			Environment:   "production",
+			Port:          "8080",
+			JWTSecret:     "a-real-secret",
+			AnalyticsSalt: "change-this-analytics-salt",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for production config with default analytics salt")
+		}
+
+		cfg.AnalyticsSalt = "a-real-salt"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected valid production config, got error: %v", err)
+		}
+	})
+
+	t.Run("VAPIDKeyWithoutSubject", func(t *testing.T) {
+		cfg := &Config{
+			Environment:     "development",
+			Port:            "8080",
+			JWTSecret:       "test-secret",
+			VAPIDPrivateKey: "key",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for VAPID key without a subject")
+		}
+
+		cfg.VAPIDSubject = "mailto:admin@example.com"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected valid config, got error: %v", err)
+		}
+	})
+
+	t.Run("SearchPingSettings", func(t *testing.T) {
+		cfg := &Config{
+			Environment: "development",
+			Port:        "8080",
+			JWTSecret:   "test-secret",
+			IndexNowKey: "short",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for a malformed IndexNow key")
+		}
+
+		cfg.IndexNowKey = "0123456789abcdef"
+		cfg.SitemapPingURLs = "https://www.bing.com/ping, ftp://example.com"
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for a non-HTTP sitemap ping URL")
+		}
+
+		cfg.SitemapPingURLs = "https://www.bing.com/ping, "
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected valid config, got error: %v", err)
+		}
+	})
+
+	t.Run("EmailBranding", func(t *testing.T) {
+		cfg := &Config{
+			Environment:     "development",
+			Port:            "8080",
+			JWTSecret:       "test-secret",
+			EmailBrandColor: "red;background:url(x)",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for a brand color that is not #rrggbb")
+		}
+
+		cfg.EmailBrandColor = "#5cb85c"
+		cfg.EmailLogoURL = "javascript:alert(1)"
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for a non-HTTP logo URL")
+		}
+
+		cfg.EmailLogoURL = "https://cdn.example.com/logo.png"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected valid config, got error: %v", err)
+		}
+	})
+
+	t.Run("RobotsDisallowRelativePath", func(t *testing.T) {
+		cfg := &Config{
+			Environment:    "development",
+			Port:           "8080",
+			JWTSecret:      "test-secret",
+			RobotsDisallow: "/api/, admin",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Expected validation error for a robots path without a leading slash")
+		}
+
+		cfg.RobotsDisallow = "/api/, /admin"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected valid config, got error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+)
+
+// Sources a configuration value can come from, in order of precedence
+const (
+	SourceEnv     = "env"
+	SourceDefault = "default"
+)
+
+// RedactedValue replaces secret values when the configuration is described
+const RedactedValue = "[redacted]"
+
+// Setting describes one effective configuration value and where it came from
+type Setting struct {
+	Name   string      `json:"name"`
+	Env    string      `json:"env"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+	Note   string      `json:"note,omitempty"`
+}
+
+// Describe returns the effective configuration with secrets redacted.
+// Environment variables take precedence over built-in defaults; a set but
+// unparseable variable falls back to the default and is flagged with a note.
+func (c *Config) Describe() []Setting {
+	value := reflect.ValueOf(c).Elem()
+	fields := value.Type()
+
+	settings := make([]Setting, 0, fields.NumField())
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		env := field.Tag.Get("env")
+		if env == "" {
+			continue
+		}
+
+		setting := Setting{
+			Name:   field.Name,
+			Env:    env,
+			Value:  value.Field(i).Interface(),
+			Source: SourceDefault,
+		}
+
+		if raw := os.Getenv(env); raw != "" {
+			setting.Source = SourceEnv
+			if !parses(field.Type.Kind(), raw) {
+				setting.Source = SourceDefault
+				setting.Note = fmt.Sprintf("ignored invalid %s value %q", field.Type.Kind(), raw)
+			}
+		}
+
+		if field.Tag.Get("secret") == "true" && value.Field(i).String() != "" {
+			setting.Value = RedactedValue
+		}
+
+		settings = append(settings, setting)
+	}
+
+	return settings
+}
+
+// Print writes the effective configuration as ENV=value lines annotated with their source
+func (c *Config) Print(w io.Writer) {
+	for _, setting := range c.Describe() {
+		line := fmt.Sprintf("%s=%v\t# %s", setting.Env, setting.Value, setting.Source)
+		if setting.Note != "" {
+			line += ", " + setting.Note
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// parses reports whether raw is accepted by the env helper for the given kind
+func parses(kind reflect.Kind, raw string) bool {
+	switch kind {
+	case reflect.Int:
+		_, err := strconv.Atoi(raw)
+		return err == nil
+	case reflect.Bool:
+		_, err := strconv.ParseBool(raw)
+		return err == nil
+	default:
+		return true
+	}
+}
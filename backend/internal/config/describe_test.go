@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Setenv("PORT", "9090")
+	t.Setenv("JWT_SECRET", "super-secret")
+	t.Setenv("SMTP_PORT", "not-a-number")
+	t.Setenv("SMTP_PASS", "")
+
+	cfg := LoadConfig()
+
+	settings := make(map[string]Setting)
+	for _, setting := range cfg.Describe() {
+		settings[setting.Env] = setting
+	}
+
+	if len(settings) == 0 || settings["ENV"].Name != "Environment" {
+		t.Fatalf("Expected every config field to be described, got %v", settings)
+	}
+
+	if port := settings["PORT"]; port.Value != "9090" || port.Source != SourceEnv {
+		t.Errorf("Expected PORT=9090 from env, got %+v", port)
+	}
+
+	if host := settings["HOST"]; host.Source != SourceDefault {
+		t.Errorf("Expected HOST from default, got %+v", host)
+	}
+
+	if secret := settings["JWT_SECRET"]; secret.Value != RedactedValue || secret.Source != SourceEnv {
+		t.Errorf("Expected redacted JWT_SECRET from env, got %+v", secret)
+	}
+
+	if pass := settings["SMTP_PASS"]; pass.Value != "" {
+		t.Errorf("Expected unset secret to stay empty, got %+v", pass)
+	}
+
+	if smtpPort := settings["SMTP_PORT"]; smtpPort.Value != 587 || smtpPort.Source != SourceDefault || smtpPort.Note == "" {
+		t.Errorf("Expected invalid SMTP_PORT to fall back to default with a note, got %+v", smtpPort)
+	}
+
+	var out bytes.Buffer
+	cfg.Print(&out)
+	if strings.Contains(out.String(), "super-secret") {
+		t.Error("Expected printed config to redact secrets")
+	}
+	if !strings.Contains(out.String(), "PORT=9090\t# env\n") {
+		t.Errorf("Expected PORT line in printed config, got:\n%s", out.String())
+	}
+}
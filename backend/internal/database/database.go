@@ -1,7 +1,9 @@
 package database
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"io/fs"
 	"log"
@@ -88,6 +90,11 @@ func (db *DB) Migrate(migrationsDir string) error {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
+	// Make sure applied migrations still match the files on disk
+	if err := db.verifyMigrationChecksums(migrationsDir, migrationFiles, appliedMigrations); err != nil {
+		return err
+	}
+
 	// Apply pending migrations
 	for _, file := range migrationFiles {
 		if _, applied := appliedMigrations[file]; !applied {
@@ -107,11 +114,26 @@ func (db *DB) createMigrationsTable() error {
 	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			filename TEXT PRIMARY KEY,
-			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			checksum TEXT
 		)
 	`
-	_, err := db.DB.Exec(query)
-	return err
+	if _, err := db.DB.Exec(query); err != nil {
+		return err
+	}
+
+	// Databases created before checksums were tracked need the column added
+	columns, err := db.tableColumns("schema_migrations")
+	if err != nil {
+		return err
+	}
+	if !columns["checksum"] {
+		if _, err := db.DB.Exec("ALTER TABLE schema_migrations ADD COLUMN checksum TEXT"); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // getMigrationFiles returns sorted list of migration files
@@ -139,26 +161,67 @@ func getMigrationFiles(migrationsDir string) ([]string, error) {
 	return files, nil
 }
 
-// getAppliedMigrations returns a map of applied migration filenames
-func (db *DB) getAppliedMigrations() (map[string]bool, error) {
-	rows, err := db.DB.Query("SELECT filename FROM schema_migrations")
+// getAppliedMigrations returns applied migration filenames mapped to their
+// recorded checksums (empty for migrations applied before checksums were tracked)
+func (db *DB) getAppliedMigrations() (map[string]string, error) {
+	rows, err := db.DB.Query("SELECT filename, COALESCE(checksum, '') FROM schema_migrations")
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	applied := make(map[string]bool)
+	applied := make(map[string]string)
 	for rows.Next() {
-		var filename string
-		if err := rows.Scan(&filename); err != nil {
+		var filename, checksum string
+		if err := rows.Scan(&filename, &checksum); err != nil {
 			return nil, err
 		}
-		applied[filename] = true
+		applied[filename] = checksum
 	}
 
 	return applied, rows.Err()
 }
 
+// verifyMigrationChecksums fails if an applied migration was edited or removed.
+// Migrations applied before checksums were tracked get their checksum recorded.
+func (db *DB) verifyMigrationChecksums(migrationsDir string, files []string, applied map[string]string) error {
+	onDisk := make(map[string]bool, len(files))
+	for _, file := range files {
+		onDisk[file] = true
+	}
+
+	for file, recorded := range applied {
+		if !onDisk[file] {
+			return fmt.Errorf("migration %s was applied but is missing from %s; restore the file or point the server at the correct migrations directory", file, migrationsDir)
+		}
+
+		content, err := os.ReadFile(filepath.Join(migrationsDir, file))
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+		checksum := migrationChecksum(content)
+
+		if recorded == "" {
+			if _, err := db.DB.Exec("UPDATE schema_migrations SET checksum = ? WHERE filename = ?", checksum, file); err != nil {
+				return fmt.Errorf("failed to record checksum for %s: %w", file, err)
+			}
+			continue
+		}
+
+		if recorded != checksum {
+			return fmt.Errorf("migration %s has changed since it was applied (checksum mismatch); revert the edit and put schema changes in a new migration", file)
+		}
+	}
+
+	return nil
+}
+
+// migrationChecksum returns the hex SHA-256 of a migration file's contents
+func migrationChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
 // applyMigration applies a single migration file
 func (db *DB) applyMigration(migrationsDir, filename string) error {
 	// Read migration file
@@ -188,8 +251,8 @@ func (db *DB) applyMigration(migrationsDir, filename string) error {
 
 	// Record migration as applied
 	if _, err := tx.Exec(
-		"INSERT INTO schema_migrations (filename) VALUES (?)",
-		filename,
+		"INSERT INTO schema_migrations (filename, checksum) VALUES (?, ?)",
+		filename, migrationChecksum(content),
 	); err != nil {
 		return err
 	}
@@ -236,4 +299,47 @@ func (db *DB) Transaction(fn func(*sql.Tx) error) error {
 	}
 
 	return tx.Commit()
-}
\ No newline at end of file
+}
+
+// Reset deletes every row from every table except the migration history,
+// leaving an empty database on the current schema
+func (db *DB) Reset() error {
+	rows, err := db.DB.Query(`
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name != 'schema_migrations'
+			AND (name = 'sqlite_sequence' OR name NOT LIKE 'sqlite_%')
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	// Foreign keys can't be toggled inside a transaction, and with them off
+	// the tables can be emptied in any order
+	if _, err := db.DB.Exec("PRAGMA foreign_keys = OFF"); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer db.DB.Exec("PRAGMA foreign_keys = ON")
+
+	return db.Transaction(func(tx *sql.Tx) error {
+		for _, table := range tables {
+			if _, err := tx.Exec(`DELETE FROM "` + table + `"`); err != nil {
+				return fmt.Errorf("failed to empty %s: %w", table, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package database
+
+import "testing"
+
+func TestDB_Reset(t *testing.T) {
+	db, migrationsDir := setupSelfCheckDB(t)
+	writeMigration(t, migrationsDir, "002_create_replies.sql",
+		"CREATE TABLE replies (id INTEGER PRIMARY KEY AUTOINCREMENT, note_id INTEGER NOT NULL REFERENCES notes(id), body TEXT);")
+
+	if err := db.Migrate(migrationsDir); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO notes (id, body) VALUES (1, 'note')"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO replies (note_id, body) VALUES (1, 'reply')"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	for _, table := range []string{"notes", "replies"} {
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("Expected %s to be empty, got %d rows", table, count)
+		}
+	}
+
+	// Migration history survives, so migrating again is a no-op
+	var applied int
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&applied); err != nil {
+		t.Fatal(err)
+	}
+	if applied != 2 {
+		t.Errorf("Expected 2 applied migrations, got %d", applied)
+	}
+
+	// IDs start over and foreign keys are enforced again
+	if _, err := db.Exec("INSERT INTO notes (id, body) VALUES (1, 'note')"); err != nil {
+		t.Fatal(err)
+	}
+	var id int64
+	if err := db.QueryRow("INSERT INTO replies (note_id, body) VALUES (1, 'reply') RETURNING id").Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 {
+		t.Errorf("Expected reply IDs to restart at 1, got %d", id)
+	}
+	if _, err := db.Exec("INSERT INTO replies (note_id, body) VALUES (99, 'orphan')"); err == nil {
+		t.Error("Expected foreign keys to be enforced after reset")
+	}
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RequiredSchema lists the tables and columns the application cannot run without
+var RequiredSchema = map[string][]string{
+	"users":                    {"id", "username", "email", "email_index", "password_hash", "bio", "image_url", "role", "deactivated_at", "locked_until", "moderation_status", "registration_network", "birthdate", "created_at", "updated_at"},
+	"articles":                 {"id", "slug", "title", "description", "body", "author_id", "favorites_count", "claps_count", "comments_count", "last_comment_at", "language", "translation_of", "status", "review_note", "featured_at", "featured_note", "featured_position", "pinned_at", "pin_position", "comments_locked_at", "noindex", "created_at", "updated_at"},
+	"comments":                 {"id", "body", "author_id", "article_id", "parent_id", "deleted_at", "created_at", "updated_at"},
+	"tags":                     {"id", "name", "description", "updated_at"},
+	"article_tags":             {"article_id", "tag_id"},
+	"tag_aliases":              {"alias", "tag_id"},
+	"notifications":            {"id", "user_id", "event_type", "message", "link", "delivery", "digested_at", "created_at", "group_key", "count", "updated_at"},
+	"notification_preferences": {"user_id", "event_type", "delivery"},
+	"follows":                  {"follower_id", "following_id"},
+	"feed_reads":               {"user_id", "last_seen_at"},
+	"analytics_events":         {"id", "event_type", "article_id", "visitor_hash", "day"},
+	"article_daily_stats":      {"article_id", "day", "views", "interactions"},
+	"link_previews":            {"url", "title", "description", "image_url", "site_name", "fetched_at"},
+	"article_link_previews":    {"article_id", "url"},
+	"favorites":                {"user_id", "article_id", "created_at"},
+	"user_settings":            {"user_id", "profile_visibility", "show_favorites", "record_reading_history", "searchable", "timezone", "locale"},
+	"anomalies":                {"id", "kind", "user_id", "network", "event_count", "window_start", "detected_at", "resolved_at", "action"},
+	"anomaly_actors":           {"anomaly_id", "user_id"},
+	"invites":                  {"code", "created_by", "used_by", "created_at", "claimed_at"},
+	"series":                   {"id", "slug", "title", "description", "author_id", "created_at", "updated_at"},
+	"series_articles":          {"series_id", "article_id", "position"},
+	"reading_lists":            {"id", "slug", "name", "description", "owner_id", "created_at", "updated_at"},
+	"reading_list_items":       {"list_id", "article_id", "position", "added_at"},
+	"reading_list_follows":     {"list_id", "user_id", "created_at"},
+	"article_claps":            {"article_id", "user_id", "count", "last_key", "created_at", "updated_at"},
+	"push_subscriptions":       {"id", "user_id", "endpoint", "p256dh", "auth", "expires_at", "created_at"},
+	"search_pings":             {"id", "target", "url", "status", "attempts", "last_error", "next_attempt_at", "delivered_at", "created_at", "updated_at"},
+	"deletion_certificates":    {"id", "mode", "subject_hash", "articles", "comments", "issued_at"},
+	"legal_holds":              {"id", "subject_type", "subject_id", "reason", "placed_by", "placed_at", "released_by", "released_at", "release_reason", "blocked_attempts", "last_blocked_at"},
+	"user_quota_overrides":     {"user_id", "articles_per_day", "comments_per_day", "note", "set_by", "updated_at"},
+	"quarantine_approvals":     {"user_id", "approved_by", "approved_at"},
+	"article_attachments":      {"id", "article_id", "name", "content", "size", "created_at"},
+	"revoked_tokens":           {"jti", "user_id", "expires_at", "revoked_at"},
+	"article_exports":          {"id", "article_id", "user_id", "format", "status", "error", "content_type", "content", "created_at", "completed_at"},
+	"book_exports":             {"id", "user_id", "series_id", "article_ids", "title", "format", "status", "error", "content_type", "content", "created_at", "completed_at"},
+	"signing_keys":             {"kid", "algorithm", "private_key", "created_at", "retired_at"},
+	"api_keys":                 {"id", "user_id", "name", "prefix", "key_hash", "scopes", "created_at", "last_used_at", "expires_at", "revoked_at"},
+	"notification_quiet_hours": {"user_id", "enabled", "start_time", "end_time", "timezone", "updated_at"},
+	"email_statuses":           {"user_id", "status", "soft_bounces", "last_event", "last_event_at", "suppressed_at"},
+	"login_failures":           {"id", "user_id", "network", "failed_at"},
+	"article_share_clicks":     {"article_id", "medium", "day", "clicks"},
+	"incidents":                {"id", "kind", "severity", "title", "message", "components", "created_by", "started_at", "updated_at", "resolved_at"},
+	"sessions":                 {"id", "user_id", "user_agent", "ip", "created_at", "last_used_at", "expires_at", "revoked_at"},
+}
+
+// SelfCheckOptions configures the startup self-check
+type SelfCheckOptions struct {
+	// IntegrityCheck runs PRAGMA integrity_check, which reads the whole database file
+	IntegrityCheck bool
+}
+
+// SelfCheck verifies the schema and, optionally, the database file integrity.
+// Run it after migrations so problems surface at boot instead of on first use.
+func (db *DB) SelfCheck(opts SelfCheckOptions) error {
+	if err := db.VerifySchema(RequiredSchema); err != nil {
+		return err
+	}
+
+	if opts.IntegrityCheck {
+		if err := db.CheckIntegrity(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifySchema checks that every required table and column exists
+func (db *DB) VerifySchema(required map[string][]string) error {
+	tables := make([]string, 0, len(required))
+	for table := range required {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var problems []string
+	for _, table := range tables {
+		columns, err := db.tableColumns(table)
+		if err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", table, err)
+		}
+		if len(columns) == 0 {
+			problems = append(problems, fmt.Sprintf("table %s is missing", table))
+			continue
+		}
+		for _, column := range required[table] {
+			if !columns[column] {
+				problems = append(problems, fmt.Sprintf("column %s.%s is missing", table, column))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema check failed: %s; make sure all migrations in ./migrations have been applied to %s", strings.Join(problems, ", "), db.path)
+	}
+
+	return nil
+}
+
+// CheckIntegrity runs SQLite's integrity check
+func (db *DB) CheckIntegrity() error {
+	rows, err := db.DB.Query("PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return fmt.Errorf("failed to read integrity check result: %w", err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read integrity check result: %w", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database integrity check failed for %s: %s; restore from a backup or set STARTUP_INTEGRITY_CHECK=false to start anyway", db.path, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// tableColumns returns the column names of a table; empty if the table does not exist
+func (db *DB) tableColumns(table string) (map[string]bool, error) {
+	rows, err := db.DB.Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid          int
+			name, typ    string
+			notNull, pk  int
+			defaultValue interface{}
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultValue, &pk); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+
+	return columns, rows.Err()
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupSelfCheckDB(t *testing.T) (*DB, string) {
+	tempDir := t.TempDir()
+
+	db, err := NewDB(filepath.Join(tempDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrationsDir := filepath.Join(tempDir, "migrations")
+	if err := os.Mkdir(migrationsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeMigration(t, migrationsDir, "001_create_notes.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT);")
+
+	return db, migrationsDir
+}
+
+func writeMigration(t *testing.T, dir, name, up string) {
+	content := "-- +migrate Up\n" + up + "\n\n-- +migrate Down\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrate_ChecksumMismatch(t *testing.T) {
+	db, migrationsDir := setupSelfCheckDB(t)
+
+	if err := db.Migrate(migrationsDir); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Re-running unchanged migrations is fine
+	if err := db.Migrate(migrationsDir); err != nil {
+		t.Fatalf("Expected unchanged migrations to pass, got %v", err)
+	}
+
+	writeMigration(t, migrationsDir, "001_create_notes.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT, title TEXT);")
+	err := db.Migrate(migrationsDir)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch error, got %v", err)
+	}
+}
+
+func TestMigrate_MissingAppliedMigration(t *testing.T) {
+	db, migrationsDir := setupSelfCheckDB(t)
+
+	if err := db.Migrate(migrationsDir); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	os.Remove(filepath.Join(migrationsDir, "001_create_notes.sql"))
+	err := db.Migrate(migrationsDir)
+	if err == nil || !strings.Contains(err.Error(), "is missing") {
+		t.Errorf("Expected missing migration error, got %v", err)
+	}
+}
+
+func TestSelfCheck(t *testing.T) {
+	db, migrationsDir := setupSelfCheckDB(t)
+
+	if err := db.Migrate(migrationsDir); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	if err := db.VerifySchema(map[string][]string{"notes": {"id", "body"}}); err != nil {
+		t.Errorf("Expected schema to verify, got %v", err)
+	}
+
+	err := db.VerifySchema(map[string][]string{"notes": {"id", "title"}, "tags": {"id"}})
+	if err == nil {
+		t.Fatal("Expected schema check to fail")
+	}
+	for _, want := range []string{"column notes.title is missing", "table tags is missing"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got %v", want, err)
+		}
+	}
+
+	if err := db.CheckIntegrity(); err != nil {
+		t.Errorf("Expected integrity check to pass, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// Account deletion modes
+const (
+	// DeletionModeDelete removes the account along with everything it wrote
+	DeletionModeDelete = "delete"
+	// DeletionModeAnonymize removes the account but keeps its articles,
+	// comments and series, reassigned to the ghost user
+	DeletionModeAnonymize = "anonymize"
+)
+
+// GhostUsername owns the content of anonymized accounts. The hyphen makes it
+// impossible to register or rename an account to it.
+const GhostUsername = "deleted-user"
+
+// AccountDeletion represents a request to permanently delete the current
+// account; like deactivation it requires the password again
+type AccountDeletion struct {
+	Password string `json:"password"`
+	Mode     string `json:"mode"`
+}
+
+// Validate validates an account deletion request
+func (ad *AccountDeletion) Validate() *ValidationErrors {
+	if ad.Mode != DeletionModeDelete && ad.Mode != DeletionModeAnonymize {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "mode",
+			Message: "mode must be one of: delete, anonymize",
+		}}}
+	}
+	return nil
+}
+
+// DeletionCertificate records that an account was deleted without keeping
+// any of its personal data. SubjectHash lets the former owner, who knows
+// their user ID, username and signup time, show the record is about them.
+type DeletionCertificate struct {
+	ID          string    `json:"id"`
+	Mode        string    `json:"mode"`
+	SubjectHash string    `json:"subjectHash"`
+	Articles    int       `json:"articles"`
+	Comments    int       `json:"comments"`
+	IssuedAt    time.Time `json:"issuedAt"`
+}
+
+// DeletionCertificateResponse wraps a deletion certificate
+type DeletionCertificateResponse struct {
+	Certificate DeletionCertificate `json:"certificate"`
+}
@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// Activity types in a user's activity log
+const (
+	ActivityArticlePublished = "article_published"
+	ActivityCommentCreated   = "comment_created"
+	ActivityUserFollowed     = "user_followed"
+	ActivityArticleFavorited = "article_favorited"
+)
+
+// ActivityTypes lists every activity type, for filtering the log
+var ActivityTypes = []string{
+	ActivityArticlePublished,
+	ActivityCommentCreated,
+	ActivityUserFollowed,
+	ActivityArticleFavorited,
+}
+
+// Activity is one entry in a user's log of their own actions
+type Activity struct {
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Article is set for article, comment and favorite activity
+	Article *ActivityArticle `json:"article,omitempty"`
+	// CommentID is set for comment activity
+	CommentID int64 `json:"commentId,omitempty"`
+	// Username is the followed user for follow activity
+	Username string `json:"username,omitempty"`
+}
+
+// ActivityArticle names the article an activity concerns
+type ActivityArticle struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+// ActivitiesResponse represents a page of the activity log
+type ActivitiesResponse struct {
+	Activities      []Activity `json:"activities"`
+	ActivitiesCount int        `json:"activitiesCount"`
+}
+
+// IsActivityType reports whether t names an activity type
+func IsActivityType(t string) bool {
+	for _, activityType := range ActivityTypes {
+		if activityType == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package entities
+
+import "time"
+
+// UserListQuery represents the admin user listing filters
+type UserListQuery struct {
+	// Search matches usernames containing it literally. A search with an "@"
+	// is taken as an email and matches only that whole address, ignoring case.
+	Search           string
+	Role             string
+	ModerationStatus string
+	Limit            int
+	Offset           int
+}
+
+// AdminUser is a user account as admins see it
+type AdminUser struct {
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	Image            string     `json:"image"`
+	Role             string     `json:"role"`
+	ModerationStatus string     `json:"moderationStatus"`
+	DeactivatedAt    *time.Time `json:"deactivatedAt"`
+	LockedUntil      *time.Time `json:"lockedUntil"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// AdminView returns the user as admins see them
+func (u *User) AdminView() AdminUser {
+	return AdminUser{
+		Username:         u.Username,
+		Email:            u.Email,
+		Image:            u.ImageURL,
+		Role:             u.Role,
+		ModerationStatus: u.ModerationStatus,
+		DeactivatedAt:    u.DeactivatedAt,
+		LockedUntil:      u.LockedUntil,
+		CreatedAt:        u.CreatedAt,
+	}
+}
+
+// AdminUserResponse represents single admin user API response
+type AdminUserResponse struct {
+	User AdminUser `json:"user"`
+}
+
+// AdminUsersResponse represents admin user list API response
+type AdminUsersResponse struct {
+	Users      []AdminUser `json:"users"`
+	UsersCount int         `json:"usersCount"`
+}
+
+// ModerationUpdate represents an admin setting a user's moderation status
+type ModerationUpdate struct {
+	Status string `json:"status"`
+}
+
+// IsValidModerationStatus reports whether status is one of the moderation statuses
+func IsValidModerationStatus(status string) bool {
+	switch status {
+	case ModerationNone, ModerationRateLimited, ModerationShadowBanned, ModerationBanned:
+		return true
+	}
+	return false
+}
+
+// Validate validates a moderation update
+func (mu *ModerationUpdate) Validate() *ValidationErrors {
+	if !IsValidModerationStatus(mu.Status) {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "status",
+			Message: "status must be one of: none, rate_limited, shadow_banned, banned",
+		}}}
+	}
+	return nil
+}
+
+// PlatformStatsResponse represents platform stats API response
+type PlatformStatsResponse struct {
+	Stats PlatformStats `json:"stats"`
+}
+
+// PlatformStats is the community's size and activity with the moderation
+// workload, for operators
+type PlatformStats struct {
+	CommunityStats
+	// UsersByModeration counts accounts by moderation status other than none
+	UsersByModeration map[string]int `json:"usersByModeration"`
+	PendingArticles   int            `json:"pendingArticles"`
+	OpenAnomalies     int            `json:"openAnomalies"`
+}
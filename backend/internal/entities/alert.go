@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// Alert rules evaluated against in-process metrics
+const (
+	// AlertRuleErrorRate fires when the share of 5xx responses spikes
+	AlertRuleErrorRate = "error_rate"
+	// AlertRuleLoginFailures fires on a burst of failed logins
+	AlertRuleLoginFailures = "login_failures"
+	// AlertRuleQueueBacklog fires when background work piles up
+	AlertRuleQueueBacklog = "queue_backlog"
+)
+
+// Alert is a fired alert rule, as delivered to alert channels
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Message   string    `json:"message"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	FiredAt   time.Time `json:"firedAt"`
+}
@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Analytics event types
+const (
+	AnalyticsPageView    = "page_view"
+	AnalyticsInteraction = "interaction"
+)
+
+// MaxAnalyticsBatchSize limits how many events a single request may carry
+const MaxAnalyticsBatchSize = 50
+
+// AnalyticsEvent represents a client-reported page view or interaction
+type AnalyticsEvent struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Article  string `json:"article,omitempty"`
+	Referrer string `json:"referrer,omitempty"`
+}
+
+// AnalyticsBatch represents a batched analytics ingestion request
+type AnalyticsBatch struct {
+	Events []AnalyticsEvent `json:"events"`
+}
+
+// AnalyticsEventRecord is an event prepared for storage; it never contains the client IP
+type AnalyticsEventRecord struct {
+	AnalyticsEvent
+	ReferrerHost string
+	VisitorHash  string
+	Day          string
+}
+
+// ArticleDailyStats represents one day of aggregated article analytics
+type ArticleDailyStats struct {
+	Date         string `json:"date"`
+	Views        int    `json:"views"`
+	Interactions int    `json:"interactions"`
+}
+
+// ArticleStats represents aggregated analytics for an article
+type ArticleStats struct {
+	Slug              string              `json:"slug"`
+	TotalViews        int                 `json:"totalViews"`
+	TotalInteractions int                 `json:"totalInteractions"`
+	TotalShareClicks  int                 `json:"totalShareClicks"`
+	Daily             []ArticleDailyStats `json:"daily"`
+	// Shares breaks the share link clicks of the period down by medium
+	Shares []ArticleShareStats `json:"shares"`
+}
+
+// ArticleStatsResponse represents article stats API response
+type ArticleStatsResponse struct {
+	Stats ArticleStats `json:"stats"`
+}
+
+// ArticleStatsSummary represents an article's analytics totals over a period
+type ArticleStatsSummary struct {
+	Slug         string `json:"slug"`
+	Title        string `json:"title"`
+	Views        int    `json:"views"`
+	Interactions int    `json:"interactions"`
+}
+
+// ArticleStatsSummaryResponse represents site-wide article stats API response
+type ArticleStatsSummaryResponse struct {
+	Articles []ArticleStatsSummary `json:"articles"`
+}
+
+// Validate validates an analytics batch
+func (b *AnalyticsBatch) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if len(b.Events) == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "events",
+			Message: "at least one event is required",
+		})
+	} else if len(b.Events) > MaxAnalyticsBatchSize {
+		errors = append(errors, ValidationError{
+			Field:   "events",
+			Message: fmt.Sprintf("a batch can contain at most %d events", MaxAnalyticsBatchSize),
+		})
+	}
+
+	for i, event := range b.Events {
+		field := fmt.Sprintf("events[%d]", i)
+
+		switch event.Type {
+		case AnalyticsPageView:
+		case AnalyticsInteraction:
+			if strings.TrimSpace(event.Name) == "" {
+				errors = append(errors, ValidationError{
+					Field:   field + ".name",
+					Message: "name is required for interactions",
+				})
+			}
+		default:
+			errors = append(errors, ValidationError{
+				Field:   field + ".type",
+				Message: "type must be page_view or interaction",
+			})
+		}
+
+		if len(event.Name) > 100 {
+			errors = append(errors, ValidationError{
+				Field:   field + ".name",
+				Message: "name must be less than 100 characters long",
+			})
+		}
+		if len(event.Path) > 500 || len(event.Referrer) > 500 {
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Message: "path and referrer must be less than 500 characters long",
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
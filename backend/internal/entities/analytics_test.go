@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestAnalyticsBatchValidate(t *testing.T) {
+	tooMany := make([]AnalyticsEvent, MaxAnalyticsBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = AnalyticsEvent{Type: AnalyticsPageView}
+	}
+
+	tests := []struct {
+		name   string
+		events []AnalyticsEvent
+		field  string
+	}{
+		{"Valid page view", []AnalyticsEvent{{Type: AnalyticsPageView, Path: "/", Article: "hello"}}, ""},
+		{"Valid interaction", []AnalyticsEvent{{Type: AnalyticsInteraction, Name: "share"}}, ""},
+		{"Empty batch", nil, "events"},
+		{"Too many events", tooMany, "events"},
+		{"Unknown type", []AnalyticsEvent{{Type: "click"}}, "events[0].type"},
+		{"Interaction without name", []AnalyticsEvent{{Type: AnalyticsInteraction}}, "events[0].name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := AnalyticsBatch{Events: tt.events}
+			err := batch.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Expected no validation error, got %v", err.Errors)
+				}
+				return
+			}
+			if err == nil || err.Errors[0].Field != tt.field {
+				t.Errorf("Expected validation error on %q, got %v", tt.field, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package entities
+
+import "time"
+
+// Anomaly kinds detected from recent activity
+const (
+	// AnomalyRegistrationBurst is many registrations from one network
+	AnomalyRegistrationBurst = "registration_burst"
+	// AnomalyFavoriteBurst is one user favoriting many articles
+	AnomalyFavoriteBurst = "favorite_burst"
+	// AnomalyCommentFlood is one user posting many comments
+	AnomalyCommentFlood = "comment_flood"
+)
+
+// Actions admins can take on an anomaly; every action resolves it
+const (
+	AnomalyActionRateLimit = "rate_limit"
+	AnomalyActionShadowBan = "shadow_ban"
+	AnomalyActionBan       = "ban"
+	// AnomalyActionDismiss resolves the anomaly without restricting anyone
+	AnomalyActionDismiss = "dismiss"
+)
+
+// Anomaly status filters for listing
+const (
+	AnomalyStatusOpen     = "open"
+	AnomalyStatusResolved = "resolved"
+	AnomalyStatusAll      = "all"
+)
+
+// Anomaly represents suspicious activity and the accounts behind it
+type Anomaly struct {
+	ID   int64  `json:"id"`
+	Kind string `json:"kind"`
+	// Network is the salted hash of the source network for registration bursts
+	Network     string     `json:"network,omitempty"`
+	Actors      []string   `json:"actors"`
+	EventCount  int        `json:"eventCount"`
+	WindowStart time.Time  `json:"windowStart"`
+	DetectedAt  time.Time  `json:"detectedAt"`
+	ResolvedAt  *time.Time `json:"resolvedAt"`
+	Action      string     `json:"action,omitempty"`
+}
+
+// AnomalySignal is one burst found by a scan, before it is recorded
+type AnomalySignal struct {
+	Kind string
+	// UserID is the subject of per-user anomalies; zero for network anomalies
+	UserID  int64
+	Network string
+	// ActorIDs lists every account involved
+	ActorIDs    []int64
+	EventCount  int
+	WindowStart time.Time
+}
+
+// AnomaliesResponse represents a page of anomalies
+type AnomaliesResponse struct {
+	Anomalies      []Anomaly `json:"anomalies"`
+	AnomaliesCount int       `json:"anomaliesCount"`
+}
+
+// AnomalyResponse represents a single anomaly API response
+type AnomalyResponse struct {
+	Anomaly Anomaly `json:"anomaly"`
+}
+
+// AnomalyScanResponse reports how many new anomalies a scan recorded
+type AnomalyScanResponse struct {
+	Detected int `json:"detected"`
+}
+
+// AnomalyAction represents an admin's action on an anomaly
+type AnomalyAction struct {
+	Action string `json:"action"`
+}
+
+// Validate validates an anomaly action
+func (aa *AnomalyAction) Validate() *ValidationErrors {
+	if aa.ModerationStatus() == "" && aa.Action != AnomalyActionDismiss {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "action",
+			Message: "action must be one of: rate_limit, shadow_ban, ban, dismiss",
+		}}}
+	}
+	return nil
+}
+
+// ModerationStatus returns the status the action applies to the anomaly's
+// actors, or an empty string if it applies none
+func (aa *AnomalyAction) ModerationStatus() string {
+	switch aa.Action {
+	case AnomalyActionRateLimit:
+		return ModerationRateLimited
+	case AnomalyActionShadowBan:
+		return ModerationShadowBanned
+	case AnomalyActionBan:
+		return ModerationBanned
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "testing"
+
+func TestAnomalyActionValidate(t *testing.T) {
+	tests := []struct {
+		action string
+		status string
+		valid  bool
+	}{
+		{AnomalyActionRateLimit, ModerationRateLimited, true},
+		{AnomalyActionShadowBan, ModerationShadowBanned, true},
+		{AnomalyActionBan, ModerationBanned, true},
+		{AnomalyActionDismiss, "", true},
+		{"delete", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		action := AnomalyAction{Action: tt.action}
+		if got := action.ModerationStatus(); got != tt.status {
+			t.Errorf("ModerationStatus(%q) = %q, want %q", tt.action, got, tt.status)
+		}
+		if valid := action.Validate() == nil; valid != tt.valid {
+			t.Errorf("Validate(%q) valid = %v, want %v", tt.action, valid, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxAPIKeysPerUser caps how many unrevoked API keys one user can hold
+const MaxAPIKeysPerUser = 20
+
+// APIKeyPrefix starts every API key, so leaked keys are easy to recognise
+const APIKeyPrefix = "cdk_"
+
+// APIKey represents a personal API key scripts and CI jobs authenticate with.
+// Only a hash of the key is stored; Key is set once, when it is created.
+type APIKey struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"-"`
+	Name   string `json:"name"`
+	// Prefix is the start of the key, shown so users can tell their keys apart
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Key        string     `json:"key,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+// IsActive reports whether the key can still authenticate at now
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
+
+// APIKeyCreate represents a request to create an API key
+type APIKeyCreate struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresInDays is how long the key lasts; null keeps it until revoked
+	ExpiresInDays *int `json:"expiresInDays"`
+}
+
+// APIKeyResponse represents single API key API response
+type APIKeyResponse struct {
+	APIKey APIKey `json:"apiKey"`
+}
+
+// APIKeysResponse represents API key list API response
+type APIKeysResponse struct {
+	APIKeys []APIKey `json:"apiKeys"`
+}
+
+// IsValidScope reports whether scope is one of the token scopes
+func IsValidScope(scope string) bool {
+	return scope == ScopeRead || scope == ScopeWrite || scope == ScopeAdmin
+}
+
+// Validate validates API key creation data
+func (kc *APIKeyCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	name := strings.TrimSpace(kc.Name)
+	if name == "" {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name is required",
+		})
+	} else if len(name) > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name must be less than 100 characters long",
+		})
+	}
+
+	if len(kc.Scopes) == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "scopes",
+			Message: "scopes must list at least one of: read, write, admin",
+		})
+	}
+	for _, scope := range kc.Scopes {
+		if !IsValidScope(scope) {
+			errors = append(errors, ValidationError{
+				Field:   "scopes",
+				Message: "scopes must be read, write or admin",
+			})
+			break
+		}
+	}
+
+	if kc.ExpiresInDays != nil && (*kc.ExpiresInDays < 1 || *kc.ExpiresInDays > 365) {
+		errors = append(errors, ValidationError{
+			Field:   "expiresInDays",
+			Message: "expiresInDays must be between 1 and 365",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
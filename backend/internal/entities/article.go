@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"regexp"
 	"strings"
 	"time"
@@ -13,29 +14,139 @@ type Article struct {
 	Slug        string    `json:"slug"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
-	Body        string    `json:"body"`
+	Body        string    `json:"body,omitempty"` // left out of lightweight lists
+	Language    string    `json:"language"`
 	AuthorID    int64     `json:"-"`
 	Author      *User     `json:"author,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	
+	// Canonical tag names, sorted
+	TagList []string `json:"tagList"`
+
 	// Additional fields for future features
 	FavoritesCount int  `json:"favoritesCount"`
 	Favorited      bool `json:"favorited"`
+
+	// Claps from all readers, and the viewer's own where known
+	ClapsCount int `json:"clapsCount"`
+	UserClaps  int `json:"userClaps,omitempty"`
+
+	// Live comments and when the latest was written, kept with each comment
+	// change; LastCommentAt is nil until the article is discussed
+	CommentsCount int        `json:"commentsCount"`
+	LastCommentAt *time.Time `json:"lastCommentAt"`
+
+	// Localized variants linked to this article
+	TranslationOf *int64               `json:"-"`
+	Translations  []ArticleTranslation `json:"translations,omitempty"`
+
+	// Link cards attached by the author
+	LinkPreviews []LinkPreview `json:"linkPreviews,omitempty"`
+
+	// Text files attached by the author, downloaded individually
+	Attachments []ArticleAttachment `json:"attachments,omitempty"`
+
+	// Review state; only published articles are listed
+	Status     string `json:"status"`
+	ReviewNote string `json:"reviewNote,omitempty"`
+
+	// Editor's pick state, with the editor's note shown alongside the pick
+	Featured     bool   `json:"featured"`
+	FeaturedNote string `json:"featuredNote,omitempty"`
+
+	// Pinned articles lead their author's profile listing
+	Pinned bool `json:"pinned"`
+
+	// New comments are rejected while locked, except from moderators
+	CommentsLocked bool `json:"commentsLocked"`
+
+	// NoIndex asks search engines not to index the article
+	NoIndex bool `json:"noindex"`
+
+	// Series navigation, included in detail responses for articles in a series
+	Series *SeriesNavigation `json:"series,omitempty"`
+
+	// Table of contents built from the body's headings, in detail responses
+	TOC []TOCEntry `json:"toc,omitempty"`
 }
 
+// Article review statuses
+const (
+	ArticleStatusPublished = "published"
+	// ArticleStatusPending articles wait in the review queue
+	ArticleStatusPending = "pending"
+	// ArticleStatusRejected articles stay visible to their author with the reviewer's note
+	ArticleStatusRejected = "rejected"
+)
+
 // ArticleCreate represents article creation request
 type ArticleCreate struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Body        string `json:"body"`
+	Language    string `json:"language,omitempty"`
+	NoIndex     bool   `json:"noindex,omitempty"`
+
+	// TagList names the article's tags; aliases resolve to their canonical tag
+	TagList []string `json:"tagList,omitempty"`
+
+	// Status is set by the server: pending when the article needs review
+	Status string `json:"-"`
+}
+
+// MaxFeaturedNoteLength caps the editor's note on a featured article
+const MaxFeaturedNoteLength = 280
+
+// ArticleFeature represents an admin featuring an article as an editor's pick
+type ArticleFeature struct {
+	Note string `json:"note,omitempty"`
+	// Position orders the picks, lowest first; ties go to the most recently featured
+	Position int `json:"position"`
+}
+
+// ArticleReview represents a reviewer's decision on a pending article
+type ArticleReview struct {
+	// Note is shown to the author, typically explaining a rejection
+	Note string `json:"note,omitempty"`
 }
 
-// ArticleUpdate represents article update request
+// ArticleUpdate represents article update request. Omitted fields are left
+// unchanged; sending null for a required field is a validation error.
 type ArticleUpdate struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Body        *string `json:"body,omitempty"`
+	NoIndex     *bool   `json:"noindex,omitempty"`
+
+	// TagList replaces the article's tags; null or an empty list removes them
+	TagList *[]string `json:"tagList,omitempty"`
+
+	// NullFields lists the JSON fields explicitly sent as null
+	NullFields []string `json:"-"`
+}
+
+// UnmarshalJSON decodes the update and records which fields were sent as null
+func (au *ArticleUpdate) UnmarshalJSON(data []byte) error {
+	type plain ArticleUpdate
+	var update plain
+	if err := json.Unmarshal(data, &update); err != nil {
+		return err
+	}
+
+	nulls, err := nullFields(data)
+	if err != nil {
+		return err
+	}
+
+	*au = ArticleUpdate(update)
+	au.NullFields = nulls
+	return nil
+}
+
+// Clears reports whether field was explicitly sent as null
+func (au *ArticleUpdate) Clears(field string) bool {
+	return containsField(au.NullFields, field)
 }
 
 // ArticleResponse represents single article API response
@@ -49,11 +160,81 @@ type ArticlesResponse struct {
 	ArticlesCount int       `json:"articlesCount"`
 }
 
+// FeedUnreadResponse represents the unread badge count for the personal feed
+type FeedUnreadResponse struct {
+	UnreadCount int `json:"unreadCount"`
+}
+
 // ArticleListQuery represents query parameters for article listing
 type ArticleListQuery struct {
 	Limit  int    `json:"limit"`
 	Offset int    `json:"offset"`
 	Author string `json:"author"`
+	Tag    string `json:"tag"`
+
+	// Favorited restricts results to articles favorited by this username.
+	// Only users who show their favorites on a public profile can be browsed,
+	// except by themselves (ViewerID).
+	Favorited string `json:"favorited"`
+	ViewerID  int64  `json:"-"`
+
+	// FollowedBy restricts results to authors followed by this user (personal feed)
+	FollowedBy int64 `json:"-"`
+
+	// Status selects articles in another review state; empty lists published
+	// articles. Other states are listed oldest first, like a queue.
+	Status string `json:"-"`
+
+	// Featured restricts results to editor's picks, listed in pick order
+	Featured bool `json:"featured"`
+
+	// Since and Until restrict results to articles created in [Since, Until);
+	// zero values leave that end open
+	Since time.Time `json:"-"`
+	Until time.Time `json:"-"`
+
+	// ExcludeBody leaves the body out, for list views that only show summaries
+	ExcludeBody bool `json:"-"`
+
+	// Sort orders published articles; empty lists the newest first
+	Sort string `json:"sort"`
+}
+
+// Article list orders
+const (
+	// ArticleSortRecent lists the newest articles first
+	ArticleSortRecent = "recent"
+	// ArticleSortActivity lists the most recently discussed articles first,
+	// followed by articles nobody has commented on
+	ArticleSortActivity = "activity"
+)
+
+// IsValidArticleSort checks if the article list order is supported
+func IsValidArticleSort(sort string) bool {
+	return sort == "" || sort == ArticleSortRecent || sort == ArticleSortActivity
+}
+
+// Validate validates a feature request
+func (af *ArticleFeature) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if len(af.Note) > MaxFeaturedNoteLength {
+		errors = append(errors, ValidationError{
+			Field:   "note",
+			Message: "note must be at most 280 characters long",
+		})
+	}
+	if af.Position < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "position",
+			Message: "position must not be negative",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
 }
 
 // Validate validates article creation data
@@ -114,6 +295,16 @@ func (ac *ArticleCreate) Validate() *ValidationErrors {
 		})
 	}
 
+	errors = append(errors, validateTagList(ac.TagList)...)
+
+	// Language validation (if provided)
+	if ac.Language != "" && !IsValidLanguageCode(ac.Language) {
+		errors = append(errors, ValidationError{
+			Field:   "language",
+			Message: "language must be a language code like 'en' or 'pt-BR'",
+		})
+	}
+
 	if len(errors) > 0 {
 		return &ValidationErrors{Errors: errors}
 	}
@@ -172,14 +363,44 @@ func (au *ArticleUpdate) Validate() *ValidationErrors {
 		}
 	}
 
+	if au.TagList != nil {
+		errors = append(errors, validateTagList(*au.TagList)...)
+	}
+
+	// Title, description and body are required and cannot be cleared
+	errors = append(errors, nullFieldErrors(au.NullFields, "tagList")...)
+
 	if len(errors) > 0 {
 		return &ValidationErrors{Errors: errors}
 	}
 	return nil
 }
 
-// ToArticleResponse converts Article to ArticleResponse
+// IsPublished returns true if the article has passed review (or never needed it)
+func (a *Article) IsPublished() bool {
+	return a.Status == "" || a.Status == ArticleStatusPublished
+}
+
+// Indexable reports whether search engines may index the article: it must be
+// published and its author must not have opted it out
+func (a *Article) Indexable() bool {
+	return a.IsPublished() && !a.NoIndex
+}
+
+// VisibleTo reports whether the user may see the article; unpublished articles
+// are visible only to their author and to reviewers (moderators and admins).
+// viewer is nil for anonymous requests.
+func (a *Article) VisibleTo(viewer *User) bool {
+	if a.IsPublished() {
+		return true
+	}
+	return viewer != nil && (viewer.ID == a.AuthorID || viewer.IsModerator())
+}
+
+// ToArticleResponse converts Article to ArticleResponse, adding the table of
+// contents that only single-article responses carry
 func (a *Article) ToArticleResponse() ArticleResponse {
+	a.TOC = ExtractTOC(a.Body)
 	return ArticleResponse{
 		Article: *a,
 	}
@@ -211,6 +211,14 @@ func TestArticleUpdateValidate(t *testing.T) {
 			wantErr:  true,
 			errorMsg: "body must be less than 10000 characters long",
 		},
+		{
+			name: "Null title",
+			article: ArticleUpdate{
+				NullFields: []string{"title"},
+			},
+			wantErr:  true,
+			errorMsg: "title cannot be cleared",
+		},
 	}
 
 	for _, tt := range tests {
@@ -482,6 +490,36 @@ func TestIntToString(t *testing.T) {
 	}
 }
 
+func TestArticleVisibleTo(t *testing.T) {
+	author := &User{ID: 1, Role: RoleUser}
+	reader := &User{ID: 2, Role: RoleUser}
+	admin := &User{ID: 3, Role: RoleAdmin}
+	moderator := &User{ID: 4, Role: RoleModerator}
+
+	tests := []struct {
+		status string
+		viewer *User
+		want   bool
+	}{
+		{ArticleStatusPublished, nil, true},
+		{"", reader, true},
+		{ArticleStatusPending, nil, false},
+		{ArticleStatusPending, reader, false},
+		{ArticleStatusPending, author, true},
+		{ArticleStatusPending, admin, true},
+		{ArticleStatusPending, moderator, true},
+		{ArticleStatusRejected, reader, false},
+		{ArticleStatusRejected, author, true},
+	}
+
+	for _, tt := range tests {
+		article := &Article{AuthorID: author.ID, Status: tt.status}
+		if got := article.VisibleTo(tt.viewer); got != tt.want {
+			t.Errorf("VisibleTo(status %q, viewer %+v) = %v, want %v", tt.status, tt.viewer, got, tt.want)
+		}
+	}
+}
+
 // Helper function to generate a long string of specified length
 func generateLongString(length int) string {
 	if length <= 0 {
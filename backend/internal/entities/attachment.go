@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAttachmentNameLength caps attachment file names
+const MaxAttachmentNameLength = 100
+
+// attachmentNamePattern allows plain file names such as "main.go" or "docker-compose.yml"
+var attachmentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ArticleAttachment is a named text file, such as a code snippet, attached to an article.
+// Article responses list attachments without their content, which is downloaded separately.
+type ArticleAttachment struct {
+	ID        int64     `json:"-"`
+	ArticleID int64     `json:"-"`
+	Name      string    `json:"name"`
+	Size      int       `json:"size"`
+	Content   string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ArticleAttachmentCreate represents a request to attach a file to an article
+type ArticleAttachmentCreate struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// ArticleAttachmentResponse represents single attachment API response
+type ArticleAttachmentResponse struct {
+	Attachment ArticleAttachment `json:"attachment"`
+}
+
+// Validate validates attachment creation data; the size limit is checked by the caller
+func (ac *ArticleAttachmentCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	// Name validation
+	if ac.Name == "" {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name is required",
+		})
+	} else if len(ac.Name) > MaxAttachmentNameLength {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name must be less than 100 characters",
+		})
+	} else if !attachmentNamePattern.MatchString(ac.Name) {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name can only contain letters, numbers, dots, hyphens and underscores",
+		})
+	}
+
+	// Content validation
+	if strings.TrimSpace(ac.Content) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "content",
+			Message: "content is required",
+		})
+	} else if !IsText(ac.Content) {
+		errors = append(errors, ValidationError{
+			Field:   "content",
+			Message: "content must be UTF-8 text",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// IsText reports whether s is valid UTF-8 without control characters other
+// than tabs and line breaks, which rules out binary files
+func IsText(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if (r < 0x20 && r != '\t' && r != '\n' && r != '\r') || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArticleAttachmentCreateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"main.go", "package main\n\nfunc main() {}\n", false},
+		{"docker-compose.yml", "services:\r\n\tapp: {}", false},
+		{"README", "# 제목", false},
+		{"", "x", true},
+		{"../etc/passwd", "x", true},
+		{".env", "x", true},
+		{"dir/main.go", "x", true},
+		{strings.Repeat("a", 101), "x", true},
+		{"main.go", "   \n", true},
+		{"image.png", "\x89PNG\r\n\x1a\n\x00", true},
+		{"latin1.txt", "caf\xe9", true},
+	}
+
+	for _, tt := range tests {
+		create := ArticleAttachmentCreate{Name: tt.name, Content: tt.content}
+		if err := create.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.name, tt.content, err, tt.wantErr)
+		}
+	}
+}
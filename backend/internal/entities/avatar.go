@@ -0,0 +1,20 @@
+package entities
+
+import "net/url"
+
+// AvatarBaseURL prefixes generated avatar URLs, e.g. "https://api.example.com".
+// It is set from configuration at startup; when empty the URLs are relative.
+var AvatarBaseURL = ""
+
+// DefaultAvatarURL returns the URL of the generated avatar for a username
+func DefaultAvatarURL(username string) string {
+	return AvatarBaseURL + "/media/avatars/" + url.PathEscape(username) + ".png"
+}
+
+// AvatarURL returns the user's image, falling back to their generated avatar
+func (u *User) AvatarURL() string {
+	if u.ImageURL != "" {
+		return u.ImageURL
+	}
+	return DefaultAvatarURL(u.Username)
+}
@@ -0,0 +1,20 @@
+package entities
+
+// MaxIdempotencyKeyLength caps the Idempotency-Key header stored with a clap
+const MaxIdempotencyKeyLength = 100
+
+// ClapCreate represents a request to add claps to an article
+type ClapCreate struct {
+	Count int `json:"count"`
+}
+
+// Validate validates clap data
+func (cc *ClapCreate) Validate() *ValidationErrors {
+	if cc.Count < 1 {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "count",
+			Message: "count must be at least 1",
+		}}}
+	}
+	return nil
+}
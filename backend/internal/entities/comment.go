@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strconv"
 	"strings"
 	"time"
 )
@@ -12,12 +13,36 @@ type Comment struct {
 	AuthorID  int64     `json:"-"`
 	Author    *User     `json:"author,omitempty"`
 	ArticleID int64     `json:"-"`
+	ParentID  *int64    `json:"parentId"` // nil for top-level comments; clients nest replies
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Computed participant labels for badging
+	IsArticleAuthor bool `json:"isArticleAuthor"`
+	IsModerator     bool `json:"isModerator"`
+
+	// Deleted comments keep their place in the thread as a placeholder
+	Deleted   bool       `json:"deleted"`
+	DeletedAt *time.Time `json:"-"`
 }
 
+// DeletedCommentPlaceholder replaces the body of comments deleted in placeholder mode
+const DeletedCommentPlaceholder = "[deleted]"
+
+// Comment deletion modes
+const (
+	CommentDeleteModeHard        = "hard"
+	CommentDeleteModePlaceholder = "placeholder"
+)
+
 // CommentCreate represents comment creation request
 type CommentCreate struct {
+	Body     string `json:"body"`
+	ParentID *int64 `json:"parentId,omitempty"`
+}
+
+// CommentUpdate represents a comment edit request
+type CommentUpdate struct {
 	Body string `json:"body"`
 }
 
@@ -26,9 +51,61 @@ type CommentResponse struct {
 	Comment Comment `json:"comment"`
 }
 
+// CommentPermalink places a comment within its article's discussion so links
+// can land on it whether clients show comments flat, threaded or paginated
+type CommentPermalink struct {
+	CommentID   int64  `json:"commentId"`
+	ArticleSlug string `json:"articleSlug"`
+	// Position is 1-based in the chronological comment list
+	Position int `json:"position"`
+	// ThreadID is the top-level comment the comment belongs under (itself for
+	// top-level comments), and Depth how many replies down it is
+	ThreadID int64 `json:"threadId"`
+	Depth    int   `json:"depth"`
+	// ThreadPosition is the thread's 1-based place among top-level comments,
+	// and Page the page it is on when threads are paged by PageSize
+	ThreadPosition int    `json:"threadPosition"`
+	Page           int    `json:"page"`
+	PageSize       int    `json:"pageSize"`
+	URL            string `json:"url"`
+}
+
+// CommentPermalinkResponse represents a resolved comment permalink API response
+type CommentPermalinkResponse struct {
+	Comment   Comment          `json:"comment"`
+	Permalink CommentPermalink `json:"permalink"`
+}
+
 // CommentsResponse represents multiple comments API response
 type CommentsResponse struct {
-	Comments []Comment `json:"comments"`
+	Comments       []Comment `json:"comments"`
+	CommentsLocked bool      `json:"commentsLocked"`
+}
+
+// InboundEmail represents a reply email forwarded by the mail provider webhook
+type InboundEmail struct {
+	Recipient string
+	Text      string
+}
+
+// ExtractReplyText returns the newly written part of a reply email, dropping quoted
+// lines and everything from the "On ... wrote:" attribution or signature marker down
+func ExtractReplyText(text string) string {
+	var kept []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "--" || strings.HasPrefix(trimmed, "-----Original Message-----") {
+			break
+		}
+		if strings.HasPrefix(trimmed, "On ") && strings.HasSuffix(trimmed, "wrote:") {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
 }
 
 // Validate validates comment creation data
@@ -59,9 +136,75 @@ func (cc *CommentCreate) Validate() *ValidationErrors {
 	return nil
 }
 
+// Validate validates comment edits with the same rules as new comments
+func (cu *CommentUpdate) Validate() *ValidationErrors {
+	create := CommentCreate{Body: cu.Body}
+	return create.Validate()
+}
+
+// IsValidCommentDeleteMode checks if the comment deletion mode is supported
+func IsValidCommentDeleteMode(mode string) bool {
+	return mode == CommentDeleteModeHard || mode == CommentDeleteModePlaceholder
+}
+
 // ToCommentResponse converts Comment to CommentResponse
 func (c *Comment) ToCommentResponse() CommentResponse {
 	return CommentResponse{
 		Comment: *c,
 	}
-}
\ No newline at end of file
+}
+
+// LocateComment finds the comment in an article's chronological comment list
+// and works out its thread and page. It returns nil if the comment is not in
+// the list.
+func LocateComment(comments []Comment, commentID int64, articleSlug string, pageSize int) *CommentPermalink {
+	parents := make(map[int64]*int64, len(comments))
+	position := 0
+	for i, comment := range comments {
+		parents[comment.ID] = comment.ParentID
+		if comment.ID == commentID {
+			position = i + 1
+		}
+	}
+	if position == 0 {
+		return nil
+	}
+
+	// Walk up to the top-level comment; a parent missing from the list (or a
+	// cycle) ends the walk there
+	threadID, depth := commentID, 0
+	for parent := parents[threadID]; parent != nil && depth < len(comments); parent = parents[threadID] {
+		if _, ok := parents[*parent]; !ok {
+			break
+		}
+		threadID = *parent
+		depth++
+	}
+
+	threadPosition := 0
+	for _, comment := range comments {
+		if comment.ParentID == nil || comment.ID == threadID {
+			threadPosition++
+		}
+		if comment.ID == threadID {
+			break
+		}
+	}
+
+	return &CommentPermalink{
+		CommentID:      commentID,
+		ArticleSlug:    articleSlug,
+		Position:       position,
+		ThreadID:       threadID,
+		Depth:          depth,
+		ThreadPosition: threadPosition,
+		Page:           (threadPosition-1)/pageSize + 1,
+		PageSize:       pageSize,
+		URL:            CommentURL(articleSlug, commentID),
+	}
+}
+
+// CommentURL is the site path that shows the article scrolled to the comment
+func CommentURL(articleSlug string, commentID int64) string {
+	return "/article/" + articleSlug + "#comment-" + strconv.FormatInt(commentID, 10)
+}
@@ -88,6 +88,20 @@ func TestCommentCreateValidate(t *testing.T) {
 	}
 }
 
+func TestCommentUpdateValidate(t *testing.T) {
+	valid := CommentUpdate{Body: "Fixed a typo"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+
+	for _, body := range []string{"", "   ", generateLongString(10001)} {
+		update := CommentUpdate{Body: body}
+		if err := update.Validate(); err == nil || err.Errors[0].Field != "body" {
+			t.Errorf("Expected body validation error for %q, got %v", body, err)
+		}
+	}
+}
+
 func TestCommentToCommentResponse(t *testing.T) {
 	comment := &Comment{
 		ID:   1,
@@ -118,4 +132,59 @@ func generateLongString(length int) string {
 		result[i] = 'a'
 	}
 	return string(result)
-}
\ No newline at end of file
+}
+func TestExtractReplyText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain reply", "Thanks for reading!", "Thanks for reading!"},
+		{"Quoted lines", "Agreed.\n> original comment\n> more", "Agreed."},
+		{"Attribution", "Good point.\r\n\r\nOn Mon, Jan 1, 2024 at 10:00 AM Conduit <noreply@conduit.local> wrote:\r\nbob commented", "Good point."},
+		{"Signature", "See you there\n--\nAlice", "See you there"},
+		{"Only quote", "> quoted only", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractReplyText(tt.input); got != tt.expected {
+				t.Errorf("ExtractReplyText(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocateComment(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	// Threads 1, 2 and 5 in chronological order, with replies interleaved
+	comments := []Comment{
+		{ID: 1},
+		{ID: 2},
+		{ID: 3, ParentID: id(1)},
+		{ID: 4, ParentID: id(3)},
+		{ID: 5},
+		{ID: 6, ParentID: id(2)},
+	}
+
+	link := LocateComment(comments, 4, "hello", 2)
+	if link == nil {
+		t.Fatal("Expected the comment to be found")
+	}
+	if link.Position != 4 || link.ThreadID != 1 || link.Depth != 2 || link.ThreadPosition != 1 || link.Page != 1 {
+		t.Errorf("Unexpected location for a nested reply: %+v", link)
+	}
+	if link.URL != "/article/hello#comment-4" {
+		t.Errorf("Unexpected URL %q", link.URL)
+	}
+
+	if link := LocateComment(comments, 5, "hello", 2); link.ThreadID != 5 || link.Depth != 0 || link.ThreadPosition != 3 || link.Page != 2 {
+		t.Errorf("Unexpected location for a top-level comment: %+v", link)
+	}
+	if link := LocateComment(comments, 6, "hello", 2); link.ThreadID != 2 || link.ThreadPosition != 2 || link.Page != 1 {
+		t.Errorf("Unexpected location for a reply: %+v", link)
+	}
+	if link := LocateComment(comments, 9, "hello", 2); link != nil {
+		t.Errorf("Expected a missing comment not to be found, got %+v", link)
+	}
+}
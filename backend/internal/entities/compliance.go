@@ -0,0 +1,32 @@
+package entities
+
+// AnalyticsConsentCookie is set by the frontend once a visitor accepts
+// analytics cookies. It is a cookie rather than a header because events are
+// sent with navigator.sendBeacon, which cannot set headers.
+const AnalyticsConsentCookie = "analytics_consent"
+
+// Compliance describes the regional rules the deployment runs under, so
+// clients know which consent prompts to show and which features to hide
+type Compliance struct {
+	// Region is the compliance preset the deployment was configured with, if any
+	Region string `json:"region"`
+	// CookieConsentRequired means analytics events are only recorded for
+	// visitors whose AnalyticsConsentCookie is "granted"
+	CookieConsentRequired bool   `json:"cookieConsentRequired"`
+	ConsentCookie         string `json:"consentCookie,omitempty"`
+	// DataResidency means user data stays in the deployment's region; features
+	// handing it to services elsewhere are unavailable
+	DataResidency bool `json:"dataResidency"`
+	// MinimumAge is the age users must be at registration; 0 means none
+	MinimumAge int `json:"minimumAge"`
+	// BirthdateRequired means registration takes a birthdate to check the
+	// minimum age against, rather than the user's confirmation
+	BirthdateRequired bool `json:"birthdateRequired"`
+	// Unavailable lists the routes that answer 451 in this deployment
+	Unavailable []string `json:"unavailable"`
+}
+
+// ComplianceResponse represents compliance API response
+type ComplianceResponse struct {
+	Compliance Compliance `json:"compliance"`
+}
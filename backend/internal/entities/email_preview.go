@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// EmailPreview is an email captured in development, as rendered for the
+// recipient
+type EmailPreview struct {
+	To      string `json:"to"`
+	ReplyTo string `json:"replyTo,omitempty"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	// HTML previews the email as a mail client would show it
+	HTML   string    `json:"html"`
+	SentAt time.Time `json:"sentAt"`
+}
+
+// EmailPreviewsResponse represents captured email list API response
+type EmailPreviewsResponse struct {
+	Emails      []EmailPreview `json:"emails"`
+	EmailsCount int            `json:"emailsCount"`
+}
@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Email delivery statuses
+const (
+	// EmailDeliverable addresses receive email
+	EmailDeliverable = "deliverable"
+	// EmailBouncing addresses have soft-bounced but are still sent to
+	EmailBouncing = "bouncing"
+	// EmailUndeliverable addresses hard-bounced, or soft-bounced too often;
+	// nothing more is sent to them
+	EmailUndeliverable = "undeliverable"
+	// EmailComplained addresses reported our email as spam; nothing more is
+	// sent to them
+	EmailComplained = "complained"
+)
+
+// Email event types reported by the email provider
+const (
+	EmailEventBounce    = "bounce"
+	EmailEventComplaint = "complaint"
+	EmailEventDelivery  = "delivery"
+)
+
+// EmailStatus is the deliverability of a user's email address
+type EmailStatus struct {
+	Username string `json:"username"`
+	Status   string `json:"status"`
+	// SoftBounces counts soft bounces since the last delivery
+	SoftBounces  int        `json:"softBounces"`
+	LastEvent    string     `json:"lastEvent,omitempty"`
+	LastEventAt  *time.Time `json:"lastEventAt"`
+	SuppressedAt *time.Time `json:"suppressedAt"`
+}
+
+// IsSuppressed reports whether email to the address is no longer sent
+func (s EmailStatus) IsSuppressed() bool {
+	return s.Status == EmailUndeliverable || s.Status == EmailComplained
+}
+
+// Apply returns the status after the event arrived at at. Complaints and hard
+// bounces suppress the address at once; softBounceLimit soft bounces in a row
+// do too, unless it is 0. A delivery resets the soft bounce count of an
+// address that is not suppressed.
+func (s EmailStatus) Apply(event EmailEvent, at time.Time, softBounceLimit int) EmailStatus {
+	if s.Status == "" {
+		s.Status = EmailDeliverable
+	}
+	s.LastEvent = event.Type
+	s.LastEventAt = &at
+
+	switch {
+	case s.IsSuppressed():
+		if event.Type == EmailEventBounce && !event.Permanent {
+			s.SoftBounces++
+		}
+		return s
+	case event.Type == EmailEventComplaint:
+		s.Status = EmailComplained
+	case event.Type == EmailEventBounce && event.Permanent:
+		s.Status = EmailUndeliverable
+	case event.Type == EmailEventBounce:
+		s.SoftBounces++
+		s.Status = EmailBouncing
+		if softBounceLimit > 0 && s.SoftBounces >= softBounceLimit {
+			s.Status = EmailUndeliverable
+		}
+	case event.Type == EmailEventDelivery:
+		s.Status = EmailDeliverable
+		s.SoftBounces = 0
+	}
+
+	if s.IsSuppressed() {
+		s.SuppressedAt = &at
+	}
+	return s
+}
+
+// EmailStatusResponse represents email status API response
+type EmailStatusResponse struct {
+	EmailStatus EmailStatus `json:"emailStatus"`
+}
+
+// EmailEvent is a bounce, complaint or delivery notification from the email
+// provider. Permanent marks hard bounces.
+type EmailEvent struct {
+	Type      string `json:"type"`
+	Email     string `json:"email"`
+	Permanent bool   `json:"permanent,omitempty"`
+}
+
+// EmailEventBatch represents the email provider's webhook payload
+type EmailEventBatch struct {
+	Events []EmailEvent `json:"events"`
+}
+
+// Validate validates an email event batch
+func (b *EmailEventBatch) Validate() *ValidationErrors {
+	if len(b.Events) == 0 || len(b.Events) > 100 {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "events",
+			Message: "events must list between 1 and 100 events",
+		}}}
+	}
+
+	for _, event := range b.Events {
+		if event.Type != EmailEventBounce && event.Type != EmailEventComplaint && event.Type != EmailEventDelivery {
+			return &ValidationErrors{Errors: []ValidationError{{
+				Field:   "events.type",
+				Message: "type must be one of: bounce, complaint, delivery",
+			}}}
+		}
+		if strings.TrimSpace(event.Email) == "" {
+			return &ValidationErrors{Errors: []ValidationError{{
+				Field:   "events.email",
+				Message: "email is required",
+			}}}
+		}
+	}
+
+	return nil
+}
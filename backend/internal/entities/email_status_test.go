@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmailStatus_Apply(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	soft := EmailEvent{Type: EmailEventBounce}
+	hard := EmailEvent{Type: EmailEventBounce, Permanent: true}
+	complaint := EmailEvent{Type: EmailEventComplaint}
+	delivery := EmailEvent{Type: EmailEventDelivery}
+
+	tests := []struct {
+		name        string
+		events      []EmailEvent
+		limit       int
+		wantStatus  string
+		wantBounces int
+	}{
+		{"soft bounce", []EmailEvent{soft}, 3, EmailBouncing, 1},
+		{"soft bounces up to the limit", []EmailEvent{soft, soft, soft}, 3, EmailUndeliverable, 3},
+		{"no limit", []EmailEvent{soft, soft, soft}, 0, EmailBouncing, 3},
+		{"delivery resets soft bounces", []EmailEvent{soft, soft, delivery, soft}, 3, EmailBouncing, 1},
+		{"hard bounce", []EmailEvent{hard}, 3, EmailUndeliverable, 0},
+		{"complaint", []EmailEvent{complaint}, 3, EmailComplained, 0},
+		{"delivery does not lift suppression", []EmailEvent{complaint, delivery}, 3, EmailComplained, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status EmailStatus
+			for _, event := range tt.events {
+				status = status.Apply(event, now, tt.limit)
+			}
+			if status.Status != tt.wantStatus || status.SoftBounces != tt.wantBounces {
+				t.Errorf("Apply() = %s with %d soft bounces, want %s with %d",
+					status.Status, status.SoftBounces, tt.wantStatus, tt.wantBounces)
+			}
+			if status.IsSuppressed() != (status.SuppressedAt != nil) {
+				t.Errorf("Expected SuppressedAt to be set only for suppressed addresses, got %v", status.SuppressedAt)
+			}
+		})
+	}
+}
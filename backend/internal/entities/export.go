@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Document formats articles can be exported to
+const (
+	ExportFormatPDF  = "pdf"
+	ExportFormatEPUB = "epub"
+)
+
+// Export states: queued, rendered and ready to download, or given up on
+const (
+	ExportStatusPending = "pending"
+	ExportStatusReady   = "ready"
+	ExportStatusFailed  = "failed"
+)
+
+// ArticleExport is an article rendered, or queued to be rendered, to a
+// downloadable document for the user who asked for it
+type ArticleExport struct {
+	ID          int64      `json:"id"`
+	ArticleID   int64      `json:"-"`
+	UserID      int64      `json:"-"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	ContentType string     `json:"-"`
+	Content     []byte     `json:"-"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+	// DownloadURL is set once the export is ready
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
+// ArticleExportResponse represents single article export API response
+type ArticleExportResponse struct {
+	Export ArticleExport `json:"export"`
+}
+
+// MaxBookChapters caps the articles picked for one book export
+const MaxBookChapters = 100
+
+// BookExport is a series, or articles an author picked, bundled into one
+// downloadable book for the user who asked for it
+type BookExport struct {
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"-"`
+	SeriesID *int64 `json:"-"`
+	// ArticleIDs are the chapters of a book of picked articles, in order; a
+	// series book takes its chapters from the series when it is rendered
+	ArticleIDs  []int64    `json:"-"`
+	Title       string     `json:"title"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	ContentType string     `json:"-"`
+	Content     []byte     `json:"-"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+	// DownloadURL is set once the export is ready
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
+// BookExportCreate represents a request to bundle an author's articles
+type BookExportCreate struct {
+	Title  string `json:"title"`
+	Format string `json:"format"`
+	// Articles are article slugs in chapter order
+	Articles []string `json:"articles"`
+}
+
+// BookExportResponse represents single book export API response
+type BookExportResponse struct {
+	Export BookExport `json:"export"`
+}
+
+// Validate validates book export data
+func (bc *BookExportCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if strings.TrimSpace(bc.Title) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "title",
+			Message: "title is required",
+		})
+	} else if len(bc.Title) > 200 {
+		errors = append(errors, ValidationError{
+			Field:   "title",
+			Message: "title must be less than 200 characters long",
+		})
+	}
+
+	if len(bc.Articles) == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "articles",
+			Message: "at least one article is required",
+		})
+	} else if len(bc.Articles) > MaxBookChapters {
+		errors = append(errors, ValidationError{
+			Field:   "articles",
+			Message: fmt.Sprintf("at most %d articles can be exported at once", MaxBookChapters),
+		})
+	} else {
+		seen := make(map[string]bool, len(bc.Articles))
+		for _, slug := range bc.Articles {
+			if strings.TrimSpace(slug) == "" || seen[slug] {
+				errors = append(errors, ValidationError{
+					Field:   "articles",
+					Message: "articles must be distinct article slugs",
+				})
+				break
+			}
+			seen[slug] = true
+		}
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
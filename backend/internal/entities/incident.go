@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Incident kinds
+const (
+	// IncidentKindIncident is an unplanned outage or degradation
+	IncidentKindIncident = "incident"
+	// IncidentKindMaintenance is planned work announced ahead of or during it
+	IncidentKindMaintenance = "maintenance"
+)
+
+// Incident severities, least severe first
+const (
+	IncidentSeverityMinor    = "minor"
+	IncidentSeverityMajor    = "major"
+	IncidentSeverityCritical = "critical"
+)
+
+// Incident status filters for listing
+const (
+	IncidentStatusActive   = "active"
+	IncidentStatusResolved = "resolved"
+	IncidentStatusAll      = "all"
+)
+
+// Limits on incident notices
+const (
+	MaxIncidentTitleLength   = 200
+	MaxIncidentMessageLength = 2000
+	MaxIncidentComponents    = 10
+)
+
+// Incident is an outage or maintenance notice admins post for the status page
+type Incident struct {
+	ID       int64  `json:"id"`
+	Kind     string `json:"kind"`
+	Severity string `json:"severity"`
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	// Components names the affected subsystems, e.g. "email"
+	Components []string   `json:"components"`
+	StartedAt  time.Time  `json:"startedAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	ResolvedAt *time.Time `json:"resolvedAt"`
+}
+
+// IsActive reports whether the incident has not been resolved
+func (i *Incident) IsActive() bool {
+	return i.ResolvedAt == nil
+}
+
+// IncidentCreate represents a request to open an incident
+type IncidentCreate struct {
+	Kind       string   `json:"kind"`
+	Severity   string   `json:"severity"`
+	Title      string   `json:"title"`
+	Message    string   `json:"message"`
+	Components []string `json:"components"`
+}
+
+// IncidentUpdate represents a request to update an open incident; omitted
+// fields are left unchanged
+type IncidentUpdate struct {
+	Severity   *string   `json:"severity,omitempty"`
+	Title      *string   `json:"title,omitempty"`
+	Message    *string   `json:"message,omitempty"`
+	Components *[]string `json:"components,omitempty"`
+}
+
+// IncidentResponse represents single incident API response
+type IncidentResponse struct {
+	Incident Incident `json:"incident"`
+}
+
+// IncidentsResponse represents incident list API response
+type IncidentsResponse struct {
+	Incidents      []Incident `json:"incidents"`
+	IncidentsCount int        `json:"incidentsCount"`
+}
+
+// IncidentSeverityRank orders severities from 1 (minor) to 3 (critical); 0
+// means the severity is not valid
+func IncidentSeverityRank(severity string) int {
+	switch severity {
+	case IncidentSeverityMinor:
+		return 1
+	case IncidentSeverityMajor:
+		return 2
+	case IncidentSeverityCritical:
+		return 3
+	}
+	return 0
+}
+
+// Validate validates an incident creation request, defaulting the kind to
+// incident and the severity to minor
+func (ic *IncidentCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	ic.Title = strings.TrimSpace(ic.Title)
+	ic.Message = strings.TrimSpace(ic.Message)
+	if ic.Kind == "" {
+		ic.Kind = IncidentKindIncident
+	}
+	if ic.Severity == "" {
+		ic.Severity = IncidentSeverityMinor
+	}
+
+	if ic.Kind != IncidentKindIncident && ic.Kind != IncidentKindMaintenance {
+		errors = append(errors, ValidationError{Field: "kind", Message: "kind must be one of: incident, maintenance"})
+	}
+	errors = append(errors, validateIncidentFields(&ic.Severity, &ic.Title, &ic.Message, &ic.Components)...)
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// Validate validates an incident update request
+func (iu *IncidentUpdate) Validate() *ValidationErrors {
+	if iu.Title != nil {
+		*iu.Title = strings.TrimSpace(*iu.Title)
+	}
+	if iu.Message != nil {
+		*iu.Message = strings.TrimSpace(*iu.Message)
+	}
+
+	if errors := validateIncidentFields(iu.Severity, iu.Title, iu.Message, iu.Components); len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// Apply returns the incident with the update's fields
+func (iu *IncidentUpdate) Apply(incident Incident) Incident {
+	if iu.Severity != nil {
+		incident.Severity = *iu.Severity
+	}
+	if iu.Title != nil {
+		incident.Title = *iu.Title
+	}
+	if iu.Message != nil {
+		incident.Message = *iu.Message
+	}
+	if iu.Components != nil {
+		incident.Components = *iu.Components
+	}
+	return incident
+}
+
+// validateIncidentFields checks the fields shared by creates and updates;
+// nil fields are skipped
+func validateIncidentFields(severity, title, message *string, components *[]string) []ValidationError {
+	var errors []ValidationError
+
+	if severity != nil && IncidentSeverityRank(*severity) == 0 {
+		errors = append(errors, ValidationError{Field: "severity", Message: "severity must be one of: minor, major, critical"})
+	}
+	if title != nil {
+		if *title == "" {
+			errors = append(errors, ValidationError{Field: "title", Message: "title is required"})
+		} else if len(*title) > MaxIncidentTitleLength {
+			errors = append(errors, ValidationError{Field: "title", Message: "title must be at most 200 characters"})
+		}
+	}
+	if message != nil && len(*message) > MaxIncidentMessageLength {
+		errors = append(errors, ValidationError{Field: "message", Message: "message must be at most 2000 characters"})
+	}
+	if components != nil {
+		if len(*components) > MaxIncidentComponents {
+			errors = append(errors, ValidationError{Field: "components", Message: "at most 10 components can be affected"})
+		}
+		for _, component := range *components {
+			if component == "" || strings.ContainsAny(component, " \t\n") {
+				errors = append(errors, ValidationError{Field: "components", Message: "component names cannot be empty or contain spaces"})
+				break
+			}
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// MaxInvitesPerRequest caps how many invites an admin can create at once
+const MaxInvitesPerRequest = 50
+
+// Invite represents a single-use registration invite
+type Invite struct {
+	Code      string     `json:"code"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ClaimedAt *time.Time `json:"claimedAt"`
+	// UsedBy is the username of the account registered with the invite
+	UsedBy string `json:"usedBy,omitempty"`
+}
+
+// InviteCreate represents a request to create invites
+type InviteCreate struct {
+	Count int `json:"count"`
+}
+
+// InvitesResponse represents a list of invites
+type InvitesResponse struct {
+	Invites      []Invite `json:"invites"`
+	InvitesCount int      `json:"invitesCount"`
+}
+
+// Validate validates an invite creation request
+func (ic *InviteCreate) Validate() *ValidationErrors {
+	if ic.Count < 1 || ic.Count > MaxInvitesPerRequest {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "count",
+			Message: "count must be between 1 and 50",
+		}}}
+	}
+	return nil
+}
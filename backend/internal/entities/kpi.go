@@ -0,0 +1,18 @@
+package entities
+
+// CommunityStats is a snapshot of community size and activity, exported as
+// metrics for growth dashboards
+type CommunityStats struct {
+	// Users counts accounts that are neither deactivated nor banned
+	Users     int `json:"users"`
+	Articles  int `json:"articles"`
+	Comments  int `json:"comments"`
+	Favorites int `json:"favorites"`
+	Follows   int `json:"follows"`
+	// DailyActiveVisitors counts distinct analytics visitors today (UTC); it
+	// approximates daily actives including anonymous readers
+	DailyActiveVisitors int `json:"dailyActiveVisitors"`
+	// DailyActiveUsers counts accounts that published, commented, favorited,
+	// clapped or followed during the last 24 hours
+	DailyActiveUsers int `json:"dailyActiveUsers"`
+}
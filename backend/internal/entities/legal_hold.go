@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Kinds of content a legal hold can be placed on
+const (
+	LegalHoldSubjectUser    = "user"
+	LegalHoldSubjectArticle = "article"
+)
+
+// MaxLegalHoldReasonLength caps the reason recorded when placing or releasing a hold
+const MaxLegalHoldReasonLength = 1000
+
+// LegalHold blocks deleting a user or article until an admin releases it.
+// Holds are never removed, so together they form the audit trail.
+type LegalHold struct {
+	ID          int64  `json:"id"`
+	SubjectType string `json:"subjectType"`
+	// Subject is the username or article slug; empty once the subject is gone
+	Subject  string    `json:"subject"`
+	Reason   string    `json:"reason"`
+	PlacedBy string    `json:"placedBy"`
+	PlacedAt time.Time `json:"placedAt"`
+
+	ReleasedBy    string     `json:"releasedBy,omitempty"`
+	ReleasedAt    *time.Time `json:"releasedAt"`
+	ReleaseReason string     `json:"releaseReason,omitempty"`
+
+	// Deletion requests refused while the hold was active
+	BlockedAttempts int        `json:"blockedAttempts"`
+	LastBlockedAt   *time.Time `json:"lastBlockedAt"`
+}
+
+// LegalHoldChange represents placing or releasing a hold; both need a reason
+type LegalHoldChange struct {
+	Reason string `json:"reason"`
+}
+
+// LegalHoldResponse represents single legal hold API response
+type LegalHoldResponse struct {
+	Hold LegalHold `json:"hold"`
+}
+
+// LegalHoldsResponse represents legal hold listing API response
+type LegalHoldsResponse struct {
+	Holds []LegalHold `json:"holds"`
+}
+
+// Validate validates a legal hold change
+func (lc *LegalHoldChange) Validate() *ValidationErrors {
+	reason := strings.TrimSpace(lc.Reason)
+	if reason == "" {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "reason",
+			Message: "reason is required",
+		}}}
+	}
+	if len(reason) > MaxLegalHoldReasonLength {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "reason",
+			Message: "reason must be at most 1000 characters long",
+		}}}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"net/url"
+	"time"
+)
+
+// LinkPreview holds the Open Graph metadata of a linked page, used to render link cards
+type LinkPreview struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image"`
+	SiteName    string    `json:"siteName"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+}
+
+// LinkPreviewCreate represents a request to attach a link preview to an article
+type LinkPreviewCreate struct {
+	URL string `json:"url"`
+}
+
+// LinkPreviewResponse represents single link preview API response
+type LinkPreviewResponse struct {
+	LinkPreview LinkPreview `json:"linkPreview"`
+}
+
+// LinkPreviewsResponse represents multiple link previews API response
+type LinkPreviewsResponse struct {
+	LinkPreviews []LinkPreview `json:"linkPreviews"`
+}
+
+// Validate validates link preview creation data
+func (lc *LinkPreviewCreate) Validate() *ValidationErrors {
+	if message := ValidateLinkPreviewURL(lc.URL); message != "" {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "url",
+			Message: message,
+		}}}
+	}
+	return nil
+}
+
+// ValidateLinkPreviewURL returns a validation message if raw cannot be
+// previewed, or "" if it is an absolute http(s) URL
+func ValidateLinkPreviewURL(raw string) string {
+	if raw == "" {
+		return "url is required"
+	}
+	if len(raw) > 2048 {
+		return "url must be less than 2048 characters"
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "url must be an absolute http or https URL"
+	}
+	return ""
+}
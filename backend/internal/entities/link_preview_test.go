@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinkPreviewCreateValidate(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://example.com/post", false},
+		{"http://example.com", false},
+		{"", true},
+		{"example.com/post", true},
+		{"javascript:alert(1)", true},
+		{"https://" + strings.Repeat("a", 2048), true},
+	}
+
+	for _, tt := range tests {
+		create := LinkPreviewCreate{URL: tt.url}
+		if err := create.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
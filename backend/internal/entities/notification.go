@@ -0,0 +1,293 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification event types
+const (
+	EventNewComment       = "new_comment"
+	EventNewFollower      = "new_follower"
+	EventFollowedArticle  = "followed_article"
+	EventArticleFavorited = "article_favorited"
+	EventArticleReviewed  = "article_reviewed"
+	// EventQuietHoursSummary sums up the notifications held during quiet
+	// hours; it is sent, never configured
+	EventQuietHoursSummary = "quiet_hours_summary"
+)
+
+// Notification delivery modes
+const (
+	DeliveryImmediate   = "immediate"
+	DeliveryDailyDigest = "daily_digest"
+	DeliveryOff         = "off"
+	// DeliveryHeld marks immediate notifications that arrived during the
+	// user's quiet hours; they are sent as a summary once quiet hours end
+	DeliveryHeld = "held"
+)
+
+// NotificationEventTypes lists every event type a user can configure
+var NotificationEventTypes = []string{
+	EventNewComment,
+	EventNewFollower,
+	EventFollowedArticle,
+	EventArticleFavorited,
+	EventArticleReviewed,
+}
+
+// Notification represents a notification delivered to a user. A burst of
+// the same event coalesces into one notification: Count is how many events it
+// stands for, and Message and Link describe the latest of them.
+type Notification struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"-"`
+	EventType string    `json:"type"`
+	Message   string    `json:"message"`
+	Link      string    `json:"link"`
+	Count     int       `json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Internal fields (not exposed in API)
+	GroupKey   string     `json:"-"`
+	Delivery   string     `json:"-"`
+	DigestedAt *time.Time `json:"-"`
+}
+
+// NotificationGroupKey returns the key under which notifications of the event
+// coalesce, or "" if each one stands alone. Favorites coalesce per article and
+// new followers all together; comments carry their own text and are never
+// merged.
+func NotificationGroupKey(eventType, link string) string {
+	switch eventType {
+	case EventArticleFavorited:
+		return eventType + ":" + link
+	case EventNewFollower:
+		return eventType
+	default:
+		return ""
+	}
+}
+
+// Summary returns the notification's message, noting how many other events
+// were coalesced into it
+func (n *Notification) Summary() string {
+	switch {
+	case n.Count == 2:
+		return n.Message + " (and 1 more)"
+	case n.Count > 2:
+		return fmt.Sprintf("%s (and %d more)", n.Message, n.Count-1)
+	default:
+		return n.Message
+	}
+}
+
+// NotificationSettings maps event types to delivery modes
+type NotificationSettings map[string]string
+
+// NotificationSettingsResponse represents notification settings returned by
+// API. As an update, either part may be left out.
+type NotificationSettingsResponse struct {
+	NotificationSettings NotificationSettings `json:"notificationSettings"`
+	QuietHours           *QuietHours          `json:"quietHours,omitempty"`
+}
+
+// QuietHours is a daily window, in the user's timezone, during which
+// immediate notifications are held instead of emailed and pushed. Start and
+// End are "HH:MM"; a window whose end is before its start runs past midnight.
+// An empty Timezone follows the time zone in the user's settings.
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
+
+// DefaultQuietHours returns the quiet hours of a user who never set them:
+// off, with a night-time window ready to switch on
+func DefaultQuietHours() QuietHours {
+	return QuietHours{
+		Enabled:  false,
+		Start:    "22:00",
+		End:      "07:00",
+		Timezone: "",
+	}
+}
+
+// Active reports whether now falls within the quiet hours. fallback is the
+// user's own time zone, used when the quiet hours do not name one.
+func (q QuietHours) Active(now time.Time, fallback *time.Location) bool {
+	if !q.Enabled {
+		return false
+	}
+	start, okStart := parseClock(q.Start)
+	end, okEnd := parseClock(q.End)
+	if !okStart || !okEnd {
+		return false
+	}
+	location := fallback
+	if q.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(q.Timezone); err != nil {
+			return false
+		}
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// Validate validates quiet hours
+func (q QuietHours) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	start, okStart := parseClock(q.Start)
+	if !okStart {
+		errors = append(errors, ValidationError{
+			Field:   "quietHours.start",
+			Message: "start must be a time of day as HH:MM",
+		})
+	}
+	end, okEnd := parseClock(q.End)
+	if !okEnd {
+		errors = append(errors, ValidationError{
+			Field:   "quietHours.end",
+			Message: "end must be a time of day as HH:MM",
+		})
+	}
+	if okStart && okEnd && start == end {
+		errors = append(errors, ValidationError{
+			Field:   "quietHours.end",
+			Message: "end must differ from start",
+		})
+	}
+
+	if q.Timezone != "" && !IsValidTimezone(q.Timezone) {
+		errors = append(errors, ValidationError{
+			Field:   "quietHours.timezone",
+			Message: "timezone must be an IANA time zone such as Europe/Berlin",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// Validate validates a notification settings update, which must change the
+// delivery of at least one event type or set the quiet hours
+func (r *NotificationSettingsResponse) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if len(r.NotificationSettings) > 0 || r.QuietHours == nil {
+		if settingsErr := r.NotificationSettings.Validate(); settingsErr != nil {
+			errors = append(errors, settingsErr.Errors...)
+		}
+	}
+	if r.QuietHours != nil {
+		if quietErr := r.QuietHours.Validate(); quietErr != nil {
+			errors = append(errors, quietErr.Errors...)
+		}
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// parseClock returns the minute of the day of an "HH:MM" time
+func parseClock(clock string) (int, bool) {
+	hours, minutes, ok := strings.Cut(clock, ":")
+	if !ok || len(hours) != 2 || len(minutes) != 2 {
+		return 0, false
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 23 {
+		return 0, false
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+// DefaultNotificationSettings returns immediate delivery for every event type
+func DefaultNotificationSettings() NotificationSettings {
+	settings := make(NotificationSettings, len(NotificationEventTypes))
+	for _, eventType := range NotificationEventTypes {
+		settings[eventType] = DeliveryImmediate
+	}
+	return settings
+}
+
+// Delivery returns the delivery mode for an event type, defaulting to immediate
+func (s NotificationSettings) Delivery(eventType string) string {
+	if delivery, ok := s[eventType]; ok {
+		return delivery
+	}
+	return DeliveryImmediate
+}
+
+// Validate validates a (possibly partial) notification settings update
+func (s NotificationSettings) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if len(s) == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "notificationSettings",
+			Message: "at least one event type is required",
+		})
+	}
+
+	// Sort keys so error order is stable
+	eventTypes := make([]string, 0, len(s))
+	for eventType := range s {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	for _, eventType := range eventTypes {
+		if !IsValidNotificationEvent(eventType) {
+			errors = append(errors, ValidationError{
+				Field:   eventType,
+				Message: "unknown notification event type",
+			})
+		} else if !IsValidDeliveryMode(s[eventType]) {
+			errors = append(errors, ValidationError{
+				Field:   eventType,
+				Message: "delivery must be one of: immediate, daily_digest, off",
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// IsValidNotificationEvent checks if the event type is a known notification event
+func IsValidNotificationEvent(eventType string) bool {
+	for _, known := range NotificationEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidDeliveryMode checks if the delivery mode is supported
+func IsValidDeliveryMode(delivery string) bool {
+	return delivery == DeliveryImmediate || delivery == DeliveryDailyDigest || delivery == DeliveryOff
+}
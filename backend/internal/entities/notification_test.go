@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestDefaultNotificationSettings(t *testing.T) {
+	settings := DefaultNotificationSettings()
+
+	if len(settings) != len(NotificationEventTypes) {
+		t.Fatalf("Expected %d event types, got %d", len(NotificationEventTypes), len(settings))
+	}
+
+	for _, eventType := range NotificationEventTypes {
+		if settings[eventType] != DeliveryImmediate {
+			t.Errorf("Expected %s to default to immediate, got %q", eventType, settings[eventType])
+		}
+	}
+}
+
+func TestNotificationSettingsDelivery(t *testing.T) {
+	settings := NotificationSettings{EventNewComment: DeliveryOff}
+
+	if got := settings.Delivery(EventNewComment); got != DeliveryOff {
+		t.Errorf("Expected off, got %q", got)
+	}
+	if got := settings.Delivery(EventNewFollower); got != DeliveryImmediate {
+		t.Errorf("Expected unset event to fall back to immediate, got %q", got)
+	}
+}
+
+func TestNotificationSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings NotificationSettings
+		field    string
+	}{
+		{"Valid partial update", NotificationSettings{EventNewComment: DeliveryDailyDigest}, ""},
+		{"Empty", NotificationSettings{}, "notificationSettings"},
+		{"Unknown event", NotificationSettings{"unknown": DeliveryOff}, "unknown"},
+		{"Unknown delivery", NotificationSettings{EventNewFollower: "weekly"}, EventNewFollower},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Expected no validation error, got %v", err.Errors)
+				}
+				return
+			}
+			if err == nil || err.Errors[0].Field != tt.field {
+				t.Errorf("Expected validation error on %q, got %v", tt.field, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// nullFields returns the keys of a JSON object that are explicitly set to null.
+// Update requests use it to tell "clear this field" apart from "leave it alone".
+func nullFields(data []byte) ([]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var nulls []string
+	for key, value := range raw {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			nulls = append(nulls, key)
+		}
+	}
+	sort.Strings(nulls)
+	return nulls, nil
+}
+
+// nullFieldErrors reports null fields that are not in the clearable set
+func nullFieldErrors(nulls []string, clearable ...string) []ValidationError {
+	var errors []ValidationError
+	for _, field := range nulls {
+		if !containsField(clearable, field) {
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Message: field + " cannot be cleared",
+			})
+		}
+	}
+	return errors
+}
+
+// containsField reports whether field is in fields
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
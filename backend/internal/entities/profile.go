@@ -0,0 +1,106 @@
+package entities
+
+import "fmt"
+
+// MaxBulkFollow caps how many profiles a single bulk follow request may name
+const MaxBulkFollow = 50
+
+// Reasons a profile is suggested to a user
+const (
+	SuggestionReasonTag     = "tag"
+	SuggestionReasonPopular = "popular"
+)
+
+// Profile represents the public view of a user in profile lists
+type Profile struct {
+	Username string `json:"username"`
+	Bio      string `json:"bio"`
+	ImageURL string `json:"image"`
+}
+
+// ProfilesResponse represents a paginated list of profiles
+type ProfilesResponse struct {
+	Profiles      []Profile `json:"profiles"`
+	ProfilesCount int       `json:"profilesCount"`
+}
+
+// ProfileView represents a single profile as seen by the viewer
+type ProfileView struct {
+	Profile
+	Following bool `json:"following"`
+}
+
+// ProfileResponse represents single profile API response
+type ProfileResponse struct {
+	Profile ProfileView `json:"profile"`
+}
+
+// FavoritersResponse represents the users who favorited an article. Users who
+// hide their favorites are only counted in AnonymousCount.
+type FavoritersResponse struct {
+	Profiles       []Profile `json:"profiles"`
+	ProfilesCount  int       `json:"profilesCount"`
+	AnonymousCount int       `json:"anonymousCount"`
+}
+
+// ProfileSuggestion represents an author suggested to follow during onboarding
+type ProfileSuggestion struct {
+	Username       string `json:"username"`
+	Bio            string `json:"bio"`
+	ImageURL       string `json:"image"`
+	FollowersCount int    `json:"followersCount"`
+	ArticlesCount  int    `json:"articlesCount"`
+	Reason         string `json:"reason"`
+}
+
+// ProfileSuggestionsResponse represents profile suggestions API response
+type ProfileSuggestionsResponse struct {
+	Profiles []ProfileSuggestion `json:"profiles"`
+}
+
+// BulkFollow represents a request to follow or unfollow several profiles at once
+type BulkFollow struct {
+	Usernames []string `json:"usernames"`
+}
+
+// BulkFollowResponse reports the outcome of a bulk follow or unfollow request.
+// Changed lists profiles whose follow state changed; unchanged ones were
+// already in the requested state.
+type BulkFollowResponse struct {
+	Changed   []string `json:"changed"`
+	Unchanged []string `json:"unchanged"`
+	NotFound  []string `json:"notFound"`
+}
+
+// Normalize trims usernames and drops blanks and duplicates, keeping request order
+func (bf *BulkFollow) Normalize() {
+	seen := make(map[string]bool, len(bf.Usernames))
+	usernames := make([]string, 0, len(bf.Usernames))
+	for _, username := range bf.Usernames {
+		username = NormalizeUsername(username)
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	bf.Usernames = usernames
+}
+
+// Validate validates bulk follow data
+func (bf *BulkFollow) Validate() *ValidationErrors {
+	var message string
+	switch {
+	case len(bf.Usernames) == 0:
+		message = "at least one username is required"
+	case len(bf.Usernames) > MaxBulkFollow:
+		message = fmt.Sprintf("at most %d usernames can be followed at once", MaxBulkFollow)
+	default:
+		return nil
+	}
+
+	return &ValidationErrors{Errors: []ValidationError{{
+		Field:   "usernames",
+		Message: message,
+	}}}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBulkFollowNormalizeAndValidate(t *testing.T) {
+	follow := BulkFollow{Usernames: []string{" alice ", "bob", "", "alice", "carol"}}
+	follow.Normalize()
+
+	want := []string{"alice", "bob", "carol"}
+	if !reflect.DeepEqual(follow.Usernames, want) {
+		t.Errorf("Normalize() usernames = %v, want %v", follow.Usernames, want)
+	}
+	if err := follow.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	empty := BulkFollow{Usernames: []string{" ", ""}}
+	empty.Normalize()
+	if err := empty.Validate(); err == nil {
+		t.Error("Validate() with no usernames should fail")
+	}
+
+	tooMany := BulkFollow{}
+	for i := 0; i <= MaxBulkFollow; i++ {
+		tooMany.Usernames = append(tooMany.Usernames, fmt.Sprintf("user%d", i))
+	}
+	if err := tooMany.Validate(); err == nil {
+		t.Errorf("Validate() with %d usernames should fail", len(tooMany.Usernames))
+	}
+}
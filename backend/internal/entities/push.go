@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxPushSubscriptions caps how many browsers one user can subscribe
+const MaxPushSubscriptions = 10
+
+// PushEventTypes lists the notification events also sent as Web Push
+var PushEventTypes = []string{
+	EventNewComment,
+	EventNewFollower,
+	EventFollowedArticle,
+}
+
+// IsPushEvent reports whether notifications of the event type are pushed
+func IsPushEvent(eventType string) bool {
+	for _, pushed := range PushEventTypes {
+		if pushed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// PushSubscription represents a browser subscribed to a user's notifications
+type PushSubscription struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"-"`
+	Endpoint  string     `json:"endpoint"`
+	P256dh    string     `json:"-"`
+	Auth      string     `json:"-"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// IsExpired reports whether the browser said the subscription lapses before now
+func (s *PushSubscription) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !s.ExpiresAt.After(now)
+}
+
+// PushSubscriptionCreate is a browser PushSubscription in its toJSON() form
+type PushSubscriptionCreate struct {
+	Endpoint string `json:"endpoint"`
+	// ExpirationTime is in milliseconds since the epoch, or null
+	ExpirationTime *int64 `json:"expirationTime"`
+	Keys           struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// ExpiresAt returns the subscription's expiry, if the browser gave one
+func (sc *PushSubscriptionCreate) ExpiresAt() *time.Time {
+	if sc.ExpirationTime == nil {
+		return nil
+	}
+	expiresAt := time.UnixMilli(*sc.ExpirationTime)
+	return &expiresAt
+}
+
+// PushSubscriptionResponse represents single push subscription API response
+type PushSubscriptionResponse struct {
+	Subscription PushSubscription `json:"subscription"`
+}
+
+// PushSubscriptionsResponse represents push subscription list API response
+type PushSubscriptionsResponse struct {
+	Subscriptions []PushSubscription `json:"subscriptions"`
+}
+
+// PushKeyResponse carries the VAPID public key browsers subscribe with
+type PushKeyResponse struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// PushMessage is the JSON payload delivered to a subscribed browser
+type PushMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Link    string `json:"link"`
+}
+
+// DecodePushKey decodes a base64url key as sent by browsers, with or without padding
+func DecodePushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
+
+// Validate validates push subscription data
+func (sc *PushSubscriptionCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if endpoint, err := url.Parse(sc.Endpoint); err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		errors = append(errors, ValidationError{
+			Field:   "endpoint",
+			Message: "endpoint must be an https URL",
+		})
+	} else if len(sc.Endpoint) > 2048 {
+		errors = append(errors, ValidationError{
+			Field:   "endpoint",
+			Message: "endpoint must be less than 2048 characters long",
+		})
+	}
+
+	// p256dh is an uncompressed P-256 point
+	if key, err := DecodePushKey(sc.Keys.P256dh); err != nil || len(key) != 65 || key[0] != 0x04 {
+		errors = append(errors, ValidationError{
+			Field:   "keys.p256dh",
+			Message: "p256dh must be a base64url-encoded P-256 public key",
+		})
+	}
+
+	if secret, err := DecodePushKey(sc.Keys.Auth); err != nil || len(secret) != 16 {
+		errors = append(errors, ValidationError{
+			Field:   "keys.auth",
+			Message: "auth must be a base64url-encoded 16-byte secret",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
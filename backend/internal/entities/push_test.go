@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestPushSubscriptionCreate_Validate(t *testing.T) {
+	point := make([]byte, 65)
+	point[0] = 0x04
+	p256dh := base64.RawURLEncoding.EncodeToString(point)
+	auth := base64.URLEncoding.EncodeToString(make([]byte, 16)) // padding is accepted
+
+	tests := []struct {
+		name     string
+		endpoint string
+		p256dh   string
+		auth     string
+		wantErr  bool
+	}{
+		{"valid", "https://push.example.com/send/abc", p256dh, auth, false},
+		{"http endpoint", "http://push.example.com/send/abc", p256dh, auth, true},
+		{"missing endpoint", "", p256dh, auth, true},
+		{"short key", "https://push.example.com/send/abc", base64.RawURLEncoding.EncodeToString(point[:33]), auth, true},
+		{"short secret", "https://push.example.com/send/abc", p256dh, base64.RawURLEncoding.EncodeToString(make([]byte, 8)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := PushSubscriptionCreate{Endpoint: tt.endpoint}
+			create.Keys.P256dh = tt.p256dh
+			create.Keys.Auth = tt.auth
+			if err := create.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPushSubscription_IsExpired(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	if (&PushSubscription{}).IsExpired(now) {
+		t.Error("Subscriptions without an expiry never expire")
+	}
+	if !(&PushSubscription{ExpiresAt: &past}).IsExpired(now) {
+		t.Error("Subscription past its expiry should be expired")
+	}
+	if (&PushSubscription{ExpiresAt: &future}).IsExpired(now) {
+		t.Error("Subscription before its expiry should not be expired")
+	}
+}
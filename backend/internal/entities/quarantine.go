@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// QuarantineApproval records an admin releasing an account from quarantine early
+type QuarantineApproval struct {
+	ApprovedBy string    `json:"approvedBy,omitempty"`
+	ApprovedAt time.Time `json:"approvedAt"`
+}
+
+// QuarantineStatus reports whether a new account's articles are kept out of
+// listings, tags and feeds. Quarantined articles stay reachable by direct link.
+type QuarantineStatus struct {
+	Username    string `json:"username"`
+	Quarantined bool   `json:"quarantined"`
+	// Until is when the account ages out of quarantine; nil if it never entered it
+	Until    *time.Time          `json:"quarantinedUntil"`
+	Approval *QuarantineApproval `json:"approval"`
+}
+
+// NewQuarantineStatus reports the user's quarantine as of now for the given
+// quarantine window; a zero window quarantines no one
+func NewQuarantineStatus(user *User, window time.Duration, approval *QuarantineApproval, now time.Time) QuarantineStatus {
+	status := QuarantineStatus{Username: user.Username, Approval: approval}
+	if window > 0 {
+		until := user.CreatedAt.Add(window)
+		status.Until = &until
+		status.Quarantined = approval == nil && until.After(now)
+	}
+	return status
+}
+
+// QuarantineStatusResponse represents quarantine status API response
+type QuarantineStatusResponse struct {
+	Quarantine QuarantineStatus `json:"quarantine"`
+}
@@ -0,0 +1,107 @@
+package entities
+
+import "time"
+
+// Kinds of content covered by daily quotas
+const (
+	QuotaArticles = "articles"
+	QuotaComments = "comments"
+)
+
+// Quota tiers; staff accounts have no quota
+const (
+	QuotaTierNew         = "new"
+	QuotaTierEstablished = "established"
+	QuotaTierStaff       = "staff"
+)
+
+// DailyQuota caps how many articles and comments an account may create in any
+// 24 hours; zero means no cap
+type DailyQuota struct {
+	Articles int `json:"articlesPerDay"`
+	Comments int `json:"commentsPerDay"`
+}
+
+// Limit returns the cap for a kind of content
+func (q DailyQuota) Limit(kind string) int {
+	if kind == QuotaArticles {
+		return q.Articles
+	}
+	return q.Comments
+}
+
+// QuotaOverride replaces the tier's quota for one account. Nil fields keep
+// the tier's cap for that kind of content.
+type QuotaOverride struct {
+	Articles  *int      `json:"articlesPerDay"`
+	Comments  *int      `json:"commentsPerDay"`
+	Note      string    `json:"note,omitempty"`
+	SetBy     string    `json:"setBy,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Apply returns the quota with the override's caps in place of the tier's
+func (o *QuotaOverride) Apply(quota DailyQuota) DailyQuota {
+	if o == nil {
+		return quota
+	}
+	if o.Articles != nil {
+		quota.Articles = *o.Articles
+	}
+	if o.Comments != nil {
+		quota.Comments = *o.Comments
+	}
+	return quota
+}
+
+// QuotaStatus reports an account's effective quota and what it used in the last 24 hours
+type QuotaStatus struct {
+	Username string         `json:"username"`
+	Tier     string         `json:"tier"`
+	Quota    DailyQuota     `json:"quota"`
+	Used     DailyQuota     `json:"used"`
+	Override *QuotaOverride `json:"override"`
+}
+
+// QuotaStatusResponse represents quota status API response
+type QuotaStatusResponse struct {
+	Quota QuotaStatus `json:"quota"`
+}
+
+// MaxQuotaNoteLength caps the admin's note on a quota override
+const MaxQuotaNoteLength = 280
+
+// Validate validates a quota override
+func (o *QuotaOverride) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if o.Articles != nil && *o.Articles < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "articlesPerDay",
+			Message: "articlesPerDay must not be negative",
+		})
+	}
+	if o.Comments != nil && *o.Comments < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "commentsPerDay",
+			Message: "commentsPerDay must not be negative",
+		})
+	}
+	if o.Articles == nil && o.Comments == nil {
+		errors = append(errors, ValidationError{
+			Field:   "articlesPerDay",
+			Message: "set articlesPerDay, commentsPerDay or both",
+		})
+	}
+	if len(o.Note) > MaxQuotaNoteLength {
+		errors = append(errors, ValidationError{
+			Field:   "note",
+			Message: "note must be at most 280 characters long",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxReadingListItems caps how many articles one reading list can hold
+const MaxReadingListItems = 200
+
+// ReadingList represents a public, ordered collection of articles curated by
+// any user from any authors' work
+type ReadingList struct {
+	ID             int64     `json:"-"`
+	Slug           string    `json:"slug"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	OwnerID        int64     `json:"-"`
+	Owner          *Profile  `json:"owner,omitempty"`
+	Articles       []Article `json:"articles"`
+	FollowersCount int       `json:"followersCount"`
+	Following      bool      `json:"following"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// ReadingListCreate represents reading list creation request
+type ReadingListCreate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ReadingListItemAdd represents adding an article to a reading list
+type ReadingListItemAdd struct {
+	Slug string `json:"slug"`
+	// Position is 1-based; zero appends the article to the end
+	Position int `json:"position,omitempty"`
+}
+
+// ReadingListReorder lists every article slug in a reading list in its new order
+type ReadingListReorder struct {
+	Articles []string `json:"articles"`
+}
+
+// ReadingListResponse represents single reading list API response
+type ReadingListResponse struct {
+	ReadingList ReadingList `json:"readingList"`
+}
+
+// Validate validates reading list creation data
+func (rc *ReadingListCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if strings.TrimSpace(rc.Name) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name is required",
+		})
+	} else if len(rc.Name) > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name must be less than 100 characters long",
+		})
+	}
+
+	if len(rc.Description) > 1000 {
+		errors = append(errors, ValidationError{
+			Field:   "description",
+			Message: "description must be less than 1000 characters long",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// Validate validates reading list item data
+func (ra *ReadingListItemAdd) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if strings.TrimSpace(ra.Slug) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "slug",
+			Message: "slug is required",
+		})
+	}
+	if ra.Position < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "position",
+			Message: "position must not be negative",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// Validate validates reading list reorder data
+func (rr *ReadingListReorder) Validate() *ValidationErrors {
+	seen := make(map[string]bool, len(rr.Articles))
+	for _, slug := range rr.Articles {
+		if seen[slug] {
+			return &ValidationErrors{Errors: []ValidationError{{
+				Field:   "articles",
+				Message: "articles must not contain duplicates",
+			}}}
+		}
+		seen[slug] = true
+	}
+	return nil
+}
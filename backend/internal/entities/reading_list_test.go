@@ -0,0 +1,33 @@
+package entities
+
+import "testing"
+
+func TestReadingListCreate_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		create  ReadingListCreate
+		wantErr bool
+	}{
+		{"valid", ReadingListCreate{Name: "Weekend reads"}, false},
+		{"blank name", ReadingListCreate{Name: "   "}, true},
+		{"long name", ReadingListCreate{Name: string(make([]byte, 101))}, true},
+		{"long description", ReadingListCreate{Name: "Reads", Description: string(make([]byte, 1001))}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.create.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadingListReorder_Validate(t *testing.T) {
+	if err := (&ReadingListReorder{Articles: []string{"a", "b"}}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	if err := (&ReadingListReorder{Articles: []string{"a", "a"}}).Validate(); err == nil {
+		t.Error("Validate() should reject duplicate slugs")
+	}
+}
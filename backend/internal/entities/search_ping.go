@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// Search engines notified when articles are published or updated
+const (
+	// SearchPingIndexNow submits the article URL to an IndexNow endpoint
+	SearchPingIndexNow = "indexnow"
+	// SearchPingSitemap tells a search engine the sitemap changed
+	SearchPingSitemap = "sitemap"
+)
+
+// Search ping delivery states; SearchPingStatusAll only filters listings
+const (
+	SearchPingStatusPending   = "pending"
+	SearchPingStatusDelivered = "delivered"
+	SearchPingStatusFailed    = "failed"
+	SearchPingStatusAll       = "all"
+)
+
+// MaxSearchPingAttempts is how often a ping is tried before it is marked failed
+const MaxSearchPingAttempts = 5
+
+// SearchPing is one queued search engine notification
+type SearchPing struct {
+	ID     int64  `json:"id"`
+	Target string `json:"target"`
+	// URL is the article URL for IndexNow and the full ping URL for sitemaps
+	URL         string     `json:"url"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"lastError,omitempty"`
+	NextAttempt time.Time  `json:"nextAttemptAt"`
+	DeliveredAt *time.Time `json:"deliveredAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// SearchPingRetryDelay returns how long to wait after the given number of
+// failed attempts: one minute, doubling each time
+func SearchPingRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return time.Minute << uint(attempts-1)
+}
+
+// SearchPingsResponse represents a page of search pings
+type SearchPingsResponse struct {
+	SearchPings      []SearchPing `json:"searchPings"`
+	SearchPingsCount int          `json:"searchPingsCount"`
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Series represents an ordered collection of one author's articles
+type Series struct {
+	ID          int64     `json:"-"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	AuthorID    int64     `json:"-"`
+	Author      *Profile  `json:"author,omitempty"`
+	Articles    []Article `json:"articles"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// SeriesLink names a neighbouring article in a series
+type SeriesLink struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+// SeriesNavigation places an article within its series, counting only
+// published articles
+type SeriesNavigation struct {
+	Slug     string      `json:"slug"`
+	Title    string      `json:"title"`
+	Position int         `json:"position"`
+	Total    int         `json:"total"`
+	Previous *SeriesLink `json:"previous"`
+	Next     *SeriesLink `json:"next"`
+}
+
+// SeriesCreate represents series creation request
+type SeriesCreate struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// SeriesArticleAdd represents attaching an article to a series
+type SeriesArticleAdd struct {
+	Slug string `json:"slug"`
+	// Position is 1-based; zero appends the article to the end
+	Position int `json:"position,omitempty"`
+}
+
+// SeriesResponse represents single series API response
+type SeriesResponse struct {
+	Series Series `json:"series"`
+}
+
+// Validate validates series creation data
+func (sc *SeriesCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if strings.TrimSpace(sc.Title) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "title",
+			Message: "title is required",
+		})
+	} else if len(sc.Title) > 200 {
+		errors = append(errors, ValidationError{
+			Field:   "title",
+			Message: "title must be less than 200 characters long",
+		})
+	}
+
+	if len(sc.Description) > 1000 {
+		errors = append(errors, ValidationError{
+			Field:   "description",
+			Message: "description must be less than 1000 characters long",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// Validate validates series article data
+func (sa *SeriesArticleAdd) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	if strings.TrimSpace(sa.Slug) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "slug",
+			Message: "slug is required",
+		})
+	}
+	if sa.Position < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "position",
+			Message: "position must not be negative",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
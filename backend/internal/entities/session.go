@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Session is one login, typically one device, that access tokens are issued
+// for. Revoking it rejects every token issued for it.
+type Session struct {
+	ID        string `json:"id"`
+	UserID    int64  `json:"-"`
+	UserAgent string `json:"userAgent"`
+	// Device describes the user agent, e.g. "Firefox on Windows"
+	Device     string     `json:"device"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt time.Time  `json:"lastUsedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	RevokedAt  *time.Time `json:"-"`
+	// Current marks the session the request was made with
+	Current bool `json:"current"`
+}
+
+// IsActive reports whether tokens of the session are accepted at now
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(now)
+}
+
+// SessionsResponse represents the user's session list API response
+type SessionsResponse struct {
+	Sessions      []Session `json:"sessions"`
+	SessionsCount int       `json:"sessionsCount"`
+}
+
+// MaxUserAgentLength caps the user agent stored with a session
+const MaxUserAgentLength = 255
+
+// Browsers and operating systems DescribeDevice recognizes, checked in order
+// since user agents name the engines they are compatible with too
+var (
+	deviceBrowsers = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	}
+	deviceSystems = []struct{ token, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DescribeDevice names the browser and operating system of a user agent,
+// e.g. "Chrome on macOS", for users to recognize their sessions by
+func DescribeDevice(userAgent string) string {
+	browser, system := "", ""
+	for _, candidate := range deviceBrowsers {
+		if strings.Contains(userAgent, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+	for _, candidate := range deviceSystems {
+		if strings.Contains(userAgent, candidate.token) {
+			system = candidate.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return "Browser on " + system
+	default:
+		return "Unknown device"
+	}
+}
@@ -0,0 +1,204 @@
+package entities
+
+import "time"
+
+// Profile visibility levels
+const (
+	// VisibilityPublic profiles are visible to everyone
+	VisibilityPublic = "public"
+	// VisibilityMembers profiles are visible to signed-in users
+	VisibilityMembers = "members"
+	// VisibilityPrivate profiles are visible only to their owner
+	VisibilityPrivate = "private"
+)
+
+// SupportedLocales lists the languages emails are written in
+var SupportedLocales = []string{"en", "ko"}
+
+// DefaultLocale is used for users who never picked one
+const DefaultLocale = "en"
+
+// UserSettings holds a user's privacy settings
+type UserSettings struct {
+	// ProfileVisibility controls who can see the profile, its followers and its author feed
+	ProfileVisibility string `json:"profileVisibility"`
+	// ShowFavorites lists the user among an article's favoriters; when false
+	// their favorites are only counted
+	ShowFavorites bool `json:"showFavorites"`
+	// RecordReadingHistory allows analytics events to be recorded for the user
+	RecordReadingHistory bool `json:"recordReadingHistory"`
+	// Searchable lets the user appear in author suggestions and discovery lists
+	Searchable bool `json:"searchable"`
+	// Timezone is the IANA time zone digests are scheduled in and quiet hours
+	// are read in, unless they name their own
+	Timezone string `json:"timezone"`
+	// Locale is the language emails are written in
+	Locale string `json:"locale"`
+}
+
+// UserSettingsUpdate represents a partial settings update; omitted fields are left unchanged
+type UserSettingsUpdate struct {
+	ProfileVisibility    *string `json:"profileVisibility,omitempty"`
+	ShowFavorites        *bool   `json:"showFavorites,omitempty"`
+	RecordReadingHistory *bool   `json:"recordReadingHistory,omitempty"`
+	Searchable           *bool   `json:"searchable,omitempty"`
+	Timezone             *string `json:"timezone,omitempty"`
+	Locale               *string `json:"locale,omitempty"`
+}
+
+// UserSettingsResponse represents user settings API response
+type UserSettingsResponse struct {
+	Settings UserSettings `json:"settings"`
+}
+
+// UserSettingsUpdateRequest represents user settings update request
+type UserSettingsUpdateRequest struct {
+	Settings UserSettingsUpdate `json:"settings"`
+}
+
+// DefaultUserSettings returns the settings of a user who never changed them
+func DefaultUserSettings() UserSettings {
+	return UserSettings{
+		ProfileVisibility:    VisibilityPublic,
+		ShowFavorites:        true,
+		RecordReadingHistory: true,
+		Searchable:           true,
+		Timezone:             "UTC",
+		Locale:               DefaultLocale,
+	}
+}
+
+// Location returns the user's time zone, or UTC if it cannot be loaded
+func (s UserSettings) Location() *time.Location {
+	if location, err := time.LoadLocation(s.Timezone); err == nil {
+		return location
+	}
+	return time.UTC
+}
+
+// RestrictForMinor returns the settings with a minor's profile kept to
+// signed-in users and out of author suggestions and discovery lists
+func (s UserSettings) RestrictForMinor() UserSettings {
+	if s.ProfileVisibility == VisibilityPublic {
+		s.ProfileVisibility = VisibilityMembers
+	}
+	s.Searchable = false
+	return s
+}
+
+// ValidateForMinor rejects the settings RestrictForMinor turns off
+func (su *UserSettingsUpdate) ValidateForMinor() *ValidationErrors {
+	var errors []ValidationError
+	if su.ProfileVisibility != nil && *su.ProfileVisibility == VisibilityPublic {
+		errors = append(errors, ValidationError{
+			Field:   "profileVisibility",
+			Message: "public profiles are not available to minors",
+		})
+	}
+	if su.Searchable != nil && *su.Searchable {
+		errors = append(errors, ValidationError{
+			Field:   "searchable",
+			Message: "appearing in suggestions is not available to minors",
+		})
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// CanViewProfile reports whether the viewer may see the owner's profile.
+// viewerID is 0 for anonymous requests.
+func (s UserSettings) CanViewProfile(viewerID, ownerID int64) bool {
+	switch s.ProfileVisibility {
+	case VisibilityMembers:
+		return viewerID != 0
+	case VisibilityPrivate:
+		return viewerID == ownerID
+	default:
+		return true
+	}
+}
+
+// Apply returns the settings with the update's fields replaced
+func (su *UserSettingsUpdate) Apply(settings UserSettings) UserSettings {
+	if su.ProfileVisibility != nil {
+		settings.ProfileVisibility = *su.ProfileVisibility
+	}
+	if su.ShowFavorites != nil {
+		settings.ShowFavorites = *su.ShowFavorites
+	}
+	if su.RecordReadingHistory != nil {
+		settings.RecordReadingHistory = *su.RecordReadingHistory
+	}
+	if su.Searchable != nil {
+		settings.Searchable = *su.Searchable
+	}
+	if su.Timezone != nil {
+		settings.Timezone = *su.Timezone
+	}
+	if su.Locale != nil {
+		settings.Locale = *su.Locale
+	}
+	return settings
+}
+
+// Validate validates a user settings update
+func (su *UserSettingsUpdate) Validate() *ValidationErrors {
+	if su.ProfileVisibility == nil && su.ShowFavorites == nil && su.RecordReadingHistory == nil && su.Searchable == nil &&
+		su.Timezone == nil && su.Locale == nil {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "settings",
+			Message: "at least one setting is required",
+		}}}
+	}
+
+	if su.ProfileVisibility != nil && !IsValidProfileVisibility(*su.ProfileVisibility) {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "profileVisibility",
+			Message: "profileVisibility must be one of: public, members, private",
+		}}}
+	}
+
+	if su.Timezone != nil && !IsValidTimezone(*su.Timezone) {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "timezone",
+			Message: "timezone must be an IANA time zone such as Europe/Berlin",
+		}}}
+	}
+
+	if su.Locale != nil && !IsSupportedLocale(*su.Locale) {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "locale",
+			Message: "locale must be one of: en, ko",
+		}}}
+	}
+
+	return nil
+}
+
+// IsValidTimezone checks if the time zone is a known IANA zone name. Local is
+// refused, as it would mean the server's zone.
+func IsValidTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
+// IsSupportedLocale checks if emails can be written in the locale
+func IsSupportedLocale(locale string) bool {
+	for _, supported := range SupportedLocales {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidProfileVisibility checks if the profile visibility level is supported
+func IsValidProfileVisibility(visibility string) bool {
+	return visibility == VisibilityPublic || visibility == VisibilityMembers || visibility == VisibilityPrivate
+}
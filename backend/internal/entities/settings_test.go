@@ -0,0 +1,61 @@
+package entities
+
+import "testing"
+
+func TestUserSettingsCanViewProfile(t *testing.T) {
+	const owner, member, anonymous = 1, 2, 0
+
+	tests := []struct {
+		visibility string
+		viewer     int64
+		want       bool
+	}{
+		{VisibilityPublic, anonymous, true},
+		{VisibilityMembers, anonymous, false},
+		{VisibilityMembers, member, true},
+		{VisibilityPrivate, member, false},
+		{VisibilityPrivate, owner, true},
+	}
+
+	for _, tt := range tests {
+		settings := DefaultUserSettings()
+		settings.ProfileVisibility = tt.visibility
+		if got := settings.CanViewProfile(tt.viewer, owner); got != tt.want {
+			t.Errorf("CanViewProfile(%s, viewer %d) = %v, want %v", tt.visibility, tt.viewer, got, tt.want)
+		}
+	}
+}
+
+func TestUserSettingsUpdate(t *testing.T) {
+	empty := UserSettingsUpdate{}
+	if err := empty.Validate(); err == nil {
+		t.Error("Validate() with no settings should fail")
+	}
+
+	invalid := "friends"
+	if err := (&UserSettingsUpdate{ProfileVisibility: &invalid}).Validate(); err == nil {
+		t.Error("Validate() with unknown visibility should fail")
+	}
+
+	for _, timezone := range []string{"Mars/Olympus", "Local", ""} {
+		if err := (&UserSettingsUpdate{Timezone: &timezone}).Validate(); err == nil {
+			t.Errorf("Validate() with timezone %q should fail", timezone)
+		}
+	}
+	unsupported := "fr"
+	if err := (&UserSettingsUpdate{Locale: &unsupported}).Validate(); err == nil {
+		t.Error("Validate() with unsupported locale should fail")
+	}
+
+	private, off := VisibilityPrivate, false
+	update := UserSettingsUpdate{ProfileVisibility: &private, Searchable: &off}
+	if err := update.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	got := update.Apply(DefaultUserSettings())
+	want := UserSettings{ProfileVisibility: VisibilityPrivate, ShowFavorites: true, RecordReadingHistory: true, Searchable: false, Timezone: "UTC", Locale: DefaultLocale}
+	if got != want {
+		t.Errorf("Apply() = %+v, want %+v", got, want)
+	}
+}
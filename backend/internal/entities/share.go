@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Share mediums an article can be shared through
+const (
+	ShareTwitter  = "twitter"
+	ShareFacebook = "facebook"
+	ShareLinkedIn = "linkedin"
+	ShareReddit   = "reddit"
+	ShareEmail    = "email"
+	// ShareCopy is a link copied to the clipboard
+	ShareCopy = "copy"
+)
+
+// ShareMediums lists the share mediums in the order links are offered
+var ShareMediums = []string{ShareTwitter, ShareFacebook, ShareLinkedIn, ShareReddit, ShareEmail, ShareCopy}
+
+// shareMediumCodes are the one-letter codes closing short link codes
+var shareMediumCodes = map[string]byte{
+	ShareTwitter:  't',
+	ShareFacebook: 'f',
+	ShareLinkedIn: 'l',
+	ShareReddit:   'r',
+	ShareEmail:    'e',
+	ShareCopy:     'c',
+}
+
+// ShareLink is a short link to an article for one medium. Following it counts
+// a click and redirects to Destination, which carries the UTM parameters.
+type ShareLink struct {
+	Medium      string `json:"medium"`
+	URL         string `json:"url"`
+	Destination string `json:"destination"`
+}
+
+// ShareLinksResponse represents share links API response
+type ShareLinksResponse struct {
+	ShareLinks []ShareLink `json:"shareLinks"`
+}
+
+// ArticleShareStats counts the clicks on an article's short links from one medium
+type ArticleShareStats struct {
+	Medium string `json:"medium"`
+	Clicks int    `json:"clicks"`
+}
+
+// ShareCode returns the short link code of an article for medium: the article
+// ID in base 36 followed by the medium's letter
+func ShareCode(articleID int64, medium string) string {
+	return strconv.FormatInt(articleID, 36) + string(shareMediumCodes[medium])
+}
+
+// ParseShareCode returns the article ID and medium a short link code stands
+// for, or false if it is not one
+func ParseShareCode(code string) (int64, string, bool) {
+	if len(code) < 2 || len(code) > 14 {
+		return 0, "", false
+	}
+
+	id, err := strconv.ParseInt(code[:len(code)-1], 36, 64)
+	if err != nil || id <= 0 {
+		return 0, "", false
+	}
+	for medium, letter := range shareMediumCodes {
+		if code[len(code)-1] == letter {
+			return id, medium, true
+		}
+	}
+	return 0, "", false
+}
+
+// ShareDestination returns the article's page on siteURL tagged with the UTM
+// parameters of medium
+func ShareDestination(siteURL, slug, medium string) string {
+	source, channel := medium, "social"
+	switch medium {
+	case ShareEmail:
+		channel = "email"
+	case ShareCopy:
+		source, channel = "share", "link"
+	}
+
+	query := url.Values{}
+	query.Set("utm_source", source)
+	query.Set("utm_medium", channel)
+	query.Set("utm_campaign", "article_share")
+	return strings.TrimRight(siteURL, "/") + "/article/" + url.PathEscape(slug) + "?" + query.Encode()
+}
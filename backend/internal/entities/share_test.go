@@ -0,0 +1,38 @@
+package entities
+
+import "testing"
+
+func TestShareCode_RoundTrip(t *testing.T) {
+	for _, medium := range ShareMediums {
+		for _, id := range []int64{1, 35, 36, 123456789} {
+			code := ShareCode(id, medium)
+			gotID, gotMedium, ok := ParseShareCode(code)
+			if !ok || gotID != id || gotMedium != medium {
+				t.Errorf("ParseShareCode(%q) = %d, %q, %v, want %d, %q", code, gotID, gotMedium, ok, id, medium)
+			}
+		}
+	}
+
+	for _, code := range []string{"", "t", "1x", "-1t", "0t", "zzzzzzzzzzzzzzt"} {
+		if _, _, ok := ParseShareCode(code); ok {
+			t.Errorf("Expected %q not to parse", code)
+		}
+	}
+}
+
+func TestShareDestination(t *testing.T) {
+	tests := []struct {
+		medium string
+		want   string
+	}{
+		{ShareTwitter, "https://blog.example.com/article/hello-world?utm_campaign=article_share&utm_medium=social&utm_source=twitter"},
+		{ShareEmail, "https://blog.example.com/article/hello-world?utm_campaign=article_share&utm_medium=email&utm_source=email"},
+		{ShareCopy, "https://blog.example.com/article/hello-world?utm_campaign=article_share&utm_medium=link&utm_source=share"},
+	}
+
+	for _, tt := range tests {
+		if got := ShareDestination("https://blog.example.com/", "hello-world", tt.medium); got != tt.want {
+			t.Errorf("ShareDestination(%s) = %s, want %s", tt.medium, got, tt.want)
+		}
+	}
+}
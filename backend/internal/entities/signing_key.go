@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// Algorithms access tokens can be signed with. HS256 uses the shared
+// JWT_SECRET; the asymmetric algorithms use rotating keys whose public halves
+// are published so other services can validate tokens.
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey is an asymmetric key pair access tokens are signed with. Only
+// the newest key signs; retired keys still verify the tokens they signed
+// until those expire.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey []byte // PKCS #8, DER encoded, then encrypted
+	CreatedAt  time.Time
+	RetiredAt  *time.Time
+}
+
+// JSONWebKey is the public half of a signing key as published in the JWKS
+// (RFC 7517); RSA keys set N and E, Ed25519 keys set Curve and X
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet represents the JWKS document
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// IsValidJWTAlgorithm checks if access tokens can be signed with the algorithm
+func IsValidJWTAlgorithm(algorithm string) bool {
+	return algorithm == JWTAlgorithmHS256 || algorithm == JWTAlgorithmRS256 || algorithm == JWTAlgorithmEdDSA
+}
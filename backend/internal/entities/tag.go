@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Tag represents a canonical tag in the system
+type Tag struct {
+	ID            int64    `json:"-"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	ArticlesCount int      `json:"articlesCount"`
+	Aliases       []string `json:"aliases"`
+}
+
+// TagAuthor represents an author ranked by number of articles under a tag
+type TagAuthor struct {
+	Username      string `json:"username"`
+	Bio           string `json:"bio"`
+	ImageURL      string `json:"image"`
+	ArticlesCount int    `json:"articlesCount"`
+}
+
+// TagDetail represents the data behind a tag landing page
+type TagDetail struct {
+	Tag
+	TopAuthors     []TagAuthor `json:"topAuthors"`
+	RecentArticles []Article   `json:"recentArticles"`
+}
+
+// TagDetailResponse represents tag detail API response
+type TagDetailResponse struct {
+	Tag TagDetail `json:"tag"`
+}
+
+// TagDescriptionUpdate represents tag description update request
+type TagDescriptionUpdate struct {
+	Description string `json:"description"`
+}
+
+// MaxArticleTags caps how many tags a single article may carry
+const MaxArticleTags = 10
+
+// TagsResponse represents the tags listing API response
+type TagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// TagResponse represents single tag API response
+type TagResponse struct {
+	Tag Tag `json:"tag"`
+}
+
+// TagListResponse represents detailed tags API response used by moderation endpoints
+type TagListResponse struct {
+	Tags []Tag `json:"tags"`
+}
+
+// TagRename represents tag rename request
+type TagRename struct {
+	Name string `json:"name"`
+}
+
+// TagMerge represents tag merge request; the path tag is merged into Into
+type TagMerge struct {
+	Into string `json:"into"`
+}
+
+// TagAliasCreate represents alias creation request
+type TagAliasCreate struct {
+	Alias string `json:"alias"`
+}
+
+// Validate validates tag rename data
+func (tr *TagRename) Validate() *ValidationErrors {
+	return validateTagNameField("name", tr.Name)
+}
+
+// Validate validates tag merge data
+func (tm *TagMerge) Validate() *ValidationErrors {
+	return validateTagNameField("into", tm.Into)
+}
+
+// Validate validates alias creation data
+func (ta *TagAliasCreate) Validate() *ValidationErrors {
+	return validateTagNameField("alias", ta.Alias)
+}
+
+// Validate validates tag description update data
+func (td *TagDescriptionUpdate) Validate() *ValidationErrors {
+	if len(td.Description) > 1000 {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   "description",
+			Message: "description must be less than 1000 characters long",
+		}}}
+	}
+	return nil
+}
+
+// NormalizeTagName converts a tag name to its stored form (trimmed, lowercase)
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// IsValidTagName checks if a normalized tag name has an acceptable format
+func IsValidTagName(name string) bool {
+	if name == "" || len(name) > 50 {
+		return false
+	}
+
+	// Letters and numbers, plus a few separators common in tech tags (c++, c#, node.js)
+	re := regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}\-_.+#]*$`)
+	return re.MatchString(name)
+}
+
+// validateTagNameField validates a single tag name field
+func validateTagNameField(field, value string) *ValidationErrors {
+	name := NormalizeTagName(value)
+	if name == "" {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   field,
+			Message: field + " is required",
+		}}}
+	}
+
+	if !IsValidTagName(name) {
+		return &ValidationErrors{Errors: []ValidationError{{
+			Field:   field,
+			Message: field + " must be at most 50 characters of letters, numbers, '-', '_', '.', '+' or '#'",
+		}}}
+	}
+
+	return nil
+}
+
+// validateTagList validates the tags sent with an article
+func validateTagList(tags []string) []ValidationError {
+	if len(tags) > MaxArticleTags {
+		return []ValidationError{{
+			Field:   "tagList",
+			Message: "tagList must have at most 10 tags",
+		}}
+	}
+
+	for _, tag := range tags {
+		if !IsValidTagName(NormalizeTagName(tag)) {
+			return []ValidationError{{
+				Field:   "tagList",
+				Message: "tags must be at most 50 characters of letters, numbers, '-', '_', '.', '+' or '#'",
+			}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Go", "go"},
+		{"  GoLang  ", "golang"},
+		{"C++", "c++"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTagName(tt.input); got != tt.expected {
+			t.Errorf("NormalizeTagName(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestIsValidTagName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"Simple", "go", true},
+		{"With dots", "node.js", true},
+		{"With plus", "c++", true},
+		{"With hash", "c#", true},
+		{"Unicode", "한글", true},
+		{"Empty", "", false},
+		{"Leading separator", "-go", false},
+		{"Contains space", "go lang", false},
+		{"Too long", generateLongString(51), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidTagName(tt.input); got != tt.valid {
+				t.Errorf("IsValidTagName(%q) = %v, want %v", tt.input, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestTagRenameValidate(t *testing.T) {
+	valid := TagRename{Name: "Go"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected no validation error, got %v", err.Errors)
+	}
+
+	empty := TagRename{Name: "   "}
+	err := empty.Validate()
+	if err == nil || err.Errors[0].Field != "name" {
+		t.Errorf("Expected name validation error, got %v", err)
+	}
+
+	merge := TagMerge{Into: "not valid"}
+	if err := merge.Validate(); err == nil || err.Errors[0].Field != "into" {
+		t.Errorf("Expected into validation error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TOCEntry is one heading in an article's table of contents
+type TOCEntry struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+var (
+	// atxHeadingRegex matches "## Heading" lines, including an optional closing run of #
+	atxHeadingRegex = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	// fenceRegex matches the opening or closing line of a fenced code block
+	fenceRegex = regexp.MustCompile("^ {0,3}(```|~~~)")
+	// inlineLinkRegex matches Markdown links and images so only their text is kept
+	inlineLinkRegex = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+)
+
+// ExtractTOC lists the Markdown headings of body in order. Headings inside
+// fenced code blocks are skipped. Anchors follow GitHub's scheme (lowercase,
+// punctuation dropped, spaces as hyphens, -1, -2... for repeats) so renderers
+// that generate heading IDs the same way link up.
+func ExtractTOC(body string) []TOCEntry {
+	var entries []TOCEntry
+	seen := make(map[string]int)
+	fence := ""
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if match := fenceRegex.FindStringSubmatch(line); match != nil {
+			if fence == "" {
+				fence = match[1]
+			} else if fence == match[1] {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		match := atxHeadingRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		text := headingText(match[2])
+		if text == "" {
+			continue
+		}
+
+		anchor := headingAnchor(text)
+		if count := seen[anchor]; count > 0 {
+			seen[anchor] = count + 1
+			anchor += "-" + strconv.Itoa(count)
+		} else {
+			seen[anchor] = 1
+		}
+
+		entries = append(entries, TOCEntry{
+			Level:  len(match[1]),
+			Text:   text,
+			Anchor: anchor,
+		})
+	}
+
+	return entries
+}
+
+// headingText strips inline Markdown (links, emphasis, code) from a heading
+func headingText(raw string) string {
+	text := inlineLinkRegex.ReplaceAllString(raw, "$1")
+	text = strings.NewReplacer("**", "", "__", "", "*", "", "`", "").Replace(text)
+	return strings.TrimSpace(text)
+}
+
+// headingAnchor turns heading text into a URL fragment
+func headingAnchor(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTOC(t *testing.T) {
+	body := "Intro\n\n" +
+		"# Getting Started #\n" +
+		"## Install the `cli`\r\n" +
+		"```sh\n# not a heading\n```\n" +
+		"## [Config](https://example.com) & **Flags**\n" +
+		"### 설치 방법\n" +
+		"## Getting Started\n" +
+		"#NoSpace\n" +
+		"####### Too deep\n"
+
+	want := []TOCEntry{
+		{Level: 1, Text: "Getting Started", Anchor: "getting-started"},
+		{Level: 2, Text: "Install the cli", Anchor: "install-the-cli"},
+		{Level: 2, Text: "Config & Flags", Anchor: "config--flags"},
+		{Level: 3, Text: "설치 방법", Anchor: "설치-방법"},
+		{Level: 2, Text: "Getting Started", Anchor: "getting-started-1"},
+	}
+
+	if got := ExtractTOC(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractTOC() = %+v, want %+v", got, want)
+	}
+	if got := ExtractTOC("No headings here"); got != nil {
+		t.Errorf("Expected no entries, got %+v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultArticleLanguage is the language assigned to articles created without one
+const DefaultArticleLanguage = "en"
+
+// ArticleTranslation represents a linked language variant of an article
+type ArticleTranslation struct {
+	Language string `json:"language"`
+	Slug     string `json:"slug"`
+	Title    string `json:"title"`
+}
+
+// ArticleTranslationCreate represents translation creation request
+type ArticleTranslationCreate struct {
+	Language    string `json:"language"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+}
+
+// Validate validates translation creation data
+func (tc *ArticleTranslationCreate) Validate() *ValidationErrors {
+	var errors []ValidationError
+
+	// Language validation
+	if tc.Language == "" {
+		errors = append(errors, ValidationError{
+			Field:   "language",
+			Message: "language is required",
+		})
+	} else if !IsValidLanguageCode(tc.Language) {
+		errors = append(errors, ValidationError{
+			Field:   "language",
+			Message: "language must be a language code like 'en' or 'pt-BR'",
+		})
+	}
+
+	// Title, description and body follow the same rules as a new article
+	content := ArticleCreate{
+		Title:       tc.Title,
+		Description: tc.Description,
+		Body:        tc.Body,
+	}
+	if contentErr := content.Validate(); contentErr != nil {
+		errors = append(errors, contentErr.Errors...)
+	}
+
+	if len(errors) > 0 {
+		return &ValidationErrors{Errors: errors}
+	}
+	return nil
+}
+
+// languageCodePattern matches a normalized language tag
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// IsValidLanguageCode checks for a simple language tag such as "en", "ko" or
+// "pt-BR", in any case since codes are stored normalized
+func IsValidLanguageCode(code string) bool {
+	return languageCodePattern.MatchString(NormalizeLanguageCode(code))
+}
+
+// NormalizeLanguageCode lowercases the language part and uppercases the region part
+func NormalizeLanguageCode(code string) string {
+	code = strings.TrimSpace(code)
+	parts := strings.SplitN(strings.ReplaceAll(code, "_", "-"), "-", 2)
+	if len(parts) == 2 {
+		return strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+	}
+	return strings.ToLower(parts[0])
+}
+
+// NegotiateLanguage picks the best available language for an Accept-Language header.
+// It returns an empty string when none of the requested languages are available.
+func NegotiateLanguage(acceptLanguage string, available []string) string {
+	type preference struct {
+		language string
+		quality  float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		language := strings.TrimSpace(fields[0])
+		if language == "" || language == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		preferences = append(preferences, preference{
+			language: NormalizeLanguageCode(language),
+			quality:  quality,
+		})
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	for _, pref := range preferences {
+		// Exact match first (pt-BR == pt-BR)
+		for _, candidate := range available {
+			if candidate == pref.language {
+				return candidate
+			}
+		}
+
+		// Then match on the primary language (pt-BR -> pt, pt -> pt-BR)
+		primary := strings.SplitN(pref.language, "-", 2)[0]
+		for _, candidate := range available {
+			if strings.SplitN(candidate, "-", 2)[0] == primary {
+				return candidate
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestArticleTranslationCreateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ArticleTranslationCreate
+		wantErr bool
+		field   string
+	}{
+		{
+			name: "Valid translation",
+			input: ArticleTranslationCreate{
+				Language:    "ko",
+				Title:       "안녕하세요",
+				Description: "설명",
+				Body:        "본문",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Missing language",
+			input: ArticleTranslationCreate{
+				Title:       "Hola",
+				Description: "Descripción",
+				Body:        "Cuerpo",
+			},
+			wantErr: true,
+			field:   "language",
+		},
+		{
+			name: "Invalid language code",
+			input: ArticleTranslationCreate{
+				Language:    "spanish",
+				Title:       "Hola",
+				Description: "Descripción",
+				Body:        "Cuerpo",
+			},
+			wantErr: true,
+			field:   "language",
+		},
+		{
+			name: "Language code in another case",
+			input: ArticleTranslationCreate{
+				Language:    "pt-br",
+				Title:       "Olá",
+				Description: "Descrição",
+				Body:        "Corpo",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Missing title",
+			input: ArticleTranslationCreate{
+				Language:    "es",
+				Description: "Descripción",
+				Body:        "Cuerpo",
+			},
+			wantErr: true,
+			field:   "title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected validation error for field %s, got none", tt.field)
+				}
+				found := false
+				for _, e := range err.Errors {
+					if e.Field == tt.field {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Expected error for field %s, got %v", tt.field, err.Errors)
+				}
+			} else if err != nil {
+				t.Errorf("Expected no validation error, got %v", err.Errors)
+			}
+		})
+	}
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	available := []string{"en", "ko", "pt-BR"}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"Exact match", "ko", "ko"},
+		{"Quality ordering", "fr;q=0.9, ko;q=0.8, en;q=0.5", "ko"},
+		{"Higher quality wins regardless of order", "en;q=0.3, ko", "ko"},
+		{"Primary language fallback", "pt", "pt-BR"},
+		{"Region fallback to primary", "ko-KR", "ko"},
+		{"Case insensitive", "PT-br", "pt-BR"},
+		{"No match", "fr, de", ""},
+		{"Zero quality ignored", "ko;q=0, en", "en"},
+		{"Wildcard ignored", "*", ""},
+		{"Empty header", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NegotiateLanguage(tt.acceptLanguage, available); got != tt.expected {
+				t.Errorf("NegotiateLanguage(%q) = %q, want %q", tt.acceptLanguage, got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"regexp"
 	"strings"
 	"time"
@@ -14,17 +15,63 @@ type User struct {
 	Bio      string `json:"bio"`
 	ImageURL string `json:"image"`
 	
+	// Following is set on article authors for signed-in viewers
+	Following bool `json:"following"`
+
 	// Internal fields (not exposed in API)
-	PasswordHash string    `json:"-"`
-	CreatedAt    time.Time `json:"-"`
-	UpdatedAt    time.Time `json:"-"`
+	Role             string     `json:"-"`
+	ModerationStatus string     `json:"-"`
+	PasswordHash     string     `json:"-"`
+	CreatedAt        time.Time  `json:"-"`
+	UpdatedAt        time.Time  `json:"-"`
+	DeactivatedAt    *time.Time `json:"-"`
+	// LockedUntil is set while repeated failed logins keep the account locked
+	LockedUntil *time.Time `json:"-"`
 }
 
+// User roles
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Token scopes; a token with a scope claim may only call routes needing one
+// of its scopes, and a token without one may call any route
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// Moderation statuses admins can apply to abusive accounts
+const (
+	ModerationNone = "none"
+	// ModerationRateLimited accounts always get the new-account comment limits
+	ModerationRateLimited = "rate_limited"
+	// ModerationShadowBanned accounts can still post, but their content is
+	// hidden from listings
+	ModerationShadowBanned = "shadow_banned"
+	// ModerationBanned accounts cannot log in and their content is hidden from listings
+	ModerationBanned = "banned"
+)
+
 // UserRegistration represents user registration request
 type UserRegistration struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Invite is the invite code, required while registration is invite-only
+	Invite string `json:"invite,omitempty"`
+	// AgeConfirmed is the user's statement that they meet the deployment's
+	// minimum age, required while one is configured
+	AgeConfirmed bool `json:"ageConfirmed,omitempty"`
+	// Birthdate is a YYYY-MM-DD date, required while the deployment verifies
+	// ages; it is stored encrypted
+	Birthdate string `json:"birthdate,omitempty"`
+
+	// Network is the salted hash of the client's IP, set by the server
+	Network string `json:"-"`
 }
 
 // UserLogin represents user login request
@@ -33,13 +80,40 @@ type UserLogin struct {
 	Password string `json:"password"`
 }
 
-// UserUpdate represents user update request
+// UserUpdate represents user update request. Omitted fields are left
+// unchanged; bio and image can be cleared by sending null.
 type UserUpdate struct {
 	Username *string `json:"username,omitempty"`
 	Email    *string `json:"email,omitempty"`
 	Bio      *string `json:"bio,omitempty"`
 	ImageURL *string `json:"image,omitempty"`
 	Password *string `json:"password,omitempty"`
+
+	// NullFields lists the JSON fields explicitly sent as null
+	NullFields []string `json:"-"`
+}
+
+// UnmarshalJSON decodes the update and records which fields were sent as null
+func (uu *UserUpdate) UnmarshalJSON(data []byte) error {
+	type plain UserUpdate
+	var update plain
+	if err := json.Unmarshal(data, &update); err != nil {
+		return err
+	}
+
+	nulls, err := nullFields(data)
+	if err != nil {
+		return err
+	}
+
+	*uu = UserUpdate(update)
+	uu.NullFields = nulls
+	return nil
+}
+
+// Clears reports whether the update explicitly clears the given JSON field
+func (uu *UserUpdate) Clears(field string) bool {
+	return containsField(uu.NullFields, field)
 }
 
 // UserResponse represents user data returned by API
@@ -56,6 +130,33 @@ type UserData struct {
 	Token    string `json:"token"`
 }
 
+// Availability reports whether a username or email can be used to register
+type Availability struct {
+	Value     string `json:"value"`
+	Available bool   `json:"available"`
+	Message   string `json:"message,omitempty"`
+}
+
+// AvailabilityResponse represents the registration availability check API response;
+// only the fields that were asked about are present
+type AvailabilityResponse struct {
+	Username *Availability `json:"username,omitempty"`
+	Email    *Availability `json:"email,omitempty"`
+}
+
+// AccountDeactivation represents an account deactivation request; the
+// password is required again so a stolen token cannot lock the owner out
+type AccountDeactivation struct {
+	Password string `json:"password"`
+}
+
+// AccountDeactivationResponse reports when a deactivated account stops being
+// reactivatable by logging in
+type AccountDeactivationResponse struct {
+	DeactivatedAt    time.Time `json:"deactivatedAt"`
+	ReactivateBefore time.Time `json:"reactivateBefore"`
+}
+
 // ValidationError represents validation errors
 type ValidationError struct {
 	Field   string `json:"field"`
@@ -75,43 +176,66 @@ func (ve *ValidationErrors) Error() string {
 	return strings.Join(messages, ", ")
 }
 
+// Normalize applies the canonical form registration stores: surrounding
+// whitespace is trimmed and emails are lowercased
+func (ur *UserRegistration) Normalize() {
+	ur.Username = NormalizeUsername(ur.Username)
+	ur.Email = NormalizeEmail(ur.Email)
+}
+
+// NormalizeUsername returns the canonical form of a username
+func NormalizeUsername(username string) string {
+	return strings.TrimSpace(username)
+}
+
+// NormalizeEmail returns the canonical form of an email address
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateUsername returns a validation message for a username, or "" if it is valid
+func ValidateUsername(username string) string {
+	switch {
+	case username == "":
+		return "username is required"
+	case len(username) < 3:
+		return "username must be at least 3 characters long"
+	case len(username) > 50:
+		return "username must be less than 50 characters long"
+	case !isValidUsername(username):
+		return "username can only contain letters, numbers, and underscores"
+	}
+	return ""
+}
+
+// ValidateEmail returns a validation message for an email address, or "" if it is valid
+func ValidateEmail(email string) string {
+	switch {
+	case email == "":
+		return "email is required"
+	case !isValidEmail(email):
+		return "email format is invalid"
+	}
+	return ""
+}
+
 // Validate validates user registration data
 func (ur *UserRegistration) Validate() *ValidationErrors {
 	var errors []ValidationError
 
 	// Username validation
-	if ur.Username == "" {
+	if message := ValidateUsername(ur.Username); message != "" {
 		errors = append(errors, ValidationError{
 			Field:   "username",
-			Message: "username is required",
-		})
-	} else if len(ur.Username) < 3 {
-		errors = append(errors, ValidationError{
-			Field:   "username",
-			Message: "username must be at least 3 characters long",
-		})
-	} else if len(ur.Username) > 50 {
-		errors = append(errors, ValidationError{
-			Field:   "username",
-			Message: "username must be less than 50 characters long",
-		})
-	} else if !isValidUsername(ur.Username) {
-		errors = append(errors, ValidationError{
-			Field:   "username",
-			Message: "username can only contain letters, numbers, and underscores",
+			Message: message,
 		})
 	}
 
 	// Email validation
-	if ur.Email == "" {
+	if message := ValidateEmail(ur.Email); message != "" {
 		errors = append(errors, ValidationError{
 			Field:   "email",
-			Message: "email is required",
-		})
-	} else if !isValidEmail(ur.Email) {
-		errors = append(errors, ValidationError{
-			Field:   "email",
-			Message: "email format is invalid",
+			Message: message,
 		})
 	}
 
@@ -133,12 +257,46 @@ func (ur *UserRegistration) Validate() *ValidationErrors {
 		})
 	}
 
+	if ur.Birthdate != "" {
+		if birthdate, err := ParseBirthdate(ur.Birthdate); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "birthdate",
+				Message: "birthdate must be a date as YYYY-MM-DD",
+			})
+		} else if !birthdate.Before(time.Now()) {
+			errors = append(errors, ValidationError{
+				Field:   "birthdate",
+				Message: "birthdate must be in the past",
+			})
+		}
+	}
+
 	if len(errors) > 0 {
 		return &ValidationErrors{Errors: errors}
 	}
 	return nil
 }
 
+// ParseBirthdate parses a YYYY-MM-DD birthdate
+func ParseBirthdate(birthdate string) (time.Time, error) {
+	return time.Parse("2006-01-02", birthdate)
+}
+
+// AgeOn returns the age in whole years of someone born on birthdate, on the
+// calendar day of now
+func AgeOn(birthdate, now time.Time) int {
+	age := now.Year() - birthdate.Year()
+	if now.Month() < birthdate.Month() || (now.Month() == birthdate.Month() && now.Day() < birthdate.Day()) {
+		age--
+	}
+	return age
+}
+
+// Normalize applies the same email normalization as registration
+func (ul *UserLogin) Normalize() {
+	ul.Email = NormalizeEmail(ul.Email)
+}
+
 // Validate validates user login data
 func (ul *UserLogin) Validate() *ValidationErrors {
 	var errors []ValidationError
@@ -234,6 +392,9 @@ func (uu *UserUpdate) Validate() *ValidationErrors {
 		})
 	}
 
+	// Only optional profile fields can be cleared with null
+	errors = append(errors, nullFieldErrors(uu.NullFields, "bio", "image")...)
+
 	if len(errors) > 0 {
 		return &ValidationErrors{Errors: errors}
 	}
@@ -246,7 +407,7 @@ func (u *User) ToUserData(token string) UserData {
 		Username: u.Username,
 		Email:    u.Email,
 		Bio:      u.Bio,
-		ImageURL: u.ImageURL,
+		ImageURL: u.AvatarURL(),
 		Token:    token,
 	}
 }
@@ -258,6 +419,61 @@ func (u *User) ToUserResponse(token string) UserResponse {
 	}
 }
 
+// IsAdmin returns true if the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// IsModerator returns true if the user can moderate content (moderators and admins)
+func (u *User) IsModerator() bool {
+	return u.Role == RoleModerator || u.Role == RoleAdmin
+}
+
+// IsDeactivated returns true if the user has deactivated their account
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
+// IsBanned returns true if an admin has banned the user
+func (u *User) IsBanned() bool {
+	return u.ModerationStatus == ModerationBanned
+}
+
+// IsRateLimited returns true if an admin has restricted the user to the
+// new-account rate limits
+func (u *User) IsRateLimited() bool {
+	return u.ModerationStatus == ModerationRateLimited
+}
+
+// AccessDenial returns why the user may not use authenticated endpoints,
+// or an empty string if they may
+func (u *User) AccessDenial() string {
+	switch {
+	case u.IsBanned():
+		return "Account is banned"
+	case u.IsDeactivated():
+		return "Account is deactivated"
+	default:
+		return ""
+	}
+}
+
+// IsLocked reports whether failed logins keep the account locked at now
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// CanReactivate reports whether a deactivated account may still be
+// reactivated by logging in at the given time
+func (u *User) CanReactivate(now time.Time, grace time.Duration) bool {
+	return u.DeactivatedAt != nil && now.Before(u.DeactivatedAt.Add(grace))
+}
+
+// IsValidRole checks if a role is one of the known roles
+func IsValidRole(role string) bool {
+	return role == RoleUser || role == RoleModerator || role == RoleAdmin
+}
+
 // Helper functions
 func isValidEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}$`)
@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+// UserDataProfile is the account part of a user's data export
+type UserDataProfile struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Bio      string `json:"bio"`
+	Image    string `json:"image"`
+	Role     string `json:"role"`
+	// Birthdate is YYYY-MM-DD, or null if it was never given
+	Birthdate *string   `json:"birthdate"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserDataArticle is an article the user wrote, in any review state
+type UserDataArticle struct {
+	ID          int64     `json:"-"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Body        string    `json:"body"`
+	TagList     []string  `json:"tagList"`
+	Language    string    `json:"language"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// UserDataComment is a comment the user posted, including deleted ones
+// that are still stored
+type UserDataComment struct {
+	ID        int64      `json:"id"`
+	Article   string     `json:"article"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt"`
+}
+
+// UserDataFavorite is an article the user favorited
+type UserDataFavorite struct {
+	ArticleID   int64     `json:"-"`
+	Article     string    `json:"article"`
+	Title       string    `json:"title"`
+	FavoritedAt time.Time `json:"favoritedAt"`
+}
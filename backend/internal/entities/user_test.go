@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestUserRegistrationValidate(t *testing.T) {
@@ -249,6 +251,21 @@ func TestUserUpdateValidate(t *testing.T) {
 			wantErr:  true,
 			errorMsg: "bio must be less than 500 characters long",
 		},
+		{
+			name: "Valid update - clear bio and image",
+			user: UserUpdate{
+				NullFields: []string{"bio", "image"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid update - clear email",
+			user: UserUpdate{
+				NullFields: []string{"email"},
+			},
+			wantErr:  true,
+			errorMsg: "email cannot be cleared",
+		},
 	}
 
 	for _, tt := range tests {
@@ -274,6 +291,21 @@ func TestUserUpdateValidate(t *testing.T) {
 	}
 }
 
+func TestUserRegistrationNormalize(t *testing.T) {
+	registration := UserRegistration{
+		Username: "  jake ",
+		Email:    " Jake@Example.COM ",
+	}
+	registration.Normalize()
+
+	if registration.Username != "jake" {
+		t.Errorf("Expected trimmed username, got %q", registration.Username)
+	}
+	if registration.Email != "jake@example.com" {
+		t.Errorf("Expected lowercased email, got %q", registration.Email)
+	}
+}
+
 func TestUserToUserData(t *testing.T) {
 	user := &User{
 		ID:       1,
@@ -303,6 +335,24 @@ func TestUserToUserData(t *testing.T) {
 	}
 }
 
+func TestUserAvatarURL(t *testing.T) {
+	defer func(base string) { AvatarBaseURL = base }(AvatarBaseURL)
+	AvatarBaseURL = "https://api.example.com"
+
+	user := &User{Username: "jane doe"}
+	if got, want := user.AvatarURL(), "https://api.example.com/media/avatars/jane%20doe.png"; got != want {
+		t.Errorf("Expected generated avatar %s, got %s", want, got)
+	}
+	if got := user.ToUserData("").ImageURL; got != user.AvatarURL() {
+		t.Errorf("Expected UserData to use the generated avatar, got %s", got)
+	}
+
+	user.ImageURL = "https://example.com/image.jpg"
+	if got := user.AvatarURL(); got != user.ImageURL {
+		t.Errorf("Expected uploaded image %s, got %s", user.ImageURL, got)
+	}
+}
+
 func TestUserToUserResponse(t *testing.T) {
 	user := &User{
 		ID:       1,
@@ -323,6 +373,55 @@ func TestUserToUserResponse(t *testing.T) {
 	}
 }
 
+func TestUserCanReactivate(t *testing.T) {
+	now := time.Now()
+	grace := 30 * 24 * time.Hour
+
+	user := &User{}
+	if user.IsDeactivated() || user.CanReactivate(now, grace) {
+		t.Error("Expected an active user to be neither deactivated nor reactivatable")
+	}
+
+	recent := now.Add(-24 * time.Hour)
+	user.DeactivatedAt = &recent
+	if !user.IsDeactivated() || !user.CanReactivate(now, grace) {
+		t.Error("Expected a recently deactivated user to be reactivatable")
+	}
+
+	expired := now.Add(-31 * 24 * time.Hour)
+	user.DeactivatedAt = &expired
+	if user.CanReactivate(now, grace) {
+		t.Error("Expected the grace period to have ended")
+	}
+}
+
+func TestAgeOn(t *testing.T) {
+	birthdate, err := ParseBirthdate("2010-06-15")
+	if err != nil {
+		t.Fatalf("ParseBirthdate() error = %v", err)
+	}
+
+	tests := []struct {
+		on   string
+		want int
+	}{
+		{"2026-06-14", 15},
+		{"2026-06-15", 16},
+		{"2026-12-31", 16},
+	}
+	for _, tt := range tests {
+		on, _ := ParseBirthdate(tt.on)
+		if got := AgeOn(birthdate, on); got != tt.want {
+			t.Errorf("AgeOn(%s) = %d, want %d", tt.on, got, tt.want)
+		}
+	}
+
+	future := UserRegistration{Username: "kid", Email: "kid@example.com", Password: "password123", Birthdate: "2999-01-01"}
+	if err := future.Validate(); err == nil {
+		t.Error("Expected a birthdate in the future to be rejected")
+	}
+}
+
 func TestIsValidEmail(t *testing.T) {
 	tests := []struct {
 		email string
@@ -384,4 +483,23 @@ func generateLongString(length int) string {
 		result[i] = 'a'
 	}
 	return string(result)
-}
\ No newline at end of file
+}
+func TestUserUpdateUnmarshalJSON(t *testing.T) {
+	var update UserUpdate
+	if err := json.Unmarshal([]byte(`{"bio":null,"image":"","username":"newuser"}`), &update); err != nil {
+		t.Fatalf("Failed to unmarshal update: %v", err)
+	}
+
+	if update.Bio != nil || !update.Clears("bio") {
+		t.Errorf("Expected null bio to be recorded as cleared, got %v %v", update.Bio, update.NullFields)
+	}
+	if update.ImageURL == nil || *update.ImageURL != "" || update.Clears("image") {
+		t.Errorf("Expected empty image to be set, not cleared")
+	}
+	if update.Password != nil || update.Clears("password") {
+		t.Errorf("Expected omitted password to be left alone")
+	}
+	if update.Username == nil || *update.Username != "newuser" {
+		t.Errorf("Expected username to be decoded")
+	}
+}
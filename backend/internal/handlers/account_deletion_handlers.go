@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// AccountDeletionHandlers handles permanent account deletion HTTP requests
+type AccountDeletionHandlers struct {
+	userRepo     repositories.UserRepository
+	deletionRepo repositories.AccountDeletionRepository
+	holdRepo     repositories.LegalHoldRepository
+}
+
+// NewAccountDeletionHandlers creates a new account deletion handlers instance
+func NewAccountDeletionHandlers(userRepo repositories.UserRepository, deletionRepo repositories.AccountDeletionRepository, holdRepo repositories.LegalHoldRepository) *AccountDeletionHandlers {
+	return &AccountDeletionHandlers{
+		userRepo:     userRepo,
+		deletionRepo: deletionRepo,
+		holdRepo:     holdRepo,
+	}
+}
+
+// DeleteAccount handles permanently deleting the current user's account,
+// either with all of its content or keeping the content under the ghost user.
+// Unlike deactivation this cannot be undone; the response is a certificate
+// the former owner can keep as proof.
+func (h *AccountDeletionHandlers) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		User entities.AccountDeletion `json:"user"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.User.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	user, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	if !h.userRepo.VerifyPassword(user, req.User.Password) {
+		writeError(w, http.StatusUnauthorized, "Invalid password")
+		return
+	}
+
+	// A hold on the account, or on an article that would go with it, blocks
+	// deletion; the attempt is counted on the hold for the audit trail
+	hold, err := h.holdRepo.BlockDeletion(entities.LegalHoldSubjectUser, user.ID, req.User.Mode == entities.DeletionModeDelete, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to check legal holds")
+		return
+	}
+	if hold != nil {
+		writeError(w, http.StatusConflict, "This account is under a legal hold and cannot be deleted until it is lifted")
+		return
+	}
+
+	certificate, err := h.deletionRepo.Delete(user, req.User.Mode, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to delete account")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.DeletionCertificateResponse{Certificate: *certificate})
+}
+
+// GetDeletionCertificate handles looking up a deletion certificate by ID
+func (h *AccountDeletionHandlers) GetDeletionCertificate(w http.ResponseWriter, r *http.Request) {
+	certificate, err := h.deletionRepo.GetCertificate(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Certificate not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.DeletionCertificateResponse{Certificate: *certificate})
+}
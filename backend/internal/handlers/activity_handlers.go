@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// ActivityHandlers handles activity log HTTP requests
+type ActivityHandlers struct {
+	activityRepo repositories.ActivityRepository
+}
+
+// NewActivityHandlers creates a new activity handlers instance
+func NewActivityHandlers(activityRepo repositories.ActivityRepository) *ActivityHandlers {
+	return &ActivityHandlers{
+		activityRepo: activityRepo,
+	}
+}
+
+// ListActivity handles listing a page of the user's own actions, newest
+// first. ?types=a,b limits the log to those activity types.
+func (h *ActivityHandlers) ListActivity(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	types, ok := parseActivityTypes(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	activities, total, err := h.activityRepo.ListByUser(userID, types, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get activity")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ActivitiesResponse{
+		Activities:      activities,
+		ActivitiesCount: total,
+	})
+}
+
+// parseActivityTypes reads the ?types=a,b filter, writing a 400 for unknown
+// types; no filter yields nil
+func parseActivityTypes(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	raw := r.URL.Query().Get("types")
+	if raw == "" {
+		return nil, true
+	}
+
+	var types []string
+	for _, activityType := range strings.Split(raw, ",") {
+		activityType = strings.TrimSpace(activityType)
+		if !entities.IsActivityType(activityType) {
+			writeError(w, http.StatusBadRequest, "types must be any of: "+strings.Join(entities.ActivityTypes, ", "))
+			return nil, false
+		}
+		types = append(types, activityType)
+	}
+	return types, true
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// AdminHandlers handles the admin user directory, account moderation and
+// platform stats
+type AdminHandlers struct {
+	userRepo repositories.UserRepository
+	kpiRepo  repositories.KPIRepository
+}
+
+// NewAdminHandlers creates a new admin handlers instance
+func NewAdminHandlers(userRepo repositories.UserRepository, kpiRepo repositories.KPIRepository) *AdminHandlers {
+	return &AdminHandlers{
+		userRepo: userRepo,
+		kpiRepo:  kpiRepo,
+	}
+}
+
+// ListUsers handles listing a page of users, newest first, optionally
+// searched by username or email and filtered by role and moderation status.
+// ?search= matches part of a username, or with an "@" a whole email address:
+// emails are stored encrypted, so partial email searches are not possible.
+func (h *AdminHandlers) ListUsers(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePage(r, 20, 100)
+	query := &entities.UserListQuery{
+		Search:           r.URL.Query().Get("search"),
+		Role:             r.URL.Query().Get("role"),
+		ModerationStatus: r.URL.Query().Get("moderation"),
+		Limit:            limit,
+		Offset:           offset,
+	}
+
+	users, total, err := h.userRepo.List(query)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get users")
+		return
+	}
+
+	views := make([]entities.AdminUser, len(users))
+	for i := range users {
+		views[i] = users[i].AdminView()
+	}
+
+	writeJSON(w, http.StatusOK, entities.AdminUsersResponse{
+		Users:      views,
+		UsersCount: total,
+	})
+}
+
+// SetModerationStatus handles banning, shadow banning or rate limiting a
+// user, or lifting it. Admins cannot moderate themselves or other admins.
+func (h *AdminHandlers) SetModerationStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Moderation entities.ModerationUpdate `json:"moderation"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Moderation.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	if user.ID == userID {
+		writeError(w, http.StatusForbidden, "You cannot change your own moderation status")
+		return
+	}
+	if user.IsAdmin() {
+		writeError(w, http.StatusForbidden, "Admins cannot be moderated")
+		return
+	}
+
+	if err := h.userRepo.SetModerationStatus(user.ID, req.Moderation.Status, time.Now()); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to set moderation status")
+		return
+	}
+
+	user.ModerationStatus = req.Moderation.Status
+	writeJSON(w, http.StatusOK, entities.AdminUserResponse{User: user.AdminView()})
+}
+
+// GetPlatformStats handles showing the community's size and activity with the
+// moderation workload
+func (h *AdminHandlers) GetPlatformStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.kpiRepo.PlatformStats(time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get platform stats")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.PlatformStatsResponse{Stats: *stats})
+}
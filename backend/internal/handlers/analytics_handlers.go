@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// AnalyticsHandlers handles analytics ingestion and stats HTTP requests
+type AnalyticsHandlers struct {
+	analyticsRepo repositories.AnalyticsRepository
+	articleRepo   repositories.ArticleRepository
+	userRepo      repositories.UserRepository
+	settingsRepo  repositories.SettingsRepository
+	salt          string
+	// requireConsent records events only from visitors who accepted analytics cookies
+	requireConsent bool
+}
+
+// NewAnalyticsHandlers creates a new analytics handlers instance.
+// The salt keys the daily visitor hashes so they cannot be reversed to IP
+// addresses. With requireConsent, events are only recorded for visitors
+// carrying the analytics consent cookie.
+func NewAnalyticsHandlers(analyticsRepo repositories.AnalyticsRepository, articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, settingsRepo repositories.SettingsRepository, salt string, requireConsent bool) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		analyticsRepo:  analyticsRepo,
+		articleRepo:    articleRepo,
+		userRepo:       userRepo,
+		settingsRepo:   settingsRepo,
+		salt:           salt,
+		requireConsent: requireConsent,
+	}
+}
+
+// RecordEvents handles batched, anonymous analytics ingestion
+func (h *AnalyticsHandlers) RecordEvents(w http.ResponseWriter, r *http.Request) {
+	// Respect Do Not Track and Global Privacy Control: accept the request, store nothing
+	if r.Header.Get("DNT") == "1" || r.Header.Get("Sec-GPC") == "1" {
+		writeJSON(w, http.StatusAccepted, map[string]int{"accepted": 0})
+		return
+	}
+
+	// Where consent is required, visitors who have not given it are told so
+	if h.requireConsent && !hasAnalyticsConsent(r) {
+		w.Header().Set("X-Consent-Required", "analytics")
+		writeJSON(w, http.StatusAccepted, map[string]int{"accepted": 0})
+		return
+	}
+
+	// Signed-in users who opted out of reading history are treated the same way
+	if userID, err := getUserIDFromContext(r); err == nil {
+		settings, err := h.settingsRepo.Get(userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to record events")
+			return
+		}
+		if !settings.RecordReadingHistory {
+			writeJSON(w, http.StatusAccepted, map[string]int{"accepted": 0})
+			return
+		}
+	}
+
+	var batch entities.AnalyticsBatch
+	if err := parseJSON(r, &batch); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := batch.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	day := time.Now().UTC().Format("2006-01-02")
+	visitorHash := h.visitorHash(r, day)
+
+	records := make([]entities.AnalyticsEventRecord, len(batch.Events))
+	for i, event := range batch.Events {
+		records[i] = entities.AnalyticsEventRecord{
+			AnalyticsEvent: event,
+			ReferrerHost:   referrerHost(event.Referrer),
+			VisitorHash:    visitorHash,
+			Day:            day,
+		}
+		// Only the host of the referrer is kept
+		records[i].Referrer = ""
+	}
+
+	accepted, err := h.analyticsRepo.RecordEvents(records)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to record events")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]int{"accepted": accepted})
+}
+
+// hasAnalyticsConsent reports whether the visitor accepted analytics cookies
+func hasAnalyticsConsent(r *http.Request) bool {
+	cookie, err := r.Cookie(entities.AnalyticsConsentCookie)
+	return err == nil && cookie.Value == "granted"
+}
+
+// GetArticleStats handles daily view and interaction stats for an article (author or admin)
+func (h *AnalyticsHandlers) GetArticleStats(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if article.AuthorID != userID {
+		user, err := h.userRepo.GetByID(userID)
+		if err != nil || !user.IsAdmin() {
+			writeError(w, http.StatusForbidden, "You can only view stats for your own articles")
+			return
+		}
+	}
+
+	days := parseDays(r, 30)
+	since := time.Now().AddDate(0, 0, -(days - 1))
+	daily, err := h.analyticsRepo.GetArticleStats(article.ID, since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article stats")
+		return
+	}
+	shares, err := h.analyticsRepo.GetShareStats(article.ID, since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article stats")
+		return
+	}
+
+	stats := entities.ArticleStats{
+		Slug:   article.Slug,
+		Daily:  daily,
+		Shares: shares,
+	}
+	for _, day := range daily {
+		stats.TotalViews += day.Views
+		stats.TotalInteractions += day.Interactions
+	}
+	for _, share := range shares {
+		stats.TotalShareClicks += share.Clicks
+	}
+
+	if wantsCSV(r) {
+		rows := make([][]string, len(daily))
+		for i, day := range daily {
+			rows[i] = []string{day.Date, strconv.Itoa(day.Views), strconv.Itoa(day.Interactions)}
+		}
+		writeCSV(w, article.Slug+"-stats.csv", []string{"date", "views", "interactions"}, rows)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ArticleStatsResponse{Stats: stats})
+}
+
+// ListArticleStats handles site-wide per-article totals (admin only)
+func (h *AnalyticsHandlers) ListArticleStats(w http.ResponseWriter, r *http.Request) {
+	days := parseDays(r, 30)
+	totals, err := h.analyticsRepo.ListArticleTotals(time.Now().AddDate(0, 0, -(days - 1)))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list article stats")
+		return
+	}
+
+	if wantsCSV(r) {
+		rows := make([][]string, len(totals))
+		for i, summary := range totals {
+			rows[i] = []string{summary.Slug, summary.Title, strconv.Itoa(summary.Views), strconv.Itoa(summary.Interactions)}
+		}
+		writeCSV(w, "article-stats.csv", []string{"slug", "title", "views", "interactions"}, rows)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ArticleStatsSummaryResponse{Articles: totals})
+}
+
+// ListTrendingArticles handles listing the most viewed articles over the last few days
+func (h *AnalyticsHandlers) ListTrendingArticles(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 50 {
+			limit = parsed
+		}
+	}
+
+	days := parseDays(r, 7)
+	ids, err := h.analyticsRepo.ListTrendingArticleIDs(time.Now().AddDate(0, 0, -(days-1)), limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list trending articles")
+		return
+	}
+
+	articles := []entities.Article{}
+	for _, id := range ids {
+		article, err := h.articleRepo.GetByID(id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to load trending article")
+			return
+		}
+		articles = append(articles, *article)
+	}
+
+	response := entities.ArticlesResponse{
+		Articles:      articles,
+		ArticlesCount: len(articles),
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// visitorHash derives a daily-rotating visitor identifier so raw IPs are never stored
+func (h *AnalyticsHandlers) visitorHash(r *http.Request, day string) string {
+	ip := middleware.ClientIP(r)
+	sum := sha256.Sum256([]byte(h.salt + "|" + day + "|" + ip + "|" + r.UserAgent()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// referrerHost reduces a referrer URL to its host
+func referrerHost(referrer string) string {
+	if referrer == "" {
+		return ""
+	}
+	parsed, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// parseDays reads the ?days= window, clamped to 1..365
+func parseDays(r *http.Request, defaultDays int) int {
+	days := defaultDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		if parsed, err := strconv.Atoi(daysStr); err == nil && parsed > 0 {
+			days = parsed
+		}
+	}
+	if days > 365 {
+		days = 365
+	}
+	return days
+}
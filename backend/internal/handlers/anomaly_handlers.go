@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// AnomalyHandlers handles abuse report HTTP requests (admins)
+type AnomalyHandlers struct {
+	anomalyRepo repositories.AnomalyRepository
+	detector    services.AnomalyDetector
+}
+
+// NewAnomalyHandlers creates a new anomaly handlers instance
+func NewAnomalyHandlers(anomalyRepo repositories.AnomalyRepository, detector services.AnomalyDetector) *AnomalyHandlers {
+	return &AnomalyHandlers{
+		anomalyRepo: anomalyRepo,
+		detector:    detector,
+	}
+}
+
+// ListAnomalies handles listing a page of anomalies; ?status= is open (the
+// default), resolved or all
+func (h *AnomalyHandlers) ListAnomalies(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = entities.AnomalyStatusOpen
+	}
+	if status != entities.AnomalyStatusOpen && status != entities.AnomalyStatusResolved && status != entities.AnomalyStatusAll {
+		writeError(w, http.StatusBadRequest, "status must be one of: open, resolved, all")
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	anomalies, total, err := h.anomalyRepo.List(status, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get anomalies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.AnomaliesResponse{
+		Anomalies:      anomalies,
+		AnomaliesCount: total,
+	})
+}
+
+// ScanAnomalies handles running a detection scan immediately instead of
+// waiting for the scheduled one
+func (h *AnomalyHandlers) ScanAnomalies(w http.ResponseWriter, r *http.Request) {
+	detected, err := h.detector.Scan()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to scan for anomalies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.AnomalyScanResponse{Detected: detected})
+}
+
+// ResolveAnomaly handles acting on an anomaly: rate-limiting, shadow-banning
+// or banning every account involved, or dismissing it
+func (h *AnomalyHandlers) ResolveAnomaly(w http.ResponseWriter, r *http.Request) {
+	anomalyID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid anomaly ID")
+		return
+	}
+
+	var req entities.AnomalyAction
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	if err := h.anomalyRepo.Resolve(anomalyID, req.Action, req.ModerationStatus(), time.Now()); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Anomaly not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to resolve anomaly")
+		return
+	}
+
+	anomaly, err := h.anomalyRepo.GetByID(anomalyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get anomaly")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.AnomalyResponse{Anomaly: *anomaly})
+}
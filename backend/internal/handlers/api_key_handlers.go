@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// APIKeyHandlers handles personal API key HTTP requests
+type APIKeyHandlers struct {
+	apiKeyRepo repositories.APIKeyRepository
+}
+
+// NewAPIKeyHandlers creates a new API key handlers instance
+func NewAPIKeyHandlers(apiKeyRepo repositories.APIKeyRepository) *APIKeyHandlers {
+	return &APIKeyHandlers{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// ListAPIKeys handles listing the user's API keys, without the keys themselves
+func (h *APIKeyHandlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	keys, err := h.apiKeyRepo.ListByUser(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get API keys")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.APIKeysResponse{APIKeys: keys})
+}
+
+// CreateAPIKey handles creating an API key. The key is only ever shown in
+// this response. Keys cannot create other keys, so a leaked key cannot be
+// used to outlive its revocation.
+func (h *APIKeyHandlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, ok := middleware.APIKeyIDFromContext(r); ok {
+		writeError(w, http.StatusForbidden, "API keys cannot be managed with an API key")
+		return
+	}
+
+	var req struct {
+		APIKey entities.APIKeyCreate `json:"apiKey"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.APIKey.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	key, err := h.apiKeyRepo.Create(userID, &req.APIKey)
+	if err != nil {
+		if strings.Contains(err.Error(), "too many") {
+			writeError(w, http.StatusConflict, "Too many API keys; revoke one first")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.APIKeyResponse{APIKey: *key})
+}
+
+// RevokeAPIKey handles revoking one of the user's API keys
+func (h *APIKeyHandlers) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, ok := middleware.APIKeyIDFromContext(r); ok {
+		writeError(w, http.StatusForbidden, "API keys cannot be managed with an API key")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	if err := h.apiKeyRepo.Revoke(userID, id, time.Now().UTC()); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+func TestAPIKeyHandlers_RefuseManagementWithAPIKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	userRepo := repositories.NewUserRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	handlers := NewAPIKeyHandlers(apiKeyRepo)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "scripter",
+		Email:    "scripter@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	existing, err := apiKeyRepo.Create(user.ID, &entities.APIKeyCreate{Name: "CI", Scopes: []string{entities.ScopeRead, entities.ScopeWrite}})
+	if err != nil {
+		t.Fatalf("Failed to create API key: %v", err)
+	}
+
+	// withCaller authenticates the request as the user, through the key when withKey is set
+	withCaller := func(req *http.Request, withKey bool) *http.Request {
+		ctx := context.WithValue(req.Context(), middleware.UserIDContextKey, user.ID)
+		if withKey {
+			ctx = context.WithValue(ctx, middleware.APIKeyContextKey, existing.ID)
+		}
+		return req.WithContext(ctx)
+	}
+	createRequest := func() *http.Request {
+		body, _ := json.Marshal(map[string]interface{}{
+			"apiKey": map[string]interface{}{"name": "Another", "scopes": []string{entities.ScopeRead}},
+		})
+		return httptest.NewRequest(http.MethodPost, "/api/user/api-keys", bytes.NewReader(body))
+	}
+
+	rr := httptest.NewRecorder()
+	handlers.CreateAPIKey(rr, withCaller(createRequest(), true))
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected creating a key with a key to be forbidden, got %d", rr.Code)
+	}
+
+	id := strconv.FormatInt(existing.ID, 10)
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/api/user/api-keys/"+id, nil), map[string]string{"id": id})
+	rr = httptest.NewRecorder()
+	handlers.RevokeAPIKey(rr, withCaller(req, true))
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected revoking a key with a key to be forbidden, got %d", rr.Code)
+	}
+
+	keys, err := apiKeyRepo.ListByUser(user.ID)
+	if err != nil {
+		t.Fatalf("Failed to list API keys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].RevokedAt != nil {
+		t.Errorf("Expected the existing key alone and still active, got %+v", keys)
+	}
+
+	// A token can still manage keys
+	rr = httptest.NewRecorder()
+	handlers.CreateAPIKey(rr, withCaller(createRequest(), false))
+	if rr.Code != http.StatusCreated {
+		t.Errorf("Expected a token to create a key, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
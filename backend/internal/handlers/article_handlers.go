@@ -1,34 +1,51 @@
 package handlers
 
 import (
+	"html/template"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/emotab87/vibe_coding/backend/internal/entities"
 	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
 )
 
 // ArticleHandlers handles article-related HTTP requests
 type ArticleHandlers struct {
-	articleRepo repositories.ArticleRepository
+	articleRepo     repositories.ArticleRepository
+	linkPreviewRepo repositories.LinkPreviewRepository
+	attachmentRepo  repositories.AttachmentRepository
+	userRepo        repositories.UserRepository
+	seriesRepo      repositories.SeriesRepository
+	favoriteRepo    repositories.FavoriteRepository
+	followRepo      repositories.FollowRepository
+	holdRepo        repositories.LegalHoldRepository
+	reviewPolicy    services.ArticleReviewPolicy
+	quotas          services.QuotaService
 }
 
 // NewArticleHandlers creates a new article handlers instance
-func NewArticleHandlers(articleRepo repositories.ArticleRepository) *ArticleHandlers {
+func NewArticleHandlers(articleRepo repositories.ArticleRepository, linkPreviewRepo repositories.LinkPreviewRepository, attachmentRepo repositories.AttachmentRepository, userRepo repositories.UserRepository, seriesRepo repositories.SeriesRepository, favoriteRepo repositories.FavoriteRepository, followRepo repositories.FollowRepository, holdRepo repositories.LegalHoldRepository, reviewPolicy services.ArticleReviewPolicy, quotas services.QuotaService) *ArticleHandlers {
 	return &ArticleHandlers{
-		articleRepo: articleRepo,
+		articleRepo:     articleRepo,
+		linkPreviewRepo: linkPreviewRepo,
+		attachmentRepo:  attachmentRepo,
+		userRepo:        userRepo,
+		seriesRepo:      seriesRepo,
+		favoriteRepo:    favoriteRepo,
+		followRepo:      followRepo,
+		holdRepo:        holdRepo,
+		reviewPolicy:    reviewPolicy,
+		quotas:          quotas,
 	}
 }
 
 // CreateArticle handles article creation
 func (h *ArticleHandlers) CreateArticle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -52,6 +69,26 @@ func (h *ArticleHandlers) CreateArticle(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Enforce the author's daily article quota
+	if !checkQuota(w, h.quotas, userID, entities.QuotaArticles) {
+		return
+	}
+
+	// Hold the article for approval if the author's articles need review
+	author, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	requiresReview, err := h.reviewPolicy.RequiresReview(author)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create article")
+		return
+	}
+	if requiresReview {
+		req.Article.Status = entities.ArticleStatusPending
+	}
+
 	// Create article
 	article, err := h.articleRepo.Create(userID, &req.Article)
 	if err != nil {
@@ -70,11 +107,6 @@ func (h *ArticleHandlers) CreateArticle(w http.ResponseWriter, r *http.Request)
 
 // GetArticle handles article retrieval by slug
 func (h *ArticleHandlers) GetArticle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Get slug from URL path
 	vars := mux.Vars(r)
 	slug := vars["slug"]
@@ -94,18 +126,260 @@ func (h *ArticleHandlers) GetArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Articles awaiting review are hidden from everyone but their author and reviewers
+	if !article.IsPublished() {
+		viewer, err := h.viewer(r)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get user")
+			return
+		}
+		if !article.VisibleTo(viewer) {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+	}
+
+	// Load linked language variants
+	translations, err := h.articleRepo.GetTranslations(article)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article translations")
+		return
+	}
+
+	// Negotiate language: an explicit ?lang= always wins. Accept-Language only
+	// applies when the original was requested, so a translation's slug always
+	// returns that translation.
+	requested := r.URL.Query().Get("lang")
+	if requested == "" && article.TranslationOf == nil {
+		requested = r.Header.Get("Accept-Language")
+	}
+	if len(translations) > 0 {
+		// Cached copies of articles with variants must be kept per language
+		w.Header().Add("Vary", "Accept-Language")
+	}
+	if requested != "" {
+		available := []string{article.Language}
+		for _, translation := range translations {
+			available = append(available, translation.Language)
+		}
+
+		if language := entities.NegotiateLanguage(requested, available); language != "" && language != article.Language {
+			for _, translation := range translations {
+				if translation.Language != language {
+					continue
+				}
+				variant, err := h.articleRepo.GetBySlug(translation.Slug)
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, "Failed to get article")
+					return
+				}
+				if translations, err = h.articleRepo.GetTranslations(variant); err != nil {
+					writeError(w, http.StatusInternalServerError, "Failed to get article translations")
+					return
+				}
+				article = variant
+				break
+			}
+		}
+	}
+	article.Translations = translations
+
+	// Load series navigation; translations are placed by their original
+	seriesArticleID := article.ID
+	if article.TranslationOf != nil {
+		seriesArticleID = *article.TranslationOf
+	}
+	if article.Series, err = h.seriesRepo.Navigation(seriesArticleID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article series")
+		return
+	}
+
+	// Load attached link cards
+	if article.LinkPreviews, err = h.linkPreviewRepo.ListByArticle(article.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get link previews")
+		return
+	}
+
+	// Load attached files; their content is downloaded separately
+	if article.Attachments, err = h.attachmentRepo.ListByArticle(article.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get attachments")
+		return
+	}
+
+	// Mark whether the signed-in reader has favorited it and follows its author
+	if userID, err := getUserIDFromContext(r); err == nil {
+		if article.Favorited, err = h.favoriteRepo.IsFavorited(userID, article.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get favorites")
+			return
+		}
+		if err := h.followRepo.MarkFollowing(userID, []*entities.User{article.Author}); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get follows")
+			return
+		}
+	}
+
 	// Return article response
+	w.Header().Set("Content-Language", article.Language)
+	if !article.Indexable() {
+		w.Header().Set("X-Robots-Tag", "noindex")
+	}
 	response := article.ToArticleResponse()
 	writeJSON(w, http.StatusOK, response)
 }
 
-// UpdateArticle handles article updates
-func (h *ArticleHandlers) UpdateArticle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+// printTemplate is the reader view of an article. It has no scripts and only
+// inline styles; the body is the sanitized Markdown source, shown as text.
+var printTemplate = template.Must(template.New("print").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 42em; margin: 2em auto; padding: 0 1em; font: 18px/1.6 Georgia, serif; color: #222; background: #fff; }
+h1 { font-size: 2em; line-height: 1.2; margin-bottom: 0.2em; }
+.description { font-size: 1.15em; color: #555; margin-top: 0; }
+.meta { font: 14px/1.4 sans-serif; color: #777; border-bottom: 1px solid #ddd; padding-bottom: 1em; }
+.toc { font: 15px/1.5 sans-serif; margin: 1.5em 0; }
+.toc li.level-3 { margin-left: 1.5em; }
+.toc li.level-4, .toc li.level-5, .toc li.level-6 { margin-left: 3em; }
+.body { white-space: pre-wrap; overflow-wrap: break-word; }
+@media print { body { margin: 0; max-width: none; font-size: 12pt; } .toc { display: none; } }
+</style>
+</head>
+<body>
+<article>
+<h1>{{.Title}}</h1>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+<p class="meta">{{with .Author}}{{.Username}} · {{end}}<time datetime="{{.CreatedAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.CreatedAt.Format "January 2, 2006"}}</time>{{if .TagList}} · {{range $i, $tag := .TagList}}{{if $i}}, {{end}}#{{$tag}}{{end}}{{end}}</p>
+{{if .TOC}}<ul class="toc">{{range .TOC}}<li class="level-{{.Level}}">{{.Text}}</li>{{end}}</ul>{{end}}
+<div class="body">{{.Body}}</div>
+</article>
+</body>
+</html>
+`))
+
+// PrintArticle serves a script-free HTML page of an article for printing and
+// reading. Unpublished articles are only shown to those who can see them.
+func (h *ArticleHandlers) PrintArticle(w http.ResponseWriter, r *http.Request) {
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if !article.IsPublished() {
+		viewer, err := h.viewer(r)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get user")
+			return
+		}
+		if !article.VisibleTo(viewer) {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+	}
+
+	response := article.ToArticleResponse()
+	// The body is shown as text, so markup the sanitizer keeps or adds, such
+	// as embedded players, would otherwise appear literally
+	response.Article.Body = services.PlainText(response.Article.Body)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// The page is a copy of the article, so search engines should index the original
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Content-Language", article.Language)
+	w.WriteHeader(http.StatusOK)
+	if err := printTemplate.Execute(w, response.Article); err != nil {
+		log.Printf("⚠️  Failed to render print view of article %s: %v", article.Slug, err)
+	}
+}
+
+// CreateTranslation handles attaching a language variant to an article
+func (h *ArticleHandlers) CreateTranslation(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get slug from URL path
+	vars := mux.Vars(r)
+	slug := vars["slug"]
+	if slug == "" {
+		writeError(w, http.StatusBadRequest, "Missing article slug")
+		return
+	}
+
+	// Get source article to check authorization
+	source, err := h.articleRepo.GetBySlug(slug)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	// Check if user is the author
+	if source.AuthorID != userID {
+		writeError(w, http.StatusForbidden, "You can only translate your own articles")
+		return
+	}
+
+	// Parse request body
+	var req struct {
+		Article entities.ArticleTranslationCreate `json:"article"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate translation data
+	if validationErr := req.Article.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	// Translations count towards the daily article quota
+	if !checkQuota(w, h.quotas, userID, entities.QuotaArticles) {
+		return
+	}
+
+	// Create translation
+	translation, err := h.articleRepo.CreateTranslation(source, &req.Article)
+	if err != nil {
+		if containsString(err.Error(), "already exists") {
+			writeError(w, http.StatusConflict, "Translation for this language already exists")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to create translation")
 		return
 	}
 
+	if translation.Translations, err = h.articleRepo.GetTranslations(translation); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article translations")
+		return
+	}
+
+	// Return translation response
+	response := translation.ToArticleResponse()
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// UpdateArticle handles article updates
+func (h *ArticleHandlers) UpdateArticle(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -169,6 +443,11 @@ func (h *ArticleHandlers) UpdateArticle(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if updatedArticle.Translations, err = h.articleRepo.GetTranslations(updatedArticle); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article translations")
+		return
+	}
+
 	// Return updated article response
 	response := updatedArticle.ToArticleResponse()
 	writeJSON(w, http.StatusOK, response)
@@ -176,11 +455,6 @@ func (h *ArticleHandlers) UpdateArticle(w http.ResponseWriter, r *http.Request)
 
 // DeleteArticle handles article deletion
 func (h *ArticleHandlers) DeleteArticle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -213,8 +487,40 @@ func (h *ArticleHandlers) DeleteArticle(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.deleteArticle(w, existingArticle)
+}
+
+// AdminDeleteArticle handles an admin removing any article, e.g. spam or
+// content breaking the rules. Legal holds still apply.
+func (h *ArticleHandlers) AdminDeleteArticle(w http.ResponseWriter, r *http.Request) {
+	existingArticle, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	h.deleteArticle(w, existingArticle)
+}
+
+// deleteArticle deletes the article unless it is under a legal hold
+func (h *ArticleHandlers) deleteArticle(w http.ResponseWriter, article *entities.Article) {
+	// Articles under a legal hold are kept until the hold is lifted
+	hold, err := h.holdRepo.BlockDeletion(entities.LegalHoldSubjectArticle, article.ID, false, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to check legal holds")
+		return
+	}
+	if hold != nil {
+		writeError(w, http.StatusConflict, "This article is under a legal hold and cannot be deleted until it is lifted")
+		return
+	}
+
 	// Delete article
-	if err := h.articleRepo.Delete(existingArticle.ID); err != nil {
+	if err := h.articleRepo.Delete(article.ID); err != nil {
 		if containsString(err.Error(), "not found") {
 			writeError(w, http.StatusNotFound, "Article not found")
 			return
@@ -229,11 +535,6 @@ func (h *ArticleHandlers) DeleteArticle(w http.ResponseWriter, r *http.Request)
 
 // ListArticles handles article listing with pagination
 func (h *ArticleHandlers) ListArticles(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Parse query parameters
 	query := &entities.ArticleListQuery{
 		Limit:  20, // Default limit
@@ -259,6 +560,54 @@ func (h *ArticleHandlers) ListArticles(w http.ResponseWriter, r *http.Request) {
 		query.Author = author
 	}
 
+	// Parse tag filter (aliases resolve to their canonical tag)
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		query.Tag = tag
+	}
+
+	// Parse favorited filter; signed-in readers can always browse their own favorites
+	if favorited := r.URL.Query().Get("favorited"); favorited != "" {
+		query.Favorited = favorited
+		query.ViewerID, _ = getUserIDFromContext(r)
+	}
+
+	// Parse featured filter (editor's picks, in pick order)
+	if featured, err := strconv.ParseBool(r.URL.Query().Get("featured")); err == nil {
+		query.Featured = featured
+	}
+
+	// Parse creation date range; since is inclusive, until exclusive
+	since, err := parseListTime(r.URL.Query().Get("since"), false)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "since must be a date (2006-01-02) or RFC 3339 time")
+		return
+	}
+	until, err := parseListTime(r.URL.Query().Get("until"), true)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "until must be a date (2006-01-02) or RFC 3339 time")
+		return
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		writeError(w, http.StatusBadRequest, "since must be before until")
+		return
+	}
+	query.Since, query.Until = since, until
+
+	// Parse order; activity lists the most recently discussed articles first
+	query.Sort = r.URL.Query().Get("sort")
+	if !entities.IsValidArticleSort(query.Sort) {
+		writeError(w, http.StatusBadRequest, "sort must be one of: recent, activity")
+		return
+	}
+
+	// Parse projection; summaries leave out the body
+	excludeBody, err := parseExcludeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query.ExcludeBody = excludeBody
+
 	// Get articles
 	articles, totalCount, err := h.articleRepo.List(query)
 	if err != nil {
@@ -266,6 +615,18 @@ func (h *ArticleHandlers) ListArticles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Mark the articles the signed-in reader has favorited and the authors they follow
+	if userID, err := getUserIDFromContext(r); err == nil {
+		if err := h.favoriteRepo.MarkFavorited(userID, articles); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get favorites")
+			return
+		}
+		if err := h.followRepo.MarkFollowing(userID, articleAuthors(articles)); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get follows")
+			return
+		}
+	}
+
 	// Return articles response
 	response := entities.ArticlesResponse{
 		Articles:      articles,
@@ -274,6 +635,68 @@ func (h *ArticleHandlers) ListArticles(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, response)
 }
 
+// ListOwnArticles handles listing a page of the signed-in author's articles in
+// one review state (?status= pending, the default, rejected or published) so
+// authors can follow their submissions
+func (h *ArticleHandlers) ListOwnArticles(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = entities.ArticleStatusPending
+	}
+	if status != entities.ArticleStatusPending && status != entities.ArticleStatusRejected && status != entities.ArticleStatusPublished {
+		writeError(w, http.StatusBadRequest, "status must be one of: pending, rejected, published")
+		return
+	}
+
+	user, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	articles, totalCount, err := h.articleRepo.List(&entities.ArticleListQuery{
+		Author: user.Username,
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list articles")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ArticlesResponse{
+		Articles:      articles,
+		ArticlesCount: totalCount,
+	})
+}
+
+// viewer loads the signed-in user, or returns nil for anonymous requests
+func (h *ArticleHandlers) viewer(r *http.Request) (*entities.User, error) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		return nil, nil
+	}
+	return h.userRepo.GetByID(userID)
+}
+
+// articleAuthors returns the authors of a page of articles
+func articleAuthors(articles []entities.Article) []*entities.User {
+	authors := make([]*entities.User, len(articles))
+	for i := range articles {
+		authors[i] = articles[i].Author
+	}
+	return authors
+}
+
 // Helper function to check string contains (case-insensitive)
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && findSubstring(toLowerCase(s), toLowerCase(substr)) >= 0
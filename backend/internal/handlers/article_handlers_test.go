@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+func TestArticleHandlers_PrintArticle(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	userRepo := repositories.NewUserRepository(db)
+	sanitizer := services.NewSanitizer(services.SanitizerOptions{AllowHTML: true, EmbedProviders: []string{"youtube"}})
+	articleRepo := services.NewSanitizingArticleRepository(repositories.NewArticleRepository(db, userRepo, 0), sanitizer)
+	handlers := NewArticleHandlers(articleRepo, nil, nil, userRepo, nil, nil, nil, nil, nil, nil)
+
+	author, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	article, err := articleRepo.Create(author.ID, &entities.ArticleCreate{
+		Title:       "Printable",
+		Description: "Test description",
+		Body:        "## Watch this\n\nhttps://youtu.be/dQw4w9WgXcQ\n\nSome <strong>bold</strong> & <script>alert(1)</script>text",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	printPage := func(slug string) *httptest.ResponseRecorder {
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/articles/"+slug+"/print", nil), map[string]string{"slug": slug})
+		rr := httptest.NewRecorder()
+		handlers.PrintArticle(rr, req)
+		return rr
+	}
+
+	rr := printPage(article.Slug)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if contentType := rr.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("Expected an HTML page, got %q", contentType)
+	}
+
+	page := rr.Body.String()
+	for _, want := range []string{
+		`<li class="level-2">Watch this</li>`,
+		"https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ",
+		"Some bold &amp; text",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected the page to contain %q, got %s", want, page)
+		}
+	}
+	for _, unwanted := range []string{"iframe", "strong", "alert(1)"} {
+		if strings.Contains(page, unwanted) {
+			t.Errorf("Expected no %q markup on the page, got %s", unwanted, page)
+		}
+	}
+
+	if rr := printPage("missing"); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a missing article, got %d", http.StatusNotFound, rr.Code)
+	}
+}
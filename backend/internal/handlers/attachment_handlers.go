@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// AttachmentHandlers handles text attachments (code snippets, small files) on articles
+type AttachmentHandlers struct {
+	attachmentRepo repositories.AttachmentRepository
+	articleRepo    repositories.ArticleRepository
+	userRepo       repositories.UserRepository
+	maxBytes       int
+	maxPerArticle  int
+}
+
+// NewAttachmentHandlers creates a new attachment handlers instance; attachments
+// are limited to maxBytes each and maxPerArticle per article
+func NewAttachmentHandlers(attachmentRepo repositories.AttachmentRepository, articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, maxBytes, maxPerArticle int) *AttachmentHandlers {
+	return &AttachmentHandlers{
+		attachmentRepo: attachmentRepo,
+		articleRepo:    articleRepo,
+		userRepo:       userRepo,
+		maxBytes:       maxBytes,
+		maxPerArticle:  maxPerArticle,
+	}
+}
+
+// CreateAttachment handles attaching a named text file to an article (author only)
+func (h *AttachmentHandlers) CreateAttachment(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.authorArticle(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Attachment entities.ArticleAttachmentCreate `json:"attachment"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+	if validationErr := req.Attachment.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+	if len(req.Attachment.Content) > h.maxBytes {
+		writeValidationErrors(w, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+			Field:   "content",
+			Message: fmt.Sprintf("content must be at most %d bytes", h.maxBytes),
+		}}})
+		return
+	}
+
+	attachment, err := h.attachmentRepo.Create(article.ID, &req.Attachment, h.maxPerArticle)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "already exists"):
+			writeError(w, http.StatusConflict, "An attachment with this name already exists")
+		case strings.Contains(err.Error(), "limit reached"):
+			writeError(w, http.StatusConflict, fmt.Sprintf("Articles can have at most %d attachments", h.maxPerArticle))
+		default:
+			writeError(w, http.StatusInternalServerError, "Failed to create attachment")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.ArticleAttachmentResponse{Attachment: *attachment})
+}
+
+// GetAttachment handles downloading an attachment as plain text. Attachments of
+// unpublished articles are only visible to those who can see the article.
+func (h *AttachmentHandlers) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	article, err := h.articleRepo.GetBySlug(vars["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if !article.IsPublished() {
+		var viewer *entities.User
+		if userID, err := getUserIDFromContext(r); err == nil {
+			if viewer, err = h.userRepo.GetByID(userID); err != nil {
+				writeError(w, http.StatusInternalServerError, "Failed to get user")
+				return
+			}
+		}
+		if !article.VisibleTo(viewer) {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+	}
+
+	attachment, err := h.attachmentRepo.Get(article.ID, vars["name"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Attachment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get attachment")
+		return
+	}
+
+	// Always served as plain text so browsers never render or run the content
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.Name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(attachment.Content))
+}
+
+// DeleteAttachment handles removing an attachment from an article (author only)
+func (h *AttachmentHandlers) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.authorArticle(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentRepo.Delete(article.ID, mux.Vars(r)["name"]); err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Attachment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to delete attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorArticle loads the article named in the path and checks that the caller
+// wrote it, writing an error response if not
+func (h *AttachmentHandlers) authorArticle(w http.ResponseWriter, r *http.Request) (*entities.Article, bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return nil, false
+	}
+
+	if article.AuthorID != userID {
+		writeError(w, http.StatusForbidden, "You can only edit attachments on your own articles")
+		return nil, false
+	}
+
+	return article, true
+}
@@ -1,34 +1,76 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
 	"github.com/emotab87/vibe_coding/backend/internal/repositories"
 	"github.com/emotab87/vibe_coding/backend/internal/services"
 )
 
 // AuthHandlers handles authentication-related HTTP requests
 type AuthHandlers struct {
-	userRepo   repositories.UserRepository
-	jwtService services.JWTService
+	userRepo         repositories.UserRepository
+	inviteRepo       repositories.InviteRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+	sessionRepo      repositories.SessionRepository
+	settingsRepo     repositories.SettingsRepository
+	jwtService       services.JWTService
+	options          AuthOptions
+}
+
+// AuthOptions holds deployment settings for account handling
+type AuthOptions struct {
+	// DeactivationGrace is how long a deactivated account can be reactivated by logging in
+	DeactivationGrace time.Duration
+	// NetworkSalt salts the stored hash of each registration's network
+	NetworkSalt string
+	// RequireInvite makes registration invite-only (private beta)
+	RequireInvite bool
+	// MinimumAge requires new users to confirm they are at least this old; 0
+	// disables the age gate
+	MinimumAge int
+	// RequireBirthdate verifies the minimum age from a birthdate given at
+	// registration instead of the user's confirmation
+	RequireBirthdate bool
+	// AdultAge is the age below which new accounts get the restricted
+	// settings of entities.UserSettings.RestrictForMinor; 0 disables it
+	AdultAge int
+	// MaxFailuresPerAccount failed logins within FailureWindow lock the
+	// account for LockoutDuration; 0 disables lockouts
+	MaxFailuresPerAccount int
+	// MaxFailuresPerNetwork failed logins from one network within
+	// FailureWindow, across any accounts, refuse further attempts from it
+	// until they age out; 0 disables the limit
+	MaxFailuresPerNetwork int
+	FailureWindow         time.Duration
+	LockoutDuration       time.Duration
+	// SessionLifetime is how long a login session lasts without a new token;
+	// it matches the access token lifetime
+	SessionLifetime time.Duration
 }
 
 // NewAuthHandlers creates a new auth handlers instance
-func NewAuthHandlers(userRepo repositories.UserRepository, jwtService services.JWTService) *AuthHandlers {
+func NewAuthHandlers(userRepo repositories.UserRepository, inviteRepo repositories.InviteRepository, revokedTokenRepo repositories.RevokedTokenRepository, sessionRepo repositories.SessionRepository, settingsRepo repositories.SettingsRepository, jwtService services.JWTService, options AuthOptions) *AuthHandlers {
 	return &AuthHandlers{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:         userRepo,
+		inviteRepo:       inviteRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		sessionRepo:      sessionRepo,
+		settingsRepo:     settingsRepo,
+		jwtService:       jwtService,
+		options:          options,
 	}
 }
 
 // RegisterUser handles user registration
 func (h *AuthHandlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Parse request body
 	var req struct {
 		User entities.UserRegistration `json:"user"`
@@ -39,12 +81,19 @@ func (h *AuthHandlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate user data
+	// Validate user data in its stored form
+	req.User.Normalize()
 	if validationErr := req.User.Validate(); validationErr != nil {
 		writeValidationErrors(w, validationErr)
 		return
 	}
 
+	age, ageErr := h.checkAge(&req.User)
+	if ageErr != nil {
+		writeValidationErrors(w, ageErr)
+		return
+	}
+
 	// Check if email already exists
 	if exists, err := h.userRepo.EmailExists(req.User.Email); err != nil {
 		writeError(w, http.StatusInternalServerError, "Internal server error")
@@ -63,15 +112,56 @@ func (h *AuthHandlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create user
+	// Claim the invite before creating the account so it cannot be used twice
+	if h.options.RequireInvite {
+		claimed := false
+		if req.User.Invite != "" {
+			var err error
+			if claimed, err = h.inviteRepo.Claim(req.User.Invite); err != nil {
+				writeError(w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+		}
+		if !claimed {
+			writeValidationErrors(w, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+				Field:   "invite",
+				Message: "A valid invite is required to register",
+			}}})
+			return
+		}
+	}
+
+	// Create user, remembering the network for abuse detection
+	req.User.Network = h.networkHash(r)
 	user, err := h.userRepo.Create(&req.User)
 	if err != nil {
+		if h.options.RequireInvite {
+			if err := h.inviteRepo.Release(req.User.Invite); err != nil {
+				log.Printf("⚠️  Failed to release invite: %v", err)
+			}
+		}
 		writeError(w, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user)
+	if h.options.RequireInvite {
+		if err := h.inviteRepo.Redeem(req.User.Invite, user.ID); err != nil {
+			log.Printf("⚠️  Failed to redeem invite: %v", err)
+		}
+	}
+
+	if age >= 0 && age < h.options.AdultAge {
+		restricted := entities.DefaultUserSettings().RestrictForMinor()
+		if _, err := h.settingsRepo.Update(user.ID, &entities.UserSettingsUpdate{
+			ProfileVisibility: &restricted.ProfileVisibility,
+			Searchable:        &restricted.Searchable,
+		}); err != nil {
+			log.Printf("⚠️  Failed to restrict settings of minor user %d: %v", user.ID, err)
+		}
+	}
+
+	// Start a session for this device and issue its token
+	token, err := h.startSession(r, user)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
@@ -82,13 +172,42 @@ func (h *AuthHandlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, response)
 }
 
-// LoginUser handles user login
-func (h *AuthHandlers) LoginUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
+// checkAge applies the age gate to a registration and returns the user's age,
+// or -1 when it is not known. Where birthdates are not required the user only
+// confirms the minimum age, and any birthdate sent is dropped unstored.
+func (h *AuthHandlers) checkAge(registration *entities.UserRegistration) (int, *entities.ValidationErrors) {
+	if !h.options.RequireBirthdate {
+		registration.Birthdate = ""
+		if h.options.MinimumAge > 0 && !registration.AgeConfirmed {
+			return -1, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+				Field:   "ageConfirmed",
+				Message: fmt.Sprintf("You must confirm you are at least %d years old to register", h.options.MinimumAge),
+			}}}
+		}
+		return -1, nil
+	}
+
+	if registration.Birthdate == "" {
+		return -1, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+			Field:   "birthdate",
+			Message: "birthdate is required",
+		}}}
 	}
 
+	// Validate has already checked the format
+	birthdate, _ := entities.ParseBirthdate(registration.Birthdate)
+	age := entities.AgeOn(birthdate, time.Now())
+	if age < h.options.MinimumAge {
+		return -1, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+			Field:   "birthdate",
+			Message: fmt.Sprintf("You must be at least %d years old to register", h.options.MinimumAge),
+		}}}
+	}
+	return age, nil
+}
+
+// LoginUser handles user login
+func (h *AuthHandlers) LoginUser(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req struct {
 		User entities.UserLogin `json:"user"`
@@ -100,26 +219,72 @@ func (h *AuthHandlers) LoginUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate login data
+	req.User.Normalize()
 	if validationErr := req.User.Validate(); validationErr != nil {
 		writeValidationErrors(w, validationErr)
 		return
 	}
 
+	// Credential stuffing from one network is refused before any password check
+	now := time.Now()
+	network := h.networkHash(r)
+	if h.options.MaxFailuresPerNetwork > 0 {
+		failures, err := h.userRepo.CountNetworkLoginFailures(network, now.Add(-h.options.FailureWindow))
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to log in")
+			return
+		}
+		if failures >= h.options.MaxFailuresPerNetwork {
+			writeTooManyRequests(w, h.options.FailureWindow, "Too many failed logins, please try again later")
+			return
+		}
+	}
+
 	// Get user by email
 	user, err := h.userRepo.GetByEmail(req.User.Email)
 	if err != nil {
-		writeError(w, http.StatusUnauthorized, "Invalid email or password")
+		h.loginFailed(w, nil, network, now)
+		return
+	}
+
+	if user.IsLocked(now) {
+		writeRetryLater(w, http.StatusLocked, user.LockedUntil.Sub(now), "Account is temporarily locked after repeated failed logins")
 		return
 	}
 
 	// Verify password
 	if !h.userRepo.VerifyPassword(user, req.User.Password) {
-		writeError(w, http.StatusUnauthorized, "Invalid email or password")
+		h.loginFailed(w, user, network, now)
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user)
+	if h.options.MaxFailuresPerAccount > 0 {
+		if err := h.userRepo.ClearLoginFailures(user.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to log in")
+			return
+		}
+	}
+
+	if user.IsBanned() {
+		writeError(w, http.StatusForbidden, "Account is banned")
+		return
+	}
+
+	// Logging in reactivates a deactivated account until the grace period ends
+	if user.IsDeactivated() {
+		if !user.CanReactivate(time.Now(), h.options.DeactivationGrace) {
+			writeError(w, http.StatusForbidden, "Account is deactivated")
+			return
+		}
+		if err := h.userRepo.Reactivate(user.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to reactivate account")
+			return
+		}
+		user.DeactivatedAt = nil
+	}
+
+	// Start a session for this device and issue its token
+	token, err := h.startSession(r, user)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
@@ -130,13 +295,164 @@ func (h *AuthHandlers) LoginUser(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, response)
 }
 
-// GetCurrentUser handles getting current user info
-func (h *AuthHandlers) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+// LogoutUser handles logging out by revoking the token the request was made
+// with; it is rejected from then on, even before it expires
+func (h *AuthHandlers) LogoutUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	tokenString, err := extractToken(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	claims, err := h.jwtService.ValidateToken(tokenString)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	// Logging out ends the session on this device
+	if sessionID := middleware.SessionIDFromContext(r); sessionID != "" {
+		if err := h.sessionRepo.Revoke(userID, sessionID, time.Now().UTC()); err != nil && !containsString(err.Error(), "not found") {
+			writeError(w, http.StatusInternalServerError, "Failed to log out")
+			return
+		}
+	}
+
+	// Tokens issued before revocation existed have no ID; they only expire
+	tokenID, _ := (*claims)["jti"].(string)
+	if tokenID == "" {
+		writeError(w, http.StatusBadRequest, "Token cannot be revoked")
+		return
+	}
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	if err := h.revokedTokenRepo.Revoke(tokenID, userID, expiresAt.Time); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckAvailability handles checking whether a username and/or email can be
+// used to register, applying the same normalization and validation as registration
+func (h *AuthHandlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !query.Has("username") && !query.Has("email") {
+		writeError(w, http.StatusBadRequest, "Provide a username or email to check")
 		return
 	}
 
+	var response entities.AvailabilityResponse
+
+	if query.Has("username") {
+		username := entities.NormalizeUsername(query.Get("username"))
+		availability, err := checkAvailability(username, entities.ValidateUsername, h.userRepo.UsernameExists, "username is already taken")
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		response.Username = availability
+	}
+
+	if query.Has("email") {
+		email := entities.NormalizeEmail(query.Get("email"))
+		availability, err := checkAvailability(email, entities.ValidateEmail, h.userRepo.EmailExists, "email is already registered")
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		response.Email = availability
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// loginFailed records a failed login for the user, or for no account when
+// user is nil, locking the account once it has failed too often, and writes
+// the 401 response. The response is the same whether or not the account
+// exists or was just locked, so failures do not reveal either.
+func (h *AuthHandlers) loginFailed(w http.ResponseWriter, user *entities.User, network string, now time.Time) {
+	if h.options.MaxFailuresPerAccount > 0 || h.options.MaxFailuresPerNetwork > 0 {
+		var userID int64
+		if user != nil {
+			userID = user.ID
+		}
+		failures, err := h.userRepo.RecordLoginFailure(userID, network, now, now.Add(-h.options.FailureWindow))
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to log in")
+			return
+		}
+		if user != nil && h.options.MaxFailuresPerAccount > 0 && failures >= h.options.MaxFailuresPerAccount {
+			if err := h.userRepo.LockUntil(user.ID, now.Add(h.options.LockoutDuration)); err != nil {
+				writeError(w, http.StatusInternalServerError, "Failed to log in")
+				return
+			}
+		}
+	}
+
+	writeError(w, http.StatusUnauthorized, "Invalid email or password")
+}
+
+// startSession records a login from the request's device and issues a token for it
+func (h *AuthHandlers) startSession(r *http.Request, user *entities.User) (string, error) {
+	now := time.Now().UTC()
+	session, err := h.sessionRepo.Create(user.ID, r.UserAgent(), middleware.ClientIP(r), now, now.Add(h.options.SessionLifetime))
+	if err != nil {
+		return "", err
+	}
+	return h.jwtService.GenerateSessionToken(user, session.ID)
+}
+
+// renewSession issues a new token for the request's session, extending it.
+// Requests with a token from before sessions existed start one.
+func (h *AuthHandlers) renewSession(r *http.Request, user *entities.User) (string, error) {
+	sessionID := middleware.SessionIDFromContext(r)
+	if sessionID == "" {
+		return h.startSession(r, user)
+	}
+
+	if err := h.sessionRepo.Extend(sessionID, time.Now().UTC().Add(h.options.SessionLifetime)); err != nil {
+		return "", err
+	}
+	return h.jwtService.GenerateSessionToken(user, sessionID)
+}
+
+// networkHash identifies the client's network without storing its IP
+func (h *AuthHandlers) networkHash(r *http.Request) string {
+	sum := sha256.Sum256([]byte(h.options.NetworkSalt + "|" + middleware.ClientIP(r)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// checkAvailability validates a normalized value and, if valid, checks that it is not taken
+func checkAvailability(value string, validate func(string) string, exists func(string) (bool, error), takenMessage string) (*entities.Availability, error) {
+	if message := validate(value); message != "" {
+		return &entities.Availability{Value: value, Message: message}, nil
+	}
+
+	taken, err := exists(value)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return &entities.Availability{Value: value, Message: takenMessage}, nil
+	}
+
+	return &entities.Availability{Value: value, Available: true}, nil
+}
+
+// GetCurrentUser handles getting current user info
+func (h *AuthHandlers) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -165,11 +481,6 @@ func (h *AuthHandlers) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 // UpdateUser handles updating current user info
 func (h *AuthHandlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -239,7 +550,7 @@ func (h *AuthHandlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate new JWT token (in case username changed)
-	token, err := h.jwtService.GenerateToken(updatedUser)
+	token, err := h.renewSession(r, updatedUser)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
@@ -248,4 +559,46 @@ func (h *AuthHandlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Return updated user response
 	response := updatedUser.ToUserResponse(token)
 	writeJSON(w, http.StatusOK, response)
-}
\ No newline at end of file
+}
+// DeactivateAccount handles temporarily deactivating the current user's
+// account. Their content is hidden but kept, and logging in again within the
+// grace period reactivates it.
+func (h *AuthHandlers) DeactivateAccount(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		User entities.AccountDeactivation `json:"user"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	user, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	if !h.userRepo.VerifyPassword(user, req.User.Password) {
+		writeError(w, http.StatusUnauthorized, "Invalid password")
+		return
+	}
+
+	now := time.Now().UTC()
+	if err := h.userRepo.Deactivate(userID, now); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to deactivate account")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.AccountDeactivationResponse{
+		DeactivatedAt:    now,
+		ReactivateBefore: now.Add(h.options.DeactivationGrace),
+	})
+}
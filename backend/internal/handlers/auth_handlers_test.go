@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
 
 	"github.com/emotab87/vibe_coding/backend/internal/database"
 	"github.com/emotab87/vibe_coding/backend/internal/entities"
@@ -42,8 +46,12 @@ func setupTestDB(t *testing.T) *database.DB {
 func setupTestHandlers(t *testing.T) (*AuthHandlers, *database.DB) {
 	db := setupTestDB(t)
 	userRepo := repositories.NewUserRepository(db)
-	jwtService := services.NewJWTService("test-secret-key", 24)
-	handlers := NewAuthHandlers(userRepo, jwtService)
+	jwtService := services.NewJWTService("test-secret-key", services.NewTokenPolicy(services.TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
+	handlers := NewAuthHandlers(userRepo, repositories.NewInviteRepository(db), repositories.NewRevokedTokenRepository(db), repositories.NewSessionRepository(db), repositories.NewSettingsRepository(db), jwtService, AuthOptions{
+		DeactivationGrace: 30 * 24 * time.Hour,
+		NetworkSalt:       "test-salt",
+		SessionLifetime:   24 * time.Hour,
+	})
 	
 	return handlers, db
 }
@@ -499,4 +507,359 @@ func TestAuthHandlers_DuplicateRegistration(t *testing.T) {
 	if w3.Code != http.StatusBadRequest {
 		t.Errorf("Expected status %d for duplicate username, got %d", http.StatusBadRequest, w3.Code)
 	}
-}
\ No newline at end of file
+}
+func TestAuthHandlers_CheckAvailability(t *testing.T) {
+	handlers, db := setupTestHandlers(t)
+	defer cleanupTestDB(db)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"username": "testuser",
+			"email":    "Test@Example.com",
+			"password": "password123",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handlers.RegisterUser(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Registration failed: %d", w.Code)
+	}
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedStatus    int
+		usernameAvailable *bool
+		emailAvailable    *bool
+	}{
+		{"Missing parameters", "", http.StatusBadRequest, nil, nil},
+		{"Taken username", "?username=testuser", http.StatusOK, boolPtr(false), nil},
+		{"Free username", "?username=newuser", http.StatusOK, boolPtr(true), nil},
+		{"Invalid username", "?username=ab", http.StatusOK, boolPtr(false), nil},
+		{"Taken email in another case", "?email=%20TEST@example.COM", http.StatusOK, nil, boolPtr(false)},
+		{"Both", "?username=newuser&email=new@example.com", http.StatusOK, boolPtr(true), boolPtr(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/users/check"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handlers.CheckAvailability(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response entities.AvailabilityResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if (response.Username == nil) != (tt.usernameAvailable == nil) {
+				t.Fatalf("Unexpected username result %+v", response.Username)
+			}
+			if tt.usernameAvailable != nil && response.Username.Available != *tt.usernameAvailable {
+				t.Errorf("Expected username available=%v, got %+v", *tt.usernameAvailable, response.Username)
+			}
+			if (response.Email == nil) != (tt.emailAvailable == nil) {
+				t.Fatalf("Unexpected email result %+v", response.Email)
+			}
+			if tt.emailAvailable != nil && response.Email.Available != *tt.emailAvailable {
+				t.Errorf("Expected email available=%v, got %+v", *tt.emailAvailable, response.Email)
+			}
+		})
+	}
+}
+
+func TestAuthHandlers_DeactivateAccount(t *testing.T) {
+	handlers, db := setupTestHandlers(t)
+	defer cleanupTestDB(db)
+
+	registerBody, _ := json.Marshal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"username": "testuser",
+			"email":    "test@example.com",
+			"password": "password123",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(registerBody))
+	w := httptest.NewRecorder()
+	handlers.RegisterUser(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to register test user: %d", w.Code)
+	}
+
+	deactivate := func(password string) int {
+		body, _ := json.Marshal(map[string]interface{}{
+			"user": map[string]interface{}{"password": password},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/user/deactivate", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, int64(1)))
+		w := httptest.NewRecorder()
+		handlers.DeactivateAccount(w, req)
+		return w.Code
+	}
+	login := func() int {
+		body, _ := json.Marshal(map[string]interface{}{
+			"user": map[string]interface{}{"email": "test@example.com", "password": "password123"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/users/login", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		handlers.LoginUser(w, req)
+		return w.Code
+	}
+
+	if code := deactivate("wrongpassword"); code != http.StatusUnauthorized {
+		t.Fatalf("Expected status %d for wrong password, got %d", http.StatusUnauthorized, code)
+	}
+	if code := deactivate("password123"); code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+	}
+
+	// Logging in within the grace period reactivates the account
+	if code := login(); code != http.StatusOK {
+		t.Fatalf("Expected login to reactivate with status %d, got %d", http.StatusOK, code)
+	}
+	user, err := handlers.userRepo.GetByID(1)
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if user.IsDeactivated() {
+		t.Error("Expected login to reactivate the account")
+	}
+
+	// After the grace period login stays blocked
+	if err := handlers.userRepo.Deactivate(1, time.Now().Add(-31*24*time.Hour)); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	if code := login(); code != http.StatusForbidden {
+		t.Errorf("Expected status %d after the grace period, got %d", http.StatusForbidden, code)
+	}
+}
+
+func TestAuthHandlers_LogoutUser(t *testing.T) {
+	handlers, db := setupTestHandlers(t)
+	defer cleanupTestDB(db)
+
+	registerBody, _ := json.Marshal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"username": "testuser",
+			"email":    "test@example.com",
+			"password": "password123",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(registerBody))
+	w := httptest.NewRecorder()
+	handlers.RegisterUser(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to register test user: %d", w.Code)
+	}
+	var registered entities.UserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &registered); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	token := registered.User.Token
+
+	// Requests pass through the auth middleware with the denylist, as routed
+	protected := middleware.AuthMiddleware(middleware.TokenOptions{
+		Secret:  "test-secret-key",
+		Revoked: handlers.revokedTokenRepo.IsRevoked,
+	})
+	call := func(handler http.HandlerFunc) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/users/logout", nil)
+		req.Header.Set("Authorization", "Token "+token)
+		w := httptest.NewRecorder()
+		protected(handler).ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := call(handlers.GetCurrentUser); code != http.StatusOK {
+		t.Fatalf("Expected status %d before logout, got %d", http.StatusOK, code)
+	}
+	if code := call(handlers.LogoutUser); code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d", http.StatusNoContent, code)
+	}
+
+	// The token is rejected from then on, though it has not expired
+	if code := call(handlers.GetCurrentUser); code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d after logout, got %d", http.StatusUnauthorized, code)
+	}
+}
+
+func TestAuthHandlers_LoginLockout(t *testing.T) {
+	handlers, db := setupTestHandlers(t)
+	defer cleanupTestDB(db)
+	handlers.options.MaxFailuresPerAccount = 3
+	handlers.options.MaxFailuresPerNetwork = 5
+	handlers.options.FailureWindow = time.Hour
+	handlers.options.LockoutDuration = 15 * time.Minute
+
+	registerBody, _ := json.Marshal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"username": "testuser",
+			"email":    "test@example.com",
+			"password": "password123",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(registerBody))
+	w := httptest.NewRecorder()
+	handlers.RegisterUser(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to register test user: %d", w.Code)
+	}
+
+	login := func(email, password, remoteAddr string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"user": map[string]interface{}{"email": email, "password": password},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/users/login", bytes.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handlers.LoginUser(w, req)
+		return w
+	}
+
+	for i := 0; i < 3; i++ {
+		if w := login("test@example.com", "wrongpassword", "192.0.2.1:1234"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("Expected status %d for failure %d, got %d", http.StatusUnauthorized, i+1, w.Code)
+		}
+	}
+
+	// The account stays locked even for the right password, from anywhere
+	w = login("test@example.com", "password123", "198.51.100.1:1234")
+	if w.Code != http.StatusLocked {
+		t.Fatalf("Expected status %d once locked, got %d", http.StatusLocked, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header on a locked account")
+	}
+
+	// The lock lifts after the cool-down, and logging in clears the failures
+	if _, err := db.Exec("UPDATE users SET locked_until = ?", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Failed to expire lock: %v", err)
+	}
+	if w := login("test@example.com", "password123", "198.51.100.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d after the cool-down, got %d", http.StatusOK, w.Code)
+	}
+	if w := login("test@example.com", "wrongpassword", "198.51.100.1:1234"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected a fresh failure count after logging in, got %d", w.Code)
+	}
+
+	// A network guessing across accounts is refused before passwords are checked
+	for i := 0; i < 5; i++ {
+		login(fmt.Sprintf("user%d@example.com", i), "password123", "203.0.113.1:1234")
+	}
+	w = login("test@example.com", "password123", "203.0.113.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d for a stuffing network, got %d", http.StatusTooManyRequests, w.Code)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestSessionHandlers_RevokeOtherDevice(t *testing.T) {
+	handlers, db := setupTestHandlers(t)
+	defer cleanupTestDB(db)
+	sessionHandlers := NewSessionHandlers(handlers.sessionRepo)
+
+	registerBody, _ := json.Marshal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"username": "sessionuser",
+			"email":    "session@example.com",
+			"password": "password123",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(registerBody))
+	w := httptest.NewRecorder()
+	handlers.RegisterUser(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to register test user: %d", w.Code)
+	}
+
+	// Log in from a phone too
+	loginBody, _ := json.Marshal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"email":    "session@example.com",
+			"password": "password123",
+		},
+	})
+	req = httptest.NewRequest(http.MethodPost, "/api/users/login", bytes.NewReader(loginBody))
+	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Version/17.0 Mobile/15E148 Safari/604.1")
+	w = httptest.NewRecorder()
+	handlers.LoginUser(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to log in: %d", w.Code)
+	}
+	var phone entities.UserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &phone); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Requests pass through the auth middleware with the session check, as routed
+	protected := middleware.AuthMiddleware(middleware.TokenOptions{
+		Secret: "test-secret-key",
+		Sessions: func(sessionID string) (bool, error) {
+			return handlers.sessionRepo.Use(sessionID, time.Now().UTC())
+		},
+	})
+	call := func(token string, handler http.HandlerFunc, vars map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/user/sessions", nil)
+		req.Header.Set("Authorization", "Token "+token)
+		req = mux.SetURLVars(req, vars)
+		w := httptest.NewRecorder()
+		protected(handler).ServeHTTP(w, req)
+		return w
+	}
+
+	// And again from this device
+	var laptop entities.UserResponse
+	req = httptest.NewRequest(http.MethodPost, "/api/users/login", bytes.NewReader(loginBody))
+	w = httptest.NewRecorder()
+	handlers.LoginUser(w, req)
+	if err := json.Unmarshal(w.Body.Bytes(), &laptop); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	w = call(laptop.User.Token, sessionHandlers.ListSessions, nil)
+	var list entities.SessionsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to decode sessions: %v", err)
+	}
+	if list.SessionsCount != 3 {
+		t.Fatalf("Expected a session per login, got %+v", list)
+	}
+
+	var phoneSession string
+	currentCount := 0
+	for _, session := range list.Sessions {
+		if session.Current {
+			currentCount++
+		}
+		if session.Device == "Safari on iOS" {
+			phoneSession = session.ID
+		}
+	}
+	if currentCount != 1 || phoneSession == "" {
+		t.Fatalf("Expected the current session and the phone to be recognized, got %+v", list.Sessions)
+	}
+
+	if code := call(phone.User.Token, handlers.GetCurrentUser, nil).Code; code != http.StatusOK {
+		t.Fatalf("Expected the phone's token to work before revocation, got %d", code)
+	}
+	if code := call(laptop.User.Token, sessionHandlers.RevokeSession, map[string]string{"id": phoneSession}).Code; code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d", http.StatusNoContent, code)
+	}
+	if code := call(phone.User.Token, handlers.GetCurrentUser, nil).Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected the phone's token to be rejected, got %d", code)
+	}
+	if code := call(laptop.User.Token, handlers.GetCurrentUser, nil).Code; code != http.StatusOK {
+		t.Errorf("Expected the current session to keep working, got %d", code)
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image/png"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// avatarSize is the width and height of generated avatars in pixels
+const avatarSize = 240
+
+// AvatarHandlers serves generated avatars for users without an uploaded image
+type AvatarHandlers struct {
+	userRepo repositories.UserRepository
+}
+
+// NewAvatarHandlers creates a new avatar handlers instance
+func NewAvatarHandlers(userRepo repositories.UserRepository) *AvatarHandlers {
+	return &AvatarHandlers{
+		userRepo: userRepo,
+	}
+}
+
+// GetAvatar handles serving a user's identicon as a PNG. The image depends
+// only on the username, so it is cached aggressively and revalidated by ETag.
+func (h *AvatarHandlers) GetAvatar(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	user, err := h.userRepo.GetByUsername(username)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(user.Username))
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, services.GenerateIdenticon(user.Username, avatarSize)); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate avatar")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
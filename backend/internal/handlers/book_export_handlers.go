@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// BookExportHandlers handles bundling series and picked articles into books
+type BookExportHandlers struct {
+	exporter    services.BookExporter
+	exportRepo  repositories.BookExportRepository
+	seriesRepo  repositories.SeriesRepository
+	articleRepo repositories.ArticleRepository
+}
+
+// NewBookExportHandlers creates a new book export handlers instance
+func NewBookExportHandlers(exporter services.BookExporter, exportRepo repositories.BookExportRepository, seriesRepo repositories.SeriesRepository, articleRepo repositories.ArticleRepository) *BookExportHandlers {
+	return &BookExportHandlers{
+		exporter:    exporter,
+		exportRepo:  exportRepo,
+		seriesRepo:  seriesRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ExportSeries handles asking for a series as a book (?format=epub), with its
+// published articles as chapters in series order. Like article exports, the
+// book is 202 Accepted while it is queued and the same request returns it
+// with a download link once it is ready.
+func (h *BookExportHandlers) ExportSeries(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	series, err := h.seriesRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Series not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get series")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	supported := h.exporter.Formats()
+	if !containsFormat(supported, format) {
+		writeError(w, http.StatusBadRequest, "format must be one of: "+strings.Join(supported, ", "))
+		return
+	}
+
+	export, err := h.exporter.RequestSeries(series, userID, format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to export series")
+		return
+	}
+
+	h.writeExport(w, export)
+}
+
+// ExportArticles handles bundling some of the user's own articles into a
+// book, with chapters in the order the articles are listed
+func (h *BookExportHandlers) ExportArticles(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Export entities.BookExportCreate `json:"export"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	req.Export.Title = strings.TrimSpace(req.Export.Title)
+	if validationErr := req.Export.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	if req.Export.Format == "" {
+		req.Export.Format = entities.ExportFormatEPUB
+	}
+	supported := h.exporter.Formats()
+	if !containsFormat(supported, req.Export.Format) {
+		writeError(w, http.StatusBadRequest, "format must be one of: "+strings.Join(supported, ", "))
+		return
+	}
+
+	articleIDs := make([]int64, 0, len(req.Export.Articles))
+	for _, slug := range req.Export.Articles {
+		article, err := h.articleRepo.GetBySlug(slug)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				writeError(w, http.StatusNotFound, "Article not found: "+slug)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, "Failed to get article")
+			return
+		}
+		if article.AuthorID != userID {
+			writeError(w, http.StatusForbidden, "You can only export your own articles")
+			return
+		}
+		articleIDs = append(articleIDs, article.ID)
+	}
+
+	export, err := h.exporter.RequestArticles(userID, req.Export.Title, articleIDs, req.Export.Format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to export articles")
+		return
+	}
+
+	h.writeExport(w, export)
+}
+
+// GetBookExport handles checking on one of the user's book exports
+func (h *BookExportHandlers) GetBookExport(w http.ResponseWriter, r *http.Request) {
+	export, ok := h.ownExport(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeExport(w, export)
+}
+
+// DownloadBookExport handles downloading a rendered book; only the user who
+// asked for it can download it
+func (h *BookExportHandlers) DownloadBookExport(w http.ResponseWriter, r *http.Request) {
+	export, ok := h.ownExport(w, r)
+	if !ok {
+		return
+	}
+	if export.Status != entities.ExportStatusReady {
+		writeError(w, http.StatusConflict, "Export is not ready")
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bookFilename(export)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Content)
+}
+
+// ownExport loads the book export named by the {id} path variable, writing an
+// error response if it cannot or it belongs to someone else
+func (h *BookExportHandlers) ownExport(w http.ResponseWriter, r *http.Request) (*entities.BookExport, bool) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+
+	exportID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid export ID")
+		return nil, false
+	}
+
+	export, err := h.exportRepo.Get(exportID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Export not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get export")
+		return nil, false
+	}
+	if export.UserID != userID {
+		writeError(w, http.StatusNotFound, "Export not found")
+		return nil, false
+	}
+
+	return export, true
+}
+
+// writeExport responds 202 Accepted while the book is queued and with its
+// download link once it is ready
+func (h *BookExportHandlers) writeExport(w http.ResponseWriter, export *entities.BookExport) {
+	status := http.StatusAccepted
+	if export.Status == entities.ExportStatusReady {
+		export.DownloadURL = fmt.Sprintf("/api/exports/books/%d/download", export.ID)
+		status = http.StatusOK
+	}
+	writeJSON(w, status, entities.BookExportResponse{Export: *export})
+}
+
+// bookFilename names the download after the book's title
+func bookFilename(export *entities.BookExport) string {
+	name := entities.GenerateSlug(export.Title)
+	if name == "" {
+		name = fmt.Sprintf("book-%d", export.ID)
+	}
+	return name + "." + export.Format
+}
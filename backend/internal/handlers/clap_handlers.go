@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// ClapHandlers handles article clap HTTP requests
+type ClapHandlers struct {
+	clapRepo    repositories.ClapRepository
+	articleRepo repositories.ArticleRepository
+	maxClaps    int
+}
+
+// NewClapHandlers creates a new clap handlers instance; maxClaps caps the
+// claps one user can give an article
+func NewClapHandlers(clapRepo repositories.ClapRepository, articleRepo repositories.ArticleRepository, maxClaps int) *ClapHandlers {
+	return &ClapHandlers{
+		clapRepo:    clapRepo,
+		articleRepo: articleRepo,
+		maxClaps:    maxClaps,
+	}
+}
+
+// ClapArticle handles clapping for an article. Claps beyond the per-user cap
+// are dropped, and a retried request carrying the same Idempotency-Key header
+// is not counted twice.
+func (h *ClapHandlers) ClapArticle(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req entities.ClapCreate
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if len(key) > entities.MaxIdempotencyKeyLength {
+		writeError(w, http.StatusBadRequest, "Idempotency-Key header is too long")
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	if article.AuthorID == userID {
+		writeError(w, http.StatusForbidden, "You cannot clap for your own article")
+		return
+	}
+
+	userClaps, err := h.clapRepo.Clap(userID, article.ID, req.Count, h.maxClaps, key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to clap for article")
+		return
+	}
+
+	// Reload for the updated claps count
+	article, err = h.articleRepo.GetByID(article.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+	article.UserClaps = userClaps
+
+	writeJSON(w, http.StatusOK, entities.ArticleResponse{Article: *article})
+}
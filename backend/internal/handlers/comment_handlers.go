@@ -1,36 +1,59 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
 	"github.com/emotab87/vibe_coding/backend/internal/entities"
 	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
 )
 
 // CommentHandlers handles comment-related HTTP requests
 type CommentHandlers struct {
-	commentRepo repositories.CommentRepository
-	articleRepo repositories.ArticleRepository
+	commentRepo         repositories.CommentRepository
+	articleRepo         repositories.ArticleRepository
+	userRepo            repositories.UserRepository
+	followRepo          repositories.FollowRepository
+	notificationService services.NotificationService
+	replyTokens         services.ReplyTokenService
+	rateLimiter         services.CommentRateLimiter
+	quotas              services.QuotaService
+	options             CommentOptions
+}
+
+// CommentOptions holds deployment settings for comment handling
+type CommentOptions struct {
+	// ReplyDomain, when set, gives comment emails a reply-by-email address on that domain
+	ReplyDomain string
+	// DeleteMode is either entities.CommentDeleteModeHard or entities.CommentDeleteModePlaceholder
+	DeleteMode string
 }
 
 // NewCommentHandlers creates a new comment handlers instance
-func NewCommentHandlers(commentRepo repositories.CommentRepository, articleRepo repositories.ArticleRepository) *CommentHandlers {
+func NewCommentHandlers(commentRepo repositories.CommentRepository, articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, followRepo repositories.FollowRepository, notificationService services.NotificationService, replyTokens services.ReplyTokenService, rateLimiter services.CommentRateLimiter, quotas services.QuotaService, options CommentOptions) *CommentHandlers {
 	return &CommentHandlers{
-		commentRepo: commentRepo,
-		articleRepo: articleRepo,
+		commentRepo:         commentRepo,
+		articleRepo:         articleRepo,
+		userRepo:            userRepo,
+		followRepo:          followRepo,
+		notificationService: notificationService,
+		replyTokens:         replyTokens,
+		rateLimiter:         rateLimiter,
+		quotas:              quotas,
+		options:             options,
 	}
 }
 
 // CreateComment handles comment creation
 func (h *CommentHandlers) CreateComment(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -57,6 +80,16 @@ func (h *CommentHandlers) CreateComment(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Articles awaiting review cannot be discussed yet
+	if !article.IsPublished() {
+		writeError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	if !h.canComment(w, article, userID) {
+		return
+	}
+
 	// Parse request body
 	var req struct {
 		Comment entities.CommentCreate `json:"comment"`
@@ -73,6 +106,16 @@ func (h *CommentHandlers) CreateComment(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Replies must answer a comment on the same article
+	if req.Comment.ParentID != nil && !h.checkParent(w, article, *req.Comment.ParentID) {
+		return
+	}
+
+	// Enforce per-user cooldowns, hourly caps and the daily quota
+	if !h.checkRateLimit(w, userID) {
+		return
+	}
+
 	// Create comment
 	comment, err := h.commentRepo.Create(userID, article.ID, &req.Comment)
 	if err != nil {
@@ -80,6 +123,8 @@ func (h *CommentHandlers) CreateComment(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.notifyArticleAuthor(article, comment)
+
 	// Return comment response
 	response := comment.ToCommentResponse()
 	writeJSON(w, http.StatusCreated, response)
@@ -87,11 +132,6 @@ func (h *CommentHandlers) CreateComment(w http.ResponseWriter, r *http.Request)
 
 // GetCommentsByArticle handles comment listing for an article
 func (h *CommentHandlers) GetCommentsByArticle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	// Get slug from URL path
 	vars := mux.Vars(r)
 	slug := vars["slug"]
@@ -100,8 +140,8 @@ func (h *CommentHandlers) GetCommentsByArticle(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Check if article exists
-	_, err := h.articleRepo.GetBySlug(slug)
+	// Check if article exists and is published
+	article, err := h.articleRepo.GetBySlug(slug)
 	if err != nil {
 		if containsString(err.Error(), "not found") {
 			writeError(w, http.StatusNotFound, "Article not found")
@@ -110,6 +150,10 @@ func (h *CommentHandlers) GetCommentsByArticle(w http.ResponseWriter, r *http.Re
 		writeError(w, http.StatusInternalServerError, "Failed to get article")
 		return
 	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusNotFound, "Article not found")
+		return
+	}
 
 	// Get comments for the article
 	comments, err := h.commentRepo.GetByArticleSlug(slug)
@@ -118,20 +162,165 @@ func (h *CommentHandlers) GetCommentsByArticle(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Mark the comment authors the signed-in reader follows
+	if userID, err := getUserIDFromContext(r); err == nil {
+		authors := make([]*entities.User, len(comments))
+		for i := range comments {
+			authors[i] = comments[i].Author
+		}
+		if err := h.followRepo.MarkFollowing(userID, authors); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get follows")
+			return
+		}
+	}
+
 	// Return comments response
 	response := entities.CommentsResponse{
-		Comments: comments,
+		Comments:       comments,
+		CommentsLocked: article.CommentsLocked,
 	}
 	writeJSON(w, http.StatusOK, response)
 }
 
-// DeleteComment handles comment deletion
-func (h *CommentHandlers) DeleteComment(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+// GetComment resolves a comment permalink to the comment and where it sits in
+// its article's discussion (?limit= sets how many threads a page holds)
+func (h *CommentHandlers) GetComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	comment, err := h.commentRepo.GetByID(commentID)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get comment")
+		return
+	}
+
+	// Comments on articles awaiting review are not shown
+	article, err := h.articleRepo.GetByID(comment.ArticleID)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+
+	comments, err := h.commentRepo.GetByArticleSlug(article.Slug)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get comments")
+		return
+	}
+
+	// Comments hidden from the article's list (e.g. by deactivated authors)
+	// cannot be linked to either
+	pageSize, _ := parsePage(r, 20, 100)
+	permalink := entities.LocateComment(comments, comment.ID, article.Slug, pageSize)
+	if permalink == nil {
+		writeError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.CommentPermalinkResponse{
+		Comment:   *comment,
+		Permalink: *permalink,
+	})
+}
+
+// UpdateComment handles editing a comment's body; only its author may edit it
+func (h *CommentHandlers) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get slug and comment ID from URL path
+	vars := mux.Vars(r)
+	commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	// Check if article exists
+	article, err := h.articleRepo.GetBySlug(vars["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	// Check the comment exists on this article (placeholders can't be edited)
+	existingComment, err := h.commentRepo.GetByID(commentID)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get comment")
+		return
+	}
+	if existingComment.Deleted || existingComment.ArticleID != article.ID {
+		writeError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+
+	// Check if user is the author
+	if existingComment.AuthorID != userID {
+		writeError(w, http.StatusForbidden, "You can only edit your own comments")
+		return
+	}
+
+	if !h.canComment(w, article, userID) {
 		return
 	}
 
+	// Parse request body
+	var req struct {
+		Comment entities.CommentUpdate `json:"comment"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate comment data
+	if validationErr := req.Comment.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	comment, err := h.commentRepo.Update(commentID, &req.Comment)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to update comment")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comment.ToCommentResponse())
+}
+
+// DeleteComment handles comment deletion
+func (h *CommentHandlers) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, err := getUserIDFromContext(r)
 	if err != nil {
@@ -172,7 +361,7 @@ func (h *CommentHandlers) DeleteComment(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Check if comment exists
+	// Check if comment exists (placeholders count as already deleted)
 	existingComment, err := h.commentRepo.GetByID(commentID)
 	if err != nil {
 		if containsString(err.Error(), "not found") {
@@ -182,6 +371,10 @@ func (h *CommentHandlers) DeleteComment(w http.ResponseWriter, r *http.Request)
 		writeError(w, http.StatusInternalServerError, "Failed to get comment")
 		return
 	}
+	if existingComment.Deleted {
+		writeError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
 
 	// Check if user is the author
 	if existingComment.AuthorID != userID {
@@ -189,7 +382,33 @@ func (h *CommentHandlers) DeleteComment(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Delete comment
+	// Delete comment, leaving a placeholder when configured
+	deleteComment := h.commentRepo.Delete
+	if h.options.DeleteMode == entities.CommentDeleteModePlaceholder {
+		deleteComment = h.commentRepo.SoftDelete
+	}
+
+	if err := deleteComment(commentID); err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to delete comment")
+		return
+	}
+
+	// Return 204 No Content for successful deletion
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HardDeleteComment handles permanently removing a comment (admins), regardless of delete mode
+func (h *CommentHandlers) HardDeleteComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
 	if err := h.commentRepo.Delete(commentID); err != nil {
 		if containsString(err.Error(), "not found") {
 			writeError(w, http.StatusNotFound, "Comment not found")
@@ -203,6 +422,262 @@ func (h *CommentHandlers) DeleteComment(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// CreateCommentFromEmail handles the mail provider's inbound webhook, turning a reply
+// to a comment notification into a comment on the article thread
+func (h *CommentHandlers) CreateCommentFromEmail(w http.ResponseWriter, r *http.Request) {
+	inbound, err := parseInboundEmail(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid inbound email payload")
+		return
+	}
+
+	// The signed token in the reply address identifies the replying user and the thread
+	match := replyAddressPattern.FindStringSubmatch(inbound.Recipient)
+	if match == nil {
+		writeError(w, http.StatusBadRequest, "Recipient is not a reply address")
+		return
+	}
+
+	userID, articleID, err := h.replyTokens.ParseToken(match[1])
+	if err != nil {
+		writeError(w, http.StatusForbidden, "Invalid reply token")
+		return
+	}
+
+	// The webhook is not authenticated as the user, so the account checks the auth
+	// middleware does must happen here or a banned user's old reply address still works
+	user, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusForbidden, "Invalid reply token")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if denial := user.AccessDenial(); denial != "" {
+		writeError(w, http.StatusForbidden, denial)
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(articleID)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if !h.canComment(w, article, userID) {
+		return
+	}
+
+	commentCreate := entities.CommentCreate{Body: entities.ExtractReplyText(inbound.Text)}
+	if validationErr := commentCreate.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	if !h.checkRateLimit(w, userID) {
+		return
+	}
+
+	comment, err := h.commentRepo.Create(userID, article.ID, &commentCreate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create comment")
+		return
+	}
+
+	h.notifyArticleAuthor(article, comment)
+
+	// Return comment response
+	response := comment.ToCommentResponse()
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// LockComments handles closing comments on an article (its author or a moderator)
+func (h *CommentHandlers) LockComments(w http.ResponseWriter, r *http.Request) {
+	h.setCommentsLocked(w, r, true)
+}
+
+// UnlockComments handles reopening comments on an article (its author or a moderator)
+func (h *CommentHandlers) UnlockComments(w http.ResponseWriter, r *http.Request) {
+	h.setCommentsLocked(w, r, false)
+}
+
+// setCommentsLocked locks or unlocks comments and responds with the article
+func (h *CommentHandlers) setCommentsLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if article.AuthorID != userID {
+		user, err := h.userRepo.GetByID(userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get user")
+			return
+		}
+		if !user.IsModerator() {
+			writeError(w, http.StatusForbidden, "You can only lock comments on your own articles")
+			return
+		}
+	}
+
+	if err := h.articleRepo.SetCommentsLocked(article.ID, locked); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update comment lock")
+		return
+	}
+
+	article, err = h.articleRepo.GetByID(article.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, article.ToArticleResponse())
+}
+
+// checkParent writes a validation error and returns false unless parentID is
+// a comment on the article that hasn't been deleted
+func (h *CommentHandlers) checkParent(w http.ResponseWriter, article *entities.Article, parentID int64) bool {
+	parent, err := h.commentRepo.GetByID(parentID)
+	if err != nil && !containsString(err.Error(), "not found") {
+		writeError(w, http.StatusInternalServerError, "Failed to get parent comment")
+		return false
+	}
+
+	if err != nil || parent.Deleted || parent.ArticleID != article.ID {
+		writeValidationErrors(w, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+			Field:   "parentId",
+			Message: "parent comment not found on this article",
+		}}})
+		return false
+	}
+
+	return true
+}
+
+// canComment rejects comments on locked articles unless the user is a
+// moderator, writing an error response if so
+func (h *CommentHandlers) canComment(w http.ResponseWriter, article *entities.Article, userID int64) bool {
+	if !article.CommentsLocked {
+		return true
+	}
+
+	user, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return false
+	}
+	if !user.IsModerator() {
+		writeError(w, http.StatusForbidden, "Comments are locked on this article")
+		return false
+	}
+
+	return true
+}
+
+// replyAddressPattern extracts the token from addresses like "Name <reply+TOKEN@example.com>"
+var replyAddressPattern = regexp.MustCompile(`reply\+([A-Za-z0-9.]+)@`)
+
+// parseInboundEmail reads the recipient and plain-text body from either a JSON or a
+// form-encoded provider payload, accepting the common field names used by providers
+func parseInboundEmail(r *http.Request) (*entities.InboundEmail, error) {
+	fields := map[string]string{}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		for key, value := range payload {
+			if str, ok := value.(string); ok {
+				fields[strings.ToLower(key)] = str
+			}
+		}
+	} else {
+		if err := r.ParseMultipartForm(10 << 20); err != nil && err != http.ErrNotMultipart {
+			return nil, err
+		}
+		for key, values := range r.Form {
+			if len(values) > 0 {
+				fields[strings.ToLower(key)] = values[0]
+			}
+		}
+	}
+
+	inbound := &entities.InboundEmail{
+		Recipient: firstNonEmpty(fields["recipient"], fields["to"]),
+		Text:      firstNonEmpty(fields["stripped-text"], fields["text"], fields["body-plain"], fields["textbody"]),
+	}
+	if inbound.Recipient == "" {
+		return nil, fmt.Errorf("missing recipient")
+	}
+
+	return inbound, nil
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// checkRateLimit writes a 429 response and returns false when the user must
+// wait before commenting, either for a cooldown or for their daily quota
+func (h *CommentHandlers) checkRateLimit(w http.ResponseWriter, userID int64) bool {
+	retryAfter, err := h.rateLimiter.Check(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to check comment rate limit")
+		return false
+	}
+
+	if retryAfter > 0 {
+		writeTooManyRequests(w, retryAfter, "You are commenting too quickly, please wait before posting again")
+		return false
+	}
+
+	return checkQuota(w, h.quotas, userID, entities.QuotaComments)
+}
+
+// notifyArticleAuthor tells the article author about a new comment; a failed
+// notification must not fail the comment itself
+func (h *CommentHandlers) notifyArticleAuthor(article *entities.Article, comment *entities.Comment) {
+	if article.AuthorID == comment.AuthorID {
+		return
+	}
+
+	message := fmt.Sprintf("%s commented on \"%s\"", comment.Author.Username, article.Title)
+	email := &services.NotificationEmail{Body: comment.Body}
+	if h.options.ReplyDomain != "" {
+		token := h.replyTokens.GenerateToken(article.AuthorID, article.ID)
+		email.ReplyTo = "reply+" + token + "@" + h.options.ReplyDomain
+		email.Body += "\n\nReply to this email to respond in the thread."
+	}
+
+	if err := h.notificationService.Notify(article.AuthorID, entities.EventNewComment, message, entities.CommentURL(article.Slug, comment.ID), email); err != nil {
+		log.Printf("⚠️  Failed to send comment notification: %v", err)
+	}
+}
+
 // Helper function to check string contains (case-insensitive)
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && findSubstring(toLowerCase(s), toLowerCase(substr)) >= 0
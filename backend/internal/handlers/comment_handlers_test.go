@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+func TestCommentHandlers_EmailReplyFromRestrictedAccount(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	userRepo := repositories.NewUserRepository(db)
+	articleRepo := repositories.NewArticleRepository(db, userRepo, 0)
+	commentRepo := repositories.NewCommentRepository(db, userRepo)
+	replyTokens := services.NewReplyTokenService("test-secret-key", services.NewTokenPolicy(services.TokenLifetimes{Reply: 24 * time.Hour}, 0))
+	handlers := NewCommentHandlers(commentRepo, articleRepo, userRepo, repositories.NewFollowRepository(db), nil, replyTokens, nil, nil, CommentOptions{})
+
+	users := make(map[string]*entities.User)
+	for _, name := range []string{"author", "banned", "deactivated"} {
+		user, err := userRepo.Create(&entities.UserRegistration{
+			Username: name,
+			Email:    name + "@example.com",
+			Password: "password123",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create user %s: %v", name, err)
+		}
+		users[name] = user
+	}
+	if err := userRepo.SetModerationStatus(users["banned"].ID, entities.ModerationBanned, time.Now().UTC()); err != nil {
+		t.Fatalf("Failed to ban user: %v", err)
+	}
+	if err := userRepo.Deactivate(users["deactivated"].ID, time.Now().UTC()); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+
+	article, err := articleRepo.Create(users["author"].ID, &entities.ArticleCreate{
+		Title:       "Replied to by email",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	for _, name := range []string{"banned", "deactivated"} {
+		t.Run(name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{
+				"recipient": "reply+" + replyTokens.GenerateToken(users[name].ID, article.ID) + "@reply.example.com",
+				"text":      "Sent from an account that may not comment",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks/email/inbound", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			handlers.CreateCommentFromEmail(rr, req)
+
+			if rr.Code != http.StatusForbidden {
+				t.Errorf("Expected status %d, got %d: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	comments, err := commentRepo.GetByArticleSlug(article.Slug)
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("Expected no comments from restricted accounts, got %d", len(comments))
+	}
+}
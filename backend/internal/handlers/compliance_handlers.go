@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ComplianceHandlers publishes the regional rules the deployment runs under
+type ComplianceHandlers struct {
+	compliance entities.Compliance
+}
+
+// NewComplianceHandlers creates a new compliance handlers instance
+func NewComplianceHandlers(compliance entities.Compliance) *ComplianceHandlers {
+	return &ComplianceHandlers{
+		compliance: compliance,
+	}
+}
+
+// GetCompliance handles returning the consent, residency and age rules, so
+// clients can prompt for consent and hide unavailable features up front
+func (h *ComplianceHandlers) GetCompliance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, entities.ComplianceResponse{Compliance: h.compliance})
+}
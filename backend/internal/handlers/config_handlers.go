@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/config"
+)
+
+// ConfigHandlers handles configuration introspection HTTP requests
+type ConfigHandlers struct {
+	cfg *config.Config
+}
+
+// NewConfigHandlers creates a new config handlers instance
+func NewConfigHandlers(cfg *config.Config) *ConfigHandlers {
+	return &ConfigHandlers{
+		cfg: cfg,
+	}
+}
+
+// GetConfig handles returning the effective configuration with secrets redacted (admin only)
+func (h *ConfigHandlers) GetConfig(w http.ResponseWriter, r *http.Request) {
+	response := map[string]interface{}{
+		"config": h.cfg.Describe(),
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"html"
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// EmailPreviewHandlers shows the emails the console sender captured, so
+// email copy can be iterated on in development without sending real mail
+type EmailPreviewHandlers struct {
+	capture *services.EmailCapture
+}
+
+// NewEmailPreviewHandlers creates a new email preview handlers instance;
+// capture is nil when emails are not being captured
+func NewEmailPreviewHandlers(capture *services.EmailCapture) *EmailPreviewHandlers {
+	return &EmailPreviewHandlers{
+		capture: capture,
+	}
+}
+
+// ListEmails handles listing the captured emails, newest first
+func (h *EmailPreviewHandlers) ListEmails(w http.ResponseWriter, r *http.Request) {
+	if h.capture == nil {
+		writeError(w, http.StatusNotFound, "Emails are only captured in development without SMTP")
+		return
+	}
+
+	emails := []entities.EmailPreview{}
+	for _, captured := range h.capture.Emails() {
+		message := captured.Message
+		preview := message.HTML
+		if preview == "" {
+			preview = "<pre>" + html.EscapeString(message.Body) + "</pre>"
+		}
+		emails = append(emails, entities.EmailPreview{
+			To:      message.To,
+			ReplyTo: message.ReplyTo,
+			Subject: message.Subject,
+			Text:    message.Body,
+			HTML:    preview,
+			SentAt:  captured.SentAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, entities.EmailPreviewsResponse{
+		Emails:      emails,
+		EmailsCount: len(emails),
+	})
+}
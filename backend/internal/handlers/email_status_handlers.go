@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// EmailStatusHandlers handles the email provider's bounce and complaint
+// notifications and shows admins the resulting deliverability of users' addresses
+type EmailStatusHandlers struct {
+	userRepo        repositories.UserRepository
+	emailStatusRepo repositories.EmailStatusRepository
+	softBounceLimit int
+}
+
+// NewEmailStatusHandlers creates a new email status handlers instance.
+// softBounceLimit soft bounces in a row suppress an address; 0 only
+// suppresses on hard bounces and complaints.
+func NewEmailStatusHandlers(userRepo repositories.UserRepository, emailStatusRepo repositories.EmailStatusRepository, softBounceLimit int) *EmailStatusHandlers {
+	return &EmailStatusHandlers{
+		userRepo:        userRepo,
+		emailStatusRepo: emailStatusRepo,
+		softBounceLimit: softBounceLimit,
+	}
+}
+
+// ReceiveEmailEvents handles the email provider's webhook. Events for
+// addresses that belong to no user are acknowledged and ignored, so the
+// provider does not retry them.
+func (h *EmailStatusHandlers) ReceiveEmailEvents(w http.ResponseWriter, r *http.Request) {
+	var batch entities.EmailEventBatch
+	if err := parseJSON(r, &batch); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := batch.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	recorded := 0
+	now := time.Now().UTC()
+	for _, event := range batch.Events {
+		user, err := h.userRepo.GetByEmail(entities.NormalizeEmail(event.Email))
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				continue
+			}
+			writeError(w, http.StatusInternalServerError, "Failed to record email events")
+			return
+		}
+
+		if _, err := h.emailStatusRepo.Record(user.ID, event, now, h.softBounceLimit); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to record email events")
+			return
+		}
+		recorded++
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"recorded": recorded})
+}
+
+// GetEmailStatus handles showing whether email still reaches a user
+func (h *EmailStatusHandlers) GetEmailStatus(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	status, err := h.emailStatusRepo.Get(user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get email status")
+		return
+	}
+
+	status.Username = user.Username
+	writeJSON(w, http.StatusOK, entities.EmailStatusResponse{EmailStatus: status})
+}
+
+// ClearEmailStatus handles lifting a suppression, e.g. once the user has
+// fixed their mailbox
+func (h *EmailStatusHandlers) ClearEmailStatus(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	if err := h.emailStatusRepo.Clear(user.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to clear email status")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.EmailStatusResponse{EmailStatus: entities.EmailStatus{
+		Username: user.Username,
+		Status:   entities.EmailDeliverable,
+	}})
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// ExportHandlers handles exporting articles to downloadable documents
+type ExportHandlers struct {
+	exporter    services.ArticleExporter
+	exportRepo  repositories.ExportRepository
+	articleRepo repositories.ArticleRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewExportHandlers creates a new export handlers instance
+func NewExportHandlers(exporter services.ArticleExporter, exportRepo repositories.ExportRepository, articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository) *ExportHandlers {
+	return &ExportHandlers{
+		exporter:    exporter,
+		exportRepo:  exportRepo,
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// ExportArticle handles asking for an article as a document (?format=pdf).
+// Rendering happens in the background: the export is 202 Accepted while it is
+// queued, and the same request returns it with a download link once it is ready.
+func (h *ExportHandlers) ExportArticle(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if !article.IsPublished() {
+		viewer, err := h.userRepo.GetByID(userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get user")
+			return
+		}
+		if !article.VisibleTo(viewer) {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+	}
+
+	format := r.URL.Query().Get("format")
+	supported := h.exporter.Formats()
+	if len(supported) == 0 {
+		writeError(w, http.StatusServiceUnavailable, "Article export is not configured")
+		return
+	}
+	if !containsFormat(supported, format) {
+		writeError(w, http.StatusBadRequest, "format must be one of: "+strings.Join(supported, ", "))
+		return
+	}
+
+	export, err := h.exporter.Request(article, userID, format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to export article")
+		return
+	}
+
+	status := http.StatusAccepted
+	if export.Status == entities.ExportStatusReady {
+		export.DownloadURL = fmt.Sprintf("/api/exports/%d/download", export.ID)
+		status = http.StatusOK
+	}
+	writeJSON(w, status, entities.ArticleExportResponse{Export: *export})
+}
+
+// DownloadExport handles downloading a rendered export; only the user who
+// asked for it can download it
+func (h *ExportHandlers) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	exportID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid export ID")
+		return
+	}
+
+	export, err := h.exportRepo.Get(exportID)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Export not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get export")
+		return
+	}
+	if export.UserID != userID {
+		writeError(w, http.StatusNotFound, "Export not found")
+		return
+	}
+	if export.Status != entities.ExportStatusReady {
+		writeError(w, http.StatusConflict, "Export is not ready")
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(export.ArticleID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", article.Slug+"."+export.Format))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Content)
+}
+
+// containsFormat reports whether format is one of formats
+func containsFormat(formats []string, format string) bool {
+	for _, supported := range formats {
+		if supported == format {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// FavoriteHandlers handles article favorite HTTP requests
+type FavoriteHandlers struct {
+	favoriteRepo        repositories.FavoriteRepository
+	articleRepo         repositories.ArticleRepository
+	userRepo            repositories.UserRepository
+	settingsRepo        repositories.SettingsRepository
+	notificationService services.NotificationService
+}
+
+// NewFavoriteHandlers creates a new favorite handlers instance
+func NewFavoriteHandlers(favoriteRepo repositories.FavoriteRepository, articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, settingsRepo repositories.SettingsRepository, notificationService services.NotificationService) *FavoriteHandlers {
+	return &FavoriteHandlers{
+		favoriteRepo:        favoriteRepo,
+		articleRepo:         articleRepo,
+		userRepo:            userRepo,
+		settingsRepo:        settingsRepo,
+		notificationService: notificationService,
+	}
+}
+
+// FavoriteArticle handles favoriting an article
+func (h *FavoriteHandlers) FavoriteArticle(w http.ResponseWriter, r *http.Request) {
+	h.setFavorite(w, r, true)
+}
+
+// UnfavoriteArticle handles removing a favorite from an article
+func (h *FavoriteHandlers) UnfavoriteArticle(w http.ResponseWriter, r *http.Request) {
+	h.setFavorite(w, r, false)
+}
+
+// setFavorite favorites or unfavorites the article and returns it with the updated count
+func (h *FavoriteHandlers) setFavorite(w http.ResponseWriter, r *http.Request, favorite bool) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	article, ok := h.article(w, r)
+	if !ok {
+		return
+	}
+
+	apply := h.favoriteRepo.Unfavorite
+	if favorite {
+		apply = h.favoriteRepo.Favorite
+	}
+	changed, err := apply(userID, article.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update favorite")
+		return
+	}
+
+	// Reload for the updated favorites count
+	article, err = h.articleRepo.GetByID(article.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+	article.Favorited = favorite
+
+	if favorite && changed {
+		h.notifyArticleAuthor(article, userID)
+	}
+
+	writeJSON(w, http.StatusOK, entities.ArticleResponse{Article: *article})
+}
+
+// ListFavoriters handles listing a page of the profiles that favorited an
+// article. Users who hide their favorites or their profile are counted but not listed.
+func (h *FavoriteHandlers) ListFavoriters(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.article(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	profiles, total, anonymous, err := h.favoriteRepo.ListFavoriters(article.ID, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get favoriters")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.FavoritersResponse{
+		Profiles:       profiles,
+		ProfilesCount:  total,
+		AnonymousCount: anonymous,
+	})
+}
+
+// article loads the published article named by the {slug} path variable,
+// writing an error response if it cannot
+func (h *FavoriteHandlers) article(w http.ResponseWriter, r *http.Request) (*entities.Article, bool) {
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return nil, false
+	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusNotFound, "Article not found")
+		return nil, false
+	}
+	return article, true
+}
+
+// notifyArticleAuthor tells the author about a new favorite without naming
+// users who hide their favorites; a failed notification must not fail the favorite itself
+func (h *FavoriteHandlers) notifyArticleAuthor(article *entities.Article, userID int64) {
+	if article.AuthorID == userID {
+		return
+	}
+
+	name := "Someone"
+	settings, err := h.settingsRepo.Get(userID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load favoriter settings: %v", err)
+		return
+	}
+	if settings.ShowFavorites {
+		user, err := h.userRepo.GetByID(userID)
+		if err != nil {
+			log.Printf("⚠️  Failed to load favoriter for notification: %v", err)
+			return
+		}
+		name = user.Username
+	}
+
+	message := fmt.Sprintf("%s favorited \"%s\"", name, article.Title)
+	if err := h.notificationService.Notify(article.AuthorID, entities.EventArticleFavorited, message, "/article/"+article.Slug, nil); err != nil {
+		log.Printf("⚠️  Failed to send favorite notification: %v", err)
+	}
+}
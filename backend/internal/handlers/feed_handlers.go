@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// FeedHandlers handles personal feed HTTP requests
+type FeedHandlers struct {
+	articleRepo  repositories.ArticleRepository
+	feedRepo     repositories.FeedRepository
+	favoriteRepo repositories.FavoriteRepository
+	followRepo   repositories.FollowRepository
+}
+
+// NewFeedHandlers creates a new feed handlers instance
+func NewFeedHandlers(articleRepo repositories.ArticleRepository, feedRepo repositories.FeedRepository, favoriteRepo repositories.FavoriteRepository, followRepo repositories.FollowRepository) *FeedHandlers {
+	return &FeedHandlers{
+		articleRepo:  articleRepo,
+		feedRepo:     feedRepo,
+		favoriteRepo: favoriteRepo,
+		followRepo:   followRepo,
+	}
+}
+
+// GetFeed handles listing articles by followed authors and marks them as read
+func (h *FeedHandlers) GetFeed(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := &entities.ArticleListQuery{
+		Limit:      20, // Default limit
+		Offset:     0,  // Default offset
+		FollowedBy: userID,
+	}
+
+	// Parse limit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			query.Limit = limit
+		}
+	}
+
+	// Parse offset
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	// Parse projection; summaries leave out the body
+	excludeBody, err := parseExcludeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query.ExcludeBody = excludeBody
+
+	// Get articles
+	articles, totalCount, err := h.articleRepo.List(query)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get feed")
+		return
+	}
+	if articles == nil {
+		articles = []entities.Article{}
+	}
+	if err := h.favoriteRepo.MarkFavorited(userID, articles); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get favorites")
+		return
+	}
+	if err := h.followRepo.MarkFollowing(userID, articleAuthors(articles)); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get follows")
+		return
+	}
+
+	// Articles are newest first, so the first one is the newest item now seen
+	if len(articles) > 0 {
+		if err := h.feedRepo.MarkSeen(userID, articles[0].CreatedAt); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to update feed read marker")
+			return
+		}
+	}
+
+	// Return articles response
+	response := entities.ArticlesResponse{
+		Articles:      articles,
+		ArticlesCount: totalCount,
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetFeedUnreadCount handles the lightweight unread badge count for the feed
+func (h *FeedHandlers) GetFeedUnreadCount(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	count, err := h.feedRepo.UnreadCount(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to count unread articles")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.FeedUnreadResponse{UnreadCount: count})
+}
@@ -1,11 +1,20 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
 
 	"github.com/emotab87/vibe_coding/backend/internal/entities"
 	"github.com/emotab87/vibe_coding/backend/internal/middleware"
@@ -66,9 +75,34 @@ func DeleteCommentHandler(w http.ResponseWriter, r *http.Request) {
 	writeNotImplemented(w, "Delete comment not yet implemented")
 }
 
-// Profile handlers
-func GetProfileHandler(w http.ResponseWriter, r *http.Request) {
-	writeNotImplemented(w, "Get profile not yet implemented")
+// Router fallback handlers
+
+// NotFoundHandler answers requests that match no route
+func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+// UnavailableInRegionHandler answers requests for features the deployment's
+// compliance rules switch off; GET /api/compliance lists them
+func UnavailableInRegionHandler(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusUnavailableForLegalReasons, "Not available in this region")
+}
+
+// MethodNotAllowedHandler answers requests whose path exists but not for the
+// request method. allowed reports the methods the path supports; they are
+// returned in the Allow header, and OPTIONS requests get 204 instead of 405.
+func MethodNotAllowedHandler(allowed func(r *http.Request) []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods := append(allowed(r), http.MethodOptions)
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
 }
 
 // Helper functions
@@ -98,10 +132,27 @@ func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 
 // writeError writes an error response
 func writeError(w http.ResponseWriter, statusCode int, message string) {
-	response := map[string]string{
+	response := map[string]interface{}{
 		"error": message,
 	}
-	writeJSON(w, statusCode, response)
+	writeJSON(w, statusCode, withCorrelationIDs(w, response))
+}
+
+// writeTooManyRequests writes a 429 response telling the client when to retry
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration, message string) {
+	writeRetryLater(w, http.StatusTooManyRequests, retryAfter, message)
+}
+
+// writeRetryLater writes an error response telling the client when to retry
+func writeRetryLater(w http.ResponseWriter, statusCode int, retryAfter time.Duration, message string) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+
+	response := map[string]interface{}{
+		"error":      message,
+		"retryAfter": seconds,
+	}
+	writeJSON(w, statusCode, withCorrelationIDs(w, response))
 }
 
 // writeValidationErrors writes validation error response
@@ -109,21 +160,228 @@ func writeValidationErrors(w http.ResponseWriter, validationErrors *entities.Val
 	response := map[string]interface{}{
 		"errors": validationErrors.Errors,
 	}
-	writeJSON(w, http.StatusBadRequest, response)
+	writeJSON(w, http.StatusBadRequest, withCorrelationIDs(w, response))
+}
+
+// withCorrelationIDs adds the request and trace IDs set on the response to an
+// error payload, so clients can quote them when reporting problems
+func withCorrelationIDs(w http.ResponseWriter, response map[string]interface{}) map[string]interface{} {
+	if requestID := w.Header().Get(middleware.RequestIDHeader); requestID != "" {
+		response["requestId"] = requestID
+	}
+	if traceID := w.Header().Get(middleware.TraceIDHeader); traceID != "" {
+		response["traceId"] = traceID
+	}
+	return response
+}
+
+// parsePage reads ?limit= and ?offset=, falling back to defaultLimit and 0
+// for missing or invalid values and capping the limit at maxLimit
+func parsePage(r *http.Request, defaultLimit, maxLimit int) (int, int) {
+	limit, offset := defaultLimit, 0
+	if parsed, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && parsed > 0 {
+		limit = parsed
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+	if parsed, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && parsed >= 0 {
+		offset = parsed
+	}
+	return limit, offset
+}
+
+// parseListTime parses a list filter bound given as an RFC 3339 time or a
+// UTC date. A date used as an exclusive upper bound (endOfDay) means the end of
+// that day, so until=2024-05-01 includes articles from May 1st. Empty values
+// give the zero time.
+func parseListTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+// parseExcludeBody reads ?exclude=body, which asks for list items without their
+// body. body is the only field that can be left out.
+func parseExcludeBody(r *http.Request) (bool, error) {
+	exclude := r.URL.Query().Get("exclude")
+	if exclude == "" {
+		return false, nil
+	}
+	for _, field := range strings.Split(exclude, ",") {
+		if strings.TrimSpace(field) != "body" {
+			return false, fmt.Errorf("exclude only supports: body")
+		}
+	}
+	return true, nil
+}
+
+// wantsCSV reports whether the client asked for CSV output, either via
+// ?format=csv or an Accept header that names text/csv but not JSON
+func wantsCSV(r *http.Request) bool {
+	if strings.EqualFold(r.URL.Query().Get("format"), "csv") {
+		return true
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "text/csv") && !strings.Contains(accept, "application/json")
 }
 
-// parseJSON parses JSON request body into the provided struct
+// writeCSV streams rows as a CSV attachment. Cells that a spreadsheet would
+// evaluate as formulas are prefixed with a single quote.
+func writeCSV(w http.ResponseWriter, filename string, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+				cell = "'" + cell
+			}
+			escaped[i] = cell
+		}
+		writer.Write(escaped)
+	}
+	writer.Flush()
+}
+
+// parseJSON parses JSON request body into the provided struct. Unknown fields
+// are rejected unless the route parses leniently, in which case they are
+// ignored and reported as response warnings.
 func parseJSON(r *http.Request, v interface{}) error {
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	lenient := middleware.JSONParsingModeFromContext(r.Context()) == middleware.JSONLenient
+	if !lenient {
+		decoder.DisallowUnknownFields()
+	}
+
 	if err := decoder.Decode(v); err != nil {
 		return fmt.Errorf("invalid JSON: %w", err)
 	}
-	
+
+	// Types with custom unmarshalers bypass DisallowUnknownFields, so unknown
+	// fields are also found by walking the body against the target type
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+	unknown := unknownFields(raw, reflect.TypeOf(v), "")
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if !lenient {
+		return fmt.Errorf("invalid JSON: unknown field %q", unknown[0].Field)
+	}
+	middleware.AddParseWarnings(r.Context(), unknown...)
 	return nil
 }
 
+// unknownFields walks decoded JSON alongside the target type and returns a
+// warning for every object key that does not map to a struct field
+func unknownFields(raw interface{}, t reflect.Type, prefix string) []middleware.ParseWarning {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var warnings []middleware.ParseWarning
+	switch value := raw.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		fields := jsonFields(t)
+
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok {
+				warnings = append(warnings, middleware.ParseWarning{
+					Field:   path,
+					Message: "unknown field ignored",
+				})
+				continue
+			}
+			warnings = append(warnings, unknownFields(value[key], fieldType, path)...)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil
+		}
+		for i, element := range value {
+			warnings = append(warnings, unknownFields(element, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i))...)
+		}
+	}
+
+	return warnings
+}
+
+// jsonFields maps the lowercased JSON names of a struct's fields, including
+// promoted fields of embedded structs, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					fields[embeddedName] = embeddedType
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
 // getUserIDFromContext extracts user ID from request context
 func getUserIDFromContext(r *http.Request) (int64, error) {
 	userID := r.Context().Value(middleware.UserIDContextKey)
@@ -162,6 +420,21 @@ func getUsernameFromContext(r *http.Request) (string, error) {
 	return usernameStr, nil
 }
 
+// pathUser loads the account named by the {username} path variable, writing
+// an error if it doesn't exist
+func pathUser(w http.ResponseWriter, r *http.Request, userRepo repositories.UserRepository) (*entities.User, bool) {
+	user, err := userRepo.GetByUsername(mux.Vars(r)["username"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "User not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return nil, false
+	}
+	return user, true
+}
+
 // extractToken extracts JWT token from Authorization header
 func extractToken(r *http.Request) (string, error) {
 	authHeader := r.Header.Get("Authorization")
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+)
+
+func TestWriteCSV(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	writeCSV(rr, "stats.csv", []string{"slug", "title"}, [][]string{
+		{"hello", `Say "hi", world`},
+		{"formula", "=SUM(A1:A2)"},
+	})
+
+	if got := rr.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Expected CSV content type, got %q", got)
+	}
+	if got := rr.Header().Get("Content-Disposition"); got != `attachment; filename="stats.csv"` {
+		t.Errorf("Unexpected content disposition %q", got)
+	}
+
+	expected := "slug,title\nhello,\"Say \"\"hi\"\", world\"\nformula,'=SUM(A1:A2)\n"
+	if rr.Body.String() != expected {
+		t.Errorf("Expected body %q, got %q", expected, rr.Body.String())
+	}
+}
+
+func TestParseJSON_Modes(t *testing.T) {
+	type request struct {
+		User struct {
+			Email string  `json:"email"`
+			Bio   *string `json:"bio"`
+		} `json:"user"`
+		Tags []struct {
+			Name string `json:"name"`
+		} `json:"tags"`
+	}
+
+	body := `{"user":{"email":"a@example.com","token":"x"},"tags":[{"name":"go","color":"blue"}],"extra":1}`
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		if err := parseJSON(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON format")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"email": req.User.Email})
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		middleware.JSONParsing(middleware.JSONStrict)(handler).ServeHTTP(rr, httptest.NewRequest("POST", "/", strings.NewReader(body)))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for unknown fields in strict mode, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		middleware.JSONParsing(middleware.JSONLenient)(handler).ServeHTTP(rr, httptest.NewRequest("POST", "/", strings.NewReader(body)))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected 200 in lenient mode, got %d: %s", rr.Code, rr.Body.String())
+		}
+
+		var response struct {
+			Email    string                    `json:"email"`
+			Warnings []middleware.ParseWarning `json:"warnings"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Could not parse response: %v", err)
+		}
+
+		if response.Email != "a@example.com" {
+			t.Errorf("Expected known fields to be parsed, got %q", response.Email)
+		}
+
+		var fields []string
+		for _, warning := range response.Warnings {
+			fields = append(fields, warning.Field)
+		}
+		if got := strings.Join(fields, ","); got != "extra,tags[0].color,user.token" {
+			t.Errorf("Unexpected warnings for fields %q", got)
+		}
+	})
+}
+
+func TestRenderRobots(t *testing.T) {
+	got := renderRobots(RobotsOptions{
+		Disallow:      []string{"/api/"},
+		BlockedAgents: []string{"GPTBot"},
+		SitemapURL:    "https://api.example.com/sitemap.xml",
+	})
+	expected := "User-agent: GPTBot\nDisallow: /\n\nUser-agent: *\nDisallow: /api/\n\nSitemap: https://api.example.com/sitemap.xml\n"
+	if got != expected {
+		t.Errorf("Expected robots.txt %q, got %q", expected, got)
+	}
+
+	// Blocking everyone hides the sitemap too
+	got = renderRobots(RobotsOptions{DisallowAll: true, SitemapURL: "https://api.example.com/sitemap.xml"})
+	if got != "User-agent: *\nDisallow: /\n" {
+		t.Errorf("Unexpected robots.txt for DisallowAll: %q", got)
+	}
+}
+
+func TestWriteMetric_CounterNaming(t *testing.T) {
+	var openMetrics, text strings.Builder
+	writeMetric(&openMetrics, true, "conduit_kpi_refreshes", "counter", "Refreshes.", 3)
+	writeMetric(&text, false, "conduit_kpi_refreshes", "counter", "Refreshes.", 3)
+
+	expected := "# TYPE conduit_kpi_refreshes counter\n# HELP conduit_kpi_refreshes Refreshes.\nconduit_kpi_refreshes_total 3\n"
+	if openMetrics.String() != expected {
+		t.Errorf("Expected OpenMetrics %q, got %q", expected, openMetrics.String())
+	}
+	expected = "# TYPE conduit_kpi_refreshes_total counter\n# HELP conduit_kpi_refreshes_total Refreshes.\nconduit_kpi_refreshes_total 3\n"
+	if text.String() != expected {
+		t.Errorf("Expected text format %q, got %q", expected, text.String())
+	}
+}
+
+func TestParseListTime(t *testing.T) {
+	tests := []struct {
+		value    string
+		endOfDay bool
+		want     time.Time
+		wantErr  bool
+	}{
+		{"", false, time.Time{}, false},
+		{"2024-05-01", false, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2024-05-01", true, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), false},
+		{"2024-05-01T10:30:00+09:00", true, time.Date(2024, 5, 1, 1, 30, 0, 0, time.UTC), false},
+		{"yesterday", false, time.Time{}, true},
+		{"2024-13-01", false, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseListTime(tt.value, tt.endOfDay)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseListTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseListTime(%q, %v) = %v, want %v", tt.value, tt.endOfDay, got, tt.want)
+		}
+	}
+}
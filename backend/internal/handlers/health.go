@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
 )
 
 // HealthResponse represents the health check response
@@ -12,6 +15,32 @@ type HealthResponse struct {
 	Timestamp time.Time `json:"timestamp"`
 	Version   string    `json:"version"`
 	Service   string    `json:"service"`
+
+	Components []services.ComponentHealth `json:"components,omitempty"`
+}
+
+// StatusResponse is what a public status page is built from: overall
+// health, uptime, a day of hourly health per component and current notices
+type StatusResponse struct {
+	Status        string                      `json:"status"`
+	Timestamp     time.Time                   `json:"timestamp"`
+	StartedAt     time.Time                   `json:"startedAt"`
+	UptimeSeconds int64                       `json:"uptimeSeconds"`
+	Components    []services.ComponentHistory `json:"components"`
+	Notices       []StatusNotice              `json:"notices"`
+}
+
+// StatusNotice is an ongoing incident or maintenance shown on the status
+// page. Notices posted by admins have an ID; the others are derived from
+// component health and draining.
+type StatusNotice struct {
+	ID         int64     `json:"id,omitempty"`
+	Type       string    `json:"type"`
+	Severity   string    `json:"severity"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message,omitempty"`
+	Components []string  `json:"components,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
 }
 
 // HealthCheckHandler handles health check requests
@@ -34,4 +63,140 @@ func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("Health check failed"))
 		return
 	}
-}
\ No newline at end of file
+}
+
+// HealthHandlers serves health checks that include subsystem status, and
+// readiness checks that also fail while the server drains before shutdown
+type HealthHandlers struct {
+	registry  services.HealthRegistry
+	lifecycle services.Lifecycle
+	incidents *services.IncidentBoard
+	drain     time.Duration
+}
+
+// NewHealthHandlers creates a new health handlers instance; drain is how long
+// the server keeps serving after draining starts, and incidents are the
+// notices admins post for the status page
+func NewHealthHandlers(registry services.HealthRegistry, lifecycle services.Lifecycle, incidents *services.IncidentBoard, drain time.Duration) *HealthHandlers {
+	return &HealthHandlers{
+		registry:  registry,
+		lifecycle: lifecycle,
+		incidents: incidents,
+		drain:     drain,
+	}
+}
+
+// Check reports overall and per-component health. A degraded service still
+// answers 200 since core features keep working; 503 means a critical
+// component is down.
+func (h *HealthHandlers) Check(w http.ResponseWriter, r *http.Request) {
+	response := HealthResponse{
+		Status:     h.registry.Status(),
+		Timestamp:  time.Now().UTC(),
+		Version:    "1.0.0",
+		Service:    "conduit-api",
+		Components: h.registry.Components(),
+	}
+
+	statusCode := http.StatusOK
+	if response.Status == services.HealthStatusDown {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, statusCode, response)
+}
+
+// Ready reports whether the server should receive traffic: 503 while a
+// critical component is down or the server is draining
+func (h *HealthHandlers) Ready(w http.ResponseWriter, r *http.Request) {
+	response := HealthResponse{
+		Status:    h.registry.Status(),
+		Timestamp: time.Now().UTC(),
+		Version:   "1.0.0",
+		Service:   "conduit-api",
+	}
+	if h.lifecycle.Draining() {
+		response.Status = services.HealthStatusDraining
+	}
+
+	statusCode := http.StatusOK
+	if response.Status == services.HealthStatusDown || response.Status == services.HealthStatusDraining {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, statusCode, response)
+}
+
+// Drain starts draining ahead of shutdown, for example from a preStop hook.
+// Requests in flight and new ones are still served, but readiness fails and
+// connections are not kept alive, so load balancers move traffic elsewhere.
+func (h *HealthHandlers) Drain(w http.ResponseWriter, r *http.Request) {
+	startedAt := h.lifecycle.Drain()
+
+	response := map[string]interface{}{
+		"status":    services.HealthStatusDraining,
+		"startedAt": startedAt.UTC(),
+		"drainEnds": startedAt.Add(h.drain).UTC(),
+	}
+	writeJSON(w, http.StatusAccepted, response)
+}
+
+// Status summarizes health for a public status page. Unlike Check it always
+// answers 200, since the page should render whatever the status. Besides the
+// incidents admins posted, components that are down right now are listed as
+// incidents, and draining before a restart as maintenance.
+func (h *HealthHandlers) Status(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	startedAt := h.registry.StartedAt()
+	response := StatusResponse{
+		Status:        h.registry.Status(),
+		Timestamp:     now,
+		StartedAt:     startedAt,
+		UptimeSeconds: int64(now.Sub(startedAt).Seconds()),
+		Components:    h.registry.History(),
+		Notices:       []StatusNotice{},
+	}
+
+	// Without the database the incidents cannot be read, but the database
+	// is then listed as down below
+	if active, err := h.incidents.Active(); err == nil {
+		for _, incident := range active {
+			response.Notices = append(response.Notices, StatusNotice{
+				ID:         incident.ID,
+				Type:       incident.Kind,
+				Severity:   incident.Severity,
+				Title:      incident.Title,
+				Message:    incident.Message,
+				Components: incident.Components,
+				StartedAt:  incident.StartedAt,
+			})
+		}
+	}
+
+	for _, component := range h.registry.Components() {
+		if component.Status == services.HealthStatusOK {
+			continue
+		}
+		severity := entities.IncidentSeverityMinor
+		if component.Critical {
+			severity = entities.IncidentSeverityCritical
+		}
+		response.Notices = append(response.Notices, StatusNotice{
+			Type:       entities.IncidentKindIncident,
+			Severity:   severity,
+			Title:      component.Name + " is unavailable",
+			Components: []string{component.Name},
+			StartedAt:  component.Since,
+		})
+	}
+	if h.lifecycle.Draining() {
+		response.Notices = append(response.Notices, StatusNotice{
+			Type:      entities.IncidentKindMaintenance,
+			Severity:  entities.IncidentSeverityMinor,
+			Title:     "The server is restarting",
+			StartedAt: h.lifecycle.Drain(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// ImageProxyHandlers serves external images through the image proxy
+type ImageProxyHandlers struct {
+	proxy services.ImageProxy
+}
+
+// NewImageProxyHandlers creates a new image proxy handlers instance.
+// A nil proxy means the proxy is disabled.
+func NewImageProxyHandlers(proxy services.ImageProxy) *ImageProxyHandlers {
+	return &ImageProxyHandlers{
+		proxy: proxy,
+	}
+}
+
+// GetImage handles proxying an external image given by ?url=, optionally
+// scaled down to ?w= pixels wide. Readers' browsers only ever contact this
+// server, which hides them from image hosts and avoids mixed content.
+func (h *ImageProxyHandlers) GetImage(w http.ResponseWriter, r *http.Request) {
+	if h.proxy == nil {
+		writeError(w, http.StatusNotFound, "Image proxy is disabled")
+		return
+	}
+
+	query := r.URL.Query()
+	rawURL := query.Get("url")
+	if rawURL == "" {
+		writeError(w, http.StatusBadRequest, "Missing url parameter")
+		return
+	}
+
+	width := 0
+	if raw := query.Get("w"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "w must be a positive integer")
+			return
+		}
+		width = parsed
+	}
+
+	proxied, err := h.proxy.Fetch(r.Context(), rawURL, width)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrImageHostNotAllowed):
+			writeError(w, http.StatusForbidden, "Image host is not allowed")
+		case errors.Is(err, services.ErrImageTooLarge):
+			writeError(w, http.StatusRequestEntityTooLarge, "Image is too large")
+		case errors.Is(err, services.ErrNotAnImage):
+			writeError(w, http.StatusUnprocessableEntity, "URL is not a supported image")
+		default:
+			writeError(w, http.StatusBadGateway, "Failed to fetch image")
+		}
+		return
+	}
+
+	// Proxied bytes are untrusted: never let browsers sniff or run them
+	w.Header().Set("Content-Type", proxied.ContentType)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	w.Write(proxied.Body)
+}
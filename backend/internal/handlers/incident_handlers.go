@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// IncidentHandlers handles the incident and maintenance notices admins post
+// for the status page
+type IncidentHandlers struct {
+	incidentRepo repositories.IncidentRepository
+	board        *services.IncidentBoard
+}
+
+// NewIncidentHandlers creates a new incident handlers instance; board is
+// refreshed whenever an incident changes
+func NewIncidentHandlers(incidentRepo repositories.IncidentRepository, board *services.IncidentBoard) *IncidentHandlers {
+	return &IncidentHandlers{
+		incidentRepo: incidentRepo,
+		board:        board,
+	}
+}
+
+// ListIncidents handles listing a page of incidents; ?status= is active (the
+// default), resolved or all
+func (h *IncidentHandlers) ListIncidents(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = entities.IncidentStatusActive
+	}
+	if status != entities.IncidentStatusActive && status != entities.IncidentStatusResolved && status != entities.IncidentStatusAll {
+		writeError(w, http.StatusBadRequest, "status must be one of: active, resolved, all")
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	incidents, total, err := h.incidentRepo.List(status, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get incidents")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.IncidentsResponse{
+		Incidents:      incidents,
+		IncidentsCount: total,
+	})
+}
+
+// CreateIncident handles opening an incident or announcing maintenance
+func (h *IncidentHandlers) CreateIncident(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Incident entities.IncidentCreate `json:"incident"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Incident.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	incident, err := h.incidentRepo.Create(userID, &req.Incident, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create incident")
+		return
+	}
+	h.board.Invalidate()
+
+	writeJSON(w, http.StatusCreated, entities.IncidentResponse{Incident: *incident})
+}
+
+// UpdateIncident handles changing an active incident's severity, wording or
+// affected components as it develops
+func (h *IncidentHandlers) UpdateIncident(w http.ResponseWriter, r *http.Request) {
+	incident, ok := h.incident(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Incident entities.IncidentUpdate `json:"incident"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Incident.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	if !incident.IsActive() {
+		writeError(w, http.StatusConflict, "Resolved incidents cannot be updated")
+		return
+	}
+
+	updated := req.Incident.Apply(*incident)
+	if err := h.incidentRepo.Update(&updated, time.Now()); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Incident not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to update incident")
+		return
+	}
+	h.board.Invalidate()
+
+	writeJSON(w, http.StatusOK, entities.IncidentResponse{Incident: updated})
+}
+
+// ResolveIncident handles closing an incident or ending maintenance
+func (h *IncidentHandlers) ResolveIncident(w http.ResponseWriter, r *http.Request) {
+	incident, ok := h.incident(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.incidentRepo.Resolve(incident.ID, time.Now()); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Incident not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to resolve incident")
+		return
+	}
+	h.board.Invalidate()
+
+	resolved, err := h.incidentRepo.GetByID(incident.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get incident")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.IncidentResponse{Incident: *resolved})
+}
+
+// incident loads the incident named in the path, writing an error if it doesn't exist
+func (h *IncidentHandlers) incident(w http.ResponseWriter, r *http.Request) (*entities.Incident, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid incident ID")
+		return nil, false
+	}
+
+	incident, err := h.incidentRepo.GetByID(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Incident not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get incident")
+		return nil, false
+	}
+	return incident, true
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// InviteHandlers handles registration invite HTTP requests (admins)
+type InviteHandlers struct {
+	inviteRepo repositories.InviteRepository
+}
+
+// NewInviteHandlers creates a new invite handlers instance
+func NewInviteHandlers(inviteRepo repositories.InviteRepository) *InviteHandlers {
+	return &InviteHandlers{
+		inviteRepo: inviteRepo,
+	}
+}
+
+// ListInvites handles listing a page of invites, newest first
+func (h *InviteHandlers) ListInvites(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePage(r, 20, 100)
+	invites, total, err := h.inviteRepo.List(limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get invites")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.InvitesResponse{
+		Invites:      invites,
+		InvitesCount: total,
+	})
+}
+
+// CreateInvites handles generating a batch of single-use invite codes
+func (h *InviteHandlers) CreateInvites(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Invites entities.InviteCreate `json:"invites"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Invites.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	invites, err := h.inviteRepo.Create(userID, req.Invites.Count)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create invites")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.InvitesResponse{
+		Invites:      invites,
+		InvitesCount: len(invites),
+	})
+}
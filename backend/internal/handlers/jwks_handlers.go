@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// JWKSHandlers publishes the public keys access tokens are signed with
+type JWKSHandlers struct {
+	keys services.JWTKeyRing
+}
+
+// NewJWKSHandlers creates a new JWKS handlers instance
+func NewJWKSHandlers(keys services.JWTKeyRing) *JWKSHandlers {
+	return &JWKSHandlers{
+		keys: keys,
+	}
+}
+
+// GetJWKS handles serving the key set other services validate our tokens
+// with; it is empty while tokens are signed with the shared secret. A new key
+// signs as soon as it is rotated in, so clients caching the set should fetch
+// it again when a token names a kid they do not know.
+func (h *JWKSHandlers) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, h.keys.PublicKeys())
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// LegalHoldHandlers handles legal hold HTTP requests (admin only)
+type LegalHoldHandlers struct {
+	holdRepo    repositories.LegalHoldRepository
+	userRepo    repositories.UserRepository
+	articleRepo repositories.ArticleRepository
+}
+
+// NewLegalHoldHandlers creates a new legal hold handlers instance
+func NewLegalHoldHandlers(holdRepo repositories.LegalHoldRepository, userRepo repositories.UserRepository, articleRepo repositories.ArticleRepository) *LegalHoldHandlers {
+	return &LegalHoldHandlers{
+		holdRepo:    holdRepo,
+		userRepo:    userRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ListLegalHolds handles listing active holds, or every hold ever placed with
+// ?status=all for the audit trail
+func (h *LegalHoldHandlers) ListLegalHolds(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" && status != "active" && status != "all" {
+		writeError(w, http.StatusBadRequest, "status must be one of: active, all")
+		return
+	}
+
+	holds, err := h.holdRepo.List(status != "all")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get legal holds")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.LegalHoldsResponse{Holds: holds})
+}
+
+// PlaceUserHold handles placing a hold that blocks deleting an account
+func (h *LegalHoldHandlers) PlaceUserHold(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+	h.place(w, r, entities.LegalHoldSubjectUser, user.ID)
+}
+
+// ReleaseUserHold handles lifting an account's hold
+func (h *LegalHoldHandlers) ReleaseUserHold(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+	h.release(w, r, entities.LegalHoldSubjectUser, user.ID)
+}
+
+// PlaceArticleHold handles placing a hold that blocks deleting an article
+func (h *LegalHoldHandlers) PlaceArticleHold(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.article(w, r)
+	if !ok {
+		return
+	}
+	h.place(w, r, entities.LegalHoldSubjectArticle, article.ID)
+}
+
+// ReleaseArticleHold handles lifting an article's hold
+func (h *LegalHoldHandlers) ReleaseArticleHold(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.article(w, r)
+	if !ok {
+		return
+	}
+	h.release(w, r, entities.LegalHoldSubjectArticle, article.ID)
+}
+
+// place records a new hold on the subject
+func (h *LegalHoldHandlers) place(w http.ResponseWriter, r *http.Request, subjectType string, subjectID int64) {
+	adminID, change, ok := h.parseChange(w, r)
+	if !ok {
+		return
+	}
+
+	hold, err := h.holdRepo.Place(subjectType, subjectID, adminID, change.Reason, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "already under legal hold") {
+			writeError(w, http.StatusConflict, "Already under legal hold")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to place legal hold")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.LegalHoldResponse{Hold: *hold})
+}
+
+// release lifts the subject's active hold
+func (h *LegalHoldHandlers) release(w http.ResponseWriter, r *http.Request, subjectType string, subjectID int64) {
+	adminID, change, ok := h.parseChange(w, r)
+	if !ok {
+		return
+	}
+
+	hold, err := h.holdRepo.Release(subjectType, subjectID, adminID, change.Reason, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "No active legal hold")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to release legal hold")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.LegalHoldResponse{Hold: *hold})
+}
+
+// parseChange reads and validates the reason for placing or releasing a hold
+func (h *LegalHoldHandlers) parseChange(w http.ResponseWriter, r *http.Request) (int64, *entities.LegalHoldChange, bool) {
+	adminID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, nil, false
+	}
+
+	var req struct {
+		Hold entities.LegalHoldChange `json:"hold"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return 0, nil, false
+	}
+
+	req.Hold.Reason = strings.TrimSpace(req.Hold.Reason)
+	if validationErr := req.Hold.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return 0, nil, false
+	}
+	return adminID, &req.Hold, true
+}
+
+// article loads the article named in the path, writing an error if it doesn't exist
+func (h *LegalHoldHandlers) article(w http.ResponseWriter, r *http.Request) (*entities.Article, bool) {
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return nil, false
+	}
+	return article, true
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// LinkPreviewHandlers handles link preview HTTP requests
+type LinkPreviewHandlers struct {
+	previews        services.LinkPreviewService
+	linkPreviewRepo repositories.LinkPreviewRepository
+	articleRepo     repositories.ArticleRepository
+}
+
+// NewLinkPreviewHandlers creates a new link preview handlers instance
+func NewLinkPreviewHandlers(previews services.LinkPreviewService, linkPreviewRepo repositories.LinkPreviewRepository, articleRepo repositories.ArticleRepository) *LinkPreviewHandlers {
+	return &LinkPreviewHandlers{
+		previews:        previews,
+		linkPreviewRepo: linkPreviewRepo,
+		articleRepo:     articleRepo,
+	}
+}
+
+// GetLinkPreview handles fetching the preview for ?url= so editors can show a link card
+func (h *LinkPreviewHandlers) GetLinkPreview(w http.ResponseWriter, r *http.Request) {
+	request := entities.LinkPreviewCreate{URL: r.URL.Query().Get("url")}
+	if validationErr := request.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	preview, err := h.previews.Get(r.Context(), request.URL)
+	if err != nil {
+		writeLinkPreviewError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.LinkPreviewResponse{LinkPreview: *preview})
+}
+
+// AttachLinkPreview handles fetching a preview and attaching it to an article (author only)
+func (h *LinkPreviewHandlers) AttachLinkPreview(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.authorArticle(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		LinkPreview entities.LinkPreviewCreate `json:"linkPreview"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+	if validationErr := req.LinkPreview.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	preview, err := h.previews.Get(r.Context(), req.LinkPreview.URL)
+	if err != nil {
+		writeLinkPreviewError(w, err)
+		return
+	}
+
+	if err := h.linkPreviewRepo.Attach(article.ID, preview.URL); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to attach link preview")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.LinkPreviewResponse{LinkPreview: *preview})
+}
+
+// DetachLinkPreview handles removing the preview for ?url= from an article (author only)
+func (h *LinkPreviewHandlers) DetachLinkPreview(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.authorArticle(w, r)
+	if !ok {
+		return
+	}
+
+	url := r.URL.Query().Get("url")
+	if url == "" {
+		writeError(w, http.StatusBadRequest, "Missing url parameter")
+		return
+	}
+
+	if err := h.linkPreviewRepo.Detach(article.ID, url); err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Link preview not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to detach link preview")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorArticle loads the article named in the path and checks that the caller
+// wrote it, writing an error response if not
+func (h *LinkPreviewHandlers) authorArticle(w http.ResponseWriter, r *http.Request) (*entities.Article, bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return nil, false
+	}
+
+	if article.AuthorID != userID {
+		writeError(w, http.StatusForbidden, "You can only edit link previews on your own articles")
+		return nil, false
+	}
+
+	return article, true
+}
+
+// writeLinkPreviewError maps link preview service errors to responses
+func writeLinkPreviewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbiddenAddress):
+		writeValidationErrors(w, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+			Field:   "url",
+			Message: "url points to an address that is not allowed",
+		}}})
+	case errors.Is(err, services.ErrLinkPreviewUnavailable):
+		writeError(w, http.StatusBadGateway, "Could not fetch a preview for this URL")
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to get link preview")
+	}
+}
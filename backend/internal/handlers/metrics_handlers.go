@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// Exposition formats for scrapes; Prometheus falls back to the text format
+// when it does not ask for OpenMetrics
+const (
+	contentTypeOpenMetrics    = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	contentTypePrometheusText = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// MetricsHandlers serves business KPIs for monitoring systems
+type MetricsHandlers struct {
+	collector services.KPICollector
+}
+
+// NewMetricsHandlers creates a new metrics handlers instance
+func NewMetricsHandlers(collector services.KPICollector) *MetricsHandlers {
+	return &MetricsHandlers{
+		collector: collector,
+	}
+}
+
+// GetMetrics handles a scrape of the latest KPI snapshot. Gauges are omitted
+// until the first refresh succeeds; the refresh counters are always present.
+func (h *MetricsHandlers) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	snapshot := h.collector.Snapshot()
+
+	var b strings.Builder
+	if stats := snapshot.Stats; stats != nil {
+		writeMetric(&b, openMetrics, "conduit_users", "gauge", "User accounts that are neither deactivated nor banned.", int64(stats.Users))
+		writeMetric(&b, openMetrics, "conduit_articles", "gauge", "Published articles.", int64(stats.Articles))
+		writeMetric(&b, openMetrics, "conduit_comments", "gauge", "Comments that were not deleted.", int64(stats.Comments))
+		writeMetric(&b, openMetrics, "conduit_favorites", "gauge", "Article favorites.", int64(stats.Favorites))
+		writeMetric(&b, openMetrics, "conduit_follows", "gauge", "Follow relationships between users.", int64(stats.Follows))
+		writeMetric(&b, openMetrics, "conduit_daily_active_visitors", "gauge", "Distinct analytics visitors today (UTC).", int64(stats.DailyActiveVisitors))
+		writeMetric(&b, openMetrics, "conduit_daily_active_users", "gauge", "Accounts that published, commented, favorited, clapped or followed in the last 24 hours.", int64(stats.DailyActiveUsers))
+		writeMetric(&b, openMetrics, "conduit_kpi_last_refresh_timestamp_seconds", "gauge", "Unix time of the last successful KPI refresh.", snapshot.RefreshedAt.Unix())
+	}
+	writeMetric(&b, openMetrics, "conduit_kpi_refreshes", "counter", "Successful KPI refreshes.", snapshot.Refreshes)
+	writeMetric(&b, openMetrics, "conduit_kpi_refresh_failures", "counter", "Failed KPI refreshes.", snapshot.Failures)
+
+	contentType := contentTypePrometheusText
+	if openMetrics {
+		contentType = contentTypeOpenMetrics
+		b.WriteString("# EOF\n")
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
+
+// writeMetric writes one metric family with a single sample. Counter samples
+// carry the _total suffix, which OpenMetrics leaves off the family name.
+func writeMetric(b *strings.Builder, openMetrics bool, name, kind, help string, value int64) {
+	sample := name
+	if kind == "counter" {
+		sample += "_total"
+		if !openMetrics {
+			name = sample
+		}
+	}
+	fmt.Fprintf(b, "# TYPE %s %s\n# HELP %s %s\n%s %d\n", name, kind, name, help, sample, value)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// NotificationHandlers handles notification-related HTTP requests
+type NotificationHandlers struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+// NewNotificationHandlers creates a new notification handlers instance
+func NewNotificationHandlers(notificationRepo repositories.NotificationRepository) *NotificationHandlers {
+	return &NotificationHandlers{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// GetNotificationSettings handles getting the current user's notification
+// preferences and quiet hours
+func (h *NotificationHandlers) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	settings, err := h.notificationRepo.GetSettings(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get notification settings")
+		return
+	}
+
+	quietHours, err := h.notificationRepo.GetQuietHours(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get notification settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.NotificationSettingsResponse{NotificationSettings: settings, QuietHours: &quietHours})
+}
+
+// UpdateNotificationSettings handles updating delivery modes for one or more
+// event types and replacing the quiet hours; either may be left out
+func (h *NotificationHandlers) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req entities.NotificationSettingsResponse
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate settings
+	if validationErr := req.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	if req.QuietHours != nil {
+		if err := h.notificationRepo.UpdateQuietHours(userID, *req.QuietHours); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to update notification settings")
+			return
+		}
+	}
+
+	settings, err := h.notificationRepo.UpdateSettings(userID, req.NotificationSettings)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update notification settings")
+		return
+	}
+
+	quietHours, err := h.notificationRepo.GetQuietHours(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get notification settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.NotificationSettingsResponse{NotificationSettings: settings, QuietHours: &quietHours})
+}
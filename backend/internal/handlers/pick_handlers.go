@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// PickHandlers handles editor's pick HTTP requests
+type PickHandlers struct {
+	articleRepo repositories.ArticleRepository
+}
+
+// NewPickHandlers creates a new pick handlers instance
+func NewPickHandlers(articleRepo repositories.ArticleRepository) *PickHandlers {
+	return &PickHandlers{
+		articleRepo: articleRepo,
+	}
+}
+
+// ListPicks handles the home page's editor's picks, in pick order
+func (h *PickHandlers) ListPicks(w http.ResponseWriter, r *http.Request) {
+	limit, _ := parsePage(r, 6, 20)
+	articles, total, err := h.articleRepo.List(&entities.ArticleListQuery{
+		Featured: true,
+		Limit:    limit,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get editor's picks")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ArticlesResponse{
+		Articles:      articles,
+		ArticlesCount: total,
+	})
+}
+
+// FeatureArticle handles making a published article an editor's pick, or
+// changing the note and position of an existing pick (admin only)
+func (h *PickHandlers) FeatureArticle(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Feature entities.ArticleFeature `json:"feature"`
+	}
+	if r.ContentLength != 0 {
+		if err := parseJSON(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON format")
+			return
+		}
+	}
+
+	req.Feature.Note = strings.TrimSpace(req.Feature.Note)
+	if validationErr := req.Feature.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	article, ok := h.article(w, r)
+	if !ok {
+		return
+	}
+
+	// Picks are listed, so they must be public originals
+	if article.TranslationOf != nil {
+		writeError(w, http.StatusBadRequest, "Feature the original article instead of a translation")
+		return
+	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusConflict, "Only published articles can be featured")
+		return
+	}
+
+	if err := h.articleRepo.Feature(article.ID, userID, &req.Feature); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to feature article")
+		return
+	}
+
+	h.writeArticle(w, article.ID)
+}
+
+// UnfeatureArticle handles removing an article from the editor's picks (admin only)
+func (h *PickHandlers) UnfeatureArticle(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.article(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.articleRepo.Unfeature(article.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to unfeature article")
+		return
+	}
+
+	h.writeArticle(w, article.ID)
+}
+
+// article loads the article named by the {slug} path variable, writing an
+// error response if it cannot
+func (h *PickHandlers) article(w http.ResponseWriter, r *http.Request) (*entities.Article, bool) {
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return nil, false
+	}
+	return article, true
+}
+
+// writeArticle responds with the article's current state
+func (h *PickHandlers) writeArticle(w http.ResponseWriter, id int64) {
+	article, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, article.ToArticleResponse())
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// PinHandlers handles pinning articles to their author's profile
+type PinHandlers struct {
+	articleRepo repositories.ArticleRepository
+	maxPinned   int
+}
+
+// NewPinHandlers creates a new pin handlers instance; authors can pin up to
+// maxPinned articles
+func NewPinHandlers(articleRepo repositories.ArticleRepository, maxPinned int) *PinHandlers {
+	return &PinHandlers{
+		articleRepo: articleRepo,
+		maxPinned:   maxPinned,
+	}
+}
+
+// PinArticle handles pinning one of the user's published articles
+func (h *PinHandlers) PinArticle(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.ownArticle(w, r)
+	if !ok {
+		return
+	}
+
+	// Pins are shown on the profile listing, which holds published originals
+	if article.TranslationOf != nil {
+		writeError(w, http.StatusBadRequest, "Pin the original article instead of a translation")
+		return
+	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusConflict, "Only published articles can be pinned")
+		return
+	}
+
+	if err := h.articleRepo.Pin(article.ID, article.AuthorID, h.maxPinned); err != nil {
+		if strings.Contains(err.Error(), "pin limit reached") {
+			writeError(w, http.StatusConflict, fmt.Sprintf("You can pin at most %d articles", h.maxPinned))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to pin article")
+		return
+	}
+
+	h.writeArticle(w, article.ID)
+}
+
+// UnpinArticle handles unpinning one of the user's articles
+func (h *PinHandlers) UnpinArticle(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.ownArticle(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.articleRepo.Unpin(article.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to unpin article")
+		return
+	}
+
+	h.writeArticle(w, article.ID)
+}
+
+// ownArticle loads the article named by the {slug} path variable and checks
+// the user wrote it, writing an error response if not
+func (h *PinHandlers) ownArticle(w http.ResponseWriter, r *http.Request) (*entities.Article, bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return nil, false
+	}
+
+	if article.AuthorID != userID {
+		writeError(w, http.StatusForbidden, "You can only pin your own articles")
+		return nil, false
+	}
+
+	return article, true
+}
+
+// writeArticle responds with the article's current state
+func (h *PinHandlers) writeArticle(w http.ResponseWriter, id int64) {
+	article, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, article.ToArticleResponse())
+}
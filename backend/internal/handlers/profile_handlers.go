@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// ProfileHandlers handles profile, follow and profile suggestion HTTP requests
+type ProfileHandlers struct {
+	userRepo            repositories.UserRepository
+	followRepo          repositories.FollowRepository
+	settingsRepo        repositories.SettingsRepository
+	activityRepo        repositories.ActivityRepository
+	notificationService services.NotificationService
+}
+
+// NewProfileHandlers creates a new profile handlers instance
+func NewProfileHandlers(userRepo repositories.UserRepository, followRepo repositories.FollowRepository, settingsRepo repositories.SettingsRepository, activityRepo repositories.ActivityRepository, notificationService services.NotificationService) *ProfileHandlers {
+	return &ProfileHandlers{
+		userRepo:            userRepo,
+		followRepo:          followRepo,
+		settingsRepo:        settingsRepo,
+		activityRepo:        activityRepo,
+		notificationService: notificationService,
+	}
+}
+
+// GetProfile handles getting a profile, honoring its visibility setting
+func (h *ProfileHandlers) GetProfile(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.visibleProfile(w, r)
+	if !ok {
+		return
+	}
+
+	profile := entities.ProfileView{Profile: entities.Profile{
+		Username: user.Username,
+		Bio:      user.Bio,
+		ImageURL: user.AvatarURL(),
+	}}
+
+	// Anonymous viewers follow no one
+	if viewerID, err := getUserIDFromContext(r); err == nil && viewerID != user.ID {
+		following, err := h.followRepo.IsFollowing(viewerID, user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get profile")
+			return
+		}
+		profile.Following = following
+	}
+
+	writeJSON(w, http.StatusOK, entities.ProfileResponse{Profile: profile})
+}
+
+// GetSuggestions handles listing authors to follow during onboarding.
+// ?tags=a,b ranks authors writing under those tags first.
+func (h *ProfileHandlers) GetSuggestions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit := 10 // Default limit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= entities.MaxBulkFollow {
+			limit = parsed
+		}
+	}
+
+	var tags []string
+	for _, tag := range strings.Split(r.URL.Query().Get("tags"), ",") {
+		if tag = entities.NormalizeTagName(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	suggestions, err := h.followRepo.Suggestions(userID, tags, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get profile suggestions")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ProfileSuggestionsResponse{Profiles: suggestions})
+}
+
+// ListFollowers handles listing a page of the profiles following a user
+func (h *ProfileHandlers) ListFollowers(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.visibleProfile(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	profiles, total, err := h.followRepo.ListFollowers(user.ID, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get followers")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ProfilesResponse{Profiles: profiles, ProfilesCount: total})
+}
+
+// ListActivity handles listing a page of a user's recent public actions,
+// newest first. ?types=a,b limits the feed to those activity types.
+func (h *ProfileHandlers) ListActivity(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.visibleProfile(w, r)
+	if !ok {
+		return
+	}
+
+	types, ok := parseActivityTypes(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	activities, total, err := h.activityRepo.ListPublicByUser(user.ID, types, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get activity")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ActivitiesResponse{
+		Activities:      activities,
+		ActivitiesCount: total,
+	})
+}
+
+// visibleProfile loads the user named by the {username} path variable. Profiles
+// the viewer may not see are reported as not found so their existence is not revealed.
+func (h *ProfileHandlers) visibleProfile(w http.ResponseWriter, r *http.Request) (*entities.User, bool) {
+	user, err := h.userRepo.GetByUsername(mux.Vars(r)["username"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Profile not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get profile")
+		return nil, false
+	}
+
+	settings, err := h.settingsRepo.Get(user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get profile")
+		return nil, false
+	}
+
+	// Anonymous requests have no user ID
+	viewerID, _ := getUserIDFromContext(r)
+	if user.IsDeactivated() || !settings.CanViewProfile(viewerID, user.ID) {
+		writeError(w, http.StatusNotFound, "Profile not found")
+		return nil, false
+	}
+
+	return user, true
+}
+
+// FollowProfile handles following a single profile
+func (h *ProfileHandlers) FollowProfile(w http.ResponseWriter, r *http.Request) {
+	h.followProfile(w, r, true)
+}
+
+// UnfollowProfile handles unfollowing a single profile
+func (h *ProfileHandlers) UnfollowProfile(w http.ResponseWriter, r *http.Request) {
+	h.followProfile(w, r, false)
+}
+
+// followProfile follows or unfollows the profile in the path and responds
+// with the profile as the caller now sees it
+func (h *ProfileHandlers) followProfile(w http.ResponseWriter, r *http.Request, follow bool) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, ok := h.visibleProfile(w, r)
+	if !ok {
+		return
+	}
+
+	profile := entities.ProfileView{Profile: entities.Profile{
+		Username: user.Username,
+		Bio:      user.Bio,
+		ImageURL: user.AvatarURL(),
+	}}
+
+	// Following yourself is a no-op rather than an error, as in bulk follows
+	if user.ID != userID {
+		apply := h.followRepo.Unfollow
+		if follow {
+			apply = h.followRepo.Follow
+		}
+		changed, err := apply(userID, []int64{user.ID})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to update follows")
+			return
+		}
+		if follow && len(changed) > 0 {
+			h.notifyFollowed(userID, changed)
+		}
+		profile.Following = follow
+	}
+
+	writeJSON(w, http.StatusOK, entities.ProfileResponse{Profile: profile})
+}
+
+// FollowProfiles handles following several profiles in one request
+func (h *ProfileHandlers) FollowProfiles(w http.ResponseWriter, r *http.Request) {
+	h.bulkFollow(w, r, true)
+}
+
+// UnfollowProfiles handles unfollowing several profiles in one request
+func (h *ProfileHandlers) UnfollowProfiles(w http.ResponseWriter, r *http.Request) {
+	h.bulkFollow(w, r, false)
+}
+
+// bulkFollow resolves the requested usernames and follows or unfollows them
+// together. Unknown usernames are reported rather than failing the request.
+func (h *ProfileHandlers) bulkFollow(w http.ResponseWriter, r *http.Request, follow bool) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req entities.BulkFollow
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+	req.Normalize()
+	if validationErr := req.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	response := entities.BulkFollowResponse{
+		Changed:   []string{},
+		Unchanged: []string{},
+		NotFound:  []string{},
+	}
+	usernames := make(map[int64]string, len(req.Usernames))
+	var ids []int64
+	for _, username := range req.Usernames {
+		user, err := h.userRepo.GetByUsername(username)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				response.NotFound = append(response.NotFound, username)
+				continue
+			}
+			writeError(w, http.StatusInternalServerError, "Failed to get user")
+			return
+		}
+		// Following yourself is a no-op rather than an error
+		if user.ID == userID {
+			response.Unchanged = append(response.Unchanged, user.Username)
+			continue
+		}
+		usernames[user.ID] = user.Username
+		ids = append(ids, user.ID)
+	}
+
+	apply := h.followRepo.Unfollow
+	if follow {
+		apply = h.followRepo.Follow
+	}
+	changed, err := apply(userID, ids)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update follows")
+		return
+	}
+
+	changedIDs := make(map[int64]bool, len(changed))
+	for _, id := range changed {
+		changedIDs[id] = true
+	}
+	for _, id := range ids {
+		if changedIDs[id] {
+			response.Changed = append(response.Changed, usernames[id])
+		} else {
+			response.Unchanged = append(response.Unchanged, usernames[id])
+		}
+	}
+
+	if follow && len(changed) > 0 {
+		h.notifyFollowed(userID, changed)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// notifyFollowed tells newly followed users about their new follower; a failed
+// notification must not fail the follow itself
+func (h *ProfileHandlers) notifyFollowed(followerID int64, followedIDs []int64) {
+	follower, err := h.userRepo.GetByID(followerID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load follower for notifications: %v", err)
+		return
+	}
+
+	message := fmt.Sprintf("%s started following you", follower.Username)
+	for _, id := range followedIDs {
+		if err := h.notificationService.Notify(id, entities.EventNewFollower, message, "/profile/"+follower.Username, nil); err != nil {
+			log.Printf("⚠️  Failed to send follower notification: %v", err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// PushHandlers handles Web Push subscription HTTP requests
+type PushHandlers struct {
+	subscriptionRepo repositories.PushSubscriptionRepository
+	publicKey        string
+}
+
+// NewPushHandlers creates a new push handlers instance; publicKey is the
+// VAPID public key, empty when push is not configured
+func NewPushHandlers(subscriptionRepo repositories.PushSubscriptionRepository, publicKey string) *PushHandlers {
+	return &PushHandlers{
+		subscriptionRepo: subscriptionRepo,
+		publicKey:        publicKey,
+	}
+}
+
+// GetPushKey handles returning the VAPID public key browsers subscribe with
+func (h *PushHandlers) GetPushKey(w http.ResponseWriter, r *http.Request) {
+	if h.publicKey == "" {
+		writeError(w, http.StatusServiceUnavailable, "Web push is not configured")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.PushKeyResponse{PublicKey: h.publicKey})
+}
+
+// ListPushSubscriptions handles listing the user's subscribed browsers
+func (h *PushHandlers) ListPushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	subscriptions, err := h.subscriptionRepo.ListByUser(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get push subscriptions")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.PushSubscriptionsResponse{Subscriptions: subscriptions})
+}
+
+// CreatePushSubscription handles registering a browser's push subscription
+func (h *PushHandlers) CreatePushSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Subscription entities.PushSubscriptionCreate `json:"subscription"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Subscription.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	subscription, err := h.subscriptionRepo.Save(userID, &req.Subscription)
+	if err != nil {
+		if strings.Contains(err.Error(), "too many") {
+			writeError(w, http.StatusConflict, "Too many push subscriptions; remove one first")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to save push subscription")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.PushSubscriptionResponse{Subscription: *subscription})
+}
+
+// DeletePushSubscription handles removing one of the user's push subscriptions
+func (h *PushHandlers) DeletePushSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	if err := h.subscriptionRepo.Delete(userID, id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Push subscription not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to delete push subscription")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
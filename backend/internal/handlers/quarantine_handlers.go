@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// QuarantineHandlers handles new account quarantine HTTP requests (admin only)
+type QuarantineHandlers struct {
+	userRepo       repositories.UserRepository
+	quarantineRepo repositories.QuarantineRepository
+	window         time.Duration
+}
+
+// NewQuarantineHandlers creates a new quarantine handlers instance
+func NewQuarantineHandlers(userRepo repositories.UserRepository, quarantineRepo repositories.QuarantineRepository, window time.Duration) *QuarantineHandlers {
+	return &QuarantineHandlers{
+		userRepo:       userRepo,
+		quarantineRepo: quarantineRepo,
+		window:         window,
+	}
+}
+
+// GetQuarantine handles showing whether an account is quarantined
+func (h *QuarantineHandlers) GetQuarantine(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	approval, err := h.quarantineRepo.GetApproval(user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get quarantine status")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.QuarantineStatusResponse{
+		Quarantine: entities.NewQuarantineStatus(user, h.window, approval, time.Now()),
+	})
+}
+
+// ApproveAccount handles releasing an account from quarantine before it ages out
+func (h *QuarantineHandlers) ApproveAccount(w http.ResponseWriter, r *http.Request) {
+	adminID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	approval, err := h.quarantineRepo.Approve(user.ID, adminID, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to approve account")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.QuarantineStatusResponse{
+		Quarantine: entities.NewQuarantineStatus(user, h.window, approval, time.Now()),
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// QuotaHandlers handles daily quota HTTP requests (admin only)
+type QuotaHandlers struct {
+	userRepo  repositories.UserRepository
+	quotaRepo repositories.QuotaRepository
+	quotas    services.QuotaService
+}
+
+// NewQuotaHandlers creates a new quota handlers instance
+func NewQuotaHandlers(userRepo repositories.UserRepository, quotaRepo repositories.QuotaRepository, quotas services.QuotaService) *QuotaHandlers {
+	return &QuotaHandlers{
+		userRepo:  userRepo,
+		quotaRepo: quotaRepo,
+		quotas:    quotas,
+	}
+}
+
+// GetQuota handles showing an account's quota and usage
+func (h *QuotaHandlers) GetQuota(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+	h.writeStatus(w, user)
+}
+
+// SetQuotaOverride handles giving an account its own daily caps; a cap of 0
+// removes it, and an omitted cap keeps the account's tier default
+func (h *QuotaHandlers) SetQuotaOverride(w http.ResponseWriter, r *http.Request) {
+	adminID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Override entities.QuotaOverride `json:"override"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	req.Override.Note = strings.TrimSpace(req.Override.Note)
+	if validationErr := req.Override.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	if err := h.quotaRepo.SetOverride(user.ID, adminID, &req.Override, time.Now()); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to set quota override")
+		return
+	}
+
+	h.writeStatus(w, user)
+}
+
+// DeleteQuotaOverride handles returning an account to its tier's quota
+func (h *QuotaHandlers) DeleteQuotaOverride(w http.ResponseWriter, r *http.Request) {
+	user, ok := pathUser(w, r, h.userRepo)
+	if !ok {
+		return
+	}
+
+	if err := h.quotaRepo.DeleteOverride(user.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Quota override not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to delete quota override")
+		return
+	}
+
+	h.writeStatus(w, user)
+}
+
+// writeStatus responds with the account's current quota status
+func (h *QuotaHandlers) writeStatus(w http.ResponseWriter, user *entities.User) {
+	status, err := h.quotas.Status(user)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get quota")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.QuotaStatusResponse{Quota: *status})
+}
+
+// checkQuota writes a 429 response and returns false when the user has used
+// their daily quota for kind
+func checkQuota(w http.ResponseWriter, quotas services.QuotaService, userID int64, kind string) bool {
+	limit, retryAfter, err := quotas.Check(userID, kind)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to check daily quota")
+		return false
+	}
+
+	if retryAfter > 0 {
+		writeTooManyRequests(w, retryAfter, fmt.Sprintf("You have reached your daily limit of %d %s, please try again later", limit, kind))
+		return false
+	}
+
+	return true
+}
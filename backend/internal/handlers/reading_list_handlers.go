@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// ReadingListHandlers handles reading list HTTP requests
+type ReadingListHandlers struct {
+	listRepo    repositories.ReadingListRepository
+	articleRepo repositories.ArticleRepository
+}
+
+// NewReadingListHandlers creates a new reading list handlers instance
+func NewReadingListHandlers(listRepo repositories.ReadingListRepository, articleRepo repositories.ArticleRepository) *ReadingListHandlers {
+	return &ReadingListHandlers{
+		listRepo:    listRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// CreateReadingList handles reading list creation
+func (h *ReadingListHandlers) CreateReadingList(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		ReadingList entities.ReadingListCreate `json:"readingList"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	req.ReadingList.Name = strings.TrimSpace(req.ReadingList.Name)
+	if validationErr := req.ReadingList.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	list, err := h.listRepo.Create(userID, &req.ReadingList)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			writeError(w, http.StatusConflict, "Reading list with this name already exists")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to create reading list")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.ReadingListResponse{ReadingList: *list})
+}
+
+// GetReadingList handles reading list retrieval with its published articles in order
+func (h *ReadingListHandlers) GetReadingList(w http.ResponseWriter, r *http.Request) {
+	list, ok := h.list(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeList(w, http.StatusOK, list)
+}
+
+// DeleteReadingList handles deleting one of the user's reading lists
+func (h *ReadingListHandlers) DeleteReadingList(w http.ResponseWriter, r *http.Request) {
+	list, ok := h.ownList(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.listRepo.Delete(list.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to delete reading list")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddReadingListItem handles adding any published article to the user's reading list
+func (h *ReadingListHandlers) AddReadingListItem(w http.ResponseWriter, r *http.Request) {
+	list, ok := h.ownList(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Article entities.ReadingListItemAdd `json:"article"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Article.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(req.Article.Slug)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+	if !article.IsPublished() {
+		writeError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	if err := h.listRepo.AddItem(list.ID, article.ID, req.Article.Position); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "already in the reading list"):
+			writeError(w, http.StatusConflict, "Article is already in the reading list")
+		case strings.Contains(err.Error(), "is full"):
+			writeError(w, http.StatusConflict, "Reading list is full")
+		default:
+			writeError(w, http.StatusInternalServerError, "Failed to add article to reading list")
+		}
+		return
+	}
+
+	h.writeList(w, http.StatusOK, list)
+}
+
+// RemoveReadingListItem handles removing an article from the user's reading list
+func (h *ReadingListHandlers) RemoveReadingListItem(w http.ResponseWriter, r *http.Request) {
+	list, ok := h.ownList(w, r)
+	if !ok {
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["article"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if err := h.listRepo.RemoveItem(list.ID, article.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article is not in this reading list")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to remove article from reading list")
+		return
+	}
+
+	h.writeList(w, http.StatusOK, list)
+}
+
+// ReorderReadingList handles putting the user's reading list in a new order.
+// The body names every listed article; ones no longer published keep their
+// relative order at the end.
+func (h *ReadingListHandlers) ReorderReadingList(w http.ResponseWriter, r *http.Request) {
+	list, ok := h.ownList(w, r)
+	if !ok {
+		return
+	}
+
+	var req entities.ReadingListReorder
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	current, err := h.listRepo.ArticleIDs(list.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get reading list items")
+		return
+	}
+
+	order := make([]int64, 0, len(current))
+	named := make(map[int64]bool, len(req.Articles))
+	for _, slug := range req.Articles {
+		article, err := h.articleRepo.GetBySlug(slug)
+		if err != nil || !article.IsPublished() {
+			writeValidationErrors(w, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+				Field:   "articles",
+				Message: "articles must match the reading list",
+			}}})
+			return
+		}
+		order = append(order, article.ID)
+		named[article.ID] = true
+	}
+	for _, id := range current {
+		if named[id] {
+			continue
+		}
+		article, err := h.articleRepo.GetByID(id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get reading list items")
+			return
+		}
+		if !article.IsPublished() {
+			order = append(order, id)
+		}
+	}
+
+	if err := h.listRepo.Reorder(list.ID, order); err != nil {
+		if strings.Contains(err.Error(), "do not match") {
+			writeValidationErrors(w, &entities.ValidationErrors{Errors: []entities.ValidationError{{
+				Field:   "articles",
+				Message: "articles must match the reading list",
+			}}})
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to reorder reading list")
+		return
+	}
+
+	h.writeList(w, http.StatusOK, list)
+}
+
+// FollowReadingList handles following a reading list
+func (h *ReadingListHandlers) FollowReadingList(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, true)
+}
+
+// UnfollowReadingList handles unfollowing a reading list
+func (h *ReadingListHandlers) UnfollowReadingList(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, false)
+}
+
+// setFollowing follows or unfollows the reading list and responds with it
+func (h *ReadingListHandlers) setFollowing(w http.ResponseWriter, r *http.Request, follow bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	list, ok := h.list(w, r)
+	if !ok {
+		return
+	}
+
+	apply := h.listRepo.Unfollow
+	if follow {
+		apply = h.listRepo.Follow
+	}
+	if _, err := apply(list.ID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update reading list follow")
+		return
+	}
+
+	// Reload for the new follower count
+	list, ok = h.list(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeList(w, http.StatusOK, list)
+}
+
+// list loads the reading list named by the {slug} path variable as seen by
+// the (possibly anonymous) viewer, writing an error response if it cannot
+func (h *ReadingListHandlers) list(w http.ResponseWriter, r *http.Request) (*entities.ReadingList, bool) {
+	viewerID, _ := getUserIDFromContext(r)
+
+	list, err := h.listRepo.GetBySlug(mux.Vars(r)["slug"], viewerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Reading list not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get reading list")
+		return nil, false
+	}
+	return list, true
+}
+
+// ownList loads the reading list and checks the user created it
+func (h *ReadingListHandlers) ownList(w http.ResponseWriter, r *http.Request) (*entities.ReadingList, bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+
+	list, ok := h.list(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if list.OwnerID != userID {
+		writeError(w, http.StatusForbidden, "You can only change your own reading lists")
+		return nil, false
+	}
+
+	return list, true
+}
+
+// writeList responds with the reading list and its published articles in order
+func (h *ReadingListHandlers) writeList(w http.ResponseWriter, status int, list *entities.ReadingList) {
+	ids, err := h.listRepo.ArticleIDs(list.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get reading list items")
+		return
+	}
+
+	list.Articles = []entities.Article{}
+	for _, id := range ids {
+		article, err := h.articleRepo.GetByID(id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get reading list items")
+			return
+		}
+		if article.IsPublished() {
+			list.Articles = append(list.Articles, *article)
+		}
+	}
+
+	writeJSON(w, status, entities.ReadingListResponse{ReadingList: *list})
+}
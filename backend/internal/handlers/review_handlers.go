@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// ReviewHandlers handles the article approval queue HTTP requests (reviewers)
+type ReviewHandlers struct {
+	articleRepo         repositories.ArticleRepository
+	notificationService services.NotificationService
+}
+
+// NewReviewHandlers creates a new review handlers instance
+func NewReviewHandlers(articleRepo repositories.ArticleRepository, notificationService services.NotificationService) *ReviewHandlers {
+	return &ReviewHandlers{
+		articleRepo:         articleRepo,
+		notificationService: notificationService,
+	}
+}
+
+// ListPendingArticles handles listing a page of articles awaiting review, oldest first
+func (h *ReviewHandlers) ListPendingArticles(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePage(r, 20, 100)
+	articles, total, err := h.articleRepo.List(&entities.ArticleListQuery{
+		Status: entities.ArticleStatusPending,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list pending articles")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.ArticlesResponse{
+		Articles:      articles,
+		ArticlesCount: total,
+	})
+}
+
+// ApproveArticle handles publishing a pending article
+func (h *ReviewHandlers) ApproveArticle(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, entities.ArticleStatusPublished)
+}
+
+// RejectArticle handles rejecting a pending article with an optional note for its author
+func (h *ReviewHandlers) RejectArticle(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, entities.ArticleStatusRejected)
+}
+
+// review records the reviewer's decision on the pending article named by the
+// {slug} path variable and tells its author
+func (h *ReviewHandlers) review(w http.ResponseWriter, r *http.Request, status string) {
+	reviewerID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var req struct {
+		Review entities.ArticleReview `json:"review"`
+	}
+	if r.ContentLength != 0 {
+		if err := parseJSON(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON format")
+			return
+		}
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	// Reviews apply to whole translation groups, recorded on the original
+	if article.TranslationOf != nil {
+		writeError(w, http.StatusBadRequest, "Review the original article instead of a translation")
+		return
+	}
+	if article.Status != entities.ArticleStatusPending {
+		writeError(w, http.StatusConflict, "Article is not awaiting review")
+		return
+	}
+
+	reviewed, err := h.articleRepo.Review(article.ID, status, reviewerID, strings.TrimSpace(req.Review.Note))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to review article")
+		return
+	}
+
+	h.notifyAuthor(reviewed)
+
+	writeJSON(w, http.StatusOK, reviewed.ToArticleResponse())
+}
+
+// notifyAuthor tells the author about the decision; a failed notification
+// must not fail the review itself
+func (h *ReviewHandlers) notifyAuthor(article *entities.Article) {
+	message := fmt.Sprintf("\"%s\" was published", article.Title)
+	if article.Status == entities.ArticleStatusRejected {
+		message = fmt.Sprintf("\"%s\" was not approved", article.Title)
+		if article.ReviewNote != "" {
+			message += ": " + article.ReviewNote
+		}
+	}
+
+	if err := h.notificationService.Notify(article.AuthorID, entities.EventArticleReviewed, message, "/article/"+article.Slug, nil); err != nil {
+		log.Printf("⚠️  Failed to send review notification: %v", err)
+	}
+}
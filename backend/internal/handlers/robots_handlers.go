@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RobotsOptions configures the robots.txt served to crawlers
+type RobotsOptions struct {
+	// Disallow lists paths no crawler may fetch
+	Disallow []string
+	// BlockedAgents are user agents shut out of the whole site
+	BlockedAgents []string
+	// DisallowAll shuts every crawler out, e.g. on staging deployments
+	DisallowAll bool
+	// SitemapURL is advertised to crawlers when set
+	SitemapURL string
+}
+
+// RobotsHandlers serves robots.txt
+type RobotsHandlers struct {
+	body []byte
+}
+
+// NewRobotsHandlers creates a new robots handlers instance; the document is
+// rendered once since it only depends on configuration
+func NewRobotsHandlers(options RobotsOptions) *RobotsHandlers {
+	return &RobotsHandlers{
+		body: []byte(renderRobots(options)),
+	}
+}
+
+// GetRobots handles serving robots.txt
+func (h *RobotsHandlers) GetRobots(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(h.body)
+}
+
+// renderRobots writes one group per blocked agent followed by the group for
+// every other crawler
+func renderRobots(options RobotsOptions) string {
+	var b strings.Builder
+	for _, agent := range options.BlockedAgents {
+		b.WriteString("User-agent: " + agent + "\nDisallow: /\n\n")
+	}
+
+	b.WriteString("User-agent: *\n")
+	switch {
+	case options.DisallowAll:
+		b.WriteString("Disallow: /\n")
+	case len(options.Disallow) == 0:
+		// An empty Disallow allows everything
+		b.WriteString("Disallow:\n")
+	default:
+		for _, path := range options.Disallow {
+			b.WriteString("Disallow: " + path + "\n")
+		}
+	}
+
+	if options.SitemapURL != "" && !options.DisallowAll {
+		b.WriteString("\nSitemap: " + options.SitemapURL + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// SearchPingHandlers handles search engine notification HTTP requests
+type SearchPingHandlers struct {
+	searchPingRepo repositories.SearchPingRepository
+	indexNowKey    string
+}
+
+// NewSearchPingHandlers creates a new search ping handlers instance
+func NewSearchPingHandlers(searchPingRepo repositories.SearchPingRepository, indexNowKey string) *SearchPingHandlers {
+	return &SearchPingHandlers{
+		searchPingRepo: searchPingRepo,
+		indexNowKey:    indexNowKey,
+	}
+}
+
+// ListSearchPings handles listing a page of queued and sent search pings with
+// their delivery status (admins); ?status= is pending, delivered, failed or all (the default)
+func (h *SearchPingHandlers) ListSearchPings(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = entities.SearchPingStatusAll
+	}
+	switch status {
+	case entities.SearchPingStatusPending, entities.SearchPingStatusDelivered, entities.SearchPingStatusFailed, entities.SearchPingStatusAll:
+	default:
+		writeError(w, http.StatusBadRequest, "status must be one of: pending, delivered, failed, all")
+		return
+	}
+
+	limit, offset := parsePage(r, 20, 100)
+	pings, total, err := h.searchPingRepo.List(status, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get search pings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.SearchPingsResponse{
+		SearchPings:      pings,
+		SearchPingsCount: total,
+	})
+}
+
+// GetIndexNowKey handles serving the IndexNow key file search engines fetch
+// to verify submissions
+func (h *SearchPingHandlers) GetIndexNowKey(w http.ResponseWriter, r *http.Request) {
+	if h.indexNowKey == "" {
+		writeError(w, http.StatusNotFound, "IndexNow is not configured")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(h.indexNowKey))
+}
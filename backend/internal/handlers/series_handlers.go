@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// SeriesHandlers handles article series HTTP requests
+type SeriesHandlers struct {
+	seriesRepo  repositories.SeriesRepository
+	articleRepo repositories.ArticleRepository
+}
+
+// NewSeriesHandlers creates a new series handlers instance
+func NewSeriesHandlers(seriesRepo repositories.SeriesRepository, articleRepo repositories.ArticleRepository) *SeriesHandlers {
+	return &SeriesHandlers{
+		seriesRepo:  seriesRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// CreateSeries handles series creation
+func (h *SeriesHandlers) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req struct {
+		Series entities.SeriesCreate `json:"series"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	req.Series.Title = strings.TrimSpace(req.Series.Title)
+	if validationErr := req.Series.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	series, err := h.seriesRepo.Create(userID, &req.Series)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			writeError(w, http.StatusConflict, "Series with this title already exists")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to create series")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.SeriesResponse{Series: *series})
+}
+
+// GetSeries handles series retrieval with its published articles in order
+func (h *SeriesHandlers) GetSeries(w http.ResponseWriter, r *http.Request) {
+	series, ok := h.series(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeSeries(w, http.StatusOK, series)
+}
+
+// DeleteSeries handles deleting one of the user's series; its articles are kept
+func (h *SeriesHandlers) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	series, ok := h.ownSeries(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.seriesRepo.Delete(series.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to delete series")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddSeriesArticle handles attaching one of the user's articles to their series
+func (h *SeriesHandlers) AddSeriesArticle(w http.ResponseWriter, r *http.Request) {
+	series, ok := h.ownSeries(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Article entities.SeriesArticleAdd `json:"article"`
+	}
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Article.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(req.Article.Slug)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if article.AuthorID != series.AuthorID {
+		writeError(w, http.StatusForbidden, "You can only add your own articles to a series")
+		return
+	}
+	// Translations follow their original article
+	if article.TranslationOf != nil {
+		writeError(w, http.StatusBadRequest, "Add the original article instead of a translation")
+		return
+	}
+
+	if err := h.seriesRepo.AddArticle(series.ID, article.ID, req.Article.Position); err != nil {
+		if strings.Contains(err.Error(), "already in a series") {
+			writeError(w, http.StatusConflict, "Article is already in a series")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to add article to series")
+		return
+	}
+
+	h.writeSeries(w, http.StatusOK, series)
+}
+
+// RemoveSeriesArticle handles detaching an article from the user's series
+func (h *SeriesHandlers) RemoveSeriesArticle(w http.ResponseWriter, r *http.Request) {
+	series, ok := h.ownSeries(w, r)
+	if !ok {
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["article"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	if err := h.seriesRepo.RemoveArticle(series.ID, article.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article is not in this series")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to remove article from series")
+		return
+	}
+
+	h.writeSeries(w, http.StatusOK, series)
+}
+
+// series loads the series named by the {slug} path variable, writing an error
+// response if it cannot
+func (h *SeriesHandlers) series(w http.ResponseWriter, r *http.Request) (*entities.Series, bool) {
+	series, err := h.seriesRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Series not found")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get series")
+		return nil, false
+	}
+	return series, true
+}
+
+// ownSeries loads the series and checks the user created it
+func (h *SeriesHandlers) ownSeries(w http.ResponseWriter, r *http.Request) (*entities.Series, bool) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+
+	series, ok := h.series(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if series.AuthorID != userID {
+		writeError(w, http.StatusForbidden, "You can only change your own series")
+		return nil, false
+	}
+
+	return series, true
+}
+
+// writeSeries responds with the series and its published articles in order
+func (h *SeriesHandlers) writeSeries(w http.ResponseWriter, status int, series *entities.Series) {
+	ids, err := h.seriesRepo.ArticleIDs(series.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get series articles")
+		return
+	}
+
+	series.Articles = []entities.Article{}
+	for _, id := range ids {
+		article, err := h.articleRepo.GetByID(id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to get series articles")
+			return
+		}
+		if article.IsPublished() {
+			series.Articles = append(series.Articles, *article)
+		}
+	}
+
+	writeJSON(w, status, entities.SeriesResponse{Series: *series})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// SessionHandlers handles the current user's login sessions, so they can see
+// where they are logged in and log other devices out
+type SessionHandlers struct {
+	sessionRepo repositories.SessionRepository
+}
+
+// NewSessionHandlers creates a new session handlers instance
+func NewSessionHandlers(sessionRepo repositories.SessionRepository) *SessionHandlers {
+	return &SessionHandlers{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// ListSessions handles listing the user's active sessions, most recently
+// used first, marking the one the request was made with
+func (h *SessionHandlers) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessions, err := h.sessionRepo.ListActive(userID, time.Now().UTC())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get sessions")
+		return
+	}
+
+	current := middleware.SessionIDFromContext(r)
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+
+	writeJSON(w, http.StatusOK, entities.SessionsResponse{
+		Sessions:      sessions,
+		SessionsCount: len(sessions),
+	})
+}
+
+// RevokeSession handles logging one of the user's sessions out; its tokens
+// are rejected from then on
+func (h *SessionHandlers) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, ok := middleware.APIKeyIDFromContext(r); ok {
+		writeError(w, http.StatusForbidden, "Sessions cannot be managed with an API key")
+		return
+	}
+
+	if err := h.sessionRepo.Revoke(userID, mux.Vars(r)["id"], time.Now().UTC()); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Session not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
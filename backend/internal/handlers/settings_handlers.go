@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// SettingsHandlers handles user privacy settings HTTP requests
+type SettingsHandlers struct {
+	settingsRepo repositories.SettingsRepository
+	userRepo     repositories.UserRepository
+	adultAge     int
+}
+
+// NewSettingsHandlers creates a new settings handlers instance. Users whose
+// birthdate makes them younger than adultAge cannot make their profile public
+// or searchable; 0 lifts the restriction.
+func NewSettingsHandlers(settingsRepo repositories.SettingsRepository, userRepo repositories.UserRepository, adultAge int) *SettingsHandlers {
+	return &SettingsHandlers{
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+		adultAge:     adultAge,
+	}
+}
+
+// GetSettings handles getting the current user's settings
+func (h *SettingsHandlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	settings, err := h.settingsRepo.Get(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.UserSettingsResponse{Settings: settings})
+}
+
+// UpdateSettings handles updating one or more of the current user's settings.
+// Changes apply to existing favorites and follows as well as future ones.
+func (h *SettingsHandlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req entities.UserSettingsUpdateRequest
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate settings
+	if validationErr := req.Settings.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	if h.adultAge > 0 {
+		birthdate, err := h.userRepo.GetBirthdate(userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to update settings")
+			return
+		}
+		if birthdate != nil && entities.AgeOn(*birthdate, time.Now()) < h.adultAge {
+			if validationErr := req.Settings.ValidateForMinor(); validationErr != nil {
+				writeValidationErrors(w, validationErr)
+				return
+			}
+		}
+	}
+
+	settings, err := h.settingsRepo.Update(userID, &req.Settings)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.UserSettingsResponse{Settings: settings})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// ShareHandlers hands out short share links for articles and follows them
+type ShareHandlers struct {
+	articleRepo   repositories.ArticleRepository
+	analyticsRepo repositories.AnalyticsRepository
+	siteURL       string
+	publicURL     string
+}
+
+// NewShareHandlers creates a new share handlers instance. Short links are
+// served from publicURL and redirect to article pages on siteURL.
+func NewShareHandlers(articleRepo repositories.ArticleRepository, analyticsRepo repositories.AnalyticsRepository, siteURL, publicURL string) *ShareHandlers {
+	return &ShareHandlers{
+		articleRepo:   articleRepo,
+		analyticsRepo: analyticsRepo,
+		siteURL:       strings.TrimRight(siteURL, "/"),
+		publicURL:     strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// GetShareLinks handles listing a published article's short link for each
+// share medium. Links are keyed by article ID, so they survive slug changes.
+func (h *ShareHandlers) GetShareLinks(w http.ResponseWriter, r *http.Request) {
+	article, err := h.articleRepo.GetBySlug(mux.Vars(r)["slug"])
+	if err != nil || article.Status != entities.ArticleStatusPublished {
+		if err == nil || strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	links := make([]entities.ShareLink, 0, len(entities.ShareMediums))
+	for _, medium := range entities.ShareMediums {
+		links = append(links, entities.ShareLink{
+			Medium:      medium,
+			URL:         h.publicURL + "/s/" + entities.ShareCode(article.ID, medium),
+			Destination: entities.ShareDestination(h.siteURL, article.Slug, medium),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, entities.ShareLinksResponse{ShareLinks: links})
+}
+
+// FollowShareLink handles a click on a short share link: it counts the click
+// for the article's author and redirects to the UTM-tagged article page
+func (h *ShareHandlers) FollowShareLink(w http.ResponseWriter, r *http.Request) {
+	id, medium, ok := entities.ParseShareCode(mux.Vars(r)["code"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "Share link not found")
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(id)
+	if err != nil || article.Status != entities.ArticleStatusPublished {
+		if err == nil || strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "Share link not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get article")
+		return
+	}
+
+	// A lost count must not strand the visitor
+	if err := h.analyticsRepo.RecordShareClick(article.ID, medium, time.Now()); err != nil {
+		log.Printf("⚠️  Failed to record share click on article %d: %v", article.ID, err)
+	}
+
+	// Every click should reach us to be counted
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, entities.ShareDestination(h.siteURL, article.Slug, medium), http.StatusFound)
+}
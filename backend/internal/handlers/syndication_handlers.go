@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// Syndication limits
+const (
+	// feedItemLimit is the number of most recent articles in an RSS feed
+	feedItemLimit = 20
+	// sitemapURLLimit is the maximum number of URLs a single sitemap may hold
+	sitemapURLLimit = 50000
+)
+
+// SyndicationHandlers serves RSS feeds and the sitemap from the syndication cache
+type SyndicationHandlers struct {
+	articleRepo  repositories.ArticleRepository
+	userRepo     repositories.UserRepository
+	settingsRepo repositories.SettingsRepository
+	cache        services.SyndicationCache
+	siteURL      string
+}
+
+// NewSyndicationHandlers creates a new syndication handlers instance.
+// siteURL is the public frontend address that article links point to.
+func NewSyndicationHandlers(articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, settingsRepo repositories.SettingsRepository, cache services.SyndicationCache, siteURL string) *SyndicationHandlers {
+	return &SyndicationHandlers{
+		articleRepo:  articleRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		cache:        cache,
+		siteURL:      strings.TrimRight(siteURL, "/"),
+	}
+}
+
+// rssDocument is an RSS 2.0 document
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel describes a feed and its items
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+// rssItem is one article in a feed
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+// rssGUID identifies an item; the article link is its permanent identifier
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// sitemapDocument is a sitemaps.org URL set
+type sitemapDocument struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is one page in the sitemap
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// GetFeed handles the site-wide RSS feed of recent articles
+func (h *SyndicationHandlers) GetFeed(w http.ResponseWriter, r *http.Request) {
+	body, err := h.cache.Get(services.SyndicationKeyFeed, func() ([]byte, error) {
+		return h.buildFeed("Conduit", h.siteURL+"/", "Recent articles", &entities.ArticleListQuery{Limit: feedItemLimit})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate feed")
+		return
+	}
+
+	writeXML(w, "application/rss+xml", body)
+}
+
+// GetAuthorFeed handles an author's RSS feed of recent articles. Feed readers
+// are anonymous, so only authors with public profiles have one.
+func (h *SyndicationHandlers) GetAuthorFeed(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	user, err := h.userRepo.GetByUsername(username)
+	if err != nil {
+		if containsString(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+
+	settings, err := h.settingsRepo.Get(user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if user.IsDeactivated() || !settings.CanViewProfile(0, user.ID) {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	body, err := h.cache.Get(services.SyndicationAuthorFeedKey(user.Username), func() ([]byte, error) {
+		link := h.siteURL + "/profile/" + url.PathEscape(user.Username)
+		return h.buildFeed("Conduit: "+user.Username, link, "Recent articles by "+user.Username, &entities.ArticleListQuery{Limit: feedItemLimit, Author: user.Username})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate feed")
+		return
+	}
+
+	writeXML(w, "application/rss+xml", body)
+}
+
+// GetSitemap handles the sitemap of the home page and every article
+func (h *SyndicationHandlers) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	body, err := h.cache.Get(services.SyndicationKeySitemap, h.buildSitemap)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate sitemap")
+		return
+	}
+
+	writeXML(w, "application/xml", body)
+}
+
+// GetCacheStats handles returning syndication cache metrics (admin only)
+func (h *SyndicationHandlers) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"cache": h.cache.Stats(),
+	})
+}
+
+// FlushCache handles dropping every cached feed and sitemap (admin only)
+func (h *SyndicationHandlers) FlushCache(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]int{
+		"flushed": h.cache.Flush(),
+	})
+}
+
+// buildFeed renders an RSS document for the articles matching query
+func (h *SyndicationHandlers) buildFeed(title, link, description string, query *entities.ArticleListQuery) ([]byte, error) {
+	articles, _, err := h.articleRepo.List(query)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := rssChannel{
+		Title:         title,
+		Link:          link,
+		Description:   description,
+		LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+		Items:         make([]rssItem, 0, len(articles)),
+	}
+	for _, article := range articles {
+		articleLink := h.articleURL(article.Slug)
+		channel.Items = append(channel.Items, rssItem{
+			Title:       article.Title,
+			Link:        articleLink,
+			GUID:        rssGUID{Value: articleLink, IsPermaLink: true},
+			PubDate:     article.CreatedAt.UTC().Format(time.RFC1123Z),
+			Description: article.Description,
+		})
+	}
+
+	return marshalXML(rssDocument{Version: "2.0", Channel: channel})
+}
+
+// buildSitemap renders the sitemap, paging through every article
+func (h *SyndicationHandlers) buildSitemap() ([]byte, error) {
+	urls := []sitemapURL{{Loc: h.siteURL + "/"}}
+
+	query := &entities.ArticleListQuery{Limit: 100}
+	for len(urls) < sitemapURLLimit {
+		articles, _, err := h.articleRepo.List(query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, article := range articles {
+			if !article.Indexable() {
+				continue
+			}
+			urls = append(urls, sitemapURL{
+				Loc:     h.articleURL(article.Slug),
+				LastMod: article.UpdatedAt.UTC().Format("2006-01-02"),
+			})
+		}
+
+		if len(articles) < query.Limit {
+			break
+		}
+		query.Offset += len(articles)
+	}
+
+	if len(urls) > sitemapURLLimit {
+		urls = urls[:sitemapURLLimit]
+	}
+
+	return marshalXML(sitemapDocument{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: urls})
+}
+
+// articleURL returns the frontend address of an article
+func (h *SyndicationHandlers) articleURL(slug string) string {
+	return h.siteURL + "/article/" + url.PathEscape(slug)
+}
+
+// marshalXML encodes a document with the XML declaration
+func marshalXML(document interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// writeXML writes a pre-rendered XML document; clients and proxies may reuse
+// it briefly since the server-side cache already tracks changes
+func writeXML(w http.ResponseWriter, mediaType string, body []byte) {
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
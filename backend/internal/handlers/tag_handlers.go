@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// TagHandlers handles tag-related HTTP requests
+type TagHandlers struct {
+	tagRepo     repositories.TagRepository
+	articleRepo repositories.ArticleRepository
+}
+
+// NewTagHandlers creates a new tag handlers instance
+func NewTagHandlers(tagRepo repositories.TagRepository, articleRepo repositories.ArticleRepository) *TagHandlers {
+	return &TagHandlers{
+		tagRepo:     tagRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// ListTags handles listing canonical tag names
+func (h *TagHandlers) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tagRepo.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.TagsResponse{Tags: tags})
+}
+
+// GetTag handles the tag landing page: description, counts, top authors and recent articles
+func (h *TagHandlers) GetTag(w http.ResponseWriter, r *http.Request) {
+	tag, err := h.tagRepo.GetByName(mux.Vars(r)["tag"])
+	if err != nil {
+		writeTagError(w, err, "Failed to get tag")
+		return
+	}
+
+	topAuthors, err := h.tagRepo.GetTopAuthors(tag.ID, 5)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get top authors")
+		return
+	}
+
+	recentArticles, _, err := h.articleRepo.List(&entities.ArticleListQuery{
+		Limit:       5,
+		Tag:         tag.Name,
+		ExcludeBody: true,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get recent articles")
+		return
+	}
+	if recentArticles == nil {
+		recentArticles = []entities.Article{}
+	}
+
+	response := entities.TagDetailResponse{
+		Tag: entities.TagDetail{
+			Tag:            *tag,
+			TopAuthors:     topAuthors,
+			RecentArticles: recentArticles,
+		},
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// UpdateTagDescription handles editing a tag's description (moderators and admins)
+func (h *TagHandlers) UpdateTagDescription(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["tag"]
+
+	var req struct {
+		Tag entities.TagDescriptionUpdate `json:"tag"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Tag.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	tag, err := h.tagRepo.UpdateDescription(name, req.Tag.Description)
+	if err != nil {
+		writeTagError(w, err, "Failed to update tag description")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.TagResponse{Tag: *tag})
+}
+
+// ListTagsDetailed handles listing tags with counts and aliases for moderators
+func (h *TagHandlers) ListTagsDetailed(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tagRepo.ListDetailed()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.TagListResponse{Tags: tags})
+}
+
+// RenameTag handles renaming a tag; the old name becomes an alias
+func (h *TagHandlers) RenameTag(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["tag"]
+
+	var req struct {
+		Tag entities.TagRename `json:"tag"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Tag.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	tag, err := h.tagRepo.Rename(name, req.Tag.Name)
+	if err != nil {
+		writeTagError(w, err, "Failed to rename tag")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.TagResponse{Tag: *tag})
+}
+
+// MergeTag handles merging the path tag into another tag
+func (h *TagHandlers) MergeTag(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["tag"]
+
+	var req struct {
+		Tag entities.TagMerge `json:"tag"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Tag.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	tag, err := h.tagRepo.Merge(name, req.Tag.Into)
+	if err != nil {
+		writeTagError(w, err, "Failed to merge tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entities.TagResponse{Tag: *tag})
+}
+
+// AddTagAlias handles defining an alias for a tag
+func (h *TagHandlers) AddTagAlias(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["tag"]
+
+	var req struct {
+		Tag entities.TagAliasCreate `json:"tag"`
+	}
+
+	if err := parseJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErr := req.Tag.Validate(); validationErr != nil {
+		writeValidationErrors(w, validationErr)
+		return
+	}
+
+	tag, err := h.tagRepo.AddAlias(name, req.Tag.Alias)
+	if err != nil {
+		writeTagError(w, err, "Failed to create alias")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, entities.TagResponse{Tag: *tag})
+}
+
+// RemoveTagAlias handles deleting an alias
+func (h *TagHandlers) RemoveTagAlias(w http.ResponseWriter, r *http.Request) {
+	alias := mux.Vars(r)["alias"]
+
+	if err := h.tagRepo.RemoveAlias(alias); err != nil {
+		writeTagError(w, err, "Failed to delete alias")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTagError maps tag repository errors to HTTP responses
+func writeTagError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case containsString(err.Error(), "not found"):
+		writeError(w, http.StatusNotFound, err.Error())
+	case containsString(err.Error(), "already exists"):
+		writeError(w, http.StatusConflict, err.Error())
+	case containsString(err.Error(), "cannot merge"):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+const (
+	// exportBatchSize is how many articles, comments or favorites are read
+	// and written at a time while streaming an export
+	exportBatchSize = 100
+	// exportBatchWriteTimeout is how long each batch may take to reach the
+	// client. The server's write timeout would cut long exports short, so
+	// the deadline is pushed forward before each batch instead.
+	exportBatchWriteTimeout = 15 * time.Second
+)
+
+// UserDataHandlers handles exporting everything a user created on the site
+type UserDataHandlers struct {
+	userRepo     repositories.UserRepository
+	settingsRepo repositories.SettingsRepository
+	userDataRepo repositories.UserDataRepository
+}
+
+// NewUserDataHandlers creates a new user data handlers instance
+func NewUserDataHandlers(userRepo repositories.UserRepository, settingsRepo repositories.SettingsRepository, userDataRepo repositories.UserDataRepository) *UserDataHandlers {
+	return &UserDataHandlers{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		userDataRepo: userDataRepo,
+	}
+}
+
+// ExportUserData handles downloading the user's profile, settings, articles,
+// comments and favorites as one JSON document. The lists are streamed a batch
+// at a time, so exports of prolific users are never held in memory.
+func (h *UserDataHandlers) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserIDFromContext(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, ok := middleware.APIKeyIDFromContext(r); ok {
+		writeError(w, http.StatusForbidden, "Data exports cannot be requested with an API key")
+		return
+	}
+
+	user, err := h.userRepo.GetByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	birthdate, err := h.userRepo.GetBirthdate(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	settings, err := h.settingsRepo.Get(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get settings")
+		return
+	}
+
+	profile := entities.UserDataProfile{
+		Username:  user.Username,
+		Email:     user.Email,
+		Bio:       user.Bio,
+		Image:     user.ImageURL,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}
+	if birthdate != nil {
+		formatted := birthdate.Format("2006-01-02")
+		profile.Birthdate = &formatted
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", user.Username+"-export.json"))
+	w.Header().Set("Cache-Control", "no-store")
+
+	out := newExportWriter(w)
+	if err := out.extendDeadline(); err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to start export")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent, so a failure part way through can only cut
+	// the document short, which leaves it invalid rather than silently partial
+	if err := h.streamExport(out, userID, profile, settings); err != nil {
+		log.Printf("⚠️  Failed to export data of user %d: %v", userID, err)
+	}
+}
+
+// streamExport writes the export document of the user
+func (h *UserDataHandlers) streamExport(out *exportWriter, userID int64, profile entities.UserDataProfile, settings entities.UserSettings) error {
+	out.raw(`{"export":{"generatedAt":`)
+	out.value(time.Now().UTC())
+	out.raw(`,"profile":`)
+	out.value(profile)
+	out.raw(`,"settings":`)
+	out.value(settings)
+
+	out.raw(`,"articles":[`)
+	for after, first := int64(0), true; ; {
+		if err := out.extendDeadline(); err != nil {
+			return err
+		}
+		articles, err := h.userDataRepo.ArticlesAfter(userID, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, article := range articles {
+			out.item(&first, article)
+			after = article.ID
+		}
+		if err := out.flush(); err != nil || len(articles) < exportBatchSize {
+			break
+		}
+	}
+
+	out.raw(`],"comments":[`)
+	for after, first := int64(0), true; ; {
+		if err := out.extendDeadline(); err != nil {
+			return err
+		}
+		comments, err := h.userDataRepo.CommentsAfter(userID, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			out.item(&first, comment)
+			after = comment.ID
+		}
+		if err := out.flush(); err != nil || len(comments) < exportBatchSize {
+			break
+		}
+	}
+
+	out.raw(`],"favorites":[`)
+	for after, first := int64(0), true; ; {
+		if err := out.extendDeadline(); err != nil {
+			return err
+		}
+		favorites, err := h.userDataRepo.FavoritesAfter(userID, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, favorite := range favorites {
+			out.item(&first, favorite)
+			after = favorite.ArticleID
+		}
+		if err := out.flush(); err != nil || len(favorites) < exportBatchSize {
+			break
+		}
+	}
+
+	out.raw(`]}}`)
+	return out.flush()
+}
+
+// exportWriter writes a JSON document piece by piece, keeping the first
+// error so the pieces can be written without checking each one
+type exportWriter struct {
+	w          io.Writer
+	controller *http.ResponseController
+	err        error
+}
+
+// newExportWriter creates an export writer for the response
+func newExportWriter(w http.ResponseWriter) *exportWriter {
+	return &exportWriter{w: w, controller: http.NewResponseController(w)}
+}
+
+// extendDeadline gives the next batch exportBatchWriteTimeout to be written.
+// Writers without deadlines, e.g. in tests, are left as they are.
+func (e *exportWriter) extendDeadline() error {
+	if e.err != nil {
+		return e.err
+	}
+	err := e.controller.SetWriteDeadline(time.Now().Add(exportBatchWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		e.err = err
+	}
+	return e.err
+}
+
+// raw writes literal JSON syntax
+func (e *exportWriter) raw(s string) {
+	if e.err == nil {
+		_, e.err = io.WriteString(e.w, s)
+	}
+}
+
+// value writes v encoded as JSON
+func (e *exportWriter) value(v interface{}) {
+	if e.err != nil {
+		return
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		e.err = err
+		return
+	}
+	_, e.err = e.w.Write(encoded)
+}
+
+// item writes v as an element of the array being written, after a comma
+// unless it is the first
+func (e *exportWriter) item(first *bool, v interface{}) {
+	if !*first {
+		e.raw(",")
+	}
+	*first = false
+	e.value(v)
+}
+
+// flush sends what was written so far to the client, returning the first
+// error met, e.g. because the client went away
+func (e *exportWriter) flush() error {
+	if e.err == nil {
+		if err := e.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			e.err = err
+		}
+	}
+	return e.err
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// slowUserDataRepository serves full batches of articles, taking delay for each
+type slowUserDataRepository struct {
+	batches int
+	delay   time.Duration
+}
+
+func (r *slowUserDataRepository) ArticlesAfter(userID, afterID int64, limit int) ([]entities.UserDataArticle, error) {
+	time.Sleep(r.delay)
+	if afterID >= int64(r.batches*limit) {
+		return []entities.UserDataArticle{}, nil
+	}
+
+	articles := make([]entities.UserDataArticle, limit)
+	for i := range articles {
+		id := afterID + int64(i) + 1
+		articles[i] = entities.UserDataArticle{ID: id, Slug: fmt.Sprintf("article-%d", id), TagList: []string{}}
+	}
+	return articles, nil
+}
+
+func (r *slowUserDataRepository) CommentsAfter(userID, afterID int64, limit int) ([]entities.UserDataComment, error) {
+	return []entities.UserDataComment{}, nil
+}
+
+func (r *slowUserDataRepository) FavoritesAfter(userID, afterArticleID int64, limit int) ([]entities.UserDataFavorite, error) {
+	return []entities.UserDataFavorite{}, nil
+}
+
+func TestUserDataHandlers_ExportOutlastsWriteTimeout(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	userRepo := repositories.NewUserRepository(db)
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "exporter",
+		Email:    "exporter@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	handlers := NewUserDataHandlers(userRepo, repositories.NewSettingsRepository(db), &slowUserDataRepository{batches: 3, delay: 100 * time.Millisecond})
+	handler := middleware.LoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middleware.UserIDContextKey, user.ID)
+		handlers.ExportUserData(w, r.WithContext(ctx))
+	}))
+
+	// The export takes about 400ms, twice the server's write timeout
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.WriteTimeout = 200 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/user/export")
+	if err != nil {
+		t.Fatalf("Failed to request export: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Export was cut short after %d bytes: %v", len(body), err)
+	}
+
+	var export struct {
+		Export struct {
+			Profile  entities.UserDataProfile   `json:"profile"`
+			Articles []entities.UserDataArticle `json:"articles"`
+		} `json:"export"`
+	}
+	if err := json.Unmarshal(body, &export); err != nil {
+		t.Fatalf("Export is not valid JSON: %v", err)
+	}
+	if export.Export.Profile.Username != "exporter" || len(export.Export.Articles) != 3*exportBatchSize {
+		t.Errorf("Expected %d articles of exporter, got %d of %q", 3*exportBatchSize, len(export.Export.Articles), export.Export.Profile.Username)
+	}
+}
+
+func TestUserDataHandlers_ExportRefusesAPIKeys(t *testing.T) {
+	db := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	userRepo := repositories.NewUserRepository(db)
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "scripter",
+		Email:    "scripter@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	handlers := NewUserDataHandlers(userRepo, repositories.NewSettingsRepository(db), repositories.NewUserDataRepository(db))
+	req := httptest.NewRequest(http.MethodGet, "/api/user/export", nil)
+	ctx := context.WithValue(req.Context(), middleware.UserIDContextKey, user.ID)
+	ctx = context.WithValue(ctx, middleware.APIKeyContextKey, int64(1))
+	rr := httptest.NewRecorder()
+
+	handlers.ExportUserData(rr, req.WithContext(ctx))
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected an export requested with an API key to be forbidden, got %d", rr.Code)
+	}
+	if rr.Header().Get("Content-Disposition") != "" {
+		t.Error("Expected no export to be started")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -17,79 +18,268 @@ const (
 	UserIDContextKey ContextKey = "user_id"
 	// UsernameContextKey is the key for username in context
 	UsernameContextKey ContextKey = "username"
+	// ScopesContextKey is the key for the token's scopes in context; it is
+	// absent for tokens without a scope claim
+	ScopesContextKey ContextKey = "scopes"
+	// APIKeyContextKey is the key for the ID of the API key a request was
+	// authenticated with in context; it is absent for tokens
+	APIKeyContextKey ContextKey = "api_key_id"
+	// SessionIDContextKey is the key for the ID of the login session the
+	// request's token was issued for in context; it is absent for tokens
+	// without a sid claim and for API keys
+	SessionIDContextKey ContextKey = "session_id"
 )
 
+// RevocationLookup reports whether the token with the given jti was revoked
+type RevocationLookup func(tokenID string) (bool, error)
+
+// SessionLookup reports whether the login session with the given ID is
+// still active, i.e. neither revoked nor expired
+type SessionLookup func(sessionID string) (bool, error)
+
+// APIKeyIdentity is who an API key authenticates and what it may do
+type APIKeyIdentity struct {
+	KeyID    int64
+	UserID   int64
+	Username string
+	Scopes   []string
+}
+
+// APIKeyLookup returns the identity of an active API key, or nil if the key
+// is unknown, revoked or expired
+type APIKeyLookup func(key string) (*APIKeyIdentity, error)
+
+// TokenOptions configures how access tokens are validated. Tokens must carry
+// the registered exp, iat and sub claims, plus iss and aud matching Issuer and
+// Audience when those are set. Leeway tolerates clock skew on nbf and exp.
+// Tokens whose jti Revoked reports are rejected; tokens without a jti predate
+// revocation and cannot be revoked. Tokens naming a session (sid) are also
+// rejected once Sessions reports it inactive. Tokens are HS256-signed with Secret unless
+// Keys is set, in which case it finds the key for each token and Algorithms
+// lists the accepted signing algorithms. When APIKeys is set, an "ApiKey"
+// authorization header is accepted in place of a token.
+type TokenOptions struct {
+	Secret     string
+	Keys       jwt.Keyfunc
+	Algorithms []string
+	Issuer     string
+	Audience   string
+	Leeway     time.Duration
+	Revoked    RevocationLookup
+	Sessions   SessionLookup
+	APIKeys    APIKeyLookup
+}
+
 // AuthMiddleware validates JWT tokens and adds user info to context
-func AuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
+func AuthMiddleware(options TokenOptions) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get the Authorization header
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				writeUnauthorizedError(w, "Missing authorization header")
+			ctx, message := authenticate(r, options)
+			if message != "" {
+				writeUnauthorizedError(w, message)
 				return
 			}
 
-			// Check if it starts with "Token "
-			if !strings.HasPrefix(authHeader, "Token ") {
-				writeUnauthorizedError(w, "Invalid authorization header format")
-				return
-			}
+			// Call next handler with updated context
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
 
-			// Extract the token
-			tokenString := strings.TrimPrefix(authHeader, "Token ")
-			if tokenString == "" {
-				writeUnauthorizedError(w, "Missing token")
+// OptionalAuthMiddleware adds user info to context when a token is sent.
+// Requests without an Authorization header pass through anonymously; an
+// invalid token is still rejected.
+func OptionalAuthMiddleware(options TokenOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Parse and validate the token
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				// Validate the signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, jwt.ErrSignatureInvalid
-				}
-				return []byte(jwtSecret), nil
-			})
-
-			if err != nil {
-				writeUnauthorizedError(w, "Invalid token")
+			ctx, message := authenticate(r, options)
+			if message != "" {
+				writeUnauthorizedError(w, message)
 				return
 			}
 
-			if !token.Valid {
-				writeUnauthorizedError(w, "Token is not valid")
-				return
-			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
 
-			// Extract claims
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				writeUnauthorizedError(w, "Invalid token claims")
-				return
-			}
+// authenticate validates the request's token and returns a context carrying
+// the user info, or a message describing why authentication failed
+func authenticate(r *http.Request, options TokenOptions) (context.Context, string) {
+	// Get the Authorization header
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return nil, "Missing authorization header"
+	}
 
-			// Get user info from claims
-			userID, ok := claims["user_id"]
-			if !ok {
-				writeUnauthorizedError(w, "Missing user_id in token")
-				return
-			}
+	if strings.HasPrefix(authHeader, "ApiKey ") && options.APIKeys != nil {
+		return authenticateAPIKey(r, strings.TrimPrefix(authHeader, "ApiKey "), options.APIKeys)
+	}
 
-			username, ok := claims["username"]
-			if !ok {
-				writeUnauthorizedError(w, "Missing username in token")
-				return
+	// Check if it starts with "Token "
+	if !strings.HasPrefix(authHeader, "Token ") {
+		return nil, "Invalid authorization header format"
+	}
+
+	// Extract the token
+	tokenString := strings.TrimPrefix(authHeader, "Token ")
+	if tokenString == "" {
+		return nil, "Missing token"
+	}
+
+	// Parse and validate the token
+	keys := options.Keys
+	if keys == nil {
+		keys = func(token *jwt.Token) (interface{}, error) {
+			// Validate the signing method
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, jwt.ErrSignatureInvalid
 			}
+			return []byte(options.Secret), nil
+		}
+	}
+	token, err := jwt.Parse(tokenString, keys, options.parserOptions()...)
 
-			// Add user info to context
-			ctx := context.WithValue(r.Context(), UserIDContextKey, userID)
-			ctx = context.WithValue(ctx, UsernameContextKey, username)
+	if err != nil {
+		return nil, "Invalid token"
+	}
 
-			// Call next handler with updated context
-			next.ServeHTTP(w, r.WithContext(ctx))
-		})
+	if !token.Valid {
+		return nil, "Token is not valid"
 	}
+
+	// Extract claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, "Invalid token claims"
+	}
+
+	if message := requireRegisteredClaims(claims); message != "" {
+		return nil, message
+	}
+
+	if tokenID, ok := claims["jti"].(string); ok && options.Revoked != nil {
+		revoked, err := options.Revoked(tokenID)
+		if err != nil {
+			return nil, "Failed to verify token"
+		}
+		if revoked {
+			return nil, "Token has been revoked"
+		}
+	}
+
+	sessionID, _ := claims["sid"].(string)
+	if sessionID != "" && options.Sessions != nil {
+		active, err := options.Sessions(sessionID)
+		if err != nil {
+			return nil, "Failed to verify token"
+		}
+		if !active {
+			return nil, "Session has been revoked"
+		}
+	}
+
+	// Get user info from claims
+	userID, ok := claims["user_id"]
+	if !ok {
+		return nil, "Missing user_id in token"
+	}
+
+	username, ok := claims["username"]
+	if !ok {
+		return nil, "Missing username in token"
+	}
+
+	// Add user info to context
+	ctx := context.WithValue(r.Context(), UserIDContextKey, userID)
+	ctx = context.WithValue(ctx, UsernameContextKey, username)
+	if sessionID != "" {
+		ctx = context.WithValue(ctx, SessionIDContextKey, sessionID)
+	}
+
+	if scope, ok := claims["scope"]; ok {
+		scopes, ok := scope.(string)
+		if !ok {
+			return nil, "Invalid scope in token"
+		}
+		ctx = context.WithValue(ctx, ScopesContextKey, strings.Fields(scopes))
+	}
+
+	return ctx, ""
+}
+
+// authenticateAPIKey looks up an API key and returns a context carrying its
+// user and scopes, or a message describing why authentication failed
+func authenticateAPIKey(r *http.Request, key string, lookup APIKeyLookup) (context.Context, string) {
+	if key == "" {
+		return nil, "Missing API key"
+	}
+
+	identity, err := lookup(key)
+	if err != nil {
+		return nil, "Failed to verify API key"
+	}
+	if identity == nil {
+		return nil, "Invalid API key"
+	}
+
+	ctx := context.WithValue(r.Context(), UserIDContextKey, identity.UserID)
+	ctx = context.WithValue(ctx, UsernameContextKey, identity.Username)
+	ctx = context.WithValue(ctx, ScopesContextKey, identity.Scopes)
+	ctx = context.WithValue(ctx, APIKeyContextKey, identity.KeyID)
+	return ctx, ""
+}
+
+// APIKeyIDFromContext returns the ID of the API key the request was
+// authenticated with, if it was not authenticated with a token
+func APIKeyIDFromContext(r *http.Request) (int64, bool) {
+	keyID, ok := r.Context().Value(APIKeyContextKey).(int64)
+	return keyID, ok
+}
+
+// SessionIDFromContext returns the ID of the login session the request's
+// token was issued for, or "" if it names none
+func SessionIDFromContext(r *http.Request) string {
+	sessionID, _ := r.Context().Value(SessionIDContextKey).(string)
+	return sessionID
+}
+
+// parserOptions returns the checks applied on top of the signature and expiry
+func (o TokenOptions) parserOptions() []jwt.ParserOption {
+	algorithms := o.Algorithms
+	if o.Keys == nil {
+		algorithms = []string{jwt.SigningMethodHS256.Alg()}
+	}
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(o.Leeway),
+	}
+	if o.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(o.Issuer))
+	}
+	if o.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(o.Audience))
+	}
+	return parserOptions
+}
+
+// requireRegisteredClaims returns a message naming the first registered claim
+// the token is missing, or an empty string if it has them all. exp, iss and
+// aud are already enforced by the parser.
+func requireRegisteredClaims(claims jwt.MapClaims) string {
+	for _, claim := range []string{"iat", "sub"} {
+		if _, ok := claims[claim]; !ok {
+			return "Missing " + claim + " in token"
+		}
+	}
+	return ""
 }
 
 // writeUnauthorizedError writes a 401 Unauthorized response
@@ -97,9 +287,7 @@ func writeUnauthorizedError(w http.ResponseWriter, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusUnauthorized)
 
-	response := ErrorResponse{
-		Error: message,
-	}
+	response := newErrorResponse(w, message)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		// If JSON encoding fails, fall back to plain text
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	lookup := func(key string) (*APIKeyIdentity, error) {
+		if key != "read-only-key" {
+			return nil, nil
+		}
+		return &APIKeyIdentity{KeyID: 7, UserID: 42, Username: "scripter", Scopes: []string{"read"}}, nil
+	}
+
+	var gotUserID, gotKeyID int64
+	final := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, _ = UserIDFromContext(r)
+		gotKeyID, _ = APIKeyIDFromContext(r)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		apiKeys        APIKeyLookup
+		scope          string
+		authorization  string
+		expectedStatus int
+	}{
+		{"Read-only key on a read route", lookup, "read", "ApiKey read-only-key", http.StatusOK},
+		{"Read-only key on a write route", lookup, "write", "ApiKey read-only-key", http.StatusForbidden},
+		{"Unknown key", lookup, "read", "ApiKey other-key", http.StatusUnauthorized},
+		{"Empty key", lookup, "read", "ApiKey ", http.StatusUnauthorized},
+		{"Keys not accepted", nil, "read", "ApiKey read-only-key", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUserID, gotKeyID = 0, 0
+			handler := AuthMiddleware(TokenOptions{Secret: "test-secret-key", APIKeys: tt.apiKeys})(RequireScope(tt.scope)(final))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user", nil)
+			req.Header.Set("Authorization", tt.authorization)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus == http.StatusOK && (gotUserID != 42 || gotKeyID != 7) {
+				t.Errorf("Expected user 42 through key 7, got user %d through key %d", gotUserID, gotKeyID)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RequireContentType rejects request bodies whose media type is not one of the
+// given types (415), or whose charset is not UTF-8. Requests without a body pass.
+func RequireContentType(types ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || !containsMediaType(types, mediaType) {
+				writeContentError(w, http.StatusUnsupportedMediaType, "Content-Type must be "+strings.Join(types, " or "))
+				return
+			}
+
+			if charset, ok := params["charset"]; ok && !strings.EqualFold(charset, "utf-8") {
+				writeContentError(w, http.StatusUnsupportedMediaType, "Only the utf-8 charset is supported")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAccept rejects requests whose Accept header excludes every type the
+// route can produce (406). A missing Accept header accepts anything.
+func RequireAccept(types ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept := r.Header.Get("Accept")
+			if accept == "" || acceptsAny(accept, types) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			writeContentError(w, http.StatusNotAcceptable, "Response can only be served as "+strings.Join(types, " or "))
+		})
+	}
+}
+
+// acceptsAny reports whether an Accept header allows at least one of the given types
+func acceptsAny(accept string, types []string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight <= 0 {
+				continue
+			}
+		}
+
+		for _, t := range types {
+			if mediaTypeMatches(mediaType, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mediaTypeMatches reports whether an Accept range such as */* or text/* covers the type
+func mediaTypeMatches(pattern, mediaType string) bool {
+	if pattern == "*/*" || pattern == mediaType {
+		return true
+	}
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*"))
+	}
+	return false
+}
+
+// containsMediaType reports whether mediaType is one of types
+func containsMediaType(types []string, mediaType string) bool {
+	for _, t := range types {
+		if t == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// writeContentError writes a JSON error for content negotiation failures
+func writeContentError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(newErrorResponse(w, message))
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import "net/http"
+
+// DemoModeHeader is set on every response from a server running in demo mode,
+// so clients can show a banner saying that changes are not kept
+const DemoModeHeader = "X-Demo-Mode"
+
+// DemoBanner flags every response as coming from a demo server
+func DemoBanner(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(DemoModeHeader, "true")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import "net/http"
+
+// CloseWhileDraining asks clients to close their connection after the response
+// once draining has started, so their next request goes to another instance
+func CloseWhileDraining(draining func() bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if draining() {
+				w.Header().Set("Connection", "close")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import "net/http"
+
+// IncidentNoticeHeader is set on responses while an incident or maintenance
+// is ongoing, to the highest incident severity or "maintenance", so clients
+// can show a banner linking to the status page
+const IncidentNoticeHeader = "X-Incident-Notice"
+
+// IncidentNotice flags responses with the current notice; an empty notice
+// leaves the header out
+func IncidentNotice(notice func() string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if current := notice(); current != "" {
+				w.Header().Set(IncidentNoticeHeader, current)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
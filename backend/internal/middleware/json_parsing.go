@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+)
+
+// JSON request parsing modes
+const (
+	// JSONStrict rejects request bodies containing unknown fields
+	JSONStrict = "strict"
+	// JSONLenient ignores unknown fields and reports them as warnings in the response
+	JSONLenient = "lenient"
+)
+
+// jsonParsingContextKey holds the parsing state for the current request
+const jsonParsingContextKey ContextKey = "json_parsing"
+
+// ParseWarning describes a request field that was ignored in lenient mode
+type ParseWarning struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// jsonParsingState carries the mode and collected warnings for a request
+type jsonParsingState struct {
+	mode     string
+	warnings []ParseWarning
+}
+
+// JSONParsing sets how request bodies are parsed for a route. In lenient mode,
+// warnings recorded while parsing are added to a top-level "warnings" field
+// of JSON object responses.
+func JSONParsing(mode string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := &jsonParsingState{mode: mode}
+			r = r.WithContext(context.WithValue(r.Context(), jsonParsingContextKey, state))
+
+			if mode != JSONLenient {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buffered := &bufferedResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(buffered, r)
+
+			body := buffered.body.Bytes()
+			if len(state.warnings) > 0 {
+				body = withWarnings(w.Header(), body, state.warnings)
+				w.Header().Del("Content-Length")
+			}
+
+			w.WriteHeader(buffered.statusCode)
+			w.Write(body)
+		})
+	}
+}
+
+// JSONParsingModeFromContext returns the parsing mode for the request; strict by default
+func JSONParsingModeFromContext(ctx context.Context) string {
+	if state, ok := ctx.Value(jsonParsingContextKey).(*jsonParsingState); ok && state.mode != "" {
+		return state.mode
+	}
+	return JSONStrict
+}
+
+// AddParseWarnings records warnings to be returned with the response
+func AddParseWarnings(ctx context.Context, warnings ...ParseWarning) {
+	if state, ok := ctx.Value(jsonParsingContextKey).(*jsonParsingState); ok {
+		state.warnings = append(state.warnings, warnings...)
+	}
+}
+
+// withWarnings adds a "warnings" field to a JSON object body; other bodies are returned unchanged
+func withWarnings(header http.Header, body []byte, warnings []ParseWarning) []byte {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		return body
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return body
+	}
+
+	encodedWarnings, err := json.Marshal(warnings)
+	if err != nil {
+		return body
+	}
+	object["warnings"] = encodedWarnings
+
+	var out bytes.Buffer
+	if err := json.NewEncoder(&out).Encode(object); err != nil {
+		return body
+	}
+	return out.Bytes()
+}
+
+// bufferedResponseWriter holds the response so it can be amended before sending
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+// WriteHeader records the status code
+func (w *bufferedResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+// Write buffers the body
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
@@ -17,17 +17,27 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 			statusCode:     http.StatusOK,
 		}
 
+		// Let the router report which named route handled the request
+		route, r := withRouteSlot(r)
+
 		// Call the next handler
 		next.ServeHTTP(wrapper, r)
 
+		routeName := route.Name
+		if routeName == "" {
+			routeName = "unmatched"
+		}
+
 		// Log the request
 		duration := time.Since(start)
-		log.Printf("📊 %s %s - %d - %v - %s",
+		log.Printf("📊 %s %s [%s] - %d - %v - %s - %s",
 			r.Method,
 			r.URL.Path,
+			routeName,
 			wrapper.statusCode,
 			duration,
 			r.RemoteAddr,
+			RequestIDFromContext(r.Context()),
 		)
 	})
 }
@@ -42,4 +52,10 @@ type responseWriterWrapper struct {
 func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.statusCode = statusCode
 	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Unwrap returns the wrapped writer, so http.ResponseController can flush
+// streamed responses and move their write deadline
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package middleware
+
+import "net/http"
+
+// ObserveResponses reports the route name and status code of every response,
+// e.g. to feed in-process alerting. Mounted outside RecoveryMiddleware it also
+// sees recovered panics as 500s; unmatched requests have an empty route name.
+func ObserveResponses(observe func(route string, status int)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrapper := &responseWriterWrapper{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			route, r := withRouteSlot(r)
+			next.ServeHTTP(wrapper, r)
+
+			observe(route.Name, wrapper.statusCode)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket: up to Burst requests at once,
+// refilled at PerMinute requests per minute
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
+// maxIdleBuckets bounds memory use; full buckets are dropped past this size
+const maxIdleBuckets = 10000
+
+// tokenBucket tracks one client's remaining requests
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter decides whether a client may make another request. The
+// built-in limiter keeps its buckets in process memory, so every replica
+// limits on its own; a shared store can implement it to hold one limit
+// across replicas.
+type RateLimiter interface {
+	// Allow takes a request for key, or reports how long until one is available
+	Allow(key string) (bool, time.Duration)
+}
+
+// memoryRateLimiter implements RateLimiter with a token bucket per key
+type memoryRateLimiter struct {
+	limit   RateLimit
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter; all routes sharing it share each client's bucket
+func NewRateLimiter(limit RateLimit) RateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &memoryRateLimiter{
+		limit:   limit,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token for key, or reports how long until one is available
+func (l *memoryRateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	rate := float64(l.limit.PerMinute) / time.Minute.Seconds()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.pruneFull(now, rate)
+		}
+		bucket = &tokenBucket{tokens: float64(l.limit.Burst), updated: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(l.limit.Burst), bucket.tokens+now.Sub(bucket.updated).Seconds()*rate)
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	if rate <= 0 {
+		return false, time.Minute
+	}
+	return false, time.Duration((1 - bucket.tokens) / rate * float64(time.Second))
+}
+
+// pruneFull drops buckets that have refilled completely, since they behave like new ones
+func (l *memoryRateLimiter) pruneFull(now time.Time, rate float64) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*rate >= float64(l.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimitByClient rejects requests over the limiter's rate with 429 and a
+// Retry-After header. Clients are identified by their connection's IP.
+func RateLimitByClient(limiter RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter := limiter.Allow(ClientIP(r))
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(newErrorResponse(w, "Too many requests, please slow down"))
+		})
+	}
+}
+
+// ClientIP returns the IP of the connection the request arrived on
+func ClientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
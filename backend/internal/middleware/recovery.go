@@ -10,6 +10,10 @@ import (
 // ErrorResponse represents a standard error response
 type ErrorResponse struct {
 	Error string `json:"error"`
+
+	// Correlation IDs of the failed request, for support and log lookups
+	RequestID string `json:"requestId,omitempty"`
+	TraceID   string `json:"traceId,omitempty"`
 }
 
 // RecoveryMiddleware recovers from panics and returns a 500 error
@@ -24,9 +28,7 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusInternalServerError)
 
-				response := ErrorResponse{
-					Error: "Internal server error",
-				}
+				response := newErrorResponse(w, "Internal server error")
 
 				if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
 					// If JSON encoding fails, fall back to plain text
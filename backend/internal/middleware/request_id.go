@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Correlation headers. Every response carries a request ID; a trace ID is
+// added when a trusted client sent W3C trace context.
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceIDHeader     = "X-Trace-ID"
+	TraceParentHeader = "traceparent"
+)
+
+const (
+	// RequestIDContextKey is the key for the request ID in context
+	RequestIDContextKey ContextKey = "request_id"
+	// TraceIDContextKey is the key for the trace ID in context, when there is one
+	TraceIDContextKey ContextKey = "trace_id"
+)
+
+// inboundRequestIDPattern bounds request IDs accepted from clients, keeping
+// them safe to log and echo
+var inboundRequestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// traceParentPattern matches a version 00 traceparent header
+var traceParentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+// Correlate gives every request a request ID, returned in the X-Request-ID
+// header and stored in the context. Clients on trusted networks (such as a
+// gateway that already assigned an ID) may supply their own X-Request-ID and a
+// traceparent, whose trace ID is returned in X-Trace-ID; other clients' are
+// replaced.
+func Correlate(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID, traceID := "", ""
+			if isTrusted(trusted, ClientIP(r)) {
+				if inbound := r.Header.Get(RequestIDHeader); inboundRequestIDPattern.MatchString(inbound) {
+					requestID = inbound
+				}
+				if match := traceParentPattern.FindStringSubmatch(r.Header.Get(TraceParentHeader)); match != nil && strings.Trim(match[1], "0") != "" {
+					traceID = match[1]
+				}
+			}
+			if requestID == "" {
+				requestID = newRequestID()
+			}
+
+			ctx := context.WithValue(r.Context(), RequestIDContextKey, requestID)
+			w.Header().Set(RequestIDHeader, requestID)
+			if traceID != "" {
+				ctx = context.WithValue(ctx, TraceIDContextKey, traceID)
+				w.Header().Set(TraceIDHeader, traceID)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RestoreCorrelationHeaders sets the correlation headers again from the
+// context. http.TimeoutHandler gives the handlers it wraps a fresh header map,
+// so it must run inside the timeout for handlers to see the IDs there.
+func RestoreCorrelationHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestID, ok := r.Context().Value(RequestIDContextKey).(string); ok {
+			w.Header().Set(RequestIDHeader, requestID)
+		}
+		if traceID, ok := r.Context().Value(TraceIDContextKey).(string); ok {
+			w.Header().Set(TraceIDHeader, traceID)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestIDFromContext returns the request ID assigned by Correlate
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+// newErrorResponse builds an error payload carrying the response's correlation IDs
+func newErrorResponse(w http.ResponseWriter, message string) ErrorResponse {
+	return ErrorResponse{
+		Error:     message,
+		RequestID: w.Header().Get(RequestIDHeader),
+		TraceID:   w.Header().Get(TraceIDHeader),
+	}
+}
+
+// isTrusted reports whether ip is inside one of the trusted networks
+func isTrusted(trusted []*net.IPNet, ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRequestID returns a random 128-bit hex ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
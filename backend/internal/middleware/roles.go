@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// RoleLookup resolves the current role of a user by ID
+type RoleLookup func(userID int64) (string, error)
+
+// RequireRole allows the request only if the authenticated user has one of the given roles.
+// It must run after AuthMiddleware so the user ID is present in the context.
+func RequireRole(lookup RoleLookup, roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := UserIDFromContext(r)
+			if err != nil {
+				writeUnauthorizedError(w, "Unauthorized")
+				return
+			}
+
+			// Roles are looked up on every request so demotions take effect immediately
+			role, err := lookup(userID)
+			if err != nil {
+				writeForbiddenError(w, "Insufficient permissions")
+				return
+			}
+
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			writeForbiddenError(w, "Insufficient permissions")
+		})
+	}
+}
+
+// AccessLookup returns why a user's account may not be used (for example because
+// it is deactivated or banned), or an empty string if it may
+type AccessLookup func(userID int64) (string, error)
+
+// RequireActiveUser rejects requests from deactivated and banned accounts, whose
+// tokens stay valid until they expire. It must run after AuthMiddleware.
+func RequireActiveUser(lookup AccessLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := UserIDFromContext(r)
+			if err != nil {
+				writeUnauthorizedError(w, "Unauthorized")
+				return
+			}
+
+			denial, err := lookup(userID)
+			if err != nil {
+				writeForbiddenError(w, "Account is not active")
+				return
+			}
+			if denial != "" {
+				writeForbiddenError(w, denial)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope rejects requests whose token was issued for other scopes. Tokens
+// without a scope claim carry every scope, as do anonymous requests, which
+// RequireActiveUser or the handler deal with. It must run after AuthMiddleware
+// or OptionalAuthMiddleware.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, ok := r.Context().Value(ScopesContextKey).([]string)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, granted := range scopes {
+				if granted == scope {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			writeForbiddenError(w, "Token scope does not allow this request")
+		})
+	}
+}
+
+// UserIDFromContext extracts the authenticated user ID set by AuthMiddleware
+func UserIDFromContext(r *http.Request) (int64, error) {
+	userID := r.Context().Value(UserIDContextKey)
+	if userID == nil {
+		return 0, fmt.Errorf("user ID not found in context")
+	}
+
+	switch v := userID.(type) {
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid user ID format: %w", err)
+		}
+		return id, nil
+	default:
+		return 0, fmt.Errorf("invalid user ID type: %T", userID)
+	}
+}
+
+// writeForbiddenError writes a 403 Forbidden response
+func writeForbiddenError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+
+	response := newErrorResponse(w, message)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		// If JSON encoding fails, fall back to plain text
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("Forbidden"))
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// RouteInfoContextKey is the key for the matched route's metadata in context
+const RouteInfoContextKey ContextKey = "route_info"
+
+// routeLabelContextKey holds a slot that outer middleware (e.g. logging) can read
+// after the router has matched a route
+const routeLabelContextKey ContextKey = "route_label"
+
+// RouteInfo is the per-route metadata exposed to middleware and handlers
+type RouteInfo struct {
+	Name           string
+	RateLimitClass string
+}
+
+// WithRouteInfo attaches route metadata to the request context
+func WithRouteInfo(info RouteInfo) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slot, ok := r.Context().Value(routeLabelContextKey).(*RouteInfo); ok {
+				*slot = info
+			}
+
+			ctx := context.WithValue(r.Context(), RouteInfoContextKey, info)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// withRouteSlot returns the slot an outer middleware attached for the matched
+// route, attaching one if there is none, so every wrapper sees the same route
+func withRouteSlot(r *http.Request) (*RouteInfo, *http.Request) {
+	if slot, ok := r.Context().Value(routeLabelContextKey).(*RouteInfo); ok {
+		return slot, r
+	}
+	slot := &RouteInfo{}
+	return slot, r.WithContext(context.WithValue(r.Context(), routeLabelContextKey, slot))
+}
+
+// RouteInfoFromContext returns the metadata of the matched route, if any
+func RouteInfoFromContext(ctx context.Context) (RouteInfo, bool) {
+	info, ok := ctx.Value(RouteInfoContextKey).(RouteInfo)
+	return info, ok
+}
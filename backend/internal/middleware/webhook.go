@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// WebhookSecretHeader is the header providers use to present the shared webhook secret
+const WebhookSecretHeader = "X-Webhook-Secret"
+
+// RequireWebhookSecret authenticates provider webhooks with a shared secret, accepted
+// either in the X-Webhook-Secret header or the "secret" query parameter for providers
+// that can only be configured with a URL
+func RequireWebhookSecret(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get(WebhookSecretHeader)
+			if provided == "" {
+				provided = r.URL.Query().Get("secret")
+			}
+
+			if secret == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+				writeUnauthorizedError(w, "Invalid webhook secret")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
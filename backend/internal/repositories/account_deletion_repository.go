@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// AccountDeletionRepository defines the interface for permanent account deletion
+type AccountDeletionRepository interface {
+	Delete(user *entities.User, mode string, at time.Time) (*entities.DeletionCertificate, error)
+	GetCertificate(id string) (*entities.DeletionCertificate, error)
+}
+
+// accountDeletionRepository implements AccountDeletionRepository using direct SQL
+type accountDeletionRepository struct {
+	db *database.DB
+}
+
+// NewAccountDeletionRepository creates a new account deletion repository
+func NewAccountDeletionRepository(db *database.DB) AccountDeletionRepository {
+	return &accountDeletionRepository{
+		db: db,
+	}
+}
+
+// Delete removes the user's row, which cascades to their follows, favorites,
+// claps, notifications, settings, reading lists, push subscriptions and API
+// keys. In anonymize mode their articles, comments and series first move to
+// the ghost user; in delete mode they go with the account. Favorite and clap
+// counts of the articles the user reacted to are recounted, as are comment
+// counts of the articles they commented on. A certificate is issued in the
+// same transaction.
+func (r *accountDeletionRepository) Delete(user *entities.User, mode string, at time.Time) (*entities.DeletionCertificate, error) {
+	id, err := newCertificateID()
+	if err != nil {
+		return nil, err
+	}
+
+	subject := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", user.ID, user.Username, user.CreatedAt.UTC().Format(time.RFC3339))))
+	certificate := &entities.DeletionCertificate{
+		ID:          id,
+		Mode:        mode,
+		SubjectHash: hex.EncodeToString(subject[:]),
+		IssuedAt:    at.UTC(),
+	}
+
+	err = r.db.Transaction(func(tx *sql.Tx) error {
+		if err := tx.QueryRow(`
+			SELECT (SELECT COUNT(*) FROM articles WHERE author_id = ?),
+			       (SELECT COUNT(*) FROM comments WHERE author_id = ?)
+		`, user.ID, user.ID).Scan(&certificate.Articles, &certificate.Comments); err != nil {
+			return fmt.Errorf("failed to count user content: %w", err)
+		}
+
+		if mode == entities.DeletionModeAnonymize {
+			ghostID, err := ensureGhostUser(tx, at)
+			if err != nil {
+				return err
+			}
+			// Pins are a personal choice of the author, so they are dropped
+			for _, query := range []string{
+				"UPDATE articles SET author_id = ?, pinned_at = NULL, pin_position = 0 WHERE author_id = ?",
+				"UPDATE comments SET author_id = ? WHERE author_id = ?",
+				"UPDATE series SET author_id = ? WHERE author_id = ?",
+			} {
+				if _, err := tx.Exec(query, ghostID, user.ID); err != nil {
+					return fmt.Errorf("failed to reassign content: %w", err)
+				}
+			}
+		}
+
+		// Remember what the user reacted to before the cascade removes it
+		reacted, err := reactedArticleIDs(tx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.Exec("DELETE FROM users WHERE id = ?", user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			return fmt.Errorf("user not found")
+		}
+
+		for _, articleID := range reacted {
+			if _, err := tx.Exec(`
+				UPDATE articles SET
+					favorites_count = (SELECT COUNT(*) FROM favorites WHERE article_id = ?),
+					claps_count = (SELECT COALESCE(SUM(count), 0) FROM article_claps WHERE article_id = ?),
+					comments_count = (SELECT COUNT(*) FROM comments WHERE article_id = ? AND deleted_at IS NULL),
+					last_comment_at = (SELECT MAX(created_at) FROM comments WHERE article_id = ? AND deleted_at IS NULL)
+				WHERE id = ?
+			`, articleID, articleID, articleID, articleID, articleID); err != nil {
+				return fmt.Errorf("failed to recount reactions: %w", err)
+			}
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO deletion_certificates (id, mode, subject_hash, articles, comments, issued_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, certificate.ID, certificate.Mode, certificate.SubjectHash, certificate.Articles, certificate.Comments, certificate.IssuedAt); err != nil {
+			return fmt.Errorf("failed to record deletion certificate: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+// GetCertificate retrieves a deletion certificate by ID
+func (r *accountDeletionRepository) GetCertificate(id string) (*entities.DeletionCertificate, error) {
+	certificate := &entities.DeletionCertificate{}
+	err := r.db.QueryRow(`
+		SELECT id, mode, subject_hash, articles, comments, issued_at
+		FROM deletion_certificates
+		WHERE id = ?
+	`, id).Scan(&certificate.ID, &certificate.Mode, &certificate.SubjectHash, &certificate.Articles, &certificate.Comments, &certificate.IssuedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("certificate not found")
+		}
+		return nil, fmt.Errorf("failed to get deletion certificate: %w", err)
+	}
+	return certificate, nil
+}
+
+// reactedArticleIDs returns the articles the user favorited, clapped or
+// commented on
+func reactedArticleIDs(tx *sql.Tx, userID int64) ([]int64, error) {
+	rows, err := tx.Query(`
+		SELECT article_id FROM favorites WHERE user_id = ?
+		UNION
+		SELECT article_id FROM article_claps WHERE user_id = ?
+		UNION
+		SELECT article_id FROM comments WHERE author_id = ?
+	`, userID, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reacted articles: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan reacted article: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ensureGhostUser returns the ghost user's ID, creating the account on first
+// use. It has no usable password, so nobody can log in as it.
+func ensureGhostUser(tx *sql.Tx, at time.Time) (int64, error) {
+	if _, err := tx.Exec(`
+		INSERT INTO users (username, email, password_hash, bio, image_url, created_at, updated_at)
+		VALUES (?, ?, '!', 'This account holds content from deleted accounts.', '', ?, ?)
+		ON CONFLICT (username) DO NOTHING
+	`, entities.GhostUsername, entities.GhostUsername+"@users.invalid", at, at); err != nil {
+		return 0, fmt.Errorf("failed to create ghost user: %w", err)
+	}
+
+	var id int64
+	if err := tx.QueryRow("SELECT id FROM users WHERE username = ?", entities.GhostUsername).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get ghost user: %w", err)
+	}
+	return id, nil
+}
+
+// newCertificateID returns a random, hard to guess certificate ID
+func newCertificateID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate certificate ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestAccountDeletionRepository_Modes(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+	favoriteRepo := NewFavoriteRepository(db)
+	deletionRepo := NewAccountDeletionRepository(db)
+
+	users := createTestUsers(t, userRepo, "reader", "anna", "dave")
+	articles := make(map[string]*entities.Article)
+	for _, name := range []string{"reader", "anna", "dave"} {
+		article, err := articleRepo.Create(users[name].ID, &entities.ArticleCreate{
+			Title:       "Article by " + name,
+			Description: "Test description",
+			Body:        "Test body",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		articles[name] = article
+	}
+
+	// anna and dave comment on and favorite the reader's article
+	for _, name := range []string{"anna", "dave"} {
+		if _, err := commentRepo.Create(users[name].ID, articles["reader"].ID, &entities.CommentCreate{Body: "Nice"}); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		if _, err := favoriteRepo.Favorite(users[name].ID, articles["reader"].ID); err != nil {
+			t.Fatalf("Failed to favorite: %v", err)
+		}
+	}
+
+	certificate, err := deletionRepo.Delete(users["anna"], entities.DeletionModeAnonymize, time.Now())
+	if err != nil {
+		t.Fatalf("Anonymize failed: %v", err)
+	}
+	if certificate.Articles != 1 || certificate.Comments != 1 || certificate.Mode != entities.DeletionModeAnonymize {
+		t.Errorf("Unexpected certificate %+v", certificate)
+	}
+
+	kept, err := articleRepo.GetByID(articles["anna"].ID)
+	if err != nil {
+		t.Fatalf("Expected the anonymized article to be kept: %v", err)
+	}
+	if kept.Author.Username != entities.GhostUsername {
+		t.Errorf("Expected the article to move to the ghost user, got %s", kept.Author.Username)
+	}
+	if _, err := userRepo.GetByID(users["anna"].ID); err == nil {
+		t.Error("Expected the anonymized account to be removed")
+	}
+
+	if _, err := deletionRepo.Delete(users["dave"], entities.DeletionModeDelete, time.Now()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := articleRepo.GetByID(articles["dave"].ID); err == nil {
+		t.Error("Expected the deleted account's article to be removed")
+	}
+
+	comments, err := commentRepo.GetByArticleSlug(articles["reader"].Slug)
+	if err != nil {
+		t.Fatalf("Failed to list comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author.Username != entities.GhostUsername {
+		t.Errorf("Expected only the anonymized comment to remain, got %+v", comments)
+	}
+
+	favorited, err := articleRepo.GetByID(articles["reader"].ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if favorited.FavoritesCount != 0 {
+		t.Errorf("Expected favorites to be recounted, got %d", favorited.FavoritesCount)
+	}
+
+	stored, err := deletionRepo.GetCertificate(certificate.ID)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if stored.SubjectHash != certificate.SubjectHash || stored.Articles != 1 {
+		t.Errorf("Stored certificate %+v does not match %+v", stored, certificate)
+	}
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ActivityRepository defines the interface for reading a user's activity log
+type ActivityRepository interface {
+	ListByUser(userID int64, types []string, limit, offset int) ([]entities.Activity, int, error)
+	ListPublicByUser(userID int64, types []string, limit, offset int) ([]entities.Activity, int, error)
+}
+
+// activityRepository implements ActivityRepository using direct SQL
+type activityRepository struct {
+	db *database.DB
+}
+
+// NewActivityRepository creates a new activity repository
+func NewActivityRepository(db *database.DB) ActivityRepository {
+	return &activityRepository{
+		db: db,
+	}
+}
+
+// activityLog merges the user's published articles, live comments, follows
+// and favorites into one stream; each branch takes the user ID once. Times
+// are normalized to Unix seconds since rows store them in different formats.
+// The public log leaves out favorites the user hides and follows of users
+// whose profile is not public.
+func activityLog(public bool) string {
+	followed, favorites := "", ""
+	if public {
+		followed = " AND COALESCE((SELECT profile_visibility FROM user_settings WHERE user_id = u.id), 'public') = 'public'"
+		favorites = " AND COALESCE((SELECT show_favorites FROM user_settings WHERE user_id = fv.user_id), 1)"
+	}
+
+	return `
+	SELECT '` + entities.ActivityArticlePublished + `' AS type, CAST(strftime('%s', COALESCE(a.reviewed_at, a.created_at)) AS INTEGER) AS at,
+		a.slug AS slug, a.title AS title, 0 AS comment_id, '' AS username
+	FROM articles a
+	WHERE a.author_id = ? AND a.status = 'published'
+	UNION ALL
+	SELECT '` + entities.ActivityCommentCreated + `', CAST(strftime('%s', c.created_at) AS INTEGER), a.slug, a.title, c.id, ''
+	FROM comments c
+	JOIN articles a ON a.id = c.article_id
+	WHERE c.author_id = ? AND c.deleted_at IS NULL AND a.status = 'published'
+	UNION ALL
+	SELECT '` + entities.ActivityUserFollowed + `', CAST(strftime('%s', f.created_at) AS INTEGER), '', '', 0, u.username
+	FROM follows f
+	JOIN users u ON u.id = f.following_id
+	WHERE f.follower_id = ? AND ` + activeUser("u.id") + followed + `
+	UNION ALL
+	SELECT '` + entities.ActivityArticleFavorited + `', CAST(strftime('%s', fv.created_at) AS INTEGER), a.slug, a.title, 0, ''
+	FROM favorites fv
+	JOIN articles a ON a.id = fv.article_id
+	WHERE fv.user_id = ? AND a.status = 'published'` + favorites + `
+`
+}
+
+// ListByUser returns a page of the user's activity, newest first, and the
+// total number of entries; an empty types list includes every type
+func (r *activityRepository) ListByUser(userID int64, types []string, limit, offset int) ([]entities.Activity, int, error) {
+	return r.list(activityLog(false), userID, types, limit, offset)
+}
+
+// ListPublicByUser is ListByUser limited to what the user's privacy settings
+// let others see
+func (r *activityRepository) ListPublicByUser(userID int64, types []string, limit, offset int) ([]entities.Activity, int, error) {
+	return r.list(activityLog(true), userID, types, limit, offset)
+}
+
+// list reads a page of the given activity log
+func (r *activityRepository) list(log string, userID int64, types []string, limit, offset int) ([]entities.Activity, int, error) {
+	args := []interface{}{userID, userID, userID, userID}
+	where := ""
+	if len(types) > 0 {
+		placeholders := make([]string, len(types))
+		for i, activityType := range types {
+			placeholders[i] = "?"
+			args = append(args, activityType)
+		}
+		where = "WHERE type IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM ("+log+") "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count activity: %w", err)
+	}
+
+	query := `
+		SELECT type, at, slug, title, comment_id, username
+		FROM (` + log + `)
+		` + where + `
+		ORDER BY at DESC, type ASC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query activity: %w", err)
+	}
+	defer rows.Close()
+
+	activities := []entities.Activity{}
+	for rows.Next() {
+		var activity entities.Activity
+		var at int64
+		var slug, title string
+		if err := rows.Scan(&activity.Type, &at, &slug, &title, &activity.CommentID, &activity.Username); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan activity: %w", err)
+		}
+		activity.CreatedAt = time.Unix(at, 0).UTC()
+		if slug != "" {
+			activity.Article = &entities.ActivityArticle{Slug: slug, Title: title}
+		}
+		activities = append(activities, activity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate over activity: %w", err)
+	}
+	return activities, total, nil
+}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestActivityRepository_ListByUserMergesActions(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+	followRepo := NewFollowRepository(db)
+	favoriteRepo := NewFavoriteRepository(db)
+	activityRepo := NewActivityRepository(db)
+
+	users := createTestUsers(t, userRepo, "alice", "bob")
+
+	own, err := articleRepo.Create(users["alice"].ID, &entities.ArticleCreate{
+		Title:       "Alice Writes",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	theirs, err := articleRepo.Create(users["bob"].ID, &entities.ArticleCreate{
+		Title:       "Bob Writes",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	if _, err := commentRepo.Create(users["alice"].ID, theirs.ID, &entities.CommentCreate{Body: "Nice"}); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if _, err := followRepo.Follow(users["alice"].ID, []int64{users["bob"].ID}); err != nil {
+		t.Fatalf("Failed to follow: %v", err)
+	}
+	if _, err := favoriteRepo.Favorite(users["alice"].ID, theirs.ID); err != nil {
+		t.Fatalf("Failed to favorite: %v", err)
+	}
+	// Bob's actions are not in Alice's log
+	if _, err := favoriteRepo.Favorite(users["bob"].ID, own.ID); err != nil {
+		t.Fatalf("Failed to favorite: %v", err)
+	}
+
+	activities, total, err := activityRepo.ListByUser(users["alice"].ID, nil, 20, 0)
+	if err != nil {
+		t.Fatalf("Failed to list activity: %v", err)
+	}
+	if total != 4 || len(activities) != 4 {
+		t.Fatalf("Got %d of %d activities, want 4 of 4: %+v", len(activities), total, activities)
+	}
+
+	seen := make(map[string]entities.Activity)
+	for _, activity := range activities {
+		seen[activity.Type] = activity
+		if activity.CreatedAt.IsZero() {
+			t.Errorf("Activity %s has no time", activity.Type)
+		}
+	}
+	if a := seen[entities.ActivityArticlePublished]; a.Article == nil || a.Article.Slug != own.Slug {
+		t.Errorf("Published activity = %+v, want %s", a, own.Slug)
+	}
+	if a := seen[entities.ActivityCommentCreated]; a.Article == nil || a.Article.Slug != theirs.Slug || a.CommentID == 0 {
+		t.Errorf("Comment activity = %+v, want a comment on %s", a, theirs.Slug)
+	}
+	if a := seen[entities.ActivityUserFollowed]; a.Username != "bob" || a.Article != nil {
+		t.Errorf("Follow activity = %+v, want bob", a)
+	}
+
+	filtered, total, err := activityRepo.ListByUser(users["alice"].ID, []string{entities.ActivityArticleFavorited}, 20, 0)
+	if err != nil {
+		t.Fatalf("Failed to list filtered activity: %v", err)
+	}
+	if total != 1 || len(filtered) != 1 || filtered[0].Article.Slug != theirs.Slug {
+		t.Errorf("Filtered activity = %+v (total %d), want the favorite of %s", filtered, total, theirs.Slug)
+	}
+
+	page, _, _ := activityRepo.ListByUser(users["alice"].ID, nil, 2, 3)
+	if len(page) != 1 {
+		t.Errorf("Last page has %d activities, want 1", len(page))
+	}
+}
+
+func TestActivityRepository_ListPublicByUserHonorsSettings(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	followRepo := NewFollowRepository(db)
+	favoriteRepo := NewFavoriteRepository(db)
+	settingsRepo := NewSettingsRepository(db)
+	activityRepo := NewActivityRepository(db)
+
+	users := createTestUsers(t, userRepo, "alice", "bob", "carol")
+
+	article, err := articleRepo.Create(users["bob"].ID, &entities.ArticleCreate{
+		Title:       "Bob Writes",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	if _, err := followRepo.Follow(users["alice"].ID, []int64{users["bob"].ID, users["carol"].ID}); err != nil {
+		t.Fatalf("Failed to follow: %v", err)
+	}
+	if _, err := favoriteRepo.Favorite(users["alice"].ID, article.ID); err != nil {
+		t.Fatalf("Failed to favorite: %v", err)
+	}
+
+	showFavorites := false
+	if _, err := settingsRepo.Update(users["alice"].ID, &entities.UserSettingsUpdate{ShowFavorites: &showFavorites}); err != nil {
+		t.Fatalf("Failed to update settings: %v", err)
+	}
+	private := entities.VisibilityPrivate
+	if _, err := settingsRepo.Update(users["carol"].ID, &entities.UserSettingsUpdate{ProfileVisibility: &private}); err != nil {
+		t.Fatalf("Failed to update settings: %v", err)
+	}
+
+	activities, total, err := activityRepo.ListPublicByUser(users["alice"].ID, nil, 20, 0)
+	if err != nil {
+		t.Fatalf("Failed to list public activity: %v", err)
+	}
+	if total != 1 || len(activities) != 1 || activities[0].Username != "bob" {
+		t.Errorf("Public activity = %+v (total %d), want only the follow of bob", activities, total)
+	}
+
+	// The owner's own log still has everything
+	if _, total, _ := activityRepo.ListByUser(users["alice"].ID, nil, 20, 0); total != 3 {
+		t.Errorf("Own activity count = %d, want 3", total)
+	}
+}
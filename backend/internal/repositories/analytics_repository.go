@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// AnalyticsRepository defines the interface for analytics data operations
+type AnalyticsRepository interface {
+	RecordEvents(events []entities.AnalyticsEventRecord) (int, error)
+	GetArticleStats(articleID int64, since time.Time) ([]entities.ArticleDailyStats, error)
+	ListTrendingArticleIDs(since time.Time, limit int) ([]int64, error)
+	ListArticleTotals(since time.Time) ([]entities.ArticleStatsSummary, error)
+	RecordShareClick(articleID int64, medium string, at time.Time) error
+	GetShareStats(articleID int64, since time.Time) ([]entities.ArticleShareStats, error)
+}
+
+// analyticsRepository implements AnalyticsRepository using direct SQL
+type analyticsRepository struct {
+	db         *database.DB
+	quarantine time.Duration
+}
+
+// NewAnalyticsRepository creates a new analytics repository; articles by
+// accounts younger than quarantine never trend until an admin approves the account
+func NewAnalyticsRepository(db *database.DB, quarantine time.Duration) AnalyticsRepository {
+	return &analyticsRepository{
+		db:         db,
+		quarantine: quarantine,
+	}
+}
+
+// RecordEvents stores a batch of events and folds article events into the daily
+// aggregates. Repeat views of an article by the same visitor on the same day are
+// stored but only counted once. Returns the number of events stored.
+func (r *analyticsRepository) RecordEvents(events []entities.AnalyticsEventRecord) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		var articleID sql.NullInt64
+		if event.Article != "" {
+			err := tx.QueryRow("SELECT id FROM articles WHERE slug = ?", event.Article).Scan(&articleID)
+			if err != nil && err != sql.ErrNoRows {
+				return 0, fmt.Errorf("failed to resolve article: %w", err)
+			}
+		}
+
+		// Check for an earlier view before inserting this one
+		firstView := false
+		if articleID.Valid && event.Type == entities.AnalyticsPageView {
+			var exists int
+			err := tx.QueryRow(`
+				SELECT COUNT(*) FROM analytics_events
+				WHERE visitor_hash = ? AND article_id = ? AND day = ? AND event_type = ?
+			`, event.VisitorHash, articleID, event.Day, entities.AnalyticsPageView).Scan(&exists)
+			if err != nil {
+				return 0, fmt.Errorf("failed to check previous views: %w", err)
+			}
+			firstView = exists == 0
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO analytics_events (event_type, name, path, article_id, referrer_host, visitor_hash, day, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, event.Type, event.Name, event.Path, articleID, event.ReferrerHost, event.VisitorHash, event.Day, time.Now())
+		if err != nil {
+			return 0, fmt.Errorf("failed to record event: %w", err)
+		}
+
+		if !articleID.Valid {
+			continue
+		}
+
+		views, interactions := 0, 0
+		if firstView {
+			views = 1
+		}
+		if event.Type == entities.AnalyticsInteraction {
+			interactions = 1
+		}
+		if views == 0 && interactions == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO article_daily_stats (article_id, day, views, interactions)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (article_id, day) DO UPDATE SET
+				views = views + excluded.views,
+				interactions = interactions + excluded.interactions
+		`, articleID, event.Day, views, interactions)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update article stats: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit events: %w", err)
+	}
+
+	return len(events), nil
+}
+
+// GetArticleStats returns daily aggregates for an article since the given day, oldest first
+func (r *analyticsRepository) GetArticleStats(articleID int64, since time.Time) ([]entities.ArticleDailyStats, error) {
+	query := `
+		SELECT day, views, interactions
+		FROM article_daily_stats
+		WHERE article_id = ? AND day >= ?
+		ORDER BY day ASC
+	`
+
+	rows, err := r.db.Query(query, articleID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query article stats: %w", err)
+	}
+	defer rows.Close()
+
+	daily := []entities.ArticleDailyStats{}
+	for rows.Next() {
+		var day entities.ArticleDailyStats
+		if err := rows.Scan(&day.Date, &day.Views, &day.Interactions); err != nil {
+			return nil, fmt.Errorf("failed to scan article stats: %w", err)
+		}
+		daily = append(daily, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over article stats: %w", err)
+	}
+
+	return daily, nil
+}
+
+// ListTrendingArticleIDs returns the most viewed articles since the given day
+func (r *analyticsRepository) ListTrendingArticleIDs(since time.Time, limit int) ([]int64, error) {
+	listed, cutoff := listedAuthor("a.author_id", r.quarantine)
+	query := `
+		SELECT s.article_id
+		FROM article_daily_stats s
+		JOIN articles a ON a.id = s.article_id
+		WHERE s.day >= ? AND a.translation_of IS NULL AND a.status = 'published' AND ` + activeUser("a.author_id") + ` AND ` + listed + `
+		GROUP BY s.article_id
+		ORDER BY SUM(s.views) DESC, SUM(s.interactions) DESC, s.article_id DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, since.UTC().Format("2006-01-02"), cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trending articles: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan trending article: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over trending articles: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ListArticleTotals returns per-article totals since the given day, most viewed first
+func (r *analyticsRepository) ListArticleTotals(since time.Time) ([]entities.ArticleStatsSummary, error) {
+	query := `
+		SELECT a.slug, a.title, SUM(s.views), SUM(s.interactions)
+		FROM article_daily_stats s
+		JOIN articles a ON a.id = s.article_id
+		WHERE s.day >= ?
+		GROUP BY s.article_id
+		ORDER BY SUM(s.views) DESC, SUM(s.interactions) DESC, s.article_id DESC
+	`
+
+	rows, err := r.db.Query(query, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query article totals: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []entities.ArticleStatsSummary{}
+	for rows.Next() {
+		var summary entities.ArticleStatsSummary
+		if err := rows.Scan(&summary.Slug, &summary.Title, &summary.Views, &summary.Interactions); err != nil {
+			return nil, fmt.Errorf("failed to scan article totals: %w", err)
+		}
+		totals = append(totals, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over article totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// RecordShareClick counts a click on one of the article's share links on the
+// day of at
+func (r *analyticsRepository) RecordShareClick(articleID int64, medium string, at time.Time) error {
+	_, err := r.db.Exec(`
+		INSERT INTO article_share_clicks (article_id, medium, day, clicks)
+		VALUES (?, ?, ?, 1)
+		ON CONFLICT(article_id, medium, day) DO UPDATE SET clicks = clicks + 1
+	`, articleID, medium, at.UTC().Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to record share click: %w", err)
+	}
+	return nil
+}
+
+// GetShareStats returns the article's share link clicks since the given day
+// by medium, most clicked first
+func (r *analyticsRepository) GetShareStats(articleID int64, since time.Time) ([]entities.ArticleShareStats, error) {
+	query := `
+		SELECT medium, SUM(clicks)
+		FROM article_share_clicks
+		WHERE article_id = ? AND day >= ?
+		GROUP BY medium
+		ORDER BY SUM(clicks) DESC, medium ASC
+	`
+
+	rows, err := r.db.Query(query, articleID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query share stats: %w", err)
+	}
+	defer rows.Close()
+
+	shares := []entities.ArticleShareStats{}
+	for rows.Next() {
+		var share entities.ArticleShareStats
+		if err := rows.Scan(&share.Medium, &share.Clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan share stats: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over share stats: %w", err)
+	}
+
+	return shares, nil
+}
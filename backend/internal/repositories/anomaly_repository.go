@@ -0,0 +1,352 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// AnomalyRepository defines the interface for abuse signal detection and anomaly review
+type AnomalyRepository interface {
+	RegistrationBursts(since time.Time, threshold int) ([]entities.AnomalySignal, error)
+	FavoriteBursts(since time.Time, threshold int) ([]entities.AnomalySignal, error)
+	CommentFloods(since time.Time, threshold int) ([]entities.AnomalySignal, error)
+	Record(signal entities.AnomalySignal, at time.Time) (bool, error)
+	List(status string, limit, offset int) ([]entities.Anomaly, int, error)
+	GetByID(id int64) (*entities.Anomaly, error)
+	Resolve(id int64, action, moderationStatus string, at time.Time) error
+}
+
+// anomalyRepository implements AnomalyRepository using direct SQL
+type anomalyRepository struct {
+	db *database.DB
+}
+
+// NewAnomalyRepository creates a new anomaly repository
+func NewAnomalyRepository(db *database.DB) AnomalyRepository {
+	return &anomalyRepository{
+		db: db,
+	}
+}
+
+// RegistrationBursts returns networks with at least threshold registrations
+// since the given time, with the accounts registered from each
+func (r *anomalyRepository) RegistrationBursts(since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	query := `
+		SELECT registration_network, id
+		FROM users
+		WHERE registration_network != '' AND created_at >= ?
+			AND registration_network IN (
+				SELECT registration_network FROM users
+				WHERE registration_network != '' AND created_at >= ?
+				GROUP BY registration_network
+				HAVING COUNT(*) >= ?
+			)
+		ORDER BY registration_network, id
+	`
+
+	rows, err := r.db.Query(query, since, since, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registration bursts: %w", err)
+	}
+	defer rows.Close()
+
+	var signals []entities.AnomalySignal
+	for rows.Next() {
+		var network string
+		var userID int64
+		if err := rows.Scan(&network, &userID); err != nil {
+			return nil, fmt.Errorf("failed to scan registration: %w", err)
+		}
+
+		if len(signals) == 0 || signals[len(signals)-1].Network != network {
+			signals = append(signals, entities.AnomalySignal{
+				Kind:        entities.AnomalyRegistrationBurst,
+				Network:     network,
+				WindowStart: since,
+			})
+		}
+		signal := &signals[len(signals)-1]
+		signal.ActorIDs = append(signal.ActorIDs, userID)
+		signal.EventCount++
+	}
+
+	return signals, rows.Err()
+}
+
+// FavoriteBursts returns users who favorited at least threshold articles since the given time
+func (r *anomalyRepository) FavoriteBursts(since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	// Favorite times are CURRENT_TIMESTAMP defaults, stored in UTC
+	return r.userBursts(entities.AnomalyFavoriteBurst, "favorites", "user_id", since.UTC(), threshold)
+}
+
+// CommentFloods returns users who posted at least threshold comments since the
+// given time; deleted comments still count
+func (r *anomalyRepository) CommentFloods(since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	return r.userBursts(entities.AnomalyCommentFlood, "comments", "author_id", since, threshold)
+}
+
+// userBursts counts each user's rows in table since the given time
+func (r *anomalyRepository) userBursts(kind, table, userColumn string, since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	query := fmt.Sprintf(`
+		SELECT %[2]s, COUNT(*)
+		FROM %[1]s
+		WHERE created_at >= ?
+		GROUP BY %[2]s
+		HAVING COUNT(*) >= ?
+		ORDER BY COUNT(*) DESC
+	`, table, userColumn)
+
+	rows, err := r.db.Query(query, since, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var signals []entities.AnomalySignal
+	for rows.Next() {
+		signal := entities.AnomalySignal{Kind: kind, WindowStart: since}
+		if err := rows.Scan(&signal.UserID, &signal.EventCount); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", kind, err)
+		}
+		signal.ActorIDs = []int64{signal.UserID}
+		signals = append(signals, signal)
+	}
+
+	return signals, rows.Err()
+}
+
+// Record stores a signal as an anomaly. A signal for a subject that already
+// has an open anomaly updates it instead, and one for a subject resolved since
+// the signal's window started is ignored. It reports whether a new anomaly was created.
+func (r *anomalyRepository) Record(signal entities.AnomalySignal, at time.Time) (bool, error) {
+	var subject interface{}
+	if signal.UserID != 0 {
+		subject = signal.UserID
+	}
+
+	created := false
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		var id int64
+		var resolvedAt sql.NullTime
+		err := tx.QueryRow(`
+			SELECT id, resolved_at FROM anomalies
+			WHERE kind = ? AND user_id IS ? AND network = ? AND (resolved_at IS NULL OR resolved_at >= ?)
+			ORDER BY resolved_at IS NULL DESC, id DESC
+			LIMIT 1
+		`, signal.Kind, subject, signal.Network, signal.WindowStart).Scan(&id, &resolvedAt)
+
+		switch {
+		case err == sql.ErrNoRows:
+			result, err := tx.Exec(`
+				INSERT INTO anomalies (kind, user_id, network, event_count, window_start, detected_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`, signal.Kind, subject, signal.Network, signal.EventCount, signal.WindowStart, at)
+			if err != nil {
+				return fmt.Errorf("failed to create anomaly: %w", err)
+			}
+			if id, err = result.LastInsertId(); err != nil {
+				return fmt.Errorf("failed to get anomaly ID: %w", err)
+			}
+			created = true
+		case err != nil:
+			return fmt.Errorf("failed to find anomaly: %w", err)
+		case resolvedAt.Valid:
+			// An admin already handled this burst
+			return nil
+		default:
+			if _, err := tx.Exec(`
+				UPDATE anomalies SET event_count = MAX(event_count, ?), detected_at = ? WHERE id = ?
+			`, signal.EventCount, at, id); err != nil {
+				return fmt.Errorf("failed to update anomaly: %w", err)
+			}
+		}
+
+		for _, actorID := range signal.ActorIDs {
+			if _, err := tx.Exec("INSERT OR IGNORE INTO anomaly_actors (anomaly_id, user_id) VALUES (?, ?)", id, actorID); err != nil {
+				return fmt.Errorf("failed to record anomaly actor: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+// List returns a page of anomalies with the given status, most recently
+// detected first, and the total number with that status
+func (r *anomalyRepository) List(status string, limit, offset int) ([]entities.Anomaly, int, error) {
+	where := ""
+	switch status {
+	case entities.AnomalyStatusOpen:
+		where = "WHERE resolved_at IS NULL"
+	case entities.AnomalyStatusResolved:
+		where = "WHERE resolved_at IS NOT NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM anomalies " + where).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count anomalies: %w", err)
+	}
+
+	query := `
+		SELECT id, kind, network, event_count, window_start, detected_at, resolved_at, action
+		FROM anomalies
+		` + where + `
+		ORDER BY detected_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	anomalies := []entities.Anomaly{}
+	for rows.Next() {
+		anomaly, err := scanAnomaly(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		anomalies = append(anomalies, *anomaly)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate over anomalies: %w", err)
+	}
+	rows.Close()
+
+	// Load actors once the rows are released (SQLite uses a single connection)
+	if err := r.loadActors(anomalies); err != nil {
+		return nil, 0, err
+	}
+
+	return anomalies, total, nil
+}
+
+// GetByID retrieves an anomaly with its actors
+func (r *anomalyRepository) GetByID(id int64) (*entities.Anomaly, error) {
+	query := `
+		SELECT id, kind, network, event_count, window_start, detected_at, resolved_at, action
+		FROM anomalies
+		WHERE id = ?
+	`
+
+	anomaly, err := scanAnomaly(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("anomaly not found")
+		}
+		return nil, err
+	}
+
+	anomalies := []entities.Anomaly{*anomaly}
+	if err := r.loadActors(anomalies); err != nil {
+		return nil, err
+	}
+
+	return &anomalies[0], nil
+}
+
+// Resolve records the admin's action on an anomaly and, unless
+// moderationStatus is empty, applies it to every actor
+func (r *anomalyRepository) Resolve(id int64, action, moderationStatus string, at time.Time) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec("UPDATE anomalies SET resolved_at = ?, action = ? WHERE id = ?", at, action, id)
+		if err != nil {
+			return fmt.Errorf("failed to resolve anomaly: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return fmt.Errorf("anomaly not found")
+		}
+
+		if moderationStatus == "" {
+			return nil
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE users SET moderation_status = ?, updated_at = ?
+			WHERE id IN (SELECT user_id FROM anomaly_actors WHERE anomaly_id = ?)
+		`, moderationStatus, at, id); err != nil {
+			return fmt.Errorf("failed to restrict anomaly actors: %w", err)
+		}
+		return nil
+	})
+}
+
+// loadActors fills in the usernames of each anomaly's actors
+func (r *anomalyRepository) loadActors(anomalies []entities.Anomaly) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(anomalies))
+	placeholders := make([]string, len(anomalies))
+	args := make([]interface{}, len(anomalies))
+	for i := range anomalies {
+		anomalies[i].Actors = []string{}
+		index[anomalies[i].ID] = i
+		placeholders[i] = "?"
+		args[i] = anomalies[i].ID
+	}
+
+	query := `
+		SELECT aa.anomaly_id, u.username
+		FROM anomaly_actors aa
+		JOIN users u ON u.id = aa.user_id
+		WHERE aa.anomaly_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY u.username ASC
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query anomaly actors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var anomalyID int64
+		var username string
+		if err := rows.Scan(&anomalyID, &username); err != nil {
+			return fmt.Errorf("failed to scan anomaly actor: %w", err)
+		}
+		anomaly := &anomalies[index[anomalyID]]
+		anomaly.Actors = append(anomaly.Actors, username)
+	}
+
+	return rows.Err()
+}
+
+// scanAnomaly reads an anomaly row without its actors
+func scanAnomaly(row interface{ Scan(...interface{}) error }) (*entities.Anomaly, error) {
+	anomaly := &entities.Anomaly{}
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&anomaly.ID,
+		&anomaly.Kind,
+		&anomaly.Network,
+		&anomaly.EventCount,
+		&anomaly.WindowStart,
+		&anomaly.DetectedAt,
+		&resolvedAt,
+		&anomaly.Action,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan anomaly: %w", err)
+	}
+
+	if resolvedAt.Valid {
+		anomaly.ResolvedAt = &resolvedAt.Time
+	}
+	return anomaly, nil
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestAnomalyRepository_RecordAndResolveRegistrationBurst(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	anomalyRepo := NewAnomalyRepository(db)
+
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("bot%d", i)
+		if _, err := userRepo.Create(&entities.UserRegistration{
+			Username: name,
+			Email:    name + "@example.com",
+			Password: "password123",
+			Network:  "shared",
+		}); err != nil {
+			t.Fatalf("Failed to create user %s: %v", name, err)
+		}
+	}
+	if _, err := userRepo.Create(&entities.UserRegistration{
+		Username: "human",
+		Email:    "human@example.com",
+		Password: "password123",
+		Network:  "elsewhere",
+	}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	now := time.Now()
+	signals, err := anomalyRepo.RegistrationBursts(now.Add(-time.Hour), 3)
+	if err != nil {
+		t.Fatalf("Failed to detect registration bursts: %v", err)
+	}
+	if len(signals) != 1 || signals[0].Network != "shared" || signals[0].EventCount != 3 {
+		t.Fatalf("Signals = %+v, want one burst of 3 from the shared network", signals)
+	}
+
+	if created, err := anomalyRepo.Record(signals[0], now); err != nil || !created {
+		t.Fatalf("Record() = %v, %v; want true, nil", created, err)
+	}
+	if created, err := anomalyRepo.Record(signals[0], now); err != nil || created {
+		t.Fatalf("Recording an open anomaly again = %v, %v; want false, nil", created, err)
+	}
+
+	anomalies, total, err := anomalyRepo.List(entities.AnomalyStatusOpen, 20, 0)
+	if err != nil {
+		t.Fatalf("Failed to list anomalies: %v", err)
+	}
+	if total != 1 || len(anomalies) != 1 || len(anomalies[0].Actors) != 3 {
+		t.Fatalf("Anomalies = %+v (total %d), want one with three actors", anomalies, total)
+	}
+
+	if err := anomalyRepo.Resolve(anomalies[0].ID, entities.AnomalyActionBan, entities.ModerationBanned, now); err != nil {
+		t.Fatalf("Failed to resolve anomaly: %v", err)
+	}
+
+	bot, err := userRepo.GetByUsername("bot0")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if !bot.IsBanned() {
+		t.Errorf("Expected the actors to be banned, got status %q", bot.ModerationStatus)
+	}
+	human, _ := userRepo.GetByUsername("human")
+	if human.ModerationStatus != entities.ModerationNone {
+		t.Errorf("Expected other users to be unaffected, got status %q", human.ModerationStatus)
+	}
+
+	// A burst that was already handled is not reported again
+	if created, err := anomalyRepo.Record(signals[0], now.Add(time.Minute)); err != nil || created {
+		t.Errorf("Recording a resolved anomaly = %v, %v; want false, nil", created, err)
+	}
+	if _, total, _ := anomalyRepo.List(entities.AnomalyStatusOpen, 20, 0); total != 0 {
+		t.Errorf("Open anomalies = %d, want 0", total)
+	}
+
+	if err := anomalyRepo.Resolve(999, entities.AnomalyActionDismiss, "", now); err == nil {
+		t.Error("Expected an error resolving a missing anomaly")
+	}
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// apiKeyTouchInterval limits how often authenticating with a key records its
+// last use, so busy CI jobs do not write on every request
+const apiKeyTouchInterval = time.Minute
+
+// APIKeyRepository defines the interface for personal API key operations
+type APIKeyRepository interface {
+	Create(userID int64, create *entities.APIKeyCreate) (*entities.APIKey, error)
+	ListByUser(userID int64) ([]entities.APIKey, error)
+	Revoke(userID, id int64, at time.Time) error
+	Authenticate(key string, at time.Time) (*entities.APIKey, error)
+}
+
+// apiKeyRepository implements APIKeyRepository using direct SQL
+type apiKeyRepository struct {
+	db *database.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *database.DB) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// apiKeyColumns are the columns scanned by scanAPIKey
+const apiKeyColumns = `id, user_id, name, prefix, scopes, last_used_at, expires_at, created_at, revoked_at`
+
+// Create generates a new key for the user and stores its hash. The returned
+// key carries the plaintext Key, which cannot be recovered afterwards. It
+// fails with "too many API keys" past MaxAPIKeysPerUser unrevoked keys.
+func (r *apiKeyRepository) Create(userID int64, create *entities.APIKeyCreate) (*entities.APIKey, error) {
+	key, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var expiresAt *time.Time
+	if create.ExpiresInDays != nil {
+		expires := now.AddDate(0, 0, *create.ExpiresInDays)
+		expiresAt = &expires
+	}
+
+	var id int64
+	err = r.db.Transaction(func(tx *sql.Tx) error {
+		var count int
+		err := tx.QueryRow(`
+			SELECT COUNT(*) FROM api_keys WHERE user_id = ? AND revoked_at IS NULL
+		`, userID).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("failed to count API keys: %w", err)
+		}
+		if count >= entities.MaxAPIKeysPerUser {
+			return fmt.Errorf("too many API keys")
+		}
+
+		err = tx.QueryRow(`
+			INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, created_at, expires_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`, userID, strings.TrimSpace(create.Name), apiKeyDisplayPrefix(key), hashAPIKey(key),
+			strings.Join(create.Scopes, " "), now, expiresAt).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := scanAPIKey(r.db.QueryRow(`SELECT `+apiKeyColumns+` FROM api_keys WHERE id = ?`, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	created.Key = key
+	return created, nil
+}
+
+// ListByUser returns the user's keys, revoked ones included, newest first
+func (r *apiKeyRepository) ListByUser(userID int64) ([]entities.APIKey, error) {
+	rows, err := r.db.Query(`
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []entities.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Revoke stops one of the user's keys from authenticating. Revoking a key
+// twice keeps the first revocation time.
+func (r *apiKeyRepository) Revoke(userID, id int64, at time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?)
+		WHERE id = ? AND user_id = ?
+	`, at, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("API key not found")
+	}
+
+	return nil
+}
+
+// Authenticate returns the active key matching key, failing with "invalid API
+// key" if there is none. Its last use is recorded at most once per
+// apiKeyTouchInterval.
+func (r *apiKeyRepository) Authenticate(key string, at time.Time) (*entities.APIKey, error) {
+	if !strings.HasPrefix(key, entities.APIKeyPrefix) {
+		return nil, fmt.Errorf("invalid API key")
+	}
+
+	apiKey, err := scanAPIKey(r.db.QueryRow(`
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE key_hash = ?
+	`, hashAPIKey(key)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("invalid API key")
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if !apiKey.IsActive(at) {
+		return nil, fmt.Errorf("invalid API key")
+	}
+
+	if apiKey.LastUsedAt == nil || at.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if _, err := r.db.Exec(`UPDATE api_keys SET last_used_at = ? WHERE id = ?`, at, apiKey.ID); err != nil {
+			return nil, fmt.Errorf("failed to record API key use: %w", err)
+		}
+		apiKey.LastUsedAt = &at
+	}
+
+	return apiKey, nil
+}
+
+// scanAPIKey reads an API key row
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*entities.APIKey, error) {
+	key := &entities.APIKey{}
+	var scopes string
+	var lastUsedAt, expiresAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&scopes,
+		&lastUsedAt,
+		&expiresAt,
+		&key.CreatedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = strings.Fields(scopes)
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return key, nil
+}
+
+// newAPIKey returns a random API key
+func newAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return entities.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashAPIKey returns the hash a key is stored and looked up by. Keys are
+// long and random, so a fast unsalted hash is enough.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyDisplayPrefix returns enough of the key to recognise it by
+func apiKeyDisplayPrefix(key string) string {
+	return key[:len(entities.APIKeyPrefix)+8]
+}
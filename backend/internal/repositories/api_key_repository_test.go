@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestAPIKeyRepository_AuthenticateAndRevoke(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	apiKeyRepo := NewAPIKeyRepository(db)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	days := 30
+	created, err := apiKeyRepo.Create(user.ID, &entities.APIKeyCreate{
+		Name:          " ci ",
+		Scopes:        []string{entities.ScopeRead},
+		ExpiresInDays: &days,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API key: %v", err)
+	}
+	if !strings.HasPrefix(created.Key, entities.APIKeyPrefix) || !strings.HasPrefix(created.Key, created.Prefix) {
+		t.Errorf("Expected key %q to start with %q", created.Key, created.Prefix)
+	}
+	if created.Name != "ci" || created.ExpiresAt == nil {
+		t.Errorf("Expected trimmed name and an expiry, got %q and %v", created.Name, created.ExpiresAt)
+	}
+
+	now := time.Now().UTC()
+	authenticated, err := apiKeyRepo.Authenticate(created.Key, now)
+	if err != nil {
+		t.Fatalf("Failed to authenticate API key: %v", err)
+	}
+	if authenticated.UserID != user.ID || len(authenticated.Scopes) != 1 || authenticated.Scopes[0] != entities.ScopeRead {
+		t.Errorf("Expected alice's read key, got user %d with scopes %v", authenticated.UserID, authenticated.Scopes)
+	}
+	if authenticated.LastUsedAt == nil {
+		t.Error("Expected authenticating to record the key's last use")
+	}
+
+	if _, err := apiKeyRepo.Authenticate(created.Key+"x", now); err == nil {
+		t.Error("Expected an unknown key to be rejected")
+	}
+	if _, err := apiKeyRepo.Authenticate(created.Key, now.AddDate(0, 0, days+1)); err == nil {
+		t.Error("Expected an expired key to be rejected")
+	}
+
+	keys, err := apiKeyRepo.ListByUser(user.ID)
+	if err != nil {
+		t.Fatalf("Failed to list API keys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Key != "" {
+		t.Errorf("Expected one listed key without its secret, got %+v", keys)
+	}
+
+	if err := apiKeyRepo.Revoke(user.ID+1, created.ID, now); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected revoking another user's key to fail with not found, got %v", err)
+	}
+	if err := apiKeyRepo.Revoke(user.ID, created.ID, now); err != nil {
+		t.Fatalf("Failed to revoke API key: %v", err)
+	}
+	if _, err := apiKeyRepo.Authenticate(created.Key, now); err == nil {
+		t.Error("Expected a revoked key to be rejected")
+	}
+}
+
+func TestAPIKeyRepository_CreateCapsActiveKeys(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	user, err := NewUserRepository(db).Create(&entities.UserRegistration{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	apiKeyRepo := NewAPIKeyRepository(db)
+	create := &entities.APIKeyCreate{Name: "ci", Scopes: []string{entities.ScopeWrite}}
+	var first *entities.APIKey
+	for i := 0; i < entities.MaxAPIKeysPerUser; i++ {
+		key, err := apiKeyRepo.Create(user.ID, create)
+		if err != nil {
+			t.Fatalf("Failed to create API key %d: %v", i, err)
+		}
+		if first == nil {
+			first = key
+		}
+	}
+
+	if _, err := apiKeyRepo.Create(user.ID, create); err == nil || !strings.Contains(err.Error(), "too many") {
+		t.Fatalf("Expected too many API keys, got %v", err)
+	}
+
+	// Revoked keys no longer count towards the cap
+	if err := apiKeyRepo.Revoke(user.ID, first.ID, time.Now().UTC()); err != nil {
+		t.Fatalf("Failed to revoke API key: %v", err)
+	}
+	if _, err := apiKeyRepo.Create(user.ID, create); err != nil {
+		t.Errorf("Expected a key to be created after revoking one, got %v", err)
+	}
+}
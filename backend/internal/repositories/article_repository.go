@@ -21,19 +21,33 @@ type ArticleRepository interface {
 	SlugExists(slug string) (bool, error)
 	GetExistingSlugs(baseSlug string) ([]string, error)
 	IsAuthor(articleID, userID int64) (bool, error)
+	CreateTranslation(source *entities.Article, translation *entities.ArticleTranslationCreate) (*entities.Article, error)
+	GetTranslations(article *entities.Article) ([]entities.ArticleTranslation, error)
+	Review(id int64, status string, reviewerID int64, note string) (*entities.Article, error)
+	CountByAuthor(authorID int64, status string) (int, error)
+	ListRecentTimesByAuthor(authorID int64, since time.Time) ([]time.Time, error)
+	Feature(id, featuredBy int64, feature *entities.ArticleFeature) error
+	Unfeature(id int64) error
+	Pin(id, authorID int64, limit int) error
+	Unpin(id int64) error
+	SetCommentsLocked(id int64, locked bool) error
 }
 
 // articleRepository implements ArticleRepository using direct SQL
 type articleRepository struct {
-	db       *database.DB
-	userRepo UserRepository
+	db         *database.DB
+	userRepo   UserRepository
+	quarantine time.Duration
 }
 
-// NewArticleRepository creates a new article repository
-func NewArticleRepository(db *database.DB, userRepo UserRepository) ArticleRepository {
+// NewArticleRepository creates a new article repository. Articles by accounts
+// younger than quarantine are left out of listings other than their author's
+// until an admin approves the account.
+func NewArticleRepository(db *database.DB, userRepo UserRepository, quarantine time.Duration) ArticleRepository {
 	return &articleRepository{
-		db:       db,
-		userRepo: userRepo,
+		db:         db,
+		userRepo:   userRepo,
+		quarantine: quarantine,
 	}
 }
 
@@ -54,46 +68,81 @@ func (r *articleRepository) Create(authorID int64, articleCreate *entities.Artic
 	// Ensure unique slug
 	uniqueSlug := entities.EnsureUniqueSlug(baseSlug, existingSlugs)
 
+	language := entities.DefaultArticleLanguage
+	if articleCreate.Language != "" {
+		language = entities.NormalizeLanguageCode(articleCreate.Language)
+	}
+
+	status := entities.ArticleStatusPublished
+	if articleCreate.Status != "" {
+		status = articleCreate.Status
+	}
+
 	now := time.Now()
 
 	query := `
-		INSERT INTO articles (slug, title, description, body, author_id, favorites_count, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, 0, ?, ?)
-		RETURNING id, slug, title, description, body, author_id, favorites_count, created_at, updated_at
+		INSERT INTO articles (slug, title, description, body, language, author_id, status, noindex, favorites_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?)
+		RETURNING id, slug, title, description, body, language, translation_of, author_id, favorites_count, claps_count, comments_count, last_comment_at, created_at, updated_at, status, review_note, featured_at IS NOT NULL, featured_note, pinned_at IS NOT NULL, comments_locked_at IS NOT NULL, noindex
 	`
 
+	// The article and its tags are stored together or not at all
 	article := &entities.Article{}
-	err = r.db.QueryRow(query,
-		uniqueSlug,
-		articleCreate.Title,
-		articleCreate.Description,
-		articleCreate.Body,
-		authorID,
-		now,
-		now,
-	).Scan(
-		&article.ID,
-		&article.Slug,
-		&article.Title,
-		&article.Description,
-		&article.Body,
-		&article.AuthorID,
-		&article.FavoritesCount,
-		&article.CreatedAt,
-		&article.UpdatedAt,
-	)
+	err = r.db.Transaction(func(tx *sql.Tx) error {
+		err := tx.QueryRow(query,
+			uniqueSlug,
+			articleCreate.Title,
+			articleCreate.Description,
+			articleCreate.Body,
+			language,
+			authorID,
+			status,
+			articleCreate.NoIndex,
+			now,
+			now,
+		).Scan(
+			&article.ID,
+			&article.Slug,
+			&article.Title,
+			&article.Description,
+			&article.Body,
+			&article.Language,
+			&article.TranslationOf,
+			&article.AuthorID,
+			&article.FavoritesCount,
+			&article.ClapsCount,
+			&article.CommentsCount,
+			&article.LastCommentAt,
+			&article.CreatedAt,
+			&article.UpdatedAt,
+			&article.Status,
+			&article.ReviewNote,
+			&article.Featured,
+			&article.FeaturedNote,
+			&article.Pinned,
+			&article.CommentsLocked,
+			&article.NoIndex,
+		)
+		if err != nil {
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("article with this slug already exists")
+			}
+			return fmt.Errorf("failed to create article: %w", err)
+		}
 
+		return setTags(tx, article.ID, articleCreate.TagList)
+	})
 	if err != nil {
-		if isUniqueConstraintError(err) {
-			return nil, fmt.Errorf("article with this slug already exists")
-		}
-		return nil, fmt.Errorf("failed to create article: %w", err)
+		return nil, err
 	}
 
 	// Load author information
 	if err := r.loadAuthor(article); err != nil {
 		return nil, fmt.Errorf("failed to load author: %w", err)
 	}
+	if err := r.loadTags(article); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
 
 	return article, nil
 }
@@ -101,7 +150,7 @@ func (r *articleRepository) Create(authorID int64, articleCreate *entities.Artic
 // GetBySlug retrieves an article by slug
 func (r *articleRepository) GetBySlug(slug string) (*entities.Article, error) {
 	query := `
-		SELECT id, slug, title, description, body, author_id, favorites_count, created_at, updated_at
+		SELECT id, slug, title, description, body, language, translation_of, author_id, favorites_count, claps_count, comments_count, last_comment_at, created_at, updated_at, status, review_note, featured_at IS NOT NULL, featured_note, pinned_at IS NOT NULL, comments_locked_at IS NOT NULL, noindex
 		FROM articles 
 		WHERE slug = ?
 	`
@@ -113,10 +162,22 @@ func (r *articleRepository) GetBySlug(slug string) (*entities.Article, error) {
 		&article.Title,
 		&article.Description,
 		&article.Body,
+		&article.Language,
+		&article.TranslationOf,
 		&article.AuthorID,
 		&article.FavoritesCount,
+		&article.ClapsCount,
+		&article.CommentsCount,
+		&article.LastCommentAt,
 		&article.CreatedAt,
 		&article.UpdatedAt,
+		&article.Status,
+		&article.ReviewNote,
+		&article.Featured,
+		&article.FeaturedNote,
+		&article.Pinned,
+		&article.CommentsLocked,
+		&article.NoIndex,
 	)
 
 	if err != nil {
@@ -130,6 +191,9 @@ func (r *articleRepository) GetBySlug(slug string) (*entities.Article, error) {
 	if err := r.loadAuthor(article); err != nil {
 		return nil, fmt.Errorf("failed to load author: %w", err)
 	}
+	if err := r.loadTags(article); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
 
 	return article, nil
 }
@@ -137,7 +201,7 @@ func (r *articleRepository) GetBySlug(slug string) (*entities.Article, error) {
 // GetByID retrieves an article by ID
 func (r *articleRepository) GetByID(id int64) (*entities.Article, error) {
 	query := `
-		SELECT id, slug, title, description, body, author_id, favorites_count, created_at, updated_at
+		SELECT id, slug, title, description, body, language, translation_of, author_id, favorites_count, claps_count, comments_count, last_comment_at, created_at, updated_at, status, review_note, featured_at IS NOT NULL, featured_note, pinned_at IS NOT NULL, comments_locked_at IS NOT NULL, noindex
 		FROM articles 
 		WHERE id = ?
 	`
@@ -149,10 +213,22 @@ func (r *articleRepository) GetByID(id int64) (*entities.Article, error) {
 		&article.Title,
 		&article.Description,
 		&article.Body,
+		&article.Language,
+		&article.TranslationOf,
 		&article.AuthorID,
 		&article.FavoritesCount,
+		&article.ClapsCount,
+		&article.CommentsCount,
+		&article.LastCommentAt,
 		&article.CreatedAt,
 		&article.UpdatedAt,
+		&article.Status,
+		&article.ReviewNote,
+		&article.Featured,
+		&article.FeaturedNote,
+		&article.Pinned,
+		&article.CommentsLocked,
+		&article.NoIndex,
 	)
 
 	if err != nil {
@@ -166,6 +242,9 @@ func (r *articleRepository) GetByID(id int64) (*entities.Article, error) {
 	if err := r.loadAuthor(article); err != nil {
 		return nil, fmt.Errorf("failed to load author: %w", err)
 	}
+	if err := r.loadTags(article); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
 
 	return article, nil
 }
@@ -204,50 +283,90 @@ func (r *articleRepository) Update(id int64, updates *entities.ArticleUpdate) (*
 		args = append(args, *updates.Body)
 	}
 
-	if len(setParts) == 0 {
-		// No updates requested, just return current article
-		return r.GetByID(id)
+	if updates.NoIndex != nil {
+		setParts = append(setParts, "noindex = ?")
+		args = append(args, *updates.NoIndex)
 	}
 
-	// Add updated_at and article ID
-	setParts = append(setParts, "updated_at = ?")
-	args = append(args, time.Now())
-	args = append(args, id)
-
-	query := fmt.Sprintf(`
-		UPDATE articles 
-		SET %s
-		WHERE id = ?
-		RETURNING id, slug, title, description, body, author_id, favorites_count, created_at, updated_at
-	`, joinStrings(setParts, ", "))
-
+	// Tags and fields are updated together or not at all
 	article := &entities.Article{}
-	err := r.db.QueryRow(query, args...).Scan(
-		&article.ID,
-		&article.Slug,
-		&article.Title,
-		&article.Description,
-		&article.Body,
-		&article.AuthorID,
-		&article.FavoritesCount,
-		&article.CreatedAt,
-		&article.UpdatedAt,
-	)
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		if updates.TagList != nil || updates.Clears("tagList") {
+			var tags []string
+			if updates.TagList != nil {
+				tags = *updates.TagList
+			}
+			if err := setTags(tx, id, tags); err != nil {
+				return err
+			}
+		}
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("article not found")
+		if len(setParts) == 0 {
+			return nil
 		}
-		if isUniqueConstraintError(err) {
-			return nil, fmt.Errorf("slug already exists")
+
+		// Add updated_at and article ID
+		setParts = append(setParts, "updated_at = ?")
+		args = append(args, time.Now())
+		args = append(args, id)
+
+		query := fmt.Sprintf(`
+			UPDATE articles
+			SET %s
+			WHERE id = ?
+			RETURNING id, slug, title, description, body, language, translation_of, author_id, favorites_count, claps_count, comments_count, last_comment_at, created_at, updated_at, status, review_note, featured_at IS NOT NULL, featured_note, pinned_at IS NOT NULL, comments_locked_at IS NOT NULL, noindex
+		`, joinStrings(setParts, ", "))
+
+		err := tx.QueryRow(query, args...).Scan(
+			&article.ID,
+			&article.Slug,
+			&article.Title,
+			&article.Description,
+			&article.Body,
+			&article.Language,
+			&article.TranslationOf,
+			&article.AuthorID,
+			&article.FavoritesCount,
+			&article.ClapsCount,
+			&article.CommentsCount,
+			&article.LastCommentAt,
+			&article.CreatedAt,
+			&article.UpdatedAt,
+			&article.Status,
+			&article.ReviewNote,
+			&article.Featured,
+			&article.FeaturedNote,
+			&article.Pinned,
+			&article.CommentsLocked,
+			&article.NoIndex,
+		)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("article not found")
+			}
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("slug already exists")
+			}
+			return fmt.Errorf("failed to update article: %w", err)
 		}
-		return nil, fmt.Errorf("failed to update article: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(setParts) == 0 {
+		// No field updates requested, just return current article
+		return r.GetByID(id)
 	}
 
 	// Load author information
 	if err := r.loadAuthor(article); err != nil {
 		return nil, fmt.Errorf("failed to load author: %w", err)
 	}
+	if err := r.loadTags(article); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
 
 	return article, nil
 }
@@ -286,13 +405,63 @@ func (r *articleRepository) List(query *entities.ArticleListQuery) ([]entities.A
 		query.Offset = 0
 	}
 
-	// Build WHERE clause
-	whereParts := []string{}
-	args := []interface{}{}
+	// Build WHERE clause (translations are reached through their original
+	// article; deactivated authors' articles are hidden)
+	status := entities.ArticleStatusPublished
+	if query.Status != "" {
+		status = query.Status
+	}
+	whereParts := []string{"a.translation_of IS NULL", activeUser("a.author_id"), "a.status = ?"}
+	args := []interface{}{status}
 
 	if query.Author != "" {
 		whereParts = append(whereParts, "u.username = ?")
 		args = append(args, query.Author)
+	} else if status == entities.ArticleStatusPublished {
+		// Quarantined accounts' articles are only reachable by direct link
+		// and from their author's profile
+		condition, cutoff := listedAuthor("a.author_id", r.quarantine)
+		whereParts = append(whereParts, condition)
+		args = append(args, cutoff)
+	}
+
+	if query.Tag != "" {
+		// Match the canonical tag or any of its aliases
+		whereParts = append(whereParts, `a.id IN (
+			SELECT at.article_id FROM article_tags at JOIN tags t ON t.id = at.tag_id
+			WHERE t.name = ? OR t.id IN (SELECT tag_id FROM tag_aliases WHERE alias = ?)
+		)`)
+		tag := entities.NormalizeTagName(query.Tag)
+		args = append(args, tag, tag)
+	}
+
+	if query.Favorited != "" {
+		whereParts = append(whereParts, `a.id IN (
+			SELECT f.article_id FROM favorites f
+			JOIN users u ON u.id = f.user_id
+			LEFT JOIN user_settings s ON s.user_id = u.id
+			WHERE u.username = ? AND (u.id = ? OR `+listedFavoriter+`)
+		)`)
+		args = append(args, query.Favorited, query.ViewerID)
+	}
+
+	if query.FollowedBy != 0 {
+		whereParts = append(whereParts, "a.author_id IN (SELECT following_id FROM follows WHERE follower_id = ?)")
+		args = append(args, query.FollowedBy)
+	}
+
+	if query.Featured {
+		whereParts = append(whereParts, "a.featured_at IS NOT NULL")
+	}
+
+	// Creation times are stored in local time, so bounds are compared in it too
+	if !query.Since.IsZero() {
+		whereParts = append(whereParts, "a.created_at >= ?")
+		args = append(args, query.Since.Local())
+	}
+	if !query.Until.IsZero() {
+		whereParts = append(whereParts, "a.created_at < ?")
+		args = append(args, query.Until.Local())
 	}
 
 	whereClause := ""
@@ -314,15 +483,31 @@ func (r *articleRepository) List(query *entities.ArticleListQuery) ([]entities.A
 		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
 	}
 
-	// Get articles
+	// Get articles; review queues are worked oldest first and editor's picks
+	// and pins follow their positions unless another order is asked for
+	order := "a.created_at DESC"
+	if status != entities.ArticleStatusPublished {
+		order = "a.created_at ASC"
+	} else if query.Sort == entities.ArticleSortActivity {
+		order = "a.last_comment_at IS NULL, a.last_comment_at DESC, a.created_at DESC"
+	} else if query.Featured {
+		order = "a.featured_position ASC, a.featured_at DESC"
+	} else if query.Author != "" {
+		// Profile listings lead with the author's pins
+		order = "a.pinned_at IS NULL, a.pin_position ASC, a.created_at DESC"
+	}
+	body := "a.body"
+	if query.ExcludeBody {
+		body = "''"
+	}
 	articlesQuery := fmt.Sprintf(`
-		SELECT a.id, a.slug, a.title, a.description, a.body, a.author_id, a.favorites_count, a.created_at, a.updated_at
+		SELECT a.id, a.slug, a.title, a.description, %s, a.language, a.translation_of, a.author_id, a.favorites_count, a.claps_count, a.comments_count, a.last_comment_at, a.created_at, a.updated_at, a.status, a.review_note, a.featured_at IS NOT NULL, a.featured_note, a.pinned_at IS NOT NULL, a.comments_locked_at IS NOT NULL, a.noindex
 		FROM articles a
 		JOIN users u ON a.author_id = u.id
 		%s
-		ORDER BY a.created_at DESC
+		ORDER BY %s
 		LIMIT ? OFFSET ?
-	`, whereClause)
+	`, body, whereClause, order)
 
 	// Add limit and offset to args
 	queryArgs := append(args, query.Limit, query.Offset)
@@ -342,26 +527,44 @@ func (r *articleRepository) List(query *entities.ArticleListQuery) ([]entities.A
 			&article.Title,
 			&article.Description,
 			&article.Body,
+			&article.Language,
+			&article.TranslationOf,
 			&article.AuthorID,
 			&article.FavoritesCount,
+			&article.ClapsCount,
+			&article.CommentsCount,
+			&article.LastCommentAt,
 			&article.CreatedAt,
 			&article.UpdatedAt,
+			&article.Status,
+			&article.ReviewNote,
+			&article.Featured,
+			&article.FeaturedNote,
+			&article.Pinned,
+			&article.CommentsLocked,
+			&article.NoIndex,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan article: %w", err)
 		}
 
-		// Load author information
-		if err := r.loadAuthor(&article); err != nil {
-			return nil, 0, fmt.Errorf("failed to load author: %w", err)
-		}
-
 		articles = append(articles, article)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, 0, fmt.Errorf("failed to iterate over articles: %w", err)
 	}
+	rows.Close()
+
+	// Load author information once the rows are released (SQLite uses a single connection)
+	for i := range articles {
+		if err := r.loadAuthor(&articles[i]); err != nil {
+			return nil, 0, fmt.Errorf("failed to load author: %w", err)
+		}
+		if err := r.loadTags(&articles[i]); err != nil {
+			return nil, 0, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
 
 	return articles, totalCount, nil
 }
@@ -441,6 +644,318 @@ func (r *articleRepository) IsAuthor(articleID, userID int64) (bool, error) {
 	return authorID == userID, nil
 }
 
+// CreateTranslation creates a language variant linked to the source article's translation group
+func (r *articleRepository) CreateTranslation(source *entities.Article, translation *entities.ArticleTranslationCreate) (*entities.Article, error) {
+	// Translations always point at the original article, never at another translation
+	groupID := source.ID
+	if source.TranslationOf != nil {
+		groupID = *source.TranslationOf
+	}
+
+	language := entities.NormalizeLanguageCode(translation.Language)
+
+	baseSlug := entities.GenerateSlug(translation.Title)
+	if baseSlug == "" {
+		return nil, fmt.Errorf("failed to generate slug from title")
+	}
+
+	// Prefer a language suffix over a numeric one when the translated title keeps the same slug
+	taken, err := r.SlugExists(baseSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing slugs: %w", err)
+	}
+	if taken {
+		baseSlug = baseSlug + "-" + strings.ToLower(language)
+	}
+
+	existingSlugs, err := r.GetExistingSlugs(baseSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing slugs: %w", err)
+	}
+	uniqueSlug := entities.EnsureUniqueSlug(baseSlug, existingSlugs)
+
+	// Translations share the review state and crawler setting of their group
+	status := source.Status
+	if status == "" {
+		status = entities.ArticleStatusPublished
+	}
+
+	now := time.Now()
+
+	query := `
+		INSERT INTO articles (slug, title, description, body, language, translation_of, author_id, status, noindex, favorites_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?)
+		RETURNING id, slug, title, description, body, language, translation_of, author_id, favorites_count, claps_count, comments_count, last_comment_at, created_at, updated_at, status, review_note, featured_at IS NOT NULL, featured_note, pinned_at IS NOT NULL, comments_locked_at IS NOT NULL, noindex
+	`
+
+	article := &entities.Article{}
+	err = r.db.QueryRow(query,
+		uniqueSlug,
+		translation.Title,
+		translation.Description,
+		translation.Body,
+		language,
+		groupID,
+		source.AuthorID,
+		status,
+		source.NoIndex,
+		now,
+		now,
+	).Scan(
+		&article.ID,
+		&article.Slug,
+		&article.Title,
+		&article.Description,
+		&article.Body,
+		&article.Language,
+		&article.TranslationOf,
+		&article.AuthorID,
+		&article.FavoritesCount,
+		&article.ClapsCount,
+		&article.CommentsCount,
+		&article.LastCommentAt,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&article.Status,
+		&article.ReviewNote,
+		&article.Featured,
+		&article.FeaturedNote,
+		&article.Pinned,
+		&article.CommentsLocked,
+		&article.NoIndex,
+	)
+
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, fmt.Errorf("translation for this language already exists")
+		}
+		return nil, fmt.Errorf("failed to create translation: %w", err)
+	}
+
+	// Load author information
+	if err := r.loadAuthor(article); err != nil {
+		return nil, fmt.Errorf("failed to load author: %w", err)
+	}
+	if err := r.loadTags(article); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+
+	return article, nil
+}
+
+// GetTranslations returns the other language variants in an article's translation group
+func (r *articleRepository) GetTranslations(article *entities.Article) ([]entities.ArticleTranslation, error) {
+	groupID := article.ID
+	if article.TranslationOf != nil {
+		groupID = *article.TranslationOf
+	}
+
+	query := `
+		SELECT language, slug, title
+		FROM articles
+		WHERE (id = ? OR translation_of = ?) AND id != ?
+		ORDER BY language
+	`
+
+	rows, err := r.db.Query(query, groupID, groupID, article.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []entities.ArticleTranslation{}
+	for rows.Next() {
+		var translation entities.ArticleTranslation
+		if err := rows.Scan(&translation.Language, &translation.Slug, &translation.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		translations = append(translations, translation)
+	}
+
+	return translations, rows.Err()
+}
+
+// Review records a reviewer's decision on an article and its translations
+func (r *articleRepository) Review(id int64, status string, reviewerID int64, note string) (*entities.Article, error) {
+	query := `
+		UPDATE articles
+		SET status = ?, reviewed_by = ?, reviewed_at = ?, review_note = ?
+		WHERE id = ? OR translation_of = ?
+	`
+
+	result, err := r.db.Exec(query, status, reviewerID, time.Now(), note, id, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review article: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("article not found")
+	}
+
+	return r.GetByID(id)
+}
+
+// CountByAuthor counts the author's articles in the given review state,
+// not counting translations
+func (r *articleRepository) CountByAuthor(authorID int64, status string) (int, error) {
+	query := "SELECT COUNT(*) FROM articles WHERE author_id = ? AND status = ? AND translation_of IS NULL"
+
+	var count int
+	if err := r.db.QueryRow(query, authorID, status).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count articles: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListRecentTimesByAuthor returns creation times of the author's articles,
+// translations included, since the given time, oldest first
+func (r *articleRepository) ListRecentTimesByAuthor(authorID int64, since time.Time) ([]time.Time, error) {
+	query := `
+		SELECT created_at
+		FROM articles
+		WHERE author_id = ? AND created_at > ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, authorID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent articles: %w", err)
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var createdAt time.Time
+		if err := rows.Scan(&createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan article time: %w", err)
+		}
+		times = append(times, createdAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over recent articles: %w", err)
+	}
+
+	return times, nil
+}
+
+// Feature makes an article an editor's pick, or updates the note and position
+// of one that already is
+func (r *articleRepository) Feature(id, featuredBy int64, feature *entities.ArticleFeature) error {
+	query := `
+		UPDATE articles
+		SET featured_at = COALESCE(featured_at, ?), featured_by = ?, featured_note = ?, featured_position = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(query, time.Now(), featuredBy, feature.Note, feature.Position, id)
+	if err != nil {
+		return fmt.Errorf("failed to feature article: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("article not found")
+	}
+
+	return nil
+}
+
+// Unfeature removes an article from the editor's picks
+func (r *articleRepository) Unfeature(id int64) error {
+	query := `
+		UPDATE articles
+		SET featured_at = NULL, featured_by = NULL, featured_note = '', featured_position = 0
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to unfeature article: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("article not found")
+	}
+
+	return nil
+}
+
+// Pin pins an article after the author's existing pins; it fails with "pin
+// limit reached" if the author already has limit pinned articles. Pinning an
+// already pinned article changes nothing.
+func (r *articleRepository) Pin(id, authorID int64, limit int) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		var pinned bool
+		if err := tx.QueryRow("SELECT pinned_at IS NOT NULL FROM articles WHERE id = ?", id).Scan(&pinned); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("article not found")
+			}
+			return fmt.Errorf("failed to get article: %w", err)
+		}
+		if pinned {
+			return nil
+		}
+
+		var count, lastPosition int
+		err := tx.QueryRow(`
+			SELECT COUNT(*), COALESCE(MAX(pin_position), 0)
+			FROM articles
+			WHERE author_id = ? AND pinned_at IS NOT NULL
+		`, authorID).Scan(&count, &lastPosition)
+		if err != nil {
+			return fmt.Errorf("failed to count pinned articles: %w", err)
+		}
+		if count >= limit {
+			return fmt.Errorf("pin limit reached")
+		}
+
+		if _, err := tx.Exec("UPDATE articles SET pinned_at = ?, pin_position = ? WHERE id = ?", time.Now(), lastPosition+1, id); err != nil {
+			return fmt.Errorf("failed to pin article: %w", err)
+		}
+		return nil
+	})
+}
+
+// Unpin removes an article from its author's pins
+func (r *articleRepository) Unpin(id int64) error {
+	result, err := r.db.Exec("UPDATE articles SET pinned_at = NULL, pin_position = 0 WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to unpin article: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("article not found")
+	}
+
+	return nil
+}
+
+// SetCommentsLocked closes or reopens comments on an article
+func (r *articleRepository) SetCommentsLocked(id int64, locked bool) error {
+	// Locking twice keeps the original lock time
+	query, args := "UPDATE articles SET comments_locked_at = NULL WHERE id = ?", []interface{}{id}
+	if locked {
+		query, args = "UPDATE articles SET comments_locked_at = COALESCE(comments_locked_at, ?) WHERE id = ?", []interface{}{time.Now(), id}
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update comment lock: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("article not found")
+	}
+
+	return nil
+}
+
 // loadAuthor loads author information for an article
 func (r *articleRepository) loadAuthor(article *entities.Article) error {
 	author, err := r.userRepo.GetByID(article.AuthorID)
@@ -453,12 +968,67 @@ func (r *articleRepository) loadAuthor(article *entities.Article) error {
 		ID:       author.ID,
 		Username: author.Username,
 		Bio:      author.Bio,
-		ImageURL: author.ImageURL,
+		ImageURL: author.AvatarURL(),
 	}
 
 	return nil
 }
 
+// loadTags loads the canonical tag names of an article
+func (r *articleRepository) loadTags(article *entities.Article) error {
+	rows, err := r.db.Query(`
+		SELECT t.name
+		FROM article_tags at
+		JOIN tags t ON t.id = at.tag_id
+		WHERE at.article_id = ?
+		ORDER BY t.name ASC
+	`, article.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	article.TagList = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		article.TagList = append(article.TagList, name)
+	}
+	return rows.Err()
+}
+
+// setTags replaces an article's tags within tx. Names are normalized, aliases
+// resolve to their canonical tag, and tags that don't exist yet are created.
+func setTags(tx *sql.Tx, articleID int64, names []string) error {
+	if _, err := tx.Exec("DELETE FROM article_tags WHERE article_id = ?", articleID); err != nil {
+		return fmt.Errorf("failed to set tags: %w", err)
+	}
+
+	for _, name := range names {
+		name = entities.NormalizeTagName(name)
+
+		var tagID int64
+		err := tx.QueryRow("SELECT tag_id FROM tag_aliases WHERE alias = ?", name).Scan(&tagID)
+		if err == sql.ErrNoRows {
+			if _, err := tx.Exec("INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING", name); err != nil {
+				return fmt.Errorf("failed to set tags: %w", err)
+			}
+			err = tx.QueryRow("SELECT id FROM tags WHERE name = ?", name).Scan(&tagID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set tags: %w", err)
+		}
+
+		// Duplicates, including an alias sent next to its tag, collapse into one row
+		if _, err := tx.Exec("INSERT OR IGNORE INTO article_tags (article_id, tag_id) VALUES (?, ?)", articleID, tagID); err != nil {
+			return fmt.Errorf("failed to set tags: %w", err)
+		}
+	}
+	return nil
+}
+
 // Helper functions
 
 // isUniqueConstraintError checks if the error is a unique constraint violation
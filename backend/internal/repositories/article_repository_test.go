@@ -0,0 +1,617 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestArticleRepository_ListFeaturedInPickOrder(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "editor",
+		Email:    "editor@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	articles := make(map[string]*entities.Article)
+	for _, title := range []string{"First", "Second", "Third"} {
+		article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+			Title:       title,
+			Description: "Test description",
+			Body:        "Test body",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article %s: %v", title, err)
+		}
+		articles[title] = article
+	}
+
+	if err := articleRepo.Feature(articles["First"].ID, user.ID, &entities.ArticleFeature{Position: 2}); err != nil {
+		t.Fatalf("Failed to feature article: %v", err)
+	}
+	if err := articleRepo.Feature(articles["Third"].ID, user.ID, &entities.ArticleFeature{Note: "Must read", Position: 1}); err != nil {
+		t.Fatalf("Failed to feature article: %v", err)
+	}
+	if err := articleRepo.Feature(999, user.ID, &entities.ArticleFeature{}); err == nil {
+		t.Error("Expected featuring a missing article to fail")
+	}
+
+	picks, total, err := articleRepo.List(&entities.ArticleListQuery{Featured: true})
+	if err != nil {
+		t.Fatalf("Failed to list picks: %v", err)
+	}
+	if total != 2 || len(picks) != 2 {
+		t.Fatalf("List = %d picks of %d, want 2 of 2", len(picks), total)
+	}
+	if picks[0].Slug != articles["Third"].Slug || picks[0].FeaturedNote != "Must read" || !picks[0].Featured {
+		t.Errorf("First pick = %+v, want Third with its note", picks[0])
+	}
+
+	if err := articleRepo.Unfeature(articles["Third"].ID); err != nil {
+		t.Fatalf("Failed to unfeature article: %v", err)
+	}
+	stored, err := articleRepo.GetByID(articles["Third"].ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if stored.Featured || stored.FeaturedNote != "" {
+		t.Errorf("Unfeatured article = featured %v, note %q", stored.Featured, stored.FeaturedNote)
+	}
+}
+
+func TestArticleRepository_PinsLeadAuthorListing(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	var articles []*entities.Article
+	for _, title := range []string{"Oldest", "Middle", "Newest"} {
+		article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+			Title:       title,
+			Description: "Test description",
+			Body:        "Test body",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article %s: %v", title, err)
+		}
+		articles = append(articles, article)
+	}
+
+	for _, article := range []*entities.Article{articles[1], articles[0]} {
+		if err := articleRepo.Pin(article.ID, user.ID, 2); err != nil {
+			t.Fatalf("Failed to pin %s: %v", article.Slug, err)
+		}
+	}
+	if err := articleRepo.Pin(articles[0].ID, user.ID, 2); err != nil {
+		t.Errorf("Pinning a pinned article should change nothing, got %v", err)
+	}
+	if err := articleRepo.Pin(articles[2].ID, user.ID, 2); err == nil || err.Error() != "pin limit reached" {
+		t.Errorf("Expected the pin limit to be enforced, got %v", err)
+	}
+
+	listed, _, err := articleRepo.List(&entities.ArticleListQuery{Author: "author"})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	var order []string
+	for _, article := range listed {
+		order = append(order, article.Slug)
+	}
+	want := []string{articles[1].Slug, articles[0].Slug, articles[2].Slug}
+	if len(order) != 3 || order[0] != want[0] || order[1] != want[1] || order[2] != want[2] {
+		t.Errorf("Listing order = %v, want pins first in pin order: %v", order, want)
+	}
+	if !listed[0].Pinned || listed[2].Pinned {
+		t.Errorf("Pinned flags = %v, %v; want true, false", listed[0].Pinned, listed[2].Pinned)
+	}
+
+	if err := articleRepo.Unpin(articles[1].ID); err != nil {
+		t.Fatalf("Failed to unpin: %v", err)
+	}
+	if err := articleRepo.Pin(articles[2].ID, user.ID, 2); err != nil {
+		t.Errorf("Unpinning should free a pin slot, got %v", err)
+	}
+}
+
+func TestArticleRepository_SetCommentsLocked(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if article.CommentsLocked {
+		t.Error("New articles should accept comments")
+	}
+
+	for _, locked := range []bool{true, true, false} {
+		if err := articleRepo.SetCommentsLocked(article.ID, locked); err != nil {
+			t.Fatalf("SetCommentsLocked(%v) failed: %v", locked, err)
+		}
+		stored, err := articleRepo.GetBySlug(article.Slug)
+		if err != nil {
+			t.Fatalf("Failed to get article: %v", err)
+		}
+		if stored.CommentsLocked != locked {
+			t.Errorf("CommentsLocked = %v, want %v", stored.CommentsLocked, locked)
+		}
+	}
+
+	if err := articleRepo.SetCommentsLocked(article.ID+100, true); err == nil {
+		t.Error("Locking a missing article should fail")
+	}
+}
+
+func TestArticleRepository_NoIndexFollowsTranslations(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+		NoIndex:     true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if !article.NoIndex || article.Indexable() {
+		t.Error("Expected the article to be kept out of search engines")
+	}
+
+	translation, err := articleRepo.CreateTranslation(article, &entities.ArticleTranslationCreate{
+		Language:    "ko",
+		Title:       "Test Article KO",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create translation: %v", err)
+	}
+	if !translation.NoIndex {
+		t.Error("Expected the translation to inherit noindex")
+	}
+
+	index := false
+	updated, err := articleRepo.Update(article.ID, &entities.ArticleUpdate{NoIndex: &index})
+	if err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+	if updated.NoIndex {
+		t.Error("Expected noindex to be cleared")
+	}
+}
+
+func TestArticleRepository_TagListResolvesAliases(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	tagRepo := NewTagRepository(db, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "tagger",
+		Email:    "tagger@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Tagged",
+		Description: "Test description",
+		Body:        "Test body",
+		TagList:     []string{"Go", "testing"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	if len(article.TagList) != 2 || article.TagList[0] != "go" || article.TagList[1] != "testing" {
+		t.Errorf("Expected tags [go testing], got %v", article.TagList)
+	}
+
+	if _, err := tagRepo.AddAlias("go", "golang"); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+
+	tags := []string{"golang", "go", "sqlite"}
+	updated, err := articleRepo.Update(article.ID, &entities.ArticleUpdate{TagList: &tags})
+	if err != nil {
+		t.Fatalf("Failed to update tags: %v", err)
+	}
+	if len(updated.TagList) != 2 || updated.TagList[0] != "go" || updated.TagList[1] != "sqlite" {
+		t.Errorf("Expected tags [go sqlite], got %v", updated.TagList)
+	}
+
+	listed, total, err := articleRepo.List(&entities.ArticleListQuery{Limit: 20, Tag: "sqlite"})
+	if err != nil {
+		t.Fatalf("Failed to list by tag: %v", err)
+	}
+	if total != 1 || len(listed) != 1 || len(listed[0].TagList) != 2 {
+		t.Errorf("Expected the article with its tags under sqlite, got %d %v", total, listed)
+	}
+
+	cleared, err := articleRepo.Update(article.ID, &entities.ArticleUpdate{NullFields: []string{"tagList"}})
+	if err != nil {
+		t.Fatalf("Failed to clear tags: %v", err)
+	}
+	if cleared.TagList == nil || len(cleared.TagList) != 0 {
+		t.Errorf("Expected an empty tag list, got %v", cleared.TagList)
+	}
+}
+
+func TestArticleRepository_ListFavoritedBy(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	favoriteRepo := NewFavoriteRepository(db)
+	settingsRepo := NewSettingsRepository(db)
+
+	users := createTestUsers(t, userRepo, "author", "alice", "bob")
+
+	var articles []*entities.Article
+	for _, title := range []string{"First", "Second", "Third"} {
+		article, err := articleRepo.Create(users["author"].ID, &entities.ArticleCreate{
+			Title:       title,
+			Description: "Test description",
+			Body:        "Test body",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		articles = append(articles, article)
+	}
+
+	favorites := map[string][]*entities.Article{
+		"alice": {articles[0], articles[2]},
+		"bob":   {articles[1]},
+	}
+	for name, favorited := range favorites {
+		for _, article := range favorited {
+			if _, err := favoriteRepo.Favorite(users[name].ID, article.ID); err != nil {
+				t.Fatalf("Failed to favorite: %v", err)
+			}
+		}
+	}
+
+	hidden := false
+	if _, err := settingsRepo.Update(users["bob"].ID, &entities.UserSettingsUpdate{ShowFavorites: &hidden}); err != nil {
+		t.Fatalf("Failed to update settings: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		favorited string
+		viewerID  int64
+		want      int
+	}{
+		{"Public favorites", "alice", 0, 2},
+		{"Hidden favorites", "bob", 0, 0},
+		{"Own hidden favorites", "bob", users["bob"].ID, 1},
+		{"Unknown user", "nobody", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed, count, err := articleRepo.List(&entities.ArticleListQuery{Favorited: tt.favorited, ViewerID: tt.viewerID})
+			if err != nil {
+				t.Fatalf("Failed to list articles: %v", err)
+			}
+			if count != tt.want || len(listed) != tt.want {
+				t.Errorf("Expected %d articles, got %d (count %d)", tt.want, len(listed), count)
+			}
+		})
+	}
+}
+
+func TestArticleRepository_ListExcludeBody(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if _, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Long Read",
+		Description: "Test description",
+		Body:        "A very long body",
+		TagList:     []string{"go"},
+	}); err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	full, _, err := articleRepo.List(&entities.ArticleListQuery{})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(full) != 1 || full[0].Body != "A very long body" {
+		t.Fatalf("Expected the body in full listings, got %+v", full)
+	}
+
+	summaries, _, err := articleRepo.List(&entities.ArticleListQuery{ExcludeBody: true})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Body != "" {
+		t.Fatalf("Expected the body to be left out, got %+v", summaries)
+	}
+	if summaries[0].Title != "Long Read" || len(summaries[0].TagList) != 1 || summaries[0].Author == nil {
+		t.Errorf("Expected summaries to keep title, tags and author, got %+v", summaries[0])
+	}
+}
+
+func TestArticleRepository_TagsSavedWithArticle(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Tagged",
+		Description: "Test description",
+		Body:        "Test body",
+		TagList:     []string{"go"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	// Break tag lookups so saving tags fails part way through
+	if _, err := db.Exec("DROP TABLE tag_aliases"); err != nil {
+		t.Fatalf("Failed to drop tag aliases: %v", err)
+	}
+
+	if _, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Half Saved",
+		Description: "Test description",
+		Body:        "Test body",
+		TagList:     []string{"sql"},
+	}); err == nil {
+		t.Fatal("Expected create to fail when tags cannot be saved")
+	}
+	if exists, _ := articleRepo.SlugExists("half-saved"); exists {
+		t.Error("Expected the article to be rolled back with its tags")
+	}
+
+	title := "Renamed"
+	tags := []string{"sql"}
+	if _, err := articleRepo.Update(article.ID, &entities.ArticleUpdate{Title: &title, TagList: &tags}); err == nil {
+		t.Fatal("Expected update to fail when tags cannot be saved")
+	}
+	unchanged, err := articleRepo.GetByID(article.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if unchanged.Title != "Tagged" || len(unchanged.TagList) != 1 || unchanged.TagList[0] != "go" {
+		t.Errorf("Expected the update to be rolled back, got %q %v", unchanged.Title, unchanged.TagList)
+	}
+}
+
+func TestArticleRepository_ListCombinedFilters(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	favoriteRepo := NewFavoriteRepository(db)
+
+	users := createTestUsers(t, userRepo, "ann", "ben", "fan")
+
+	// Articles spread over authors, tags, favorites and creation days
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 12, 0, 0, 0, time.UTC) }
+	seed := []struct {
+		author    string
+		title     string
+		tag       string
+		created   time.Time
+		favorited bool
+	}{
+		{"ann", "Ann Go Early", "go", day(1), true},
+		{"ann", "Ann Go Late", "go", day(20), true},
+		{"ann", "Ann Rust Late", "rust", day(20), true},
+		{"ann", "Ann Go Unloved", "go", day(20), false},
+		{"ben", "Ben Go Late", "go", day(20), true},
+	}
+	for _, s := range seed {
+		article, err := articleRepo.Create(users[s.author].ID, &entities.ArticleCreate{
+			Title:       s.title,
+			Description: "Test description",
+			Body:        "Test body",
+			TagList:     []string{s.tag},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		if _, err := db.Exec("UPDATE articles SET created_at = ? WHERE id = ?", s.created.Local(), article.ID); err != nil {
+			t.Fatalf("Failed to backdate article: %v", err)
+		}
+		if s.favorited {
+			if _, err := favoriteRepo.Favorite(users["fan"].ID, article.ID); err != nil {
+				t.Fatalf("Failed to favorite: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query entities.ArticleListQuery
+		want  []string
+	}{
+		{"Author and tag", entities.ArticleListQuery{Author: "ann", Tag: "go"}, []string{"Ann Go Early", "Ann Go Late", "Ann Go Unloved"}},
+		{"Author, tag and favorited", entities.ArticleListQuery{Author: "ann", Tag: "go", Favorited: "fan"}, []string{"Ann Go Early", "Ann Go Late"}},
+		{"All filters", entities.ArticleListQuery{Author: "ann", Tag: "go", Favorited: "fan", Since: day(10), Until: day(25)}, []string{"Ann Go Late"}},
+		{"Date range only", entities.ArticleListQuery{Until: day(10)}, []string{"Ann Go Early"}},
+		{"Until is exclusive", entities.ArticleListQuery{Tag: "go", Since: day(1), Until: day(20)}, []string{"Ann Go Early"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed, count, err := articleRepo.List(&tt.query)
+			if err != nil {
+				t.Fatalf("Failed to list articles: %v", err)
+			}
+
+			var titles []string
+			for _, article := range listed {
+				titles = append(titles, article.Title)
+			}
+			sort.Strings(titles)
+			if count != len(tt.want) || strings.Join(titles, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v (count %d)", tt.want, titles, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// AttachmentRepository defines the interface for article attachment data operations
+type AttachmentRepository interface {
+	Create(articleID int64, attachment *entities.ArticleAttachmentCreate, limit int) (*entities.ArticleAttachment, error)
+	Get(articleID int64, name string) (*entities.ArticleAttachment, error)
+	ListByArticle(articleID int64) ([]entities.ArticleAttachment, error)
+	Delete(articleID int64, name string) error
+}
+
+// attachmentRepository implements AttachmentRepository using direct SQL
+type attachmentRepository struct {
+	db *database.DB
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *database.DB) AttachmentRepository {
+	return &attachmentRepository{
+		db: db,
+	}
+}
+
+// Create attaches a file to an article unless it already has limit attachments
+func (r *attachmentRepository) Create(articleID int64, attachment *entities.ArticleAttachmentCreate, limit int) (*entities.ArticleAttachment, error) {
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM article_attachments WHERE article_id = ?", articleID).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count attachments: %w", err)
+		}
+		if count >= limit {
+			return fmt.Errorf("attachment limit reached")
+		}
+
+		_, err := tx.Exec(
+			"INSERT INTO article_attachments (article_id, name, content, size, created_at) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)",
+			articleID, attachment.Name, attachment.Content, len(attachment.Content),
+		)
+		if err != nil {
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("attachment already exists")
+			}
+			return fmt.Errorf("failed to create attachment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Get(articleID, attachment.Name)
+}
+
+// Get returns an attachment with its content
+func (r *attachmentRepository) Get(articleID int64, name string) (*entities.ArticleAttachment, error) {
+	query := `
+		SELECT id, article_id, name, size, content, created_at
+		FROM article_attachments
+		WHERE article_id = ? AND name = ?
+	`
+
+	attachment := &entities.ArticleAttachment{}
+	err := r.db.QueryRow(query, articleID, name).Scan(
+		&attachment.ID,
+		&attachment.ArticleID,
+		&attachment.Name,
+		&attachment.Size,
+		&attachment.Content,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("attachment not found")
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListByArticle returns an article's attachments, without content, in the order they were added
+func (r *attachmentRepository) ListByArticle(articleID int64) ([]entities.ArticleAttachment, error) {
+	query := `
+		SELECT id, article_id, name, size, created_at
+		FROM article_attachments
+		WHERE article_id = ?
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []entities.ArticleAttachment{}
+	for rows.Next() {
+		var attachment entities.ArticleAttachment
+		if err := rows.Scan(&attachment.ID, &attachment.ArticleID, &attachment.Name, &attachment.Size, &attachment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// Delete removes an attachment from an article
+func (r *attachmentRepository) Delete(articleID int64, name string) error {
+	result, err := r.db.Exec("DELETE FROM article_attachments WHERE article_id = ? AND name = ?", articleID, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment not found")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestAttachmentRepository_CreateListDelete(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	attachmentRepo := NewAttachmentRepository(db)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	created, err := attachmentRepo.Create(article.ID, &entities.ArticleAttachmentCreate{Name: "main.go", Content: "package main\n"}, 2)
+	if err != nil {
+		t.Fatalf("Failed to create attachment: %v", err)
+	}
+	if created.Size != len("package main\n") || created.Content != "package main\n" {
+		t.Errorf("Unexpected attachment: %+v", created)
+	}
+
+	// Names are unique per article
+	if _, err := attachmentRepo.Create(article.ID, &entities.ArticleAttachmentCreate{Name: "main.go", Content: "x"}, 2); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate name to fail, got %v", err)
+	}
+
+	if _, err := attachmentRepo.Create(article.ID, &entities.ArticleAttachmentCreate{Name: "go.mod", Content: "module demo\n"}, 2); err != nil {
+		t.Fatalf("Failed to create second attachment: %v", err)
+	}
+	if _, err := attachmentRepo.Create(article.ID, &entities.ArticleAttachmentCreate{Name: "extra.txt", Content: "x"}, 2); err == nil || !strings.Contains(err.Error(), "limit reached") {
+		t.Errorf("Expected limit to be enforced, got %v", err)
+	}
+
+	// Listings leave out the content
+	attachments, err := attachmentRepo.ListByArticle(article.ID)
+	if err != nil {
+		t.Fatalf("Failed to list attachments: %v", err)
+	}
+	if len(attachments) != 2 || attachments[0].Name != "main.go" || attachments[1].Name != "go.mod" || attachments[0].Content != "" {
+		t.Errorf("Unexpected attachments: %+v", attachments)
+	}
+
+	if err := attachmentRepo.Delete(article.ID, "main.go"); err != nil {
+		t.Fatalf("Failed to delete attachment: %v", err)
+	}
+	if _, err := attachmentRepo.Get(article.ID, "main.go"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected deleted attachment to be gone, got %v", err)
+	}
+	if err := attachmentRepo.Delete(article.ID, "main.go"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected second delete to report not found, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// BookExportRepository defines the interface for the book export queue
+type BookExportRepository interface {
+	Create(export *entities.BookExport) (*entities.BookExport, error)
+	LatestForSeries(seriesID, userID int64, format string) (*entities.BookExport, error)
+	Get(id int64) (*entities.BookExport, error)
+	Pending(limit int) ([]entities.BookExport, error)
+	Complete(id int64, contentType string, content []byte, at time.Time) error
+	Fail(id int64, message string, at time.Time) error
+	DeleteCreatedBefore(cutoff time.Time) (int64, error)
+}
+
+// bookExportRepository implements BookExportRepository using direct SQL
+type bookExportRepository struct {
+	db *database.DB
+}
+
+// NewBookExportRepository creates a new book export repository
+func NewBookExportRepository(db *database.DB) BookExportRepository {
+	return &bookExportRepository{
+		db: db,
+	}
+}
+
+// bookExportColumns are the columns scanned by scanBookExport, without the content
+const bookExportColumns = `id, user_id, series_id, article_ids, title, format, status, error, content_type, created_at, completed_at`
+
+// Create queues a book export of a series or of the picked articles
+func (r *bookExportRepository) Create(export *entities.BookExport) (*entities.BookExport, error) {
+	query := `
+		INSERT INTO book_exports (user_id, series_id, article_ids, title, format, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	var seriesID sql.NullInt64
+	if export.SeriesID != nil {
+		seriesID = sql.NullInt64{Int64: *export.SeriesID, Valid: true}
+	}
+
+	result, err := r.db.Exec(query, export.UserID, seriesID, formatIDList(export.ArticleIDs),
+		export.Title, export.Format, export.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue book export: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get book export ID: %w", err)
+	}
+	return r.Get(id)
+}
+
+// LatestForSeries returns the user's most recent export of the series to the
+// format, without its content, or nil if they have none
+func (r *bookExportRepository) LatestForSeries(seriesID, userID int64, format string) (*entities.BookExport, error) {
+	query := `
+		SELECT ` + bookExportColumns + `
+		FROM book_exports
+		WHERE series_id = ? AND user_id = ? AND format = ?
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	export, err := scanBookExport(r.db.QueryRow(query, seriesID, userID, format))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return export, err
+}
+
+// Get returns a book export with its content
+func (r *bookExportRepository) Get(id int64) (*entities.BookExport, error) {
+	query := `SELECT ` + bookExportColumns + `, content FROM book_exports WHERE id = ?`
+
+	var content []byte
+	export, err := scanBookExport(r.db.QueryRow(query, id), &content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("book export not found")
+		}
+		return nil, err
+	}
+	export.Content = content
+	return export, nil
+}
+
+// Pending returns up to limit queued book exports, oldest first
+func (r *bookExportRepository) Pending(limit int) ([]entities.BookExport, error) {
+	query := `
+		SELECT ` + bookExportColumns + `
+		FROM book_exports
+		WHERE status = ?
+		ORDER BY id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, entities.ExportStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending book exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []entities.BookExport{}
+	for rows.Next() {
+		export, err := scanBookExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, *export)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate book exports: %w", err)
+	}
+	return exports, nil
+}
+
+// Complete stores the rendered book and marks the export ready
+func (r *bookExportRepository) Complete(id int64, contentType string, content []byte, at time.Time) error {
+	query := `
+		UPDATE book_exports
+		SET status = ?, content_type = ?, content = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.db.Exec(query, entities.ExportStatusReady, contentType, content, at, id); err != nil {
+		return fmt.Errorf("failed to complete book export: %w", err)
+	}
+	return nil
+}
+
+// Fail records why the book could not be rendered
+func (r *bookExportRepository) Fail(id int64, message string, at time.Time) error {
+	query := `
+		UPDATE book_exports
+		SET status = ?, error = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.db.Exec(query, entities.ExportStatusFailed, message, at, id); err != nil {
+		return fmt.Errorf("failed to record book export failure: %w", err)
+	}
+	return nil
+}
+
+// DeleteCreatedBefore removes book exports queued before cutoff, returning how many
+func (r *bookExportRepository) DeleteCreatedBefore(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM book_exports WHERE created_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old book exports: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// scanBookExport reads a book export from a row; extra destinations receive
+// any columns selected after bookExportColumns
+func scanBookExport(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*entities.BookExport, error) {
+	export := &entities.BookExport{}
+	var seriesID sql.NullInt64
+	var articleIDs string
+	var completedAt sql.NullTime
+	dest := append([]interface{}{&export.ID, &export.UserID, &seriesID, &articleIDs, &export.Title, &export.Format,
+		&export.Status, &export.Error, &export.ContentType, &export.CreatedAt, &completedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan book export: %w", err)
+	}
+	if seriesID.Valid {
+		export.SeriesID = &seriesID.Int64
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+
+	ids, err := parseIDList(articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan book export: %w", err)
+	}
+	export.ArticleIDs = ids
+	return export, nil
+}
+
+// formatIDList stores IDs in order as a comma-separated list
+func formatIDList(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseIDList reads a list written by formatIDList
+func parseIDList(value string) ([]int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	ids := make([]int64, len(parts))
+	for i, part := range parts {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID list %q", value)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestBookExportRepository_Lifecycle(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	seriesRepo := NewSeriesRepository(db)
+	exportRepo := NewBookExportRepository(db)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "reader",
+		Email:    "reader@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	series, err := seriesRepo.Create(user.ID, &entities.SeriesCreate{Title: "Learning Go"})
+	if err != nil {
+		t.Fatalf("Failed to create series: %v", err)
+	}
+
+	if latest, err := exportRepo.LatestForSeries(series.ID, user.ID, entities.ExportFormatEPUB); err != nil || latest != nil {
+		t.Fatalf("Expected no export yet, got %+v (err: %v)", latest, err)
+	}
+
+	picked, err := exportRepo.Create(&entities.BookExport{
+		UserID:     user.ID,
+		ArticleIDs: []int64{3, 1, 2},
+		Title:      "Collected",
+		Format:     entities.ExportFormatEPUB,
+		CreatedAt:  time.Now().UTC().Add(-48 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Failed to queue export: %v", err)
+	}
+	if picked.SeriesID != nil || len(picked.ArticleIDs) != 3 || picked.ArticleIDs[0] != 3 || picked.ArticleIDs[2] != 2 {
+		t.Errorf("Expected the picked articles in order, got %+v", picked)
+	}
+
+	book, err := exportRepo.Create(&entities.BookExport{
+		UserID:    user.ID,
+		SeriesID:  &series.ID,
+		Title:     series.Title,
+		Format:    entities.ExportFormatEPUB,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to queue export: %v", err)
+	}
+	if book.Status != entities.ExportStatusPending || book.SeriesID == nil || *book.SeriesID != series.ID || book.ArticleIDs != nil {
+		t.Errorf("Expected a pending series export, got %+v", book)
+	}
+
+	pending, err := exportRepo.Pending(10)
+	if err != nil || len(pending) != 2 || pending[0].ID != picked.ID {
+		t.Fatalf("Expected both exports pending oldest first, got %+v (err: %v)", pending, err)
+	}
+
+	if err := exportRepo.Complete(book.ID, "application/epub+zip", []byte("PK"), time.Now().UTC()); err != nil {
+		t.Fatalf("Failed to complete export: %v", err)
+	}
+	ready, err := exportRepo.Get(book.ID)
+	if err != nil {
+		t.Fatalf("Failed to get export: %v", err)
+	}
+	if ready.Status != entities.ExportStatusReady || string(ready.Content) != "PK" || ready.CompletedAt == nil {
+		t.Errorf("Expected the rendered export, got %+v", ready)
+	}
+	if latest, err := exportRepo.LatestForSeries(series.ID, user.ID, entities.ExportFormatEPUB); err != nil || latest.ID != book.ID || latest.Content != nil {
+		t.Errorf("Expected the latest series export without content, got %+v (err: %v)", latest, err)
+	}
+
+	deleted, err := exportRepo.DeleteCreatedBefore(time.Now().UTC().Add(-24 * time.Hour))
+	if err != nil || deleted != 1 {
+		t.Errorf("Expected the old export to be deleted, got %d (err: %v)", deleted, err)
+	}
+	if _, err := exportRepo.Get(picked.ID); err == nil || err.Error() != "book export not found" {
+		t.Errorf("Expected book export not found, got %v", err)
+	}
+
+	// Deleting the series removes its exports
+	if err := seriesRepo.Delete(series.ID); err != nil {
+		t.Fatalf("Failed to delete series: %v", err)
+	}
+	if _, err := exportRepo.Get(book.ID); err == nil {
+		t.Error("Expected the series export to be deleted with the series")
+	}
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+)
+
+// ClapRepository defines the interface for article clap operations
+type ClapRepository interface {
+	Clap(userID, articleID int64, count, limit int, key string) (int, error)
+}
+
+// clapRepository implements ClapRepository using direct SQL
+type clapRepository struct {
+	db *database.DB
+}
+
+// NewClapRepository creates a new clap repository
+func NewClapRepository(db *database.DB) ClapRepository {
+	return &clapRepository{
+		db: db,
+	}
+}
+
+// Clap adds up to count claps from the user, stopping at limit claps in
+// total, and bumps the article's count by the claps actually added. A
+// non-empty key matching the user's previous clap on the article is a retry
+// and adds nothing. It returns the user's clap total for the article.
+func (r *clapRepository) Clap(userID, articleID int64, count, limit int, key string) (int, error) {
+	total := 0
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		var lastKey string
+		err := tx.QueryRow("SELECT count, last_key FROM article_claps WHERE article_id = ? AND user_id = ?", articleID, userID).Scan(&total, &lastKey)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to get claps: %w", err)
+		}
+		if key != "" && key == lastKey {
+			return nil
+		}
+
+		added := count
+		if total+added > limit {
+			added = limit - total
+		}
+		if added <= 0 {
+			return nil
+		}
+		total += added
+
+		now := time.Now()
+		if _, err := tx.Exec(`
+			INSERT INTO article_claps (article_id, user_id, count, last_key, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (article_id, user_id) DO UPDATE SET count = excluded.count, last_key = excluded.last_key, updated_at = excluded.updated_at
+		`, articleID, userID, total, key, now, now); err != nil {
+			return fmt.Errorf("failed to record claps: %w", err)
+		}
+
+		if _, err := tx.Exec("UPDATE articles SET claps_count = claps_count + ? WHERE id = ?", added, articleID); err != nil {
+			return fmt.Errorf("failed to update claps count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestClapRepository_ClapCapsAndRetries(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	clapRepo := NewClapRepository(db)
+
+	users := createTestUsers(t, userRepo, "author", "alice", "bob")
+
+	article, err := articleRepo.Create(users["author"].ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	tests := []struct {
+		user  string
+		count int
+		key   string
+		want  int
+	}{
+		{"alice", 5, "k1", 5},
+		{"alice", 5, "k1", 5}, // retry
+		{"alice", 5, "k2", 10},
+		{"alice", 5, "", 12}, // capped
+		{"alice", 1, "", 12},
+		{"bob", 3, "k1", 3}, // keys are per user
+	}
+	for _, tt := range tests {
+		got, err := clapRepo.Clap(users[tt.user].ID, article.ID, tt.count, 12, tt.key)
+		if err != nil {
+			t.Fatalf("Clap(%s, %d, %q) failed: %v", tt.user, tt.count, tt.key, err)
+		}
+		if got != tt.want {
+			t.Errorf("Clap(%s, %d, %q) = %d, want %d", tt.user, tt.count, tt.key, got, tt.want)
+		}
+	}
+
+	stored, err := articleRepo.GetByID(article.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if stored.ClapsCount != 15 {
+		t.Errorf("ClapsCount = %d, want 15", stored.ClapsCount)
+	}
+}
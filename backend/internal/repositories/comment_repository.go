@@ -14,8 +14,11 @@ type CommentRepository interface {
 	Create(authorID, articleID int64, comment *entities.CommentCreate) (*entities.Comment, error)
 	GetByArticleSlug(slug string) ([]entities.Comment, error)
 	GetByID(id int64) (*entities.Comment, error)
+	Update(id int64, update *entities.CommentUpdate) (*entities.Comment, error)
 	Delete(id int64) error
+	SoftDelete(id int64) error
 	IsAuthor(commentID, userID int64) (bool, error)
+	ListRecentTimesByAuthor(authorID int64, since time.Time) ([]time.Time, error)
 }
 
 // commentRepository implements CommentRepository using direct SQL
@@ -32,34 +35,46 @@ func NewCommentRepository(db *database.DB, userRepo UserRepository) CommentRepos
 	}
 }
 
-// Create creates a new comment
+// Create creates a new comment and bumps the article's comment count and
+// activity time in the same transaction
 func (r *commentRepository) Create(authorID, articleID int64, commentCreate *entities.CommentCreate) (*entities.Comment, error) {
 	now := time.Now()
 
 	query := `
-		INSERT INTO comments (body, author_id, article_id, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
-		RETURNING id, body, author_id, article_id, created_at, updated_at
+		INSERT INTO comments (body, author_id, article_id, parent_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id, body, author_id, article_id, parent_id, created_at, updated_at
 	`
 
 	comment := &entities.Comment{}
-	err := r.db.QueryRow(query,
-		commentCreate.Body,
-		authorID,
-		articleID,
-		now,
-		now,
-	).Scan(
-		&comment.ID,
-		&comment.Body,
-		&comment.AuthorID,
-		&comment.ArticleID,
-		&comment.CreatedAt,
-		&comment.UpdatedAt,
-	)
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		err := tx.QueryRow(query,
+			commentCreate.Body,
+			authorID,
+			articleID,
+			commentCreate.ParentID,
+			now,
+			now,
+		).Scan(
+			&comment.ID,
+			&comment.Body,
+			&comment.AuthorID,
+			&comment.ArticleID,
+			&comment.ParentID,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
 
+		if _, err := tx.Exec("UPDATE articles SET comments_count = comments_count + 1, last_comment_at = ? WHERE id = ?", now, articleID); err != nil {
+			return fmt.Errorf("failed to update comments count: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+		return nil, err
 	}
 
 	// Load author information
@@ -67,16 +82,20 @@ func (r *commentRepository) Create(authorID, articleID int64, commentCreate *ent
 		return nil, fmt.Errorf("failed to load author: %w", err)
 	}
 
+	if err := r.loadArticleAuthorLabel(comment); err != nil {
+		return nil, err
+	}
+
 	return comment, nil
 }
 
 // GetByArticleSlug retrieves all comments for an article by slug
 func (r *commentRepository) GetByArticleSlug(slug string) ([]entities.Comment, error) {
 	query := `
-		SELECT c.id, c.body, c.author_id, c.article_id, c.created_at, c.updated_at
+		SELECT c.id, c.body, c.author_id, c.article_id, c.parent_id, c.created_at, c.updated_at, c.deleted_at, c.author_id = a.author_id
 		FROM comments c
 		JOIN articles a ON c.article_id = a.id
-		WHERE a.slug = ?
+		WHERE a.slug = ? AND ` + activeUser("c.author_id") + `
 		ORDER BY c.created_at ASC
 	`
 
@@ -94,32 +113,41 @@ func (r *commentRepository) GetByArticleSlug(slug string) ([]entities.Comment, e
 			&comment.Body,
 			&comment.AuthorID,
 			&comment.ArticleID,
+			&comment.ParentID,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.IsArticleAuthor,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
 
-		// Load author information
-		if err := r.loadAuthor(&comment); err != nil {
-			return nil, fmt.Errorf("failed to load author: %w", err)
-		}
-
 		comments = append(comments, comment)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("failed to iterate over comments: %w", err)
 	}
+	rows.Close()
+
+	// Load author information once the rows are released (SQLite uses a single connection)
+	for i := range comments {
+		if comments[i].DeletedAt != nil {
+			redactDeletedComment(&comments[i])
+			continue
+		}
+		if err := r.loadAuthor(&comments[i]); err != nil {
+			return nil, fmt.Errorf("failed to load author: %w", err)
+		}
+	}
 
 	return comments, nil
 }
 
-// GetByID retrieves a comment by ID
 func (r *commentRepository) GetByID(id int64) (*entities.Comment, error) {
 	query := `
-		SELECT id, body, author_id, article_id, created_at, updated_at
+		SELECT id, body, author_id, article_id, parent_id, created_at, updated_at, deleted_at
 		FROM comments 
 		WHERE id = ?
 	`
@@ -130,8 +158,10 @@ func (r *commentRepository) GetByID(id int64) (*entities.Comment, error) {
 		&comment.Body,
 		&comment.AuthorID,
 		&comment.ArticleID,
+		&comment.ParentID,
 		&comment.CreatedAt,
 		&comment.UpdatedAt,
+		&comment.DeletedAt,
 	)
 
 	if err != nil {
@@ -141,33 +171,91 @@ func (r *commentRepository) GetByID(id int64) (*entities.Comment, error) {
 		return nil, fmt.Errorf("failed to get comment by ID: %w", err)
 	}
 
+	if comment.DeletedAt != nil {
+		redactDeletedComment(comment)
+		return comment, nil
+	}
+
 	// Load author information
 	if err := r.loadAuthor(comment); err != nil {
 		return nil, fmt.Errorf("failed to load author: %w", err)
 	}
 
+	if err := r.loadArticleAuthorLabel(comment); err != nil {
+		return nil, err
+	}
+
 	return comment, nil
 }
 
-// Delete deletes a comment
-func (r *commentRepository) Delete(id int64) error {
-	query := "DELETE FROM comments WHERE id = ?"
+// Update replaces the body of a comment; deleted comments can't be edited
+func (r *commentRepository) Update(id int64, update *entities.CommentUpdate) (*entities.Comment, error) {
+	query := "UPDATE comments SET body = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL"
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.Exec(query, update.Body, time.Now(), id)
 	if err != nil {
-		return fmt.Errorf("failed to delete comment: %w", err)
+		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("comment not found")
+		return nil, fmt.Errorf("comment not found")
 	}
 
-	return nil
+	return r.GetByID(id)
+}
+
+// Delete deletes a comment and recounts its article's comments
+func (r *commentRepository) Delete(id int64) error {
+	return r.removeComment(id, "DELETE FROM comments WHERE id = ?", id)
+}
+
+// SoftDelete replaces a comment with a placeholder, keeping the row so replies
+// stay in place; the placeholder no longer counts towards the article
+func (r *commentRepository) SoftDelete(id int64) error {
+	return r.removeComment(id, "UPDATE comments SET body = '', deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
+}
+
+// removeComment runs a delete statement for the comment and recounts its
+// article's comments and last comment time in the same transaction
+func (r *commentRepository) removeComment(id int64, query string, args ...interface{}) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		var articleID int64
+		if err := tx.QueryRow("SELECT article_id FROM comments WHERE id = ?", id).Scan(&articleID); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("comment not found")
+			}
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+
+		result, err := tx.Exec(query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("comment not found")
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE articles SET
+				comments_count = (SELECT COUNT(*) FROM comments WHERE article_id = ? AND deleted_at IS NULL),
+				last_comment_at = (SELECT MAX(created_at) FROM comments WHERE article_id = ? AND deleted_at IS NULL)
+			WHERE id = ?
+		`, articleID, articleID, articleID); err != nil {
+			return fmt.Errorf("failed to update comments count: %w", err)
+		}
+		return nil
+	})
 }
 
 // IsAuthor checks if a user is the author of a comment
@@ -198,8 +286,62 @@ func (r *commentRepository) loadAuthor(comment *entities.Comment) error {
 		ID:       author.ID,
 		Username: author.Username,
 		Bio:      author.Bio,
-		ImageURL: author.ImageURL,
+		ImageURL: author.AvatarURL(),
+	}
+	comment.IsModerator = author.IsModerator()
+
+	return nil
+}
+
+// ListRecentTimesByAuthor returns creation times of the author's comments since the given
+// time, oldest first; deleted comments still count so delete-and-repost cannot dodge limits
+func (r *commentRepository) ListRecentTimesByAuthor(authorID int64, since time.Time) ([]time.Time, error) {
+	query := `
+		SELECT created_at
+		FROM comments
+		WHERE author_id = ? AND created_at > ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, authorID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent comments: %w", err)
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var createdAt time.Time
+		if err := rows.Scan(&createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment time: %w", err)
+		}
+		times = append(times, createdAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over recent comments: %w", err)
+	}
+
+	return times, nil
+}
+
+// redactDeletedComment turns a soft-deleted comment into its public placeholder
+func redactDeletedComment(comment *entities.Comment) {
+	comment.Deleted = true
+	comment.Body = entities.DeletedCommentPlaceholder
+	comment.Author = nil
+	comment.IsArticleAuthor = false
+	comment.IsModerator = false
+}
+
+// loadArticleAuthorLabel marks comments written by the author of the article they belong to
+func (r *commentRepository) loadArticleAuthorLabel(comment *entities.Comment) error {
+	var articleAuthorID int64
+	err := r.db.QueryRow("SELECT author_id FROM articles WHERE id = ?", comment.ArticleID).Scan(&articleAuthorID)
+	if err != nil {
+		return fmt.Errorf("failed to load article author: %w", err)
 	}
 
+	comment.IsArticleAuthor = articleAuthorID == comment.AuthorID
 	return nil
 }
\ No newline at end of file
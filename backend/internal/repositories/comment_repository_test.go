@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -23,7 +24,7 @@ func TestCommentRepository_Create(t *testing.T) {
 
 	// Create repositories
 	userRepo := NewUserRepository(db)
-	articleRepo := NewArticleRepository(db, userRepo)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
 	commentRepo := NewCommentRepository(db, userRepo)
 
 	// Create test user
@@ -90,7 +91,7 @@ func TestCommentRepository_GetByArticleSlug(t *testing.T) {
 
 	// Create repositories
 	userRepo := NewUserRepository(db)
-	articleRepo := NewArticleRepository(db, userRepo)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
 	commentRepo := NewCommentRepository(db, userRepo)
 
 	// Create test user
@@ -161,6 +162,81 @@ func TestCommentRepository_GetByArticleSlug(t *testing.T) {
 	}
 }
 
+func TestCommentRepository_Labels(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Create repositories
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+
+	// Create article author and a moderator
+	author, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create author: %v", err)
+	}
+	moderator, err := userRepo.Create(&entities.UserRegistration{
+		Username: "moderator",
+		Email:    "moderator@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create moderator: %v", err)
+	}
+	if err := userRepo.UpdateRole(moderator.ID, entities.RoleModerator); err != nil {
+		t.Fatalf("Failed to promote moderator: %v", err)
+	}
+
+	article, err := articleRepo.Create(author.ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	authorComment, err := commentRepo.Create(author.ID, article.ID, &entities.CommentCreate{Body: "Thanks!"})
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if !authorComment.IsArticleAuthor || authorComment.IsModerator {
+		t.Errorf("Expected author comment to be labelled as article author only, got %+v", authorComment)
+	}
+
+	if _, err := commentRepo.Create(moderator.ID, article.ID, &entities.CommentCreate{Body: "Be nice"}); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+
+	comments, err := commentRepo.GetByArticleSlug(article.Slug)
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(comments))
+	}
+	if !comments[0].IsArticleAuthor || comments[0].IsModerator {
+		t.Errorf("Expected first comment to be labelled as article author, got %+v", comments[0])
+	}
+	if comments[1].IsArticleAuthor || !comments[1].IsModerator {
+		t.Errorf("Expected second comment to be labelled as moderator, got %+v", comments[1])
+	}
+}
+
 func TestCommentRepository_GetByID(t *testing.T) {
 	// Setup test database
 	db, err := database.NewDB(":memory:")
@@ -176,7 +252,7 @@ func TestCommentRepository_GetByID(t *testing.T) {
 
 	// Create repositories
 	userRepo := NewUserRepository(db)
-	articleRepo := NewArticleRepository(db, userRepo)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
 	commentRepo := NewCommentRepository(db, userRepo)
 
 	// Create test data
@@ -219,6 +295,139 @@ func TestCommentRepository_GetByID(t *testing.T) {
 	}
 }
 
+func TestCommentRepository_Update(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Create repositories
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+
+	// Create test data
+	userReg := &entities.UserRegistration{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+	user, _ := userRepo.Create(userReg)
+
+	articleCreate := &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	}
+	article, _ := articleRepo.Create(user.ID, articleCreate)
+
+	comment, _ := commentRepo.Create(user.ID, article.ID, &entities.CommentCreate{Body: "Tset comment"})
+
+	updated, err := commentRepo.Update(comment.ID, &entities.CommentUpdate{Body: "Test comment"})
+	if err != nil {
+		t.Fatalf("Failed to update comment: %v", err)
+	}
+	if updated.Body != "Test comment" {
+		t.Errorf("Expected updated body, got %q", updated.Body)
+	}
+	if updated.Author == nil || updated.Author.Username != "testuser" || !updated.IsArticleAuthor {
+		t.Errorf("Expected author details on the updated comment, got %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(comment.CreatedAt) {
+		t.Errorf("Expected creation time to be kept, got %v", updated.CreatedAt)
+	}
+
+	// Missing and deleted comments can't be edited
+	if _, err := commentRepo.Update(9999, &entities.CommentUpdate{Body: "Test"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error for missing comment, got %v", err)
+	}
+	if err := commentRepo.SoftDelete(comment.ID); err != nil {
+		t.Fatalf("Failed to soft delete comment: %v", err)
+	}
+	if _, err := commentRepo.Update(comment.ID, &entities.CommentUpdate{Body: "Test"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error for deleted comment, got %v", err)
+	}
+}
+
+func TestCommentRepository_Replies(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Create repositories
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+
+	// Create test data
+	userReg := &entities.UserRegistration{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+	user, _ := userRepo.Create(userReg)
+
+	articleCreate := &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	}
+	article, _ := articleRepo.Create(user.ID, articleCreate)
+
+	parent, _ := commentRepo.Create(user.ID, article.ID, &entities.CommentCreate{Body: "Parent"})
+	reply, err := commentRepo.Create(user.ID, article.ID, &entities.CommentCreate{Body: "Reply", ParentID: &parent.ID})
+	if err != nil {
+		t.Fatalf("Failed to create reply: %v", err)
+	}
+	if reply.ParentID == nil || *reply.ParentID != parent.ID {
+		t.Errorf("Expected reply to parent %d, got %v", parent.ID, reply.ParentID)
+	}
+
+	// Threads come back flat, parents before their replies
+	comments, err := commentRepo.GetByArticleSlug(article.Slug)
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 2 || comments[0].ParentID != nil || comments[1].ParentID == nil || *comments[1].ParentID != parent.ID {
+		t.Errorf("Expected parent then reply, got %+v", comments)
+	}
+
+	// Placeholders keep replies attached; hard deletes detach them
+	if err := commentRepo.SoftDelete(parent.ID); err != nil {
+		t.Fatalf("Failed to soft delete parent: %v", err)
+	}
+	kept, _ := commentRepo.GetByID(reply.ID)
+	if kept.ParentID == nil || *kept.ParentID != parent.ID {
+		t.Errorf("Expected reply to stay under the placeholder, got %v", kept.ParentID)
+	}
+
+	if err := commentRepo.Delete(parent.ID); err != nil {
+		t.Fatalf("Failed to delete parent: %v", err)
+	}
+	orphan, err := commentRepo.GetByID(reply.ID)
+	if err != nil {
+		t.Fatalf("Expected reply to survive its parent, got %v", err)
+	}
+	if orphan.ParentID != nil {
+		t.Errorf("Expected reply to become top-level, got parent %d", *orphan.ParentID)
+	}
+}
+
 func TestCommentRepository_Delete(t *testing.T) {
 	// Setup test database
 	db, err := database.NewDB(":memory:")
@@ -234,7 +443,7 @@ func TestCommentRepository_Delete(t *testing.T) {
 
 	// Create repositories
 	userRepo := NewUserRepository(db)
-	articleRepo := NewArticleRepository(db, userRepo)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
 	commentRepo := NewCommentRepository(db, userRepo)
 
 	// Create test data
@@ -276,6 +485,154 @@ func TestCommentRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestCommentRepository_SoftDelete(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Create repositories
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+
+	// Create test data
+	userReg := &entities.UserRegistration{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+	user, _ := userRepo.Create(userReg)
+
+	articleCreate := &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	}
+	article, _ := articleRepo.Create(user.ID, articleCreate)
+
+	commentCreate := &entities.CommentCreate{
+		Body: "Test comment",
+	}
+	comment, _ := commentRepo.Create(user.ID, article.ID, commentCreate)
+
+	// Soft delete keeps the row as a placeholder
+	if err := commentRepo.SoftDelete(comment.ID); err != nil {
+		t.Fatalf("Failed to soft delete comment: %v", err)
+	}
+
+	deleted, err := commentRepo.GetByID(comment.ID)
+	if err != nil {
+		t.Fatalf("Expected placeholder comment to remain, got %v", err)
+	}
+	if !deleted.Deleted || deleted.Body != entities.DeletedCommentPlaceholder || deleted.Author != nil {
+		t.Errorf("Expected redacted placeholder, got %+v", deleted)
+	}
+
+	comments, err := commentRepo.GetByArticleSlug(article.Slug)
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 1 || !comments[0].Deleted {
+		t.Errorf("Expected placeholder to stay in the thread, got %+v", comments)
+	}
+
+	// Soft deleting twice reports not found
+	if err := commentRepo.SoftDelete(comment.ID); err == nil {
+		t.Error("Expected error when soft deleting an already deleted comment")
+	}
+
+	// Hard delete still removes placeholders
+	if err := commentRepo.Delete(comment.ID); err != nil {
+		t.Fatalf("Failed to hard delete comment: %v", err)
+	}
+}
+
+func TestCommentRepository_ArticleActivity(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Create repositories
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+
+	// Create test data
+	userReg := &entities.UserRegistration{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+	user, _ := userRepo.Create(userReg)
+
+	quiet, _ := articleRepo.Create(user.ID, &entities.ArticleCreate{Title: "Quiet Article", Description: "d", Body: "b"})
+	older, _ := articleRepo.Create(user.ID, &entities.ArticleCreate{Title: "Older Discussion", Description: "d", Body: "b"})
+	busy, _ := articleRepo.Create(user.ID, &entities.ArticleCreate{Title: "Busy Discussion", Description: "d", Body: "b"})
+
+	first, _ := commentRepo.Create(user.ID, busy.ID, &entities.CommentCreate{Body: "First"})
+	second, _ := commentRepo.Create(user.ID, busy.ID, &entities.CommentCreate{Body: "Second"})
+	if _, err := commentRepo.Create(user.ID, older.ID, &entities.CommentCreate{Body: "Only"}); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+
+	// Comment on busy last so it leads the activity sort
+	time.Sleep(10 * time.Millisecond)
+	third, _ := commentRepo.Create(user.ID, busy.ID, &entities.CommentCreate{Body: "Third"})
+
+	got, err := articleRepo.GetByID(busy.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if got.CommentsCount != 3 || got.LastCommentAt == nil {
+		t.Fatalf("Expected 3 comments with a last comment time, got %d, %v", got.CommentsCount, got.LastCommentAt)
+	}
+
+	listed, _, err := articleRepo.List(&entities.ArticleListQuery{Limit: 20, Sort: entities.ArticleSortActivity})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(listed) != 3 || listed[0].ID != busy.ID || listed[1].ID != older.ID || listed[2].ID != quiet.ID {
+		t.Errorf("Expected busy, older, then quiet articles, got %+v", listed)
+	}
+
+	// Soft deleted placeholders no longer count, and the last comment time
+	// falls back to the newest remaining comment
+	if err := commentRepo.SoftDelete(third.ID); err != nil {
+		t.Fatalf("Failed to soft delete comment: %v", err)
+	}
+	got, _ = articleRepo.GetByID(busy.ID)
+	if got.CommentsCount != 2 || got.LastCommentAt == nil || !got.LastCommentAt.Equal(second.CreatedAt) {
+		t.Errorf("Expected 2 comments last at %v after soft delete, got %d, %v", second.CreatedAt, got.CommentsCount, got.LastCommentAt)
+	}
+
+	if err := commentRepo.Delete(first.ID); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+	if err := commentRepo.Delete(second.ID); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+	got, _ = articleRepo.GetByID(busy.ID)
+	if got.CommentsCount != 0 || got.LastCommentAt != nil {
+		t.Errorf("Expected no comments after deletes, got %d, %v", got.CommentsCount, got.LastCommentAt)
+	}
+}
+
 func TestCommentRepository_IsAuthor(t *testing.T) {
 	// Setup test database
 	db, err := database.NewDB(":memory:")
@@ -291,7 +648,7 @@ func TestCommentRepository_IsAuthor(t *testing.T) {
 
 	// Create repositories
 	userRepo := NewUserRepository(db)
-	articleRepo := NewArticleRepository(db, userRepo)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
 	commentRepo := NewCommentRepository(db, userRepo)
 
 	// Create test users
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// EmailStatusRepository defines the interface for email deliverability operations
+type EmailStatusRepository interface {
+	Get(userID int64) (entities.EmailStatus, error)
+	Record(userID int64, event entities.EmailEvent, at time.Time, softBounceLimit int) (entities.EmailStatus, error)
+	Clear(userID int64) error
+}
+
+// emailStatusRepository implements EmailStatusRepository using direct SQL
+type emailStatusRepository struct {
+	db *database.DB
+}
+
+// NewEmailStatusRepository creates a new email status repository
+func NewEmailStatusRepository(db *database.DB) EmailStatusRepository {
+	return &emailStatusRepository{
+		db: db,
+	}
+}
+
+// Get returns the deliverability of the user's address; addresses with no
+// recorded events are deliverable
+func (r *emailStatusRepository) Get(userID int64) (entities.EmailStatus, error) {
+	return scanEmailStatus(r.db.QueryRow(emailStatusQuery, userID))
+}
+
+// Record applies a provider event to the user's address, as
+// entities.EmailStatus.Apply describes, and returns the new status
+func (r *emailStatusRepository) Record(userID int64, event entities.EmailEvent, at time.Time, softBounceLimit int) (entities.EmailStatus, error) {
+	var status entities.EmailStatus
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		current, err := scanEmailStatus(tx.QueryRow(emailStatusQuery, userID))
+		if err != nil {
+			return err
+		}
+		status = current.Apply(event, at, softBounceLimit)
+
+		_, err = tx.Exec(`
+			INSERT INTO email_statuses (user_id, status, soft_bounces, last_event, last_event_at, suppressed_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (user_id) DO UPDATE SET
+				status = excluded.status,
+				soft_bounces = excluded.soft_bounces,
+				last_event = excluded.last_event,
+				last_event_at = excluded.last_event_at,
+				suppressed_at = excluded.suppressed_at
+		`, userID, status.Status, status.SoftBounces, status.LastEvent, status.LastEventAt, status.SuppressedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record email event: %w", err)
+		}
+		return nil
+	})
+	return status, err
+}
+
+// Clear forgets the events recorded for the user's address, making it
+// deliverable again
+func (r *emailStatusRepository) Clear(userID int64) error {
+	if _, err := r.db.Exec(`DELETE FROM email_statuses WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to clear email status: %w", err)
+	}
+	return nil
+}
+
+// emailStatusQuery selects the columns scanned by scanEmailStatus for one user
+const emailStatusQuery = `
+	SELECT status, soft_bounces, last_event, last_event_at, suppressed_at
+	FROM email_statuses
+	WHERE user_id = ?
+`
+
+// scanEmailStatus reads an email status row; a missing row is a deliverable address
+func scanEmailStatus(row interface{ Scan(...interface{}) error }) (entities.EmailStatus, error) {
+	status := entities.EmailStatus{Status: entities.EmailDeliverable}
+	var lastEvent sql.NullString
+	var lastEventAt, suppressedAt sql.NullTime
+	err := row.Scan(&status.Status, &status.SoftBounces, &lastEvent, &lastEventAt, &suppressedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return status, nil
+		}
+		return entities.EmailStatus{}, fmt.Errorf("failed to get email status: %w", err)
+	}
+
+	status.LastEvent = lastEvent.String
+	if lastEventAt.Valid {
+		status.LastEventAt = &lastEventAt.Time
+	}
+	if suppressedAt.Valid {
+		status.SuppressedAt = &suppressedAt.Time
+	}
+	return status, nil
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ExportRepository defines the interface for the article export queue
+type ExportRepository interface {
+	Create(articleID, userID int64, format string, at time.Time) (*entities.ArticleExport, error)
+	Latest(articleID, userID int64, format string) (*entities.ArticleExport, error)
+	Get(id int64) (*entities.ArticleExport, error)
+	Pending(limit int) ([]entities.ArticleExport, error)
+	Complete(id int64, contentType string, content []byte, at time.Time) error
+	Fail(id int64, message string, at time.Time) error
+	DeleteCreatedBefore(cutoff time.Time) (int64, error)
+}
+
+// exportRepository implements ExportRepository using direct SQL
+type exportRepository struct {
+	db *database.DB
+}
+
+// NewExportRepository creates a new export repository
+func NewExportRepository(db *database.DB) ExportRepository {
+	return &exportRepository{
+		db: db,
+	}
+}
+
+// exportColumns are the columns scanned by scanExport, without the content
+const exportColumns = `id, article_id, user_id, format, status, error, content_type, created_at, completed_at`
+
+// Create queues an export
+func (r *exportRepository) Create(articleID, userID int64, format string, at time.Time) (*entities.ArticleExport, error) {
+	query := `
+		INSERT INTO article_exports (article_id, user_id, format, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, articleID, userID, format, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue export: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export ID: %w", err)
+	}
+	return r.Get(id)
+}
+
+// Latest returns the user's most recent export of the article to the format,
+// without its content, or nil if they have none
+func (r *exportRepository) Latest(articleID, userID int64, format string) (*entities.ArticleExport, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM article_exports
+		WHERE article_id = ? AND user_id = ? AND format = ?
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	export, err := scanExport(r.db.QueryRow(query, articleID, userID, format))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return export, err
+}
+
+// Get returns an export with its content
+func (r *exportRepository) Get(id int64) (*entities.ArticleExport, error) {
+	query := `SELECT ` + exportColumns + `, content FROM article_exports WHERE id = ?`
+
+	export := &entities.ArticleExport{}
+	var completedAt sql.NullTime
+	err := r.db.QueryRow(query, id).Scan(&export.ID, &export.ArticleID, &export.UserID, &export.Format,
+		&export.Status, &export.Error, &export.ContentType, &export.CreatedAt, &completedAt, &export.Content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("export not found")
+		}
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	return export, nil
+}
+
+// Pending returns up to limit queued exports, oldest first
+func (r *exportRepository) Pending(limit int) ([]entities.ArticleExport, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM article_exports
+		WHERE status = ?
+		ORDER BY id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, entities.ExportStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []entities.ArticleExport{}
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, *export)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exports: %w", err)
+	}
+	return exports, nil
+}
+
+// Complete stores the rendered document and marks the export ready
+func (r *exportRepository) Complete(id int64, contentType string, content []byte, at time.Time) error {
+	query := `
+		UPDATE article_exports
+		SET status = ?, content_type = ?, content = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.db.Exec(query, entities.ExportStatusReady, contentType, content, at, id); err != nil {
+		return fmt.Errorf("failed to complete export: %w", err)
+	}
+	return nil
+}
+
+// Fail records why the export could not be rendered
+func (r *exportRepository) Fail(id int64, message string, at time.Time) error {
+	query := `
+		UPDATE article_exports
+		SET status = ?, error = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.db.Exec(query, entities.ExportStatusFailed, message, at, id); err != nil {
+		return fmt.Errorf("failed to record export failure: %w", err)
+	}
+	return nil
+}
+
+// DeleteCreatedBefore removes exports queued before cutoff, returning how many
+func (r *exportRepository) DeleteCreatedBefore(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM article_exports WHERE created_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old exports: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// scanExport reads an export, without its content, from a row
+func scanExport(row interface{ Scan(...interface{}) error }) (*entities.ArticleExport, error) {
+	export := &entities.ArticleExport{}
+	var completedAt sql.NullTime
+	err := row.Scan(&export.ID, &export.ArticleID, &export.UserID, &export.Format,
+		&export.Status, &export.Error, &export.ContentType, &export.CreatedAt, &completedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan export: %w", err)
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	return export, nil
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestExportRepository_Lifecycle(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	exportRepo := NewExportRepository(db)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "reader",
+		Email:    "reader@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	article, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+		Title:       "Long read",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	if latest, err := exportRepo.Latest(article.ID, user.ID, entities.ExportFormatPDF); err != nil || latest != nil {
+		t.Fatalf("Expected no export yet, got %+v (err: %v)", latest, err)
+	}
+
+	old, err := exportRepo.Create(article.ID, user.ID, entities.ExportFormatPDF, time.Now().UTC().Add(-48*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to queue export: %v", err)
+	}
+	export, err := exportRepo.Create(article.ID, user.ID, entities.ExportFormatPDF, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("Failed to queue export: %v", err)
+	}
+	if export.Status != entities.ExportStatusPending || export.CompletedAt != nil {
+		t.Errorf("Expected a pending export, got %+v", export)
+	}
+
+	pending, err := exportRepo.Pending(10)
+	if err != nil || len(pending) != 2 || pending[0].ID != old.ID {
+		t.Fatalf("Expected both exports pending oldest first, got %+v (err: %v)", pending, err)
+	}
+
+	if err := exportRepo.Fail(old.ID, "renderer crashed", time.Now().UTC()); err != nil {
+		t.Fatalf("Failed to record failure: %v", err)
+	}
+	if err := exportRepo.Complete(export.ID, "application/pdf", []byte("%PDF-1.4"), time.Now().UTC()); err != nil {
+		t.Fatalf("Failed to complete export: %v", err)
+	}
+
+	ready, err := exportRepo.Get(export.ID)
+	if err != nil {
+		t.Fatalf("Failed to get export: %v", err)
+	}
+	if ready.Status != entities.ExportStatusReady || ready.ContentType != "application/pdf" || string(ready.Content) != "%PDF-1.4" || ready.CompletedAt == nil {
+		t.Errorf("Expected the rendered export, got %+v", ready)
+	}
+	if latest, err := exportRepo.Latest(article.ID, user.ID, entities.ExportFormatPDF); err != nil || latest.ID != export.ID || latest.Content != nil {
+		t.Errorf("Expected the latest export without content, got %+v (err: %v)", latest, err)
+	}
+	if pending, _ := exportRepo.Pending(10); len(pending) != 0 {
+		t.Errorf("Expected nothing pending, got %d", len(pending))
+	}
+
+	deleted, err := exportRepo.DeleteCreatedBefore(time.Now().UTC().Add(-24 * time.Hour))
+	if err != nil || deleted != 1 {
+		t.Errorf("Expected the old export to be deleted, got %d (err: %v)", deleted, err)
+	}
+	if _, err := exportRepo.Get(old.ID); err == nil || err.Error() != "export not found" {
+		t.Errorf("Expected export not found, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// FavoriteRepository defines the interface for article favorite operations
+type FavoriteRepository interface {
+	Favorite(userID, articleID int64) (bool, error)
+	Unfavorite(userID, articleID int64) (bool, error)
+	IsFavorited(userID, articleID int64) (bool, error)
+	MarkFavorited(userID int64, articles []entities.Article) error
+	ListFavoriters(articleID int64, limit, offset int) ([]entities.Profile, int, int, error)
+	ReconcileCounts() (int, error)
+}
+
+// favoriteRepository implements FavoriteRepository using direct SQL
+type favoriteRepository struct {
+	db *database.DB
+}
+
+// NewFavoriteRepository creates a new favorite repository
+func NewFavoriteRepository(db *database.DB) FavoriteRepository {
+	return &favoriteRepository{
+		db: db,
+	}
+}
+
+// Favorite records the user's favorite and bumps the article's count; it
+// reports false if the article was already favorited
+func (r *favoriteRepository) Favorite(userID, articleID int64) (bool, error) {
+	return r.apply(userID, articleID,
+		"INSERT OR IGNORE INTO favorites (user_id, article_id) VALUES (?, ?)",
+		"UPDATE articles SET favorites_count = favorites_count + 1 WHERE id = ?")
+}
+
+// Unfavorite removes the user's favorite and lowers the article's count; it
+// reports false if the article was not favorited
+func (r *favoriteRepository) Unfavorite(userID, articleID int64) (bool, error) {
+	return r.apply(userID, articleID,
+		"DELETE FROM favorites WHERE user_id = ? AND article_id = ?",
+		"UPDATE articles SET favorites_count = MAX(favorites_count - 1, 0) WHERE id = ?")
+}
+
+// apply runs statement and, if it changed a row, updateCount in the same transaction
+func (r *favoriteRepository) apply(userID, articleID int64, statement, updateCount string) (bool, error) {
+	changed := false
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(statement, userID, articleID)
+		if err != nil {
+			return fmt.Errorf("failed to update favorite: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return nil
+		}
+		changed = true
+
+		if _, err := tx.Exec(updateCount, articleID); err != nil {
+			return fmt.Errorf("failed to update favorites count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+// ReconcileCounts recounts favorites for every article whose stored count has
+// drifted from its favorites rows and returns how many articles were fixed
+func (r *favoriteRepository) ReconcileCounts() (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE articles
+		SET favorites_count = (SELECT COUNT(*) FROM favorites f WHERE f.article_id = articles.id)
+		WHERE favorites_count IS NOT (SELECT COUNT(*) FROM favorites f WHERE f.article_id = articles.id)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reconcile favorites counts: %w", err)
+	}
+
+	fixed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(fixed), nil
+}
+
+// IsFavorited reports whether the user has favorited the article
+func (r *favoriteRepository) IsFavorited(userID, articleID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM favorites WHERE user_id = ? AND article_id = ?)", userID, articleID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check favorite: %w", err)
+	}
+
+	return exists, nil
+}
+
+// MarkFavorited sets Favorited on each article the user has favorited, in a
+// single query for the whole page
+func (r *favoriteRepository) MarkFavorited(userID int64, articles []entities.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(articles))
+	args := []interface{}{userID}
+	for i := range articles {
+		placeholders[i] = "?"
+		args = append(args, articles[i].ID)
+	}
+
+	rows, err := r.db.Query("SELECT article_id FROM favorites WHERE user_id = ? AND article_id IN ("+strings.Join(placeholders, ", ")+")", args...)
+	if err != nil {
+		return fmt.Errorf("failed to query favorites: %w", err)
+	}
+	defer rows.Close()
+
+	favorited := make(map[int64]bool)
+	for rows.Next() {
+		var articleID int64
+		if err := rows.Scan(&articleID); err != nil {
+			return fmt.Errorf("failed to scan favorite: %w", err)
+		}
+		favorited[articleID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over favorites: %w", err)
+	}
+
+	for i := range articles {
+		articles[i].Favorited = favorited[articles[i].ID]
+	}
+	return nil
+}
+
+// listedFavoriter matches active favoriters who show their favorites on a
+// public profile; users without a settings row have the defaults
+var listedFavoriter = "COALESCE(s.show_favorites, 1) AND COALESCE(s.profile_visibility, 'public') = 'public' AND " + activeUser("u.id")
+
+// ListFavoriters returns a page of the article's listed favoriters, most
+// recent first, with the number of listed and anonymous favoriters
+func (r *favoriteRepository) ListFavoriters(articleID int64, limit, offset int) ([]entities.Profile, int, int, error) {
+	var publicCount, anonymousCount int
+	countQuery := `
+		SELECT
+			COALESCE(SUM(CASE WHEN ` + listedFavoriter + ` THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + listedFavoriter + ` THEN 0 ELSE 1 END), 0)
+		FROM favorites f
+		JOIN users u ON u.id = f.user_id
+		LEFT JOIN user_settings s ON s.user_id = f.user_id
+		WHERE f.article_id = ?
+	`
+	if err := r.db.QueryRow(countQuery, articleID).Scan(&publicCount, &anonymousCount); err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to count favoriters: %w", err)
+	}
+
+	query := `
+		SELECT u.username, u.bio, u.image_url
+		FROM favorites f
+		JOIN users u ON u.id = f.user_id
+		LEFT JOIN user_settings s ON s.user_id = f.user_id
+		WHERE f.article_id = ? AND ` + listedFavoriter + `
+		ORDER BY f.created_at DESC, u.username ASC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, articleID, limit, offset)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to query favoriters: %w", err)
+	}
+	defer rows.Close()
+
+	profiles, err := scanProfiles(rows)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return profiles, publicCount, anonymousCount, nil
+}
+
+// scanProfiles reads username, bio, image_url rows into profiles
+func scanProfiles(rows *sql.Rows) ([]entities.Profile, error) {
+	profiles := []entities.Profile{}
+	for rows.Next() {
+		var profile entities.Profile
+		if err := rows.Scan(&profile.Username, &profile.Bio, &profile.ImageURL); err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		if profile.ImageURL == "" {
+			profile.ImageURL = entities.DefaultAvatarURL(profile.Username)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
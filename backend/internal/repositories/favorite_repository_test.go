@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestFavoriteRepository_ListFavoritersHidesHiddenFavorites(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	favoriteRepo := NewFavoriteRepository(db)
+	settingsRepo := NewSettingsRepository(db)
+
+	users := createTestUsers(t, userRepo, "author", "alice", "bob")
+
+	article, err := articleRepo.Create(users["author"].ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	hidden := false
+	if _, err := settingsRepo.Update(users["bob"].ID, &entities.UserSettingsUpdate{ShowFavorites: &hidden}); err != nil {
+		t.Fatalf("Failed to update settings: %v", err)
+	}
+
+	for _, name := range []string{"alice", "bob"} {
+		if changed, err := favoriteRepo.Favorite(users[name].ID, article.ID); err != nil || !changed {
+			t.Fatalf("Favorite(%s) = %v, %v; want true, nil", name, changed, err)
+		}
+	}
+	if changed, _ := favoriteRepo.Favorite(users["alice"].ID, article.ID); changed {
+		t.Error("Favoriting twice should not change anything")
+	}
+
+	profiles, total, anonymous, err := favoriteRepo.ListFavoriters(article.ID, 20, 0)
+	if err != nil {
+		t.Fatalf("Failed to list favoriters: %v", err)
+	}
+	if total != 1 || anonymous != 1 {
+		t.Errorf("Counts = %d public, %d anonymous; want 1, 1", total, anonymous)
+	}
+	if len(profiles) != 1 || profiles[0].Username != "alice" {
+		t.Errorf("Profiles = %+v, want only alice", profiles)
+	}
+
+	stored, err := articleRepo.GetByID(article.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if stored.FavoritesCount != 2 {
+		t.Errorf("FavoritesCount = %d, want 2", stored.FavoritesCount)
+	}
+
+	if changed, err := favoriteRepo.Unfavorite(users["bob"].ID, article.ID); err != nil || !changed {
+		t.Fatalf("Unfavorite(bob) = %v, %v; want true, nil", changed, err)
+	}
+	stored, _ = articleRepo.GetByID(article.ID)
+	if stored.FavoritesCount != 1 {
+		t.Errorf("FavoritesCount after unfavorite = %d, want 1", stored.FavoritesCount)
+	}
+}
+
+func TestFavoriteRepository_MarkFavorited(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	favoriteRepo := NewFavoriteRepository(db)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "reader",
+		Email:    "reader@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	for _, title := range []string{"Liked", "Skipped"} {
+		if _, err := articleRepo.Create(user.ID, &entities.ArticleCreate{
+			Title:       title,
+			Description: "Test description",
+			Body:        "Test body",
+		}); err != nil {
+			t.Fatalf("Failed to create article %s: %v", title, err)
+		}
+	}
+
+	articles, _, err := articleRepo.List(&entities.ArticleListQuery{Limit: 20})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	for _, article := range articles {
+		if article.Title == "Liked" {
+			if _, err := favoriteRepo.Favorite(user.ID, article.ID); err != nil {
+				t.Fatalf("Failed to favorite: %v", err)
+			}
+		}
+	}
+
+	if err := favoriteRepo.MarkFavorited(user.ID, articles); err != nil {
+		t.Fatalf("MarkFavorited failed: %v", err)
+	}
+	for _, article := range articles {
+		if article.Favorited != (article.Title == "Liked") {
+			t.Errorf("%s: Favorited = %v", article.Title, article.Favorited)
+		}
+	}
+
+	if err := favoriteRepo.MarkFavorited(user.ID, nil); err != nil {
+		t.Errorf("MarkFavorited on an empty page failed: %v", err)
+	}
+}
+
+func TestFavoriteRepository_CountsAndReconcile(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	favoriteRepo := NewFavoriteRepository(db)
+
+	users := createTestUsers(t, userRepo, "author", "alice", "bob")
+
+	article, err := articleRepo.Create(users["author"].ID, &entities.ArticleCreate{
+		Title:       "Test Article",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	countOf := func() int {
+		loaded, err := articleRepo.GetByID(article.ID)
+		if err != nil {
+			t.Fatalf("Failed to get article: %v", err)
+		}
+		return loaded.FavoritesCount
+	}
+
+	// Counts follow favorites, and repeats change nothing
+	steps := []struct {
+		name string
+		call func(userID, articleID int64) (bool, error)
+		user string
+		want bool
+	}{
+		{"Favorite", favoriteRepo.Favorite, "alice", true},
+		{"Favorite", favoriteRepo.Favorite, "alice", false},
+		{"Favorite", favoriteRepo.Favorite, "bob", true},
+		{"Unfavorite", favoriteRepo.Unfavorite, "bob", true},
+		{"Unfavorite", favoriteRepo.Unfavorite, "bob", false},
+	}
+	for _, step := range steps {
+		if changed, err := step.call(users[step.user].ID, article.ID); err != nil || changed != step.want {
+			t.Fatalf("%s(%s) = %v, %v; want %v, nil", step.name, step.user, changed, err, step.want)
+		}
+	}
+	if got := countOf(); got != 1 {
+		t.Fatalf("Expected 1 favorite, got %d", got)
+	}
+
+	// Drift introduced behind the repository's back is repaired
+	if _, err := db.Exec("UPDATE articles SET favorites_count = 7 WHERE id = ?", article.ID); err != nil {
+		t.Fatalf("Failed to corrupt count: %v", err)
+	}
+	fixed, err := favoriteRepo.ReconcileCounts()
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	if fixed != 1 || countOf() != 1 {
+		t.Errorf("Expected 1 article repaired to 1 favorite, got %d repaired and count %d", fixed, countOf())
+	}
+
+	if fixed, _ := favoriteRepo.ReconcileCounts(); fixed != 0 {
+		t.Errorf("Expected nothing left to repair, got %d", fixed)
+	}
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+)
+
+// FeedRepository defines the interface for personal feed read tracking
+type FeedRepository interface {
+	GetLastSeen(userID int64) (*time.Time, error)
+	MarkSeen(userID int64, seenAt time.Time) error
+	UnreadCount(userID int64) (int, error)
+}
+
+// feedRepository implements FeedRepository using direct SQL
+type feedRepository struct {
+	db         *database.DB
+	quarantine time.Duration
+}
+
+// NewFeedRepository creates a new feed repository; articles by accounts
+// younger than quarantine are not counted until an admin approves the account
+func NewFeedRepository(db *database.DB, quarantine time.Duration) FeedRepository {
+	return &feedRepository{
+		db:         db,
+		quarantine: quarantine,
+	}
+}
+
+// GetLastSeen returns the newest feed item the user has seen, or nil if they never opened the feed
+func (r *feedRepository) GetLastSeen(userID int64) (*time.Time, error) {
+	var lastSeen time.Time
+	err := r.db.QueryRow("SELECT last_seen_at FROM feed_reads WHERE user_id = ?", userID).Scan(&lastSeen)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed read marker: %w", err)
+	}
+
+	return &lastSeen, nil
+}
+
+// MarkSeen advances the user's read marker; it never moves backwards, so
+// paging through older feed items does not resurrect newer unread ones
+func (r *feedRepository) MarkSeen(userID int64, seenAt time.Time) error {
+	lastSeen, err := r.GetLastSeen(userID)
+	if err != nil {
+		return err
+	}
+	if lastSeen != nil && !seenAt.After(*lastSeen) {
+		return nil
+	}
+
+	query := `
+		INSERT INTO feed_reads (user_id, last_seen_at)
+		VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET last_seen_at = excluded.last_seen_at
+	`
+
+	if _, err := r.db.Exec(query, userID, seenAt); err != nil {
+		return fmt.Errorf("failed to update feed read marker: %w", err)
+	}
+
+	return nil
+}
+
+// UnreadCount returns how many feed articles were published after the read marker
+func (r *feedRepository) UnreadCount(userID int64) (int, error) {
+	lastSeen, err := r.GetLastSeen(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	listed, cutoff := listedAuthor("a.author_id", r.quarantine)
+	query := `
+		SELECT COUNT(*)
+		FROM articles a
+		WHERE a.translation_of IS NULL AND a.status = 'published'
+		AND a.author_id IN (SELECT following_id FROM follows WHERE follower_id = ?)
+		AND ` + activeUser("a.author_id") + ` AND ` + listed + `
+	`
+	args := []interface{}{userID, cutoff}
+
+	if lastSeen != nil {
+		query += " AND a.created_at > ?"
+		args = append(args, *lastSeen)
+	}
+
+	var count int
+	if err := r.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread feed articles: %w", err)
+	}
+
+	return count, nil
+}
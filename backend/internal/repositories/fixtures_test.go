@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// createTestUsers registers a user for each name, with an email derived from
+// it, and returns them by name
+func createTestUsers(t *testing.T, repo UserRepository, names ...string) map[string]*entities.User {
+	t.Helper()
+
+	users := make(map[string]*entities.User, len(names))
+	for _, name := range names {
+		user, err := repo.Create(&entities.UserRegistration{
+			Username: name,
+			Email:    name + "@example.com",
+			Password: "password123",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create user %s: %v", name, err)
+		}
+		users[name] = user
+	}
+	return users
+}
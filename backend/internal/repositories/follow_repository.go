@@ -0,0 +1,232 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// FollowRepository defines the interface for follow relationship operations
+type FollowRepository interface {
+	Follow(followerID int64, followingIDs []int64) ([]int64, error)
+	Unfollow(followerID int64, followingIDs []int64) ([]int64, error)
+	IsFollowing(followerID, followingID int64) (bool, error)
+	MarkFollowing(followerID int64, users []*entities.User) error
+	ListFollowers(userID int64, limit, offset int) ([]entities.Profile, int, error)
+	Suggestions(userID int64, tags []string, limit int) ([]entities.ProfileSuggestion, error)
+}
+
+// followRepository implements FollowRepository using direct SQL
+type followRepository struct {
+	db *database.DB
+}
+
+// NewFollowRepository creates a new follow repository
+func NewFollowRepository(db *database.DB) FollowRepository {
+	return &followRepository{
+		db: db,
+	}
+}
+
+// Follow makes the follower follow every given user in one transaction and
+// returns the IDs that were not already followed
+func (r *followRepository) Follow(followerID int64, followingIDs []int64) ([]int64, error) {
+	return r.apply(followerID, followingIDs,
+		"INSERT OR IGNORE INTO follows (follower_id, following_id) VALUES (?, ?)")
+}
+
+// Unfollow removes the follower's follows of the given users in one
+// transaction and returns the IDs that were actually followed
+func (r *followRepository) Unfollow(followerID int64, followingIDs []int64) ([]int64, error) {
+	return r.apply(followerID, followingIDs,
+		"DELETE FROM follows WHERE follower_id = ? AND following_id = ?")
+}
+
+// apply runs statement for each followed user and collects the ones it changed
+func (r *followRepository) apply(followerID int64, followingIDs []int64, statement string) ([]int64, error) {
+	changed := []int64{}
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		for _, followingID := range followingIDs {
+			result, err := tx.Exec(statement, followerID, followingID)
+			if err != nil {
+				return fmt.Errorf("failed to update follow: %w", err)
+			}
+			if rows, _ := result.RowsAffected(); rows > 0 {
+				changed = append(changed, followingID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// IsFollowing reports whether the follower follows the user
+func (r *followRepository) IsFollowing(followerID, followingID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM follows WHERE follower_id = ? AND following_id = ?)", followerID, followingID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+
+	return exists, nil
+}
+
+// MarkFollowing sets Following on each of the users the follower follows, in
+// a single query; nil entries are skipped
+func (r *followRepository) MarkFollowing(followerID int64, users []*entities.User) error {
+	placeholders := []string{}
+	args := []interface{}{followerID}
+	for _, user := range users {
+		if user != nil {
+			placeholders = append(placeholders, "?")
+			args = append(args, user.ID)
+		}
+	}
+	if len(placeholders) == 0 {
+		return nil
+	}
+
+	rows, err := r.db.Query("SELECT following_id FROM follows WHERE follower_id = ? AND following_id IN ("+strings.Join(placeholders, ", ")+")", args...)
+	if err != nil {
+		return fmt.Errorf("failed to query follows: %w", err)
+	}
+	defer rows.Close()
+
+	following := make(map[int64]bool)
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return fmt.Errorf("failed to scan follow: %w", err)
+		}
+		following[userID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate over follows: %w", err)
+	}
+
+	for _, user := range users {
+		if user != nil {
+			user.Following = following[user.ID]
+		}
+	}
+	return nil
+}
+
+// ListFollowers returns a page of the user's active followers with public profiles,
+// most recent first, and their total count
+func (r *followRepository) ListFollowers(userID int64, limit, offset int) ([]entities.Profile, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM follows f
+		JOIN users u ON u.id = f.follower_id
+		LEFT JOIN user_settings s ON s.user_id = f.follower_id
+		WHERE f.following_id = ? AND COALESCE(s.profile_visibility, 'public') = 'public'
+		AND ` + activeUser("u.id") + `
+	`
+
+	var total int
+	if err := r.db.QueryRow(countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count followers: %w", err)
+	}
+
+	query := `
+		SELECT u.username, u.bio, u.image_url
+		FROM follows f
+		JOIN users u ON u.id = f.follower_id
+		LEFT JOIN user_settings s ON s.user_id = f.follower_id
+		WHERE f.following_id = ? AND COALESCE(s.profile_visibility, 'public') = 'public'
+		AND ` + activeUser("u.id") + `
+		ORDER BY f.created_at DESC, u.username ASC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query followers: %w", err)
+	}
+	defer rows.Close()
+
+	profiles, err := scanProfiles(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return profiles, total, nil
+}
+
+// Suggestions returns active, searchable public authors the user does not follow yet.
+// Authors writing under the given tags (or their aliases) come first, then
+// the most-followed.
+func (r *followRepository) Suggestions(userID int64, tags []string, limit int) ([]entities.ProfileSuggestion, error) {
+	tagArticles := "0"
+	var tagArgs []interface{}
+	if len(tags) > 0 {
+		placeholders := make([]string, len(tags))
+		for i, tag := range tags {
+			placeholders[i] = "?"
+			tagArgs = append(tagArgs, tag)
+		}
+		for _, tag := range tags {
+			tagArgs = append(tagArgs, tag)
+		}
+		in := joinStrings(placeholders, ", ")
+		tagArticles = fmt.Sprintf(`COUNT(DISTINCT CASE WHEN a.id IN (
+			SELECT at.article_id FROM article_tags at
+			WHERE at.tag_id IN (
+				SELECT id FROM tags WHERE name IN (%s)
+				UNION SELECT tag_id FROM tag_aliases WHERE alias IN (%s)
+			)
+		) THEN a.id END)`, in, in)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT u.username, u.bio, u.image_url,
+			(SELECT COUNT(*) FROM follows f WHERE f.following_id = u.id) AS followers_count,
+			COUNT(a.id) AS articles_count,
+			%s AS tag_articles
+		FROM users u
+		JOIN articles a ON a.author_id = u.id AND a.translation_of IS NULL AND a.status = 'published'
+		LEFT JOIN user_settings s ON s.user_id = u.id
+		WHERE u.id != ?
+		AND COALESCE(s.searchable, 1) AND COALESCE(s.profile_visibility, 'public') = 'public'
+		AND `+activeUser("u.id")+`
+		AND u.id NOT IN (SELECT following_id FROM follows WHERE follower_id = ?)
+		GROUP BY u.id
+		ORDER BY tag_articles DESC, followers_count DESC, articles_count DESC, u.username ASC
+		LIMIT ?
+	`, tagArticles)
+
+	args := append(tagArgs, userID, userID, limit)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []entities.ProfileSuggestion{}
+	for rows.Next() {
+		var suggestion entities.ProfileSuggestion
+		var tagArticleCount int
+		if err := rows.Scan(&suggestion.Username, &suggestion.Bio, &suggestion.ImageURL,
+			&suggestion.FollowersCount, &suggestion.ArticlesCount, &tagArticleCount); err != nil {
+			return nil, fmt.Errorf("failed to scan profile suggestion: %w", err)
+		}
+		if suggestion.ImageURL == "" {
+			suggestion.ImageURL = entities.DefaultAvatarURL(suggestion.Username)
+		}
+		suggestion.Reason = entities.SuggestionReasonPopular
+		if tagArticleCount > 0 {
+			suggestion.Reason = entities.SuggestionReasonTag
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, rows.Err()
+}
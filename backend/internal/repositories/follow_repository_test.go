@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestFollowRepository_MarkFollowing(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	followRepo := NewFollowRepository(db)
+
+	users := createTestUsers(t, userRepo, "reader", "followed", "stranger")
+
+	if _, err := followRepo.Follow(users["reader"].ID, []int64{users["followed"].ID}); err != nil {
+		t.Fatalf("Failed to follow: %v", err)
+	}
+
+	authors := []*entities.User{users["followed"], nil, users["stranger"]}
+	if err := followRepo.MarkFollowing(users["reader"].ID, authors); err != nil {
+		t.Fatalf("MarkFollowing failed: %v", err)
+	}
+	if !users["followed"].Following || users["stranger"].Following {
+		t.Errorf("Following = %v, %v; want true, false", users["followed"].Following, users["stranger"].Following)
+	}
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// IncidentRepository defines the interface for incident and maintenance notice operations
+type IncidentRepository interface {
+	Create(createdBy int64, incident *entities.IncidentCreate, at time.Time) (*entities.Incident, error)
+	GetByID(id int64) (*entities.Incident, error)
+	Update(incident *entities.Incident, at time.Time) error
+	Resolve(id int64, at time.Time) error
+	List(status string, limit, offset int) ([]entities.Incident, int, error)
+	ListActive() ([]entities.Incident, error)
+}
+
+// incidentRepository implements IncidentRepository using direct SQL
+type incidentRepository struct {
+	db *database.DB
+}
+
+// NewIncidentRepository creates a new incident repository
+func NewIncidentRepository(db *database.DB) IncidentRepository {
+	return &incidentRepository{
+		db: db,
+	}
+}
+
+const incidentColumns = `id, kind, severity, title, message, components, started_at, updated_at, resolved_at`
+
+// Create opens an incident starting now
+func (r *incidentRepository) Create(createdBy int64, incident *entities.IncidentCreate, at time.Time) (*entities.Incident, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO incidents (kind, severity, title, message, components, created_by, started_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, incident.Kind, incident.Severity, incident.Title, incident.Message, strings.Join(incident.Components, " "), createdBy, at, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident ID: %w", err)
+	}
+
+	return r.GetByID(id)
+}
+
+// GetByID retrieves an incident
+func (r *incidentRepository) GetByID(id int64) (*entities.Incident, error) {
+	incident, err := scanIncident(r.db.QueryRow("SELECT "+incidentColumns+" FROM incidents WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("incident not found")
+		}
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	return incident, nil
+}
+
+// Update saves the incident's severity, title, message and components
+func (r *incidentRepository) Update(incident *entities.Incident, at time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE incidents SET severity = ?, title = ?, message = ?, components = ?, updated_at = ?
+		WHERE id = ?
+	`, incident.Severity, incident.Title, incident.Message, strings.Join(incident.Components, " "), at, incident.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("incident not found")
+	}
+
+	incident.UpdatedAt = at
+	return nil
+}
+
+// Resolve marks an incident resolved; resolving it again keeps the first time
+func (r *incidentRepository) Resolve(id int64, at time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE incidents SET resolved_at = COALESCE(resolved_at, ?), updated_at = ?
+		WHERE id = ?
+	`, at, at, id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve incident: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("incident not found")
+	}
+
+	return nil
+}
+
+// List returns a page of incidents with the given status, most recently
+// started first, and the total number with that status
+func (r *incidentRepository) List(status string, limit, offset int) ([]entities.Incident, int, error) {
+	where := ""
+	switch status {
+	case entities.IncidentStatusActive:
+		where = "WHERE resolved_at IS NULL"
+	case entities.IncidentStatusResolved:
+		where = "WHERE resolved_at IS NOT NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM incidents " + where).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count incidents: %w", err)
+	}
+
+	incidents, err := r.query(`
+		SELECT `+incidentColumns+`
+		FROM incidents
+		`+where+`
+		ORDER BY started_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return incidents, total, nil
+}
+
+// ListActive returns every unresolved incident, most recently started first
+func (r *incidentRepository) ListActive() ([]entities.Incident, error) {
+	return r.query(`
+		SELECT ` + incidentColumns + `
+		FROM incidents
+		WHERE resolved_at IS NULL
+		ORDER BY started_at DESC, id DESC
+	`)
+}
+
+// query runs an incident query and scans every row
+func (r *incidentRepository) query(query string, args ...interface{}) ([]entities.Incident, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []entities.Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, *incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// scanIncident reads an incident row
+func scanIncident(row interface{ Scan(...interface{}) error }) (*entities.Incident, error) {
+	incident := &entities.Incident{}
+	var components string
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&incident.ID,
+		&incident.Kind,
+		&incident.Severity,
+		&incident.Title,
+		&incident.Message,
+		&components,
+		&incident.StartedAt,
+		&incident.UpdatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.Components = strings.Fields(components)
+	if resolvedAt.Valid {
+		incident.ResolvedAt = &resolvedAt.Time
+	}
+
+	return incident, nil
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestIncidentRepository_Lifecycle(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	admin, err := NewUserRepository(db).Create(&entities.UserRegistration{
+		Username: "admin",
+		Email:    "admin@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	incidentRepo := NewIncidentRepository(db)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	outage, err := incidentRepo.Create(admin.ID, &entities.IncidentCreate{
+		Kind:       entities.IncidentKindIncident,
+		Severity:   entities.IncidentSeverityMajor,
+		Title:      "Emails are delayed",
+		Components: []string{"email"},
+	}, now)
+	if err != nil {
+		t.Fatalf("Failed to create incident: %v", err)
+	}
+	if len(outage.Components) != 1 || outage.Components[0] != "email" || !outage.IsActive() {
+		t.Errorf("Unexpected incident %+v", outage)
+	}
+
+	_, err = incidentRepo.Create(admin.ID, &entities.IncidentCreate{
+		Kind:     entities.IncidentKindMaintenance,
+		Severity: entities.IncidentSeverityMinor,
+		Title:    "Database upgrade",
+	}, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to create maintenance: %v", err)
+	}
+
+	outage.Severity = entities.IncidentSeverityCritical
+	if err := incidentRepo.Update(outage, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Failed to update incident: %v", err)
+	}
+	if err := incidentRepo.Resolve(outage.ID, now.Add(3*time.Hour)); err != nil {
+		t.Fatalf("Failed to resolve incident: %v", err)
+	}
+	if err := incidentRepo.Resolve(outage.ID, now.Add(4*time.Hour)); err != nil {
+		t.Fatalf("Failed to resolve incident again: %v", err)
+	}
+
+	resolved, err := incidentRepo.GetByID(outage.ID)
+	if err != nil {
+		t.Fatalf("Failed to get incident: %v", err)
+	}
+	if resolved.Severity != entities.IncidentSeverityCritical || resolved.ResolvedAt == nil || !resolved.ResolvedAt.Equal(now.Add(3*time.Hour)) {
+		t.Errorf("Expected a critical incident resolved at the first resolution, got %+v", resolved)
+	}
+
+	active, err := incidentRepo.ListActive()
+	if err != nil {
+		t.Fatalf("Failed to list active incidents: %v", err)
+	}
+	if len(active) != 1 || active[0].Kind != entities.IncidentKindMaintenance {
+		t.Errorf("Expected only the maintenance to be active, got %+v", active)
+	}
+
+	all, total, err := incidentRepo.List(entities.IncidentStatusAll, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list incidents: %v", err)
+	}
+	if total != 2 || len(all) != 2 || all[0].Kind != entities.IncidentKindMaintenance {
+		t.Errorf("Expected both incidents, latest first, got %d: %+v", total, all)
+	}
+
+	if err := incidentRepo.Resolve(9999, now); err == nil {
+		t.Error("Expected resolving an unknown incident to fail")
+	}
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// InviteRepository defines the interface for registration invite operations
+type InviteRepository interface {
+	Create(createdBy int64, count int) ([]entities.Invite, error)
+	List(limit, offset int) ([]entities.Invite, int, error)
+	Claim(code string) (bool, error)
+	Release(code string) error
+	Redeem(code string, userID int64) error
+}
+
+// inviteRepository implements InviteRepository using direct SQL
+type inviteRepository struct {
+	db *database.DB
+}
+
+// NewInviteRepository creates a new invite repository
+func NewInviteRepository(db *database.DB) InviteRepository {
+	return &inviteRepository{
+		db: db,
+	}
+}
+
+// Create generates count new invite codes
+func (r *inviteRepository) Create(createdBy int64, count int) ([]entities.Invite, error) {
+	now := time.Now()
+	invites := make([]entities.Invite, 0, count)
+
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		for i := 0; i < count; i++ {
+			code, err := newInviteCode()
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec("INSERT INTO invites (code, created_by, created_at) VALUES (?, ?, ?)", code, createdBy, now); err != nil {
+				return fmt.Errorf("failed to create invite: %w", err)
+			}
+			invites = append(invites, entities.Invite{Code: code, CreatedAt: now})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+// List returns a page of invites, newest first, and the total number of invites
+func (r *inviteRepository) List(limit, offset int) ([]entities.Invite, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM invites").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count invites: %w", err)
+	}
+
+	query := `
+		SELECT i.code, i.created_at, i.claimed_at, COALESCE(u.username, '')
+		FROM invites i
+		LEFT JOIN users u ON u.id = i.used_by
+		ORDER BY i.created_at DESC, i.code ASC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query invites: %w", err)
+	}
+	defer rows.Close()
+
+	invites := []entities.Invite{}
+	for rows.Next() {
+		var invite entities.Invite
+		var claimedAt sql.NullTime
+		if err := rows.Scan(&invite.Code, &invite.CreatedAt, &claimedAt, &invite.UsedBy); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		if claimedAt.Valid {
+			invite.ClaimedAt = &claimedAt.Time
+		}
+		invites = append(invites, invite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate over invites: %w", err)
+	}
+
+	return invites, total, nil
+}
+
+// Claim marks an unused invite as taken; it reports false if the code does
+// not exist or was already claimed
+func (r *inviteRepository) Claim(code string) (bool, error) {
+	result, err := r.db.Exec("UPDATE invites SET claimed_at = ? WHERE code = ? AND claimed_at IS NULL", time.Now(), code)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim invite: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Release returns a claimed invite that was never redeemed, for when the
+// registration it was claimed for fails
+func (r *inviteRepository) Release(code string) error {
+	if _, err := r.db.Exec("UPDATE invites SET claimed_at = NULL WHERE code = ? AND used_by IS NULL", code); err != nil {
+		return fmt.Errorf("failed to release invite: %w", err)
+	}
+	return nil
+}
+
+// Redeem records the account registered with a claimed invite
+func (r *inviteRepository) Redeem(code string, userID int64) error {
+	if _, err := r.db.Exec("UPDATE invites SET used_by = ? WHERE code = ?", userID, code); err != nil {
+		return fmt.Errorf("failed to redeem invite: %w", err)
+	}
+	return nil
+}
+
+// newInviteCode returns a random, hard to guess invite code
+func newInviteCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestInviteRepository_ClaimIsSingleUse(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	inviteRepo := NewInviteRepository(db)
+
+	admin, err := userRepo.Create(&entities.UserRegistration{
+		Username: "admin",
+		Email:    "admin@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create admin: %v", err)
+	}
+
+	invites, err := inviteRepo.Create(admin.ID, 2)
+	if err != nil {
+		t.Fatalf("Failed to create invites: %v", err)
+	}
+	if len(invites) != 2 || invites[0].Code == invites[1].Code {
+		t.Fatalf("Invites = %+v, want two distinct codes", invites)
+	}
+	code := invites[0].Code
+
+	if claimed, err := inviteRepo.Claim("unknown"); err != nil || claimed {
+		t.Errorf("Claim(unknown) = %v, %v; want false, nil", claimed, err)
+	}
+	if claimed, err := inviteRepo.Claim(code); err != nil || !claimed {
+		t.Fatalf("Claim = %v, %v; want true, nil", claimed, err)
+	}
+	if claimed, _ := inviteRepo.Claim(code); claimed {
+		t.Error("A claimed invite should not be claimable again")
+	}
+
+	// A failed registration gives the invite back
+	if err := inviteRepo.Release(code); err != nil {
+		t.Fatalf("Failed to release invite: %v", err)
+	}
+	if claimed, _ := inviteRepo.Claim(code); !claimed {
+		t.Fatal("A released invite should be claimable")
+	}
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "invited",
+		Email:    "invited@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := inviteRepo.Redeem(code, user.ID); err != nil {
+		t.Fatalf("Failed to redeem invite: %v", err)
+	}
+
+	// Redeemed invites stay used
+	if err := inviteRepo.Release(code); err != nil {
+		t.Fatalf("Failed to release invite: %v", err)
+	}
+	if claimed, _ := inviteRepo.Claim(code); claimed {
+		t.Error("A redeemed invite should not be claimable")
+	}
+
+	listed, total, err := inviteRepo.List(20, 0)
+	if err != nil {
+		t.Fatalf("Failed to list invites: %v", err)
+	}
+	if total != 2 || len(listed) != 2 {
+		t.Fatalf("List = %d invites of %d, want 2 of 2", len(listed), total)
+	}
+	for _, invite := range listed {
+		if invite.Code == code && (invite.UsedBy != "invited" || invite.ClaimedAt == nil) {
+			t.Errorf("Redeemed invite = %+v, want used by invited", invite)
+		}
+	}
+}
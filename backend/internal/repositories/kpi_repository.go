@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// KPIRepository defines the interface for reading community KPIs
+type KPIRepository interface {
+	CommunityStats(now time.Time) (*entities.CommunityStats, error)
+	PlatformStats(now time.Time) (*entities.PlatformStats, error)
+}
+
+// kpiRepository implements KPIRepository using direct SQL
+type kpiRepository struct {
+	db *database.DB
+}
+
+// NewKPIRepository creates a new KPI repository
+func NewKPIRepository(db *database.DB) KPIRepository {
+	return &kpiRepository{
+		db: db,
+	}
+}
+
+// CommunityStats counts live content and recent activity in one query. Only
+// published articles and comments that were not deleted are counted.
+func (r *kpiRepository) CommunityStats(now time.Time) (*entities.CommunityStats, error) {
+	since := now.UTC().Add(-24 * time.Hour)
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users u WHERE ` + activeUser("u.id") + `),
+			(SELECT COUNT(*) FROM articles WHERE status = 'published'),
+			(SELECT COUNT(*) FROM comments WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM favorites),
+			(SELECT COUNT(*) FROM follows),
+			(SELECT COUNT(DISTINCT visitor_hash) FROM analytics_events WHERE day = ?),
+			(SELECT COUNT(*) FROM (
+				SELECT author_id FROM articles WHERE created_at >= ?
+				UNION SELECT author_id FROM comments WHERE created_at >= ?
+				UNION SELECT user_id FROM favorites WHERE created_at >= ?
+				UNION SELECT user_id FROM article_claps WHERE updated_at >= ?
+				UNION SELECT follower_id FROM follows WHERE created_at >= ?
+			))
+	`
+
+	stats := &entities.CommunityStats{}
+	err := r.db.QueryRow(query, now.UTC().Format("2006-01-02"), since, since, since, since, since).Scan(
+		&stats.Users,
+		&stats.Articles,
+		&stats.Comments,
+		&stats.Favorites,
+		&stats.Follows,
+		&stats.DailyActiveVisitors,
+		&stats.DailyActiveUsers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count community stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// PlatformStats adds the moderation workload to the community stats: accounts
+// under a moderation status, articles waiting for review and unresolved
+// anomalies
+func (r *kpiRepository) PlatformStats(now time.Time) (*entities.PlatformStats, error) {
+	community, err := r.CommunityStats(now)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entities.PlatformStats{
+		CommunityStats:    *community,
+		UsersByModeration: map[string]int{},
+	}
+	err = r.db.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM articles WHERE status = 'pending'),
+			(SELECT COUNT(*) FROM anomalies WHERE resolved_at IS NULL)
+	`).Scan(&stats.PendingArticles, &stats.OpenAnomalies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count moderation workload: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT moderation_status, COUNT(*)
+		FROM users
+		WHERE moderation_status != 'none'
+		GROUP BY moderation_status
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count moderated users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan moderated users: %w", err)
+		}
+		stats.UsersByModeration[status] = count
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestKPIRepository_CommunityStats(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+	followRepo := NewFollowRepository(db)
+	favoriteRepo := NewFavoriteRepository(db)
+	kpiRepo := NewKPIRepository(db)
+
+	users := createTestUsers(t, userRepo, "alice", "bob", "carol")
+
+	article, err := articleRepo.Create(users["alice"].ID, &entities.ArticleCreate{
+		Title:       "Published",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	if _, err := articleRepo.Create(users["alice"].ID, &entities.ArticleCreate{
+		Title:       "Pending",
+		Description: "Test description",
+		Body:        "Test body",
+		Status:      entities.ArticleStatusPending,
+	}); err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+	if _, err := commentRepo.Create(users["bob"].ID, article.ID, &entities.CommentCreate{Body: "Nice"}); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if _, err := favoriteRepo.Favorite(users["bob"].ID, article.ID); err != nil {
+		t.Fatalf("Failed to favorite: %v", err)
+	}
+	if _, err := followRepo.Follow(users["bob"].ID, []int64{users["alice"].ID}); err != nil {
+		t.Fatalf("Failed to follow: %v", err)
+	}
+
+	// Carol is deactivated and does not count
+	if _, err := db.Exec("UPDATE users SET deactivated_at = CURRENT_TIMESTAMP WHERE id = ?", users["carol"].ID); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+
+	stats, err := kpiRepo.CommunityStats(time.Now())
+	if err != nil {
+		t.Fatalf("Failed to get community stats: %v", err)
+	}
+
+	expected := entities.CommunityStats{
+		Users:            2,
+		Articles:         1,
+		Comments:         1,
+		Favorites:        1,
+		Follows:          1,
+		DailyActiveUsers: 2,
+	}
+	if *stats != expected {
+		t.Errorf("CommunityStats() = %+v, want %+v", *stats, expected)
+	}
+
+	// A day later nobody has been active
+	if stats, _ := kpiRepo.CommunityStats(time.Now().Add(25 * time.Hour)); stats.DailyActiveUsers != 0 {
+		t.Errorf("DailyActiveUsers a day later = %d, want 0", stats.DailyActiveUsers)
+	}
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// LegalHoldRepository defines the interface for legal hold operations
+type LegalHoldRepository interface {
+	Place(subjectType string, subjectID, placedBy int64, reason string, at time.Time) (*entities.LegalHold, error)
+	Release(subjectType string, subjectID, releasedBy int64, reason string, at time.Time) (*entities.LegalHold, error)
+	List(activeOnly bool) ([]entities.LegalHold, error)
+	BlockDeletion(subjectType string, subjectID int64, withContent bool, at time.Time) (*entities.LegalHold, error)
+}
+
+// legalHoldRepository implements LegalHoldRepository using direct SQL
+type legalHoldRepository struct {
+	db *database.DB
+}
+
+// NewLegalHoldRepository creates a new legal hold repository
+func NewLegalHoldRepository(db *database.DB) LegalHoldRepository {
+	return &legalHoldRepository{
+		db: db,
+	}
+}
+
+// legalHoldSelect reads holds with their subject and admins resolved to names
+const legalHoldSelect = `
+	SELECT h.id, h.subject_type,
+	       COALESCE(CASE h.subject_type WHEN 'user' THEN su.username ELSE sa.slug END, ''),
+	       h.reason, COALESCE(pu.username, ''), h.placed_at,
+	       COALESCE(ru.username, ''), h.released_at, h.release_reason,
+	       h.blocked_attempts, h.last_blocked_at
+	FROM legal_holds h
+	LEFT JOIN users su ON h.subject_type = 'user' AND su.id = h.subject_id
+	LEFT JOIN articles sa ON h.subject_type = 'article' AND sa.id = h.subject_id
+	LEFT JOIN users pu ON pu.id = h.placed_by
+	LEFT JOIN users ru ON ru.id = h.released_by
+`
+
+// Place puts an active hold on the subject
+func (r *legalHoldRepository) Place(subjectType string, subjectID, placedBy int64, reason string, at time.Time) (*entities.LegalHold, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO legal_holds (subject_type, subject_id, reason, placed_by, placed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, subjectType, subjectID, reason, placedBy, at)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, fmt.Errorf("already under legal hold")
+		}
+		return nil, fmt.Errorf("failed to place legal hold: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legal hold ID: %w", err)
+	}
+	return r.getByID(id)
+}
+
+// Release lifts the subject's active hold, recording who lifted it and why
+func (r *legalHoldRepository) Release(subjectType string, subjectID, releasedBy int64, reason string, at time.Time) (*entities.LegalHold, error) {
+	var id int64
+	err := r.db.QueryRow(`
+		UPDATE legal_holds SET released_by = ?, released_at = ?, release_reason = ?
+		WHERE subject_type = ? AND subject_id = ? AND released_at IS NULL
+		RETURNING id
+	`, releasedBy, at, reason, subjectType, subjectID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("legal hold not found")
+		}
+		return nil, fmt.Errorf("failed to release legal hold: %w", err)
+	}
+	return r.getByID(id)
+}
+
+// List returns holds, newest first; released holds are included unless activeOnly is set
+func (r *legalHoldRepository) List(activeOnly bool) ([]entities.LegalHold, error) {
+	query := legalHoldSelect
+	if activeOnly {
+		query += " WHERE h.released_at IS NULL"
+	}
+	query += " ORDER BY h.placed_at DESC, h.id DESC"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query legal holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := []entities.LegalHold{}
+	for rows.Next() {
+		hold, err := scanLegalHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, *hold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over legal holds: %w", err)
+	}
+	return holds, nil
+}
+
+// BlockDeletion returns the active hold that forbids deleting the subject, or
+// nil if there is none, and counts the refused attempt on it. withContent
+// also checks the articles of a user whose content would be deleted with them.
+func (r *legalHoldRepository) BlockDeletion(subjectType string, subjectID int64, withContent bool, at time.Time) (*entities.LegalHold, error) {
+	var id int64
+	err := r.db.QueryRow(`
+		SELECT id FROM legal_holds
+		WHERE released_at IS NULL AND (
+			(subject_type = ? AND subject_id = ?) OR
+			(? AND subject_type = 'article' AND subject_id IN (SELECT id FROM articles WHERE author_id = ?))
+		)
+		ORDER BY placed_at ASC, id ASC
+		LIMIT 1
+	`, subjectType, subjectID, withContent && subjectType == entities.LegalHoldSubjectUser, subjectID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check legal holds: %w", err)
+	}
+
+	if _, err := r.db.Exec("UPDATE legal_holds SET blocked_attempts = blocked_attempts + 1, last_blocked_at = ? WHERE id = ?", at, id); err != nil {
+		return nil, fmt.Errorf("failed to record blocked deletion: %w", err)
+	}
+	return r.getByID(id)
+}
+
+// getByID retrieves a hold by ID
+func (r *legalHoldRepository) getByID(id int64) (*entities.LegalHold, error) {
+	hold, err := scanLegalHold(r.db.QueryRow(legalHoldSelect+" WHERE h.id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("legal hold not found")
+		}
+		return nil, err
+	}
+	return hold, nil
+}
+
+// scanLegalHold reads a row selected with legalHoldSelect
+func scanLegalHold(row interface{ Scan(...interface{}) error }) (*entities.LegalHold, error) {
+	hold := &entities.LegalHold{}
+	var releasedAt, lastBlockedAt sql.NullTime
+	err := row.Scan(
+		&hold.ID,
+		&hold.SubjectType,
+		&hold.Subject,
+		&hold.Reason,
+		&hold.PlacedBy,
+		&hold.PlacedAt,
+		&hold.ReleasedBy,
+		&releasedAt,
+		&hold.ReleaseReason,
+		&hold.BlockedAttempts,
+		&lastBlockedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan legal hold: %w", err)
+	}
+
+	if releasedAt.Valid {
+		hold.ReleasedAt = &releasedAt.Time
+	}
+	if lastBlockedAt.Valid {
+		hold.LastBlockedAt = &lastBlockedAt.Time
+	}
+	return hold, nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestLegalHoldRepository_BlocksDeletionUntilReleased(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	holdRepo := NewLegalHoldRepository(db)
+
+	users := createTestUsers(t, userRepo, "admin", "author")
+
+	article, err := articleRepo.Create(users["author"].ID, &entities.ArticleCreate{
+		Title:       "Evidence",
+		Description: "Test description",
+		Body:        "Test body",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	now := time.Now()
+	hold, err := holdRepo.Place(entities.LegalHoldSubjectArticle, article.ID, users["admin"].ID, "Subpoena 42", now)
+	if err != nil {
+		t.Fatalf("Failed to place hold: %v", err)
+	}
+	if hold.Subject != article.Slug || hold.PlacedBy != "admin" || hold.ReleasedAt != nil {
+		t.Errorf("Unexpected hold: %+v", hold)
+	}
+	if _, err := holdRepo.Place(entities.LegalHoldSubjectArticle, article.ID, users["admin"].ID, "Again", now); err == nil {
+		t.Error("Expected a second active hold on the same article to fail")
+	}
+
+	// Deleting the author with their content is blocked by the article's hold;
+	// keeping the content under the ghost user is not
+	blocked, err := holdRepo.BlockDeletion(entities.LegalHoldSubjectUser, users["author"].ID, true, now)
+	if err != nil {
+		t.Fatalf("BlockDeletion failed: %v", err)
+	}
+	if blocked == nil || blocked.ID != hold.ID || blocked.BlockedAttempts != 1 || blocked.LastBlockedAt == nil {
+		t.Errorf("Expected the article hold to block with one attempt, got %+v", blocked)
+	}
+	if blocked, _ := holdRepo.BlockDeletion(entities.LegalHoldSubjectUser, users["author"].ID, false, now); blocked != nil {
+		t.Errorf("Expected anonymizing to be allowed, got %+v", blocked)
+	}
+
+	released, err := holdRepo.Release(entities.LegalHoldSubjectArticle, article.ID, users["admin"].ID, "Case closed", now)
+	if err != nil {
+		t.Fatalf("Failed to release hold: %v", err)
+	}
+	if released.ReleasedAt == nil || released.ReleasedBy != "admin" || released.ReleaseReason != "Case closed" {
+		t.Errorf("Unexpected released hold: %+v", released)
+	}
+	if _, err := holdRepo.Release(entities.LegalHoldSubjectArticle, article.ID, users["admin"].ID, "Twice", now); err == nil {
+		t.Error("Expected releasing without an active hold to fail")
+	}
+	if blocked, _ := holdRepo.BlockDeletion(entities.LegalHoldSubjectArticle, article.ID, false, now); blocked != nil {
+		t.Errorf("Expected no hold after release, got %+v", blocked)
+	}
+
+	active, err := holdRepo.List(true)
+	if err != nil {
+		t.Fatalf("Failed to list active holds: %v", err)
+	}
+	all, err := holdRepo.List(false)
+	if err != nil {
+		t.Fatalf("Failed to list holds: %v", err)
+	}
+	if len(active) != 0 || len(all) != 1 {
+		t.Errorf("Expected 0 active and 1 total hold, got %d and %d", len(active), len(all))
+	}
+}
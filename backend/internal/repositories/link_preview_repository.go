@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// LinkPreviewRepository defines the interface for link preview data operations
+type LinkPreviewRepository interface {
+	Get(url string) (*entities.LinkPreview, error)
+	Save(preview *entities.LinkPreview) error
+	Attach(articleID int64, url string) error
+	Detach(articleID int64, url string) error
+	ListByArticle(articleID int64) ([]entities.LinkPreview, error)
+}
+
+// linkPreviewRepository implements LinkPreviewRepository using direct SQL
+type linkPreviewRepository struct {
+	db *database.DB
+}
+
+// NewLinkPreviewRepository creates a new link preview repository
+func NewLinkPreviewRepository(db *database.DB) LinkPreviewRepository {
+	return &linkPreviewRepository{
+		db: db,
+	}
+}
+
+// Get returns the stored preview for a URL
+func (r *linkPreviewRepository) Get(url string) (*entities.LinkPreview, error) {
+	query := `
+		SELECT url, title, description, image_url, site_name, fetched_at
+		FROM link_previews
+		WHERE url = ?
+	`
+
+	preview := &entities.LinkPreview{}
+	err := r.db.QueryRow(query, url).Scan(
+		&preview.URL,
+		&preview.Title,
+		&preview.Description,
+		&preview.ImageURL,
+		&preview.SiteName,
+		&preview.FetchedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("link preview not found")
+		}
+		return nil, fmt.Errorf("failed to get link preview: %w", err)
+	}
+
+	return preview, nil
+}
+
+// Save stores a preview, replacing any earlier fetch of the same URL
+func (r *linkPreviewRepository) Save(preview *entities.LinkPreview) error {
+	_, err := r.db.Exec(`
+		INSERT INTO link_previews (url, title, description, image_url, site_name, fetched_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+			title = excluded.title,
+			description = excluded.description,
+			image_url = excluded.image_url,
+			site_name = excluded.site_name,
+			fetched_at = excluded.fetched_at
+	`, preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.SiteName, preview.FetchedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save link preview: %w", err)
+	}
+	return nil
+}
+
+// Attach links a stored preview to an article; attaching twice is a no-op
+func (r *linkPreviewRepository) Attach(articleID int64, url string) error {
+	_, err := r.db.Exec(
+		"INSERT OR IGNORE INTO article_link_previews (article_id, url, created_at) VALUES (?, ?, CURRENT_TIMESTAMP)",
+		articleID, url,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to attach link preview: %w", err)
+	}
+	return nil
+}
+
+// Detach removes a preview from an article
+func (r *linkPreviewRepository) Detach(articleID int64, url string) error {
+	result, err := r.db.Exec("DELETE FROM article_link_previews WHERE article_id = ? AND url = ?", articleID, url)
+	if err != nil {
+		return fmt.Errorf("failed to detach link preview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("link preview not found")
+	}
+	return nil
+}
+
+// ListByArticle returns the previews attached to an article in the order they were added
+func (r *linkPreviewRepository) ListByArticle(articleID int64) ([]entities.LinkPreview, error) {
+	query := `
+		SELECT lp.url, lp.title, lp.description, lp.image_url, lp.site_name, lp.fetched_at
+		FROM article_link_previews alp
+		JOIN link_previews lp ON lp.url = alp.url
+		WHERE alp.article_id = ?
+		ORDER BY alp.created_at ASC, alp.rowid ASC
+	`
+
+	rows, err := r.db.Query(query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query link previews: %w", err)
+	}
+	defer rows.Close()
+
+	previews := []entities.LinkPreview{}
+	for rows.Next() {
+		var preview entities.LinkPreview
+		if err := rows.Scan(&preview.URL, &preview.Title, &preview.Description, &preview.ImageURL, &preview.SiteName, &preview.FetchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan link preview: %w", err)
+		}
+		previews = append(previews, preview)
+	}
+
+	return previews, rows.Err()
+}
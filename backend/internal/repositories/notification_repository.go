@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// NotificationRepository defines the interface for notification data operations
+type NotificationRepository interface {
+	Create(notification *entities.Notification) (*entities.Notification, error)
+	Coalesce(notification *entities.Notification, since time.Time) (*entities.Notification, error)
+	GetSettings(userID int64) (entities.NotificationSettings, error)
+	UpdateSettings(userID int64, settings entities.NotificationSettings) (entities.NotificationSettings, error)
+	GetQuietHours(userID int64) (entities.QuietHours, error)
+	UpdateQuietHours(userID int64, quietHours entities.QuietHours) error
+	ListPending(delivery string) ([]entities.Notification, error)
+	MarkDigested(ids []int64) error
+}
+
+// notificationRepository implements NotificationRepository using direct SQL
+type notificationRepository struct {
+	db *database.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *database.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+// Create stores a notification with its resolved delivery mode
+func (r *notificationRepository) Create(n *entities.Notification) (*entities.Notification, error) {
+	query := `
+		INSERT INTO notifications (user_id, event_type, message, link, delivery, group_key, count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, 1, ?, ?)
+		RETURNING id, count, created_at, updated_at
+	`
+
+	now := time.Now()
+	notification := *n
+	err := r.db.QueryRow(query,
+		n.UserID,
+		n.EventType,
+		n.Message,
+		n.Link,
+		n.Delivery,
+		n.GroupKey,
+		now,
+		now,
+	).Scan(&notification.ID, &notification.Count, &notification.CreatedAt, &notification.UpdatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return &notification, nil
+}
+
+// Coalesce folds a notification into the user's latest one with the same
+// group key and delivery mode, if that was created since the given time and
+// has not been digested yet. The group takes the new message and link, and
+// its count goes up by one. It returns nil if there is no group to join.
+func (r *notificationRepository) Coalesce(n *entities.Notification, since time.Time) (*entities.Notification, error) {
+	query := `
+		UPDATE notifications
+		SET message = ?, link = ?, count = count + 1, updated_at = ?
+		WHERE id = (
+			SELECT id FROM notifications
+			WHERE user_id = ? AND group_key = ? AND delivery = ? AND digested_at IS NULL AND created_at >= ?
+			ORDER BY id DESC
+			LIMIT 1
+		)
+		RETURNING id, count, created_at, updated_at
+	`
+
+	notification := *n
+	err := r.db.QueryRow(query,
+		n.Message,
+		n.Link,
+		time.Now(),
+		n.UserID,
+		n.GroupKey,
+		n.Delivery,
+		since,
+	).Scan(&notification.ID, &notification.Count, &notification.CreatedAt, &notification.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to coalesce notification: %w", err)
+	}
+
+	return &notification, nil
+}
+
+// GetSettings returns the user's delivery mode for every event type
+func (r *notificationRepository) GetSettings(userID int64) (entities.NotificationSettings, error) {
+	query := `
+		SELECT event_type, delivery
+		FROM notification_preferences
+		WHERE user_id = ?
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := entities.DefaultNotificationSettings()
+	for rows.Next() {
+		var eventType, delivery string
+		if err := rows.Scan(&eventType, &delivery); err != nil {
+			return nil, fmt.Errorf("failed to scan notification setting: %w", err)
+		}
+		// Ignore preferences for event types that no longer exist
+		if entities.IsValidNotificationEvent(eventType) {
+			settings[eventType] = delivery
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings upserts the given event preferences and returns the full settings
+func (r *notificationRepository) UpdateSettings(userID int64, settings entities.NotificationSettings) (entities.NotificationSettings, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO notification_preferences (user_id, event_type, delivery, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (user_id, event_type) DO UPDATE SET
+			delivery = excluded.delivery,
+			updated_at = excluded.updated_at
+	`
+
+	now := time.Now()
+	for eventType, delivery := range settings {
+		if _, err := tx.Exec(query, userID, eventType, delivery, now); err != nil {
+			return nil, fmt.Errorf("failed to update notification setting: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit notification settings: %w", err)
+	}
+
+	return r.GetSettings(userID)
+}
+
+// GetQuietHours returns the user's quiet hours, defaulting to off
+func (r *notificationRepository) GetQuietHours(userID int64) (entities.QuietHours, error) {
+	query := `
+		SELECT enabled, start_time, end_time, timezone
+		FROM notification_quiet_hours
+		WHERE user_id = ?
+	`
+
+	quietHours := entities.DefaultQuietHours()
+	err := r.db.QueryRow(query, userID).Scan(&quietHours.Enabled, &quietHours.Start, &quietHours.End, &quietHours.Timezone)
+	if err != nil && err != sql.ErrNoRows {
+		return quietHours, fmt.Errorf("failed to get quiet hours: %w", err)
+	}
+
+	return quietHours, nil
+}
+
+// UpdateQuietHours replaces the user's quiet hours
+func (r *notificationRepository) UpdateQuietHours(userID int64, quietHours entities.QuietHours) error {
+	query := `
+		INSERT INTO notification_quiet_hours (user_id, enabled, start_time, end_time, timezone, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			enabled = excluded.enabled,
+			start_time = excluded.start_time,
+			end_time = excluded.end_time,
+			timezone = excluded.timezone,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.Exec(query, userID, quietHours.Enabled, quietHours.Start, quietHours.End, quietHours.Timezone, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update quiet hours: %w", err)
+	}
+
+	return nil
+}
+
+// ListPending returns notifications of the delivery mode that have not been
+// emailed yet: daily digest ones, or ones held during quiet hours
+func (r *notificationRepository) ListPending(delivery string) ([]entities.Notification, error) {
+	query := `
+		SELECT id, user_id, event_type, message, link, delivery, group_key, count, created_at, updated_at
+		FROM notifications
+		WHERE delivery = ? AND digested_at IS NULL
+		ORDER BY user_id ASC, created_at ASC
+	`
+
+	rows, err := r.db.Query(query, delivery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []entities.Notification
+	for rows.Next() {
+		var n entities.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.EventType, &n.Message, &n.Link, &n.Delivery, &n.GroupKey, &n.Count, &n.CreatedAt, &n.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkDigested records that the notifications were included in a digest or
+// quiet hours summary
+func (r *notificationRepository) MarkDigested(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := []interface{}{time.Now()}
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf("UPDATE notifications SET digested_at = ? WHERE id IN (%s)", joinStrings(placeholders, ", "))
+	if _, err := r.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to mark notifications digested: %w", err)
+	}
+
+	return nil
+}
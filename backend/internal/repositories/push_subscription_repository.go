@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// PushSubscriptionRepository defines the interface for Web Push subscription operations
+type PushSubscriptionRepository interface {
+	Save(userID int64, subscription *entities.PushSubscriptionCreate) (*entities.PushSubscription, error)
+	ListByUser(userID int64) ([]entities.PushSubscription, error)
+	Delete(userID, id int64) error
+	DeleteByEndpoint(endpoint string) error
+}
+
+// pushSubscriptionRepository implements PushSubscriptionRepository using direct SQL
+type pushSubscriptionRepository struct {
+	db *database.DB
+}
+
+// NewPushSubscriptionRepository creates a new push subscription repository
+func NewPushSubscriptionRepository(db *database.DB) PushSubscriptionRepository {
+	return &pushSubscriptionRepository{
+		db: db,
+	}
+}
+
+// Save stores a subscription for the user. Re-subscribing an endpoint replaces
+// its keys and moves it to the user, since a browser profile has one endpoint.
+// It fails with "too many push subscriptions" past MaxPushSubscriptions.
+func (r *pushSubscriptionRepository) Save(userID int64, subscription *entities.PushSubscriptionCreate) (*entities.PushSubscription, error) {
+	var id int64
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		var count int
+		err := tx.QueryRow(`
+			SELECT COUNT(*) FROM push_subscriptions WHERE user_id = ? AND endpoint != ?
+		`, userID, subscription.Endpoint).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("failed to count push subscriptions: %w", err)
+		}
+		if count >= entities.MaxPushSubscriptions {
+			return fmt.Errorf("too many push subscriptions")
+		}
+
+		err = tx.QueryRow(`
+			INSERT INTO push_subscriptions (user_id, endpoint, p256dh, auth, expires_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (endpoint) DO UPDATE SET
+				user_id = excluded.user_id, p256dh = excluded.p256dh, auth = excluded.auth, expires_at = excluded.expires_at
+			RETURNING id
+		`, userID, subscription.Endpoint, subscription.Keys.P256dh, subscription.Keys.Auth, subscription.ExpiresAt(), time.Now()).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("failed to save push subscription: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := scanPushSubscription(r.db.QueryRow(`
+		SELECT id, user_id, endpoint, p256dh, auth, expires_at, created_at
+		FROM push_subscriptions
+		WHERE id = ?
+	`, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get push subscription: %w", err)
+	}
+
+	return saved, nil
+}
+
+// ListByUser returns the user's subscriptions, oldest first
+func (r *pushSubscriptionRepository) ListByUser(userID int64) ([]entities.PushSubscription, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, endpoint, p256dh, auth, expires_at, created_at
+		FROM push_subscriptions
+		WHERE user_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query push subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []entities.PushSubscription{}
+	for rows.Next() {
+		subscription, err := scanPushSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan push subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// Delete removes one of the user's subscriptions
+func (r *pushSubscriptionRepository) Delete(userID, id int64) error {
+	result, err := r.db.Exec("DELETE FROM push_subscriptions WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("push subscription not found")
+	}
+
+	return nil
+}
+
+// DeleteByEndpoint removes the subscription for an endpoint the push service
+// no longer accepts; a missing endpoint is not an error
+func (r *pushSubscriptionRepository) DeleteByEndpoint(endpoint string) error {
+	if _, err := r.db.Exec("DELETE FROM push_subscriptions WHERE endpoint = ?", endpoint); err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	return nil
+}
+
+// scanPushSubscription reads a push subscription row
+func scanPushSubscription(row interface{ Scan(...interface{}) error }) (*entities.PushSubscription, error) {
+	subscription := &entities.PushSubscription{}
+	var expiresAt sql.NullTime
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.Endpoint,
+		&subscription.P256dh,
+		&subscription.Auth,
+		&expiresAt,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		subscription.ExpiresAt = &expiresAt.Time
+	}
+	return subscription, nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestPushSubscriptionRepository_SaveMovesEndpointsAndCaps(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	pushRepo := NewPushSubscriptionRepository(db)
+
+	users := createTestUsers(t, userRepo, "alice", "bob")
+
+	subscription := func(endpoint string) *entities.PushSubscriptionCreate {
+		create := &entities.PushSubscriptionCreate{Endpoint: endpoint}
+		create.Keys.P256dh = "key"
+		create.Keys.Auth = "secret"
+		return create
+	}
+
+	shared := subscription("https://push.example.com/shared")
+	saved, err := pushRepo.Save(users["alice"].ID, shared)
+	if err != nil {
+		t.Fatalf("Failed to save subscription: %v", err)
+	}
+
+	// The same browser signing in as bob takes the endpoint over
+	moved, err := pushRepo.Save(users["bob"].ID, shared)
+	if err != nil {
+		t.Fatalf("Failed to move subscription: %v", err)
+	}
+	if moved.ID != saved.ID || moved.UserID != users["bob"].ID {
+		t.Errorf("Moved subscription = %+v, want ID %d owned by bob", moved, saved.ID)
+	}
+	if list, _ := pushRepo.ListByUser(users["alice"].ID); len(list) != 0 {
+		t.Errorf("Alice still has %d subscriptions, want 0", len(list))
+	}
+
+	for i := 1; i < entities.MaxPushSubscriptions; i++ {
+		if _, err := pushRepo.Save(users["bob"].ID, subscription(fmt.Sprintf("https://push.example.com/%d", i))); err != nil {
+			t.Fatalf("Failed to save subscription %d: %v", i, err)
+		}
+	}
+	if _, err := pushRepo.Save(users["bob"].ID, subscription("https://push.example.com/extra")); err == nil {
+		t.Error("Saving past the subscription cap should fail")
+	}
+	// Re-saving an existing endpoint is always allowed
+	if _, err := pushRepo.Save(users["bob"].ID, shared); err != nil {
+		t.Errorf("Re-saving an endpoint at the cap failed: %v", err)
+	}
+
+	if err := pushRepo.Delete(users["alice"].ID, saved.ID); err == nil {
+		t.Error("Deleting another user's subscription should fail")
+	}
+	if err := pushRepo.DeleteByEndpoint(shared.Endpoint); err != nil {
+		t.Fatalf("Failed to delete by endpoint: %v", err)
+	}
+	if list, _ := pushRepo.ListByUser(users["bob"].ID); len(list) != entities.MaxPushSubscriptions-1 {
+		t.Errorf("Bob has %d subscriptions, want %d", len(list), entities.MaxPushSubscriptions-1)
+	}
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// QuarantineRepository defines the interface for releasing new accounts from quarantine
+type QuarantineRepository interface {
+	GetApproval(userID int64) (*entities.QuarantineApproval, error)
+	Approve(userID, approvedBy int64, at time.Time) (*entities.QuarantineApproval, error)
+}
+
+// quarantineRepository implements QuarantineRepository using direct SQL
+type quarantineRepository struct {
+	db *database.DB
+}
+
+// NewQuarantineRepository creates a new quarantine repository
+func NewQuarantineRepository(db *database.DB) QuarantineRepository {
+	return &quarantineRepository{
+		db: db,
+	}
+}
+
+// GetApproval returns the user's approval, or nil if they have none
+func (r *quarantineRepository) GetApproval(userID int64) (*entities.QuarantineApproval, error) {
+	query := `
+		SELECT COALESCE(u.username, ''), q.approved_at
+		FROM quarantine_approvals q
+		LEFT JOIN users u ON u.id = q.approved_by
+		WHERE q.user_id = ?
+	`
+
+	approval := &entities.QuarantineApproval{}
+	err := r.db.QueryRow(query, userID).Scan(&approval.ApprovedBy, &approval.ApprovedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get quarantine approval: %w", err)
+	}
+	return approval, nil
+}
+
+// Approve releases the user from quarantine; approving an account twice keeps
+// the first approval
+func (r *quarantineRepository) Approve(userID, approvedBy int64, at time.Time) (*entities.QuarantineApproval, error) {
+	query := `
+		INSERT INTO quarantine_approvals (user_id, approved_by, approved_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(query, userID, approvedBy, at); err != nil {
+		return nil, fmt.Errorf("failed to approve account: %w", err)
+	}
+	return r.GetApproval(userID)
+}
+
+// listedAuthor returns a condition matching rows whose user ID column belongs
+// to an account out of quarantine, along with its argument. Accounts that
+// registered within the quarantine window are left out of listings until they
+// age out or an admin approves them; a zero window quarantines no one.
+func listedAuthor(column string, quarantine time.Duration) (string, time.Time) {
+	return column + ` NOT IN (
+		SELECT id FROM users
+		WHERE created_at > ? AND id NOT IN (SELECT user_id FROM quarantine_approvals)
+	)`, time.Now().Add(-quarantine)
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestQuarantineRepository_HidesNewAccountsUntilApproved(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	quarantine := 7 * 24 * time.Hour
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, quarantine)
+	tagRepo := NewTagRepository(db, quarantine)
+	quarantineRepo := NewQuarantineRepository(db)
+
+	users := createTestUsers(t, userRepo, "admin", "veteran", "newbie")
+	if _, err := db.Exec("UPDATE users SET created_at = ? WHERE id = ?", time.Now().Add(-30*24*time.Hour), users["veteran"].ID); err != nil {
+		t.Fatalf("Failed to age account: %v", err)
+	}
+
+	for _, name := range []string{"veteran", "newbie"} {
+		_, err := articleRepo.Create(users[name].ID, &entities.ArticleCreate{
+			Title:       "By " + name,
+			Description: "Test description",
+			Body:        "Test body",
+			TagList:     []string{"go"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+	}
+
+	listed := func(query *entities.ArticleListQuery) int {
+		t.Helper()
+		_, count, err := articleRepo.List(query)
+		if err != nil {
+			t.Fatalf("Failed to list articles: %v", err)
+		}
+		return count
+	}
+	tagged := func() int {
+		t.Helper()
+		tag, err := tagRepo.GetByName("go")
+		if err != nil {
+			t.Fatalf("Failed to get tag: %v", err)
+		}
+		return tag.ArticlesCount
+	}
+
+	if count := listed(&entities.ArticleListQuery{}); count != 1 {
+		t.Errorf("Expected the quarantined article to be left out of the global listing, got %d articles", count)
+	}
+	if count := listed(&entities.ArticleListQuery{Tag: "go"}); count != 1 {
+		t.Errorf("Expected the quarantined article to be left out of the tag listing, got %d articles", count)
+	}
+	if count := tagged(); count != 1 {
+		t.Errorf("Expected the tag to count 1 listed article, got %d", count)
+	}
+	if count := listed(&entities.ArticleListQuery{Author: "newbie"}); count != 1 {
+		t.Errorf("Expected the author's profile to list their article, got %d articles", count)
+	}
+	if _, err := articleRepo.GetBySlug("by-newbie"); err != nil {
+		t.Errorf("Expected the quarantined article to be reachable by slug: %v", err)
+	}
+
+	approval, err := quarantineRepo.Approve(users["newbie"].ID, users["admin"].ID, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to approve account: %v", err)
+	}
+	if approval.ApprovedBy != "admin" {
+		t.Errorf("Expected the approval to record the admin, got %+v", approval)
+	}
+
+	if count := listed(&entities.ArticleListQuery{}); count != 2 {
+		t.Errorf("Expected the approved account's article to be listed, got %d articles", count)
+	}
+	if count := tagged(); count != 2 {
+		t.Errorf("Expected the tag to count 2 listed articles, got %d", count)
+	}
+}
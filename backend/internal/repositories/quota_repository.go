@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// QuotaRepository defines the interface for per-user quota overrides
+type QuotaRepository interface {
+	GetOverride(userID int64) (*entities.QuotaOverride, error)
+	SetOverride(userID, setBy int64, override *entities.QuotaOverride, at time.Time) error
+	DeleteOverride(userID int64) error
+}
+
+// quotaRepository implements QuotaRepository using direct SQL
+type quotaRepository struct {
+	db *database.DB
+}
+
+// NewQuotaRepository creates a new quota repository
+func NewQuotaRepository(db *database.DB) QuotaRepository {
+	return &quotaRepository{
+		db: db,
+	}
+}
+
+// GetOverride returns the user's override, or nil if they have none
+func (r *quotaRepository) GetOverride(userID int64) (*entities.QuotaOverride, error) {
+	query := `
+		SELECT o.articles_per_day, o.comments_per_day, o.note, COALESCE(u.username, ''), o.updated_at
+		FROM user_quota_overrides o
+		LEFT JOIN users u ON u.id = o.set_by
+		WHERE o.user_id = ?
+	`
+
+	override := &entities.QuotaOverride{}
+	var articles, comments sql.NullInt64
+	err := r.db.QueryRow(query, userID).Scan(&articles, &comments, &override.Note, &override.SetBy, &override.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get quota override: %w", err)
+	}
+
+	if articles.Valid {
+		limit := int(articles.Int64)
+		override.Articles = &limit
+	}
+	if comments.Valid {
+		limit := int(comments.Int64)
+		override.Comments = &limit
+	}
+	return override, nil
+}
+
+// SetOverride creates or replaces the user's override
+func (r *quotaRepository) SetOverride(userID, setBy int64, override *entities.QuotaOverride, at time.Time) error {
+	query := `
+		INSERT INTO user_quota_overrides (user_id, articles_per_day, comments_per_day, note, set_by, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			articles_per_day = excluded.articles_per_day,
+			comments_per_day = excluded.comments_per_day,
+			note = excluded.note,
+			set_by = excluded.set_by,
+			updated_at = excluded.updated_at
+	`
+
+	if _, err := r.db.Exec(query, userID, override.Articles, override.Comments, override.Note, setBy, at); err != nil {
+		return fmt.Errorf("failed to set quota override: %w", err)
+	}
+	return nil
+}
+
+// DeleteOverride returns the user to their tier's quota
+func (r *quotaRepository) DeleteOverride(userID int64) error {
+	result, err := r.db.Exec("DELETE FROM user_quota_overrides WHERE user_id = ?", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete quota override: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("quota override not found")
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ReadingListRepository defines the interface for reading list operations
+type ReadingListRepository interface {
+	Create(ownerID int64, listCreate *entities.ReadingListCreate) (*entities.ReadingList, error)
+	GetBySlug(slug string, viewerID int64) (*entities.ReadingList, error)
+	Delete(id int64) error
+	ArticleIDs(listID int64) ([]int64, error)
+	AddItem(listID, articleID int64, position int) error
+	RemoveItem(listID, articleID int64) error
+	Reorder(listID int64, articleIDs []int64) error
+	Follow(listID, userID int64) (bool, error)
+	Unfollow(listID, userID int64) (bool, error)
+}
+
+// readingListRepository implements ReadingListRepository using direct SQL
+type readingListRepository struct {
+	db *database.DB
+}
+
+// NewReadingListRepository creates a new reading list repository
+func NewReadingListRepository(db *database.DB) ReadingListRepository {
+	return &readingListRepository{
+		db: db,
+	}
+}
+
+// Create creates a new reading list with a unique slug derived from its name
+func (r *readingListRepository) Create(ownerID int64, listCreate *entities.ReadingListCreate) (*entities.ReadingList, error) {
+	baseSlug := entities.GenerateSlug(listCreate.Name)
+	if baseSlug == "" {
+		return nil, fmt.Errorf("failed to generate slug from name")
+	}
+
+	taken, err := existingSlugs(r.db, "reading_lists", baseSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result, err := r.db.Exec(`
+		INSERT INTO reading_lists (slug, name, description, owner_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, entities.EnsureUniqueSlug(baseSlug, taken), listCreate.Name, listCreate.Description, ownerID, now, now)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, fmt.Errorf("reading list with this name already exists")
+		}
+		return nil, fmt.Errorf("failed to create reading list: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading list ID: %w", err)
+	}
+
+	return r.get("l.id = ?", id, ownerID)
+}
+
+// GetBySlug retrieves a reading list with its owner's profile and follower
+// count; viewerID 0 is an anonymous viewer. Lists by inactive owners are not found.
+func (r *readingListRepository) GetBySlug(slug string, viewerID int64) (*entities.ReadingList, error) {
+	return r.get("l.slug = ?", slug, viewerID)
+}
+
+// get retrieves the reading list matching condition as seen by the viewer
+func (r *readingListRepository) get(condition string, arg interface{}, viewerID int64) (*entities.ReadingList, error) {
+	query := `
+		SELECT l.id, l.slug, l.name, l.description, l.owner_id, l.created_at, l.updated_at,
+			u.username, u.bio, u.image_url,
+			(SELECT COUNT(*) FROM reading_list_follows f WHERE f.list_id = l.id),
+			EXISTS(SELECT 1 FROM reading_list_follows f WHERE f.list_id = l.id AND f.user_id = ?)
+		FROM reading_lists l
+		JOIN users u ON u.id = l.owner_id
+		WHERE ` + condition + ` AND ` + activeUser("l.owner_id")
+
+	list := &entities.ReadingList{Owner: &entities.Profile{}}
+	err := r.db.QueryRow(query, viewerID, arg).Scan(
+		&list.ID,
+		&list.Slug,
+		&list.Name,
+		&list.Description,
+		&list.OwnerID,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+		&list.Owner.Username,
+		&list.Owner.Bio,
+		&list.Owner.ImageURL,
+		&list.FollowersCount,
+		&list.Following,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("reading list not found")
+		}
+		return nil, fmt.Errorf("failed to get reading list: %w", err)
+	}
+
+	if list.Owner.ImageURL == "" {
+		list.Owner.ImageURL = entities.DefaultAvatarURL(list.Owner.Username)
+	}
+	list.Articles = []entities.Article{}
+
+	return list, nil
+}
+
+// Delete deletes a reading list; its articles are kept
+func (r *readingListRepository) Delete(id int64) error {
+	result, err := r.db.Exec("DELETE FROM reading_lists WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reading list: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("reading list not found")
+	}
+
+	return nil
+}
+
+// ArticleIDs returns the IDs of the list's articles in list order
+func (r *readingListRepository) ArticleIDs(listID int64) ([]int64, error) {
+	rows, err := r.db.Query("SELECT article_id FROM reading_list_items WHERE list_id = ? ORDER BY position", listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading list items: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan reading list item: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// AddItem inserts an article at the 1-based position, shifting later items
+// down; position 0 or past the end appends it. It fails with "already in the
+// reading list" for duplicates and "reading list is full" at MaxReadingListItems.
+func (r *readingListRepository) AddItem(listID, articleID int64, position int) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		var count, last int
+		if err := tx.QueryRow("SELECT COUNT(*), COALESCE(MAX(position), 0) FROM reading_list_items WHERE list_id = ?", listID).Scan(&count, &last); err != nil {
+			return fmt.Errorf("failed to count reading list items: %w", err)
+		}
+		if count >= entities.MaxReadingListItems {
+			return fmt.Errorf("reading list is full")
+		}
+		if position <= 0 || position > last+1 {
+			position = last + 1
+		}
+
+		if _, err := tx.Exec("UPDATE reading_list_items SET position = position + 1 WHERE list_id = ? AND position >= ?", listID, position); err != nil {
+			return fmt.Errorf("failed to reorder reading list: %w", err)
+		}
+
+		if _, err := tx.Exec("INSERT INTO reading_list_items (list_id, article_id, position, added_at) VALUES (?, ?, ?, ?)", listID, articleID, position, time.Now()); err != nil {
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("article is already in the reading list")
+			}
+			return fmt.Errorf("failed to add article to reading list: %w", err)
+		}
+
+		return r.touch(tx, listID)
+	})
+}
+
+// RemoveItem removes an article from the list and closes the gap it leaves
+func (r *readingListRepository) RemoveItem(listID, articleID int64) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		var position int
+		err := tx.QueryRow("SELECT position FROM reading_list_items WHERE list_id = ? AND article_id = ?", listID, articleID).Scan(&position)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("article not found in reading list")
+			}
+			return fmt.Errorf("failed to get reading list item: %w", err)
+		}
+
+		if _, err := tx.Exec("DELETE FROM reading_list_items WHERE list_id = ? AND article_id = ?", listID, articleID); err != nil {
+			return fmt.Errorf("failed to remove article from reading list: %w", err)
+		}
+		if _, err := tx.Exec("UPDATE reading_list_items SET position = position - 1 WHERE list_id = ? AND position > ?", listID, position); err != nil {
+			return fmt.Errorf("failed to reorder reading list: %w", err)
+		}
+
+		return r.touch(tx, listID)
+	})
+}
+
+// Reorder puts the list's items in the given order. articleIDs must name
+// every item exactly once, otherwise it fails with "items do not match".
+func (r *readingListRepository) Reorder(listID int64, articleIDs []int64) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM reading_list_items WHERE list_id = ?", listID).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count reading list items: %w", err)
+		}
+		if count != len(articleIDs) {
+			return fmt.Errorf("items do not match the reading list")
+		}
+
+		seen := make(map[int64]bool, len(articleIDs))
+		for i, articleID := range articleIDs {
+			if seen[articleID] {
+				return fmt.Errorf("items do not match the reading list")
+			}
+			seen[articleID] = true
+
+			result, err := tx.Exec("UPDATE reading_list_items SET position = ? WHERE list_id = ? AND article_id = ?", i+1, listID, articleID)
+			if err != nil {
+				return fmt.Errorf("failed to reorder reading list: %w", err)
+			}
+			if rows, _ := result.RowsAffected(); rows == 0 {
+				return fmt.Errorf("items do not match the reading list")
+			}
+		}
+
+		return r.touch(tx, listID)
+	})
+}
+
+// touch records a change to the reading list
+func (r *readingListRepository) touch(tx *sql.Tx, listID int64) error {
+	if _, err := tx.Exec("UPDATE reading_lists SET updated_at = ? WHERE id = ?", time.Now(), listID); err != nil {
+		return fmt.Errorf("failed to update reading list: %w", err)
+	}
+	return nil
+}
+
+// Follow records the user following the list; it reports false if they already did
+func (r *readingListRepository) Follow(listID, userID int64) (bool, error) {
+	result, err := r.db.Exec("INSERT OR IGNORE INTO reading_list_follows (list_id, user_id) VALUES (?, ?)", listID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to follow reading list: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// Unfollow removes the user's follow; it reports false if they did not follow the list
+func (r *readingListRepository) Unfollow(listID, userID int64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM reading_list_follows WHERE list_id = ? AND user_id = ?", listID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unfollow reading list: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// existingSlugs returns the slugs in table that start with baseSlug
+func existingSlugs(db *database.DB, table, baseSlug string) ([]string, error) {
+	rows, err := db.Query("SELECT slug FROM "+table+" WHERE slug LIKE ? ORDER BY slug", baseSlug+"%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing slugs: %w", err)
+	}
+	defer rows.Close()
+
+	var slugs []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, fmt.Errorf("failed to scan slug: %w", err)
+		}
+		slugs = append(slugs, slug)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to check existing slugs: %w", err)
+	}
+	return slugs, nil
+}
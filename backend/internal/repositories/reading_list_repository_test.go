@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestReadingListRepository_ItemsAndFollows(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	listRepo := NewReadingListRepository(db)
+
+	users := createTestUsers(t, userRepo, "curator", "writer", "reader")
+
+	var ids []int64
+	for _, title := range []string{"First", "Second", "Third"} {
+		article, err := articleRepo.Create(users["writer"].ID, &entities.ArticleCreate{
+			Title:       title,
+			Description: "Test description",
+			Body:        "Test body",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article %s: %v", title, err)
+		}
+		ids = append(ids, article.ID)
+	}
+
+	list, err := listRepo.Create(users["curator"].ID, &entities.ReadingListCreate{Name: "Best of Go"})
+	if err != nil {
+		t.Fatalf("Failed to create reading list: %v", err)
+	}
+
+	for _, id := range ids {
+		if err := listRepo.AddItem(list.ID, id, 0); err != nil {
+			t.Fatalf("AddItem(%d) failed: %v", id, err)
+		}
+	}
+	if err := listRepo.AddItem(list.ID, ids[0], 0); err == nil {
+		t.Error("Adding an article twice should fail")
+	}
+
+	if err := listRepo.Reorder(list.ID, []int64{ids[2], ids[0]}); err == nil {
+		t.Error("Reorder with a missing item should fail")
+	}
+	if err := listRepo.Reorder(list.ID, []int64{ids[2], ids[0], ids[0]}); err == nil {
+		t.Error("Reorder with a repeated item should fail")
+	}
+	if err := listRepo.Reorder(list.ID, []int64{ids[2], ids[0], ids[1]}); err != nil {
+		t.Fatalf("Failed to reorder: %v", err)
+	}
+
+	if err := listRepo.RemoveItem(list.ID, ids[0]); err != nil {
+		t.Fatalf("Failed to remove item: %v", err)
+	}
+	got, err := listRepo.ArticleIDs(list.ID)
+	if err != nil {
+		t.Fatalf("Failed to get items: %v", err)
+	}
+	if len(got) != 2 || got[0] != ids[2] || got[1] != ids[1] {
+		t.Errorf("ArticleIDs = %v, want [%d %d]", got, ids[2], ids[1])
+	}
+
+	if changed, err := listRepo.Follow(list.ID, users["reader"].ID); err != nil || !changed {
+		t.Fatalf("Follow = %v, %v; want true, nil", changed, err)
+	}
+	if changed, _ := listRepo.Follow(list.ID, users["reader"].ID); changed {
+		t.Error("Following twice should not change anything")
+	}
+
+	seen, err := listRepo.GetBySlug(list.Slug, users["reader"].ID)
+	if err != nil {
+		t.Fatalf("Failed to get reading list: %v", err)
+	}
+	if seen.FollowersCount != 1 || !seen.Following {
+		t.Errorf("Reader sees %d followers, following=%v; want 1, true", seen.FollowersCount, seen.Following)
+	}
+
+	seen, _ = listRepo.GetBySlug(list.Slug, 0)
+	if seen.Following {
+		t.Error("Anonymous viewers should not follow the list")
+	}
+
+	if changed, _ := listRepo.Unfollow(list.ID, users["reader"].ID); !changed {
+		t.Error("Unfollow should report a change")
+	}
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+)
+
+// RevokedTokenRepository defines the interface for the denylist of access
+// tokens, by their jti claim, that were revoked before they expire
+type RevokedTokenRepository interface {
+	Revoke(tokenID string, userID int64, expiresAt time.Time) error
+	IsRevoked(tokenID string) (bool, error)
+}
+
+// revokedTokenRepository implements RevokedTokenRepository using direct SQL
+type revokedTokenRepository struct {
+	db *database.DB
+}
+
+// NewRevokedTokenRepository creates a new revoked token repository
+func NewRevokedTokenRepository(db *database.DB) RevokedTokenRepository {
+	return &revokedTokenRepository{
+		db: db,
+	}
+}
+
+// Revoke adds a token to the denylist until it expires. Entries for tokens
+// that have expired since are dropped, as expiry already rejects them.
+func (r *revokedTokenRepository) Revoke(tokenID string, userID int64, expiresAt time.Time) error {
+	if _, err := r.db.Exec(`DELETE FROM revoked_tokens WHERE expires_at < ?`, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to prune revoked tokens: %w", err)
+	}
+
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(query, tokenID, userID, expiresAt.UTC()); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked reports whether the token is on the denylist
+func (r *revokedTokenRepository) IsRevoked(tokenID string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?)`, tokenID).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+	return revoked, nil
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestRevokedTokenRepository_RevokeAndPrune(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	revokedRepo := NewRevokedTokenRepository(db)
+
+	user, err := userRepo.Create(&entities.UserRegistration{
+		Username: "leaver",
+		Email:    "leaver@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	if revoked, err := revokedRepo.IsRevoked("live"); err != nil || revoked {
+		t.Fatalf("Expected unknown token to be allowed, got %v (err: %v)", revoked, err)
+	}
+
+	if err := revokedRepo.Revoke("stale", user.ID, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Failed to revoke token: %v", err)
+	}
+	if err := revokedRepo.Revoke("live", user.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to revoke token: %v", err)
+	}
+	// Revoking twice is harmless
+	if err := revokedRepo.Revoke("live", user.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to revoke token again: %v", err)
+	}
+
+	if revoked, err := revokedRepo.IsRevoked("live"); err != nil || !revoked {
+		t.Fatalf("Expected token to be revoked, got %v (err: %v)", revoked, err)
+	}
+
+	// The expired entry was pruned by the second revocation
+	if revoked, err := revokedRepo.IsRevoked("stale"); err != nil || revoked {
+		t.Errorf("Expected expired entry to be pruned, got %v (err: %v)", revoked, err)
+	}
+}
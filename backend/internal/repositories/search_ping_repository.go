@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// SearchPingRepository defines the interface for the search ping queue
+type SearchPingRepository interface {
+	Enqueue(target, url string, at time.Time) error
+	Due(now time.Time, limit int) ([]entities.SearchPing, error)
+	MarkDelivered(id int64, at time.Time) error
+	MarkFailed(id int64, message string, at time.Time, retryAt *time.Time) error
+	List(status string, limit, offset int) ([]entities.SearchPing, int, error)
+	PendingCount() (int, error)
+}
+
+// searchPingRepository implements SearchPingRepository using direct SQL
+type searchPingRepository struct {
+	db *database.DB
+}
+
+// NewSearchPingRepository creates a new search ping repository
+func NewSearchPingRepository(db *database.DB) SearchPingRepository {
+	return &searchPingRepository{
+		db: db,
+	}
+}
+
+// Enqueue queues a ping for immediate delivery. A ping still pending for the
+// same target and URL already covers it, so nothing is added.
+func (r *searchPingRepository) Enqueue(target, url string, at time.Time) error {
+	query := `
+		INSERT INTO search_pings (target, url, next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (target, url) WHERE status = 'pending' DO NOTHING
+	`
+
+	if _, err := r.db.Exec(query, target, url, at, at, at); err != nil {
+		return fmt.Errorf("failed to queue search ping: %w", err)
+	}
+	return nil
+}
+
+// Due returns up to limit pending pings whose next attempt is due, oldest first
+func (r *searchPingRepository) Due(now time.Time, limit int) ([]entities.SearchPing, error) {
+	query := `
+		SELECT id, target, url, status, attempts, last_error, next_attempt_at, delivered_at, created_at
+		FROM search_pings
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due search pings: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSearchPings(rows)
+}
+
+// MarkDelivered records a successful delivery
+func (r *searchPingRepository) MarkDelivered(id int64, at time.Time) error {
+	query := `
+		UPDATE search_pings
+		SET status = 'delivered', attempts = attempts + 1, last_error = '', delivered_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.db.Exec(query, at, at, id); err != nil {
+		return fmt.Errorf("failed to mark search ping delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. The ping is retried at retryAt, or
+// given up on when retryAt is nil.
+func (r *searchPingRepository) MarkFailed(id int64, message string, at time.Time, retryAt *time.Time) error {
+	status, next := entities.SearchPingStatusFailed, at
+	if retryAt != nil {
+		status, next = entities.SearchPingStatusPending, *retryAt
+	}
+
+	query := `
+		UPDATE search_pings
+		SET status = ?, attempts = attempts + 1, last_error = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	if _, err := r.db.Exec(query, status, message, next, at, id); err != nil {
+		return fmt.Errorf("failed to record search ping failure: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of pings with the given status, newest first, and the
+// total number with that status
+func (r *searchPingRepository) List(status string, limit, offset int) ([]entities.SearchPing, int, error) {
+	where, args := "", []interface{}{}
+	if status != entities.SearchPingStatusAll {
+		where, args = "WHERE status = ?", append(args, status)
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM search_pings "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count search pings: %w", err)
+	}
+
+	query := `
+		SELECT id, target, url, status, attempts, last_error, next_attempt_at, delivered_at, created_at
+		FROM search_pings
+		` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query search pings: %w", err)
+	}
+	defer rows.Close()
+
+	pings, err := scanSearchPings(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return pings, total, nil
+}
+
+// PendingCount returns how many pings are still waiting for delivery
+func (r *searchPingRepository) PendingCount() (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM search_pings WHERE status = ?", entities.SearchPingStatusPending).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending search pings: %w", err)
+	}
+	return count, nil
+}
+
+// scanSearchPings reads every row of a search ping query
+func scanSearchPings(rows *sql.Rows) ([]entities.SearchPing, error) {
+	pings := []entities.SearchPing{}
+	for rows.Next() {
+		var ping entities.SearchPing
+		var deliveredAt sql.NullTime
+		if err := rows.Scan(&ping.ID, &ping.Target, &ping.URL, &ping.Status, &ping.Attempts,
+			&ping.LastError, &ping.NextAttempt, &deliveredAt, &ping.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan search ping: %w", err)
+		}
+		if deliveredAt.Valid {
+			ping.DeliveredAt = &deliveredAt.Time
+		}
+		pings = append(pings, ping)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over search pings: %w", err)
+	}
+	return pings, nil
+}
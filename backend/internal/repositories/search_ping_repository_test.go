@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestSearchPingRepository_QueueLifecycle(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewSearchPingRepository(db)
+	now := time.Now().UTC()
+
+	// A second edit while the first ping is pending is coalesced
+	for i := 0; i < 2; i++ {
+		if err := repo.Enqueue(entities.SearchPingIndexNow, "https://blog.example/article/a", now); err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+	}
+	if err := repo.Enqueue(entities.SearchPingSitemap, "https://engine.example/ping", now); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+
+	due, err := repo.Due(now, 10)
+	if err != nil {
+		t.Fatalf("Failed to get due pings: %v", err)
+	}
+	if len(due) != 2 {
+		t.Fatalf("Got %d due pings, want 2", len(due))
+	}
+
+	if err := repo.MarkDelivered(due[0].ID, now); err != nil {
+		t.Fatalf("Failed to mark delivered: %v", err)
+	}
+	retryAt := now.Add(time.Minute)
+	if err := repo.MarkFailed(due[1].ID, "status 503", now, &retryAt); err != nil {
+		t.Fatalf("Failed to mark failed: %v", err)
+	}
+
+	// The retry is not due yet
+	if due, _ := repo.Due(now, 10); len(due) != 0 {
+		t.Errorf("Got %d due pings before the retry time, want 0", len(due))
+	}
+	if due, _ := repo.Due(retryAt, 10); len(due) != 1 || due[0].Attempts != 1 || due[0].LastError != "status 503" {
+		t.Errorf("Due after retry time = %+v, want the failed ping", due)
+	}
+
+	// Once delivered, a new edit queues a fresh ping
+	if err := repo.Enqueue(entities.SearchPingIndexNow, "https://blog.example/article/a", now); err != nil {
+		t.Fatalf("Failed to enqueue: %v", err)
+	}
+
+	delivered, total, err := repo.List(entities.SearchPingStatusDelivered, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list pings: %v", err)
+	}
+	if total != 1 || delivered[0].DeliveredAt == nil || delivered[0].Attempts != 1 {
+		t.Errorf("Delivered pings = %+v, want one with a delivery time", delivered)
+	}
+	if _, total, _ := repo.List(entities.SearchPingStatusAll, 10, 0); total != 3 {
+		t.Errorf("Total pings = %d, want 3", total)
+	}
+}
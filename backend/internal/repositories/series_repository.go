@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// SeriesRepository defines the interface for article series operations
+type SeriesRepository interface {
+	Create(authorID int64, seriesCreate *entities.SeriesCreate) (*entities.Series, error)
+	GetBySlug(slug string) (*entities.Series, error)
+	GetByID(id int64) (*entities.Series, error)
+	Delete(id int64) error
+	ArticleIDs(seriesID int64) ([]int64, error)
+	AddArticle(seriesID, articleID int64, position int) error
+	RemoveArticle(seriesID, articleID int64) error
+	Navigation(articleID int64) (*entities.SeriesNavigation, error)
+}
+
+// seriesRepository implements SeriesRepository using direct SQL
+type seriesRepository struct {
+	db *database.DB
+}
+
+// NewSeriesRepository creates a new series repository
+func NewSeriesRepository(db *database.DB) SeriesRepository {
+	return &seriesRepository{
+		db: db,
+	}
+}
+
+// Create creates a new series with a unique slug derived from its title
+func (r *seriesRepository) Create(authorID int64, seriesCreate *entities.SeriesCreate) (*entities.Series, error) {
+	baseSlug := entities.GenerateSlug(seriesCreate.Title)
+	if baseSlug == "" {
+		return nil, fmt.Errorf("failed to generate slug from title")
+	}
+
+	taken, err := existingSlugs(r.db, "series", baseSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result, err := r.db.Exec(`
+		INSERT INTO series (slug, title, description, author_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, entities.EnsureUniqueSlug(baseSlug, taken), seriesCreate.Title, seriesCreate.Description, authorID, now, now)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, fmt.Errorf("series with this title already exists")
+		}
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series ID: %w", err)
+	}
+
+	return r.get("s.id = ?", id)
+}
+
+// GetBySlug retrieves a series with its author's profile; series by inactive
+// authors are not found
+func (r *seriesRepository) GetBySlug(slug string) (*entities.Series, error) {
+	return r.get("s.slug = ?", slug)
+}
+
+// GetByID retrieves a series by ID with its author's profile
+func (r *seriesRepository) GetByID(id int64) (*entities.Series, error) {
+	return r.get("s.id = ?", id)
+}
+
+// get retrieves the series matching condition
+func (r *seriesRepository) get(condition string, arg interface{}) (*entities.Series, error) {
+	query := `
+		SELECT s.id, s.slug, s.title, s.description, s.author_id, s.created_at, s.updated_at,
+			u.username, u.bio, u.image_url
+		FROM series s
+		JOIN users u ON u.id = s.author_id
+		WHERE ` + condition + ` AND ` + activeUser("s.author_id")
+
+	series := &entities.Series{Author: &entities.Profile{}}
+	err := r.db.QueryRow(query, arg).Scan(
+		&series.ID,
+		&series.Slug,
+		&series.Title,
+		&series.Description,
+		&series.AuthorID,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+		&series.Author.Username,
+		&series.Author.Bio,
+		&series.Author.ImageURL,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("series not found")
+		}
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	if series.Author.ImageURL == "" {
+		series.Author.ImageURL = entities.DefaultAvatarURL(series.Author.Username)
+	}
+	series.Articles = []entities.Article{}
+
+	return series, nil
+}
+
+// Delete deletes a series; its articles are kept
+func (r *seriesRepository) Delete(id int64) error {
+	result, err := r.db.Exec("DELETE FROM series WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("series not found")
+	}
+
+	return nil
+}
+
+// ArticleIDs returns the IDs of the series' articles in series order
+func (r *seriesRepository) ArticleIDs(seriesID int64) ([]int64, error) {
+	rows, err := r.db.Query("SELECT article_id FROM series_articles WHERE series_id = ? ORDER BY position", seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series articles: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan series article: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// AddArticle inserts an article at the 1-based position, shifting later
+// articles down; position 0 or past the end appends it. It fails with
+// "already in a series" if the article belongs to any series.
+func (r *seriesRepository) AddArticle(seriesID, articleID int64, position int) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		// Deleted articles can leave gaps, so append after the last position
+		var last int
+		if err := tx.QueryRow("SELECT COALESCE(MAX(position), 0) FROM series_articles WHERE series_id = ?", seriesID).Scan(&last); err != nil {
+			return fmt.Errorf("failed to count series articles: %w", err)
+		}
+		if position <= 0 || position > last+1 {
+			position = last + 1
+		}
+
+		if _, err := tx.Exec("UPDATE series_articles SET position = position + 1 WHERE series_id = ? AND position >= ?", seriesID, position); err != nil {
+			return fmt.Errorf("failed to reorder series: %w", err)
+		}
+
+		if _, err := tx.Exec("INSERT INTO series_articles (series_id, article_id, position) VALUES (?, ?, ?)", seriesID, articleID, position); err != nil {
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("article is already in a series")
+			}
+			return fmt.Errorf("failed to add article to series: %w", err)
+		}
+
+		return r.touch(tx, seriesID)
+	})
+}
+
+// RemoveArticle detaches an article from the series and closes the gap it leaves
+func (r *seriesRepository) RemoveArticle(seriesID, articleID int64) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		var position int
+		err := tx.QueryRow("SELECT position FROM series_articles WHERE series_id = ? AND article_id = ?", seriesID, articleID).Scan(&position)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("article not found in series")
+			}
+			return fmt.Errorf("failed to get series article: %w", err)
+		}
+
+		if _, err := tx.Exec("DELETE FROM series_articles WHERE series_id = ? AND article_id = ?", seriesID, articleID); err != nil {
+			return fmt.Errorf("failed to remove article from series: %w", err)
+		}
+		if _, err := tx.Exec("UPDATE series_articles SET position = position - 1 WHERE series_id = ? AND position > ?", seriesID, position); err != nil {
+			return fmt.Errorf("failed to reorder series: %w", err)
+		}
+
+		return r.touch(tx, seriesID)
+	})
+}
+
+// touch records a change to the series
+func (r *seriesRepository) touch(tx *sql.Tx, seriesID int64) error {
+	if _, err := tx.Exec("UPDATE series SET updated_at = ? WHERE id = ?", time.Now(), seriesID); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+	return nil
+}
+
+// Navigation places an article within its series among the published
+// articles (and the article itself, so authors can preview drafts). It returns
+// nil if the article is not in a series.
+func (r *seriesRepository) Navigation(articleID int64) (*entities.SeriesNavigation, error) {
+	query := `
+		SELECT s.slug, s.title, a.id, a.slug, a.title
+		FROM series_articles sa
+		JOIN series s ON s.id = sa.series_id
+		JOIN articles a ON a.id = sa.article_id
+		WHERE sa.series_id = (SELECT series_id FROM series_articles WHERE article_id = ?)
+			AND (a.status = 'published' OR a.id = ?)
+		ORDER BY sa.position
+	`
+
+	rows, err := r.db.Query(query, articleID, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series navigation: %w", err)
+	}
+	defer rows.Close()
+
+	var navigation *entities.SeriesNavigation
+	var links []entities.SeriesLink
+	for rows.Next() {
+		var seriesSlug, seriesTitle string
+		var id int64
+		var link entities.SeriesLink
+		if err := rows.Scan(&seriesSlug, &seriesTitle, &id, &link.Slug, &link.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan series navigation: %w", err)
+		}
+		if navigation == nil {
+			navigation = &entities.SeriesNavigation{Slug: seriesSlug, Title: seriesTitle}
+		}
+		links = append(links, link)
+		if id == articleID {
+			navigation.Position = len(links)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over series navigation: %w", err)
+	}
+
+	if navigation == nil {
+		return nil, nil
+	}
+
+	navigation.Total = len(links)
+	if navigation.Position > 1 {
+		navigation.Previous = &links[navigation.Position-2]
+	}
+	if navigation.Position < len(links) {
+		navigation.Next = &links[navigation.Position]
+	}
+
+	return navigation, nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestSeriesRepository_OrderingAndNavigation(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	seriesRepo := NewSeriesRepository(db)
+
+	author, err := userRepo.Create(&entities.UserRegistration{
+		Username: "author",
+		Email:    "author@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	series, err := seriesRepo.Create(author.ID, &entities.SeriesCreate{Title: "Learning Go"})
+	if err != nil {
+		t.Fatalf("Failed to create series: %v", err)
+	}
+	if series.Slug != "learning-go" || series.Author == nil || series.Author.Username != "author" {
+		t.Errorf("Series = %+v, want slug learning-go by author", series)
+	}
+
+	articles := make(map[string]*entities.Article)
+	for _, title := range []string{"Part One", "Part Two", "Part Three"} {
+		article, err := articleRepo.Create(author.ID, &entities.ArticleCreate{
+			Title:       title,
+			Description: "Test description",
+			Body:        "Test body",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article %s: %v", title, err)
+		}
+		articles[title] = article
+	}
+
+	// Append two, then insert the middle part between them
+	for _, add := range []struct {
+		title    string
+		position int
+	}{{"Part One", 0}, {"Part Three", 0}, {"Part Two", 2}} {
+		if err := seriesRepo.AddArticle(series.ID, articles[add.title].ID, add.position); err != nil {
+			t.Fatalf("AddArticle(%s) failed: %v", add.title, err)
+		}
+	}
+	if err := seriesRepo.AddArticle(series.ID, articles["Part One"].ID, 0); err == nil {
+		t.Error("Adding an article twice should fail")
+	}
+
+	ids, err := seriesRepo.ArticleIDs(series.ID)
+	if err != nil {
+		t.Fatalf("Failed to get series articles: %v", err)
+	}
+	want := []int64{articles["Part One"].ID, articles["Part Two"].ID, articles["Part Three"].ID}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("ArticleIDs = %v, want %v", ids, want)
+	}
+
+	navigation, err := seriesRepo.Navigation(articles["Part Two"].ID)
+	if err != nil {
+		t.Fatalf("Failed to get navigation: %v", err)
+	}
+	if navigation == nil || navigation.Position != 2 || navigation.Total != 3 {
+		t.Fatalf("Navigation = %+v, want position 2 of 3", navigation)
+	}
+	if navigation.Previous == nil || navigation.Previous.Slug != articles["Part One"].Slug {
+		t.Errorf("Previous = %+v, want part one", navigation.Previous)
+	}
+	if navigation.Next == nil || navigation.Next.Slug != articles["Part Three"].Slug {
+		t.Errorf("Next = %+v, want part three", navigation.Next)
+	}
+
+	if err := seriesRepo.RemoveArticle(series.ID, articles["Part One"].ID); err != nil {
+		t.Fatalf("Failed to remove article: %v", err)
+	}
+	navigation, _ = seriesRepo.Navigation(articles["Part Two"].ID)
+	if navigation == nil || navigation.Position != 1 || navigation.Previous != nil {
+		t.Errorf("Navigation after removal = %+v, want first with no previous", navigation)
+	}
+
+	if navigation, _ := seriesRepo.Navigation(articles["Part One"].ID); navigation != nil {
+		t.Errorf("Navigation for removed article = %+v, want nil", navigation)
+	}
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// sessionTouchInterval limits how often authenticating with a session's
+// tokens records its last use, so active clients do not write on every request
+const sessionTouchInterval = time.Minute
+
+// SessionRepository defines the interface for login session operations
+type SessionRepository interface {
+	Create(userID int64, userAgent, ip string, at, expiresAt time.Time) (*entities.Session, error)
+	Extend(id string, expiresAt time.Time) error
+	Use(id string, at time.Time) (bool, error)
+	ListActive(userID int64, at time.Time) ([]entities.Session, error)
+	Revoke(userID int64, id string, at time.Time) error
+}
+
+// sessionRepository implements SessionRepository using direct SQL
+type sessionRepository struct {
+	db *database.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *database.DB) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+// sessionColumns are the columns scanned by scanSession
+const sessionColumns = `id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked_at`
+
+// Create starts a session for a login from the given user agent and IP,
+// lasting until expiresAt. Sessions that expired or were revoked over a day
+// ago are dropped.
+func (r *sessionRepository) Create(userID int64, userAgent, ip string, at, expiresAt time.Time) (*entities.Session, error) {
+	if _, err := r.db.Exec(`DELETE FROM sessions WHERE expires_at < ? OR revoked_at < ?`, at.AddDate(0, 0, -1), at.AddDate(0, 0, -1)); err != nil {
+		return nil, fmt.Errorf("failed to prune sessions: %w", err)
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	if len(userAgent) > entities.MaxUserAgentLength {
+		userAgent = userAgent[:entities.MaxUserAgentLength]
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO sessions (id, user_id, user_agent, ip, created_at, last_used_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, userID, userAgent, ip, at, at, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return &entities.Session{
+		ID:         id,
+		UserID:     userID,
+		UserAgent:  userAgent,
+		Device:     entities.DescribeDevice(userAgent),
+		IP:         ip,
+		CreatedAt:  at,
+		LastUsedAt: at,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// Extend moves the session's expiry, when a new token is issued for it
+func (r *sessionRepository) Extend(id string, expiresAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE sessions SET expires_at = MAX(expires_at, ?) WHERE id = ? AND revoked_at IS NULL`, expiresAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// Use reports whether the session is active, recording its use at most once
+// per sessionTouchInterval
+func (r *sessionRepository) Use(id string, at time.Time) (bool, error) {
+	session, err := scanSession(r.db.QueryRow(`SELECT `+sessionColumns+` FROM sessions WHERE id = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get session: %w", err)
+	}
+	if !session.IsActive(at) {
+		return false, nil
+	}
+
+	if at.Sub(session.LastUsedAt) >= sessionTouchInterval {
+		if _, err := r.db.Exec(`UPDATE sessions SET last_used_at = ? WHERE id = ?`, at, id); err != nil {
+			return false, fmt.Errorf("failed to record session use: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// ListActive returns the user's active sessions, most recently used first
+func (r *sessionRepository) ListActive(userID int64, at time.Time) ([]entities.Session, error) {
+	rows, err := r.db.Query(`
+		SELECT `+sessionColumns+`
+		FROM sessions
+		WHERE user_id = ? AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY last_used_at DESC, created_at DESC
+	`, userID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []entities.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke ends one of the user's sessions, so its tokens are rejected from now on
+func (r *sessionRepository) Revoke(userID int64, id string, at time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE sessions SET revoked_at = ?
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`, at, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// scanSession reads a session row
+func scanSession(row interface{ Scan(...interface{}) error }) (*entities.Session, error) {
+	session := &entities.Session{}
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Device = entities.DescribeDevice(session.UserAgent)
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return session, nil
+}
+
+// newSessionID returns a random session ID
+func newSessionID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// SettingsRepository defines the interface for user settings operations
+type SettingsRepository interface {
+	Get(userID int64) (entities.UserSettings, error)
+	Update(userID int64, update *entities.UserSettingsUpdate) (entities.UserSettings, error)
+}
+
+// settingsRepository implements SettingsRepository using direct SQL
+type settingsRepository struct {
+	db *database.DB
+}
+
+// NewSettingsRepository creates a new settings repository
+func NewSettingsRepository(db *database.DB) SettingsRepository {
+	return &settingsRepository{
+		db: db,
+	}
+}
+
+// Get returns the user's settings, or the defaults if they never changed them
+func (r *settingsRepository) Get(userID int64) (entities.UserSettings, error) {
+	query := `
+		SELECT profile_visibility, show_favorites, record_reading_history, searchable, timezone, locale
+		FROM user_settings
+		WHERE user_id = ?
+	`
+
+	settings := entities.DefaultUserSettings()
+	err := r.db.QueryRow(query, userID).Scan(
+		&settings.ProfileVisibility,
+		&settings.ShowFavorites,
+		&settings.RecordReadingHistory,
+		&settings.Searchable,
+		&settings.Timezone,
+		&settings.Locale,
+	)
+	if err != nil && err != sql.ErrNoRows {
+		return entities.UserSettings{}, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Update applies the update to the user's current settings and stores the result
+func (r *settingsRepository) Update(userID int64, update *entities.UserSettingsUpdate) (entities.UserSettings, error) {
+	current, err := r.Get(userID)
+	if err != nil {
+		return entities.UserSettings{}, err
+	}
+	settings := update.Apply(current)
+
+	query := `
+		INSERT INTO user_settings (user_id, profile_visibility, show_favorites, record_reading_history, searchable,
+			timezone, locale, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			profile_visibility = excluded.profile_visibility,
+			show_favorites = excluded.show_favorites,
+			record_reading_history = excluded.record_reading_history,
+			searchable = excluded.searchable,
+			timezone = excluded.timezone,
+			locale = excluded.locale,
+			updated_at = excluded.updated_at
+	`
+
+	if _, err := r.db.Exec(query, userID, settings.ProfileVisibility, settings.ShowFavorites,
+		settings.RecordReadingHistory, settings.Searchable, settings.Timezone, settings.Locale, time.Now()); err != nil {
+		return entities.UserSettings{}, fmt.Errorf("failed to update user settings: %w", err)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// SigningKeyRepository defines the interface for the access token signing keys
+type SigningKeyRepository interface {
+	List() ([]entities.SigningKey, error)
+	Rotate(key *entities.SigningKey) error
+	SetPrivateKey(id string, privateKey []byte) error
+	DeleteRetiredBefore(cutoff time.Time) (int64, error)
+}
+
+// signingKeyRepository implements SigningKeyRepository using direct SQL
+type signingKeyRepository struct {
+	db *database.DB
+}
+
+// NewSigningKeyRepository creates a new signing key repository
+func NewSigningKeyRepository(db *database.DB) SigningKeyRepository {
+	return &signingKeyRepository{
+		db: db,
+	}
+}
+
+// List returns every stored key, oldest first
+func (r *signingKeyRepository) List() ([]entities.SigningKey, error) {
+	rows, err := r.db.Query(`
+		SELECT kid, algorithm, private_key, created_at, retired_at
+		FROM signing_keys
+		ORDER BY created_at ASC, kid ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []entities.SigningKey{}
+	for rows.Next() {
+		var key entities.SigningKey
+		var retiredAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.Algorithm, &key.PrivateKey, &key.CreatedAt, &retiredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Rotate stores a new current key and retires every other key, in one transaction
+func (r *signingKeyRepository) Rotate(key *entities.SigningKey) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`UPDATE signing_keys SET retired_at = ? WHERE retired_at IS NULL`, key.CreatedAt); err != nil {
+			return fmt.Errorf("failed to retire signing keys: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO signing_keys (kid, algorithm, private_key, created_at)
+			VALUES (?, ?, ?, ?)
+		`, key.ID, key.Algorithm, key.PrivateKey, key.CreatedAt); err != nil {
+			return fmt.Errorf("failed to store signing key: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetPrivateKey replaces a key's stored private key, such as when it is
+// re-encrypted under a new key
+func (r *signingKeyRepository) SetPrivateKey(id string, privateKey []byte) error {
+	result, err := r.db.Exec(`UPDATE signing_keys SET private_key = ? WHERE kid = ?`, privateKey, id)
+	if err != nil {
+		return fmt.Errorf("failed to update signing key: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("signing key not found")
+	}
+
+	return nil
+}
+
+// DeleteRetiredBefore removes keys retired before cutoff, returning how many
+func (r *signingKeyRepository) DeleteRetiredBefore(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM signing_keys WHERE retired_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete retired signing keys: %w", err)
+	}
+	return result.RowsAffected()
+}
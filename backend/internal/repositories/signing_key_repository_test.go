@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestSigningKeyRepository_RotateAndPrune(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewSigningKeyRepository(db)
+	start := time.Now().UTC().Add(-time.Hour)
+
+	if err := repo.Rotate(&entities.SigningKey{ID: "first", Algorithm: entities.JWTAlgorithmRS256, PrivateKey: []byte{1}, CreatedAt: start}); err != nil {
+		t.Fatalf("Failed to store first key: %v", err)
+	}
+	if err := repo.Rotate(&entities.SigningKey{ID: "second", Algorithm: entities.JWTAlgorithmEdDSA, PrivateKey: []byte{2}, CreatedAt: start.Add(30 * time.Minute)}); err != nil {
+		t.Fatalf("Failed to store second key: %v", err)
+	}
+
+	keys, err := repo.List()
+	if err != nil {
+		t.Fatalf("Failed to list keys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "first" || keys[1].ID != "second" {
+		t.Fatalf("Expected both keys oldest first, got %+v", keys)
+	}
+	if keys[0].RetiredAt == nil || !keys[0].RetiredAt.Equal(keys[1].CreatedAt) {
+		t.Errorf("Expected the first key to be retired when the second was stored, got %v", keys[0].RetiredAt)
+	}
+	if keys[1].RetiredAt != nil || string(keys[1].PrivateKey) != "\x02" {
+		t.Errorf("Expected the second key to be current, got %+v", keys[1])
+	}
+
+	if err := repo.SetPrivateKey("second", []byte{3}); err != nil {
+		t.Fatalf("Failed to replace private key: %v", err)
+	}
+	if keys, _ := repo.List(); string(keys[1].PrivateKey) != "\x03" {
+		t.Errorf("Expected the replaced private key, got %v", keys[1].PrivateKey)
+	}
+	if err := repo.SetPrivateKey("missing", []byte{3}); err == nil || err.Error() != "signing key not found" {
+		t.Errorf("Expected a missing key to be reported, got %v", err)
+	}
+
+	// Only retired keys are pruned
+	deleted, err := repo.DeleteRetiredBefore(time.Now().UTC())
+	if err != nil || deleted != 1 {
+		t.Fatalf("Expected 1 retired key deleted, got %d (err: %v)", deleted, err)
+	}
+	if keys, _ := repo.List(); len(keys) != 1 || keys[0].ID != "second" {
+		t.Errorf("Expected only the current key to remain, got %+v", keys)
+	}
+}
@@ -0,0 +1,403 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// TagRepository defines the interface for tag data operations
+type TagRepository interface {
+	List() ([]string, error)
+	ListDetailed() ([]entities.Tag, error)
+	GetByName(name string) (*entities.Tag, error)
+	ResolveName(name string) (string, error)
+	Rename(name, newName string) (*entities.Tag, error)
+	Merge(source, target string) (*entities.Tag, error)
+	AddAlias(name, alias string) (*entities.Tag, error)
+	RemoveAlias(alias string) error
+	UpdateDescription(name, description string) (*entities.Tag, error)
+	GetTopAuthors(tagID int64, limit int) ([]entities.TagAuthor, error)
+}
+
+// tagRepository implements TagRepository using direct SQL
+type tagRepository struct {
+	db         *database.DB
+	quarantine time.Duration
+}
+
+// NewTagRepository creates a new tag repository. Public tag listings leave out
+// articles by accounts younger than quarantine until an admin approves the account.
+func NewTagRepository(db *database.DB, quarantine time.Duration) TagRepository {
+	return &tagRepository{
+		db:         db,
+		quarantine: quarantine,
+	}
+}
+
+// List returns canonical tag names ordered by usage
+func (r *tagRepository) List() ([]string, error) {
+	listed, cutoff := r.listedArticles()
+	query := `
+		SELECT t.name
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id AND ` + listed + `
+		GROUP BY t.id
+		ORDER BY COUNT(at.article_id) DESC, t.name ASC
+	`
+
+	rows, err := r.db.Query(query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, name)
+	}
+
+	return tags, rows.Err()
+}
+
+// ListDetailed returns canonical tags with article counts and aliases
+func (r *tagRepository) ListDetailed() ([]entities.Tag, error) {
+	query := `
+		SELECT t.id, t.name, t.description, COUNT(at.article_id)
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []entities.Tag{}
+	for rows.Next() {
+		var tag entities.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Description, &tag.ArticlesCount); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over tags: %w", err)
+	}
+	rows.Close()
+
+	// Load aliases after the rows are closed (single connection database)
+	for i := range tags {
+		aliases, err := r.getAliases(tags[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		tags[i].Aliases = aliases
+	}
+
+	return tags, nil
+}
+
+// GetByName retrieves a canonical tag by name or alias
+func (r *tagRepository) GetByName(name string) (*entities.Tag, error) {
+	canonical, err := r.ResolveName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	listed, cutoff := r.listedArticles()
+	query := `
+		SELECT t.id, t.name, t.description, COUNT(at.article_id)
+		FROM tags t
+		LEFT JOIN article_tags at ON at.tag_id = t.id AND ` + listed + `
+		WHERE t.name = ?
+		GROUP BY t.id
+	`
+
+	tag := &entities.Tag{}
+	err = r.db.QueryRow(query, cutoff, canonical).Scan(&tag.ID, &tag.Name, &tag.Description, &tag.ArticlesCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tag not found")
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	if tag.Aliases, err = r.getAliases(tag.ID); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// ResolveName returns the canonical tag name for a tag name or alias
+func (r *tagRepository) ResolveName(name string) (string, error) {
+	name = entities.NormalizeTagName(name)
+
+	query := `
+		SELECT name FROM tags WHERE name = ?
+		UNION ALL
+		SELECT t.name FROM tag_aliases ta JOIN tags t ON t.id = ta.tag_id WHERE ta.alias = ?
+		LIMIT 1
+	`
+
+	var canonical string
+	err := r.db.QueryRow(query, name, name).Scan(&canonical)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("tag not found")
+		}
+		return "", fmt.Errorf("failed to resolve tag: %w", err)
+	}
+
+	return canonical, nil
+}
+
+// Rename renames a tag and keeps the old name as an alias so existing links keep working
+func (r *tagRepository) Rename(name, newName string) (*entities.Tag, error) {
+	tag, err := r.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	newName = entities.NormalizeTagName(newName)
+	if newName == tag.Name {
+		return tag, nil
+	}
+
+	err = r.db.Transaction(func(tx *sql.Tx) error {
+		if taken, err := nameTaken(tx, newName, tag.ID); err != nil {
+			return err
+		} else if taken {
+			return fmt.Errorf("tag name already exists")
+		}
+
+		// The new name may previously have been an alias of this tag
+		if _, err := tx.Exec("DELETE FROM tag_aliases WHERE alias = ?", newName); err != nil {
+			return fmt.Errorf("failed to remove alias: %w", err)
+		}
+
+		if _, err := tx.Exec("UPDATE tags SET name = ? WHERE id = ?", newName, tag.ID); err != nil {
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("tag name already exists")
+			}
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+
+		if _, err := tx.Exec("INSERT INTO tag_aliases (alias, tag_id) VALUES (?, ?)", tag.Name, tag.ID); err != nil {
+			return fmt.Errorf("failed to create alias: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByName(newName)
+}
+
+// Merge re-points all articles and aliases of source to target and removes source
+func (r *tagRepository) Merge(source, target string) (*entities.Tag, error) {
+	sourceTag, err := r.GetByName(source)
+	if err != nil {
+		return nil, err
+	}
+
+	targetTag, err := r.GetByName(target)
+	if err != nil {
+		return nil, fmt.Errorf("target %w", err)
+	}
+
+	if sourceTag.ID == targetTag.ID {
+		return nil, fmt.Errorf("cannot merge a tag into itself")
+	}
+
+	err = r.db.Transaction(func(tx *sql.Tx) error {
+		// Re-point article_tags, skipping articles that already carry the target tag
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO article_tags (article_id, tag_id)
+			SELECT article_id, ? FROM article_tags WHERE tag_id = ?
+		`, targetTag.ID, sourceTag.ID); err != nil {
+			return fmt.Errorf("failed to re-point article tags: %w", err)
+		}
+
+		if _, err := tx.Exec("DELETE FROM article_tags WHERE tag_id = ?", sourceTag.ID); err != nil {
+			return fmt.Errorf("failed to remove article tags: %w", err)
+		}
+
+		if _, err := tx.Exec("UPDATE tag_aliases SET tag_id = ? WHERE tag_id = ?", targetTag.ID, sourceTag.ID); err != nil {
+			return fmt.Errorf("failed to re-point aliases: %w", err)
+		}
+
+		if _, err := tx.Exec("DELETE FROM tags WHERE id = ?", sourceTag.ID); err != nil {
+			return fmt.Errorf("failed to delete merged tag: %w", err)
+		}
+
+		// The merged name keeps resolving to the target
+		if _, err := tx.Exec("INSERT INTO tag_aliases (alias, tag_id) VALUES (?, ?)", sourceTag.Name, targetTag.ID); err != nil {
+			return fmt.Errorf("failed to create alias: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByName(targetTag.Name)
+}
+
+// AddAlias makes alias resolve to the canonical tag name
+func (r *tagRepository) AddAlias(name, alias string) (*entities.Tag, error) {
+	tag, err := r.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	alias = entities.NormalizeTagName(alias)
+
+	err = r.db.Transaction(func(tx *sql.Tx) error {
+		if taken, err := nameTaken(tx, alias, 0); err != nil {
+			return err
+		} else if taken {
+			return fmt.Errorf("alias already exists as a tag or alias")
+		}
+
+		if _, err := tx.Exec("INSERT INTO tag_aliases (alias, tag_id) VALUES (?, ?)", alias, tag.ID); err != nil {
+			if isUniqueConstraintError(err) {
+				return fmt.Errorf("alias already exists as a tag or alias")
+			}
+			return fmt.Errorf("failed to create alias: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByName(tag.Name)
+}
+
+// RemoveAlias deletes an alias
+func (r *tagRepository) RemoveAlias(alias string) error {
+	result, err := r.db.Exec("DELETE FROM tag_aliases WHERE alias = ?", entities.NormalizeTagName(alias))
+	if err != nil {
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("alias not found")
+	}
+
+	return nil
+}
+
+// UpdateDescription sets the description shown on a tag's landing page
+func (r *tagRepository) UpdateDescription(name, description string) (*entities.Tag, error) {
+	tag, err := r.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE tags SET description = ?, updated_at = ? WHERE id = ?"
+	if _, err := r.db.Exec(query, description, time.Now(), tag.ID); err != nil {
+		return nil, fmt.Errorf("failed to update tag description: %w", err)
+	}
+
+	tag.Description = description
+	return tag, nil
+}
+
+// GetTopAuthors returns the searchable authors with the most articles under a tag
+func (r *tagRepository) GetTopAuthors(tagID int64, limit int) ([]entities.TagAuthor, error) {
+	listed, cutoff := listedAuthor("u.id", r.quarantine)
+	query := `
+		SELECT u.username, u.bio, u.image_url, COUNT(a.id) AS articles_count
+		FROM article_tags at
+		JOIN articles a ON a.id = at.article_id
+		JOIN users u ON u.id = a.author_id
+		LEFT JOIN user_settings s ON s.user_id = u.id
+		WHERE at.tag_id = ? AND a.status = 'published' AND COALESCE(s.searchable, 1) AND ` + activeUser("u.id") + ` AND ` + listed + `
+		GROUP BY u.id
+		ORDER BY articles_count DESC, u.username ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, tagID, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top authors: %w", err)
+	}
+	defer rows.Close()
+
+	authors := []entities.TagAuthor{}
+	for rows.Next() {
+		var author entities.TagAuthor
+		if err := rows.Scan(&author.Username, &author.Bio, &author.ImageURL, &author.ArticlesCount); err != nil {
+			return nil, fmt.Errorf("failed to scan author: %w", err)
+		}
+		if author.ImageURL == "" {
+			author.ImageURL = entities.DefaultAvatarURL(author.Username)
+		}
+		authors = append(authors, author)
+	}
+
+	return authors, rows.Err()
+}
+
+// listedArticles returns a condition matching article_tags rows whose article
+// is not by a quarantined account, along with its argument
+func (r *tagRepository) listedArticles() (string, time.Time) {
+	listed, cutoff := listedAuthor("a.author_id", r.quarantine)
+	return "at.article_id IN (SELECT a.id FROM articles a WHERE " + listed + ")", cutoff
+}
+
+// getAliases returns the aliases of a tag
+func (r *tagRepository) getAliases(tagID int64) ([]string, error) {
+	rows, err := r.db.Query("SELECT alias FROM tag_aliases WHERE tag_id = ? ORDER BY alias", tagID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// nameTaken checks whether a name is used by another tag (other than excludeID) or by any alias
+func nameTaken(tx *sql.Tx, name string, excludeID int64) (bool, error) {
+	var count int
+	err := tx.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM tags WHERE name = ? AND id != ?)
+		     + (SELECT COUNT(*) FROM tag_aliases WHERE alias = ? AND tag_id != ?)
+	`, name, excludeID, name, excludeID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check tag name: %w", err)
+	}
+
+	return count > 0, nil
+}
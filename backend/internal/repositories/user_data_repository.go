@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// UserDataRepository defines the interface for reading everything a user
+// created, for data exports. Each method returns the batch of up to limit
+// items after the given ID, in ID order, so an export can be written a batch
+// at a time without loading it all.
+type UserDataRepository interface {
+	ArticlesAfter(userID, afterID int64, limit int) ([]entities.UserDataArticle, error)
+	CommentsAfter(userID, afterID int64, limit int) ([]entities.UserDataComment, error)
+	FavoritesAfter(userID, afterArticleID int64, limit int) ([]entities.UserDataFavorite, error)
+}
+
+// userDataRepository implements UserDataRepository using direct SQL
+type userDataRepository struct {
+	db *database.DB
+}
+
+// NewUserDataRepository creates a new user data repository
+func NewUserDataRepository(db *database.DB) UserDataRepository {
+	return &userDataRepository{
+		db: db,
+	}
+}
+
+// ArticlesAfter returns a batch of the user's articles and translations,
+// whatever their review state, with their tags
+func (r *userDataRepository) ArticlesAfter(userID, afterID int64, limit int) ([]entities.UserDataArticle, error) {
+	rows, err := r.db.Query(`
+		SELECT id, slug, title, description, body, language, status, created_at, updated_at
+		FROM articles
+		WHERE author_id = ? AND id > ?
+		ORDER BY id ASC
+		LIMIT ?
+	`, userID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []entities.UserDataArticle{}
+	for rows.Next() {
+		var article entities.UserDataArticle
+		err := rows.Scan(
+			&article.ID,
+			&article.Slug,
+			&article.Title,
+			&article.Description,
+			&article.Body,
+			&article.Language,
+			&article.Status,
+			&article.CreatedAt,
+			&article.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		article.TagList = []string{}
+		articles = append(articles, article)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over articles: %w", err)
+	}
+	rows.Close()
+
+	// Load tags once the rows are released (SQLite uses a single connection)
+	if err := r.loadTags(articles); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// CommentsAfter returns a batch of the user's comments with the slug of the
+// article each is on
+func (r *userDataRepository) CommentsAfter(userID, afterID int64, limit int) ([]entities.UserDataComment, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, a.slug, c.body, c.created_at, c.updated_at, c.deleted_at
+		FROM comments c
+		JOIN articles a ON a.id = c.article_id
+		WHERE c.author_id = ? AND c.id > ?
+		ORDER BY c.id ASC
+		LIMIT ?
+	`, userID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []entities.UserDataComment{}
+	for rows.Next() {
+		var comment entities.UserDataComment
+		var deletedAt sql.NullTime
+		err := rows.Scan(
+			&comment.ID,
+			&comment.Article,
+			&comment.Body,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&deletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		if deletedAt.Valid {
+			comment.DeletedAt = &deletedAt.Time
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// FavoritesAfter returns a batch of the articles the user favorited, keyed
+// by article ID
+func (r *userDataRepository) FavoritesAfter(userID, afterArticleID int64, limit int) ([]entities.UserDataFavorite, error) {
+	rows, err := r.db.Query(`
+		SELECT f.article_id, a.slug, a.title, f.created_at
+		FROM favorites f
+		JOIN articles a ON a.id = f.article_id
+		WHERE f.user_id = ? AND f.article_id > ?
+		ORDER BY f.article_id ASC
+		LIMIT ?
+	`, userID, afterArticleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query favorites: %w", err)
+	}
+	defer rows.Close()
+
+	favorites := []entities.UserDataFavorite{}
+	for rows.Next() {
+		var favorite entities.UserDataFavorite
+		if err := rows.Scan(&favorite.ArticleID, &favorite.Article, &favorite.Title, &favorite.FavoritedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan favorite: %w", err)
+		}
+		favorites = append(favorites, favorite)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over favorites: %w", err)
+	}
+
+	return favorites, nil
+}
+
+// loadTags fills in the canonical tag names of a batch of articles
+func (r *userDataRepository) loadTags(articles []entities.UserDataArticle) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(articles))
+	placeholders := make([]string, len(articles))
+	args := make([]interface{}, len(articles))
+	for i := range articles {
+		index[articles[i].ID] = i
+		placeholders[i] = "?"
+		args[i] = articles[i].ID
+	}
+
+	rows, err := r.db.Query(`
+		SELECT at.article_id, t.name
+		FROM article_tags at
+		JOIN tags t ON t.id = at.tag_id
+		WHERE at.article_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY t.name ASC
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID int64
+		var name string
+		if err := rows.Scan(&articleID, &name); err != nil {
+			return fmt.Errorf("failed to scan tag: %w", err)
+		}
+		article := &articles[index[articleID]]
+		article.TagList = append(article.TagList, name)
+	}
+
+	return rows.Err()
+}
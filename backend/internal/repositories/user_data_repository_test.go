@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestUserDataRepository_PagesThroughUserData(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	users := createTestUsers(t, userRepo, "author", "other")
+	author, other := users["author"], users["other"]
+
+	articleRepo := NewArticleRepository(db, userRepo, 0)
+	commentRepo := NewCommentRepository(db, userRepo)
+	favoriteRepo := NewFavoriteRepository(db)
+	for i := 0; i < 3; i++ {
+		article, err := articleRepo.Create(author.ID, &entities.ArticleCreate{
+			Title:       fmt.Sprintf("Article %d", i),
+			Description: "Description",
+			Body:        "Body",
+			TagList:     []string{"go", "sqlite"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create article: %v", err)
+		}
+		if _, err := commentRepo.Create(author.ID, article.ID, &entities.CommentCreate{Body: "Mine"}); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		if _, err := commentRepo.Create(other.ID, article.ID, &entities.CommentCreate{Body: "Theirs"}); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		if _, err := favoriteRepo.Favorite(author.ID, article.ID); err != nil {
+			t.Fatalf("Failed to favorite article: %v", err)
+		}
+	}
+	if _, err := articleRepo.Create(other.ID, &entities.ArticleCreate{Title: "Not mine", Description: "Description", Body: "Body"}); err != nil {
+		t.Fatalf("Failed to create article: %v", err)
+	}
+
+	userDataRepo := NewUserDataRepository(db)
+
+	first, err := userDataRepo.ArticlesAfter(author.ID, 0, 2)
+	if err != nil {
+		t.Fatalf("Failed to get articles: %v", err)
+	}
+	if len(first) != 2 || first[0].Title != "Article 0" || len(first[0].TagList) != 2 {
+		t.Fatalf("Unexpected first batch %+v", first)
+	}
+	rest, err := userDataRepo.ArticlesAfter(author.ID, first[1].ID, 2)
+	if err != nil {
+		t.Fatalf("Failed to get articles: %v", err)
+	}
+	if len(rest) != 1 || rest[0].Title != "Article 2" {
+		t.Errorf("Expected the last article in the second batch, got %+v", rest)
+	}
+
+	comments, err := userDataRepo.CommentsAfter(author.ID, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 3 || comments[0].Body != "Mine" || comments[0].Article != first[0].Slug {
+		t.Errorf("Expected only the author's comments, got %+v", comments)
+	}
+
+	favorites, err := userDataRepo.FavoritesAfter(author.ID, first[0].ID, 10)
+	if err != nil {
+		t.Fatalf("Failed to get favorites: %v", err)
+	}
+	if len(favorites) != 2 || favorites[0].Article != first[1].Slug {
+		t.Errorf("Expected the favorites after the first article, got %+v", favorites)
+	}
+}
@@ -22,41 +22,105 @@ type UserRepository interface {
 	EmailExists(email string) (bool, error)
 	UsernameExists(username string) (bool, error)
 	VerifyPassword(user *entities.User, password string) bool
+	UpdateRole(id int64, role string) error
+	List(query *entities.UserListQuery) ([]entities.User, int, error)
+	SetModerationStatus(id int64, status string, at time.Time) error
+	Deactivate(id int64, at time.Time) error
+	Reactivate(id int64) error
+	RotateEmailEncryption() (int, error)
+	GetBirthdate(id int64) (*time.Time, error)
+	RecordLoginFailure(userID int64, network string, at, since time.Time) (int, error)
+	CountNetworkLoginFailures(network string, since time.Time) (int, error)
+	LockUntil(id int64, until time.Time) error
+	ClearLoginFailures(id int64) error
+}
+
+// FieldCipher encrypts personal data at rest. Blind indexes are deterministic
+// keyed hashes, so encrypted columns can still be matched for equality.
+type FieldCipher interface {
+	Encrypt(plaintext string) (string, error)
+	// Decrypt returns values that were never encrypted unchanged
+	Decrypt(stored string) (string, error)
+	BlindIndex(value string) string
+	// Current reports whether stored is encrypted with the current key
+	Current(stored string) bool
+}
+
+// UserRepositoryOptions configures a user repository
+type UserRepositoryOptions struct {
+	// Cipher encrypts emails and birthdates; nil stores emails in plaintext
+	Cipher FieldCipher
+	// PasswordCost is the bcrypt cost passwords are hashed with; 0 uses
+	// bcrypt.DefaultCost
+	PasswordCost int
 }
 
 // userRepository implements UserRepository using direct SQL
 type userRepository struct {
-	db *database.DB
+	db           *database.DB
+	cipher       FieldCipher
+	passwordCost int
 }
 
-// NewUserRepository creates a new user repository
+// NewUserRepository creates a new user repository that stores emails in plaintext
 func NewUserRepository(db *database.DB) UserRepository {
+	return NewUserRepositoryWithOptions(db, UserRepositoryOptions{})
+}
+
+// NewEncryptedUserRepository creates a user repository that encrypts emails
+// and looks them up by blind index. Rows written before encryption was
+// enabled stay readable and are encrypted by RotateEmailEncryption.
+func NewEncryptedUserRepository(db *database.DB, cipher FieldCipher) UserRepository {
+	return NewUserRepositoryWithOptions(db, UserRepositoryOptions{Cipher: cipher})
+}
+
+// NewUserRepositoryWithOptions creates a user repository configured by options
+func NewUserRepositoryWithOptions(db *database.DB, options UserRepositoryOptions) UserRepository {
+	passwordCost := options.PasswordCost
+	if passwordCost == 0 {
+		passwordCost = bcrypt.DefaultCost
+	}
 	return &userRepository{
-		db: db,
+		db:           db,
+		cipher:       options.Cipher,
+		passwordCost: passwordCost,
 	}
 }
 
 // Create creates a new user
 func (r *userRepository) Create(userReg *entities.UserRegistration) (*entities.User, error) {
 	// Hash password
-	hashedPassword, err := hashPassword(userReg.Password)
+	hashedPassword, err := r.hashPassword(userReg.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
+	email, emailIndex, err := r.sealEmail(userReg.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	birthdate, err := r.sealBirthdate(userReg.Birthdate)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	
 	query := `
-		INSERT INTO users (username, email, password_hash, bio, image_url, created_at, updated_at)
-		VALUES (?, ?, ?, '', '', ?, ?)
-		RETURNING id, username, email, bio, image_url, created_at, updated_at
+		INSERT INTO users (username, email, email_index, password_hash, bio, image_url, registration_network, birthdate, created_at, updated_at)
+		VALUES (?, ?, ?, ?, '', '', ?, ?, ?, ?)
+		RETURNING id, username, email, bio, image_url, role, moderation_status, deactivated_at, locked_until, created_at, updated_at
 	`
 	
 	user := &entities.User{}
 	err = r.db.QueryRow(query, 
 		userReg.Username, 
-		userReg.Email, 
+		email,
+		emailIndex,
 		hashedPassword,
+		userReg.Network,
+		birthdate,
 		now,
 		now,
 	).Scan(
@@ -65,6 +129,10 @@ func (r *userRepository) Create(userReg *entities.UserRegistration) (*entities.U
 		&user.Email,
 		&user.Bio,
 		&user.ImageURL,
+		&user.Role,
+		&user.ModerationStatus,
+		&user.DeactivatedAt,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -76,26 +144,31 @@ func (r *userRepository) Create(userReg *entities.UserRegistration) (*entities.U
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	
+	user.Email = userReg.Email
 	user.PasswordHash = hashedPassword
 	return user, nil
 }
 
-// GetByEmail retrieves a user by email
+// GetByEmail retrieves a user by email; emails match case-insensitively
 func (r *userRepository) GetByEmail(email string) (*entities.User, error) {
+	match, args := r.emailMatch(email)
 	query := `
-		SELECT id, username, email, password_hash, bio, image_url, created_at, updated_at
+		SELECT id, username, email, password_hash, bio, image_url, role, moderation_status, deactivated_at, locked_until, created_at, updated_at
 		FROM users 
-		WHERE email = ?
-	`
+		WHERE ` + match
 	
 	user := &entities.User{}
-	err := r.db.QueryRow(query, email).Scan(
+	err := r.db.QueryRow(query, args...).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.PasswordHash,
 		&user.Bio,
 		&user.ImageURL,
+		&user.Role,
+		&user.ModerationStatus,
+		&user.DeactivatedAt,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -106,14 +179,17 @@ func (r *userRepository) GetByEmail(email string) (*entities.User, error) {
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
-	
+
+	if err := r.openEmail(user); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
 // GetByUsername retrieves a user by username
 func (r *userRepository) GetByUsername(username string) (*entities.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, bio, image_url, created_at, updated_at
+		SELECT id, username, email, password_hash, bio, image_url, role, moderation_status, deactivated_at, locked_until, created_at, updated_at
 		FROM users 
 		WHERE username = ?
 	`
@@ -126,6 +202,10 @@ func (r *userRepository) GetByUsername(username string) (*entities.User, error)
 		&user.PasswordHash,
 		&user.Bio,
 		&user.ImageURL,
+		&user.Role,
+		&user.ModerationStatus,
+		&user.DeactivatedAt,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -136,14 +216,17 @@ func (r *userRepository) GetByUsername(username string) (*entities.User, error)
 		}
 		return nil, fmt.Errorf("failed to get user by username: %w", err)
 	}
-	
+
+	if err := r.openEmail(user); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
 // GetByID retrieves a user by ID
 func (r *userRepository) GetByID(id int64) (*entities.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, bio, image_url, created_at, updated_at
+		SELECT id, username, email, password_hash, bio, image_url, role, moderation_status, deactivated_at, locked_until, created_at, updated_at
 		FROM users 
 		WHERE id = ?
 	`
@@ -156,6 +239,10 @@ func (r *userRepository) GetByID(id int64) (*entities.User, error) {
 		&user.PasswordHash,
 		&user.Bio,
 		&user.ImageURL,
+		&user.Role,
+		&user.ModerationStatus,
+		&user.DeactivatedAt,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -166,7 +253,10 @@ func (r *userRepository) GetByID(id int64) (*entities.User, error) {
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
-	
+
+	if err := r.openEmail(user); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
@@ -182,22 +272,32 @@ func (r *userRepository) Update(id int64, updates *entities.UserUpdate) (*entiti
 	}
 	
 	if updates.Email != nil {
-		setParts = append(setParts, "email = ?")
-		args = append(args, *updates.Email)
+		email, emailIndex, err := r.sealEmail(*updates.Email)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, "email = ?", "email_index = ?")
+		args = append(args, email, emailIndex)
 	}
 	
 	if updates.Bio != nil {
 		setParts = append(setParts, "bio = ?")
 		args = append(args, *updates.Bio)
+	} else if updates.Clears("bio") {
+		setParts = append(setParts, "bio = ?")
+		args = append(args, "")
 	}
 	
 	if updates.ImageURL != nil {
 		setParts = append(setParts, "image_url = ?")
 		args = append(args, *updates.ImageURL)
+	} else if updates.Clears("image") {
+		setParts = append(setParts, "image_url = ?")
+		args = append(args, "")
 	}
 	
 	if updates.Password != nil {
-		hashedPassword, err := hashPassword(*updates.Password)
+		hashedPassword, err := r.hashPassword(*updates.Password)
 		if err != nil {
 			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
@@ -219,7 +319,7 @@ func (r *userRepository) Update(id int64, updates *entities.UserUpdate) (*entiti
 		UPDATE users 
 		SET %s
 		WHERE id = ?
-		RETURNING id, username, email, password_hash, bio, image_url, created_at, updated_at
+		RETURNING id, username, email, password_hash, bio, image_url, role, moderation_status, deactivated_at, locked_until, created_at, updated_at
 	`, joinStrings(setParts, ", "))
 	
 	user := &entities.User{}
@@ -230,6 +330,10 @@ func (r *userRepository) Update(id int64, updates *entities.UserUpdate) (*entiti
 		&user.PasswordHash,
 		&user.Bio,
 		&user.ImageURL,
+		&user.Role,
+		&user.ModerationStatus,
+		&user.DeactivatedAt,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -243,16 +347,27 @@ func (r *userRepository) Update(id int64, updates *entities.UserUpdate) (*entiti
 		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
-	
+
+	// Bounces and complaints were about the old address
+	if updates.Email != nil {
+		if _, err := r.db.Exec("DELETE FROM email_statuses WHERE user_id = ?", id); err != nil {
+			return nil, fmt.Errorf("failed to reset email status: %w", err)
+		}
+	}
+
+	if err := r.openEmail(user); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
-// EmailExists checks if an email already exists
+// EmailExists checks if an email already exists, ignoring case
 func (r *userRepository) EmailExists(email string) (bool, error) {
 	var count int
-	query := "SELECT COUNT(*) FROM users WHERE email = ?"
+	match, args := r.emailMatch(email)
+	query := "SELECT COUNT(*) FROM users WHERE " + match
 	
-	err := r.db.QueryRow(query, email).Scan(&count)
+	err := r.db.QueryRow(query, args...).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check email existence: %w", err)
 	}
@@ -260,6 +375,157 @@ func (r *userRepository) EmailExists(email string) (bool, error) {
 	return count > 0, nil
 }
 
+// RotateEmailEncryption encrypts plaintext emails and re-encrypts emails and
+// birthdates sealed with an older key, refreshing blind indexes; it returns
+// how many users changed
+func (r *userRepository) RotateEmailEncryption() (int, error) {
+	if r.cipher == nil {
+		return 0, fmt.Errorf("email encryption is not configured")
+	}
+
+	type storedEmail struct {
+		id        int64
+		email     string
+		index     sql.NullString
+		birthdate sql.NullString
+	}
+
+	// Read every row before writing; the database has a single connection
+	rows, err := r.db.Query("SELECT id, email, email_index, birthdate FROM users ORDER BY id")
+	if err != nil {
+		return 0, fmt.Errorf("failed to query user emails: %w", err)
+	}
+	stored := []storedEmail{}
+	for rows.Next() {
+		var row storedEmail
+		if err := rows.Scan(&row.id, &row.email, &row.index, &row.birthdate); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan user email: %w", err)
+		}
+		stored = append(stored, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read user emails: %w", err)
+	}
+
+	rotated := 0
+	for _, row := range stored {
+		email, err := r.cipher.Decrypt(row.email)
+		if err != nil {
+			return rotated, fmt.Errorf("failed to decrypt email of user %d: %w", row.id, err)
+		}
+		if r.cipher.Current(row.email) && row.index.String == r.cipher.BlindIndex(entities.NormalizeEmail(email)) &&
+			(!row.birthdate.Valid || r.cipher.Current(row.birthdate.String)) {
+			continue
+		}
+
+		sealed, emailIndex, err := r.sealEmail(email)
+		if err != nil {
+			return rotated, err
+		}
+		birthdate := row.birthdate
+		if birthdate.Valid {
+			plain, err := r.cipher.Decrypt(birthdate.String)
+			if err != nil {
+				return rotated, fmt.Errorf("failed to decrypt birthdate of user %d: %w", row.id, err)
+			}
+			if birthdate, err = r.sealBirthdate(plain); err != nil {
+				return rotated, err
+			}
+		}
+		if _, err := r.db.Exec("UPDATE users SET email = ?, email_index = ?, birthdate = ? WHERE id = ?", sealed, emailIndex, birthdate, row.id); err != nil {
+			return rotated, fmt.Errorf("failed to re-encrypt email of user %d: %w", row.id, err)
+		}
+		rotated++
+	}
+
+	return rotated, nil
+}
+
+// sealEmail returns the stored form and blind index of an email. Without a
+// cipher emails are stored in plaintext with no index.
+func (r *userRepository) sealEmail(email string) (string, interface{}, error) {
+	if r.cipher == nil {
+		return email, nil, nil
+	}
+
+	sealed, err := r.cipher.Encrypt(email)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encrypt email: %w", err)
+	}
+	return sealed, r.cipher.BlindIndex(entities.NormalizeEmail(email)), nil
+}
+
+// sealBirthdate returns the stored form of a YYYY-MM-DD birthdate, NULL when
+// none was given. Birthdates are only ever stored encrypted.
+func (r *userRepository) sealBirthdate(birthdate string) (sql.NullString, error) {
+	if birthdate == "" {
+		return sql.NullString{}, nil
+	}
+	if r.cipher == nil {
+		return sql.NullString{}, fmt.Errorf("birthdates can only be stored with encryption configured")
+	}
+
+	sealed, err := r.cipher.Encrypt(birthdate)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encrypt birthdate: %w", err)
+	}
+	return sql.NullString{String: sealed, Valid: true}, nil
+}
+
+// GetBirthdate returns the user's birthdate, or nil if they never gave one
+func (r *userRepository) GetBirthdate(id int64) (*time.Time, error) {
+	var stored sql.NullString
+	err := r.db.QueryRow("SELECT birthdate FROM users WHERE id = ?", id).Scan(&stored)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to get birthdate: %w", err)
+	}
+	if !stored.Valid {
+		return nil, nil
+	}
+	if r.cipher == nil {
+		return nil, fmt.Errorf("birthdate encryption is not configured")
+	}
+
+	plain, err := r.cipher.Decrypt(stored.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt birthdate: %w", err)
+	}
+	birthdate, err := entities.ParseBirthdate(plain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse birthdate: %w", err)
+	}
+	return &birthdate, nil
+}
+
+// emailMatch returns a condition matching a user's email, ignoring case. With
+// a cipher, rows that are not encrypted yet still match on the plaintext column.
+func (r *userRepository) emailMatch(email string) (string, []interface{}) {
+	if r.cipher == nil {
+		return "email = ? COLLATE NOCASE", []interface{}{email}
+	}
+	return "(email_index = ? OR (email_index IS NULL AND email = ? COLLATE NOCASE))",
+		[]interface{}{r.cipher.BlindIndex(entities.NormalizeEmail(email)), email}
+}
+
+// openEmail decrypts the user's stored email in place
+func (r *userRepository) openEmail(user *entities.User) error {
+	if r.cipher == nil {
+		return nil
+	}
+
+	email, err := r.cipher.Decrypt(user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt email: %w", err)
+	}
+	user.Email = email
+	return nil
+}
+
 // UsernameExists checks if a username already exists
 func (r *userRepository) UsernameExists(username string) (bool, error) {
 	var count int
@@ -273,16 +539,257 @@ func (r *userRepository) UsernameExists(username string) (bool, error) {
 	return count > 0, nil
 }
 
-// VerifyPassword verifies a password against the stored hash
+// UpdateRole changes a user's role
+func (r *userRepository) UpdateRole(id int64, role string) error {
+	query := "UPDATE users SET role = ?, updated_at = ? WHERE id = ?"
+
+	result, err := r.db.Exec(query, role, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// likeEscaper escapes LIKE wildcards so searches match them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List returns a page of users matching the query, newest first, and the
+// number of matching users. A search containing "@" is an email and must
+// match exactly, since encrypted emails can only be compared by blind index.
+func (r *userRepository) List(query *entities.UserListQuery) ([]entities.User, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if search := strings.TrimSpace(query.Search); search != "" {
+		if strings.Contains(search, "@") {
+			condition, matchArgs := r.emailMatch(search)
+			conditions = append(conditions, condition)
+			args = append(args, matchArgs...)
+		} else {
+			conditions = append(conditions, `username LIKE ? ESCAPE '\'`)
+			args = append(args, "%"+likeEscaper.Replace(search)+"%")
+		}
+	}
+	if query.Role != "" {
+		conditions = append(conditions, "role = ?")
+		args = append(args, query.Role)
+	}
+	if query.ModerationStatus != "" {
+		conditions = append(conditions, "moderation_status = ?")
+		args = append(args, query.ModerationStatus)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM users "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, username, email, password_hash, bio, image_url, role, moderation_status, deactivated_at, locked_until, created_at, updated_at
+		FROM users
+		`+where+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []entities.User{}
+	for rows.Next() {
+		var user entities.User
+		err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Email,
+			&user.PasswordHash,
+			&user.Bio,
+			&user.ImageURL,
+			&user.Role,
+			&user.ModerationStatus,
+			&user.DeactivatedAt,
+			&user.LockedUntil,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		if err := r.openEmail(&user); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate over users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// SetModerationStatus applies a moderation status to the user
+func (r *userRepository) SetModerationStatus(id int64, status string, at time.Time) error {
+	result, err := r.db.Exec("UPDATE users SET moderation_status = ?, updated_at = ? WHERE id = ?", status, at, id)
+	if err != nil {
+		return fmt.Errorf("failed to set moderation status: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// Deactivate marks the account deactivated; its content is kept but hidden from listings
+func (r *userRepository) Deactivate(id int64, at time.Time) error {
+	return r.setDeactivatedAt(id, &at)
+}
+
+// Reactivate clears the account's deactivation
+func (r *userRepository) Reactivate(id int64) error {
+	return r.setDeactivatedAt(id, nil)
+}
+
+// setDeactivatedAt stores the deactivation time, or NULL for an active account
+func (r *userRepository) setDeactivatedAt(id int64, at *time.Time) error {
+	result, err := r.db.Exec("UPDATE users SET deactivated_at = ?, updated_at = ? WHERE id = ?", at, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update account status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// RecordLoginFailure records a failed login from the network, for the user or
+// for no account when userID is 0, and returns how many logins failed for the
+// user since the given time. Older failures are dropped, as lockouts never
+// look further back.
+func (r *userRepository) RecordLoginFailure(userID int64, network string, at, since time.Time) (int, error) {
+	var failures int
+	err := r.db.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM login_failures WHERE failed_at < ?", since); err != nil {
+			return fmt.Errorf("failed to prune login failures: %w", err)
+		}
+
+		var user sql.NullInt64
+		if userID != 0 {
+			user = sql.NullInt64{Int64: userID, Valid: true}
+		}
+		if _, err := tx.Exec("INSERT INTO login_failures (user_id, network, failed_at) VALUES (?, ?, ?)", user, network, at); err != nil {
+			return fmt.Errorf("failed to record login failure: %w", err)
+		}
+
+		err := tx.QueryRow("SELECT COUNT(*) FROM login_failures WHERE user_id = ?", user).Scan(&failures)
+		if err != nil {
+			return fmt.Errorf("failed to count login failures: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+// CountNetworkLoginFailures returns how many logins failed from the network
+// since the given time
+func (r *userRepository) CountNetworkLoginFailures(network string, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM login_failures WHERE network = ? AND failed_at >= ?", network, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count login failures: %w", err)
+	}
+	return count, nil
+}
+
+// LockUntil locks the account against logging in until the given time
+func (r *userRepository) LockUntil(id int64, until time.Time) error {
+	if _, err := r.db.Exec("UPDATE users SET locked_until = ? WHERE id = ?", until, id); err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+	return nil
+}
+
+// ClearLoginFailures forgets the user's failed logins and unlocks the
+// account, after they logged in successfully
+func (r *userRepository) ClearLoginFailures(id int64) error {
+	return r.db.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM login_failures WHERE user_id = ?", id); err != nil {
+			return fmt.Errorf("failed to clear login failures: %w", err)
+		}
+		if _, err := tx.Exec("UPDATE users SET locked_until = NULL WHERE id = ? AND locked_until IS NOT NULL", id); err != nil {
+			return fmt.Errorf("failed to unlock account: %w", err)
+		}
+		return nil
+	})
+}
+
+// VerifyPassword verifies a password against the stored hash. A hash made
+// with a lower cost than the configured one is replaced while the password is
+// at hand; if that fails, the next login tries again.
 func (r *userRepository) VerifyPassword(user *entities.User, password string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return false
+	}
+
+	if cost, err := bcrypt.Cost([]byte(user.PasswordHash)); err == nil && cost < r.passwordCost {
+		if hashedPassword, err := r.hashPassword(password); err == nil {
+			// Leave the hash alone if the password changed meanwhile
+			_, err := r.db.Exec(`
+				UPDATE users SET password_hash = ? WHERE id = ? AND password_hash = ?
+			`, hashedPassword, user.ID, user.PasswordHash)
+			if err == nil {
+				user.PasswordHash = hashedPassword
+			}
+		}
+	}
+
+	return true
 }
 
 // Helper functions
 
-// hashPassword hashes a password using bcrypt
-func hashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// activeUser returns a condition matching rows whose user ID column belongs to
+// an account that is neither deactivated nor shadow-banned or banned; listings
+// use it to hide those users' content
+func activeUser(column string) string {
+	return column + ` NOT IN (
+		SELECT id FROM users
+		WHERE deactivated_at IS NOT NULL OR moderation_status IN ('shadow_banned', 'banned')
+	)`
+}
+
+// hashPassword hashes a password using bcrypt at the configured cost
+func (r *userRepository) hashPassword(password string) (string, error) {
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), r.passwordCost)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,318 @@
+package repositories
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// fakeFieldCipher base64-encodes values under a key ID, which is enough to
+// tell stored values apart from plaintext
+type fakeFieldCipher struct {
+	key string
+}
+
+func (c fakeFieldCipher) Encrypt(plaintext string) (string, error) {
+	return "enc:" + c.key + ":" + base64.StdEncoding.EncodeToString([]byte(plaintext)), nil
+}
+
+func (c fakeFieldCipher) Decrypt(stored string) (string, error) {
+	if !strings.HasPrefix(stored, "enc:") {
+		return stored, nil
+	}
+	parts := strings.SplitN(stored, ":", 3)
+	plaintext, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("bad value %q", stored)
+	}
+	return string(plaintext), nil
+}
+
+func (c fakeFieldCipher) BlindIndex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c fakeFieldCipher) Current(stored string) bool {
+	return strings.HasPrefix(stored, "enc:"+c.key+":")
+}
+
+func TestUserRepository_EncryptedEmails(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// A user registered before encryption was enabled
+	legacy, err := NewUserRepository(db).Create(&entities.UserRegistration{Username: "legacy", Email: "legacy@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to create legacy user: %v", err)
+	}
+
+	userRepo := NewEncryptedUserRepository(db, fakeFieldCipher{key: "k1"})
+	user, err := userRepo.Create(&entities.UserRegistration{Username: "alice", Email: "alice@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if user.Email != "alice@example.com" {
+		t.Errorf("Expected the plaintext email back, got %q", user.Email)
+	}
+
+	storedEmail := func(id int64) string {
+		var email string
+		if err := db.QueryRow("SELECT email FROM users WHERE id = ?", id).Scan(&email); err != nil {
+			t.Fatalf("Failed to read stored email: %v", err)
+		}
+		return email
+	}
+	if stored := storedEmail(user.ID); !strings.HasPrefix(stored, "enc:k1:") {
+		t.Errorf("Expected an encrypted email at rest, got %q", stored)
+	}
+
+	// Lookups match both encrypted and not yet encrypted rows, ignoring case
+	for _, email := range []string{"Alice@Example.com", "LEGACY@example.com"} {
+		found, err := userRepo.GetByEmail(email)
+		if err != nil {
+			t.Fatalf("GetByEmail(%s) failed: %v", email, err)
+		}
+		if found.Email != strings.ToLower(email) {
+			t.Errorf("GetByEmail(%s) returned %q", email, found.Email)
+		}
+		if exists, _ := userRepo.EmailExists(email); !exists {
+			t.Errorf("Expected %s to exist", email)
+		}
+	}
+
+	// Rotation encrypts legacy rows and re-encrypts rows under older keys
+	rotatedRepo := NewEncryptedUserRepository(db, fakeFieldCipher{key: "k2"})
+	rotated, err := rotatedRepo.RotateEmailEncryption()
+	if err != nil {
+		t.Fatalf("RotateEmailEncryption failed: %v", err)
+	}
+	if rotated != 2 {
+		t.Errorf("Expected 2 rotated users, got %d", rotated)
+	}
+	for _, id := range []int64{legacy.ID, user.ID} {
+		if stored := storedEmail(id); !strings.HasPrefix(stored, "enc:k2:") {
+			t.Errorf("Expected user %d to be encrypted with k2, got %q", id, stored)
+		}
+	}
+	if again, _ := rotatedRepo.RotateEmailEncryption(); again != 0 {
+		t.Errorf("Expected a second rotation to change nothing, changed %d", again)
+	}
+
+	renamed := "renamed@example.com"
+	updated, err := rotatedRepo.Update(legacy.ID, &entities.UserUpdate{Email: &renamed})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Email != "renamed@example.com" {
+		t.Errorf("Expected the updated plaintext email, got %q", updated.Email)
+	}
+	if _, err := rotatedRepo.GetByEmail("legacy@example.com"); err == nil {
+		t.Error("Expected the old email to stop matching")
+	}
+}
+
+func TestUserRepository_EncryptedBirthdates(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	registration := &entities.UserRegistration{Username: "teen", Email: "teen@example.com", Password: "password123", Birthdate: "2010-06-15"}
+	if _, err := NewUserRepository(db).Create(registration); err == nil {
+		t.Fatal("Expected a birthdate to be refused without encryption")
+	}
+
+	userRepo := NewEncryptedUserRepository(db, fakeFieldCipher{key: "k1"})
+	user, err := userRepo.Create(registration)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	var stored string
+	if err := db.QueryRow("SELECT birthdate FROM users WHERE id = ?", user.ID).Scan(&stored); err != nil {
+		t.Fatalf("Failed to read stored birthdate: %v", err)
+	}
+	if !strings.HasPrefix(stored, "enc:k1:") {
+		t.Errorf("Expected an encrypted birthdate at rest, got %q", stored)
+	}
+
+	// Rotation re-encrypts birthdates along with emails
+	rotatedRepo := NewEncryptedUserRepository(db, fakeFieldCipher{key: "k2"})
+	if rotated, err := rotatedRepo.RotateEmailEncryption(); err != nil || rotated != 1 {
+		t.Fatalf("Expected one rotated user, got %d (%v)", rotated, err)
+	}
+	birthdate, err := rotatedRepo.GetBirthdate(user.ID)
+	if err != nil {
+		t.Fatalf("GetBirthdate failed: %v", err)
+	}
+	if birthdate == nil || birthdate.Format("2006-01-02") != "2010-06-15" {
+		t.Errorf("Expected the birthdate back, got %v", birthdate)
+	}
+}
+
+func TestUserRepository_RehashesLowCostPasswords(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	_, err = NewUserRepositoryWithOptions(db, UserRepositoryOptions{PasswordCost: bcrypt.MinCost}).Create(&entities.UserRegistration{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	userRepo := NewUserRepositoryWithOptions(db, UserRepositoryOptions{PasswordCost: bcrypt.MinCost + 1})
+	user, err := userRepo.GetByUsername("alice")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+
+	if userRepo.VerifyPassword(user, "wrong-password") {
+		t.Fatal("Expected a wrong password to be rejected")
+	}
+	if cost, _ := bcrypt.Cost([]byte(user.PasswordHash)); cost != bcrypt.MinCost {
+		t.Errorf("Expected a failed login to keep the hash, got cost %d", cost)
+	}
+
+	if !userRepo.VerifyPassword(user, "password123") {
+		t.Fatal("Expected the password to be accepted")
+	}
+
+	stored, err := userRepo.GetByUsername("alice")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(stored.PasswordHash)); cost != bcrypt.MinCost+1 {
+		t.Errorf("Expected the hash to be upgraded to cost %d, got %d", bcrypt.MinCost+1, cost)
+	}
+	if !userRepo.VerifyPassword(stored, "password123") {
+		t.Error("Expected the upgraded hash to accept the password")
+	}
+}
+
+func TestUserRepository_ListAndModerate(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewUserRepository(db)
+	users := createTestUsers(t, userRepo, "alice", "alfred", "bob")
+
+	if err := userRepo.SetModerationStatus(users["bob"].ID, entities.ModerationBanned, time.Now()); err != nil {
+		t.Fatalf("Failed to ban user: %v", err)
+	}
+	if err := userRepo.SetModerationStatus(9999, entities.ModerationBanned, time.Now()); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error for an unknown user, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		query     entities.UserListQuery
+		wantNames []string
+		wantTotal int
+	}{
+		{"all", entities.UserListQuery{Limit: 20}, []string{"bob", "alfred", "alice"}, 3},
+		{"paged", entities.UserListQuery{Limit: 1, Offset: 1}, []string{"alfred"}, 3},
+		{"username search", entities.UserListQuery{Search: "al", Limit: 20}, []string{"alfred", "alice"}, 2},
+		{"email search", entities.UserListQuery{Search: "ALICE@example.com", Limit: 20}, []string{"alice"}, 1},
+		{"wildcards match literally", entities.UserListQuery{Search: "_", Limit: 20}, nil, 0},
+		{"partial email", entities.UserListQuery{Search: "alice@", Limit: 20}, nil, 0},
+		{"moderation status", entities.UserListQuery{ModerationStatus: entities.ModerationBanned, Limit: 20}, []string{"bob"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, total, err := userRepo.List(&tt.query)
+			if err != nil {
+				t.Fatalf("Failed to list users: %v", err)
+			}
+
+			var names []string
+			for _, user := range list {
+				names = append(names, user.Username)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") || total != tt.wantTotal {
+				t.Errorf("List() = %v of %d, want %v of %d", names, total, tt.wantNames, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestUserRepository_ListSearchesEncryptedEmailsExactly(t *testing.T) {
+	// Setup test database
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations
+	if err := db.Migrate("../../../migrations"); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	userRepo := NewEncryptedUserRepository(db, fakeFieldCipher{key: "k1"})
+	createTestUsers(t, userRepo, "alice", "bob")
+
+	for search, want := range map[string]int{
+		"Alice@Example.com": 1,
+		"alice@":            0,
+		"@example.com":      0,
+	} {
+		list, total, err := userRepo.List(&entities.UserListQuery{Search: search, Limit: 20})
+		if err != nil {
+			t.Fatalf("Failed to list users: %v", err)
+		}
+		if total != want || len(list) != want {
+			t.Errorf("List(%q) found %d users, want %d", search, total, want)
+		}
+		if want == 1 && list[0].Email != "alice@example.com" {
+			t.Errorf("List(%q) = %q, want the decrypted email of alice", search, list[0].Email)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/handlers"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+)
+
+// AuthRequirement describes who may call a route
+type AuthRequirement int
+
+const (
+	// AuthNone routes are public
+	AuthNone AuthRequirement = iota
+	// AuthUser routes require a valid token
+	AuthUser
+	// AuthOptional routes are public but identify the caller when a token is sent
+	AuthOptional
+	// AuthModerator routes require the moderator or admin role
+	AuthModerator
+	// AuthAdmin routes require the admin role
+	AuthAdmin
+	// AuthWebhook routes require the shared inbound webhook secret; they are
+	// only registered when a secret is configured
+	AuthWebhook
+	// AuthMetrics routes require the metrics scrape token; they are only
+	// registered when a token is configured
+	AuthMetrics
+	// AuthDrain routes require the drain token; they are only registered when
+	// a token is configured
+	AuthDrain
+)
+
+// Rate limit classes group routes with similar cost and abuse profiles
+const (
+	RateLimitAuth  = "auth"
+	RateLimitRead  = "read"
+	RateLimitWrite = "write"
+	RateLimitAdmin = "admin"
+)
+
+// Media types routes consume and produce
+const (
+	mediaTypeJSON      = "application/json"
+	mediaTypeCSV       = "text/csv"
+	mediaTypeText      = "text/plain"
+	mediaTypeHTML      = "text/html"
+	mediaTypeMetrics   = "application/openmetrics-text"
+	mediaTypeForm      = "application/x-www-form-urlencoded"
+	mediaTypeMultipart = "multipart/form-data"
+	mediaTypePNG       = "image/png"
+	mediaTypeJPEG      = "image/jpeg"
+	mediaTypeGIF       = "image/gif"
+	mediaTypePDF       = "application/pdf"
+	mediaTypeEPUB      = "application/epub+zip"
+	mediaTypeRSS       = "application/rss+xml"
+	mediaTypeXML       = "application/xml"
+	mediaTypeTextXML   = "text/xml"
+)
+
+// defaultRouteTimeout applies to routes that do not set their own timeout.
+// It stays below the HTTP server's write timeout so clients get a JSON error.
+const defaultRouteTimeout = 10 * time.Second
+
+// Route declares an endpoint and the metadata the router builder applies to it
+type Route struct {
+	Name      string
+	Method    string
+	Path      string
+	Handler   http.HandlerFunc
+	Auth      AuthRequirement
+	RateLimit string
+	Timeout   time.Duration
+
+	// Consumes and Produces list accepted request and response media types;
+	// both default to JSON
+	Consumes []string
+	Produces []string
+
+	// LenientJSON ignores unknown request fields and reports them as
+	// response warnings instead of rejecting the request
+	LenientJSON bool
+
+	// ReplayProtected requires a fresh nonce and timestamp (and, if configured,
+	// a request signature) when replay protection is enabled
+	ReplayProtected bool
+
+	// LeavesRegion marks routes that hand user data to services outside the
+	// deployment's region; they answer 451 under data residency
+	LeavesRegion bool
+
+	// DevelopmentOnly routes are only registered in development
+	DevelopmentOnly bool
+
+	// Streaming routes send their response as it is written, so they skip the
+	// route timeout (http.TimeoutHandler holds the whole response until done).
+	// Their handlers must move the server's write deadline as they go.
+	Streaming bool
+}
+
+// Routes returns the route table in registration order. Order matters where
+// paths overlap: static segments (e.g. /articles/feed) must precede /articles/{slug}.
+// RealWorld-compatible endpoints parse leniently so clients sending extra
+// fields keep working.
+func (s *Server) Routes() []Route {
+	return []Route{
+		// Health check endpoint
+		{Name: "health", Method: http.MethodGet, Path: "/health", Handler: s.healthHandlers.Check, RateLimit: RateLimitRead},
+		{Name: "health.ready", Method: http.MethodGet, Path: "/health/ready", Handler: s.healthHandlers.Ready, RateLimit: RateLimitRead},
+
+		// Data for a public status page
+		{Name: "status", Method: http.MethodGet, Path: "/api/status", Handler: s.healthHandlers.Status, RateLimit: RateLimitRead},
+
+		// Start draining before shutdown, e.g. from a preStop hook (drain token)
+		{Name: "lifecycle.drain", Method: http.MethodPost, Path: "/internal/drain", Handler: s.healthHandlers.Drain, Auth: AuthDrain, RateLimit: RateLimitAdmin},
+
+		// Business KPIs for monitoring (scrape token)
+		{Name: "metrics", Method: http.MethodGet, Path: "/metrics", Handler: s.metricsHandlers.GetMetrics, Auth: AuthMetrics, RateLimit: RateLimitRead, Produces: []string{mediaTypeMetrics, mediaTypeText}},
+
+		// Public keys other services validate our access tokens with
+		{Name: "jwks", Method: http.MethodGet, Path: "/.well-known/jwks.json", Handler: s.jwksHandlers.GetJWKS, RateLimit: RateLimitRead},
+
+		// Emails captured instead of sent, for iterating on email copy
+		{Name: "debug.emails", Method: http.MethodGet, Path: "/debug/emails", Handler: s.emailPreviewHandlers.ListEmails, RateLimit: RateLimitRead, DevelopmentOnly: true},
+
+		// Short share links count a click and redirect to the article
+		{Name: "share.follow", Method: http.MethodGet, Path: "/s/{code}", Handler: s.shareHandlers.FollowShareLink, RateLimit: RateLimitRead},
+
+		// Script-free reader view of an article, for printing
+		{Name: "articles.print", Method: http.MethodGet, Path: "/articles/{slug}/print", Handler: s.articleHandlers.PrintArticle, Auth: AuthOptional, RateLimit: RateLimitRead, Produces: []string{mediaTypeHTML}},
+
+		// Regional consent, residency and age rules for clients
+		{Name: "compliance", Method: http.MethodGet, Path: "/api/compliance", Handler: s.complianceHandlers.GetCompliance, RateLimit: RateLimitRead},
+
+		// Authentication routes
+		{Name: "users.register", Method: http.MethodPost, Path: "/api/users", Handler: s.authHandlers.RegisterUser, RateLimit: RateLimitAuth, LenientJSON: true},
+		{Name: "users.check", Method: http.MethodGet, Path: "/api/users/check", Handler: s.authHandlers.CheckAvailability, RateLimit: RateLimitAuth},
+		{Name: "users.login", Method: http.MethodPost, Path: "/api/users/login", Handler: s.authHandlers.LoginUser, RateLimit: RateLimitAuth, LenientJSON: true},
+		{Name: "users.logout", Method: http.MethodPost, Path: "/api/users/logout", Handler: s.authHandlers.LogoutUser, Auth: AuthUser, RateLimit: RateLimitAuth},
+		{Name: "user.get", Method: http.MethodGet, Path: "/api/user", Handler: s.authHandlers.GetCurrentUser, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.update", Method: http.MethodPut, Path: "/api/user", Handler: s.authHandlers.UpdateUser, Auth: AuthUser, RateLimit: RateLimitWrite, LenientJSON: true, ReplayProtected: true},
+		{Name: "user.deactivate", Method: http.MethodPost, Path: "/api/user/deactivate", Handler: s.authHandlers.DeactivateAccount, Auth: AuthUser, RateLimit: RateLimitAuth, ReplayProtected: true},
+		{Name: "user.delete", Method: http.MethodDelete, Path: "/api/user", Handler: s.deletionHandlers.DeleteAccount, Auth: AuthUser, RateLimit: RateLimitAuth, ReplayProtected: true},
+		{Name: "deletionCertificates.get", Method: http.MethodGet, Path: "/api/deletion-certificates/{id}", Handler: s.deletionHandlers.GetDeletionCertificate, RateLimit: RateLimitRead},
+		{Name: "user.notificationSettings.get", Method: http.MethodGet, Path: "/api/user/notification-settings", Handler: s.notificationHandlers.GetNotificationSettings, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.notificationSettings.update", Method: http.MethodPut, Path: "/api/user/notification-settings", Handler: s.notificationHandlers.UpdateNotificationSettings, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "user.activity", Method: http.MethodGet, Path: "/api/user/activity", Handler: s.activityHandlers.ListActivity, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.articles", Method: http.MethodGet, Path: "/api/user/articles", Handler: s.articleHandlers.ListOwnArticles, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.settings.get", Method: http.MethodGet, Path: "/api/user/settings", Handler: s.settingsHandlers.GetSettings, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.pushSubscriptions.list", Method: http.MethodGet, Path: "/api/user/push-subscriptions", Handler: s.pushHandlers.ListPushSubscriptions, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.pushSubscriptions.create", Method: http.MethodPost, Path: "/api/user/push-subscriptions", Handler: s.pushHandlers.CreatePushSubscription, Auth: AuthUser, RateLimit: RateLimitWrite, LeavesRegion: true},
+		{Name: "user.pushSubscriptions.delete", Method: http.MethodDelete, Path: "/api/user/push-subscriptions/{id}", Handler: s.pushHandlers.DeletePushSubscription, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "user.sessions.list", Method: http.MethodGet, Path: "/api/user/sessions", Handler: s.sessionHandlers.ListSessions, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.sessions.revoke", Method: http.MethodDelete, Path: "/api/user/sessions/{id}", Handler: s.sessionHandlers.RevokeSession, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "user.export", Method: http.MethodGet, Path: "/api/user/export", Handler: s.userDataHandlers.ExportUserData, Auth: AuthUser, RateLimit: RateLimitWrite, Streaming: true},
+		{Name: "user.apiKeys.list", Method: http.MethodGet, Path: "/api/user/api-keys", Handler: s.apiKeyHandlers.ListAPIKeys, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "user.apiKeys.create", Method: http.MethodPost, Path: "/api/user/api-keys", Handler: s.apiKeyHandlers.CreateAPIKey, Auth: AuthUser, RateLimit: RateLimitWrite, ReplayProtected: true},
+		{Name: "user.apiKeys.revoke", Method: http.MethodDelete, Path: "/api/user/api-keys/{id}", Handler: s.apiKeyHandlers.RevokeAPIKey, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "push.key", Method: http.MethodGet, Path: "/api/push/key", Handler: s.pushHandlers.GetPushKey, RateLimit: RateLimitRead, LeavesRegion: true},
+		{Name: "user.settings.update", Method: http.MethodPut, Path: "/api/user/settings", Handler: s.settingsHandlers.UpdateSettings, Auth: AuthUser, RateLimit: RateLimitWrite},
+
+		// Articles routes
+		{Name: "articles.list", Method: http.MethodGet, Path: "/api/articles", Handler: s.articleHandlers.ListArticles, Auth: AuthOptional, RateLimit: RateLimitRead},
+		{Name: "articles.create", Method: http.MethodPost, Path: "/api/articles", Handler: s.articleHandlers.CreateArticle, Auth: AuthUser, RateLimit: RateLimitWrite, LenientJSON: true},
+		{Name: "articles.feed", Method: http.MethodGet, Path: "/api/articles/feed", Handler: s.feedHandlers.GetFeed, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "articles.feed.unread", Method: http.MethodGet, Path: "/api/articles/feed/unread", Handler: s.feedHandlers.GetFeedUnreadCount, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "articles.picks", Method: http.MethodGet, Path: "/api/articles/picks", Handler: s.pickHandlers.ListPicks, RateLimit: RateLimitRead},
+		{Name: "articles.trending", Method: http.MethodGet, Path: "/api/articles/trending", Handler: s.analyticsHandlers.ListTrendingArticles, RateLimit: RateLimitRead},
+		{Name: "articles.get", Method: http.MethodGet, Path: "/api/articles/{slug}", Handler: s.articleHandlers.GetArticle, Auth: AuthOptional, RateLimit: RateLimitRead},
+		{Name: "articles.update", Method: http.MethodPut, Path: "/api/articles/{slug}", Handler: s.articleHandlers.UpdateArticle, Auth: AuthUser, RateLimit: RateLimitWrite, LenientJSON: true},
+		{Name: "articles.delete", Method: http.MethodDelete, Path: "/api/articles/{slug}", Handler: s.articleHandlers.DeleteArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.translations.create", Method: http.MethodPost, Path: "/api/articles/{slug}/translations", Handler: s.articleHandlers.CreateTranslation, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.linkPreviews.create", Method: http.MethodPost, Path: "/api/articles/{slug}/link-previews", Handler: s.linkPreviewHandlers.AttachLinkPreview, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.linkPreviews.delete", Method: http.MethodDelete, Path: "/api/articles/{slug}/link-previews", Handler: s.linkPreviewHandlers.DetachLinkPreview, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.attachments.create", Method: http.MethodPost, Path: "/api/articles/{slug}/attachments", Handler: s.attachmentHandlers.CreateAttachment, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.attachments.get", Method: http.MethodGet, Path: "/api/articles/{slug}/attachments/{name}", Handler: s.attachmentHandlers.GetAttachment, Auth: AuthOptional, RateLimit: RateLimitRead, Produces: []string{mediaTypeText}},
+		{Name: "articles.attachments.delete", Method: http.MethodDelete, Path: "/api/articles/{slug}/attachments/{name}", Handler: s.attachmentHandlers.DeleteAttachment, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.export", Method: http.MethodGet, Path: "/api/articles/{slug}/export", Handler: s.exportHandlers.ExportArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "exports.download", Method: http.MethodGet, Path: "/api/exports/{id}/download", Handler: s.exportHandlers.DownloadExport, Auth: AuthUser, RateLimit: RateLimitRead, Produces: []string{mediaTypePDF}},
+		{Name: "exports.books.create", Method: http.MethodPost, Path: "/api/exports/books", Handler: s.bookExportHandlers.ExportArticles, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "exports.books.get", Method: http.MethodGet, Path: "/api/exports/books/{id}", Handler: s.bookExportHandlers.GetBookExport, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "exports.books.download", Method: http.MethodGet, Path: "/api/exports/books/{id}/download", Handler: s.bookExportHandlers.DownloadBookExport, Auth: AuthUser, RateLimit: RateLimitRead, Produces: []string{mediaTypeEPUB}},
+		{Name: "articles.favorite", Method: http.MethodPost, Path: "/api/articles/{slug}/favorite", Handler: s.favoriteHandlers.FavoriteArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.unfavorite", Method: http.MethodDelete, Path: "/api/articles/{slug}/favorite", Handler: s.favoriteHandlers.UnfavoriteArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.pin", Method: http.MethodPost, Path: "/api/articles/{slug}/pin", Handler: s.pinHandlers.PinArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.unpin", Method: http.MethodDelete, Path: "/api/articles/{slug}/pin", Handler: s.pinHandlers.UnpinArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.clap", Method: http.MethodPost, Path: "/api/articles/{slug}/clap", Handler: s.clapHandlers.ClapArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "articles.favoriters", Method: http.MethodGet, Path: "/api/articles/{slug}/favoriters", Handler: s.favoriteHandlers.ListFavoriters, RateLimit: RateLimitRead},
+		{Name: "articles.shareLinks", Method: http.MethodGet, Path: "/api/articles/{slug}/share", Handler: s.shareHandlers.GetShareLinks, RateLimit: RateLimitRead},
+		{Name: "articles.stats", Method: http.MethodGet, Path: "/api/articles/{slug}/stats", Handler: s.analyticsHandlers.GetArticleStats, Auth: AuthUser, RateLimit: RateLimitRead, Produces: []string{mediaTypeJSON, mediaTypeCSV}},
+
+		// Series routes
+		{Name: "series.create", Method: http.MethodPost, Path: "/api/series", Handler: s.seriesHandlers.CreateSeries, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "series.get", Method: http.MethodGet, Path: "/api/series/{slug}", Handler: s.seriesHandlers.GetSeries, RateLimit: RateLimitRead},
+		{Name: "series.export", Method: http.MethodGet, Path: "/api/series/{slug}/export", Handler: s.bookExportHandlers.ExportSeries, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "series.delete", Method: http.MethodDelete, Path: "/api/series/{slug}", Handler: s.seriesHandlers.DeleteSeries, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "series.articles.add", Method: http.MethodPost, Path: "/api/series/{slug}/articles", Handler: s.seriesHandlers.AddSeriesArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "series.articles.remove", Method: http.MethodDelete, Path: "/api/series/{slug}/articles/{article}", Handler: s.seriesHandlers.RemoveSeriesArticle, Auth: AuthUser, RateLimit: RateLimitWrite},
+
+		// Reading list routes
+		{Name: "lists.create", Method: http.MethodPost, Path: "/api/reading-lists", Handler: s.readingListHandlers.CreateReadingList, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "lists.get", Method: http.MethodGet, Path: "/api/reading-lists/{slug}", Handler: s.readingListHandlers.GetReadingList, Auth: AuthOptional, RateLimit: RateLimitRead},
+		{Name: "lists.delete", Method: http.MethodDelete, Path: "/api/reading-lists/{slug}", Handler: s.readingListHandlers.DeleteReadingList, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "lists.articles.add", Method: http.MethodPost, Path: "/api/reading-lists/{slug}/articles", Handler: s.readingListHandlers.AddReadingListItem, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "lists.articles.reorder", Method: http.MethodPut, Path: "/api/reading-lists/{slug}/articles", Handler: s.readingListHandlers.ReorderReadingList, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "lists.articles.remove", Method: http.MethodDelete, Path: "/api/reading-lists/{slug}/articles/{article}", Handler: s.readingListHandlers.RemoveReadingListItem, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "lists.follow", Method: http.MethodPost, Path: "/api/reading-lists/{slug}/follow", Handler: s.readingListHandlers.FollowReadingList, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "lists.unfollow", Method: http.MethodDelete, Path: "/api/reading-lists/{slug}/follow", Handler: s.readingListHandlers.UnfollowReadingList, Auth: AuthUser, RateLimit: RateLimitWrite},
+
+		// Link previews for the editor
+		{Name: "linkPreviews.get", Method: http.MethodGet, Path: "/api/link-preview", Handler: s.linkPreviewHandlers.GetLinkPreview, Auth: AuthUser, RateLimit: RateLimitWrite},
+
+		// Comments routes
+		{Name: "comments.list", Method: http.MethodGet, Path: "/api/articles/{slug}/comments", Handler: s.commentHandlers.GetCommentsByArticle, Auth: AuthOptional, RateLimit: RateLimitRead},
+		{Name: "comments.create", Method: http.MethodPost, Path: "/api/articles/{slug}/comments", Handler: s.commentHandlers.CreateComment, Auth: AuthUser, RateLimit: RateLimitWrite, LenientJSON: true},
+		{Name: "comments.get", Method: http.MethodGet, Path: "/api/comments/{id}", Handler: s.commentHandlers.GetComment, RateLimit: RateLimitRead},
+		{Name: "comments.lock", Method: http.MethodPut, Path: "/api/articles/{slug}/comments/lock", Handler: s.commentHandlers.LockComments, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "comments.unlock", Method: http.MethodDelete, Path: "/api/articles/{slug}/comments/lock", Handler: s.commentHandlers.UnlockComments, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "comments.update", Method: http.MethodPut, Path: "/api/articles/{slug}/comments/{id}", Handler: s.commentHandlers.UpdateComment, Auth: AuthUser, RateLimit: RateLimitWrite, LenientJSON: true},
+		{Name: "comments.delete", Method: http.MethodDelete, Path: "/api/articles/{slug}/comments/{id}", Handler: s.commentHandlers.DeleteComment, Auth: AuthUser, RateLimit: RateLimitWrite},
+
+		// Inbound email webhook (reply-by-email)
+		{Name: "webhooks.email.inbound", Method: http.MethodPost, Path: "/api/webhooks/email/inbound", Handler: s.commentHandlers.CreateCommentFromEmail, Auth: AuthWebhook, RateLimit: RateLimitWrite, Consumes: []string{mediaTypeJSON, mediaTypeForm, mediaTypeMultipart}},
+
+		// Bounce, complaint and delivery notifications from the email provider
+		{Name: "webhooks.email.events", Method: http.MethodPost, Path: "/api/webhooks/email/events", Handler: s.emailStatusHandlers.ReceiveEmailEvents, Auth: AuthWebhook, RateLimit: RateLimitWrite},
+
+		// Analytics ingestion (anonymous); navigator.sendBeacon posts text/plain
+		{Name: "events.record", Method: http.MethodPost, Path: "/api/events", Handler: s.analyticsHandlers.RecordEvents, Auth: AuthOptional, RateLimit: RateLimitWrite, Timeout: 5 * time.Second, Consumes: []string{mediaTypeJSON, mediaTypeText}},
+
+		// Profile routes (static paths before /profiles/{username})
+		{Name: "profiles.suggestions", Method: http.MethodGet, Path: "/api/profiles/suggestions", Handler: s.profileHandlers.GetSuggestions, Auth: AuthUser, RateLimit: RateLimitRead},
+		{Name: "profiles.follow.bulk", Method: http.MethodPost, Path: "/api/profiles/follow", Handler: s.profileHandlers.FollowProfiles, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "profiles.unfollow.bulk", Method: http.MethodPost, Path: "/api/profiles/unfollow", Handler: s.profileHandlers.UnfollowProfiles, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "profiles.get", Method: http.MethodGet, Path: "/api/profiles/{username}", Handler: s.profileHandlers.GetProfile, Auth: AuthOptional, RateLimit: RateLimitRead},
+		{Name: "profiles.follow", Method: http.MethodPost, Path: "/api/profiles/{username}/follow", Handler: s.profileHandlers.FollowProfile, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "profiles.unfollow", Method: http.MethodDelete, Path: "/api/profiles/{username}/follow", Handler: s.profileHandlers.UnfollowProfile, Auth: AuthUser, RateLimit: RateLimitWrite},
+		{Name: "profiles.followers", Method: http.MethodGet, Path: "/api/profiles/{username}/followers", Handler: s.profileHandlers.ListFollowers, Auth: AuthOptional, RateLimit: RateLimitRead},
+		{Name: "profiles.activity", Method: http.MethodGet, Path: "/api/profiles/{username}/activity", Handler: s.profileHandlers.ListActivity, Auth: AuthOptional, RateLimit: RateLimitRead},
+
+		// Syndication (cached until the listed articles change)
+		{Name: "syndication.feed", Method: http.MethodGet, Path: "/rss.xml", Handler: s.syndicationHandlers.GetFeed, RateLimit: RateLimitRead, Produces: []string{mediaTypeRSS, mediaTypeXML, mediaTypeTextXML}},
+		{Name: "syndication.feed.author", Method: http.MethodGet, Path: "/rss/{username}.xml", Handler: s.syndicationHandlers.GetAuthorFeed, RateLimit: RateLimitRead, Produces: []string{mediaTypeRSS, mediaTypeXML, mediaTypeTextXML}},
+		{Name: "syndication.sitemap", Method: http.MethodGet, Path: "/sitemap.xml", Handler: s.syndicationHandlers.GetSitemap, RateLimit: RateLimitRead, Produces: []string{mediaTypeXML, mediaTypeTextXML}},
+
+		// Crawler rules
+		{Name: "syndication.robots", Method: http.MethodGet, Path: "/robots.txt", Handler: s.robotsHandlers.GetRobots, RateLimit: RateLimitRead, Produces: []string{mediaTypeText}},
+
+		// IndexNow key file, fetched by search engines to verify pings
+		{Name: "searchPings.indexNowKey", Method: http.MethodGet, Path: "/indexnow.txt", Handler: s.searchPingHandlers.GetIndexNowKey, RateLimit: RateLimitRead, Produces: []string{mediaTypeText}},
+
+		// External images referenced in article bodies
+		{Name: "media.imageProxy", Method: http.MethodGet, Path: "/img-proxy", Handler: s.imageProxyHandlers.GetImage, RateLimit: RateLimitRead, Produces: []string{mediaTypePNG, mediaTypeJPEG, mediaTypeGIF}},
+
+		// Generated avatars for users without an image
+		{Name: "media.avatars.get", Method: http.MethodGet, Path: "/media/avatars/{username}.png", Handler: s.avatarHandlers.GetAvatar, RateLimit: RateLimitRead, Produces: []string{mediaTypePNG}},
+
+		// Tags routes
+		{Name: "tags.list", Method: http.MethodGet, Path: "/api/tags", Handler: s.tagHandlers.ListTags, RateLimit: RateLimitRead},
+		{Name: "tags.get", Method: http.MethodGet, Path: "/api/tags/{tag}", Handler: s.tagHandlers.GetTag, RateLimit: RateLimitRead},
+		{Name: "tags.describe", Method: http.MethodPut, Path: "/api/tags/{tag}", Handler: s.tagHandlers.UpdateTagDescription, Auth: AuthModerator, RateLimit: RateLimitWrite},
+
+		// Article approval queue (moderators and admins)
+		{Name: "moderation.articles.pending", Method: http.MethodGet, Path: "/api/moderation/articles/pending", Handler: s.reviewHandlers.ListPendingArticles, Auth: AuthModerator, RateLimit: RateLimitAdmin},
+		{Name: "moderation.articles.approve", Method: http.MethodPost, Path: "/api/moderation/articles/{slug}/approve", Handler: s.reviewHandlers.ApproveArticle, Auth: AuthModerator, RateLimit: RateLimitAdmin},
+		{Name: "moderation.articles.reject", Method: http.MethodPost, Path: "/api/moderation/articles/{slug}/reject", Handler: s.reviewHandlers.RejectArticle, Auth: AuthModerator, RateLimit: RateLimitAdmin},
+
+		// Admin routes
+		{Name: "admin.anomalies.list", Method: http.MethodGet, Path: "/api/admin/anomalies", Handler: s.anomalyHandlers.ListAnomalies, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.anomalies.scan", Method: http.MethodPost, Path: "/api/admin/anomalies/scan", Handler: s.anomalyHandlers.ScanAnomalies, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.anomalies.resolve", Method: http.MethodPost, Path: "/api/admin/anomalies/{id}/actions", Handler: s.anomalyHandlers.ResolveAnomaly, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.articles.delete", Method: http.MethodDelete, Path: "/api/admin/articles/{slug}", Handler: s.articleHandlers.AdminDeleteArticle, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.articles.feature", Method: http.MethodPut, Path: "/api/admin/articles/{slug}/feature", Handler: s.pickHandlers.FeatureArticle, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.articles.unfeature", Method: http.MethodDelete, Path: "/api/admin/articles/{slug}/feature", Handler: s.pickHandlers.UnfeatureArticle, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.articles.legalHold.place", Method: http.MethodPost, Path: "/api/admin/articles/{slug}/legal-hold", Handler: s.legalHoldHandlers.PlaceArticleHold, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.articles.legalHold.release", Method: http.MethodDelete, Path: "/api/admin/articles/{slug}/legal-hold", Handler: s.legalHoldHandlers.ReleaseArticleHold, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.comments.delete", Method: http.MethodDelete, Path: "/api/admin/comments/{id}", Handler: s.commentHandlers.HardDeleteComment, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.config", Method: http.MethodGet, Path: "/api/admin/config", Handler: s.configHandlers.GetConfig, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.incidents.list", Method: http.MethodGet, Path: "/api/admin/incidents", Handler: s.incidentHandlers.ListIncidents, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.incidents.create", Method: http.MethodPost, Path: "/api/admin/incidents", Handler: s.incidentHandlers.CreateIncident, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.incidents.update", Method: http.MethodPut, Path: "/api/admin/incidents/{id}", Handler: s.incidentHandlers.UpdateIncident, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.incidents.resolve", Method: http.MethodPost, Path: "/api/admin/incidents/{id}/resolve", Handler: s.incidentHandlers.ResolveIncident, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.invites.list", Method: http.MethodGet, Path: "/api/admin/invites", Handler: s.inviteHandlers.ListInvites, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.invites.create", Method: http.MethodPost, Path: "/api/admin/invites", Handler: s.inviteHandlers.CreateInvites, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.legalHolds.list", Method: http.MethodGet, Path: "/api/admin/legal-holds", Handler: s.legalHoldHandlers.ListLegalHolds, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.searchPings.list", Method: http.MethodGet, Path: "/api/admin/search-pings", Handler: s.searchPingHandlers.ListSearchPings, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.stats", Method: http.MethodGet, Path: "/api/admin/stats", Handler: s.adminHandlers.GetPlatformStats, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.stats.articles", Method: http.MethodGet, Path: "/api/admin/stats/articles", Handler: s.analyticsHandlers.ListArticleStats, Auth: AuthAdmin, RateLimit: RateLimitAdmin, Produces: []string{mediaTypeJSON, mediaTypeCSV}},
+		{Name: "admin.syndication.stats", Method: http.MethodGet, Path: "/api/admin/syndication/cache", Handler: s.syndicationHandlers.GetCacheStats, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.syndication.flush", Method: http.MethodDelete, Path: "/api/admin/syndication/cache", Handler: s.syndicationHandlers.FlushCache, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.tags.list", Method: http.MethodGet, Path: "/api/admin/tags", Handler: s.tagHandlers.ListTagsDetailed, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.tags.aliases.delete", Method: http.MethodDelete, Path: "/api/admin/tags/aliases/{alias}", Handler: s.tagHandlers.RemoveTagAlias, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.tags.rename", Method: http.MethodPut, Path: "/api/admin/tags/{tag}", Handler: s.tagHandlers.RenameTag, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.tags.merge", Method: http.MethodPost, Path: "/api/admin/tags/{tag}/merge", Handler: s.tagHandlers.MergeTag, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.tags.aliases.create", Method: http.MethodPost, Path: "/api/admin/tags/{tag}/aliases", Handler: s.tagHandlers.AddTagAlias, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.list", Method: http.MethodGet, Path: "/api/admin/users", Handler: s.adminHandlers.ListUsers, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.moderation.set", Method: http.MethodPut, Path: "/api/admin/users/{username}/moderation", Handler: s.adminHandlers.SetModerationStatus, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.legalHold.place", Method: http.MethodPost, Path: "/api/admin/users/{username}/legal-hold", Handler: s.legalHoldHandlers.PlaceUserHold, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.legalHold.release", Method: http.MethodDelete, Path: "/api/admin/users/{username}/legal-hold", Handler: s.legalHoldHandlers.ReleaseUserHold, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.quota.get", Method: http.MethodGet, Path: "/api/admin/users/{username}/quota", Handler: s.quotaHandlers.GetQuota, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.quota.set", Method: http.MethodPut, Path: "/api/admin/users/{username}/quota", Handler: s.quotaHandlers.SetQuotaOverride, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.quota.delete", Method: http.MethodDelete, Path: "/api/admin/users/{username}/quota", Handler: s.quotaHandlers.DeleteQuotaOverride, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.quarantine.get", Method: http.MethodGet, Path: "/api/admin/users/{username}/quarantine", Handler: s.quarantineHandlers.GetQuarantine, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.emailStatus.get", Method: http.MethodGet, Path: "/api/admin/users/{username}/email-status", Handler: s.emailStatusHandlers.GetEmailStatus, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.emailStatus.clear", Method: http.MethodDelete, Path: "/api/admin/users/{username}/email-status", Handler: s.emailStatusHandlers.ClearEmailStatus, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+		{Name: "admin.users.quarantine.approve", Method: http.MethodPost, Path: "/api/admin/users/{username}/quarantine/approve", Handler: s.quarantineHandlers.ApproveAccount, Auth: AuthAdmin, RateLimit: RateLimitAdmin},
+	}
+}
+
+// registerRoutes adds every route in the table to the router, wrapping each
+// handler in the middleware its metadata calls for
+func (s *Server) registerRoutes(routes []Route) {
+	for _, route := range routes {
+		if route.Auth == AuthWebhook && s.config.InboundEmailSecret == "" {
+			continue
+		}
+		if route.Auth == AuthMetrics && s.config.MetricsToken == "" {
+			continue
+		}
+		if route.Auth == AuthDrain && s.config.DrainToken == "" {
+			continue
+		}
+		if route.DevelopmentOnly && !s.config.IsDevelopment() {
+			continue
+		}
+		if s.unavailableInRegion(route) {
+			route.Handler = handlers.UnavailableInRegionHandler
+		}
+
+		s.router.Handle(route.Path, s.routeHandler(route)).Methods(route.Method).Name(route.Name)
+	}
+
+	// Unknown paths and unsupported methods get JSON errors from the router,
+	// so handlers don't need to check the method themselves
+	s.router.NotFoundHandler = http.HandlerFunc(handlers.NotFoundHandler)
+	s.router.MethodNotAllowedHandler = handlers.MethodNotAllowedHandler(s.allowedMethods)
+}
+
+// unavailableInRegion reports whether the deployment's compliance rules
+// switch the route off
+func (s *Server) unavailableInRegion(route Route) bool {
+	return route.LeavesRegion && s.config.DataResidency
+}
+
+// compliance summarises the compliance rules for clients, listing the routes
+// they switch off
+func (s *Server) compliance() entities.Compliance {
+	compliance := entities.Compliance{
+		Region:                s.config.ComplianceRegion,
+		CookieConsentRequired: s.config.CookieConsentRequired,
+		DataResidency:         s.config.DataResidency,
+		MinimumAge:            s.config.MinimumAge,
+		BirthdateRequired:     s.config.RequireBirthdate,
+		Unavailable:           []string{},
+	}
+	if compliance.CookieConsentRequired {
+		compliance.ConsentCookie = entities.AnalyticsConsentCookie
+	}
+	for _, route := range s.Routes() {
+		if s.unavailableInRegion(route) {
+			compliance.Unavailable = append(compliance.Unavailable, route.Name)
+		}
+	}
+	return compliance
+}
+
+// allowedMethods returns the methods registered for the request's path
+func (s *Server) allowedMethods(r *http.Request) []string {
+	seen := make(map[string]bool)
+
+	s.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			probe := r.Clone(r.Context())
+			probe.Method = method
+			if route.Match(probe, &mux.RouteMatch{}) {
+				seen[method] = true
+			}
+		}
+		return nil
+	})
+
+	allowed := make([]string, 0, len(seen))
+	for method := range seen {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// routeHandler builds the middleware chain for a route: route metadata, then
+// rate limiting, content negotiation, JSON parsing mode, timeout,
+// authentication and authorization, and finally replay protection
+func (s *Server) routeHandler(route Route) http.Handler {
+	var handler http.Handler = route.Handler
+
+	if route.ReplayProtected && s.nonces != nil {
+		handler = middleware.RequireFreshRequest(s.nonces, s.config.ReplayProtection == "signed")(handler)
+	}
+
+	tokens := s.tokenOptions()
+	switch route.Auth {
+	case AuthUser:
+		handler = middleware.RequireScope(routeScope(route))(handler)
+		handler = middleware.RequireActiveUser(s.lookupAccess)(handler)
+		handler = middleware.AuthMiddleware(tokens)(handler)
+	case AuthOptional:
+		handler = middleware.RequireScope(routeScope(route))(handler)
+		handler = middleware.OptionalAuthMiddleware(tokens)(handler)
+	case AuthModerator:
+		handler = middleware.RequireRole(s.lookupRole, entities.RoleModerator, entities.RoleAdmin)(handler)
+		handler = middleware.RequireScope(routeScope(route))(handler)
+		handler = middleware.RequireActiveUser(s.lookupAccess)(handler)
+		handler = middleware.AuthMiddleware(tokens)(handler)
+	case AuthAdmin:
+		handler = middleware.RequireRole(s.lookupRole, entities.RoleAdmin)(handler)
+		handler = middleware.RequireScope(routeScope(route))(handler)
+		handler = middleware.RequireActiveUser(s.lookupAccess)(handler)
+		handler = middleware.AuthMiddleware(tokens)(handler)
+	case AuthWebhook:
+		handler = middleware.RequireWebhookSecret(s.config.InboundEmailSecret)(handler)
+	case AuthMetrics:
+		handler = middleware.RequireBearerToken(s.config.MetricsToken)(handler)
+	case AuthDrain:
+		handler = middleware.RequireBearerToken(s.config.DrainToken)(handler)
+	}
+
+	timeout := route.Timeout
+	if timeout <= 0 {
+		timeout = defaultRouteTimeout
+	}
+	handler = middleware.RestoreCorrelationHeaders(handler)
+	if !route.Streaming {
+		handler = http.TimeoutHandler(handler, timeout, `{"error":"Request timed out"}`)
+	}
+
+	parsing := middleware.JSONStrict
+	if route.LenientJSON {
+		parsing = middleware.JSONLenient
+	}
+	handler = middleware.JSONParsing(parsing)(handler)
+
+	consumes, produces := route.Consumes, route.Produces
+	if len(consumes) == 0 {
+		consumes = []string{mediaTypeJSON}
+	}
+	if len(produces) == 0 {
+		produces = []string{mediaTypeJSON}
+	}
+	handler = middleware.RequireContentType(consumes...)(handler)
+	handler = middleware.RequireAccept(produces...)(handler)
+
+	// Each client's budget is shared by every route in the class
+	if limiter, ok := s.rateLimiters[route.RateLimit]; ok {
+		handler = middleware.RateLimitByClient(limiter)(handler)
+	}
+
+	return middleware.WithRouteInfo(middleware.RouteInfo{
+		Name:           route.Name,
+		RateLimitClass: route.RateLimit,
+	})(handler)
+}
+
+// tokenOptions returns how access tokens are validated
+func (s *Server) tokenOptions() middleware.TokenOptions {
+	options := middleware.TokenOptions{
+		Secret:   s.config.JWTSecret,
+		Issuer:   s.config.JWTIssuer,
+		Audience: s.config.JWTAudience,
+		Leeway:   time.Duration(s.config.TokenClockSkewSeconds) * time.Second,
+		Revoked:  s.lookupRevoked,
+		Sessions: s.lookupSession,
+	}
+	if s.jwtKeys != nil {
+		options.Keys = s.jwtKeys.VerificationKey
+		options.Algorithms = s.jwtKeys.Algorithms()
+	}
+	if s.apiKeyRepo != nil {
+		options.APIKeys = s.lookupAPIKey
+	}
+	return options
+}
+
+// routeScope returns the token scope a route needs: admin for moderator and
+// admin routes, read for safe methods and write for everything else
+func routeScope(route Route) string {
+	switch {
+	case route.Auth == AuthModerator || route.Auth == AuthAdmin:
+		return entities.ScopeAdmin
+	case route.Method == http.MethodGet || route.Method == http.MethodHead:
+		return entities.ScopeRead
+	default:
+		return entities.ScopeWrite
+	}
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/emotab87/vibe_coding/backend/internal/config"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/handlers"
+	"github.com/emotab87/vibe_coding/backend/internal/middleware"
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+func TestRoutes_TableIsConsistent(t *testing.T) {
+	s := &Server{}
+
+	classes := rateLimitClasses(config.LoadConfig())
+
+	names := make(map[string]bool)
+	endpoints := make(map[string]bool)
+	for _, route := range s.Routes() {
+		if route.Name == "" || route.Method == "" || route.Path == "" || route.Handler == nil {
+			t.Errorf("Route %+v is missing required fields", route)
+		}
+		if _, ok := classes[route.RateLimit]; !ok {
+			t.Errorf("Route %s has unknown rate limit class %q", route.Name, route.RateLimit)
+		}
+
+		if names[route.Name] {
+			t.Errorf("Duplicate route name %s", route.Name)
+		}
+		names[route.Name] = true
+
+		endpoint := route.Method + " " + route.Path
+		if endpoints[endpoint] {
+			t.Errorf("Duplicate route %s", endpoint)
+		}
+		endpoints[endpoint] = true
+
+		if strings.HasPrefix(route.Path, "/api/admin/") && route.Auth != AuthAdmin {
+			t.Errorf("Admin route %s must require the admin role", route.Name)
+		}
+	}
+}
+
+func TestNewRateLimiters_SkipsDisabledClasses(t *testing.T) {
+	cfg := &config.Config{
+		RateLimitAuthPerMinute:  30,
+		RateLimitAuthBurst:      10,
+		RateLimitWritePerMinute: 120,
+		RateLimitWriteBurst:     30,
+	}
+
+	limiters := newRateLimiters(cfg)
+	if len(limiters) != 2 || limiters[RateLimitAuth] == nil || limiters[RateLimitWrite] == nil {
+		t.Errorf("Expected limiters for the auth and write classes only, got %v", limiters)
+	}
+}
+
+func TestRoutes_StaticPathsPrecedeParameters(t *testing.T) {
+	s := &Server{}
+
+	order := make(map[string]int)
+	for i, route := range s.Routes() {
+		order[route.Name] = i
+	}
+
+	for _, static := range []string{"articles.feed", "articles.feed.unread", "articles.trending"} {
+		if order[static] > order["articles.get"] {
+			t.Errorf("Route %s must be registered before articles.get", static)
+		}
+	}
+
+	if order["profiles.suggestions"] > order["profiles.get"] {
+		t.Errorf("Route profiles.suggestions must be registered before profiles.get")
+	}
+}
+
+func TestRoutes_MethodNotAllowedAndOptions(t *testing.T) {
+	s := &Server{router: mux.NewRouter(), config: &config.Config{}}
+	s.registerRoutes(s.Routes())
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		allow  string
+	}{
+		{http.MethodPatch, "/api/articles/hello", http.StatusMethodNotAllowed, "DELETE, GET, PUT, OPTIONS"},
+		{http.MethodOptions, "/api/articles", http.StatusNoContent, "GET, POST, OPTIONS"},
+		{http.MethodGet, "/api/unknown", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			s.router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rr.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rr.Code)
+			}
+			if got := rr.Header().Get("Allow"); got != tt.allow {
+				t.Errorf("Expected Allow %q, got %q", tt.allow, got)
+			}
+			if tt.status != http.StatusNoContent && rr.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Expected JSON error, got %q", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestRoutes_ContentNegotiation(t *testing.T) {
+	s := &Server{router: mux.NewRouter(), config: &config.Config{JWTSecret: "test-secret"}}
+	s.registerRoutes(s.Routes())
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		accept      string
+		status      int
+	}{
+		{"Form body on JSON endpoint", http.MethodPost, "/api/users", "application/x-www-form-urlencoded", "", http.StatusUnsupportedMediaType},
+		{"Missing content type", http.MethodPost, "/api/users", "", "", http.StatusUnsupportedMediaType},
+		{"Non UTF-8 charset", http.MethodPost, "/api/users", "application/json; charset=latin1", "", http.StatusUnsupportedMediaType},
+		{"XML only accepted", http.MethodGet, "/api/user", "", "application/xml", http.StatusNotAcceptable},
+		{"JSON excluded by q=0", http.MethodGet, "/api/user", "", "application/json;q=0", http.StatusNotAcceptable},
+		{"CSV accepted on stats route", http.MethodGet, "/api/articles/hello/stats", "", "text/csv", http.StatusUnauthorized},
+		{"Wildcard accept", http.MethodGet, "/api/user", "", "*/*", http.StatusUnauthorized},
+		{"JSON with charset", http.MethodPut, "/api/user", "application/json; charset=UTF-8", "application/json", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.method != http.MethodGet {
+				req = httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			s.router.ServeHTTP(rr, req)
+
+			// Requests that pass negotiation stop at authentication
+			if rr.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoutes_ReplayProtection(t *testing.T) {
+	cfg := &config.Config{ReplayProtection: "signed", ReplayWindowSeconds: 300}
+	s := &Server{config: cfg, nonces: newNonceCache(cfg)}
+	handler := s.routeHandler(Route{
+		Name:            "test.sensitive",
+		Method:          http.MethodPost,
+		Path:            "/sensitive",
+		Handler:         func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) },
+		ReplayProtected: true,
+	})
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	body := `{"user":{}}`
+	sign := func(timestamp, nonce string) string {
+		return middleware.SignRequest("secret-token", http.MethodPost, "/sensitive", timestamp, nonce, []byte(body))
+	}
+
+	tests := []struct {
+		name      string
+		nonce     string
+		timestamp string
+		signature string
+		status    int
+	}{
+		{"Missing nonce", "", now, sign(now, ""), http.StatusForbidden},
+		{"Stale timestamp", "nonce-0000000001", stale, sign(stale, "nonce-0000000001"), http.StatusForbidden},
+		{"Missing signature", "nonce-0000000002", now, "", http.StatusForbidden},
+		{"Wrong signature", "nonce-0000000003", now, sign(now, "another-nonce-000"), http.StatusForbidden},
+		{"Signed request", "nonce-0000000004", now, sign(now, "nonce-0000000004"), http.StatusNoContent},
+		{"Replayed request", "nonce-0000000004", now, sign(now, "nonce-0000000004"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/sensitive", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Token secret-token")
+			req.Header.Set(middleware.RequestNonceHeader, tt.nonce)
+			req.Header.Set(middleware.RequestTimestampHeader, tt.timestamp)
+			req.Header.Set(middleware.RequestSignatureHeader, tt.signature)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoutes_CorrelationIDs(t *testing.T) {
+	s := &Server{config: &config.Config{JWTSecret: "test-secret"}}
+	_, gateway, _ := net.ParseCIDR("10.0.0.0/8")
+	handler := middleware.Correlate([]*net.IPNet{gateway})(s.routeHandler(Route{
+		Name:    "test.private",
+		Method:  http.MethodGet,
+		Path:    "/private",
+		Handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) },
+		Auth:    AuthUser,
+	}))
+
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tests := []struct {
+		name       string
+		remoteAddr string
+		requestID  string
+		keepID     bool
+		traceID    string
+	}{
+		{"Untrusted client gets a fresh ID", "203.0.113.7:1234", "client-chosen-id", false, ""},
+		{"Trusted client keeps its ID", "10.1.2.3:1234", "gateway-id-42", true, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"Malformed ID is replaced", "10.1.2.3:1234", "bad id\nwith newline", false, "4bf92f3577b34da6a3ce929d0e0e4736"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/private", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(middleware.RequestIDHeader, tt.requestID)
+			req.Header.Set(middleware.TraceParentHeader, traceParent)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			requestID := rr.Header().Get(middleware.RequestIDHeader)
+			if requestID == "" || (requestID == tt.requestID) != tt.keepID {
+				t.Errorf("Unexpected request ID %q for inbound %q", requestID, tt.requestID)
+			}
+			if got := rr.Header().Get(middleware.TraceIDHeader); got != tt.traceID {
+				t.Errorf("Expected trace ID %q, got %q", tt.traceID, got)
+			}
+
+			// Errors from inside the route timeout carry the same IDs
+			var body middleware.ErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode error: %v", err)
+			}
+			if rr.Code != http.StatusUnauthorized || body.RequestID != requestID || body.TraceID != tt.traceID {
+				t.Errorf("Expected 401 carrying the correlation IDs, got %d %+v", rr.Code, body)
+			}
+		})
+	}
+}
+
+func TestRoutes_DataResidency(t *testing.T) {
+	s := &Server{router: mux.NewRouter(), config: &config.Config{ComplianceRegion: "eu", DataResidency: true}}
+
+	compliance := s.compliance()
+	if strings.Join(compliance.Unavailable, ",") != "user.pushSubscriptions.create,push.key" {
+		t.Errorf("Expected the web push routes to be unavailable, got %v", compliance.Unavailable)
+	}
+
+	s.registerRoutes(s.Routes())
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/push/key", nil))
+	if rr.Code != http.StatusUnavailableForLegalReasons {
+		t.Errorf("Expected status 451, got %d", rr.Code)
+	}
+}
+
+func TestRoutes_DebugEmailsOnlyInDevelopment(t *testing.T) {
+	capture := services.NewEmailCapture(2)
+	sender := services.NewCapturingLogEmailSender(capture)
+	for _, subject := range []string{"first", "second", "third"} {
+		if err := sender.Send(&services.EmailMessage{To: "alice@example.com", Subject: subject, Body: "<b>hi</b>"}); err != nil {
+			t.Fatalf("Failed to send email: %v", err)
+		}
+	}
+
+	for _, env := range []string{"development", "production"} {
+		t.Run(env, func(t *testing.T) {
+			s := &Server{router: mux.NewRouter(), config: &config.Config{Environment: env}, emailPreviewHandlers: handlers.NewEmailPreviewHandlers(capture)}
+			s.registerRoutes(s.Routes())
+
+			rr := httptest.NewRecorder()
+			s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/emails", nil))
+			if env != "development" {
+				if rr.Code != http.StatusNotFound {
+					t.Errorf("Expected status 404, got %d", rr.Code)
+				}
+				return
+			}
+
+			var response entities.EmailPreviewsResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.EmailsCount != 2 || response.Emails[0].Subject != "third" || response.Emails[1].Subject != "second" {
+				t.Errorf("Expected the two newest emails, newest first, got %+v", response.Emails)
+			}
+			if !strings.Contains(response.Emails[0].HTML, "&lt;b&gt;hi&lt;/b&gt;") {
+				t.Errorf("Expected the plain text body to be escaped in the preview, got %q", response.Emails[0].HTML)
+			}
+		})
+	}
+}
@@ -1,75 +1,470 @@
 package server
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 
 	"github.com/emotab87/vibe_coding/backend/internal/config"
 	"github.com/emotab87/vibe_coding/backend/internal/database"
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
 	"github.com/emotab87/vibe_coding/backend/internal/handlers"
 	"github.com/emotab87/vibe_coding/backend/internal/middleware"
 	"github.com/emotab87/vibe_coding/backend/internal/repositories"
 	"github.com/emotab87/vibe_coding/backend/internal/services"
 )
 
+// quietHoursReleaseInterval is how often held notifications are checked for
+// users whose quiet hours have ended
+const quietHoursReleaseInterval = 5 * time.Minute
+
+// emailCaptureLimit is how many emails development keeps for previewing
+const emailCaptureLimit = 50
+
+// incidentBoardTTL is how soon incidents changed on another instance show up
+const incidentBoardTTL = 30 * time.Second
+
 // Server represents our application server
 type Server struct {
-	config      *config.Config
-	router      *mux.Router
-	handler     http.Handler
-	db          *database.DB
-	userRepo    repositories.UserRepository
-	articleRepo repositories.ArticleRepository
-	commentRepo repositories.CommentRepository
-	jwtService  services.JWTService
-	authHandlers *handlers.AuthHandlers
-	articleHandlers *handlers.ArticleHandlers
-	commentHandlers *handlers.CommentHandlers
+	config               *config.Config
+	router               *mux.Router
+	handler              http.Handler
+	db                   *database.DB
+	userRepo             repositories.UserRepository
+	articleRepo          repositories.ArticleRepository
+	commentRepo          repositories.CommentRepository
+	tagRepo              repositories.TagRepository
+	notificationRepo     repositories.NotificationRepository
+	feedRepo             repositories.FeedRepository
+	followRepo           repositories.FollowRepository
+	favoriteRepo         repositories.FavoriteRepository
+	revokedTokenRepo     repositories.RevokedTokenRepository
+	sessionRepo          repositories.SessionRepository
+	apiKeyRepo           repositories.APIKeyRepository
+	settingsRepo         repositories.SettingsRepository
+	analyticsRepo        repositories.AnalyticsRepository
+	jwtService           services.JWTService
+	jwtKeys              services.JWTKeyRing
+	health               services.HealthRegistry
+	lifecycle            services.Lifecycle
+	notificationService  services.NotificationService
+	healthHandlers       *handlers.HealthHandlers
+	configHandlers       *handlers.ConfigHandlers
+	authHandlers         *handlers.AuthHandlers
+	articleHandlers      *handlers.ArticleHandlers
+	commentHandlers      *handlers.CommentHandlers
+	tagHandlers          *handlers.TagHandlers
+	notificationHandlers *handlers.NotificationHandlers
+	feedHandlers         *handlers.FeedHandlers
+	analyticsHandlers    *handlers.AnalyticsHandlers
+	avatarHandlers       *handlers.AvatarHandlers
+	syndicationHandlers  *handlers.SyndicationHandlers
+	imageProxyHandlers   *handlers.ImageProxyHandlers
+	linkPreviewHandlers  *handlers.LinkPreviewHandlers
+	attachmentHandlers   *handlers.AttachmentHandlers
+	exportHandlers       *handlers.ExportHandlers
+	bookExportHandlers   *handlers.BookExportHandlers
+	profileHandlers      *handlers.ProfileHandlers
+	favoriteHandlers     *handlers.FavoriteHandlers
+	settingsHandlers     *handlers.SettingsHandlers
+	anomalyHandlers      *handlers.AnomalyHandlers
+	reviewHandlers       *handlers.ReviewHandlers
+	inviteHandlers       *handlers.InviteHandlers
+	pickHandlers         *handlers.PickHandlers
+	pinHandlers          *handlers.PinHandlers
+	seriesHandlers       *handlers.SeriesHandlers
+	readingListHandlers  *handlers.ReadingListHandlers
+	clapHandlers         *handlers.ClapHandlers
+	pushHandlers         *handlers.PushHandlers
+	activityHandlers     *handlers.ActivityHandlers
+	deletionHandlers     *handlers.AccountDeletionHandlers
+	legalHoldHandlers    *handlers.LegalHoldHandlers
+	quotaHandlers        *handlers.QuotaHandlers
+	quarantineHandlers   *handlers.QuarantineHandlers
+	searchPingHandlers   *handlers.SearchPingHandlers
+	robotsHandlers       *handlers.RobotsHandlers
+	jwksHandlers         *handlers.JWKSHandlers
+	complianceHandlers   *handlers.ComplianceHandlers
+	apiKeyHandlers       *handlers.APIKeyHandlers
+	emailStatusHandlers  *handlers.EmailStatusHandlers
+	adminHandlers        *handlers.AdminHandlers
+	incidentHandlers     *handlers.IncidentHandlers
+	sessionHandlers      *handlers.SessionHandlers
+	userDataHandlers     *handlers.UserDataHandlers
+	incidentBoard        *services.IncidentBoard
+	emailPreviewHandlers *handlers.EmailPreviewHandlers
+	shareHandlers        *handlers.ShareHandlers
+	metricsHandlers      *handlers.MetricsHandlers
+	anomalyDetector      services.AnomalyDetector
+	searchPinger         services.SearchPinger
+	articleExporter      services.ArticleExporter
+	bookExporter         services.BookExporter
+	syndicationCache     services.SyndicationCache
+	demoSeeder           services.DemoSeeder
+	kpiCollector         services.KPICollector
+	traffic              services.TrafficStats
+	alerter              services.Alerter
+	rateLimiters         map[string]middleware.RateLimiter
+	nonces               middleware.NonceCache
+	jobLock              services.JobLock
 }
 
 // NewServer creates a new server instance with all routes and middleware configured
 func NewServer(cfg *config.Config) (*Server, error) {
-	// Initialize database
-	db, err := database.NewDB(cfg.DatabasePath)
+	// Refuse to start with unsafe or invalid configuration
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Initialize database; demo data lives only in memory
+	databasePath := cfg.DatabasePath
+	if cfg.DemoMode {
+		databasePath = ":memory:"
+	}
+	db, err := database.NewDB(databasePath)
 	if err != nil {
 		return nil, err
 	}
 
 	// Run migrations
 	if err := db.Migrate("./migrations"); err != nil {
+		db.Close()
 		return nil, err
 	}
 
+	// Verify the schema (and optionally file integrity) before serving traffic
+	if err := db.SelfCheck(database.SelfCheckOptions{IntegrityCheck: cfg.StartupIntegrityCheck}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("startup self-check failed: %w", err)
+	}
+
 	// Initialize repositories
-	userRepo := repositories.NewUserRepository(db)
-	articleRepo := repositories.NewArticleRepository(db, userRepo)
-	commentRepo := repositories.NewCommentRepository(db, userRepo)
+	userRepo, err := newUserRepository(cfg, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	// Article changes invalidate only the cached feeds and sitemap that list them
+	syndicationCache := services.NewSyndicationCache(time.Duration(cfg.SyndicationCacheTTLMinutes) * time.Minute)
+	// Bodies are stored as written and sanitized on every read
+	sanitizer := services.NewSanitizer(sanitizerOptions(cfg))
+	// Published articles queue IndexNow and sitemap pings for background delivery
+	searchPingRepo := repositories.NewSearchPingRepository(db)
+	searchPinger := services.NewSearchPinger(searchPingRepo, &http.Client{Timeout: 10 * time.Second}, services.SearchPingOptions{
+		SiteURL:          strings.TrimRight(cfg.SiteURL, "/"),
+		SitemapURL:       strings.TrimRight(cfg.PublicURL, "/") + "/sitemap.xml",
+		IndexNowKey:      cfg.IndexNowKey,
+		IndexNowEndpoint: cfg.IndexNowEndpoint,
+		SitemapPingURLs:  splitList(cfg.SitemapPingURLs),
+	})
+	// New accounts' articles are left out of listings, tags and feeds while quarantined
+	quarantine := time.Duration(cfg.QuarantineDays) * 24 * time.Hour
+	articleRepo := services.NewSanitizingArticleRepository(services.NewSyndicatedArticleRepository(services.NewSearchPingArticleRepository(repositories.NewArticleRepository(db, userRepo, quarantine), searchPinger), syndicationCache), sanitizer)
+	commentRepo := services.NewSanitizingCommentRepository(repositories.NewCommentRepository(db, userRepo), sanitizer)
+	tagRepo := repositories.NewTagRepository(db, quarantine)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	feedRepo := repositories.NewFeedRepository(db, quarantine)
+	followRepo := repositories.NewFollowRepository(db)
+	favoriteRepo := repositories.NewFavoriteRepository(db)
+	settingsRepo := repositories.NewSettingsRepository(db)
+	analyticsRepo := repositories.NewAnalyticsRepository(db, quarantine)
+	linkPreviewRepo := repositories.NewLinkPreviewRepository(db)
+	attachmentRepo := repositories.NewAttachmentRepository(db)
+	anomalyRepo := repositories.NewAnomalyRepository(db)
+	inviteRepo := repositories.NewInviteRepository(db)
+	seriesRepo := repositories.NewSeriesRepository(db)
+	readingListRepo := repositories.NewReadingListRepository(db)
+	legalHoldRepo := repositories.NewLegalHoldRepository(db)
+	quotaRepo := repositories.NewQuotaRepository(db)
+	quarantineRepo := repositories.NewQuarantineRepository(db)
+	clapRepo := repositories.NewClapRepository(db)
+	pushSubscriptionRepo := repositories.NewPushSubscriptionRepository(db)
+	activityRepo := repositories.NewActivityRepository(db)
+	kpiRepo := repositories.NewKPIRepository(db)
+	revokedTokenRepo := repositories.NewRevokedTokenRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	userDataRepo := repositories.NewUserDataRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	emailStatusRepo := repositories.NewEmailStatusRepository(db)
+	incidentRepo := repositories.NewIncidentRepository(db)
+
+	// Demo mode starts from sample content
+	var demoSeeder services.DemoSeeder
+	if cfg.DemoMode {
+		demoSeeder = services.NewDemoSeeder(userRepo, articleRepo, commentRepo, followRepo, favoriteRepo)
+		if err := demoSeeder.Seed(); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to seed demo data: %w", err)
+		}
+		log.Printf("🎭 Demo mode: sign in as %s with password %q", strings.Join(demoSeeder.Accounts(), ", "), services.DemoPassword)
+	}
+
+	// Promote configured administrators
+	if err := promoteAdmins(userRepo, cfg.AdminUsernames); err != nil {
+		return nil, err
+	}
+
+	// Track subsystem health; only the database is critical
+	health := services.NewHealthRegistry()
+	health.Register("database", true, func(ctx context.Context) error {
+		return db.Ping()
+	})
 
 	// Initialize services
-	jwtService := services.NewJWTService(cfg.JWTSecret, 24) // 24 hours token expiry
+	tokenPolicy := services.NewTokenPolicy(services.TokenLifetimes{
+		Access:        time.Duration(cfg.AccessTokenTTLMinutes) * time.Minute,
+		Refresh:       time.Duration(cfg.RefreshTokenTTLDays) * 24 * time.Hour,
+		MagicLink:     time.Duration(cfg.MagicLinkTTLMinutes) * time.Minute,
+		PasswordReset: time.Duration(cfg.PasswordResetTTLMinutes) * time.Minute,
+		Reply:         time.Duration(cfg.ReplyTokenTTLDays) * 24 * time.Hour,
+	}, time.Duration(cfg.TokenClockSkewSeconds)*time.Second)
+	jwtKeys, err := newJWTKeyRing(cfg, repositories.NewSigningKeyRepository(db), tokenPolicy)
+	if err != nil {
+		return nil, err
+	}
+	jwtService := services.NewJWTServiceWithKeys(jwtKeys, tokenPolicy, cfg.JWTIssuer, cfg.JWTAudience)
+	pushSender, vapidPublicKey, err := newWebPushSender(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Development keeps the emails it would have sent for previewing
+	var emailCapture *services.EmailCapture
+	if cfg.IsDevelopment() && cfg.SMTPHost == "" {
+		emailCapture = services.NewEmailCapture(emailCaptureLimit)
+	}
+	emailSender := services.NewSuppressingEmailSender(newEmailSender(cfg, health, emailCapture), userRepo, emailStatusRepo)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, settingsRepo, emailSender, services.NewPushNotifier(pushSubscriptionRepo, pushSender),
+		services.NotificationOptions{
+			CoalesceWindow: time.Duration(cfg.NotificationCoalesceMinutes) * time.Minute,
+			DigestHour:     cfg.DigestHour,
+			Branding: services.EmailBranding{
+				Name:        cfg.EmailBrandName,
+				SiteURL:     cfg.SiteURL,
+				LogoURL:     cfg.EmailLogoURL,
+				AccentColor: cfg.EmailBrandColor,
+				FooterText:  cfg.EmailFooterText,
+			},
+		})
+	replyTokenService := services.NewReplyTokenService(cfg.JWTSecret, tokenPolicy)
+	commentRateLimiter := services.NewCommentRateLimiter(commentRepo, userRepo,
+		services.CommentRateLimits{
+			MinInterval: time.Duration(cfg.CommentMinIntervalSeconds) * time.Second,
+			HourlyLimit: cfg.CommentHourlyLimit,
+		},
+		services.CommentRateLimits{
+			MinInterval: time.Duration(cfg.EstablishedCommentMinIntervalSeconds) * time.Second,
+			HourlyLimit: cfg.EstablishedCommentHourlyLimit,
+		},
+		time.Duration(cfg.EstablishedAccountDays)*24*time.Hour,
+	)
+	quotaService := services.NewQuotaService(articleRepo, commentRepo, userRepo, quotaRepo,
+		entities.DailyQuota{Articles: cfg.QuotaArticlesPerDay, Comments: cfg.QuotaCommentsPerDay},
+		entities.DailyQuota{Articles: cfg.EstablishedQuotaArticlesPerDay, Comments: cfg.EstablishedQuotaCommentsPerDay},
+		time.Duration(cfg.EstablishedAccountDays)*24*time.Hour,
+	)
+	// Active incidents are cached, since the notice header reads them on every request
+	incidentBoard := services.NewIncidentBoard(incidentRepo, incidentBoardTTL)
+	anomalyDetector := services.NewAnomalyDetector(anomalyRepo, services.AnomalyThresholds{
+		Window:                  time.Duration(cfg.AnomalyWindowMinutes) * time.Minute,
+		RegistrationsPerNetwork: cfg.AnomalyRegistrationsPerNetwork,
+		FavoritesPerUser:        cfg.AnomalyFavoritesPerUser,
+		CommentsPerUser:         cfg.AnomalyCommentsPerUser,
+	})
 
 	// Initialize handlers
-	authHandlers := handlers.NewAuthHandlers(userRepo, jwtService)
-	articleHandlers := handlers.NewArticleHandlers(articleRepo)
-	commentHandlers := handlers.NewCommentHandlers(commentRepo, articleRepo)
+	// Draining fails readiness ahead of shutdown so traffic moves elsewhere first
+	lifecycle := services.NewLifecycle()
+	healthHandlers := handlers.NewHealthHandlers(health, lifecycle, incidentBoard, time.Duration(cfg.DrainSeconds)*time.Second)
+	configHandlers := handlers.NewConfigHandlers(cfg)
+	authHandlers := handlers.NewAuthHandlers(userRepo, inviteRepo, revokedTokenRepo, sessionRepo, settingsRepo, jwtService, handlers.AuthOptions{
+		DeactivationGrace:     time.Duration(cfg.DeactivationGraceDays) * 24 * time.Hour,
+		NetworkSalt:           cfg.AnalyticsSalt,
+		RequireInvite:         cfg.BetaMode,
+		MinimumAge:            cfg.MinimumAge,
+		RequireBirthdate:      cfg.RequireBirthdate,
+		AdultAge:              cfg.AdultAge,
+		MaxFailuresPerAccount: cfg.LoginMaxFailuresPerAccount,
+		MaxFailuresPerNetwork: cfg.LoginMaxFailuresPerNetwork,
+		FailureWindow:         time.Duration(cfg.LoginFailureWindowMinutes) * time.Minute,
+		LockoutDuration:       time.Duration(cfg.LoginLockoutMinutes) * time.Minute,
+		SessionLifetime:       tokenPolicy.Lifetime(services.TokenAccess),
+	})
+	articleHandlers := handlers.NewArticleHandlers(articleRepo, linkPreviewRepo, attachmentRepo, userRepo, seriesRepo, favoriteRepo, followRepo, legalHoldRepo, services.NewArticleReviewPolicy(articleRepo, services.ArticleReviewOptions{
+		ReviewAll:     cfg.BetaMode,
+		FirstArticles: cfg.ReviewFirstArticles,
+		NewAccountAge: time.Duration(cfg.ReviewNewAccountMaxDays) * 24 * time.Hour,
+	}), quotaService)
+	commentHandlers := handlers.NewCommentHandlers(commentRepo, articleRepo, userRepo, followRepo, notificationService, replyTokenService, commentRateLimiter, quotaService, handlers.CommentOptions{
+		ReplyDomain: cfg.ReplyEmailDomain,
+		DeleteMode:  cfg.CommentDeleteMode,
+	})
+	tagHandlers := handlers.NewTagHandlers(tagRepo, articleRepo)
+	notificationHandlers := handlers.NewNotificationHandlers(notificationRepo)
+	feedHandlers := handlers.NewFeedHandlers(articleRepo, feedRepo, favoriteRepo, followRepo)
+	analyticsHandlers := handlers.NewAnalyticsHandlers(analyticsRepo, articleRepo, userRepo, settingsRepo, cfg.AnalyticsSalt, cfg.CookieConsentRequired)
+	avatarHandlers := handlers.NewAvatarHandlers(userRepo)
+	syndicationHandlers := handlers.NewSyndicationHandlers(articleRepo, userRepo, settingsRepo, syndicationCache, cfg.SiteURL)
+	imageProxyHandlers := handlers.NewImageProxyHandlers(newImageProxy(cfg))
+	linkPreviewService := services.NewLinkPreviewService(linkPreviewRepo,
+		time.Duration(cfg.LinkPreviewTimeoutSeconds)*time.Second,
+		time.Duration(cfg.LinkPreviewMaxAgeHours)*time.Hour,
+	)
+	linkPreviewHandlers := handlers.NewLinkPreviewHandlers(linkPreviewService, linkPreviewRepo, articleRepo)
+	attachmentHandlers := handlers.NewAttachmentHandlers(attachmentRepo, articleRepo, userRepo, cfg.MaxAttachmentBytes, cfg.MaxAttachmentsPerArticle)
+
+	// Exports are rendered in the background by the renderer for their format.
+	// No article format is registered yet: the built-in PDF renderer only has
+	// the standard fonts and cannot show Korean, so PDF export waits for a
+	// renderer that embeds a font covering it.
+	exportRepo := repositories.NewExportRepository(db)
+	articleExporter := services.NewArticleExporter(exportRepo, articleRepo, map[string]services.ArticleRenderer{}, time.Duration(cfg.ExportRetentionHours)*time.Hour)
+	exportHandlers := handlers.NewExportHandlers(articleExporter, exportRepo, articleRepo, userRepo)
+	bookExportRepo := repositories.NewBookExportRepository(db)
+	bookExporter := services.NewBookExporter(bookExportRepo, seriesRepo, articleRepo, userRepo, map[string]services.BookRenderer{
+		entities.ExportFormatEPUB: services.NewEPUBRenderer(),
+	}, time.Duration(cfg.ExportRetentionHours)*time.Hour)
+	bookExportHandlers := handlers.NewBookExportHandlers(bookExporter, bookExportRepo, seriesRepo, articleRepo)
+	profileHandlers := handlers.NewProfileHandlers(userRepo, followRepo, settingsRepo, activityRepo, notificationService)
+	favoriteHandlers := handlers.NewFavoriteHandlers(favoriteRepo, articleRepo, userRepo, settingsRepo, notificationService)
+	settingsHandlers := handlers.NewSettingsHandlers(settingsRepo, userRepo, cfg.AdultAge)
+	anomalyHandlers := handlers.NewAnomalyHandlers(anomalyRepo, anomalyDetector)
+	reviewHandlers := handlers.NewReviewHandlers(articleRepo, notificationService)
+	inviteHandlers := handlers.NewInviteHandlers(inviteRepo)
+	pickHandlers := handlers.NewPickHandlers(articleRepo)
+	pinHandlers := handlers.NewPinHandlers(articleRepo, cfg.MaxPinnedArticles)
+	seriesHandlers := handlers.NewSeriesHandlers(seriesRepo, articleRepo)
+	readingListHandlers := handlers.NewReadingListHandlers(readingListRepo, articleRepo)
+	clapHandlers := handlers.NewClapHandlers(clapRepo, articleRepo, cfg.MaxClapsPerUser)
+	pushHandlers := handlers.NewPushHandlers(pushSubscriptionRepo, vapidPublicKey)
+	activityHandlers := handlers.NewActivityHandlers(activityRepo)
+	deletionHandlers := handlers.NewAccountDeletionHandlers(userRepo, repositories.NewAccountDeletionRepository(db), legalHoldRepo)
+	legalHoldHandlers := handlers.NewLegalHoldHandlers(legalHoldRepo, userRepo, articleRepo)
+	quotaHandlers := handlers.NewQuotaHandlers(userRepo, quotaRepo, quotaService)
+	quarantineHandlers := handlers.NewQuarantineHandlers(userRepo, quarantineRepo, quarantine)
+	searchPingHandlers := handlers.NewSearchPingHandlers(searchPingRepo, cfg.IndexNowKey)
+	kpiCollector := services.NewKPICollector(kpiRepo)
+	metricsHandlers := handlers.NewMetricsHandlers(kpiCollector)
+	// Alert rules read response counts recorded by the middleware stack
+	traffic := services.NewTrafficStats()
+	alerter := services.NewAlerter([]services.AlertRule{
+		services.ErrorRateRule(traffic, float64(cfg.AlertErrorRatePercent), int64(cfg.AlertErrorRateMinRequests)),
+		services.LoginFailureRule(traffic, int64(cfg.AlertLoginFailures)),
+		services.QueueBacklogRule("search pings", searchPingRepo.PendingCount, cfg.AlertQueueBacklog),
+	}, newAlertChannels(cfg, emailSender), time.Duration(cfg.AlertCooldownMinutes)*time.Minute)
+	robotsHandlers := handlers.NewRobotsHandlers(handlers.RobotsOptions{
+		Disallow:      splitList(cfg.RobotsDisallow),
+		BlockedAgents: splitList(cfg.RobotsBlockedAgents),
+		DisallowAll:   cfg.RobotsDisallowAll,
+		SitemapURL:    strings.TrimRight(cfg.PublicURL, "/") + "/sitemap.xml",
+	})
+	jwksHandlers := handlers.NewJWKSHandlers(jwtKeys)
+	apiKeyHandlers := handlers.NewAPIKeyHandlers(apiKeyRepo)
+	emailStatusHandlers := handlers.NewEmailStatusHandlers(userRepo, emailStatusRepo, cfg.EmailSoftBounceLimit)
+	adminHandlers := handlers.NewAdminHandlers(userRepo, kpiRepo)
+	incidentHandlers := handlers.NewIncidentHandlers(incidentRepo, incidentBoard)
+	sessionHandlers := handlers.NewSessionHandlers(sessionRepo)
+	userDataHandlers := handlers.NewUserDataHandlers(userRepo, settingsRepo, userDataRepo)
+	emailPreviewHandlers := handlers.NewEmailPreviewHandlers(emailCapture)
+	shareHandlers := handlers.NewShareHandlers(articleRepo, analyticsRepo, cfg.SiteURL, cfg.PublicURL)
+
+	// Generated avatar URLs are absolute so frontends on other origins can load them
+	entities.AvatarBaseURL = strings.TrimRight(cfg.PublicURL, "/")
 
 	s := &Server{
-		config:       cfg,
-		router:       mux.NewRouter(),
-		db:           db,
-		userRepo:     userRepo,
-		articleRepo:  articleRepo,
-		commentRepo:  commentRepo,
-		jwtService:   jwtService,
-		authHandlers: authHandlers,
-		articleHandlers: articleHandlers,
-		commentHandlers: commentHandlers,
+		config:               cfg,
+		router:               mux.NewRouter(),
+		db:                   db,
+		userRepo:             userRepo,
+		articleRepo:          articleRepo,
+		commentRepo:          commentRepo,
+		tagRepo:              tagRepo,
+		notificationRepo:     notificationRepo,
+		feedRepo:             feedRepo,
+		followRepo:           followRepo,
+		favoriteRepo:         favoriteRepo,
+		revokedTokenRepo:     revokedTokenRepo,
+		sessionRepo:          sessionRepo,
+		apiKeyRepo:           apiKeyRepo,
+		settingsRepo:         settingsRepo,
+		analyticsRepo:        analyticsRepo,
+		jwtService:           jwtService,
+		jwtKeys:              jwtKeys,
+		health:               health,
+		notificationService:  notificationService,
+		lifecycle:            lifecycle,
+		healthHandlers:       healthHandlers,
+		configHandlers:       configHandlers,
+		authHandlers:         authHandlers,
+		articleHandlers:      articleHandlers,
+		commentHandlers:      commentHandlers,
+		tagHandlers:          tagHandlers,
+		notificationHandlers: notificationHandlers,
+		feedHandlers:         feedHandlers,
+		analyticsHandlers:    analyticsHandlers,
+		avatarHandlers:       avatarHandlers,
+		syndicationHandlers:  syndicationHandlers,
+		imageProxyHandlers:   imageProxyHandlers,
+		linkPreviewHandlers:  linkPreviewHandlers,
+		attachmentHandlers:   attachmentHandlers,
+		exportHandlers:       exportHandlers,
+		bookExportHandlers:   bookExportHandlers,
+		profileHandlers:      profileHandlers,
+		favoriteHandlers:     favoriteHandlers,
+		settingsHandlers:     settingsHandlers,
+		anomalyHandlers:      anomalyHandlers,
+		reviewHandlers:       reviewHandlers,
+		inviteHandlers:       inviteHandlers,
+		pickHandlers:         pickHandlers,
+		pinHandlers:          pinHandlers,
+		seriesHandlers:       seriesHandlers,
+		readingListHandlers:  readingListHandlers,
+		clapHandlers:         clapHandlers,
+		pushHandlers:         pushHandlers,
+		activityHandlers:     activityHandlers,
+		deletionHandlers:     deletionHandlers,
+		legalHoldHandlers:    legalHoldHandlers,
+		quotaHandlers:        quotaHandlers,
+		quarantineHandlers:   quarantineHandlers,
+		searchPingHandlers:   searchPingHandlers,
+		robotsHandlers:       robotsHandlers,
+		jwksHandlers:         jwksHandlers,
+		apiKeyHandlers:       apiKeyHandlers,
+		emailStatusHandlers:  emailStatusHandlers,
+		adminHandlers:        adminHandlers,
+		incidentHandlers:     incidentHandlers,
+		sessionHandlers:      sessionHandlers,
+		userDataHandlers:     userDataHandlers,
+		incidentBoard:        incidentBoard,
+		emailPreviewHandlers: emailPreviewHandlers,
+		shareHandlers:        shareHandlers,
+		metricsHandlers:      metricsHandlers,
+		anomalyDetector:      anomalyDetector,
+		searchPinger:         searchPinger,
+		articleExporter:      articleExporter,
+		bookExporter:         bookExporter,
+		syndicationCache:     syndicationCache,
+		demoSeeder:           demoSeeder,
+		kpiCollector:         kpiCollector,
+		traffic:              traffic,
+		alerter:              alerter,
+		rateLimiters:         newRateLimiters(cfg),
+		nonces:               newNonceCache(cfg),
+		jobLock:              services.NewLocalJobLock(),
 	}
 
+	// The compliance summary is built from the route table, which names its
+	// handler, so it can only be created once the other handlers are in place
+	s.complianceHandlers = handlers.NewComplianceHandlers(s.compliance())
+
 	s.setupRoutes()
 	s.setupMiddleware()
 
@@ -81,6 +476,379 @@ func (s *Server) Handler() http.Handler {
 	return s.handler
 }
 
+// runsJob reports whether this instance runs the current turn of a
+// background job that repeats every interval. The job lock makes sure only one
+// of several replicas does.
+func (s *Server) runsJob(job string, interval time.Duration) bool {
+	held, err := s.jobLock.Acquire(job, interval)
+	if err != nil {
+		log.Printf("⚠️  Failed to acquire the %s job lock: %v", job, err)
+		return false
+	}
+	return held
+}
+
+// RunDigests checks hourly for users whose digest hour has come and sends
+// their daily digest emails until ctx is cancelled. A negative hour disables digests.
+func (s *Server) RunDigests(ctx context.Context) {
+	if s.config.DigestHour < 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("digests", time.Hour) {
+				continue
+			}
+			sent, err := s.notificationService.SendDailyDigests()
+			if err != nil {
+				log.Printf("⚠️  Digest run failed: %v", err)
+				continue
+			}
+			log.Printf("📬 Sent %d digest emails", sent)
+		}
+	}
+}
+
+// RunQuietHoursRelease sends the notifications held during users' quiet hours
+// once those have ended, checking every few minutes until ctx is cancelled
+func (s *Server) RunQuietHoursRelease(ctx context.Context) {
+	ticker := time.NewTicker(quietHoursReleaseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("quiet-hours-release", quietHoursReleaseInterval) {
+				continue
+			}
+			sent, err := s.notificationService.ReleaseHeld()
+			if err != nil {
+				log.Printf("⚠️  Quiet hours release failed: %v", err)
+				continue
+			}
+			if sent > 0 {
+				log.Printf("🌅 Sent %d quiet hours summaries", sent)
+			}
+		}
+	}
+}
+
+// RunAnomalyScans records abuse anomalies on the configured interval until ctx
+// is cancelled. A non-positive interval disables scheduled scans.
+func (s *Server) RunAnomalyScans(ctx context.Context) {
+	if s.config.AnomalyScanIntervalMinutes <= 0 {
+		return
+	}
+
+	interval := time.Duration(s.config.AnomalyScanIntervalMinutes) * time.Minute
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("anomaly-scans", interval) {
+				continue
+			}
+			detected, err := s.anomalyDetector.Scan()
+			if err != nil {
+				log.Printf("⚠️  Anomaly scan failed: %v", err)
+				continue
+			}
+			if detected > 0 {
+				log.Printf("🚨 Detected %d new anomalies", detected)
+			}
+		}
+	}
+}
+
+// RunSearchPings delivers queued search engine pings on the configured interval
+// until ctx is cancelled. A non-positive interval disables delivery.
+func (s *Server) RunSearchPings(ctx context.Context) {
+	if s.config.SearchPingIntervalSeconds <= 0 {
+		return
+	}
+
+	interval := time.Duration(s.config.SearchPingIntervalSeconds) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("search-pings", interval) {
+				continue
+			}
+			delivered, err := s.searchPinger.Deliver()
+			if err != nil {
+				log.Printf("⚠️  Search ping delivery failed: %v", err)
+				continue
+			}
+			if delivered > 0 {
+				log.Printf("🔎 Delivered %d search pings", delivered)
+			}
+		}
+	}
+}
+
+// RunExports renders queued article and book exports on the configured
+// interval until ctx is cancelled. A non-positive interval disables rendering.
+func (s *Server) RunExports(ctx context.Context) {
+	if s.config.ExportIntervalSeconds <= 0 {
+		return
+	}
+
+	interval := time.Duration(s.config.ExportIntervalSeconds) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("exports", interval) {
+				continue
+			}
+			rendered, err := s.articleExporter.Process()
+			if err != nil {
+				log.Printf("⚠️  Article export failed: %v", err)
+			} else if rendered > 0 {
+				log.Printf("📄 Rendered %d article exports", rendered)
+			}
+			books, err := s.bookExporter.Process()
+			if err != nil {
+				log.Printf("⚠️  Book export failed: %v", err)
+			} else if books > 0 {
+				log.Printf("📚 Rendered %d book exports", books)
+			}
+		}
+	}
+}
+
+// RunKeyRotation checks hourly, until ctx is cancelled, whether the JWT
+// signing key is due for rotation and drops retired keys once their tokens
+// have expired. Nothing runs while tokens are signed with the shared secret.
+func (s *Server) RunKeyRotation(ctx context.Context) {
+	if s.config.JWTAlgorithm == "" || s.config.JWTAlgorithm == entities.JWTAlgorithmHS256 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("key-rotation", time.Hour) {
+				continue
+			}
+			rotated, err := s.jwtKeys.Rotate()
+			if err != nil {
+				log.Printf("⚠️  JWT key rotation failed: %v", err)
+			} else if rotated {
+				log.Printf("🔑 Rotated the JWT signing key")
+			}
+		}
+	}
+}
+
+// RunKPIRefresh recomputes the exported KPIs right away and then on the
+// configured interval until ctx is cancelled. Nothing runs unless metrics are
+// served; a non-positive interval keeps the first values.
+func (s *Server) RunKPIRefresh(ctx context.Context) {
+	if s.config.MetricsToken == "" {
+		return
+	}
+	if err := s.kpiCollector.Refresh(); err != nil {
+		log.Printf("⚠️  KPI refresh failed: %v", err)
+	}
+	if s.config.KPIRefreshIntervalSeconds <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(s.config.KPIRefreshIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.kpiCollector.Refresh(); err != nil {
+				log.Printf("⚠️  KPI refresh failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunAlerts evaluates alert rules on the configured interval until ctx is
+// cancelled. A non-positive interval disables alerting.
+func (s *Server) RunAlerts(ctx context.Context) {
+	if s.config.AlertIntervalSeconds <= 0 {
+		return
+	}
+
+	interval := time.Duration(s.config.AlertIntervalSeconds) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("alerts", interval) {
+				continue
+			}
+			if _, err := s.alerter.Evaluate(); err != nil {
+				log.Printf("⚠️  Alert evaluation failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunFavoriteReconciliation repairs drifted article favorite counts on the
+// configured interval until ctx is cancelled. A non-positive interval disables it.
+func (s *Server) RunFavoriteReconciliation(ctx context.Context) {
+	if s.config.FavoriteReconcileMinutes <= 0 {
+		return
+	}
+
+	interval := time.Duration(s.config.FavoriteReconcileMinutes) * time.Minute
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("favorite-reconciliation", interval) {
+				continue
+			}
+			fixed, err := s.favoriteRepo.ReconcileCounts()
+			if err != nil {
+				log.Printf("⚠️  Favorite count reconciliation failed: %v", err)
+				continue
+			}
+			if fixed > 0 {
+				log.Printf("🧮 Repaired favorite counts on %d articles", fixed)
+			}
+		}
+	}
+}
+
+// RunDemoResets wipes the demo database back to the sample content on the
+// configured interval until ctx is cancelled. Nothing runs outside demo mode;
+// a non-positive interval keeps visitors' changes.
+func (s *Server) RunDemoResets(ctx context.Context) {
+	if !s.config.DemoMode || s.config.DemoResetMinutes <= 0 {
+		return
+	}
+
+	interval := time.Duration(s.config.DemoResetMinutes) * time.Minute
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.runsJob("demo-resets", interval) {
+				continue
+			}
+			if err := s.resetDemo(); err != nil {
+				log.Printf("⚠️  Demo reset failed: %v", err)
+				continue
+			}
+			log.Printf("🎭 Demo data reset")
+		}
+	}
+}
+
+// resetDemo replaces everything in the database with freshly seeded sample
+// content and drops cached pages built from the old data
+func (s *Server) resetDemo() error {
+	if err := s.db.Reset(); err != nil {
+		return err
+	}
+	s.syndicationCache.Flush()
+
+	if err := s.demoSeeder.Seed(); err != nil {
+		return err
+	}
+	return promoteAdmins(s.userRepo, s.config.AdminUsernames)
+}
+
+// RotatePII encrypts plaintext emails and re-encrypts emails sealed with an
+// older key, returning how many users changed
+func (s *Server) RotatePII() (int, error) {
+	return s.userRepo.RotateEmailEncryption()
+}
+
+// RunHealthChecks re-checks subsystem health on the configured interval until ctx is
+// cancelled, so degraded components recover automatically
+func (s *Server) RunHealthChecks(ctx context.Context) {
+	s.health.Run(ctx, time.Duration(s.config.HealthCheckIntervalSeconds)*time.Second)
+}
+
+// Drain fails readiness checks and stops keeping connections alive so load
+// balancers move traffic elsewhere, then waits until the configured drain
+// duration has passed since draining began or ctx is cancelled
+func (s *Server) Drain(ctx context.Context) {
+	startedAt := s.lifecycle.Drain()
+
+	wait := time.Until(startedAt.Add(time.Duration(s.config.DrainSeconds) * time.Second))
+	if wait <= 0 {
+		return
+	}
+
+	open, active := s.lifecycle.Connections()
+	log.Printf("⏳ Draining for %s with %d open connections (%d active)", wait.Round(time.Second), open, active)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// DrainStarted is closed when draining begins, whether from Drain or the
+// drain endpoint
+func (s *Server) DrainStarted() <-chan struct{} {
+	return s.lifecycle.DrainStarted()
+}
+
+// TrackConnection follows client connections for drain reporting; use it as
+// http.Server.ConnState
+func (s *Server) TrackConnection(conn net.Conn, state http.ConnState) {
+	s.lifecycle.TrackConnection(conn, state)
+}
+
+// Connections returns how many client connections are open and how many of
+// them are serving a request
+func (s *Server) Connections() (int, int) {
+	return s.lifecycle.Connections()
+}
+
 // Close closes the server and its dependencies
 func (s *Server) Close() error {
 	if s.db != nil {
@@ -89,45 +857,269 @@ func (s *Server) Close() error {
 	return nil
 }
 
-// setupRoutes configures all application routes
+// setupRoutes configures all application routes from the route table
 func (s *Server) setupRoutes() {
-	// Health check endpoint
-	s.router.HandleFunc("/health", handlers.HealthCheckHandler).Methods("GET")
+	s.registerRoutes(s.Routes())
 
-	// API routes under /api prefix
-	api := s.router.PathPrefix("/api").Subrouter()
+	if s.config.IsDevelopment() {
+		log.Printf("🛣️  Routes configured for development environment")
+	}
+}
 
-	// Authentication routes
-	api.HandleFunc("/users", s.authHandlers.RegisterUser).Methods("POST")
-	api.HandleFunc("/users/login", s.authHandlers.LoginUser).Methods("POST")
+// newAlertChannels returns the configured alert channels, falling back to the log
+func newAlertChannels(cfg *config.Config, emailSender services.EmailSender) []services.AlertChannel {
+	channels := []services.AlertChannel{}
+	if cfg.AlertWebhookURL != "" {
+		channels = append(channels, services.NewWebhookAlertChannel(cfg.AlertWebhookURL, &http.Client{Timeout: 10 * time.Second}))
+	}
+	if recipients := splitList(cfg.AlertEmails); len(recipients) > 0 {
+		channels = append(channels, services.NewEmailAlertChannel(emailSender, recipients))
+	}
+	if len(channels) == 0 {
+		channels = append(channels, services.NewLogAlertChannel())
+	}
+	return channels
+}
 
-	// Protected routes (require authentication)
-	protected := api.PathPrefix("").Subrouter()
-	protected.Use(middleware.AuthMiddleware(s.config.JWTSecret))
+// newEmailSender returns a health-monitored SMTP sender when configured,
+// otherwise a logging sender that records emails in capture when it is set
+func newEmailSender(cfg *config.Config, health services.HealthRegistry, capture *services.EmailCapture) services.EmailSender {
+	if cfg.SMTPHost == "" {
+		if capture != nil {
+			return services.NewCapturingLogEmailSender(capture)
+		}
+		return services.NewLogEmailSender()
+	}
 
-	protected.HandleFunc("/user", s.authHandlers.GetCurrentUser).Methods("GET")
-	protected.HandleFunc("/user", s.authHandlers.UpdateUser).Methods("PUT")
+	addr := fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort)
+	health.Register("email", false, services.TCPHealthCheck(addr))
 
-	// Articles routes
-	api.HandleFunc("/articles", s.articleHandlers.ListArticles).Methods("GET")
-	api.HandleFunc("/articles/{slug}", s.articleHandlers.GetArticle).Methods("GET")
+	sender := services.NewSMTPEmailSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPass, cfg.EmailFrom)
+	return services.NewMonitoredEmailSender(sender, health, "email")
+}
 
-	// Protected article routes
-	protected.HandleFunc("/articles", s.articleHandlers.CreateArticle).Methods("POST")
-	protected.HandleFunc("/articles/{slug}", s.articleHandlers.UpdateArticle).Methods("PUT")
-	protected.HandleFunc("/articles/{slug}", s.articleHandlers.DeleteArticle).Methods("DELETE")
+// newWebPushSender returns the Web Push sender and the VAPID public key
+// browsers subscribe with, or a logging sender and no key when push is not configured
+func newWebPushSender(cfg *config.Config) (services.WebPushSender, string, error) {
+	// Push services run by browser vendors may be anywhere, so data residency
+	// keeps pushes, including to browsers subscribed before it, in the log
+	if cfg.VAPIDPrivateKey == "" || cfg.DataResidency {
+		return services.NewLogWebPushSender(), "", nil
+	}
 
-	// Comments routes
-	api.HandleFunc("/articles/{slug}/comments", s.commentHandlers.GetCommentsByArticle).Methods("GET")
-	protected.HandleFunc("/articles/{slug}/comments", s.commentHandlers.CreateComment).Methods("POST")
-	protected.HandleFunc("/articles/{slug}/comments/{id}", s.commentHandlers.DeleteComment).Methods("DELETE")
+	keys, err := services.ParseVAPIDKeys(cfg.VAPIDPrivateKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid configuration: VAPID_PRIVATE_KEY: %w", err)
+	}
 
-	// Profile routes
-	api.HandleFunc("/profiles/{username}", handlers.GetProfileHandler).Methods("GET")
+	return services.NewWebPushSender(keys, cfg.VAPIDSubject), keys.PublicKey, nil
+}
 
-	if s.config.IsDevelopment() {
-		log.Printf("🛣️  Routes configured for development environment")
+// newJWTKeyRing returns the keys access tokens are signed with: the shared
+// secret for HS256, or rotating key pairs stored encrypted for the asymmetric
+// algorithms
+func newJWTKeyRing(cfg *config.Config, repo repositories.SigningKeyRepository, policy services.TokenPolicy) (services.JWTKeyRing, error) {
+	if cfg.JWTAlgorithm == "" || cfg.JWTAlgorithm == entities.JWTAlgorithmHS256 {
+		return services.NewHMACKeyRing(cfg.JWTSecret), nil
+	}
+
+	encryptKeys, err := services.ParsePIIKeys(cfg.JWTKeyEncryptKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_KEY_ENCRYPT_KEYS: %w", err)
+	}
+	cipher, err := services.NewSecretCipher(encryptKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_KEY_ENCRYPT_KEYS: %w", err)
+	}
+
+	keys, err := services.NewRotatingKeyRing(repo, cipher, cfg.JWTAlgorithm, time.Duration(cfg.JWTKeyRotationDays)*24*time.Hour, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+// sanitizerOptions builds the content sanitization rules; links to the site
+// itself and to this API are not treated as external
+func sanitizerOptions(cfg *config.Config) services.SanitizerOptions {
+	var internalHosts []string
+	for _, raw := range []string{cfg.SiteURL, cfg.PublicURL} {
+		if parsed, err := url.Parse(raw); err == nil && parsed.Host != "" {
+			internalHosts = append(internalHosts, parsed.Host)
+		}
+	}
+
+	// Route images through the built-in proxy unless another one is configured
+	imageProxyURL := cfg.SanitizeImageProxyURL
+	if imageProxyURL == "" && cfg.ImageProxyEnabled {
+		imageProxyURL = strings.TrimRight(cfg.PublicURL, "/") + "/img-proxy"
+	}
+
+	return services.SanitizerOptions{
+		AllowHTML:       cfg.SanitizeAllowHTML,
+		ExternalLinkRel: cfg.SanitizeExternalLinkRel,
+		InternalHosts:   internalHosts,
+		ImageProxyURL:   imageProxyURL,
+		EmbedProviders:  splitList(cfg.SanitizeEmbedProviders),
+	}
+}
+
+// newImageProxy returns the image proxy, or nil when it is disabled
+func newImageProxy(cfg *config.Config) services.ImageProxy {
+	if !cfg.ImageProxyEnabled {
+		return nil
+	}
+
+	return services.NewImageProxy(services.ImageProxyOptions{
+		AllowedHosts: splitList(cfg.ImageProxyAllowedHosts),
+		DeniedHosts:  splitList(cfg.ImageProxyDeniedHosts),
+		MaxBytes:     int64(cfg.ImageProxyMaxBytes),
+		MaxPixels:    cfg.ImageProxyMaxPixels,
+		MaxWidth:     cfg.ImageProxyMaxWidth,
+		CacheBytes:   int64(cfg.ImageProxyCacheMB) * 1024 * 1024,
+		Timeout:      time.Duration(cfg.ImageProxyTimeoutSeconds) * time.Second,
+	})
+}
+
+// rateLimitClasses returns the configured limit of each rate limit class
+func rateLimitClasses(cfg *config.Config) map[string]middleware.RateLimit {
+	return map[string]middleware.RateLimit{
+		RateLimitAuth:  {PerMinute: cfg.RateLimitAuthPerMinute, Burst: cfg.RateLimitAuthBurst},
+		RateLimitWrite: {PerMinute: cfg.RateLimitWritePerMinute, Burst: cfg.RateLimitWriteBurst},
+		RateLimitRead:  {PerMinute: cfg.RateLimitReadPerMinute, Burst: cfg.RateLimitReadBurst},
+		RateLimitAdmin: {PerMinute: cfg.RateLimitAdminPerMinute, Burst: cfg.RateLimitAdminBurst},
+	}
+}
+
+// newUserRepository returns a user repository that hashes passwords at the
+// configured cost and encrypts emails when keys are configured
+func newUserRepository(cfg *config.Config, db *database.DB) (repositories.UserRepository, error) {
+	options := repositories.UserRepositoryOptions{PasswordCost: cfg.BcryptRounds}
+	if cfg.PIIEncryptionKeys == "" {
+		return repositories.NewUserRepositoryWithOptions(db, options), nil
+	}
+
+	keys, err := services.ParsePIIKeys(cfg.PIIEncryptionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PII_ENCRYPTION_KEYS: %w", err)
+	}
+	indexKey, err := base64.StdEncoding.DecodeString(cfg.PIIIndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PII_INDEX_KEY: %w", err)
+	}
+	cipher, err := services.NewPIICipher(keys, indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email encryption keys: %w", err)
+	}
+	options.Cipher = cipher
+	return repositories.NewUserRepositoryWithOptions(db, options), nil
+}
+
+// newNonceCache returns the nonce cache for replay-protected routes, or nil
+// when replay protection is off
+func newNonceCache(cfg *config.Config) middleware.NonceCache {
+	if cfg.ReplayProtection == "" || cfg.ReplayProtection == "off" {
+		return nil
+	}
+	return middleware.NewNonceCache(time.Duration(cfg.ReplayWindowSeconds) * time.Second)
+}
+
+// newRateLimiters returns the per-client limiters for rate limit classes that
+// have a limit configured; routes in other classes are not limited
+func newRateLimiters(cfg *config.Config) map[string]middleware.RateLimiter {
+	limiters := make(map[string]middleware.RateLimiter)
+	for class, limit := range rateLimitClasses(cfg) {
+		if limit.PerMinute > 0 {
+			limiters[class] = middleware.NewRateLimiter(limit)
+		}
+	}
+	return limiters
+}
+
+// splitList parses a comma-separated configuration value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
+
+// lookupRole resolves a user's current role for role-guarded routes
+func (s *Server) lookupRole(userID int64) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", err
 	}
+	return user.Role, nil
+}
+
+// lookupAccess returns why a user may not use authenticated routes, if any
+func (s *Server) lookupAccess(userID int64) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", err
+	}
+	return user.AccessDenial(), nil
+}
+
+// lookupRevoked reports whether a token was revoked by logging out
+func (s *Server) lookupRevoked(tokenID string) (bool, error) {
+	return s.revokedTokenRepo.IsRevoked(tokenID)
+}
+
+// lookupSession reports whether a login session is active, recording its use
+func (s *Server) lookupSession(sessionID string) (bool, error) {
+	return s.sessionRepo.Use(sessionID, time.Now().UTC())
+}
+
+// lookupAPIKey returns who an active API key authenticates, or nil
+func (s *Server) lookupAPIKey(key string) (*middleware.APIKeyIdentity, error) {
+	apiKey, err := s.apiKeyRepo.Authenticate(key, time.Now().UTC())
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid API key") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(apiKey.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &middleware.APIKeyIdentity{
+		KeyID:    apiKey.ID,
+		UserID:   user.ID,
+		Username: user.Username,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
+
+// promoteAdmins grants the admin role to the configured usernames
+func promoteAdmins(userRepo repositories.UserRepository, usernames string) error {
+	for _, username := range strings.Split(usernames, ",") {
+		username = strings.TrimSpace(username)
+		if username == "" {
+			continue
+		}
+
+		user, err := userRepo.GetByUsername(username)
+		if err != nil {
+			log.Printf("⚠️  Admin user %q not found, skipping promotion", username)
+			continue
+		}
+
+		if user.Role != entities.RoleAdmin {
+			if err := userRepo.UpdateRole(user.ID, entities.RoleAdmin); err != nil {
+				return err
+			}
+			log.Printf("👑 Promoted %s to admin", username)
+		}
+	}
+	return nil
 }
 
 // setupMiddleware configures all middleware for the server
@@ -146,9 +1138,15 @@ func (s *Server) setupMiddleware() {
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			"Idempotency-Key",
 			"X-CSRF-Token",
+			middleware.RequestNonceHeader,
+			middleware.RequestTimestampHeader,
+			middleware.RequestSignatureHeader,
+			middleware.RequestIDHeader,
+			middleware.TraceParentHeader,
 		},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", middleware.DemoModeHeader, middleware.IncidentNoticeHeader, middleware.RequestIDHeader, middleware.TraceIDHeader},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 		Debug:            s.config.DebugCORS,
@@ -158,6 +1156,15 @@ func (s *Server) setupMiddleware() {
 	handler := s.router
 	handler = middleware.LoggingMiddleware(handler)
 	handler = middleware.RecoveryMiddleware(handler)
+	handler = middleware.ObserveResponses(s.observeResponse)(handler)
+	handler = middleware.CloseWhileDraining(s.lifecycle.Draining)(handler)
+	handler = middleware.Correlate(parseCIDRs(s.config.CorrelationTrustedNetworks))(handler)
+	if s.config.DemoMode {
+		handler = middleware.DemoBanner(handler)
+	}
+	if s.config.IncidentHeader {
+		handler = middleware.IncidentNotice(s.incidentBoard.Notice)(handler)
+	}
 	handler = c.Handler(handler)
 
 	s.handler = handler
@@ -168,6 +1175,27 @@ func (s *Server) setupMiddleware() {
 	}
 }
 
+// observeResponse feeds the traffic counters used by alert rules
+func (s *Server) observeResponse(route string, status int) {
+	s.traffic.RecordResponse(status)
+	// Refused attempts during a lockout are part of the same burst
+	if route == "users.login" && (status == http.StatusUnauthorized || status == http.StatusLocked) {
+		s.traffic.RecordLoginFailure()
+	}
+}
+
+// parseCIDRs parses a comma-separated list of networks; Validate has already
+// rejected malformed entries
+func parseCIDRs(value string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range splitList(value) {
+		if _, network, err := net.ParseCIDR(cidr); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
 // parseCORSOrigins parses CORS origins from environment variable
 func parseCORSOrigins(origins string) []string {
 	if origins == "" {
@@ -187,4 +1215,4 @@ func parseCORSOrigins(origins string) []string {
 	}
 
 	return result
-}
\ No newline at end of file
+}
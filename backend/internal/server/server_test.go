@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/services"
+)
+
+// fakeJobLock grants the jobs in held and records what was asked for
+type fakeJobLock struct {
+	held     map[string]bool
+	err      error
+	acquired map[string]time.Duration
+}
+
+func (l *fakeJobLock) Acquire(job string, ttl time.Duration) (bool, error) {
+	l.acquired[job] = ttl
+	return l.held[job], l.err
+}
+
+func TestServer_RunsJobOnlyWhileHoldingTheLock(t *testing.T) {
+	lock := &fakeJobLock{held: map[string]bool{"digests": true}, acquired: map[string]time.Duration{}}
+	s := &Server{jobLock: lock}
+
+	if !s.runsJob("digests", time.Hour) {
+		t.Error("Expected the instance holding the lock to run the job")
+	}
+	if lock.acquired["digests"] != time.Hour {
+		t.Errorf("Expected the job to be held for its interval, got %v", lock.acquired["digests"])
+	}
+	if s.runsJob("exports", time.Minute) {
+		t.Error("Expected another replica's job not to run here")
+	}
+
+	lock.err = errors.New("lock store unavailable")
+	if s.runsJob("digests", time.Hour) {
+		t.Error("Expected the job to be skipped when the lock can't be checked")
+	}
+
+	s.jobLock = services.NewLocalJobLock()
+	if !s.runsJob("exports", time.Minute) {
+		t.Error("Expected a single instance to run every job")
+	}
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// TrafficSnapshot holds cumulative response counts since startup
+type TrafficSnapshot struct {
+	Requests      int64
+	ServerErrors  int64
+	LoginFailures int64
+}
+
+// TrafficStats counts responses in process for alert rules
+type TrafficStats interface {
+	RecordResponse(status int)
+	RecordLoginFailure()
+	Snapshot() TrafficSnapshot
+}
+
+// trafficStats implements TrafficStats with atomic counters
+type trafficStats struct {
+	requests      atomic.Int64
+	serverErrors  atomic.Int64
+	loginFailures atomic.Int64
+}
+
+// NewTrafficStats creates empty traffic counters
+func NewTrafficStats() TrafficStats {
+	return &trafficStats{}
+}
+
+// RecordResponse counts a response, noting server errors
+func (s *trafficStats) RecordResponse(status int) {
+	s.requests.Add(1)
+	if status >= http.StatusInternalServerError {
+		s.serverErrors.Add(1)
+	}
+}
+
+// RecordLoginFailure counts a rejected login
+func (s *trafficStats) RecordLoginFailure() {
+	s.loginFailures.Add(1)
+}
+
+// Snapshot returns the current counts
+func (s *trafficStats) Snapshot() TrafficSnapshot {
+	return TrafficSnapshot{
+		Requests:      s.requests.Load(),
+		ServerErrors:  s.serverErrors.Load(),
+		LoginFailures: s.loginFailures.Load(),
+	}
+}
+
+// AlertRule is one condition checked on every evaluation; it fires when the
+// measured value reaches the threshold
+type AlertRule struct {
+	Name      string
+	Threshold float64
+	// Describe turns a measured value into the alert message
+	Describe func(value float64) string
+	// Measure returns the current value; ok is false when there is too little data to judge
+	Measure func() (value float64, ok bool, err error)
+}
+
+// ErrorRateRule fires when the percentage of 5xx responses since the previous
+// evaluation reaches percent, once at least minRequests were served
+func ErrorRateRule(stats TrafficStats, percent float64, minRequests int64) AlertRule {
+	previous := stats.Snapshot()
+	return AlertRule{
+		Name:      entities.AlertRuleErrorRate,
+		Threshold: percent,
+		Describe: func(value float64) string {
+			return fmt.Sprintf("%.1f%% of recent requests failed with a server error (threshold %.1f%%)", value, percent)
+		},
+		Measure: func() (float64, bool, error) {
+			current := stats.Snapshot()
+			requests := current.Requests - previous.Requests
+			errors := current.ServerErrors - previous.ServerErrors
+			previous = current
+			if requests == 0 || requests < minRequests {
+				return 0, false, nil
+			}
+			return float64(errors) * 100 / float64(requests), true, nil
+		},
+	}
+}
+
+// LoginFailureRule fires when at least limit logins failed since the previous evaluation
+func LoginFailureRule(stats TrafficStats, limit int64) AlertRule {
+	previous := stats.Snapshot()
+	return AlertRule{
+		Name:      entities.AlertRuleLoginFailures,
+		Threshold: float64(limit),
+		Describe: func(value float64) string {
+			return fmt.Sprintf("%.0f logins failed recently (threshold %d)", value, limit)
+		},
+		Measure: func() (float64, bool, error) {
+			current := stats.Snapshot()
+			failures := current.LoginFailures - previous.LoginFailures
+			previous = current
+			return float64(failures), true, nil
+		},
+	}
+}
+
+// QueueBacklogRule fires when the named queue holds at least limit items
+func QueueBacklogRule(queue string, count func() (int, error), limit int) AlertRule {
+	return AlertRule{
+		Name:      entities.AlertRuleQueueBacklog,
+		Threshold: float64(limit),
+		Describe: func(value float64) string {
+			return fmt.Sprintf("%.0f %s are waiting for delivery (threshold %d)", value, queue, limit)
+		},
+		Measure: func() (float64, bool, error) {
+			backlog, err := count()
+			if err != nil {
+				return 0, false, err
+			}
+			return float64(backlog), true, nil
+		},
+	}
+}
+
+// AlertChannel delivers fired alerts to operators
+type AlertChannel interface {
+	Send(alert *entities.Alert) error
+}
+
+// Alerter evaluates alert rules and notifies every channel when one fires
+type Alerter interface {
+	// Evaluate checks every rule and returns how many alerts fired
+	Evaluate() (int, error)
+}
+
+// alerter implements Alerter
+type alerter struct {
+	rules    []AlertRule
+	channels []AlertChannel
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	lastFired map[string]time.Time
+}
+
+// NewAlerter creates an alerter. A rule that fired stays quiet for the
+// cooldown, even if its condition still holds.
+func NewAlerter(rules []AlertRule, channels []AlertChannel, cooldown time.Duration) Alerter {
+	return &alerter{
+		rules:     rules,
+		channels:  channels,
+		cooldown:  cooldown,
+		now:       time.Now,
+		lastFired: make(map[string]time.Time),
+	}
+}
+
+// Evaluate measures every rule. A failing rule or channel does not stop the
+// others; the first error is returned.
+func (a *alerter) Evaluate() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var firstErr error
+	fired := 0
+	for _, rule := range a.rules {
+		value, ok, err := rule.Measure()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("alert rule %s: %w", rule.Name, err)
+			}
+			continue
+		}
+		if !ok || value < rule.Threshold {
+			continue
+		}
+
+		now := a.now()
+		if last, seen := a.lastFired[rule.Name]; seen && now.Sub(last) < a.cooldown {
+			continue
+		}
+		a.lastFired[rule.Name] = now
+		fired++
+
+		alert := &entities.Alert{
+			Rule:      rule.Name,
+			Message:   rule.Describe(value),
+			Value:     value,
+			Threshold: rule.Threshold,
+			FiredAt:   now.UTC(),
+		}
+		for _, channel := range a.channels {
+			if err := channel.Send(alert); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("alert channel: %w", err)
+			}
+		}
+	}
+
+	return fired, firstErr
+}
+
+// logAlertChannel writes alerts to the application log
+type logAlertChannel struct{}
+
+// NewLogAlertChannel creates a channel that only logs alerts, used when no
+// webhook or email recipients are configured
+func NewLogAlertChannel() AlertChannel {
+	return logAlertChannel{}
+}
+
+// Send logs the alert
+func (logAlertChannel) Send(alert *entities.Alert) error {
+	log.Printf("🚨 Alert %s: %s", alert.Rule, alert.Message)
+	return nil
+}
+
+// webhookAlertChannel posts alerts as JSON
+type webhookAlertChannel struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlertChannel creates a channel that posts {"alert": ...} to url
+func NewWebhookAlertChannel(url string, client *http.Client) AlertChannel {
+	return &webhookAlertChannel{
+		url:    url,
+		client: client,
+	}
+}
+
+// Send posts the alert; any non-2xx response is an error
+func (c *webhookAlertChannel) Send(alert *entities.Alert) error {
+	body, err := json.Marshal(map[string]*entities.Alert{"alert": alert})
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// emailAlertChannel emails alerts to operators
+type emailAlertChannel struct {
+	sender     EmailSender
+	recipients []string
+}
+
+// NewEmailAlertChannel creates a channel that emails every recipient
+func NewEmailAlertChannel(sender EmailSender, recipients []string) AlertChannel {
+	return &emailAlertChannel{
+		sender:     sender,
+		recipients: recipients,
+	}
+}
+
+// Send emails the alert to each recipient, returning the first failure
+func (c *emailAlertChannel) Send(alert *entities.Alert) error {
+	var firstErr error
+	for _, recipient := range c.recipients {
+		err := c.sender.Send(&EmailMessage{
+			To:      recipient,
+			Subject: "Alert: " + strings.ReplaceAll(alert.Rule, "_", " "),
+			Body:    fmt.Sprintf("%s\n\nFired at %s.", alert.Message, alert.FiredAt.Format(time.RFC1123)),
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
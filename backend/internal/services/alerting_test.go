@@ -0,0 +1,126 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+type recordingAlertChannel struct {
+	alerts []*entities.Alert
+}
+
+func (c *recordingAlertChannel) Send(alert *entities.Alert) error {
+	c.alerts = append(c.alerts, alert)
+	return nil
+}
+
+func TestErrorRateRule(t *testing.T) {
+	stats := NewTrafficStats()
+	rule := ErrorRateRule(stats, 10, 20)
+
+	for i := 0; i < 10; i++ {
+		stats.RecordResponse(http.StatusInternalServerError)
+	}
+	if _, ok, _ := rule.Measure(); ok {
+		t.Fatal("Expected too few requests to be ignored")
+	}
+
+	for i := 0; i < 18; i++ {
+		stats.RecordResponse(http.StatusOK)
+	}
+	stats.RecordResponse(http.StatusBadGateway)
+	stats.RecordResponse(http.StatusNotFound)
+	value, ok, err := rule.Measure()
+	if err != nil || !ok {
+		t.Fatalf("Measure = %v, %v", ok, err)
+	}
+	// Only responses since the previous evaluation count
+	if value != 5 {
+		t.Errorf("Expected 5%% errors, got %v", value)
+	}
+}
+
+func TestAlerter_CooldownSuppressesRepeats(t *testing.T) {
+	stats := NewTrafficStats()
+	channel := &recordingAlertChannel{}
+	a := NewAlerter([]AlertRule{LoginFailureRule(stats, 3)}, []AlertChannel{channel}, 30*time.Minute).(*alerter)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	failLogins := func(n int) {
+		for i := 0; i < n; i++ {
+			stats.RecordLoginFailure()
+		}
+	}
+
+	failLogins(2)
+	if fired, _ := a.Evaluate(); fired != 0 {
+		t.Fatalf("Expected no alert below the threshold, fired %d", fired)
+	}
+
+	failLogins(3)
+	if fired, _ := a.Evaluate(); fired != 1 {
+		t.Fatalf("Expected an alert, fired %d", fired)
+	}
+	if len(channel.alerts) != 1 || channel.alerts[0].Rule != entities.AlertRuleLoginFailures || channel.alerts[0].Value != 3 {
+		t.Fatalf("Unexpected alerts %+v", channel.alerts)
+	}
+
+	now = now.Add(10 * time.Minute)
+	failLogins(5)
+	if fired, _ := a.Evaluate(); fired != 0 {
+		t.Fatalf("Expected the cooldown to suppress the alert, fired %d", fired)
+	}
+
+	now = now.Add(30 * time.Minute)
+	failLogins(5)
+	if fired, _ := a.Evaluate(); fired != 1 {
+		t.Fatalf("Expected an alert after the cooldown, fired %d", fired)
+	}
+}
+
+func TestAlerter_FailingRuleDoesNotBlockOthers(t *testing.T) {
+	channel := &recordingAlertChannel{}
+	broken := QueueBacklogRule("jobs", func() (int, error) { return 0, errors.New("database is locked") }, 1)
+	backlog := QueueBacklogRule("jobs", func() (int, error) { return 7, nil }, 5)
+	a := NewAlerter([]AlertRule{broken, backlog}, []AlertChannel{channel}, time.Minute)
+
+	fired, err := a.Evaluate()
+	if err == nil {
+		t.Error("Expected the rule error to be reported")
+	}
+	if fired != 1 || len(channel.alerts) != 1 {
+		t.Fatalf("Expected the backlog alert, fired %d", fired)
+	}
+}
+
+func TestWebhookAlertChannel(t *testing.T) {
+	var received struct {
+		Alert entities.Alert `json:"alert"`
+	}
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	channel := NewWebhookAlertChannel(srv.URL, srv.Client())
+	if err := channel.Send(&entities.Alert{Rule: entities.AlertRuleQueueBacklog, Value: 600, Threshold: 500}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if received.Alert.Rule != entities.AlertRuleQueueBacklog || received.Alert.Value != 600 {
+		t.Errorf("Unexpected payload %+v", received.Alert)
+	}
+
+	status = http.StatusInternalServerError
+	if err := channel.Send(&entities.Alert{Rule: entities.AlertRuleQueueBacklog}); err == nil {
+		t.Error("Expected a non-2xx response to fail")
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// AnomalyThresholds configures when recent activity counts as suspicious;
+// a zero threshold disables that signal
+type AnomalyThresholds struct {
+	// Window is how far back each scan looks
+	Window                  time.Duration
+	RegistrationsPerNetwork int
+	FavoritesPerUser        int
+	CommentsPerUser         int
+}
+
+// AnomalyDetector aggregates abuse signals into anomalies for admin review
+type AnomalyDetector interface {
+	// Scan records anomalies for bursts in the current window and returns how many are new
+	Scan() (int, error)
+}
+
+// anomalyDetector implements AnomalyDetector on top of stored activity
+type anomalyDetector struct {
+	anomalyRepo repositories.AnomalyRepository
+	thresholds  AnomalyThresholds
+	now         func() time.Time
+}
+
+// NewAnomalyDetector creates an anomaly detector
+func NewAnomalyDetector(anomalyRepo repositories.AnomalyRepository, thresholds AnomalyThresholds) AnomalyDetector {
+	return &anomalyDetector{
+		anomalyRepo: anomalyRepo,
+		thresholds:  thresholds,
+		now:         time.Now,
+	}
+}
+
+// Scan checks every enabled signal and records what it finds
+func (d *anomalyDetector) Scan() (int, error) {
+	now := d.now()
+	since := now.Add(-d.thresholds.Window)
+
+	detectors := []struct {
+		threshold int
+		detect    func(time.Time, int) ([]entities.AnomalySignal, error)
+	}{
+		{d.thresholds.RegistrationsPerNetwork, d.anomalyRepo.RegistrationBursts},
+		{d.thresholds.FavoritesPerUser, d.anomalyRepo.FavoriteBursts},
+		{d.thresholds.CommentsPerUser, d.anomalyRepo.CommentFloods},
+	}
+
+	detected := 0
+	for _, detector := range detectors {
+		if detector.threshold <= 0 {
+			continue
+		}
+
+		signals, err := detector.detect(since, detector.threshold)
+		if err != nil {
+			return detected, err
+		}
+
+		for _, signal := range signals {
+			created, err := d.anomalyRepo.Record(signal, now)
+			if err != nil {
+				return detected, err
+			}
+			if created {
+				detected++
+			}
+		}
+	}
+
+	return detected, nil
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakeAnomalyRepo struct {
+	repositories.AnomalyRepository
+	since    time.Time
+	recorded []entities.AnomalySignal
+}
+
+func (r *fakeAnomalyRepo) RegistrationBursts(since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	r.since = since
+	return []entities.AnomalySignal{{Kind: entities.AnomalyRegistrationBurst, Network: "net"}}, nil
+}
+
+func (r *fakeAnomalyRepo) FavoriteBursts(since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	panic("disabled signals must not be checked")
+}
+
+func (r *fakeAnomalyRepo) CommentFloods(since time.Time, threshold int) ([]entities.AnomalySignal, error) {
+	return []entities.AnomalySignal{{Kind: entities.AnomalyCommentFlood, UserID: 1}, {Kind: entities.AnomalyCommentFlood, UserID: 2}}, nil
+}
+
+func (r *fakeAnomalyRepo) Record(signal entities.AnomalySignal, at time.Time) (bool, error) {
+	r.recorded = append(r.recorded, signal)
+	// Pretend user 2 already had an open anomaly
+	return signal.UserID != 2, nil
+}
+
+func TestAnomalyDetector_Scan(t *testing.T) {
+	now := time.Now()
+	repo := &fakeAnomalyRepo{}
+	detector := NewAnomalyDetector(repo, AnomalyThresholds{
+		Window:                  time.Hour,
+		RegistrationsPerNetwork: 5,
+		CommentsPerUser:         30,
+	})
+	detector.(*anomalyDetector).now = func() time.Time { return now }
+
+	detected, err := detector.Scan()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detected != 2 {
+		t.Errorf("Expected 2 new anomalies, got %d", detected)
+	}
+	if len(repo.recorded) != 3 {
+		t.Errorf("Expected every signal to be recorded, got %d", len(repo.recorded))
+	}
+	if !repo.since.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected the scan to cover the last hour, got since %v", repo.since)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// exportBatchSize caps the exports rendered by one Process call
+const exportBatchSize = 10
+
+// ArticleExporter queues article exports to downloadable documents and renders
+// them in the background
+type ArticleExporter interface {
+	// Formats lists the formats a renderer is registered for
+	Formats() []string
+	// Request returns the user's export of the article to the format, queueing
+	// a new one unless an earlier export is still current
+	Request(article *entities.Article, userID int64, format string) (*entities.ArticleExport, error)
+	// Process renders queued exports and removes expired ones, returning how
+	// many were rendered
+	Process() (int, error)
+}
+
+// articleExporter implements ArticleExporter
+type articleExporter struct {
+	exportRepo  repositories.ExportRepository
+	articleRepo repositories.ArticleRepository
+	renderers   map[string]ArticleRenderer
+	retention   time.Duration
+}
+
+// NewArticleExporter creates an article exporter with a renderer per format.
+// Exports are deleted once they are older than retention; zero keeps them.
+func NewArticleExporter(exportRepo repositories.ExportRepository, articleRepo repositories.ArticleRepository, renderers map[string]ArticleRenderer, retention time.Duration) ArticleExporter {
+	return &articleExporter{
+		exportRepo:  exportRepo,
+		articleRepo: articleRepo,
+		renderers:   renderers,
+		retention:   retention,
+	}
+}
+
+// Formats lists the supported formats, sorted
+func (e *articleExporter) Formats() []string {
+	formats := make([]string, 0, len(e.renderers))
+	for format := range e.renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Request reuses the latest export while it is pending or was rendered after
+// the article's last update; failed and outdated exports are queued again
+func (e *articleExporter) Request(article *entities.Article, userID int64, format string) (*entities.ArticleExport, error) {
+	if _, ok := e.renderers[format]; !ok {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	latest, err := e.exportRepo.Latest(article.ID, userID, format)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Status != entities.ExportStatusFailed && !latest.CreatedAt.Before(article.UpdatedAt) {
+		return latest, nil
+	}
+
+	return e.exportRepo.Create(article.ID, userID, format, time.Now().UTC())
+}
+
+// Process renders up to exportBatchSize queued exports, oldest first. An
+// export that cannot be rendered is marked failed and asked for again later.
+func (e *articleExporter) Process() (int, error) {
+	now := time.Now().UTC()
+	if e.retention > 0 {
+		if _, err := e.exportRepo.DeleteCreatedBefore(now.Add(-e.retention)); err != nil {
+			return 0, err
+		}
+	}
+
+	exports, err := e.exportRepo.Pending(exportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	rendered := 0
+	for _, export := range exports {
+		content, contentType, renderErr := e.render(&export)
+		now := time.Now().UTC()
+		if renderErr != nil {
+			if err := e.exportRepo.Fail(export.ID, renderErr.Error(), now); err != nil {
+				return rendered, err
+			}
+			continue
+		}
+		if err := e.exportRepo.Complete(export.ID, contentType, content, now); err != nil {
+			return rendered, err
+		}
+		rendered++
+	}
+
+	return rendered, nil
+}
+
+// render renders one export's article with the renderer for its format
+func (e *articleExporter) render(export *entities.ArticleExport) ([]byte, string, error) {
+	renderer, ok := e.renderers[export.Format]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported export format: %s", export.Format)
+	}
+
+	article, err := e.articleRepo.GetByID(export.ArticleID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := renderer.Render(article)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render %s: %w", export.Format, err)
+	}
+	return content, renderer.ContentType(), nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakeExportRepo struct {
+	repositories.ExportRepository
+	exports []*entities.ArticleExport
+}
+
+func (r *fakeExportRepo) Create(articleID, userID int64, format string, at time.Time) (*entities.ArticleExport, error) {
+	export := &entities.ArticleExport{ID: int64(len(r.exports) + 1), ArticleID: articleID, UserID: userID, Format: format, Status: entities.ExportStatusPending, CreatedAt: at}
+	r.exports = append(r.exports, export)
+	return export, nil
+}
+
+func (r *fakeExportRepo) Latest(articleID, userID int64, format string) (*entities.ArticleExport, error) {
+	for i := len(r.exports) - 1; i >= 0; i-- {
+		if export := r.exports[i]; export.ArticleID == articleID && export.UserID == userID && export.Format == format {
+			return export, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeExportRepo) Pending(limit int) ([]entities.ArticleExport, error) {
+	var pending []entities.ArticleExport
+	for _, export := range r.exports {
+		if export.Status == entities.ExportStatusPending {
+			pending = append(pending, *export)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeExportRepo) Complete(id int64, contentType string, content []byte, at time.Time) error {
+	r.exports[id-1].Status, r.exports[id-1].Content = entities.ExportStatusReady, content
+	return nil
+}
+
+func (r *fakeExportRepo) Fail(id int64, message string, at time.Time) error {
+	r.exports[id-1].Status, r.exports[id-1].Error = entities.ExportStatusFailed, message
+	return nil
+}
+
+type fakeExportArticleRepo struct {
+	repositories.ArticleRepository
+}
+
+func (r *fakeExportArticleRepo) GetByID(id int64) (*entities.Article, error) {
+	if id != 1 {
+		return nil, errors.New("article not found")
+	}
+	return &entities.Article{ID: 1, Title: "Exported"}, nil
+}
+
+type fakeRenderer struct{}
+
+func (fakeRenderer) ContentType() string { return "text/plain" }
+
+func (fakeRenderer) Render(article *entities.Article) ([]byte, error) {
+	return []byte(article.Title), nil
+}
+
+func TestArticleExporter_RequestAndProcess(t *testing.T) {
+	repo := &fakeExportRepo{}
+	exporter := NewArticleExporter(repo, &fakeExportArticleRepo{}, map[string]ArticleRenderer{"txt": fakeRenderer{}}, 0)
+	article := &entities.Article{ID: 1, UpdatedAt: time.Now().Add(-time.Hour)}
+
+	if _, err := exporter.Request(article, 7, "docx"); err == nil {
+		t.Error("Expected an unsupported format to be rejected")
+	}
+
+	first, err := exporter.Request(article, 7, "txt")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	// Asking again while it is queued returns the same export
+	if again, _ := exporter.Request(article, 7, "txt"); again.ID != first.ID {
+		t.Errorf("Expected the pending export to be reused, got a new one")
+	}
+	missing, _ := exporter.Request(&entities.Article{ID: 2}, 7, "txt")
+
+	rendered, err := exporter.Process()
+	if err != nil || rendered != 1 {
+		t.Fatalf("Expected 1 rendered export, got %d (err: %v)", rendered, err)
+	}
+	if repo.exports[first.ID-1].Status != entities.ExportStatusReady || string(repo.exports[first.ID-1].Content) != "Exported" {
+		t.Errorf("Expected the export to be ready, got %+v", repo.exports[first.ID-1])
+	}
+	if repo.exports[missing.ID-1].Status != entities.ExportStatusFailed {
+		t.Errorf("Expected the export of a missing article to fail, got %s", repo.exports[missing.ID-1].Status)
+	}
+
+	// Editing the article makes the rendered export outdated
+	article.UpdatedAt = time.Now().Add(time.Minute)
+	if updated, _ := exporter.Request(article, 7, "txt"); updated.ID == first.ID {
+		t.Error("Expected an outdated export to be queued again")
+	}
+}
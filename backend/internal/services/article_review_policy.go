@@ -0,0 +1,66 @@
+package services
+
+import (
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// ArticleReviewOptions configures which new articles wait for approval
+type ArticleReviewOptions struct {
+	// ReviewAll holds every article (private beta)
+	ReviewAll bool
+	// FirstArticles holds a new author's articles until this many have been
+	// approved; zero disables first-time review
+	FirstArticles int
+	// NewAccountAge limits first-time review to accounts younger than this;
+	// zero applies it regardless of account age
+	NewAccountAge time.Duration
+}
+
+// ArticleReviewPolicy decides which new articles wait for approval before
+// they are published
+type ArticleReviewPolicy interface {
+	RequiresReview(author *entities.User) (bool, error)
+}
+
+// articleReviewPolicy implements ArticleReviewPolicy from deployment settings
+type articleReviewPolicy struct {
+	articleRepo repositories.ArticleRepository
+	options     ArticleReviewOptions
+	now         func() time.Time
+}
+
+// NewArticleReviewPolicy creates a review policy
+func NewArticleReviewPolicy(articleRepo repositories.ArticleRepository, options ArticleReviewOptions) ArticleReviewPolicy {
+	return &articleReviewPolicy{
+		articleRepo: articleRepo,
+		options:     options,
+		now:         time.Now,
+	}
+}
+
+// RequiresReview reports whether the author's next article starts out
+// pending; reviewers publish directly
+func (p *articleReviewPolicy) RequiresReview(author *entities.User) (bool, error) {
+	if author.IsModerator() {
+		return false, nil
+	}
+	if p.options.ReviewAll {
+		return true, nil
+	}
+	if p.options.FirstArticles <= 0 {
+		return false, nil
+	}
+	if p.options.NewAccountAge > 0 && p.now().Sub(author.CreatedAt) >= p.options.NewAccountAge {
+		return false, nil
+	}
+
+	published, err := p.articleRepo.CountByAuthor(author.ID, entities.ArticleStatusPublished)
+	if err != nil {
+		return false, err
+	}
+
+	return published < p.options.FirstArticles, nil
+}
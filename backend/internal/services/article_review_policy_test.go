@@ -0,0 +1,63 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakePublishedCountRepo struct {
+	repositories.ArticleRepository
+	published map[int64]int
+}
+
+func (r *fakePublishedCountRepo) CountByAuthor(authorID int64, status string) (int, error) {
+	if status != entities.ArticleStatusPublished {
+		return 0, nil
+	}
+	return r.published[authorID], nil
+}
+
+func TestArticleReviewPolicy_FirstArticles(t *testing.T) {
+	now := time.Now()
+	repo := &fakePublishedCountRepo{published: map[int64]int{1: 0, 2: 2, 3: 0}}
+	policy := NewArticleReviewPolicy(repo, ArticleReviewOptions{
+		FirstArticles: 2,
+		NewAccountAge: 30 * 24 * time.Hour,
+	})
+	policy.(*articleReviewPolicy).now = func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		author *entities.User
+		want   bool
+	}{
+		{"new author", &entities.User{ID: 1, Role: entities.RoleUser, CreatedAt: now.Add(-time.Hour)}, true},
+		{"enough published", &entities.User{ID: 2, Role: entities.RoleUser, CreatedAt: now.Add(-time.Hour)}, false},
+		{"established account", &entities.User{ID: 3, Role: entities.RoleUser, CreatedAt: now.Add(-60 * 24 * time.Hour)}, false},
+		{"moderator", &entities.User{ID: 1, Role: entities.RoleModerator, CreatedAt: now}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := policy.RequiresReview(tt.author)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: RequiresReview = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestArticleReviewPolicy_ReviewAll(t *testing.T) {
+	policy := NewArticleReviewPolicy(&fakePublishedCountRepo{}, ArticleReviewOptions{ReviewAll: true})
+
+	if got, _ := policy.RequiresReview(&entities.User{ID: 1, Role: entities.RoleUser}); !got {
+		t.Error("Expected every user's articles to be reviewed in beta mode")
+	}
+	if got, _ := policy.RequiresReview(&entities.User{ID: 2, Role: entities.RoleAdmin}); got {
+		t.Error("Expected admins to publish directly")
+	}
+}
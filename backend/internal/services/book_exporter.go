@@ -0,0 +1,258 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// BookExporter queues series and picked articles to be bundled into books
+// and renders them in the background
+type BookExporter interface {
+	// Formats lists the formats a renderer is registered for
+	Formats() []string
+	// RequestSeries returns the user's export of the series to the format,
+	// queueing a new one unless an earlier export is still current
+	RequestSeries(series *entities.Series, userID int64, format string) (*entities.BookExport, error)
+	// RequestArticles queues a book of the articles, in the order given
+	RequestArticles(userID int64, title string, articleIDs []int64, format string) (*entities.BookExport, error)
+	// Process renders queued books and removes expired ones, returning how
+	// many were rendered
+	Process() (int, error)
+}
+
+// bookExporter implements BookExporter
+type bookExporter struct {
+	exportRepo  repositories.BookExportRepository
+	seriesRepo  repositories.SeriesRepository
+	articleRepo repositories.ArticleRepository
+	userRepo    repositories.UserRepository
+	renderers   map[string]BookRenderer
+	retention   time.Duration
+}
+
+// NewBookExporter creates a book exporter with a renderer per format. Exports
+// are deleted once they are older than retention; zero keeps them.
+func NewBookExporter(exportRepo repositories.BookExportRepository, seriesRepo repositories.SeriesRepository, articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, renderers map[string]BookRenderer, retention time.Duration) BookExporter {
+	return &bookExporter{
+		exportRepo:  exportRepo,
+		seriesRepo:  seriesRepo,
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+		renderers:   renderers,
+		retention:   retention,
+	}
+}
+
+// Formats lists the supported formats, sorted
+func (e *bookExporter) Formats() []string {
+	formats := make([]string, 0, len(e.renderers))
+	for format := range e.renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// RequestSeries reuses the latest export while it is pending or was rendered
+// after the series and its articles last changed; failed and outdated exports
+// are queued again
+func (e *bookExporter) RequestSeries(series *entities.Series, userID int64, format string) (*entities.BookExport, error) {
+	if _, ok := e.renderers[format]; !ok {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	latest, err := e.exportRepo.LatestForSeries(series.ID, userID, format)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Status != entities.ExportStatusFailed {
+		chapters, err := e.seriesChapters(series.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !latest.CreatedAt.Before(lastChange(series.UpdatedAt, chapters)) {
+			return latest, nil
+		}
+	}
+
+	return e.exportRepo.Create(&entities.BookExport{
+		UserID:    userID,
+		SeriesID:  &series.ID,
+		Title:     series.Title,
+		Format:    format,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// RequestArticles queues a new book every time, since each request picks its
+// own articles
+func (e *bookExporter) RequestArticles(userID int64, title string, articleIDs []int64, format string) (*entities.BookExport, error) {
+	if _, ok := e.renderers[format]; !ok {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	return e.exportRepo.Create(&entities.BookExport{
+		UserID:     userID,
+		ArticleIDs: articleIDs,
+		Title:      title,
+		Format:     format,
+		CreatedAt:  time.Now().UTC(),
+	})
+}
+
+// Process renders up to exportBatchSize queued books, oldest first. A book
+// that cannot be rendered is marked failed and asked for again later.
+func (e *bookExporter) Process() (int, error) {
+	now := time.Now().UTC()
+	if e.retention > 0 {
+		if _, err := e.exportRepo.DeleteCreatedBefore(now.Add(-e.retention)); err != nil {
+			return 0, err
+		}
+	}
+
+	exports, err := e.exportRepo.Pending(exportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	rendered := 0
+	for _, export := range exports {
+		content, contentType, renderErr := e.render(&export)
+		now := time.Now().UTC()
+		if renderErr != nil {
+			if err := e.exportRepo.Fail(export.ID, renderErr.Error(), now); err != nil {
+				return rendered, err
+			}
+			continue
+		}
+		if err := e.exportRepo.Complete(export.ID, contentType, content, now); err != nil {
+			return rendered, err
+		}
+		rendered++
+	}
+
+	return rendered, nil
+}
+
+// render assembles one export's book and renders it with the renderer for its format
+func (e *bookExporter) render(export *entities.BookExport) ([]byte, string, error) {
+	renderer, ok := e.renderers[export.Format]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported export format: %s", export.Format)
+	}
+
+	var book *Book
+	var err error
+	if export.SeriesID != nil {
+		book, err = e.seriesBook(*export.SeriesID)
+	} else {
+		book, err = e.articlesBook(export)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := renderer.Render(book)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render %s: %w", export.Format, err)
+	}
+	return content, renderer.ContentType(), nil
+}
+
+// seriesBook takes the cover metadata from the series and its published
+// articles, in series order, as chapters
+func (e *bookExporter) seriesBook(seriesID int64) (*Book, error) {
+	series, err := e.seriesRepo.GetByID(seriesID)
+	if err != nil {
+		return nil, err
+	}
+	chapters, err := e.seriesChapters(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("series has no published articles")
+	}
+
+	book := &Book{
+		Identifier:  "urn:conduit:series:" + series.Slug,
+		Title:       series.Title,
+		Description: series.Description,
+		Language:    chapters[0].Language,
+		Chapters:    chapters,
+		ModifiedAt:  lastChange(series.UpdatedAt, chapters),
+	}
+	if series.Author != nil {
+		book.Author = series.Author.Username
+	}
+	return book, nil
+}
+
+// articlesBook collects the picked articles that still exist, in the order
+// they were picked
+func (e *bookExporter) articlesBook(export *entities.BookExport) (*Book, error) {
+	user, err := e.userRepo.GetByID(export.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var chapters []*entities.Article
+	for _, id := range export.ArticleIDs {
+		article, err := e.articleRepo.GetByID(id)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				continue
+			}
+			return nil, err
+		}
+		chapters = append(chapters, article)
+	}
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("none of the articles exist anymore")
+	}
+
+	return &Book{
+		Identifier: fmt.Sprintf("urn:conduit:book-export:%d", export.ID),
+		Title:      export.Title,
+		Author:     user.Username,
+		Language:   chapters[0].Language,
+		Chapters:   chapters,
+		ModifiedAt: lastChange(export.CreatedAt, chapters),
+	}, nil
+}
+
+// seriesChapters returns the series' published articles in series order
+func (e *bookExporter) seriesChapters(seriesID int64) ([]*entities.Article, error) {
+	ids, err := e.seriesRepo.ArticleIDs(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	var chapters []*entities.Article
+	for _, id := range ids {
+		article, err := e.articleRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if article.IsPublished() {
+			chapters = append(chapters, article)
+		}
+	}
+	return chapters, nil
+}
+
+// lastChange returns the latest of since and the chapters' update times
+func lastChange(since time.Time, chapters []*entities.Article) time.Time {
+	latest := since
+	for _, chapter := range chapters {
+		if chapter.UpdatedAt.After(latest) {
+			latest = chapter.UpdatedAt
+		}
+	}
+	return latest
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakeBookExportRepo struct {
+	repositories.BookExportRepository
+	exports []*entities.BookExport
+}
+
+func (r *fakeBookExportRepo) Create(export *entities.BookExport) (*entities.BookExport, error) {
+	created := *export
+	created.ID, created.Status = int64(len(r.exports)+1), entities.ExportStatusPending
+	r.exports = append(r.exports, &created)
+	return &created, nil
+}
+
+func (r *fakeBookExportRepo) LatestForSeries(seriesID, userID int64, format string) (*entities.BookExport, error) {
+	for i := len(r.exports) - 1; i >= 0; i-- {
+		if export := r.exports[i]; export.SeriesID != nil && *export.SeriesID == seriesID && export.UserID == userID && export.Format == format {
+			return export, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeBookExportRepo) Pending(limit int) ([]entities.BookExport, error) {
+	var pending []entities.BookExport
+	for _, export := range r.exports {
+		if export.Status == entities.ExportStatusPending {
+			pending = append(pending, *export)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeBookExportRepo) Complete(id int64, contentType string, content []byte, at time.Time) error {
+	r.exports[id-1].Status, r.exports[id-1].Content = entities.ExportStatusReady, content
+	return nil
+}
+
+func (r *fakeBookExportRepo) Fail(id int64, message string, at time.Time) error {
+	r.exports[id-1].Status, r.exports[id-1].Error = entities.ExportStatusFailed, message
+	return nil
+}
+
+type fakeBookSeriesRepo struct {
+	repositories.SeriesRepository
+	series *entities.Series
+	ids    []int64
+}
+
+func (r *fakeBookSeriesRepo) GetByID(id int64) (*entities.Series, error) {
+	return r.series, nil
+}
+
+func (r *fakeBookSeriesRepo) ArticleIDs(seriesID int64) ([]int64, error) {
+	return r.ids, nil
+}
+
+type fakeBookArticleRepo struct {
+	repositories.ArticleRepository
+	articles map[int64]*entities.Article
+}
+
+func (r *fakeBookArticleRepo) GetByID(id int64) (*entities.Article, error) {
+	if article, ok := r.articles[id]; ok {
+		return article, nil
+	}
+	return nil, errors.New("article not found")
+}
+
+type fakeBookUserRepo struct {
+	repositories.UserRepository
+}
+
+func (r *fakeBookUserRepo) GetByID(id int64) (*entities.User, error) {
+	return &entities.User{ID: id, Username: "writer"}, nil
+}
+
+// fakeBookRenderer records the books it renders
+type fakeBookRenderer struct {
+	books []*Book
+}
+
+func (f *fakeBookRenderer) ContentType() string { return "text/plain" }
+
+func (f *fakeBookRenderer) Render(book *Book) ([]byte, error) {
+	f.books = append(f.books, book)
+	return []byte(book.Title), nil
+}
+
+func TestBookExporter_RequestAndProcess(t *testing.T) {
+	hourAgo := time.Now().Add(-time.Hour)
+	articles := map[int64]*entities.Article{
+		1: {ID: 1, Title: "First", Status: entities.ArticleStatusPublished, UpdatedAt: hourAgo},
+		2: {ID: 2, Title: "Draft", Status: entities.ArticleStatusPending, UpdatedAt: hourAgo},
+		3: {ID: 3, Title: "Second", Status: entities.ArticleStatusPublished, UpdatedAt: hourAgo},
+	}
+	series := &entities.Series{ID: 5, Slug: "learning-go", Title: "Learning Go", Author: &entities.Profile{Username: "writer"}, UpdatedAt: hourAgo}
+	repo := &fakeBookExportRepo{}
+	renderer := &fakeBookRenderer{}
+	exporter := NewBookExporter(repo, &fakeBookSeriesRepo{series: series, ids: []int64{3, 2, 1}}, &fakeBookArticleRepo{articles: articles},
+		&fakeBookUserRepo{}, map[string]BookRenderer{"txt": renderer}, 0)
+
+	if _, err := exporter.RequestSeries(series, 7, "pdf"); err == nil {
+		t.Error("Expected an unsupported format to be rejected")
+	}
+
+	first, err := exporter.RequestSeries(series, 7, "txt")
+	if err != nil {
+		t.Fatalf("RequestSeries failed: %v", err)
+	}
+	if again, _ := exporter.RequestSeries(series, 7, "txt"); again.ID != first.ID {
+		t.Error("Expected the pending series export to be reused")
+	}
+	picked, err := exporter.RequestArticles(7, "Favourites", []int64{1, 99, 3}, "txt")
+	if err != nil {
+		t.Fatalf("RequestArticles failed: %v", err)
+	}
+
+	rendered, err := exporter.Process()
+	if err != nil || rendered != 2 {
+		t.Fatalf("Expected 2 rendered books, got %d (err: %v)", rendered, err)
+	}
+
+	// The series book follows the series order and leaves out unpublished articles
+	book := renderer.books[0]
+	if book.Title != "Learning Go" || book.Author != "writer" || len(book.Chapters) != 2 ||
+		book.Chapters[0].Title != "Second" || book.Chapters[1].Title != "First" {
+		t.Errorf("Unexpected series book %+v", book)
+	}
+	// Picked articles keep their order and skip deleted ones
+	book = renderer.books[1]
+	if book.Title != "Favourites" || len(book.Chapters) != 2 || book.Chapters[0].ID != 1 || book.Chapters[1].ID != 3 {
+		t.Errorf("Unexpected picked book %+v", book)
+	}
+	if repo.exports[picked.ID-1].Status != entities.ExportStatusReady {
+		t.Errorf("Expected the picked book to be ready, got %s", repo.exports[picked.ID-1].Status)
+	}
+
+	// Editing a chapter makes the rendered series book outdated
+	articles[3].UpdatedAt = time.Now().Add(time.Minute)
+	if updated, _ := exporter.RequestSeries(series, 7, "txt"); updated.ID == first.ID {
+		t.Error("Expected an outdated series export to be queued again")
+	}
+}
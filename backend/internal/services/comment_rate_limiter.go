@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// CommentRateLimits configures how often a user may comment; zero values disable a limit
+type CommentRateLimits struct {
+	MinInterval time.Duration
+	HourlyLimit int
+}
+
+// CommentRateLimiter enforces per-user comment cooldowns and hourly caps
+type CommentRateLimiter interface {
+	// Check returns how long the user must wait before commenting, or zero if allowed
+	Check(userID int64) (time.Duration, error)
+}
+
+// commentRateLimiter implements CommentRateLimiter on top of stored comment history
+type commentRateLimiter struct {
+	commentRepo    repositories.CommentRepository
+	userRepo       repositories.UserRepository
+	newLimits      CommentRateLimits
+	trustedLimits  CommentRateLimits
+	trustedAccount time.Duration
+	now            func() time.Time
+}
+
+// NewCommentRateLimiter creates a comment rate limiter. Accounts older than
+// trustedAccountAge get trustedLimits; newer and rate-limited accounts get newLimits.
+func NewCommentRateLimiter(commentRepo repositories.CommentRepository, userRepo repositories.UserRepository, newLimits, trustedLimits CommentRateLimits, trustedAccountAge time.Duration) CommentRateLimiter {
+	return &commentRateLimiter{
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		newLimits:      newLimits,
+		trustedLimits:  trustedLimits,
+		trustedAccount: trustedAccountAge,
+		now:            time.Now,
+	}
+}
+
+// Check returns the remaining cooldown for the user's next comment
+func (l *commentRateLimiter) Check(userID int64) (time.Duration, error) {
+	user, err := l.userRepo.GetByID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load commenter: %w", err)
+	}
+
+	now := l.now()
+
+	limits := l.newLimits
+	if !user.IsRateLimited() && !user.CreatedAt.IsZero() && now.Sub(user.CreatedAt) >= l.trustedAccount {
+		limits = l.trustedLimits
+	}
+
+	window := time.Hour
+	if limits.MinInterval > window {
+		window = limits.MinInterval
+	}
+
+	recent, err := l.commentRepo.ListRecentTimesByAuthor(user.ID, now.Add(-window))
+	if err != nil {
+		return 0, err
+	}
+
+	var retryAfter time.Duration
+
+	// Cooldown since the most recent comment
+	if limits.MinInterval > 0 && len(recent) > 0 {
+		if wait := recent[len(recent)-1].Add(limits.MinInterval).Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	// Hourly cap: wait until enough of the oldest comments leave the window
+	if limits.HourlyLimit > 0 {
+		var inHour []time.Time
+		for _, createdAt := range recent {
+			if createdAt.After(now.Add(-time.Hour)) {
+				inHour = append(inHour, createdAt)
+			}
+		}
+		if len(inHour) >= limits.HourlyLimit {
+			expiring := inHour[len(inHour)-limits.HourlyLimit]
+			if wait := expiring.Add(time.Hour).Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	return retryAfter, nil
+}
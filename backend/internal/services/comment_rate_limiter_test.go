@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakeCommentRepo struct {
+	repositories.CommentRepository
+	times []time.Time
+}
+
+func (r *fakeCommentRepo) ListRecentTimesByAuthor(authorID int64, since time.Time) ([]time.Time, error) {
+	var recent []time.Time
+	for _, createdAt := range r.times {
+		if createdAt.After(since) {
+			recent = append(recent, createdAt)
+		}
+	}
+	return recent, nil
+}
+
+type fakeAccountRepo struct {
+	repositories.UserRepository
+	createdAt time.Time
+}
+
+func (r *fakeAccountRepo) GetByID(id int64) (*entities.User, error) {
+	return &entities.User{ID: id, CreatedAt: r.createdAt}, nil
+}
+
+func newTestRateLimiter(now time.Time, accountAge time.Duration, times ...time.Time) CommentRateLimiter {
+	limiter := NewCommentRateLimiter(
+		&fakeCommentRepo{times: times},
+		&fakeAccountRepo{createdAt: now.Add(-accountAge)},
+		CommentRateLimits{MinInterval: 30 * time.Second, HourlyLimit: 3},
+		CommentRateLimits{MinInterval: 5 * time.Second, HourlyLimit: 10},
+		30*24*time.Hour,
+	)
+	limiter.(*commentRateLimiter).now = func() time.Time { return now }
+	return limiter
+}
+
+func TestCommentRateLimiter_Allows(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(now, time.Hour, now.Add(-time.Minute))
+
+	retryAfter, err := limiter.Check(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if retryAfter != 0 {
+		t.Errorf("Expected comment to be allowed, got retry after %v", retryAfter)
+	}
+}
+
+func TestCommentRateLimiter_MinInterval(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(now, time.Hour, now.Add(-10*time.Second))
+
+	retryAfter, _ := limiter.Check(1)
+	if retryAfter != 20*time.Second {
+		t.Errorf("Expected 20s cooldown, got %v", retryAfter)
+	}
+}
+
+func TestCommentRateLimiter_HourlyLimit(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(now, time.Hour,
+		now.Add(-50*time.Minute),
+		now.Add(-40*time.Minute),
+		now.Add(-30*time.Minute),
+	)
+
+	// The oldest comment leaves the hourly window in 10 minutes
+	retryAfter, _ := limiter.Check(1)
+	if retryAfter != 10*time.Minute {
+		t.Errorf("Expected 10m wait, got %v", retryAfter)
+	}
+}
+
+func TestCommentRateLimiter_EstablishedAccount(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(now, 60*24*time.Hour,
+		now.Add(-50*time.Minute),
+		now.Add(-40*time.Minute),
+		now.Add(-10*time.Second),
+	)
+
+	retryAfter, _ := limiter.Check(1)
+	if retryAfter != 0 {
+		t.Errorf("Expected established account to use higher limits, got retry after %v", retryAfter)
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// DemoPassword signs in to every seeded demo account
+const DemoPassword = "demo-password"
+
+// DemoSeeder fills an empty database with sample content for demo mode
+type DemoSeeder interface {
+	// Seed creates the sample accounts, articles, comments, follows and favorites
+	Seed() error
+	// Accounts returns the emails of the seeded accounts
+	Accounts() []string
+}
+
+// demoArticle is a seeded article and the reactions it gets
+type demoArticle struct {
+	author      string
+	article     entities.ArticleCreate
+	comments    []demoComment
+	favoritedBy []string
+}
+
+// demoComment is a seeded comment
+type demoComment struct {
+	author string
+	body   string
+}
+
+var demoUsers = []string{"ada", "grace", "linus"}
+
+var demoFollows = map[string][]string{
+	"ada":   {"grace"},
+	"grace": {"ada", "linus"},
+	"linus": {"ada"},
+}
+
+var demoArticles = []demoArticle{
+	{
+		author: "ada",
+		article: entities.ArticleCreate{
+			Title:       "Welcome to the Conduit demo",
+			Description: "Everything here is sample data that resets regularly",
+			Body:        "Sign in as any of the demo accounts and try writing, commenting, following and favoriting. Nothing you change is kept: the database lives in memory and goes back to this sample content on every reset.",
+			TagList:     []string{"demo", "welcome"},
+		},
+		comments:    []demoComment{{"grace", "Glad to be here!"}},
+		favoritedBy: []string{"grace", "linus"},
+	},
+	{
+		author: "grace",
+		article: entities.ArticleCreate{
+			Title:       "Notes on debugging",
+			Description: "Small habits that make bugs easier to find",
+			Body:        "Reproduce the problem first. Change one thing at a time. Write down what you expected before you look at what happened.",
+			TagList:     []string{"programming", "debugging"},
+		},
+		comments:    []demoComment{{"ada", "Writing down the expectation first is underrated."}, {"linus", "And keep the failing case as a test."}},
+		favoritedBy: []string{"ada"},
+	},
+	{
+		author: "linus",
+		article: entities.ArticleCreate{
+			Title:       "Why small commits matter",
+			Description: "A history that reviewers and bisect can follow",
+			Body:        "A commit that does one thing is easy to review, easy to revert and easy to find when something breaks later.",
+			TagList:     []string{"programming", "git"},
+		},
+		favoritedBy: []string{"grace"},
+	},
+}
+
+// demoSeeder implements DemoSeeder on top of the repositories
+type demoSeeder struct {
+	userRepo     repositories.UserRepository
+	articleRepo  repositories.ArticleRepository
+	commentRepo  repositories.CommentRepository
+	followRepo   repositories.FollowRepository
+	favoriteRepo repositories.FavoriteRepository
+}
+
+// NewDemoSeeder creates a demo seeder
+func NewDemoSeeder(userRepo repositories.UserRepository, articleRepo repositories.ArticleRepository, commentRepo repositories.CommentRepository, followRepo repositories.FollowRepository, favoriteRepo repositories.FavoriteRepository) DemoSeeder {
+	return &demoSeeder{
+		userRepo:     userRepo,
+		articleRepo:  articleRepo,
+		commentRepo:  commentRepo,
+		followRepo:   followRepo,
+		favoriteRepo: favoriteRepo,
+	}
+}
+
+// Seed creates the sample content; it expects an empty database
+func (s *demoSeeder) Seed() error {
+	users := make(map[string]int64, len(demoUsers))
+	for _, username := range demoUsers {
+		user, err := s.userRepo.Create(&entities.UserRegistration{
+			Username: username,
+			Email:    demoEmail(username),
+			Password: DemoPassword,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed user %s: %w", username, err)
+		}
+		users[username] = user.ID
+	}
+
+	for follower, followed := range demoFollows {
+		ids := make([]int64, len(followed))
+		for i, username := range followed {
+			ids[i] = users[username]
+		}
+		if _, err := s.followRepo.Follow(users[follower], ids); err != nil {
+			return fmt.Errorf("failed to seed follows: %w", err)
+		}
+	}
+
+	for _, seed := range demoArticles {
+		create := seed.article
+		article, err := s.articleRepo.Create(users[seed.author], &create)
+		if err != nil {
+			return fmt.Errorf("failed to seed article %q: %w", create.Title, err)
+		}
+
+		for _, comment := range seed.comments {
+			if _, err := s.commentRepo.Create(users[comment.author], article.ID, &entities.CommentCreate{Body: comment.body}); err != nil {
+				return fmt.Errorf("failed to seed comment: %w", err)
+			}
+		}
+
+		for _, username := range seed.favoritedBy {
+			if _, err := s.favoriteRepo.Favorite(users[username], article.ID); err != nil {
+				return fmt.Errorf("failed to seed favorite: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Accounts returns the emails the demo accounts sign in with
+func (s *demoSeeder) Accounts() []string {
+	emails := make([]string, len(demoUsers))
+	for i, username := range demoUsers {
+		emails[i] = demoEmail(username)
+	}
+	return emails
+}
+
+// demoEmail returns the email of a seeded account
+func demoEmail(username string) string {
+	return username + "@demo.local"
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// CapturedEmail is an email the console sender logged instead of sending
+type CapturedEmail struct {
+	Message EmailMessage
+	SentAt  time.Time
+}
+
+// EmailCapture keeps the most recent emails logged by the console sender, so
+// they can be previewed in development. It is safe for concurrent use.
+type EmailCapture struct {
+	mu     sync.Mutex
+	limit  int
+	emails []CapturedEmail
+}
+
+// NewEmailCapture creates a capture keeping up to limit emails
+func NewEmailCapture(limit int) *EmailCapture {
+	return &EmailCapture{
+		limit: limit,
+	}
+}
+
+// Record keeps a copy of message, dropping the oldest email past the limit
+func (c *EmailCapture) Record(message *EmailMessage, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.emails = append(c.emails, CapturedEmail{Message: *message, SentAt: at})
+	if len(c.emails) > c.limit {
+		c.emails = c.emails[len(c.emails)-c.limit:]
+	}
+}
+
+// Emails returns the captured emails, newest first
+func (c *EmailCapture) Emails() []CapturedEmail {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	emails := make([]CapturedEmail, len(c.emails))
+	for i, email := range c.emails {
+		emails[len(c.emails)-1-i] = email
+	}
+	return emails
+}
+
+// capturingEmailSender logs emails like the console sender and keeps them for
+// previews
+type capturingEmailSender struct {
+	sender  EmailSender
+	capture *EmailCapture
+}
+
+// NewCapturingLogEmailSender creates a development email sender that logs
+// messages and records them in capture
+func NewCapturingLogEmailSender(capture *EmailCapture) EmailSender {
+	return &capturingEmailSender{
+		sender:  NewLogEmailSender(),
+		capture: capture,
+	}
+}
+
+// Send logs and records the email
+func (s *capturingEmailSender) Send(message *EmailMessage) error {
+	if err := s.sender.Send(message); err != nil {
+		return err
+	}
+	s.capture.Record(message, time.Now().UTC())
+	return nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// EmailMessage represents an outgoing email. Body is the plain text; HTML,
+// when set, is sent alongside it as the preferred alternative.
+type EmailMessage struct {
+	To      string
+	ReplyTo string
+	Subject string
+	Body    string
+	HTML    string
+}
+
+// EmailSender delivers outgoing email
+type EmailSender interface {
+	Send(message *EmailMessage) error
+}
+
+// logEmailSender writes emails to the application log instead of sending them
+type logEmailSender struct{}
+
+// NewLogEmailSender creates an email sender for development that only logs messages
+func NewLogEmailSender() EmailSender {
+	return &logEmailSender{}
+}
+
+// Send logs the email
+func (s *logEmailSender) Send(message *EmailMessage) error {
+	if message.ReplyTo != "" {
+		log.Printf("📧 Email to %s (reply-to %s): %s\n%s", message.To, message.ReplyTo, message.Subject, message.Body)
+		return nil
+	}
+	log.Printf("📧 Email to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// smtpEmailSender sends email through an SMTP relay
+type smtpEmailSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPEmailSender creates an email sender backed by an SMTP server
+func NewSMTPEmailSender(host string, port int, username, password, from string) EmailSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpEmailSender{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send delivers the email via SMTP
+func (s *smtpEmailSender) Send(message *EmailMessage) error {
+	var b strings.Builder
+	b.WriteString("From: " + s.from + "\r\n")
+	b.WriteString("To: " + message.To + "\r\n")
+	if message.ReplyTo != "" {
+		b.WriteString("Reply-To: " + message.ReplyTo + "\r\n")
+	}
+	b.WriteString("Subject: " + message.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	if message.HTML == "" {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(message.Body)
+	} else if err := writeAlternatives(&b, message); err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{message.To}, []byte(b.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// writeAlternatives writes the headers and body of a multipart/alternative
+// message with the plain text and HTML bodies, quoted-printable encoded so
+// long HTML lines stay within SMTP's line limit
+func writeAlternatives(b *strings.Builder, message *EmailMessage) error {
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", message.Body},
+		{"text/html; charset=UTF-8", message.HTML},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return err
+		}
+		encoder := quotedprintable.NewWriter(w)
+		if _, err := encoder.Write([]byte(part.body)); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	b.WriteString("Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n")
+	b.WriteString("\r\n")
+	b.Write(parts.Bytes())
+	return nil
+}
+
+// monitoredEmailSender reports delivery outcomes to a health registry and stops
+// attempting delivery while the provider is down. Callers see
+// ErrSubsystemUnavailable and keep their notifications for a later retry.
+type monitoredEmailSender struct {
+	sender    EmailSender
+	health    HealthRegistry
+	component string
+}
+
+// NewMonitoredEmailSender wraps an email sender with health tracking under the given component name
+func NewMonitoredEmailSender(sender EmailSender, health HealthRegistry, component string) EmailSender {
+	return &monitoredEmailSender{
+		sender:    sender,
+		health:    health,
+		component: component,
+	}
+}
+
+// Send delivers the email unless the provider is known to be down
+func (s *monitoredEmailSender) Send(message *EmailMessage) error {
+	if !s.health.Healthy(s.component) {
+		return fmt.Errorf("email delivery skipped: %w", ErrSubsystemUnavailable)
+	}
+
+	if err := s.sender.Send(message); err != nil {
+		s.health.ReportFailure(s.component, err)
+		return err
+	}
+
+	s.health.ReportSuccess(s.component)
+	return nil
+}
+
+// suppressingEmailSender drops email to users whose address bounced for good
+// or complained, so the provider keeps trusting our sending reputation
+type suppressingEmailSender struct {
+	sender          EmailSender
+	userRepo        repositories.UserRepository
+	emailStatusRepo repositories.EmailStatusRepository
+}
+
+// NewSuppressingEmailSender wraps an email sender so addresses suppressed by
+// bounce and complaint notifications are skipped. Addresses that belong to no
+// user, such as alert recipients, are always sent to.
+func NewSuppressingEmailSender(sender EmailSender, userRepo repositories.UserRepository, emailStatusRepo repositories.EmailStatusRepository) EmailSender {
+	return &suppressingEmailSender{
+		sender:          sender,
+		userRepo:        userRepo,
+		emailStatusRepo: emailStatusRepo,
+	}
+}
+
+// Send delivers the email unless its recipient is suppressed. A suppressed
+// email counts as sent, so callers do not retry it.
+func (s *suppressingEmailSender) Send(message *EmailMessage) error {
+	user, err := s.userRepo.GetByEmail(message.To)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return s.sender.Send(message)
+		}
+		return err
+	}
+
+	status, err := s.emailStatusRepo.Get(user.ID)
+	if err != nil {
+		return err
+	}
+	if status.IsSuppressed() {
+		log.Printf("📭 Email to user %d suppressed (%s): %s", user.ID, status.Status, message.Subject)
+		return nil
+	}
+
+	return s.sender.Send(message)
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// EmailBranding is the deployment's look in HTML emails
+type EmailBranding struct {
+	// Name appears in the header, unless there is a logo, and the footer
+	Name string
+	// SiteURL is the frontend origin relative links are resolved against
+	SiteURL string
+	// LogoURL replaces the name in the header when set
+	LogoURL string
+	// AccentColor is the #rrggbb color of buttons and links
+	AccentColor string
+	// FooterText is an optional last line, such as a postal address
+	FooterText string
+}
+
+// RenderedEmail is an email body as HTML and its plain text alternative
+type RenderedEmail struct {
+	HTML string
+	Text string
+}
+
+// EmailTemplates renders emails into the shared layout in the deployment's
+// branding. Styles are inlined, since many mail clients drop <style>
+// elements, and the plain text alternative is generated from the HTML so the
+// two cannot drift apart.
+type EmailTemplates struct {
+	branding  EmailBranding
+	templates *template.Template
+}
+
+// emailPage is what the layout renders: an email's title and body with the
+// branding and the labels of the recipient's locale
+type emailPage struct {
+	Lang    string
+	Title   string
+	Brand   EmailBranding
+	Labels  emailLabels
+	Content interface{}
+	Body    template.HTML
+}
+
+// emailLabels are the layout's words in one locale
+type emailLabels struct {
+	Open           string
+	Reason         string
+	ManageSettings string
+}
+
+// emailButton is the data of the button partial
+type emailButton struct {
+	Link  string
+	Label string
+}
+
+// NewEmailTemplates creates email templates dressed in branding; its empty
+// fields fall back to the defaults
+func NewEmailTemplates(branding EmailBranding) *EmailTemplates {
+	if branding.Name == "" {
+		branding.Name = "Conduit"
+	}
+	if branding.AccentColor == "" {
+		branding.AccentColor = "#5cb85c"
+	}
+	branding.SiteURL = strings.TrimRight(branding.SiteURL, "/")
+
+	styles := emailStyles(branding.AccentColor)
+	templates := template.Must(emailTemplates.Clone())
+	templates.Funcs(template.FuncMap{
+		"style": func(name string) template.CSS {
+			return styles[name]
+		},
+		"url": func(link string) string {
+			if strings.HasPrefix(link, "/") {
+				return branding.SiteURL + link
+			}
+			return link
+		},
+	})
+
+	return &EmailTemplates{
+		branding:  branding,
+		templates: templates,
+	}
+}
+
+// Render renders the named content template with content into the layout,
+// titled title and framed in locale's words
+func (t *EmailTemplates) Render(name, locale, title string, content interface{}) (*RenderedEmail, error) {
+	wording := copyFor(locale)
+	page := emailPage{
+		Lang:  locale,
+		Title: title,
+		Brand: t.branding,
+		Labels: emailLabels{
+			Open:           wording.open,
+			Reason:         wording.reason(t.branding.Name),
+			ManageSettings: wording.manageSettings,
+		},
+		Content: content,
+	}
+
+	var body bytes.Buffer
+	if err := t.templates.ExecuteTemplate(&body, name, page); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", name, err)
+	}
+	page.Body = template.HTML(body.String())
+
+	var document bytes.Buffer
+	if err := t.templates.ExecuteTemplate(&document, "layout", page); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", name, err)
+	}
+
+	return &RenderedEmail{
+		HTML: document.String(),
+		Text: htmlToText(document.String()),
+	}, nil
+}
+
+// emailStyles returns the inline styles of the layout and partials
+func emailStyles(accent string) map[string]template.CSS {
+	return map[string]template.CSS{
+		"body":      "margin:0;padding:0;background-color:#f3f3f3;",
+		"wrapper":   "background-color:#f3f3f3;padding:24px 0;",
+		"container": "max-width:600px;width:100%;background-color:#ffffff;border-top:4px solid " + template.CSS(accent) + ";",
+		"header":    "padding:24px 32px 0 32px;",
+		"brand":     "font-family:'Titillium Web',Helvetica,Arial,sans-serif;font-size:24px;font-weight:bold;color:" + template.CSS(accent) + ";",
+		"logo":      "display:block;border:0;",
+		"content":   "padding:16px 32px 24px 32px;font-family:'Source Sans Pro',Helvetica,Arial,sans-serif;font-size:16px;line-height:1.5;color:#373a3c;",
+		"heading":   "margin:0 0 16px 0;font-size:20px;line-height:1.3;color:#373a3c;",
+		"text":      "margin:0 0 16px 0;",
+		"quote":     "margin:0 0 16px 0;padding:8px 16px;border-left:4px solid #dddddd;color:#55595c;",
+		"list":      "margin:0 0 16px 0;padding:0 0 0 20px;",
+		"item":      "margin:0 0 8px 0;",
+		"link":      "color:" + template.CSS(accent) + ";text-decoration:none;",
+		"buttonRow": "margin:8px 0 0 0;",
+		"button":    "display:inline-block;padding:10px 20px;border-radius:4px;background-color:" + template.CSS(accent) + ";color:#ffffff;font-weight:bold;text-decoration:none;",
+		"footer":    "padding:16px 32px 24px 32px;border-top:1px solid #eeeeee;font-family:Helvetica,Arial,sans-serif;font-size:12px;line-height:1.5;color:#999999;",
+		"muted":     "margin:0 0 8px 0;",
+		"mutedLink": "color:#999999;text-decoration:underline;",
+	}
+}
+
+// emailTemplates holds the layout, its partials and the content templates.
+// style and url are bound to the branding by NewEmailTemplates.
+var emailTemplates = template.Must(template.New("email").Funcs(template.FuncMap{
+	"style": func(name string) template.CSS { return "" },
+	"url":   func(link string) string { return link },
+	"button": func(link, label string) emailButton {
+		return emailButton{Link: link, Label: label}
+	},
+	"lines": func(text string) template.HTML {
+		return template.HTML(strings.ReplaceAll(html.EscapeString(text), "\n", "<br>"))
+	},
+}).Parse(`
+{{define "layout"}}<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+</head>
+<body style="{{style "body"}}">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="{{style "wrapper"}}"><tr><td align="center">
+<table role="presentation" width="600" cellpadding="0" cellspacing="0" style="{{style "container"}}">
+<tr><td style="{{style "header"}}">{{if .Brand.LogoURL}}<img src="{{.Brand.LogoURL}}" alt="{{.Brand.Name}}" height="32" style="{{style "logo"}}">{{else}}<span style="{{style "brand"}}">{{.Brand.Name}}</span>{{end}}</td></tr>
+<tr><td style="{{style "content"}}">
+<h1 style="{{style "heading"}}">{{.Title}}</h1>
+{{.Body}}
+</td></tr>
+<tr><td style="{{style "footer"}}">{{template "footer" .}}</td></tr>
+</table>
+</td></tr></table>
+</body>
+</html>
+{{end}}
+
+{{define "footer"}}
+<p style="{{style "muted"}}">{{.Labels.Reason}} <a href="{{url "/settings"}}" style="{{style "mutedLink"}}">{{.Labels.ManageSettings}}</a></p>
+{{with .Brand.FooterText}}<p style="{{style "muted"}}">{{.}}</p>{{end}}
+{{end}}
+
+{{define "button"}}
+<table role="presentation" cellpadding="0" cellspacing="0" style="{{style "buttonRow"}}"><tr><td>
+<a href="{{url .Link}}" style="{{style "button"}}">{{.Label}}</a>
+</td></tr></table>
+{{end}}
+
+{{define "notification"}}
+{{with .Content.Quote}}<blockquote style="{{style "quote"}}">{{lines .}}</blockquote>{{end}}
+{{with .Content.Link}}{{template "button" (button . $.Labels.Open)}}{{end}}
+{{end}}
+
+{{define "digest"}}
+<ul style="{{style "list"}}">
+{{range .Content.Lines}}<li style="{{style "item"}}">{{if .Link}}<a href="{{url .Link}}" style="{{style "link"}}">{{.Text}}</a>{{else}}{{.Text}}{{end}}</li>
+{{end}}</ul>
+{{end}}
+`))
+
+// Patterns htmlToText rewrites rendered emails with, in order
+var (
+	textSpacePattern = regexp.MustCompile(`\s+`)
+	textHeadPattern  = regexp.MustCompile(`(?i)<head\b.*?</head>`)
+	textLinkPattern  = regexp.MustCompile(`(?i)<a\b[^>]*\bhref="([^"]*)"[^>]*>(.*?)</a>`)
+	textItemPattern  = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	textBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>`)
+	textBlockPattern = regexp.MustCompile(`(?i)</(p|h1|h2|h3|td|ul|ol|blockquote|table)>`)
+	textTagPattern   = regexp.MustCompile(`<[^>]*>`)
+	textBlankPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText returns the plain text alternative of a rendered email. Links
+// become "text (url)", list items lines starting with "- ", and blocks
+// paragraphs. It only has to understand the markup of the templates above.
+func htmlToText(document string) string {
+	text := textSpacePattern.ReplaceAllString(document, " ")
+	text = textHeadPattern.ReplaceAllString(text, "")
+	text = textLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		parts := textLinkPattern.FindStringSubmatch(link)
+		href := parts[1]
+		label := strings.TrimSpace(textTagPattern.ReplaceAllString(parts[2], ""))
+		if label == "" || html.UnescapeString(label) == html.UnescapeString(href) {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	text = textItemPattern.ReplaceAllString(text, "\n- ")
+	text = textBreakPattern.ReplaceAllString(text, "\n")
+	text = textBlockPattern.ReplaceAllString(text, "\n\n")
+	text = textTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	text = textBlankPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text) + "\n"
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmailTemplates_RenderDigest(t *testing.T) {
+	templates := NewEmailTemplates(EmailBranding{Name: "Acme Blog", SiteURL: "https://blog.example.com/", AccentColor: "#123456", FooterText: "1 Main St"})
+
+	rendered, err := templates.Render("digest", "en", "Your daily digest", digestContent{Lines: []digestLine{
+		{Text: "bob started following you", Link: "/profile/bob"},
+		{Text: "Tom & Jerry <3 your article"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to render digest: %v", err)
+	}
+
+	for _, want := range []string{
+		`href="https://blog.example.com/profile/bob"`,
+		"color:#123456;",
+		"Tom &amp; Jerry &lt;3 your article",
+		"1 Main St",
+	} {
+		if !strings.Contains(rendered.HTML, want) {
+			t.Errorf("Expected the HTML to contain %q", want)
+		}
+	}
+
+	wantText := "Acme Blog\n\nYour daily digest\n\n" +
+		"- bob started following you (https://blog.example.com/profile/bob)\n" +
+		"- Tom & Jerry <3 your article\n\n" +
+		"You are receiving this email because of your notification settings on Acme Blog. Manage notification settings (https://blog.example.com/settings)\n\n" +
+		"1 Main St\n"
+	if rendered.Text != wantText {
+		t.Errorf("Unexpected plain text alternative:\n%s\nwant:\n%s", rendered.Text, wantText)
+	}
+}
+
+func TestEmailTemplates_RenderNotification(t *testing.T) {
+	templates := NewEmailTemplates(EmailBranding{})
+
+	rendered, err := templates.Render("notification", "ko", "bob님이 댓글을 남겼습니다", notificationContent{Quote: "Great post!\n<b>Thanks</b>", Link: "/article/hello#comment-1"})
+	if err != nil {
+		t.Fatalf("Failed to render notification: %v", err)
+	}
+
+	if !strings.Contains(rendered.HTML, "Great post!<br>&lt;b&gt;Thanks&lt;/b&gt;") {
+		t.Errorf("Expected the quote escaped with its line breaks kept, got %s", rendered.HTML)
+	}
+	if !strings.Contains(rendered.Text, "Great post!\n<b>Thanks</b>\n\n열기 (/article/hello#comment-1)") {
+		t.Errorf("Expected the quote and a Korean button in the plain text, got %q", rendered.Text)
+	}
+	if !strings.HasPrefix(rendered.Text, "Conduit\n") {
+		t.Errorf("Expected the default brand name, got %q", rendered.Text)
+	}
+}
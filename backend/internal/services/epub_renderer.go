@@ -0,0 +1,313 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// Book is a titled, ordered set of articles rendered as one document
+type Book struct {
+	// Identifier is a stable URN for the book, so readers recognise a newer
+	// export of the same series as the same book
+	Identifier  string
+	Title       string
+	Description string
+	Author      string
+	Language    string
+	Chapters    []*entities.Article
+	ModifiedAt  time.Time
+}
+
+// BookRenderer renders a book to a downloadable document. Renderers are
+// registered with the book exporter by format.
+type BookRenderer interface {
+	ContentType() string
+	Render(book *Book) ([]byte, error)
+}
+
+// epubChapter is a chapter document and its entry in the navigation
+type epubChapter struct {
+	ID      string
+	File    string
+	Article *entities.Article
+}
+
+// epubFile is a file in the EPUB container and the template that writes it
+type epubFile struct {
+	name     string
+	template *template.Template
+	data     interface{}
+}
+
+// epubCoverLine is a line of the cover title and its baseline
+type epubCoverLine struct {
+	Text string
+	Y    int
+}
+
+// epubCover is the text laid out on the generated cover image
+type epubCover struct {
+	Lines  []epubCoverLine
+	Author string
+}
+
+// epubRenderer renders books as EPUB 3 packages
+type epubRenderer struct{}
+
+// NewEPUBRenderer creates the built-in EPUB renderer. Each article becomes a
+// chapter in book order, after a generated cover with the title and author.
+// Like the print view, chapter bodies are the Markdown source shown as text.
+func NewEPUBRenderer() BookRenderer {
+	return &epubRenderer{}
+}
+
+// ContentType returns the EPUB media type
+func (e *epubRenderer) ContentType() string {
+	return "application/epub+zip"
+}
+
+// Render writes the book's package document, navigation, cover and chapters
+// into an EPUB container
+func (e *epubRenderer) Render(book *Book) ([]byte, error) {
+	if len(book.Chapters) == 0 {
+		return nil, fmt.Errorf("book has no chapters")
+	}
+
+	data := struct {
+		*Book
+		Modified string
+		Items    []epubChapter
+	}{
+		Book:     book,
+		Modified: book.ModifiedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if data.Language == "" {
+		data.Language = "en"
+	}
+	for i, article := range book.Chapters {
+		id := fmt.Sprintf("chapter-%03d", i+1)
+		data.Items = append(data.Items, epubChapter{ID: id, File: id + ".xhtml", Article: article})
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	// The mimetype entry must come first and be stored uncompressed
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mimetype.Write([]byte(e.ContentType())); err != nil {
+		return nil, err
+	}
+
+	files := []epubFile{
+		{"META-INF/container.xml", epubContainerTemplate, nil},
+		{"OEBPS/content.opf", epubPackageTemplate, data},
+		{"OEBPS/nav.xhtml", epubNavTemplate, data},
+		{"OEBPS/cover.svg", epubCoverImageTemplate, epubCoverText(book)},
+		{"OEBPS/cover.xhtml", epubCoverTemplate, data},
+		{"OEBPS/style.css", epubStyleTemplate, nil},
+	}
+	for _, item := range data.Items {
+		files = append(files, epubFile{"OEBPS/" + item.File, epubChapterTemplate, struct {
+			Language string
+			Article  *entities.Article
+		}{data.Language, item.Article}})
+	}
+
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.template.Execute(w, file.data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// epubCoverText wraps the title to fit the cover, under which the author goes
+func epubCoverText(book *Book) epubCover {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(book.Title) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > 18 {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	if len(lines) > 6 {
+		lines = append(lines[:5], lines[5]+"…")
+	}
+
+	cover := epubCover{Author: book.Author}
+	for i, text := range lines {
+		cover.Lines = append(cover.Lines, epubCoverLine{Text: text, Y: 240 + 60*i})
+	}
+	return cover
+}
+
+// xmlEscape escapes text for XML content and attributes, replacing characters
+// XML does not allow
+func xmlEscape(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// epubFuncs are the helpers available to the EPUB templates
+var epubFuncs = template.FuncMap{
+	"x": xmlEscape,
+}
+
+// epubTemplate parses one of the EPUB templates
+func epubTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(epubFuncs).Parse(text))
+}
+
+var epubContainerTemplate = epubTemplate("container", `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`)
+
+var epubPackageTemplate = epubTemplate("package", `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="{{x .Language}}">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">{{x .Identifier}}</dc:identifier>
+    <dc:title>{{x .Title}}</dc:title>
+    {{- if .Author}}
+    <dc:creator>{{x .Author}}</dc:creator>
+    {{- end}}
+    {{- if .Description}}
+    <dc:description>{{x .Description}}</dc:description>
+    {{- end}}
+    <dc:language>{{x .Language}}</dc:language>
+    <dc:publisher>Conduit</dc:publisher>
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+    <meta name="cover" content="cover-image"/>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="cover-image" href="cover.svg" media-type="image/svg+xml" properties="cover-image"/>
+    <item id="cover" href="cover.xhtml" media-type="application/xhtml+xml"/>
+    <item id="style" href="style.css" media-type="text/css"/>
+    {{- range .Items}}
+    <item id="{{.ID}}" href="{{.File}}" media-type="application/xhtml+xml"/>
+    {{- end}}
+  </manifest>
+  <spine>
+    <itemref idref="cover" linear="no"/>
+    <itemref idref="nav"/>
+    {{- range .Items}}
+    <itemref idref="{{.ID}}"/>
+    {{- end}}
+  </spine>
+</package>
+`)
+
+var epubNavTemplate = epubTemplate("nav", `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{x .Language}}" lang="{{x .Language}}">
+<head>
+<meta charset="utf-8"/>
+<title>{{x .Title}}</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+<nav epub:type="toc" id="toc">
+<h1>{{x .Title}}</h1>
+<ol>
+{{- range .Items}}
+<li><a href="{{.File}}">{{x .Article.Title}}</a></li>
+{{- end}}
+</ol>
+</nav>
+<nav epub:type="landmarks" hidden="hidden">
+<ol>
+<li><a epub:type="cover" href="cover.xhtml">Cover</a></li>
+<li><a epub:type="bodymatter" href="{{(index .Items 0).File}}">Start</a></li>
+</ol>
+</nav>
+</body>
+</html>
+`)
+
+var epubCoverImageTemplate = epubTemplate("cover-image", `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 600 800" width="600" height="800">
+<rect width="600" height="800" fill="#5cb85c"/>
+<rect x="40" y="40" width="520" height="720" fill="none" stroke="#ffffff" stroke-width="4"/>
+{{- range .Lines}}
+<text x="300" y="{{.Y}}" font-family="Georgia, serif" font-size="44" fill="#ffffff" text-anchor="middle">{{x .Text}}</text>
+{{- end}}
+{{- if .Author}}
+<text x="300" y="700" font-family="Helvetica, sans-serif" font-size="28" fill="#ffffff" text-anchor="middle">{{x .Author}}</text>
+{{- end}}
+</svg>
+`)
+
+var epubCoverTemplate = epubTemplate("cover", `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{x .Language}}" lang="{{x .Language}}">
+<head>
+<meta charset="utf-8"/>
+<title>{{x .Title}}</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body epub:type="cover" class="cover">
+<img src="cover.svg" alt="{{x .Title}}"/>
+</body>
+</html>
+`)
+
+var epubChapterTemplate = epubTemplate("chapter", `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{x .Language}}" lang="{{x .Language}}">
+<head>
+<meta charset="utf-8"/>
+<title>{{x .Article.Title}}</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+<section epub:type="chapter">
+<h1>{{x .Article.Title}}</h1>
+{{- if .Article.Description}}
+<p class="description">{{x .Article.Description}}</p>
+{{- end}}
+<p class="meta">{{.Article.CreatedAt.Format "January 2, 2006"}}{{range .Article.TagList}} · #{{x .}}{{end}}</p>
+<div class="body">{{x .Article.Body}}</div>
+</section>
+</body>
+</html>
+`)
+
+var epubStyleTemplate = epubTemplate("style", `body { font-family: Georgia, serif; line-height: 1.6; }
+h1 { line-height: 1.2; margin-bottom: 0.2em; }
+.description { font-size: 1.1em; color: #555; margin-top: 0; }
+.meta { font-family: sans-serif; font-size: 0.8em; color: #777; }
+.body { white-space: pre-wrap; overflow-wrap: break-word; }
+.cover { margin: 0; padding: 0; text-align: center; }
+.cover img { max-width: 100%; max-height: 100%; }
+`)
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestEPUBRenderer_Render(t *testing.T) {
+	book := &Book{
+		Identifier:  "urn:conduit:series:learning-go",
+		Title:       "Learning Go <the hard way>",
+		Description: "Notes & exercises",
+		Author:      "writer",
+		Language:    "ko",
+		Chapters: []*entities.Article{
+			{Title: "Part one", Body: "```\nif a < b && c {\n```", CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), TagList: []string{"go"}},
+			{Title: "Part two", Description: "한국어", Body: "Text\x00with a NUL"},
+		},
+		ModifiedAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+	}
+
+	epub, err := NewEPUBRenderer().Render(book)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(epub), int64(len(epub)))
+	if err != nil {
+		t.Fatalf("Expected a zip container: %v", err)
+	}
+	if first := archive.File[0]; first.Name != "mimetype" || first.Method != zip.Store {
+		t.Errorf("Expected an uncompressed mimetype entry first, got %s", first.Name)
+	}
+
+	files := map[string]string{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		files[file.Name] = string(content)
+
+		// Every document must be well-formed XML
+		if !strings.HasSuffix(file.Name, ".css") && file.Name != "mimetype" {
+			decoder := xml.NewDecoder(bytes.NewReader(content))
+			decoder.Strict = true
+			for {
+				if _, err := decoder.Token(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("%s is not well-formed: %v", file.Name, err)
+				}
+			}
+		}
+	}
+
+	if files["mimetype"] != "application/epub+zip" {
+		t.Errorf("Unexpected mimetype %q", files["mimetype"])
+	}
+	opf := files["OEBPS/content.opf"]
+	for _, want := range []string{
+		"<dc:title>Learning Go &lt;the hard way&gt;</dc:title>",
+		"<dc:creator>writer</dc:creator>",
+		"<dc:language>ko</dc:language>",
+		`<meta property="dcterms:modified">2024-05-02T10:00:00Z</meta>`,
+		`properties="cover-image"`,
+	} {
+		if !strings.Contains(opf, want) {
+			t.Errorf("Expected %s in the package document", want)
+		}
+	}
+	// Chapters follow the book's order
+	if one, two := strings.Index(opf, `<itemref idref="chapter-001"/>`), strings.Index(opf, `<itemref idref="chapter-002"/>`); one < 0 || two < one {
+		t.Errorf("Expected chapters in order in the spine:\n%s", opf)
+	}
+	if !strings.Contains(files["OEBPS/nav.xhtml"], `<a href="chapter-002.xhtml">Part two</a>`) {
+		t.Error("Expected the navigation to link the chapters")
+	}
+	if !strings.Contains(files["OEBPS/chapter-001.xhtml"], "if a &lt; b &amp;&amp; c {") {
+		t.Error("Expected the body to be escaped")
+	}
+
+	if _, err := NewEPUBRenderer().Render(&Book{Title: "Empty"}); err == nil {
+		t.Error("Expected a book without chapters to be rejected")
+	}
+}
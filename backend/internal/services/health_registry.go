@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Health statuses for components and the service as a whole
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+	// HealthStatusDraining is reported by readiness checks while the server
+	// finishes its work before shutting down
+	HealthStatusDraining = "draining"
+	// HealthStatusUnknown is reported for hours of history without any
+	// checks, such as before the server started
+	HealthStatusUnknown = "unknown"
+)
+
+// healthHistoryHours is how many hourly buckets of history are kept
+const healthHistoryHours = 24
+
+// ErrSubsystemUnavailable is returned by degraded subsystems instead of attempting work
+var ErrSubsystemUnavailable = errors.New("subsystem unavailable")
+
+// HealthCheck probes a subsystem and returns an error if it is unavailable
+type HealthCheck func(ctx context.Context) error
+
+// ComponentHealth represents the last known health of a subsystem
+type ComponentHealth struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+	// Since is when the subsystem entered its current status
+	Since time.Time `json:"since"`
+}
+
+// HealthBucket counts a subsystem's checks and reported outcomes in one hour
+type HealthBucket struct {
+	Start    time.Time `json:"start"`
+	Checks   int       `json:"checks"`
+	Failures int       `json:"failures"`
+	// Status is ok without failures, down when every check failed,
+	// degraded in between and unknown without checks
+	Status string `json:"status"`
+}
+
+// ComponentHistory is a subsystem's health over the last day, in hourly
+// buckets oldest first
+type ComponentHistory struct {
+	Name     string `json:"name"`
+	Critical bool   `json:"critical"`
+	// Uptime is the percentage of successful checks over the day
+	Uptime  float64        `json:"uptime"`
+	Buckets []HealthBucket `json:"buckets"`
+}
+
+// HealthRegistry tracks subsystem health. Components are marked down by failed
+// checks or reported failures and recover on the next successful check.
+type HealthRegistry interface {
+	Register(name string, critical bool, check HealthCheck)
+	ReportFailure(name string, err error)
+	ReportSuccess(name string)
+	Healthy(name string) bool
+	Components() []ComponentHealth
+	Status() string
+	CheckAll(ctx context.Context)
+	Run(ctx context.Context, interval time.Duration)
+	// StartedAt returns when the registry, and so the server, started
+	StartedAt() time.Time
+	// History returns every subsystem's health over the last day, sorted by
+	// name. It is kept in memory, so it starts over when the server restarts.
+	History() []ComponentHistory
+}
+
+// healthComponent holds a registered subsystem and its current state
+type healthComponent struct {
+	check   HealthCheck
+	health  ComponentHealth
+	buckets []HealthBucket
+}
+
+// healthRegistry implements HealthRegistry in memory
+type healthRegistry struct {
+	mu           sync.RWMutex
+	components   map[string]*healthComponent
+	checkTimeout time.Duration
+	now          func() time.Time
+	startedAt    time.Time
+}
+
+// NewHealthRegistry creates an empty health registry
+func NewHealthRegistry() HealthRegistry {
+	return &healthRegistry{
+		components:   make(map[string]*healthComponent),
+		checkTimeout: 5 * time.Second,
+		now:          time.Now,
+		startedAt:    time.Now().UTC(),
+	}
+}
+
+// Register adds a subsystem; it is assumed healthy until a check says otherwise
+func (r *healthRegistry) Register(name string, critical bool, check HealthCheck) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now().UTC()
+	r.components[name] = &healthComponent{
+		check: check,
+		health: ComponentHealth{
+			Name:      name,
+			Status:    HealthStatusOK,
+			Critical:  critical,
+			CheckedAt: now,
+			Since:     now,
+		},
+	}
+}
+
+// ReportFailure marks a subsystem down after a failed operation
+func (r *healthRegistry) ReportFailure(name string, err error) {
+	r.setStatus(name, err)
+}
+
+// ReportSuccess marks a subsystem healthy after a successful operation
+func (r *healthRegistry) ReportSuccess(name string) {
+	r.setStatus(name, nil)
+}
+
+// Healthy reports whether a subsystem is usable; unknown subsystems are considered healthy
+func (r *healthRegistry) Healthy(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	component, ok := r.components[name]
+	return !ok || component.health.Status == HealthStatusOK
+}
+
+// Components returns the health of every registered subsystem, sorted by name
+func (r *healthRegistry) Components() []ComponentHealth {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	components := make([]ComponentHealth, 0, len(r.components))
+	for _, component := range r.components {
+		components = append(components, component.health)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+	return components
+}
+
+// Status returns down if a critical subsystem is down, degraded if any other is down, otherwise ok
+func (r *healthRegistry) Status() string {
+	status := HealthStatusOK
+	for _, component := range r.Components() {
+		if component.Status == HealthStatusOK {
+			continue
+		}
+		if component.Critical {
+			return HealthStatusDown
+		}
+		status = HealthStatusDegraded
+	}
+	return status
+}
+
+// CheckAll runs every registered check once
+func (r *healthRegistry) CheckAll(ctx context.Context) {
+	r.mu.RLock()
+	checks := make(map[string]HealthCheck, len(r.components))
+	for name, component := range r.components {
+		if component.check != nil {
+			checks[name] = component.check
+		}
+	}
+	r.mu.RUnlock()
+
+	for name, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, r.checkTimeout)
+		err := check(checkCtx)
+		cancel()
+		r.setStatus(name, err)
+	}
+}
+
+// Run checks all subsystems on the given interval until ctx is cancelled.
+// A non-positive interval disables background checks.
+func (r *healthRegistry) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckAll(ctx)
+		}
+	}
+}
+
+// setStatus records the outcome for a subsystem and logs transitions
+func (r *healthRegistry) setStatus(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	component, ok := r.components[name]
+	if !ok {
+		return
+	}
+
+	previous := component.health.Status
+	now := r.now().UTC()
+	component.health.CheckedAt = now
+	if err != nil {
+		component.health.Status = HealthStatusDown
+		component.health.Error = err.Error()
+	} else {
+		component.health.Status = HealthStatusOK
+		component.health.Error = ""
+	}
+	component.record(now, err != nil)
+
+	if previous != component.health.Status {
+		component.health.Since = now
+		if err != nil {
+			log.Printf("⚠️  %s is unavailable: %v", name, err)
+		} else {
+			log.Printf("✅ %s has recovered", name)
+		}
+	}
+}
+
+// StartedAt returns when the registry was created
+func (r *healthRegistry) StartedAt() time.Time {
+	return r.startedAt
+}
+
+// History returns the hourly buckets of every subsystem for the last day,
+// filling hours without checks with unknown buckets
+func (r *healthRegistry) History() []ComponentHistory {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	first := r.now().UTC().Truncate(time.Hour).Add(-(healthHistoryHours - 1) * time.Hour)
+	histories := make([]ComponentHistory, 0, len(r.components))
+	for _, component := range r.components {
+		history := ComponentHistory{
+			Name:     component.health.Name,
+			Critical: component.health.Critical,
+			Uptime:   100,
+			Buckets:  make([]HealthBucket, healthHistoryHours),
+		}
+		for i := range history.Buckets {
+			history.Buckets[i] = HealthBucket{
+				Start:  first.Add(time.Duration(i) * time.Hour),
+				Status: HealthStatusUnknown,
+			}
+		}
+
+		checks, failures := 0, 0
+		for _, bucket := range component.buckets {
+			i := int(bucket.Start.Sub(first) / time.Hour)
+			if i < 0 || i >= healthHistoryHours {
+				continue
+			}
+			history.Buckets[i] = bucket
+			checks += bucket.Checks
+			failures += bucket.Failures
+		}
+		if checks > 0 {
+			history.Uptime = math.Round(float64(checks-failures)/float64(checks)*10000) / 100
+		}
+
+		histories = append(histories, history)
+	}
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].Name < histories[j].Name
+	})
+	return histories
+}
+
+// record counts a check in the bucket of its hour and drops buckets older
+// than the history
+func (c *healthComponent) record(at time.Time, failed bool) {
+	start := at.Truncate(time.Hour)
+	if n := len(c.buckets); n == 0 || !c.buckets[n-1].Start.Equal(start) {
+		c.buckets = append(c.buckets, HealthBucket{Start: start})
+	}
+
+	bucket := &c.buckets[len(c.buckets)-1]
+	bucket.Checks++
+	if failed {
+		bucket.Failures++
+	}
+	switch bucket.Failures {
+	case 0:
+		bucket.Status = HealthStatusOK
+	case bucket.Checks:
+		bucket.Status = HealthStatusDown
+	default:
+		bucket.Status = HealthStatusDegraded
+	}
+
+	cutoff := start.Add(-(healthHistoryHours - 1) * time.Hour)
+	for len(c.buckets) > 0 && c.buckets[0].Start.Before(cutoff) {
+		c.buckets = c.buckets[1:]
+	}
+}
+
+// TCPHealthCheck returns a check that succeeds when addr accepts TCP connections
+func TCPHealthCheck(addr string) HealthCheck {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("cannot reach %s: %w", addr, err)
+		}
+		return conn.Close()
+	}
+}
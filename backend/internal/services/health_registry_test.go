@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHealthRegistry_Status(t *testing.T) {
+	registry := NewHealthRegistry()
+	registry.Register("database", true, nil)
+	registry.Register("email", false, nil)
+
+	if status := registry.Status(); status != HealthStatusOK {
+		t.Fatalf("Expected ok, got %s", status)
+	}
+
+	registry.ReportFailure("email", errors.New("connection refused"))
+	if status := registry.Status(); status != HealthStatusDegraded {
+		t.Errorf("Expected degraded with non-critical component down, got %s", status)
+	}
+	if registry.Healthy("email") {
+		t.Error("Expected email to be unhealthy")
+	}
+
+	registry.ReportFailure("database", errors.New("disk I/O error"))
+	if status := registry.Status(); status != HealthStatusDown {
+		t.Errorf("Expected down with critical component down, got %s", status)
+	}
+
+	components := registry.Components()
+	if len(components) != 2 || components[0].Name != "database" || components[1].Error != "connection refused" {
+		t.Errorf("Unexpected components %+v", components)
+	}
+}
+
+func TestHealthRegistry_CheckAllRecovers(t *testing.T) {
+	registry := NewHealthRegistry()
+
+	var checkErr error
+	registry.Register("email", false, func(ctx context.Context) error {
+		return checkErr
+	})
+
+	checkErr = errors.New("timeout")
+	registry.CheckAll(context.Background())
+	if registry.Healthy("email") {
+		t.Fatal("Expected failed check to mark email down")
+	}
+
+	checkErr = nil
+	registry.CheckAll(context.Background())
+	if !registry.Healthy("email") {
+		t.Error("Expected successful check to recover email")
+	}
+}
+
+type failingEmailSender struct {
+	attempts int
+	err      error
+}
+
+func (s *failingEmailSender) Send(message *EmailMessage) error {
+	s.attempts++
+	return s.err
+}
+
+func TestMonitoredEmailSender(t *testing.T) {
+	registry := NewHealthRegistry()
+	registry.Register("email", false, nil)
+
+	sender := &failingEmailSender{err: errors.New("connection refused")}
+	monitored := NewMonitoredEmailSender(sender, registry, "email")
+
+	if err := monitored.Send(&EmailMessage{To: "a@example.com"}); err == nil {
+		t.Fatal("Expected delivery error")
+	}
+	if registry.Healthy("email") {
+		t.Fatal("Expected failed delivery to mark email down")
+	}
+
+	// While down, delivery is skipped without contacting the provider
+	err := monitored.Send(&EmailMessage{To: "a@example.com"})
+	if !errors.Is(err, ErrSubsystemUnavailable) {
+		t.Errorf("Expected ErrSubsystemUnavailable, got %v", err)
+	}
+	if sender.attempts != 1 {
+		t.Errorf("Expected 1 delivery attempt, got %d", sender.attempts)
+	}
+
+	registry.ReportSuccess("email")
+	sender.err = nil
+	if err := monitored.Send(&EmailMessage{To: "a@example.com"}); err != nil {
+		t.Errorf("Expected delivery after recovery, got %v", err)
+	}
+}
+
+func TestHealthRegistry_History(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	registry := &healthRegistry{
+		components: make(map[string]*healthComponent),
+		now:        func() time.Time { return now },
+	}
+	registry.Register("email", false, nil)
+
+	// Two hours ago: every send failed; an hour ago: one of two; now: none
+	now = now.Add(-2 * time.Hour)
+	registry.ReportFailure("email", errors.New("timeout"))
+	now = now.Add(time.Hour)
+	registry.ReportFailure("email", errors.New("timeout"))
+	registry.ReportSuccess("email")
+	now = now.Add(time.Hour)
+	registry.ReportSuccess("email")
+
+	history := registry.History()
+	if len(history) != 1 || len(history[0].Buckets) != healthHistoryHours {
+		t.Fatalf("Expected a day of buckets for email, got %+v", history)
+	}
+
+	buckets := history[0].Buckets
+	want := []string{HealthStatusUnknown, HealthStatusDown, HealthStatusDegraded, HealthStatusOK}
+	for i, status := range want {
+		bucket := buckets[healthHistoryHours-len(want)+i]
+		if bucket.Status != status {
+			t.Errorf("Expected bucket at %s to be %s, got %s", bucket.Start, status, bucket.Status)
+		}
+	}
+	if last := buckets[healthHistoryHours-1]; !last.Start.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the last bucket to start at the current hour, got %s", last.Start)
+	}
+	if history[0].Uptime != 50 {
+		t.Errorf("Expected 50%% uptime, got %v", history[0].Uptime)
+	}
+
+	// Buckets age out of the day
+	now = now.Add(healthHistoryHours * time.Hour)
+	registry.ReportSuccess("email")
+	if history := registry.History(); history[0].Uptime != 100 {
+		t.Errorf("Expected old failures to age out, got %v%% uptime", history[0].Uptime)
+	}
+}
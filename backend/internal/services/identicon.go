@@ -0,0 +1,49 @@
+package services
+
+import (
+	"crypto/sha256"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// identiconGrid is the number of cells per side of an identicon
+const identiconGrid = 5
+
+// identiconBackground is the fill behind the identicon pattern
+var identiconBackground = color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+// GenerateIdenticon renders a deterministic, horizontally symmetric 5x5
+// identicon for seed. The same seed always yields the same image; the
+// foreground colour and pattern are both derived from its SHA-256 hash.
+func GenerateIdenticon(seed string, size int) *image.Paletted {
+	sum := sha256.Sum256([]byte(seed))
+	foreground := color.NRGBA{
+		R: 0x30 + sum[0]%0xa0,
+		G: 0x30 + sum[1]%0xa0,
+		B: 0x30 + sum[2]%0xa0,
+		A: 0xff,
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{identiconBackground, foreground})
+
+	// Half a cell of padding on each side
+	cell := size / (identiconGrid + 1)
+	offset := (size - cell*identiconGrid) / 2
+
+	half := (identiconGrid + 1) / 2
+	for row := 0; row < identiconGrid; row++ {
+		for col := 0; col < half; col++ {
+			if sum[3+row*half+col]%2 != 0 {
+				continue
+			}
+
+			for _, c := range []int{col, identiconGrid - 1 - col} {
+				x, y := offset+c*cell, offset+row*cell
+				draw.Draw(img, image.Rect(x, y, x+cell, y+cell), &image.Uniform{C: foreground}, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	return img
+}
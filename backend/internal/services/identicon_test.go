@@ -0,0 +1,45 @@
+package services
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestGenerateIdenticon_Deterministic(t *testing.T) {
+	var first, second bytes.Buffer
+	if err := png.Encode(&first, GenerateIdenticon("jake", 120)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := png.Encode(&second, GenerateIdenticon("jake", 120)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Expected the same seed to produce the same image")
+	}
+
+	var other bytes.Buffer
+	if err := png.Encode(&other, GenerateIdenticon("jane", 120)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bytes.Equal(first.Bytes(), other.Bytes()) {
+		t.Error("Expected different seeds to produce different images")
+	}
+}
+
+func TestGenerateIdenticon_Symmetric(t *testing.T) {
+	img := GenerateIdenticon("jake", 120)
+
+	bounds := img.Bounds()
+	if bounds.Dx() != 120 || bounds.Dy() != 120 {
+		t.Fatalf("Expected 120x120 image, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx()/2; x++ {
+			if img.ColorIndexAt(x, y) != img.ColorIndexAt(bounds.Dx()-1-x, y) {
+				t.Fatalf("Expected pixel (%d,%d) to mirror its opposite", x, y)
+			}
+		}
+	}
+}
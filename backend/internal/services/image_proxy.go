@@ -0,0 +1,254 @@
+package services
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Image proxy errors
+var (
+	ErrImageHostNotAllowed = errors.New("image host is not allowed")
+	ErrImageTooLarge       = errors.New("image exceeds the size limit")
+	ErrNotAnImage          = errors.New("URL is not a supported image")
+	ErrImageFetchFailed    = errors.New("failed to fetch image")
+)
+
+// ImageProxyOptions configures which images may be proxied and their limits
+type ImageProxyOptions struct {
+	// AllowedHosts restricts proxying to these hosts and their subdomains; empty allows any public host
+	AllowedHosts []string
+	// DeniedHosts are never proxied, even when allowed
+	DeniedHosts []string
+	// MaxBytes caps the size of a fetched image
+	MaxBytes int64
+	// MaxPixels caps width*height to refuse decompression bombs
+	MaxPixels int
+	// MaxWidth caps the width images may be resized to
+	MaxWidth int
+	// CacheBytes is the total size of cached images
+	CacheBytes int64
+	// Timeout bounds each upstream fetch
+	Timeout time.Duration
+}
+
+// ProxiedImage is a validated image ready to serve
+type ProxiedImage struct {
+	Body        []byte
+	ContentType string
+}
+
+// ImageProxy fetches, validates, resizes and caches external images
+type ImageProxy interface {
+	// Fetch returns the image at rawURL, scaled down to width if it is
+	// wider; a width of zero keeps the original size
+	Fetch(ctx context.Context, rawURL string, width int) (*ProxiedImage, error)
+}
+
+// imageCacheEntry is an image held in the LRU cache
+type imageCacheEntry struct {
+	key   string
+	image *ProxiedImage
+}
+
+// imageProxy implements ImageProxy with an in-memory LRU cache
+type imageProxy struct {
+	options ImageProxyOptions
+	client  *http.Client
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	cacheBytes int64
+}
+
+// NewImageProxy creates an image proxy. Only PNG, JPEG and GIF images are
+// served; the content type is taken from the decoded image, never upstream.
+func NewImageProxy(options ImageProxyOptions) ImageProxy {
+	return &imageProxy{
+		options: options,
+		client:  NewSafeHTTPClient(options.Timeout),
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Fetch returns the proxied image, from cache when possible
+func (p *imageProxy) Fetch(ctx context.Context, rawURL string, width int) (*ProxiedImage, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid URL", ErrNotAnImage)
+	}
+	if err := ValidateOutboundURL(target); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotAnImage, err)
+	}
+
+	host := target.Hostname()
+	if HostMatches(host, p.options.DeniedHosts) || (len(p.options.AllowedHosts) > 0 && !HostMatches(host, p.options.AllowedHosts)) {
+		return nil, ErrImageHostNotAllowed
+	}
+
+	if width > p.options.MaxWidth {
+		width = p.options.MaxWidth
+	}
+
+	key := strconv.Itoa(width) + " " + target.String()
+	if cached := p.cached(key); cached != nil {
+		return cached, nil
+	}
+
+	_, body, err := fetchLimited(ctx, p.client, target.String(), "image/png,image/jpeg,image/gif", p.options.MaxBytes, ErrImageTooLarge)
+	if err != nil {
+		if errors.Is(err, ErrImageTooLarge) {
+			return nil, err
+		}
+		if errors.Is(err, ErrForbiddenAddress) {
+			return nil, ErrImageHostNotAllowed
+		}
+		return nil, fmt.Errorf("%w: %v", ErrImageFetchFailed, err)
+	}
+
+	proxied, err := p.process(body, width)
+	if err != nil {
+		return nil, err
+	}
+
+	p.store(key, proxied)
+	return proxied, nil
+}
+
+// process validates the image and resizes it when it is wider than width
+func (p *imageProxy) process(body []byte, width int) (*ProxiedImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, ErrNotAnImage
+	}
+	if config.Width*config.Height > p.options.MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	contentType := "image/" + format
+	if width <= 0 || width >= config.Width {
+		// Serve the original bytes so animated GIFs keep their frames
+		return &ProxiedImage{Body: body, ContentType: contentType}, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, ErrNotAnImage
+	}
+	height := config.Height * width / config.Width
+	if height < 1 {
+		height = 1
+	}
+	resized := resizeImage(src, width, height)
+
+	var out bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&out, resized, &jpeg.Options{Quality: 85})
+	case "gif":
+		contentType = "image/png"
+		err = png.Encode(&out, resized)
+	case "png":
+		err = png.Encode(&out, resized)
+	default:
+		return nil, ErrNotAnImage
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	return &ProxiedImage{Body: out.Bytes(), ContentType: contentType}, nil
+}
+
+// resizeImage scales src to width x height by averaging the source pixels
+// each destination pixel covers
+func resizeImage(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := bounds.Min.Y + (y+1)*srcHeight/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := bounds.Min.X + (x+1)*srcWidth/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pixel := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+					r += uint64(pixel.R)
+					g += uint64(pixel.G)
+					b += uint64(pixel.B)
+					a += uint64(pixel.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+
+	return dst
+}
+
+// cached returns a cached image and marks it recently used
+func (p *imageProxy) cached(key string) *ProxiedImage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	element, ok := p.entries[key]
+	if !ok {
+		return nil
+	}
+	p.lru.MoveToFront(element)
+	return element.Value.(*imageCacheEntry).image
+}
+
+// store caches an image, evicting the least recently used images to stay within CacheBytes
+func (p *imageProxy) store(key string, proxied *ProxiedImage) {
+	size := int64(len(proxied.Body))
+	if size > p.options.CacheBytes {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.entries[key]; ok {
+		return
+	}
+
+	p.entries[key] = p.lru.PushFront(&imageCacheEntry{key: key, image: proxied})
+	p.cacheBytes += size
+
+	for p.cacheBytes > p.options.CacheBytes {
+		oldest := p.lru.Back()
+		entry := oldest.Value.(*imageCacheEntry)
+		p.lru.Remove(oldest)
+		delete(p.entries, entry.key)
+		p.cacheBytes -= int64(len(entry.image.Body))
+	}
+}
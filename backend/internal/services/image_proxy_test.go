@@ -0,0 +1,134 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// newTestImageProxy serves body from a local server; the proxy's client is
+// swapped for one that may reach it, since the safe client refuses loopback
+func newTestImageProxy(t *testing.T, options ImageProxyOptions, body []byte) (ImageProxy, string, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	if options.MaxBytes == 0 {
+		options.MaxBytes = 1 << 20
+	}
+	if options.MaxPixels == 0 {
+		options.MaxPixels = 1 << 20
+	}
+	if options.MaxWidth == 0 {
+		options.MaxWidth = 1000
+	}
+	if options.CacheBytes == 0 {
+		options.CacheBytes = 1 << 20
+	}
+	options.Timeout = time.Second
+
+	proxy := NewImageProxy(options)
+	proxy.(*imageProxy).client = server.Client()
+	return proxy, server.URL + "/cat.png", &requests
+}
+
+func TestImageProxy_ServesAndCaches(t *testing.T) {
+	original := testPNG(t, 40, 20)
+	proxy, url, requests := newTestImageProxy(t, ImageProxyOptions{}, original)
+
+	for i := 0; i < 2; i++ {
+		proxied, err := proxy.Fetch(context.Background(), url, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if proxied.ContentType != "image/png" || !bytes.Equal(proxied.Body, original) {
+			t.Errorf("Expected the original PNG, got %s (%d bytes)", proxied.ContentType, len(proxied.Body))
+		}
+	}
+
+	if *requests != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", *requests)
+	}
+}
+
+func TestImageProxy_Resizes(t *testing.T) {
+	proxy, url, _ := newTestImageProxy(t, ImageProxyOptions{MaxWidth: 30}, testPNG(t, 40, 20))
+
+	proxied, err := proxy.Fetch(context.Background(), url, 100)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	config, err := png.DecodeConfig(bytes.NewReader(proxied.Body))
+	if err != nil {
+		t.Fatalf("Expected a PNG, got %v", err)
+	}
+	if config.Width != 30 || config.Height != 15 {
+		t.Errorf("Expected width capped to 30x15, got %dx%d", config.Width, config.Height)
+	}
+}
+
+func TestImageProxy_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ImageProxyOptions
+		body    []byte
+		url     string
+		want    error
+	}{
+		{"not an image", ImageProxyOptions{}, []byte("<svg onload=alert(1)>"), "", ErrNotAnImage},
+		{"too many bytes", ImageProxyOptions{MaxBytes: 10}, testPNG(t, 40, 20), "", ErrImageTooLarge},
+		{"too many pixels", ImageProxyOptions{MaxPixels: 100}, testPNG(t, 40, 20), "", ErrImageTooLarge},
+		{"denied host", ImageProxyOptions{DeniedHosts: []string{"127.0.0.1"}}, testPNG(t, 1, 1), "", ErrImageHostNotAllowed},
+		{"host not allowed", ImageProxyOptions{AllowedHosts: []string{"images.example"}}, testPNG(t, 1, 1), "", ErrImageHostNotAllowed},
+		{"unsupported scheme", ImageProxyOptions{}, testPNG(t, 1, 1), "file:///etc/passwd", ErrNotAnImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy, url, _ := newTestImageProxy(t, tt.options, tt.body)
+			if tt.url != "" {
+				url = tt.url
+			}
+
+			if _, err := proxy.Fetch(context.Background(), url, 0); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestImageProxy_RefusesInternalAddresses(t *testing.T) {
+	proxy := NewImageProxy(ImageProxyOptions{MaxBytes: 1 << 20, MaxPixels: 1 << 20, CacheBytes: 1 << 20, Timeout: time.Second})
+
+	if _, err := proxy.Fetch(context.Background(), "http://127.0.0.1:1/cat.png", 0); !errors.Is(err, ErrImageHostNotAllowed) {
+		t.Errorf("Expected ErrImageHostNotAllowed, got %v", err)
+	}
+}
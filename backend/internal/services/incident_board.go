@@ -0,0 +1,87 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ActiveIncidentSource lists the incidents that have not been resolved
+type ActiveIncidentSource interface {
+	ListActive() ([]entities.Incident, error)
+}
+
+// IncidentBoard caches the active incidents, since the notice header reads
+// them on every request. The cache is refreshed after ttl, so changes made
+// through another instance show up within it, and dropped on Invalidate.
+type IncidentBoard struct {
+	source ActiveIncidentSource
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	active   []entities.Incident
+	loadedAt time.Time
+}
+
+// NewIncidentBoard creates a board reading active incidents from source
+func NewIncidentBoard(source ActiveIncidentSource, ttl time.Duration) *IncidentBoard {
+	return &IncidentBoard{
+		source: source,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Active returns the unresolved incidents, most recently started first
+func (b *IncidentBoard) Active() ([]entities.Incident, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if b.active != nil && now.Sub(b.loadedAt) < b.ttl {
+		return b.active, nil
+	}
+
+	active, err := b.source.ListActive()
+	if err != nil {
+		return nil, err
+	}
+	b.active = active
+	b.loadedAt = now
+	return active, nil
+}
+
+// Invalidate makes the next read load the incidents again
+func (b *IncidentBoard) Invalidate() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.active = nil
+}
+
+// Notice summarizes the active incidents for the notice header: the highest
+// severity among incidents, else "maintenance" while only maintenance is
+// ongoing, else "" when there is nothing to report
+func (b *IncidentBoard) Notice() string {
+	active, err := b.Active()
+	if err != nil {
+		// The header is informational; the status page reports the error
+		return ""
+	}
+
+	notice := ""
+	for _, incident := range active {
+		if incident.Kind == entities.IncidentKindMaintenance {
+			if notice == "" {
+				notice = entities.IncidentKindMaintenance
+			}
+			continue
+		}
+		if entities.IncidentSeverityRank(incident.Severity) > entities.IncidentSeverityRank(notice) {
+			notice = incident.Severity
+		}
+	}
+	return notice
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+type fakeIncidentSource struct {
+	incidents []entities.Incident
+	loads     int
+}
+
+func (s *fakeIncidentSource) ListActive() ([]entities.Incident, error) {
+	s.loads++
+	return s.incidents, nil
+}
+
+func TestIncidentBoard_Notice(t *testing.T) {
+	source := &fakeIncidentSource{incidents: []entities.Incident{}}
+	board := NewIncidentBoard(source, time.Minute)
+
+	if notice := board.Notice(); notice != "" {
+		t.Errorf("Expected no notice without incidents, got %q", notice)
+	}
+
+	source.incidents = []entities.Incident{
+		{Kind: entities.IncidentKindMaintenance, Severity: entities.IncidentSeverityCritical},
+	}
+	if notice := board.Notice(); notice != "" || source.loads != 1 {
+		t.Errorf("Expected the cached notice until invalidated, got %q after %d loads", notice, source.loads)
+	}
+
+	board.Invalidate()
+	if notice := board.Notice(); notice != entities.IncidentKindMaintenance {
+		t.Errorf("Expected maintenance, got %q", notice)
+	}
+
+	source.incidents = append(source.incidents,
+		entities.Incident{Kind: entities.IncidentKindIncident, Severity: entities.IncidentSeverityMinor},
+		entities.Incident{Kind: entities.IncidentKindIncident, Severity: entities.IncidentSeverityMajor},
+	)
+	board.Invalidate()
+	if notice := board.Notice(); notice != entities.IncidentSeverityMajor {
+		t.Errorf("Expected the highest incident severity, got %q", notice)
+	}
+}
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// keyReloadInterval limits how often an unknown kid makes the key ring reload
+// keys that another instance may have rotated in
+const keyReloadInterval = time.Minute
+
+// JWTKeyRing holds the keys access tokens are signed and verified with
+type JWTKeyRing interface {
+	// SigningKey returns the current key's ID, which is empty for a shared
+	// secret, and the method and key to sign with
+	SigningKey() (string, jwt.SigningMethod, interface{}, error)
+	// VerificationKey returns the key a token was signed with, found by its
+	// kid header; it is a jwt.Keyfunc
+	VerificationKey(token *jwt.Token) (interface{}, error)
+	// Algorithms lists the alg headers tokens may carry
+	Algorithms() []string
+	// PublicKeys returns the keys other services can validate tokens with
+	PublicKeys() entities.JSONWebKeySet
+	// Rotate replaces the current key once it is due and drops retired keys
+	// whose tokens have all expired, reporting whether it rotated
+	Rotate() (bool, error)
+}
+
+// hmacKeyRing implements JWTKeyRing with a single shared secret
+type hmacKeyRing struct {
+	secret []byte
+}
+
+// NewHMACKeyRing creates a key ring that signs and verifies HS256 tokens with
+// the shared secret. It publishes no keys and never rotates.
+func NewHMACKeyRing(secret string) JWTKeyRing {
+	return &hmacKeyRing{secret: []byte(secret)}
+}
+
+// SigningKey returns the shared secret, without a key ID
+func (r *hmacKeyRing) SigningKey() (string, jwt.SigningMethod, interface{}, error) {
+	return "", jwt.SigningMethodHS256, r.secret, nil
+}
+
+// VerificationKey returns the shared secret for HS256 tokens
+func (r *hmacKeyRing) VerificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method != jwt.SigningMethodHS256 {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return r.secret, nil
+}
+
+// Algorithms accepts HS256 only
+func (r *hmacKeyRing) Algorithms() []string {
+	return []string{jwt.SigningMethodHS256.Alg()}
+}
+
+// PublicKeys is empty, as a shared secret must never be published
+func (r *hmacKeyRing) PublicKeys() entities.JSONWebKeySet {
+	return entities.JSONWebKeySet{Keys: []entities.JSONWebKey{}}
+}
+
+// Rotate does nothing; the secret is changed through configuration
+func (r *hmacKeyRing) Rotate() (bool, error) {
+	return false, nil
+}
+
+// ringKey is a stored signing key, parsed
+type ringKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	retired bool
+}
+
+// rotatingKeyRing implements JWTKeyRing with asymmetric keys kept in the
+// database, so every instance signs with the same current key
+type rotatingKeyRing struct {
+	repo      repositories.SigningKeyRepository
+	cipher    SecretCipher
+	algorithm string
+	rotation  time.Duration
+	retention time.Duration
+
+	mu       sync.RWMutex
+	current  *ringKey
+	keys     map[string]*ringKey
+	loadedAt time.Time
+}
+
+// NewRotatingKeyRing creates a key ring that signs with RS256 or EdDSA keys
+// and replaces the current key once it is older than rotation; zero keeps it
+// until the algorithm changes. Retired keys verify tokens until every token
+// they can have signed has expired under the policy. A first key is created
+// if there is none. Private keys are stored encrypted with cipher.
+func NewRotatingKeyRing(repo repositories.SigningKeyRepository, cipher SecretCipher, algorithm string, rotation time.Duration, policy TokenPolicy) (JWTKeyRing, error) {
+	if signingMethod(algorithm) == nil {
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+	if cipher == nil {
+		return nil, fmt.Errorf("a key encryption key is required for %s signing keys", algorithm)
+	}
+
+	r := &rotatingKeyRing{
+		repo:      repo,
+		cipher:    cipher,
+		algorithm: algorithm,
+		rotation:  rotation,
+		retention: policy.Lifetime(TokenAccess) + policy.ClockSkew(),
+		keys:      make(map[string]*ringKey),
+	}
+	if _, err := r.Rotate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SigningKey returns the current key
+func (r *rotatingKeyRing) SigningKey() (string, jwt.SigningMethod, interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return "", nil, nil, fmt.Errorf("no current signing key")
+	}
+	return r.current.id, r.current.method, r.current.private, nil
+}
+
+// VerificationKey returns the public key named by the token's kid. An unknown
+// kid reloads the keys, at most once per keyReloadInterval, in case another
+// instance has rotated.
+func (r *rotatingKeyRing) VerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("token has no kid header")
+	}
+
+	key := r.lookup(kid)
+	if key == nil && r.stale() {
+		if err := r.reload(); err != nil {
+			return nil, err
+		}
+		key = r.lookup(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.private.Public(), nil
+}
+
+// Algorithms accepts both asymmetric algorithms, so tokens signed before the
+// configured algorithm changed stay valid until they expire
+func (r *rotatingKeyRing) Algorithms() []string {
+	return []string{entities.JWTAlgorithmRS256, entities.JWTAlgorithmEdDSA}
+}
+
+// PublicKeys returns the current and retired keys, by key ID
+func (r *rotatingKeyRing) PublicKeys() entities.JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := entities.JSONWebKeySet{Keys: make([]entities.JSONWebKey, 0, len(r.keys))}
+	for _, key := range r.keys {
+		set.Keys = append(set.Keys, publicJWK(key))
+	}
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].KeyID < set.Keys[j].KeyID
+	})
+	return set
+}
+
+// Rotate creates a new current key when there is none for the configured
+// algorithm or the current one is older than the rotation period, retiring
+// the others, then deletes keys retired longer ago than the retention. Keys
+// stored in plaintext or under an older key encryption key are re-encrypted.
+func (r *rotatingKeyRing) Rotate() (bool, error) {
+	now := time.Now().UTC()
+
+	keys, err := r.repo.List()
+	if err != nil {
+		return false, err
+	}
+	if err := r.reseal(keys); err != nil {
+		return false, err
+	}
+
+	var current *entities.SigningKey
+	for i := range keys {
+		if keys[i].RetiredAt == nil && keys[i].Algorithm == r.algorithm {
+			current = &keys[i]
+		}
+	}
+
+	rotated := false
+	if current == nil || (r.rotation > 0 && !now.Before(current.CreatedAt.Add(r.rotation))) {
+		key, err := generateSigningKey(r.algorithm, r.cipher, now)
+		if err != nil {
+			return false, err
+		}
+		if err := r.repo.Rotate(key); err != nil {
+			return false, err
+		}
+		rotated = true
+	}
+
+	if _, err := r.repo.DeleteRetiredBefore(now.Add(-r.retention)); err != nil {
+		return rotated, err
+	}
+	return rotated, r.reload()
+}
+
+// reload replaces the keys held in memory with the stored ones
+func (r *rotatingKeyRing) reload() error {
+	stored, err := r.repo.List()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]*ringKey, len(stored))
+	var current *ringKey
+	for _, s := range stored {
+		key, err := parseSigningKey(&s, r.cipher)
+		if err != nil {
+			return err
+		}
+		keys[key.id] = key
+		if !key.retired && s.Algorithm == r.algorithm {
+			current = key
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = keys
+	r.current = current
+	r.loadedAt = time.Now()
+	return nil
+}
+
+// reseal encrypts the stored keys that are not sealed with the current key
+// encryption key
+func (r *rotatingKeyRing) reseal(keys []entities.SigningKey) error {
+	for _, key := range keys {
+		if r.cipher.Current(string(key.PrivateKey)) {
+			continue
+		}
+		der, err := r.cipher.Decrypt(string(key.PrivateKey))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt signing key %q: %w", key.ID, err)
+		}
+		sealed, err := r.cipher.Encrypt(der)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt signing key %q: %w", key.ID, err)
+		}
+		if err := r.repo.SetPrivateKey(key.ID, []byte(sealed)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup returns the loaded key with the ID, or nil
+func (r *rotatingKeyRing) lookup(kid string) *ringKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.keys[kid]
+}
+
+// stale reports whether the keys may be reloaded again
+func (r *rotatingKeyRing) stale() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return time.Since(r.loadedAt) >= keyReloadInterval
+}
+
+// signingMethod returns the JWT signing method of an asymmetric algorithm, or
+// nil if it is not one
+func signingMethod(algorithm string) jwt.SigningMethod {
+	switch algorithm {
+	case entities.JWTAlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case entities.JWTAlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return nil
+	}
+}
+
+// generateSigningKey creates a 2048-bit RSA or an Ed25519 key with a random
+// ID, encrypted with cipher
+func generateSigningKey(algorithm string, cipher SecretCipher, now time.Time) (*entities.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case entities.JWTAlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case entities.JWTAlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signing key: %w", err)
+	}
+	sealed, err := cipher.Encrypt(string(der))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate key ID: %w", err)
+	}
+
+	return &entities.SigningKey{
+		ID:         hex.EncodeToString(id),
+		Algorithm:  algorithm,
+		PrivateKey: []byte(sealed),
+		CreatedAt:  now,
+	}, nil
+}
+
+// parseSigningKey decrypts and decodes a stored key and checks it matches its
+// algorithm
+func parseSigningKey(stored *entities.SigningKey, cipher SecretCipher) (*ringKey, error) {
+	method := signingMethod(stored.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("signing key %q has unsupported algorithm %s", stored.ID, stored.Algorithm)
+	}
+
+	der, err := cipher.Decrypt(string(stored.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key %q: %w", stored.ID, err)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey([]byte(der))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %q: %w", stored.ID, err)
+	}
+
+	var private crypto.Signer
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if method == jwt.SigningMethodRS256 {
+			private = key
+		}
+	case ed25519.PrivateKey:
+		if method == jwt.SigningMethodEdDSA {
+			private = key
+		}
+	}
+	if private == nil {
+		return nil, fmt.Errorf("signing key %q does not match algorithm %s", stored.ID, stored.Algorithm)
+	}
+
+	return &ringKey{
+		id:      stored.ID,
+		method:  method,
+		private: private,
+		retired: stored.RetiredAt != nil,
+	}, nil
+}
+
+// publicJWK describes a key's public half as a JSON Web Key
+func publicJWK(key *ringKey) entities.JSONWebKey {
+	jwk := entities.JSONWebKey{
+		Use:       "sig",
+		Algorithm: key.method.Alg(),
+		KeyID:     key.id,
+	}
+	switch public := key.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
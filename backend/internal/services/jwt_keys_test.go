@@ -0,0 +1,234 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+type fakeSigningKeyRepo struct {
+	keys []entities.SigningKey
+}
+
+func (r *fakeSigningKeyRepo) List() ([]entities.SigningKey, error) {
+	return append([]entities.SigningKey(nil), r.keys...), nil
+}
+
+func (r *fakeSigningKeyRepo) Rotate(key *entities.SigningKey) error {
+	for i := range r.keys {
+		if r.keys[i].RetiredAt == nil {
+			retiredAt := key.CreatedAt
+			r.keys[i].RetiredAt = &retiredAt
+		}
+	}
+	r.keys = append(r.keys, *key)
+	return nil
+}
+
+func (r *fakeSigningKeyRepo) SetPrivateKey(id string, privateKey []byte) error {
+	for i := range r.keys {
+		if r.keys[i].ID == id {
+			r.keys[i].PrivateKey = privateKey
+			return nil
+		}
+	}
+	return fmt.Errorf("signing key not found")
+}
+
+func (r *fakeSigningKeyRepo) DeleteRetiredBefore(cutoff time.Time) (int64, error) {
+	kept := r.keys[:0]
+	for _, key := range r.keys {
+		if key.RetiredAt == nil || !key.RetiredAt.Before(cutoff) {
+			kept = append(kept, key)
+		}
+	}
+	deleted := int64(len(r.keys) - len(kept))
+	r.keys = kept
+	return deleted, nil
+}
+
+func testSecretCipher(t *testing.T, ids ...string) SecretCipher {
+	cipher, err := NewSecretCipher(testPIIKeys(ids...))
+	if err != nil {
+		t.Fatalf("NewSecretCipher failed: %v", err)
+	}
+	return cipher
+}
+
+func TestRotatingKeyRing_SignsAndVerifies(t *testing.T) {
+	policy := NewTokenPolicy(TokenLifetimes{Access: time.Hour}, 0)
+	user := &entities.User{ID: 1, Username: "testuser"}
+
+	for _, tt := range []struct {
+		algorithm string
+		keyType   string
+	}{
+		{entities.JWTAlgorithmRS256, "RSA"},
+		{entities.JWTAlgorithmEdDSA, "OKP"},
+	} {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			repo := &fakeSigningKeyRepo{}
+			keys, err := NewRotatingKeyRing(repo, testSecretCipher(t, "k1"), tt.algorithm, 0, policy)
+			if err != nil {
+				t.Fatalf("Failed to create key ring: %v", err)
+			}
+			service := NewJWTServiceWithKeys(keys, policy, "conduit-api", "conduit")
+
+			tokenString, err := service.GenerateToken(user)
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+			token, err := service.ParseToken(tokenString)
+			if err != nil {
+				t.Fatalf("Expected token to verify, got %v", err)
+			}
+			if token.Method.Alg() != tt.algorithm || token.Header["kid"] != repo.keys[0].ID {
+				t.Errorf("Expected %s token naming key %s, got %v", tt.algorithm, repo.keys[0].ID, token.Header)
+			}
+
+			published := keys.PublicKeys()
+			if len(published.Keys) != 1 || published.Keys[0].KeyType != tt.keyType || published.Keys[0].KeyID != repo.keys[0].ID {
+				t.Errorf("Expected the key to be published as %s, got %+v", tt.keyType, published.Keys)
+			}
+
+			// A token signed with the shared secret is not accepted
+			hmac, _ := NewJWTService("test-secret-key", policy, "conduit-api", "conduit").GenerateToken(user)
+			if _, err := service.ValidateToken(hmac); err == nil {
+				t.Error("Expected an HS256 token to be rejected")
+			}
+		})
+	}
+}
+
+func TestRotatingKeyRing_Rotation(t *testing.T) {
+	policy := NewTokenPolicy(TokenLifetimes{Access: time.Hour}, time.Minute)
+	user := &entities.User{ID: 1, Username: "testuser"}
+
+	repo := &fakeSigningKeyRepo{}
+	keys, err := NewRotatingKeyRing(repo, testSecretCipher(t, "k1"), entities.JWTAlgorithmEdDSA, 24*time.Hour, policy)
+	if err != nil {
+		t.Fatalf("Failed to create key ring: %v", err)
+	}
+	service := NewJWTServiceWithKeys(keys, policy, "conduit-api", "conduit")
+	oldToken, _ := service.GenerateToken(user)
+
+	if rotated, err := keys.Rotate(); err != nil || rotated {
+		t.Fatalf("Expected a fresh key not to rotate, got %v (err: %v)", rotated, err)
+	}
+
+	// Once the key is a day old it is replaced, but still verifies
+	repo.keys[0].CreatedAt = repo.keys[0].CreatedAt.Add(-25 * time.Hour)
+	if rotated, err := keys.Rotate(); err != nil || !rotated {
+		t.Fatalf("Expected an expired key to rotate, got %v (err: %v)", rotated, err)
+	}
+	newToken, _ := service.GenerateToken(user)
+	parsed, err := service.ParseToken(newToken)
+	if err != nil || parsed.Header["kid"] != repo.keys[1].ID {
+		t.Fatalf("Expected new tokens to be signed with the new key, got %v (err: %v)", parsed, err)
+	}
+	if _, err := service.ValidateToken(oldToken); err != nil {
+		t.Errorf("Expected a token signed with the retired key to verify, got %v", err)
+	}
+	if published := keys.PublicKeys(); len(published.Keys) != 2 {
+		t.Errorf("Expected both keys to be published, got %d", len(published.Keys))
+	}
+
+	// The retired key goes once every token it signed has expired
+	retiredAt := time.Now().Add(-time.Hour - 2*time.Minute)
+	repo.keys[0].RetiredAt = &retiredAt
+	keys.Rotate()
+	if published := keys.PublicKeys(); len(published.Keys) != 1 || published.Keys[0].KeyID != repo.keys[0].ID {
+		t.Errorf("Expected only the new key to be published, got %+v", published.Keys)
+	}
+	if _, err := service.ValidateToken(oldToken); err == nil || !strings.Contains(err.Error(), "unknown signing key") {
+		t.Errorf("Expected a token naming a deleted key to be rejected, got %v", err)
+	}
+}
+
+func TestRotatingKeyRing_RejectsMismatchedAlgorithm(t *testing.T) {
+	policy := NewTokenPolicy(TokenLifetimes{Access: time.Hour}, 0)
+	keys, err := NewRotatingKeyRing(&fakeSigningKeyRepo{}, testSecretCipher(t, "k1"), entities.JWTAlgorithmRS256, 0, policy)
+	if err != nil {
+		t.Fatalf("Failed to create key ring: %v", err)
+	}
+	kid, _, _, _ := keys.SigningKey()
+
+	// A token claiming the RSA key's ID but another algorithm is refused
+	token := jwt.New(jwt.SigningMethodEdDSA)
+	token.Header["kid"] = kid
+	if _, err := keys.VerificationKey(token); err == nil {
+		t.Error("Expected a mismatched algorithm to be rejected")
+	}
+
+	if _, err := NewRotatingKeyRing(&fakeSigningKeyRepo{}, testSecretCipher(t, "k1"), entities.JWTAlgorithmHS256, 0, policy); err == nil {
+		t.Error("Expected HS256 to be refused for key pairs")
+	}
+}
+
+func TestRotatingKeyRing_EncryptsStoredKeys(t *testing.T) {
+	policy := NewTokenPolicy(TokenLifetimes{Access: time.Hour}, 0)
+	user := &entities.User{ID: 1, Username: "testuser"}
+
+	// A key stored in plaintext before keys were encrypted
+	_, legacy, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(legacy)
+	if err != nil {
+		t.Fatalf("Failed to encode key: %v", err)
+	}
+	repo := &fakeSigningKeyRepo{keys: []entities.SigningKey{{ID: "legacy", Algorithm: entities.JWTAlgorithmEdDSA, PrivateKey: der, CreatedAt: time.Now().UTC()}}}
+
+	keys, err := NewRotatingKeyRing(repo, testSecretCipher(t, "k1"), entities.JWTAlgorithmEdDSA, 0, policy)
+	if err != nil {
+		t.Fatalf("Failed to create key ring: %v", err)
+	}
+	if kid, _, _, _ := keys.SigningKey(); kid != "legacy" {
+		t.Fatalf("Expected the stored key to keep signing, got %q", kid)
+	}
+	token, _ := NewJWTServiceWithKeys(keys, policy, "conduit-api", "conduit").GenerateToken(user)
+
+	// Switching algorithms stores a new key; neither key is stored parseable
+	if _, err := NewRotatingKeyRing(repo, testSecretCipher(t, "k1"), entities.JWTAlgorithmRS256, 0, policy); err != nil {
+		t.Fatalf("Failed to create key ring: %v", err)
+	}
+	if len(repo.keys) != 2 {
+		t.Fatalf("Expected a new RS256 key next to the legacy one, got %d keys", len(repo.keys))
+	}
+	for _, stored := range repo.keys {
+		if _, err := x509.ParsePKCS8PrivateKey(stored.PrivateKey); err == nil {
+			t.Errorf("Expected key %s to be stored encrypted", stored.ID)
+		}
+	}
+
+	// A new key encryption key takes over, and the old one still reads the keys
+	rotated, err := NewRotatingKeyRing(repo, testSecretCipher(t, "k2", "k1"), entities.JWTAlgorithmRS256, 0, policy)
+	if err != nil {
+		t.Fatalf("Failed to load keys after adding a key encryption key: %v", err)
+	}
+	for _, stored := range repo.keys {
+		if !strings.HasPrefix(string(stored.PrivateKey), "enc:k2:") {
+			t.Errorf("Expected key %s to be re-encrypted with the new key", stored.ID)
+		}
+	}
+	if _, err := NewJWTServiceWithKeys(rotated, policy, "conduit-api", "conduit").ValidateToken(token); err != nil {
+		t.Errorf("Expected a token signed with the legacy key to verify, got %v", err)
+	}
+
+	// Without the key encryption key the keys can't be read
+	if _, err := NewRotatingKeyRing(repo, testSecretCipher(t, "k3"), entities.JWTAlgorithmRS256, 0, policy); err == nil {
+		t.Error("Expected keys sealed with an unknown key encryption key to fail to load")
+	}
+	if _, err := NewRotatingKeyRing(&fakeSigningKeyRepo{}, nil, entities.JWTAlgorithmRS256, 0, policy); err == nil {
+		t.Error("Expected a key ring without a key encryption key to be refused")
+	}
+}
@@ -1,7 +1,10 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,6 +15,8 @@ import (
 // JWTService handles JWT token operations
 type JWTService interface {
 	GenerateToken(user *entities.User) (string, error)
+	GenerateScopedToken(user *entities.User, scopes []string) (string, error)
+	GenerateSessionToken(user *entities.User, sessionID string) (string, error)
 	ValidateToken(tokenString string) (*jwt.MapClaims, error)
 	ParseToken(tokenString string) (*jwt.Token, error)
 	GetUserIDFromToken(tokenString string) (int64, error)
@@ -20,46 +25,97 @@ type JWTService interface {
 
 // jwtService implements JWTService
 type jwtService struct {
-	secretKey    []byte
-	tokenExpiry  time.Duration
-	signingMethod jwt.SigningMethod
+	keys     JWTKeyRing
+	policy   TokenPolicy
+	issuer   string
+	audience string
 }
 
 // JWTClaims represents the claims in a JWT token
 type JWTClaims struct {
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
+	// Scope lists the space-separated scopes the token is limited to; tokens
+	// without one may call any route
+	Scope string `json:"scope,omitempty"`
+	// SessionID names the login session the token was issued for; revoking
+	// the session rejects the token
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// NewJWTService creates a new JWT service
-func NewJWTService(secretKey string, tokenExpiryHours int) JWTService {
+// NewJWTService creates a new JWT service. Tokens are issued by issuer for
+// audience, and tokens naming another issuer or audience are rejected. The
+// policy sets the access token lifetime and the clock skew allowed on nbf and exp.
+func NewJWTService(secretKey string, policy TokenPolicy, issuer, audience string) JWTService {
+	return NewJWTServiceWithKeys(NewHMACKeyRing(secretKey), policy, issuer, audience)
+}
+
+// NewJWTServiceWithKeys creates a JWT service that signs tokens with the key
+// ring's current key, naming it in the kid header, and verifies them with the
+// key they name
+func NewJWTServiceWithKeys(keys JWTKeyRing, policy TokenPolicy, issuer, audience string) JWTService {
 	return &jwtService{
-		secretKey:     []byte(secretKey),
-		tokenExpiry:   time.Duration(tokenExpiryHours) * time.Hour,
-		signingMethod: jwt.SigningMethodHS256,
+		keys:     keys,
+		policy:   policy,
+		issuer:   issuer,
+		audience: audience,
 	}
 }
 
 // GenerateToken generates a JWT token for a user
 func (s *jwtService) GenerateToken(user *entities.User) (string, error) {
+	return s.GenerateScopedToken(user, nil)
+}
+
+// GenerateScopedToken generates a JWT token limited to the given scopes; no
+// scopes means an unrestricted token
+func (s *jwtService) GenerateScopedToken(user *entities.User, scopes []string) (string, error) {
+	return s.generateToken(user, scopes, "")
+}
+
+// GenerateSessionToken generates an unrestricted JWT token for a login session
+func (s *jwtService) GenerateSessionToken(user *entities.User, sessionID string) (string, error) {
+	return s.generateToken(user, nil, sessionID)
+}
+
+// generateToken signs a token for the user with the given scopes and session
+func (s *jwtService) generateToken(user *entities.User, scopes []string, sessionID string) (string, error) {
 	now := time.Now()
-	expirationTime := now.Add(s.tokenExpiry)
+	expirationTime := s.policy.ExpiresAt(TokenAccess, now)
+
+	// A random jti lets the token be revoked on its own
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
 
 	claims := &JWTClaims{
-		UserID:   user.ID,
-		Username: user.Username,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Scope:     strings.Join(scopes, " "),
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Subject:   fmt.Sprintf("user:%d", user.ID),
-			Issuer:    "conduit-api",
+			Issuer:    s.issuer,
+			Audience:  jwt.ClaimStrings{s.audience},
+			ID:        hex.EncodeToString(tokenID),
 		},
 	}
 
-	token := jwt.NewWithClaims(s.signingMethod, claims)
-	tokenString, err := token.SignedString(s.secretKey)
+	kid, method, key, err := s.keys.SigningKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	tokenString, err := token.SignedString(key)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -83,21 +139,22 @@ func (s *jwtService) ValidateToken(tokenString string) (*jwt.MapClaims, error) {
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	// exp, iss and aud are enforced by the parser
+	for _, claim := range []string{"iat", "sub"} {
+		if _, ok := claims[claim]; !ok {
+			return nil, fmt.Errorf("invalid token: missing %s claim", claim)
+		}
+	}
+
 	return &claims, nil
 }
 
-// ParseToken parses a JWT token
+// ParseToken parses a JWT token, checking its signature, expiry, issuer and audience
 func (s *jwtService) ParseToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if method, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		} else if method != s.signingMethod {
-			return nil, fmt.Errorf("unexpected signing method: %v", method)
-		}
-
-		return s.secretKey, nil
-	})
+	// The key ring checks the signing method against the key the token names
+	token, err := jwt.Parse(tokenString, s.keys.VerificationKey,
+		jwt.WithValidMethods(s.keys.Algorithms()), jwt.WithExpirationRequired(), jwt.WithIssuedAt(),
+		jwt.WithIssuer(s.issuer), jwt.WithAudience(s.audience), jwt.WithLeeway(s.policy.ClockSkew()))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
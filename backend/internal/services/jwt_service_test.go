@@ -10,7 +10,7 @@ import (
 )
 
 func TestJWTService_GenerateToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 24)
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	user := &entities.User{
 		ID:       1,
@@ -29,7 +29,7 @@ func TestJWTService_GenerateToken(t *testing.T) {
 }
 
 func TestJWTService_ValidateToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 24)
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	user := &entities.User{
 		ID:       1,
@@ -85,7 +85,7 @@ func TestJWTService_ValidateToken(t *testing.T) {
 }
 
 func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 24)
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	tests := []struct {
 		name  string
@@ -107,7 +107,7 @@ func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 }
 
 func TestJWTService_GetUserIDFromToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 24)
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	user := &entities.User{
 		ID:       123,
@@ -131,7 +131,7 @@ func TestJWTService_GetUserIDFromToken(t *testing.T) {
 }
 
 func TestJWTService_GetUsernameFromToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 24)
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	user := &entities.User{
 		ID:       1,
@@ -155,7 +155,7 @@ func TestJWTService_GetUsernameFromToken(t *testing.T) {
 }
 
 func TestJWTService_ParseToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 24)
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	user := &entities.User{
 		ID:       1,
@@ -184,7 +184,7 @@ func TestJWTService_ParseToken(t *testing.T) {
 
 func TestJWTService_ExpiredToken(t *testing.T) {
 	// Create service with very short expiry
-	service := NewJWTService("test-secret-key", 0) // 0 hours = immediate expiry
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 0}, 0), "conduit-api", "conduit") // zero lifetime = immediate expiry
 	
 	user := &entities.User{
 		ID:       1,
@@ -212,9 +212,97 @@ func TestJWTService_ExpiredToken(t *testing.T) {
 	}
 }
 
+func TestJWTService_IssuerAndAudience(t *testing.T) {
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
+	user := &entities.User{ID: 1, Username: "testuser"}
+
+	tests := []struct {
+		name    string
+		issuer  string
+		aud     string
+		wantErr bool
+	}{
+		{"Matching issuer and audience", "conduit-api", "conduit", false},
+		{"Other issuer", "someone-else", "conduit", true},
+		{"Other audience", "conduit-api", "another-app", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), tt.issuer, tt.aud).GenerateToken(user)
+			if err != nil {
+				t.Fatalf("Failed to generate token: %v", err)
+			}
+
+			_, err = service.ValidateToken(token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestJWTService_MissingRegisteredClaims(t *testing.T) {
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"Missing exp", jwt.MapClaims{"iat": now.Unix(), "sub": "user:1", "iss": "conduit-api", "aud": "conduit"}},
+		{"Missing iat", jwt.MapClaims{"exp": now.Add(time.Hour).Unix(), "sub": "user:1", "iss": "conduit-api", "aud": "conduit"}},
+		{"Missing sub", jwt.MapClaims{"exp": now.Add(time.Hour).Unix(), "iat": now.Unix(), "iss": "conduit-api", "aud": "conduit"}},
+		{"Missing iss", jwt.MapClaims{"exp": now.Add(time.Hour).Unix(), "iat": now.Unix(), "sub": "user:1", "aud": "conduit"}},
+		{"Missing aud", jwt.MapClaims{"exp": now.Add(time.Hour).Unix(), "iat": now.Unix(), "sub": "user:1", "iss": "conduit-api"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.claims["user_id"] = 1
+			tt.claims["username"] = "testuser"
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tt.claims).SignedString([]byte("test-secret-key"))
+			if err != nil {
+				t.Fatalf("Failed to sign token: %v", err)
+			}
+
+			if _, err := service.ValidateToken(token); err == nil {
+				t.Error("Expected token without a required claim to be rejected")
+			}
+		})
+	}
+}
+
+func TestJWTService_GenerateScopedToken(t *testing.T) {
+	service := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
+	user := &entities.User{ID: 1, Username: "testuser"}
+
+	token, err := service.GenerateScopedToken(user, []string{entities.ScopeRead, entities.ScopeWrite})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if scope := (*claims)["scope"]; scope != "read write" {
+		t.Errorf("Expected scope %q, got %v", "read write", scope)
+	}
+
+	token, err = service.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if claims, _ := service.ValidateToken(token); claims != nil {
+		if _, ok := (*claims)["scope"]; ok {
+			t.Error("Expected an unrestricted token to have no scope claim")
+		}
+	}
+}
+
 func TestJWTService_DifferentSecrets(t *testing.T) {
-	service1 := NewJWTService("secret-key-1", 24)
-	service2 := NewJWTService("secret-key-2", 24)
+	service1 := NewJWTService("secret-key-1", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
+	service2 := NewJWTService("secret-key-2", NewTokenPolicy(TokenLifetimes{Access: 24 * time.Hour}, 0), "conduit-api", "conduit")
 	
 	user := &entities.User{
 		ID:       1,
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// KPISnapshot is the latest community stats with refresh bookkeeping
+type KPISnapshot struct {
+	// Stats is nil until the first refresh succeeds
+	Stats       *entities.CommunityStats
+	RefreshedAt time.Time
+	Refreshes   int64
+	Failures    int64
+}
+
+// KPICollector periodically recomputes community KPIs so that scrapes are
+// served from memory instead of querying the database
+type KPICollector interface {
+	Refresh() error
+	Snapshot() KPISnapshot
+}
+
+// kpiCollector implements KPICollector
+type kpiCollector struct {
+	repo repositories.KPIRepository
+
+	mu       sync.RWMutex
+	snapshot KPISnapshot
+}
+
+// NewKPICollector creates a KPI collector; call Refresh to populate it
+func NewKPICollector(repo repositories.KPIRepository) KPICollector {
+	return &kpiCollector{repo: repo}
+}
+
+// Refresh recomputes the stats. On failure the previous stats are kept and
+// the failure is counted.
+func (c *kpiCollector) Refresh() error {
+	now := time.Now()
+	stats, err := c.repo.CommunityStats(now)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.snapshot.Failures++
+		return err
+	}
+	c.snapshot.Stats = stats
+	c.snapshot.RefreshedAt = now
+	c.snapshot.Refreshes++
+	return nil
+}
+
+// Snapshot returns the latest stats
+func (c *kpiCollector) Snapshot() KPISnapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Lifecycle tracks whether the server is draining before shutdown and the
+// client connections it still holds
+type Lifecycle interface {
+	// Drain marks the server as draining and returns when draining began;
+	// later calls return the time of the first
+	Drain() time.Time
+	Draining() bool
+	// DrainStarted is closed when draining begins
+	DrainStarted() <-chan struct{}
+	// TrackConnection follows connection state changes; it is meant to be
+	// used as http.Server.ConnState
+	TrackConnection(conn net.Conn, state http.ConnState)
+	// Connections returns how many connections are open and how many of them
+	// are serving a request
+	Connections() (open, active int)
+}
+
+// lifecycle implements Lifecycle in memory
+type lifecycle struct {
+	mu          sync.Mutex
+	drainedAt   time.Time
+	drainStart  chan struct{}
+	connections map[net.Conn]http.ConnState
+	now         func() time.Time
+}
+
+// NewLifecycle creates a lifecycle for a server that is serving traffic
+func NewLifecycle() Lifecycle {
+	return &lifecycle{
+		drainStart:  make(chan struct{}),
+		connections: make(map[net.Conn]http.ConnState),
+		now:         time.Now,
+	}
+}
+
+// Drain starts draining unless it already started
+func (l *lifecycle) Drain() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.drainedAt.IsZero() {
+		l.drainedAt = l.now()
+		close(l.drainStart)
+	}
+	return l.drainedAt
+}
+
+// Draining reports whether draining has started
+func (l *lifecycle) Draining() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.drainedAt.IsZero()
+}
+
+// DrainStarted returns a channel that is closed when draining begins
+func (l *lifecycle) DrainStarted() <-chan struct{} {
+	return l.drainStart
+}
+
+// TrackConnection records the connection's new state, forgetting closed and
+// hijacked connections
+func (l *lifecycle) TrackConnection(conn net.Conn, state http.ConnState) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch state {
+	case http.StateClosed, http.StateHijacked:
+		delete(l.connections, conn)
+	default:
+		l.connections[conn] = state
+	}
+}
+
+// Connections counts the tracked connections
+func (l *lifecycle) Connections() (int, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	active := 0
+	for _, state := range l.connections {
+		if state == http.StateActive {
+			active++
+		}
+	}
+	return len(l.connections), active
+}
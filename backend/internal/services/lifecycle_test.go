@@ -0,0 +1,70 @@
+package services
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLifecycle_Drain(t *testing.T) {
+	l := NewLifecycle().(*lifecycle)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return start }
+
+	if l.Draining() {
+		t.Fatal("Expected a new lifecycle not to be draining")
+	}
+	select {
+	case <-l.DrainStarted():
+		t.Fatal("Expected DrainStarted to stay open before draining")
+	default:
+	}
+
+	if got := l.Drain(); !got.Equal(start) {
+		t.Errorf("Expected drain to start at %v, got %v", start, got)
+	}
+
+	// Draining again keeps the original start
+	l.now = func() time.Time { return start.Add(time.Minute) }
+	if got := l.Drain(); !got.Equal(start) {
+		t.Errorf("Expected repeated drain to keep %v, got %v", start, got)
+	}
+
+	if !l.Draining() {
+		t.Error("Expected lifecycle to be draining")
+	}
+	select {
+	case <-l.DrainStarted():
+	default:
+		t.Error("Expected DrainStarted to be closed")
+	}
+}
+
+func TestLifecycle_TrackConnection(t *testing.T) {
+	l := NewLifecycle()
+	first, firstPeer := net.Pipe()
+	second, secondPeer := net.Pipe()
+	defer func() {
+		for _, conn := range []net.Conn{first, firstPeer, second, secondPeer} {
+			conn.Close()
+		}
+	}()
+
+	l.TrackConnection(first, http.StateNew)
+	l.TrackConnection(first, http.StateActive)
+	l.TrackConnection(second, http.StateNew)
+	l.TrackConnection(second, http.StateActive)
+	l.TrackConnection(second, http.StateIdle)
+
+	if open, active := l.Connections(); open != 2 || active != 1 {
+		t.Errorf("Expected 2 open and 1 active connections, got %d and %d", open, active)
+	}
+
+	l.TrackConnection(first, http.StateHijacked)
+	l.TrackConnection(second, http.StateClosed)
+
+	if open, active := l.Connections(); open != 0 || active != 0 {
+		t.Errorf("Expected no connections, got %d open and %d active", open, active)
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// ErrLinkPreviewUnavailable is returned when a page cannot be fetched or has no previewable metadata
+var ErrLinkPreviewUnavailable = errors.New("link preview unavailable")
+
+// Link preview limits
+const (
+	// linkPreviewMaxBytes is how much of a page is read; metadata lives in the head
+	linkPreviewMaxBytes = 512 * 1024
+	linkPreviewMaxTitle = 300
+	linkPreviewMaxText  = 1000
+)
+
+var (
+	// metaTagRegex matches <meta> tags
+	metaTagRegex = regexp.MustCompile(`(?is)<meta\b[^>]*>`)
+	// titleTagRegex matches the document title
+	titleTagRegex = regexp.MustCompile(`(?is)<title\b[^>]*>(.*?)</title\s*>`)
+)
+
+// LinkPreviewService fetches Open Graph metadata for URLs
+type LinkPreviewService interface {
+	// Get returns the preview for rawURL, fetching it if it is not stored or is stale
+	Get(ctx context.Context, rawURL string) (*entities.LinkPreview, error)
+}
+
+// linkPreviewService implements LinkPreviewService, storing previews as a cache
+type linkPreviewService struct {
+	repo   repositories.LinkPreviewRepository
+	client *http.Client
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewLinkPreviewService creates a link preview service. Pages are fetched with
+// the SSRF-safe client; stored previews are reused until they are maxAge old.
+func NewLinkPreviewService(repo repositories.LinkPreviewRepository, timeout, maxAge time.Duration) LinkPreviewService {
+	return &linkPreviewService{
+		repo:   repo,
+		client: NewSafeHTTPClient(timeout),
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+}
+
+// Get returns a fresh stored preview or fetches the page. If fetching fails
+// and a stale preview exists, the stale preview is returned.
+func (s *linkPreviewService) Get(ctx context.Context, rawURL string) (*entities.LinkPreview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid URL", ErrLinkPreviewUnavailable)
+	}
+	if err := ValidateOutboundURL(target); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkPreviewUnavailable, err)
+	}
+	target.Fragment = ""
+	key := target.String()
+
+	stored, err := s.repo.Get(key)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+	if stored != nil && s.now().Sub(stored.FetchedAt) < s.maxAge {
+		return stored, nil
+	}
+
+	preview, err := s.fetch(ctx, target)
+	if err != nil {
+		if stored != nil && !errors.Is(err, ErrForbiddenAddress) {
+			return stored, nil
+		}
+		return nil, err
+	}
+
+	preview.URL = key
+	preview.FetchedAt = s.now()
+	if err := s.repo.Save(preview); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// fetch downloads the start of an HTML page and extracts its metadata
+func (s *linkPreviewService) fetch(ctx context.Context, target *url.URL) (*entities.LinkPreview, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkPreviewUnavailable, err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "Conduit/1.0 (+link preview)")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrForbiddenAddress) {
+			return nil, ErrForbiddenAddress
+		}
+		return nil, fmt.Errorf("%w: %v", ErrLinkPreviewUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: upstream returned %s", ErrLinkPreviewUnavailable, resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: not an HTML page", ErrLinkPreviewUnavailable)
+	}
+
+	// Pages larger than the limit are parsed from their beginning
+	page, err := io.ReadAll(io.LimitReader(resp.Body, linkPreviewMaxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkPreviewUnavailable, err)
+	}
+
+	preview := ParseLinkPreview(string(page), resp.Request.URL)
+	if preview.Title == "" && preview.Description == "" {
+		return nil, fmt.Errorf("%w: page has no title or description", ErrLinkPreviewUnavailable)
+	}
+	return preview, nil
+}
+
+// ParseLinkPreview extracts Open Graph metadata from an HTML page, falling
+// back to Twitter card tags, the meta description and the document title.
+// Relative image URLs are resolved against pageURL.
+func ParseLinkPreview(page string, pageURL *url.URL) *entities.LinkPreview {
+	meta := make(map[string]string)
+	for _, tag := range metaTagRegex.FindAllString(page, -1) {
+		var key, content string
+		for _, attribute := range attributeRegex.FindAllStringSubmatch(tag, -1) {
+			value := html.UnescapeString(strings.Trim(attribute[2], `"'`))
+			switch strings.ToLower(attribute[1]) {
+			case "property", "name":
+				key = strings.ToLower(value)
+			case "content":
+				content = value
+			}
+		}
+		if key != "" && content != "" {
+			if _, seen := meta[key]; !seen {
+				meta[key] = content
+			}
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := strings.TrimSpace(meta[key]); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	title := first("og:title", "twitter:title")
+	if title == "" {
+		if match := titleTagRegex.FindStringSubmatch(page); match != nil {
+			title = strings.Join(strings.Fields(html.UnescapeString(match[1])), " ")
+		}
+	}
+
+	preview := &entities.LinkPreview{
+		Title:       truncateText(title, linkPreviewMaxTitle),
+		Description: truncateText(first("og:description", "twitter:description", "description"), linkPreviewMaxText),
+		SiteName:    truncateText(first("og:site_name"), linkPreviewMaxTitle),
+	}
+	if preview.SiteName == "" {
+		preview.SiteName = pageURL.Hostname()
+	}
+
+	if image := first("og:image:secure_url", "og:image", "og:image:url", "twitter:image"); image != "" {
+		if resolved, err := pageURL.Parse(image); err == nil && (resolved.Scheme == "http" || resolved.Scheme == "https") {
+			preview.ImageURL = resolved.String()
+		}
+	}
+
+	return preview
+}
+
+// truncateText shortens text to at most max characters, adding an ellipsis
+func truncateText(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
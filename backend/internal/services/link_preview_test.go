@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+func TestParseLinkPreview(t *testing.T) {
+	pageURL, _ := url.Parse("https://blog.example/posts/1")
+
+	page := `<html><head>
+		<title>Fallback title</title>
+		<meta property="og:title" content="Hello &amp; welcome">
+		<meta name="description" content="Meta description">
+		<meta property="og:image" content="/img/card.png">
+		<meta property="og:site_name" content='Example Blog'>
+	</head><body></body></html>`
+
+	preview := ParseLinkPreview(page, pageURL)
+	if preview.Title != "Hello & welcome" {
+		t.Errorf("Expected og:title, got %q", preview.Title)
+	}
+	if preview.Description != "Meta description" {
+		t.Errorf("Expected meta description fallback, got %q", preview.Description)
+	}
+	if preview.ImageURL != "https://blog.example/img/card.png" {
+		t.Errorf("Expected resolved image URL, got %q", preview.ImageURL)
+	}
+	if preview.SiteName != "Example Blog" {
+		t.Errorf("Expected og:site_name, got %q", preview.SiteName)
+	}
+}
+
+func TestParseLinkPreview_Fallbacks(t *testing.T) {
+	pageURL, _ := url.Parse("https://blog.example/posts/1")
+
+	preview := ParseLinkPreview(`<title>
+		Plain   title </title><meta property="og:image" content="javascript:alert(1)">`, pageURL)
+	if preview.Title != "Plain title" {
+		t.Errorf("Expected document title, got %q", preview.Title)
+	}
+	if preview.ImageURL != "" {
+		t.Errorf("Expected unsafe image to be dropped, got %q", preview.ImageURL)
+	}
+	if preview.SiteName != "blog.example" {
+		t.Errorf("Expected host as site name, got %q", preview.SiteName)
+	}
+}
+
+type fakeLinkPreviewRepo struct {
+	repositories.LinkPreviewRepository
+	previews map[string]*entities.LinkPreview
+}
+
+func (r *fakeLinkPreviewRepo) Get(url string) (*entities.LinkPreview, error) {
+	if preview, ok := r.previews[url]; ok {
+		return preview, nil
+	}
+	return nil, errors.New("link preview not found")
+}
+
+func (r *fakeLinkPreviewRepo) Save(preview *entities.LinkPreview) error {
+	r.previews[preview.URL] = preview
+	return nil
+}
+
+func TestLinkPreviewService_CachesAndRefreshes(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<meta property="og:title" content="Card">`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	repo := &fakeLinkPreviewRepo{previews: map[string]*entities.LinkPreview{}}
+	service := NewLinkPreviewService(repo, time.Second, time.Hour)
+	service.(*linkPreviewService).client = server.Client()
+	service.(*linkPreviewService).now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		preview, err := service.Get(context.Background(), server.URL+"/page#section")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if preview.Title != "Card" || preview.URL != server.URL+"/page" {
+			t.Errorf("Unexpected preview %+v", preview)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected stored preview to be reused, got %d requests", requests)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := service.Get(context.Background(), server.URL+"/page"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected stale preview to be refetched, got %d requests", requests)
+	}
+}
+
+func TestLinkPreviewService_Rejects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("not html"))
+	}))
+	defer server.Close()
+
+	repo := &fakeLinkPreviewRepo{previews: map[string]*entities.LinkPreview{}}
+
+	// The real client refuses loopback addresses
+	service := NewLinkPreviewService(repo, time.Second, time.Hour)
+	if _, err := service.Get(context.Background(), server.URL); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Expected ErrForbiddenAddress, got %v", err)
+	}
+
+	service.(*linkPreviewService).client = server.Client()
+	if _, err := service.Get(context.Background(), server.URL); !errors.Is(err, ErrLinkPreviewUnavailable) {
+		t.Errorf("Expected ErrLinkPreviewUnavailable for non-HTML, got %v", err)
+	}
+	if _, err := service.Get(context.Background(), "ftp://files.example/a"); !errors.Is(err, ErrLinkPreviewUnavailable) {
+		t.Errorf("Expected ErrLinkPreviewUnavailable for ftp, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// notificationCopy is the text notification emails are framed in, in one
+// locale. The notifications themselves are written by the features raising them.
+type notificationCopy struct {
+	digestSubject func(count int) string
+	awaySubject   func(count int) string
+	// andMore follows the message of a notification grouping others
+	andMore func(others int) string
+	// open labels the button to a notification's page
+	open string
+	// reason tells recipients in the footer why they got the email
+	reason         func(brand string) string
+	manageSettings string
+}
+
+// notificationCopies holds the copy for each of entities.SupportedLocales
+var notificationCopies = map[string]notificationCopy{
+	"en": {
+		digestSubject: func(count int) string {
+			return fmt.Sprintf("Your daily digest: %d new notifications", count)
+		},
+		awaySubject: func(count int) string {
+			if count == 1 {
+				return "While you were away: 1 new notification"
+			}
+			return fmt.Sprintf("While you were away: %d new notifications", count)
+		},
+		andMore: func(others int) string {
+			return fmt.Sprintf(" (and %d more)", others)
+		},
+		open: "Open",
+		reason: func(brand string) string {
+			return fmt.Sprintf("You are receiving this email because of your notification settings on %s.", brand)
+		},
+		manageSettings: "Manage notification settings",
+	},
+	"ko": {
+		digestSubject: func(count int) string {
+			return fmt.Sprintf("오늘의 알림 요약: 새 알림 %d개", count)
+		},
+		awaySubject: func(count int) string {
+			return fmt.Sprintf("방해 금지 시간 동안 새 알림 %d개", count)
+		},
+		andMore: func(others int) string {
+			return fmt.Sprintf(" (외 %d건)", others)
+		},
+		open: "열기",
+		reason: func(brand string) string {
+			return fmt.Sprintf("%s 알림 설정에 따라 보내드린 이메일입니다.", brand)
+		},
+		manageSettings: "알림 설정 관리",
+	},
+}
+
+// copyFor returns the copy for locale, falling back to the default locale
+func copyFor(locale string) notificationCopy {
+	if c, ok := notificationCopies[locale]; ok {
+		return c
+	}
+	return notificationCopies[entities.DefaultLocale]
+}
+
+// summary renders a notification's message with a count of the others it groups
+func (c notificationCopy) summary(n *entities.Notification) string {
+	if n.Count > 1 {
+		return n.Message + c.andMore(n.Count-1)
+	}
+	return n.Message
+}
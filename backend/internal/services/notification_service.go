@@ -0,0 +1,376 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// NotificationService delivers notifications according to user preferences
+type NotificationService interface {
+	Notify(userID int64, eventType, message, link string, email *NotificationEmail) error
+	SendDailyDigests() (int, error)
+	ReleaseHeld() (int, error)
+}
+
+// NotificationEmail carries optional extras used only when a notification is emailed immediately
+type NotificationEmail struct {
+	Body    string
+	ReplyTo string
+}
+
+// NotificationOptions configures notification grouping and digest timing
+type NotificationOptions struct {
+	// CoalesceWindow folds notifications of the same group (see
+	// entities.NotificationGroupKey) arriving within it of the group's first
+	// one into that group; zero disables coalescing
+	CoalesceWindow time.Duration
+	// DigestHour is the hour of the day, in each user's time zone, their
+	// digest is sent at. A negative hour disables digests, and notifications
+	// users asked to have digested are then delivered immediately instead.
+	DigestHour int
+	// DigestEveryHour makes SendDailyDigests send every pending digest,
+	// whatever the hour in the recipients' time zones
+	DigestEveryHour bool
+	// Branding dresses notification emails
+	Branding EmailBranding
+}
+
+// notificationService implements NotificationService
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+	settingsRepo     repositories.SettingsRepository
+	emailSender      EmailSender
+	pushNotifier     PushNotifier
+	templates        *EmailTemplates
+	options          NotificationOptions
+}
+
+// notificationContent is the content of an immediate notification email
+type notificationContent struct {
+	// Quote is extra text the feature raising the notification sent along,
+	// such as the comment
+	Quote string
+	Link  string
+}
+
+// digestContent is the content of a digest or quiet hours summary email
+type digestContent struct {
+	Lines []digestLine
+}
+
+// digestLine is one notification in a digest or summary
+type digestLine struct {
+	Text string
+	Link string
+}
+
+// NewNotificationService creates a new notification service. Users' settings
+// supply the time zone digests and quiet hours follow and the locale emails
+// are written in.
+func NewNotificationService(notificationRepo repositories.NotificationRepository, userRepo repositories.UserRepository, settingsRepo repositories.SettingsRepository, emailSender EmailSender, pushNotifier PushNotifier, options NotificationOptions) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		settingsRepo:     settingsRepo,
+		emailSender:      emailSender,
+		pushNotifier:     pushNotifier,
+		templates:        NewEmailTemplates(options.Branding),
+		options:          options,
+	}
+}
+
+// Notify records a notification and emails it right away or queues it for the
+// daily digest, depending on the user's preference for the event type.
+// Immediate notifications of push event types also go to subscribed browsers.
+// Events the user has turned off are dropped entirely. An event that joins a
+// recent group of the same kind only bumps its count, so a burst of favorites
+// sends one email instead of one each. Immediate notifications arriving during
+// the user's quiet hours are held for ReleaseHeld instead.
+func (s *notificationService) Notify(userID int64, eventType, message, link string, email *NotificationEmail) error {
+	settings, err := s.notificationRepo.GetSettings(userID)
+	if err != nil {
+		return err
+	}
+
+	delivery := settings.Delivery(eventType)
+	if delivery == entities.DeliveryOff {
+		return nil
+	}
+	if delivery == entities.DeliveryDailyDigest && s.options.DigestHour < 0 {
+		delivery = entities.DeliveryImmediate
+	}
+
+	var userSettings entities.UserSettings
+	if delivery == entities.DeliveryImmediate {
+		if userSettings, err = s.settingsRepo.Get(userID); err != nil {
+			return err
+		}
+		quietHours, err := s.notificationRepo.GetQuietHours(userID)
+		if err != nil {
+			return err
+		}
+		if quietHours.Active(time.Now(), userSettings.Location()) {
+			delivery = entities.DeliveryHeld
+		}
+	}
+
+	notification := &entities.Notification{
+		UserID:    userID,
+		EventType: eventType,
+		Message:   message,
+		Link:      link,
+		Delivery:  delivery,
+		GroupKey:  entities.NotificationGroupKey(eventType, link),
+	}
+
+	if notification.GroupKey != "" && s.options.CoalesceWindow > 0 {
+		group, err := s.notificationRepo.Coalesce(notification, time.Now().Add(-s.options.CoalesceWindow))
+		if err != nil {
+			return err
+		}
+		if group != nil {
+			return nil
+		}
+	}
+
+	notification, err = s.notificationRepo.Create(notification)
+	if err != nil {
+		return err
+	}
+
+	if delivery != entities.DeliveryImmediate {
+		return nil
+	}
+
+	// Push is best effort; the email is the notification of record
+	if entities.IsPushEvent(eventType) {
+		if err := s.pushNotifier.Push(notification); err != nil {
+			log.Printf("⚠️  Failed to push notification to user %d: %v", userID, err)
+		}
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to load notification recipient: %w", err)
+	}
+
+	content := notificationContent{Link: notification.Link}
+	if email != nil {
+		content.Quote = email.Body
+	}
+	rendered, err := s.templates.Render("notification", userSettings.Locale, copyFor(userSettings.Locale).summary(notification), content)
+	if err != nil {
+		return err
+	}
+
+	outgoing := &EmailMessage{
+		To:      user.Email,
+		Subject: notification.Message,
+		Body:    rendered.Text,
+		HTML:    rendered.HTML,
+	}
+	if email != nil {
+		outgoing.ReplyTo = email.ReplyTo
+	}
+
+	return s.emailSender.Send(outgoing)
+}
+
+// SendDailyDigests emails each user for whom it is now the digest hour a
+// summary of their queued digest notifications and returns the number of
+// digests sent. It is meant to be called hourly.
+func (s *notificationService) SendDailyDigests() (int, error) {
+	pending, err := s.notificationRepo.ListPending(entities.DeliveryDailyDigest)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	now := time.Now()
+	for _, notifications := range byUser(pending) {
+		userID := notifications[0].UserID
+		settings, err := s.settingsRepo.Get(userID)
+		if err != nil {
+			return sent, err
+		}
+		if !s.options.DigestEveryHour && now.In(settings.Location()).Hour() != s.options.DigestHour {
+			continue
+		}
+
+		if err := s.sendDigest(userID, settings, notifications); err != nil {
+			log.Printf("⚠️  Failed to send digest to user %d: %v", userID, err)
+		} else {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// ReleaseHeld emails each user whose quiet hours have ended a summary of the
+// notifications held meanwhile, and pushes one message for them if any were
+// of push event types. It returns the number of summaries sent.
+func (s *notificationService) ReleaseHeld() (int, error) {
+	pending, err := s.notificationRepo.ListPending(entities.DeliveryHeld)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	now := time.Now()
+	for _, notifications := range byUser(pending) {
+		userID := notifications[0].UserID
+		settings, err := s.settingsRepo.Get(userID)
+		if err != nil {
+			return sent, err
+		}
+		quietHours, err := s.notificationRepo.GetQuietHours(userID)
+		if err != nil {
+			return sent, err
+		}
+		if quietHours.Active(now, settings.Location()) {
+			continue
+		}
+
+		if err := s.sendHeld(userID, settings, notifications); err != nil {
+			log.Printf("⚠️  Failed to send quiet hours summary to user %d: %v", userID, err)
+		} else {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendHeld emails one user's held notifications as a summary, pushes a single
+// message about them and marks them as delivered
+func (s *notificationService) sendHeld(userID int64, settings entities.UserSettings, notifications []entities.Notification) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to load quiet hours recipient: %w", err)
+	}
+
+	ids := make([]int64, 0, len(notifications))
+	pushed := false
+	for i := range notifications {
+		ids = append(ids, notifications[i].ID)
+		pushed = pushed || entities.IsPushEvent(notifications[i].EventType)
+	}
+
+	wording := copyFor(settings.Locale)
+	lines := coalesceDigest(notifications)
+	subject := wording.awaySubject(len(lines))
+	rendered, err := s.templates.Render("digest", settings.Locale, subject, digestLines(lines, wording))
+	if err != nil {
+		return err
+	}
+
+	// Push is best effort; the email is the notification of record
+	if pushed {
+		summary := &entities.Notification{UserID: userID, EventType: entities.EventQuietHoursSummary, Message: subject}
+		if err := s.pushNotifier.Push(summary); err != nil {
+			log.Printf("⚠️  Failed to push quiet hours summary to user %d: %v", userID, err)
+		}
+	}
+
+	err = s.emailSender.Send(&EmailMessage{
+		To:      user.Email,
+		Subject: subject,
+		Body:    rendered.Text,
+		HTML:    rendered.HTML,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notificationRepo.MarkDigested(ids)
+}
+
+// byUser splits pending notifications, which are ordered by user, into one
+// run per user
+func byUser(pending []entities.Notification) [][]entities.Notification {
+	var runs [][]entities.Notification
+	for start := 0; start < len(pending); {
+		end := start
+		for end < len(pending) && pending[end].UserID == pending[start].UserID {
+			end++
+		}
+		runs = append(runs, pending[start:end])
+		start = end
+	}
+	return runs
+}
+
+// sendDigest emails one user's digest and marks its notifications as delivered
+func (s *notificationService) sendDigest(userID int64, settings entities.UserSettings, notifications []entities.Notification) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to load digest recipient: %w", err)
+	}
+
+	ids := make([]int64, 0, len(notifications))
+	for i := range notifications {
+		ids = append(ids, notifications[i].ID)
+	}
+
+	wording := copyFor(settings.Locale)
+	lines := coalesceDigest(notifications)
+	subject := wording.digestSubject(len(lines))
+	rendered, err := s.templates.Render("digest", settings.Locale, subject, digestLines(lines, wording))
+	if err != nil {
+		return err
+	}
+
+	err = s.emailSender.Send(&EmailMessage{
+		To:      user.Email,
+		Subject: subject,
+		Body:    rendered.Text,
+		HTML:    rendered.HTML,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notificationRepo.MarkDigested(ids)
+}
+
+// coalesceDigest merges digest notifications of the same group, which may
+// span several coalescing windows over a day, into one line each. A merged
+// line keeps the place of the group's first notification and the message and
+// link of its latest.
+func coalesceDigest(notifications []entities.Notification) []entities.Notification {
+	lines := make([]entities.Notification, 0, len(notifications))
+	groups := make(map[string]int)
+	for _, n := range notifications {
+		if n.GroupKey == "" {
+			lines = append(lines, n)
+			continue
+		}
+		if i, ok := groups[n.GroupKey]; ok {
+			count := lines[i].Count + n.Count
+			lines[i] = n
+			lines[i].Count = count
+			continue
+		}
+		groups[n.GroupKey] = len(lines)
+		lines = append(lines, n)
+	}
+	return lines
+}
+
+// digestLines renders notifications as the lines of a digest or summary
+func digestLines(notifications []entities.Notification, wording notificationCopy) digestContent {
+	content := digestContent{Lines: make([]digestLine, 0, len(notifications))}
+	for i := range notifications {
+		content.Lines = append(content.Lines, digestLine{
+			Text: wording.summary(&notifications[i]),
+			Link: notifications[i].Link,
+		})
+	}
+	return content
+}
@@ -0,0 +1,407 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakeNotificationRepo struct {
+	settings      map[int64]entities.NotificationSettings
+	quietHours    map[int64]entities.QuietHours
+	notifications []entities.Notification
+}
+
+func (r *fakeNotificationRepo) Create(n *entities.Notification) (*entities.Notification, error) {
+	created := *n
+	created.ID = int64(len(r.notifications) + 1)
+	created.Count = 1
+	created.CreatedAt = time.Now()
+	r.notifications = append(r.notifications, created)
+	return &created, nil
+}
+
+func (r *fakeNotificationRepo) Coalesce(n *entities.Notification, since time.Time) (*entities.Notification, error) {
+	for i := len(r.notifications) - 1; i >= 0; i-- {
+		group := &r.notifications[i]
+		if group.UserID == n.UserID && group.GroupKey == n.GroupKey && group.Delivery == n.Delivery &&
+			group.DigestedAt == nil && !group.CreatedAt.Before(since) {
+			group.Message, group.Link = n.Message, n.Link
+			group.Count++
+			coalesced := *group
+			return &coalesced, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeNotificationRepo) GetSettings(userID int64) (entities.NotificationSettings, error) {
+	settings := entities.DefaultNotificationSettings()
+	for eventType, delivery := range r.settings[userID] {
+		settings[eventType] = delivery
+	}
+	return settings, nil
+}
+
+func (r *fakeNotificationRepo) UpdateSettings(userID int64, settings entities.NotificationSettings) (entities.NotificationSettings, error) {
+	r.settings[userID] = settings
+	return r.GetSettings(userID)
+}
+
+func (r *fakeNotificationRepo) GetQuietHours(userID int64) (entities.QuietHours, error) {
+	if quietHours, ok := r.quietHours[userID]; ok {
+		return quietHours, nil
+	}
+	return entities.DefaultQuietHours(), nil
+}
+
+func (r *fakeNotificationRepo) UpdateQuietHours(userID int64, quietHours entities.QuietHours) error {
+	r.quietHours[userID] = quietHours
+	return nil
+}
+
+func (r *fakeNotificationRepo) ListPending(delivery string) ([]entities.Notification, error) {
+	var pending []entities.Notification
+	for _, n := range r.notifications {
+		if n.Delivery == delivery && n.DigestedAt == nil {
+			pending = append(pending, n)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeNotificationRepo) MarkDigested(ids []int64) error {
+	for _, id := range ids {
+		r.notifications[id-1].DigestedAt = &r.notifications[id-1].CreatedAt
+	}
+	return nil
+}
+
+type fakeUserRepo struct {
+	repositories.UserRepository
+}
+
+func (r *fakeUserRepo) GetByID(id int64) (*entities.User, error) {
+	return &entities.User{ID: id, Email: fmt.Sprintf("user%d@example.com", id)}, nil
+}
+
+type fakeSettingsRepo struct {
+	settings map[int64]entities.UserSettings
+}
+
+func (r *fakeSettingsRepo) Get(userID int64) (entities.UserSettings, error) {
+	if settings, ok := r.settings[userID]; ok {
+		return settings, nil
+	}
+	return entities.DefaultUserSettings(), nil
+}
+
+func (r *fakeSettingsRepo) Update(userID int64, update *entities.UserSettingsUpdate) (entities.UserSettings, error) {
+	settings, _ := r.Get(userID)
+	settings = update.Apply(settings)
+	r.settings[userID] = settings
+	return settings, nil
+}
+
+// testNotificationOptions sends digests whenever asked
+var testNotificationOptions = NotificationOptions{CoalesceWindow: time.Hour, DigestEveryHour: true}
+
+type recordingPushNotifier struct {
+	pushed []entities.Notification
+}
+
+func (p *recordingPushNotifier) Push(notification *entities.Notification) error {
+	p.pushed = append(p.pushed, *notification)
+	return nil
+}
+
+type recordingEmailSender struct {
+	sent []EmailMessage
+}
+
+func (s *recordingEmailSender) Send(message *EmailMessage) error {
+	s.sent = append(s.sent, *message)
+	return nil
+}
+
+func newTestNotificationService() (NotificationService, *fakeNotificationRepo, *recordingEmailSender) {
+	repo := &fakeNotificationRepo{settings: map[int64]entities.NotificationSettings{}, quietHours: map[int64]entities.QuietHours{}}
+	sender := &recordingEmailSender{}
+	return NewNotificationService(repo, &fakeUserRepo{}, &fakeSettingsRepo{settings: map[int64]entities.UserSettings{}}, sender, &recordingPushNotifier{}, testNotificationOptions), repo, sender
+}
+
+func TestNotificationService_NotifyImmediate(t *testing.T) {
+	service, repo, sender := newTestNotificationService()
+
+	if err := service.Notify(1, entities.EventNewComment, "New comment", "/article/hello", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(repo.notifications) != 1 {
+		t.Fatalf("Expected 1 stored notification, got %d", len(repo.notifications))
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To != "user1@example.com" {
+		t.Fatalf("Expected immediate email to user1, got %v", sender.sent)
+	}
+}
+
+func TestNotificationService_NotifyWithEmailExtras(t *testing.T) {
+	service, _, sender := newTestNotificationService()
+
+	email := &NotificationEmail{Body: "Great post!", ReplyTo: "reply+token@example.com"}
+	if err := service.Notify(1, entities.EventNewComment, "New comment", "", email); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(sender.sent))
+	}
+	if sender.sent[0].ReplyTo != email.ReplyTo {
+		t.Errorf("Expected Reply-To %q, got %q", email.ReplyTo, sender.sent[0].ReplyTo)
+	}
+	if !strings.Contains(sender.sent[0].Body, "Great post!") {
+		t.Errorf("Expected body to include extra content, got %q", sender.sent[0].Body)
+	}
+}
+
+func TestNotificationService_NotifyOff(t *testing.T) {
+	service, repo, sender := newTestNotificationService()
+	repo.settings[1] = entities.NotificationSettings{entities.EventNewComment: entities.DeliveryOff}
+
+	if err := service.Notify(1, entities.EventNewComment, "New comment", "", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(repo.notifications) != 0 || len(sender.sent) != 0 {
+		t.Errorf("Expected disabled event to be dropped, got %d notifications and %d emails", len(repo.notifications), len(sender.sent))
+	}
+}
+
+func TestNotificationService_DailyDigest(t *testing.T) {
+	service, repo, sender := newTestNotificationService()
+	repo.settings[1] = entities.NotificationSettings{entities.EventNewComment: entities.DeliveryDailyDigest}
+	repo.settings[2] = entities.NotificationSettings{entities.EventNewComment: entities.DeliveryDailyDigest}
+
+	service.Notify(1, entities.EventNewComment, "First", "", nil)
+	service.Notify(1, entities.EventNewComment, "Second", "", nil)
+	service.Notify(2, entities.EventNewComment, "Third", "", nil)
+
+	if len(sender.sent) != 0 {
+		t.Fatalf("Expected digest notifications not to be emailed immediately, got %d emails", len(sender.sent))
+	}
+
+	sent, err := service.SendDailyDigests()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent != 2 || len(sender.sent) != 2 {
+		t.Fatalf("Expected 2 digests, got %d (%d emails)", sent, len(sender.sent))
+	}
+
+	// Digested notifications are not sent again
+	if sent, _ := service.SendDailyDigests(); sent != 0 {
+		t.Errorf("Expected no digests on second run, got %d", sent)
+	}
+}
+
+func TestNotificationService_DigestsDisabled(t *testing.T) {
+	repo := &fakeNotificationRepo{settings: map[int64]entities.NotificationSettings{}, quietHours: map[int64]entities.QuietHours{}}
+	sender := &recordingEmailSender{}
+	service := NewNotificationService(repo, &fakeUserRepo{}, &fakeSettingsRepo{settings: map[int64]entities.UserSettings{}}, sender, &recordingPushNotifier{},
+		NotificationOptions{CoalesceWindow: time.Hour, DigestHour: -1})
+	repo.settings[1] = entities.NotificationSettings{entities.EventNewComment: entities.DeliveryDailyDigest}
+
+	if err := service.Notify(1, entities.EventNewComment, "New comment", "/article/hello", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("Expected the notification to be emailed right away without digests, got %d emails", len(sender.sent))
+	}
+	if pending, _ := repo.ListPending(entities.DeliveryDailyDigest); len(pending) != 0 {
+		t.Errorf("Expected nothing left waiting for a digest, got %d", len(pending))
+	}
+}
+
+func TestNotificationService_PushesImmediatePushEvents(t *testing.T) {
+	repo := &fakeNotificationRepo{settings: map[int64]entities.NotificationSettings{}}
+	pusher := &recordingPushNotifier{}
+	service := NewNotificationService(repo, &fakeUserRepo{}, &fakeSettingsRepo{settings: map[int64]entities.UserSettings{}}, &recordingEmailSender{}, pusher, testNotificationOptions)
+
+	repo.settings[2] = entities.NotificationSettings{entities.EventNewFollower: entities.DeliveryDailyDigest}
+
+	service.Notify(1, entities.EventNewComment, "New comment", "/article/hello", nil)
+	service.Notify(1, entities.EventArticleFavorited, "New favorite", "/article/hello", nil)
+	service.Notify(2, entities.EventNewFollower, "New follower", "/profile/bob", nil)
+
+	if len(pusher.pushed) != 1 || pusher.pushed[0].EventType != entities.EventNewComment {
+		t.Errorf("Expected only the immediate comment to be pushed, got %v", pusher.pushed)
+	}
+}
+
+func TestNotificationService_CoalescesBursts(t *testing.T) {
+	service, repo, sender := newTestNotificationService()
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		service.Notify(1, entities.EventArticleFavorited, name+" favorited \"Hello\"", "/article/hello", nil)
+	}
+	service.Notify(1, entities.EventArticleFavorited, "dave favorited \"Other\"", "/article/other", nil)
+
+	if len(repo.notifications) != 2 {
+		t.Fatalf("Expected favorites to coalesce per article into 2 notifications, got %d", len(repo.notifications))
+	}
+	group := repo.notifications[0]
+	if group.Count != 3 || group.Summary() != "carol favorited \"Hello\" (and 2 more)" {
+		t.Errorf("Expected a group of 3 led by the latest favorite, got %d: %q", group.Count, group.Summary())
+	}
+	if len(sender.sent) != 2 {
+		t.Errorf("Expected one email per group, got %d", len(sender.sent))
+	}
+
+	// Comments are never merged
+	service.Notify(1, entities.EventNewComment, "First", "/article/hello", nil)
+	service.Notify(1, entities.EventNewComment, "Second", "/article/hello", nil)
+	if len(repo.notifications) != 4 {
+		t.Errorf("Expected each comment to stand alone, got %d notifications", len(repo.notifications))
+	}
+}
+
+func TestNotificationService_DigestMergesGroups(t *testing.T) {
+	service, repo, sender := newTestNotificationService()
+	repo.settings[1] = entities.NotificationSettings{
+		entities.EventNewFollower: entities.DeliveryDailyDigest,
+		entities.EventNewComment:  entities.DeliveryDailyDigest,
+	}
+
+	service.Notify(1, entities.EventNewFollower, "alice started following you", "/profile/alice", nil)
+	service.Notify(1, entities.EventNewComment, "New comment", "", nil)
+
+	// A follower after the window opens a new group, which the digest merges
+	repo.notifications[0].CreatedAt = time.Now().Add(-2 * time.Hour)
+	service.Notify(1, entities.EventNewFollower, "bob started following you", "/profile/bob", nil)
+	service.Notify(1, entities.EventNewFollower, "carol started following you", "/profile/carol", nil)
+
+	if len(repo.notifications) != 3 {
+		t.Fatalf("Expected 3 stored notifications, got %d", len(repo.notifications))
+	}
+
+	if sent, _ := service.SendDailyDigests(); sent != 1 {
+		t.Fatalf("Expected 1 digest, got %d", sent)
+	}
+	digest := sender.sent[0]
+	if !strings.Contains(digest.Subject, "2 new notifications") {
+		t.Errorf("Expected merged groups to count once in the subject, got %q", digest.Subject)
+	}
+	if !strings.Contains(digest.Body, "carol started following you (and 2 more) (/profile/carol)") {
+		t.Errorf("Expected one line for all followers, got %q", digest.Body)
+	}
+	if pending, _ := repo.ListPending(entities.DeliveryDailyDigest); len(pending) != 0 {
+		t.Errorf("Expected every merged notification to be marked digested, got %d pending", len(pending))
+	}
+}
+
+func TestNotificationService_HoldsDuringQuietHours(t *testing.T) {
+	repo := &fakeNotificationRepo{settings: map[int64]entities.NotificationSettings{}, quietHours: map[int64]entities.QuietHours{}}
+	sender := &recordingEmailSender{}
+	pusher := &recordingPushNotifier{}
+	service := NewNotificationService(repo, &fakeUserRepo{}, &fakeSettingsRepo{settings: map[int64]entities.UserSettings{}}, sender, pusher, testNotificationOptions)
+
+	// A two-hour window around now
+	now := time.Now().UTC()
+	quiet := entities.QuietHours{
+		Enabled:  true,
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+		Timezone: "UTC",
+	}
+	repo.quietHours[1] = quiet
+	repo.settings[1] = entities.NotificationSettings{entities.EventArticleFavorited: entities.DeliveryDailyDigest}
+
+	service.Notify(1, entities.EventNewComment, "First comment", "/article/hello", nil)
+	service.Notify(1, entities.EventNewComment, "Second comment", "/article/hello", nil)
+	service.Notify(1, entities.EventArticleFavorited, "alice favorited \"Hello\"", "/article/hello", nil)
+
+	if len(sender.sent) != 0 || len(pusher.pushed) != 0 {
+		t.Fatalf("Expected nothing to be sent during quiet hours, got %d emails and %d pushes", len(sender.sent), len(pusher.pushed))
+	}
+	if repo.notifications[2].Delivery != entities.DeliveryDailyDigest {
+		t.Errorf("Expected digest notifications to stay queued for the digest, got %q", repo.notifications[2].Delivery)
+	}
+
+	if sent, _ := service.ReleaseHeld(); sent != 0 {
+		t.Fatalf("Expected nothing to be released while quiet hours last, got %d", sent)
+	}
+
+	quiet.Enabled = false
+	repo.quietHours[1] = quiet
+	sent, err := service.ReleaseHeld()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent != 1 || len(sender.sent) != 1 {
+		t.Fatalf("Expected one summary email, got %d (%d emails)", sent, len(sender.sent))
+	}
+	summary := sender.sent[0]
+	if !strings.Contains(summary.Subject, "2 new notifications") || !strings.Contains(summary.Body, "Second comment") {
+		t.Errorf("Expected a summary of both comments, got %q: %q", summary.Subject, summary.Body)
+	}
+	if len(pusher.pushed) != 1 || pusher.pushed[0].EventType != entities.EventQuietHoursSummary {
+		t.Errorf("Expected one summary push, got %v", pusher.pushed)
+	}
+
+	if sent, _ := service.ReleaseHeld(); sent != 0 {
+		t.Errorf("Expected held notifications to be released once, got %d", sent)
+	}
+}
+
+func TestNotificationService_UsesUserTimezoneAndLocale(t *testing.T) {
+	repo := &fakeNotificationRepo{settings: map[int64]entities.NotificationSettings{}, quietHours: map[int64]entities.QuietHours{}}
+	settingsRepo := &fakeSettingsRepo{settings: map[int64]entities.UserSettings{}}
+	sender := &recordingEmailSender{}
+
+	// Seoul is always three and a half hours ahead of Kolkata, so it is the
+	// digest hour there and not in Kolkata
+	now := time.Now()
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	digestHour := now.In(seoul).Hour()
+	service := NewNotificationService(repo, &fakeUserRepo{}, settingsRepo, sender, &recordingPushNotifier{},
+		NotificationOptions{CoalesceWindow: time.Hour, DigestHour: digestHour})
+
+	settingsRepo.settings[1] = entities.UserSettings{Timezone: "Asia/Seoul", Locale: "ko"}
+	settingsRepo.settings[2] = entities.UserSettings{Timezone: "Asia/Kolkata", Locale: "en"}
+
+	for _, userID := range []int64{1, 2} {
+		repo.settings[userID] = entities.NotificationSettings{entities.EventNewFollower: entities.DeliveryDailyDigest}
+		service.Notify(userID, entities.EventNewFollower, "alice started following you", "/profile/alice", nil)
+		service.Notify(userID, entities.EventNewFollower, "bob started following you", "/profile/bob", nil)
+	}
+
+	sent, err := service.SendDailyDigests()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent != 1 || len(sender.sent) != 1 || sender.sent[0].To != "user1@example.com" {
+		t.Fatalf("Expected only the Seoul user's digest to be due, got %d: %v", sent, sender.sent)
+	}
+	digest := sender.sent[0]
+	if !strings.Contains(digest.Subject, "새 알림 1개") || !strings.Contains(digest.Body, "bob started following you (외 1건)") {
+		t.Errorf("Expected a Korean digest, got %q: %q", digest.Subject, digest.Body)
+	}
+
+	// Quiet hours without their own zone follow the user's
+	quiet := entities.QuietHours{
+		Enabled: true,
+		Start:   now.In(seoul).Add(-time.Hour).Format("15:04"),
+		End:     now.In(seoul).Add(time.Hour).Format("15:04"),
+	}
+	if !quiet.Active(now, settingsRepo.settings[1].Location()) {
+		t.Error("Expected quiet hours to be read in the user's time zone")
+	}
+	if quiet.Active(now, time.UTC) {
+		t.Error("Expected quiet hours in Seoul not to be active in UTC")
+	}
+}
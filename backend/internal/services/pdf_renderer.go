@@ -0,0 +1,236 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ArticleRenderer renders an article to a downloadable document. Renderers
+// are registered with the article exporter by format, so a richer one (for
+// example with embedded fonts) can replace the built-in PDF renderer.
+type ArticleRenderer interface {
+	ContentType() string
+	Render(article *entities.Article) ([]byte, error)
+}
+
+// PDF page geometry in points (A4)
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 56
+)
+
+// pdfFonts are the standard fonts the renderer uses, by resource name
+var pdfFonts = []struct{ name, base string }{
+	{"F1", "Helvetica"},
+	{"F2", "Helvetica-Bold"},
+	{"F3", "Courier"},
+}
+
+// pdfWinAnsi maps typographic characters outside Latin-1 to their WinAnsi codes
+var pdfWinAnsi = map[rune]byte{
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '…': 0x85, '€': 0x80,
+}
+
+// pdfLine is one line of text placed on a page
+type pdfLine struct {
+	font string
+	size float64
+	text string
+}
+
+// pdfRenderer renders articles as plain PDF documents using the standard
+// fonts, which need nothing embedded but only cover Western European text
+type pdfRenderer struct{}
+
+// NewPDFRenderer creates the built-in PDF renderer. The body is laid out as
+// the Markdown source, with code blocks in a monospaced font. Articles with
+// characters the standard fonts lack (Korean, for one) are refused rather
+// than printed illegibly, so it is not registered until a renderer that
+// embeds a font covering them exists.
+func NewPDFRenderer() ArticleRenderer {
+	return &pdfRenderer{}
+}
+
+// ContentType returns the PDF media type
+func (p *pdfRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+// Render lays out the article and writes it as a PDF document
+func (p *pdfRenderer) Render(article *entities.Article) ([]byte, error) {
+	var lines []pdfLine
+	var unsupported rune
+	add := func(font string, size float64, text string) {
+		for _, r := range text {
+			if unsupported == 0 && !pdfEncodable(r) {
+				unsupported = r
+			}
+		}
+		for _, wrapped := range pdfWrap(text, font, size) {
+			lines = append(lines, pdfLine{font: font, size: size, text: wrapped})
+		}
+	}
+
+	add("F2", 20, article.Title)
+	meta := article.CreatedAt.Format("January 2, 2006")
+	if article.Author != nil {
+		meta = article.Author.Username + " · " + meta
+	}
+	if len(article.TagList) > 0 {
+		meta += " · #" + strings.Join(article.TagList, " #")
+	}
+	add("F1", 9, meta)
+	add("F1", 11, "")
+	if article.Description != "" {
+		add("F1", 12, article.Description)
+		add("F1", 11, "")
+	}
+
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(article.Body, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if inFence {
+			add("F3", 9, line)
+		} else {
+			add("F1", 11, line)
+		}
+	}
+
+	if unsupported != 0 {
+		return nil, fmt.Errorf("the standard PDF fonts cannot show %q", unsupported)
+	}
+
+	return pdfDocument(article.Title, pdfPaginate(lines)), nil
+}
+
+// pdfWrap breaks text into lines that fit the page width. Widths are
+// estimated per character, which is exact for Courier and generous for Helvetica.
+func pdfWrap(text, font string, size float64) []string {
+	charWidth := 0.55 * size
+	if font == "F3" {
+		charWidth = 0.6 * size
+	}
+	maxChars := int(float64(pdfPageWidth-2*pdfMargin) / charWidth)
+
+	runes := []rune(strings.TrimRight(text, " "))
+	if len(runes) <= maxChars {
+		return []string{string(runes)}
+	}
+
+	var wrapped []string
+	for len(runes) > maxChars {
+		cut := maxChars
+		for i := maxChars; i > maxChars/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		wrapped = append(wrapped, string(runes[:cut]))
+		runes = runes[cut:]
+		if len(runes) > 0 && runes[0] == ' ' {
+			runes = runes[1:]
+		}
+	}
+	return append(wrapped, string(runes))
+}
+
+// pdfPaginate places lines top to bottom, starting a new page when one is full,
+// and returns each page's content stream
+func pdfPaginate(lines []pdfLine) []string {
+	var pages []string
+	var page strings.Builder
+	y := float64(pdfPageHeight - pdfMargin)
+
+	for _, line := range lines {
+		leading := line.size * 1.4
+		if y-leading < pdfMargin && page.Len() > 0 {
+			pages = append(pages, page.String())
+			page.Reset()
+			y = pdfPageHeight - pdfMargin
+		}
+		y -= leading
+		if line.text != "" {
+			fmt.Fprintf(&page, "BT /%s %.0f Tf 1 0 0 1 %d %.1f Tm (%s) Tj ET\n", line.font, line.size, pdfMargin, y, pdfString(line.text))
+		}
+	}
+	return append(pages, page.String())
+}
+
+// pdfEncodable reports whether the standard fonts can show r in WinAnsi
+func pdfEncodable(r rune) bool {
+	_, typographic := pdfWinAnsi[r]
+	return (r >= 0x20 && r < 0x7f) || (r >= 0xa0 && r <= 0xff) || typographic
+}
+
+// pdfString encodes text for a PDF string literal in WinAnsi. Render has
+// already refused text with characters outside it.
+func pdfString(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&out, "\\%03o", r)
+		default:
+			fmt.Fprintf(&out, "\\%03o", pdfWinAnsi[r])
+		}
+	}
+	return out.String()
+}
+
+// pdfDocument assembles the catalog, fonts, pages and cross-reference table
+func pdfDocument(title string, pages []string) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects: catalog, page tree, fonts, then a page and its content per page
+	firstPage := 3 + len(pdfFonts)
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	fonts := make([]string, len(pdfFonts))
+	for i, font := range pdfFonts {
+		fonts[i] = fmt.Sprintf("/%s %d 0 R", font.name, 3+i)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	for _, font := range pdfFonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.base))
+	}
+	for i, content := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, strings.Join(fonts, " "), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Conduit) >>", pdfString(title)))
+	info := len(offsets)
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, info, xref)
+	return buf.Bytes()
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+func TestPDFRenderer_Render(t *testing.T) {
+	article := &entities.Article{
+		Title:       "Notes (draft) on Go",
+		Description: "Café talk",
+		Body:        strings.Repeat("A line of text that fills up the page. ", 400) + "\n\n```\nfmt.Println(\"hi\")\n```\n“Quoted” – done…",
+		Author:      &entities.User{Username: "writer"},
+		CreatedAt:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		TagList:     []string{"go"},
+	}
+
+	pdf, err := NewPDFRenderer().Render(article)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatalf("Expected a PDF header and trailer")
+	}
+
+	// Every cross-reference entry points at the start of its object
+	xref := regexp.MustCompile(`(?m)^(\d{10}) 00000 n $`).FindAllSubmatch(pdf, -1)
+	if len(xref) == 0 {
+		t.Fatal("Expected cross-reference entries")
+	}
+	for i, entry := range xref {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("Object %d is not at offset %d", i+1, offset)
+		}
+	}
+
+	if pages := bytes.Count(pdf, []byte("/Type /Page ")); pages < 2 {
+		t.Errorf("Expected a long body to span pages, got %d", pages)
+	}
+	for _, want := range []string{`(Notes \(draft\) on Go)`, `(Caf\351 talk)`, `/F3 9 Tf`, `(\223Quoted\224 \226 done\205)`, `(writer \267 May 1, 2024 \267 #go)`} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("Expected %s in the document", want)
+		}
+	}
+}
+
+func TestPDFRenderer_RefusesUnsupportedCharacters(t *testing.T) {
+	article := &entities.Article{
+		Title:     "Hello",
+		Body:      "Plain text, then 한국어",
+		CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	pdf, err := NewPDFRenderer().Render(article)
+	if err == nil {
+		t.Fatalf("Expected Korean text to be refused, got a %d byte document", len(pdf))
+	}
+	if !strings.Contains(err.Error(), "한") {
+		t.Errorf("Expected the error to name the character, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// encryptedPrefix marks column values sealed by a PII cipher; other values
+// are legacy plaintext written before encryption was enabled
+const encryptedPrefix = "enc:"
+
+// PIIKey is one AES-256 key, identified in every value it encrypts
+type PIIKey struct {
+	ID  string
+	Key []byte
+}
+
+// piiCipher implements repositories.FieldCipher with AES-256-GCM and
+// HMAC-SHA256 blind indexes
+type piiCipher struct {
+	current  string
+	keys     map[string]cipher.AEAD
+	indexKey []byte
+}
+
+// NewPIICipher creates a cipher that encrypts with the first key and decrypts
+// with any of them, so keys can be rotated without downtime
+func NewPIICipher(keys []PIIKey, indexKey []byte) (repositories.FieldCipher, error) {
+	c, err := newPIICipher(keys)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexKey) < 32 {
+		return nil, fmt.Errorf("blind index key must be at least 32 bytes")
+	}
+	c.indexKey = indexKey
+	return c, nil
+}
+
+// SecretCipher seals secrets the server only needs to read back, never to look up
+type SecretCipher interface {
+	Encrypt(plaintext string) (string, error)
+	// Decrypt returns values that were never encrypted unchanged
+	Decrypt(stored string) (string, error)
+	// Current reports whether stored is encrypted with the current key
+	Current(stored string) bool
+}
+
+// NewSecretCipher creates a cipher like NewPIICipher's, but without blind
+// indexes, for secrets such as signing keys
+func NewSecretCipher(keys []PIIKey) (SecretCipher, error) {
+	c, err := newPIICipher(keys)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newPIICipher sets up the AES-256-GCM keys, the first one current
+func newPIICipher(keys []PIIKey) (*piiCipher, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one encryption key is required")
+	}
+
+	c := &piiCipher{
+		current: keys[0].ID,
+		keys:    make(map[string]cipher.AEAD),
+	}
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ":") {
+			return nil, fmt.Errorf("key IDs must be non-empty and must not contain ':'")
+		}
+		if _, ok := c.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		if len(key.Key) != 32 {
+			return nil, fmt.Errorf("key %q must be 32 bytes", key.ID)
+		}
+		block, err := aes.NewCipher(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		c.keys[key.ID] = aead
+	}
+	return c, nil
+}
+
+// ParsePIIKeys parses a comma-separated list of id:base64key pairs, current key first
+func ParsePIIKeys(spec string) ([]PIIKey, error) {
+	keys := []PIIKey{}
+	for _, entry := range strings.Split(spec, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("key %q must be written as id:base64key", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not valid base64: %w", id, err)
+		}
+		keys = append(keys, PIIKey{ID: id, Key: key})
+	}
+	return keys, nil
+}
+
+// GeneratePIIKey returns a new random key, base64 encoded
+func GeneratePIIKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt seals plaintext with the current key as "enc:<key id>:<base64 nonce+ciphertext>"
+func (c *piiCipher) Encrypt(plaintext string) (string, error) {
+	aead := c.keys[c.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + c.current + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value sealed with any configured key; legacy plaintext is returned unchanged
+func (c *piiCipher) Decrypt(stored string) (string, error) {
+	if !strings.HasPrefix(stored, encryptedPrefix) {
+		return stored, nil
+	}
+
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(stored, encryptedPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	aead, ok := c.keys[id]
+	if !ok {
+		return "", fmt.Errorf("value is encrypted with unknown key %q", id)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %q: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a deterministic keyed hash of value for equality lookups
+func (c *piiCipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Current reports whether stored is already encrypted with the current key
+func (c *piiCipher) Current(stored string) bool {
+	return strings.HasPrefix(stored, encryptedPrefix+c.current+":")
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func testPIIKeys(ids ...string) []PIIKey {
+	keys := []PIIKey{}
+	for _, id := range ids {
+		keys = append(keys, PIIKey{ID: id, Key: bytes.Repeat([]byte(id), 32)[:32]})
+	}
+	return keys
+}
+
+func TestPIICipher_RoundTripAndRotation(t *testing.T) {
+	indexKey := bytes.Repeat([]byte{9}, 32)
+	old, err := NewPIICipher(testPIIKeys("k1"), indexKey)
+	if err != nil {
+		t.Fatalf("NewPIICipher failed: %v", err)
+	}
+
+	sealed, err := old.Encrypt("alice@example.com")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !strings.HasPrefix(sealed, "enc:k1:") || strings.Contains(sealed, "alice") {
+		t.Fatalf("Unexpected ciphertext %q", sealed)
+	}
+	if again, _ := old.Encrypt("alice@example.com"); again == sealed {
+		t.Error("Expected a fresh nonce for every encryption")
+	}
+
+	// After rotation the old key still decrypts, but values are no longer current
+	rotated, err := NewPIICipher(testPIIKeys("k2", "k1"), indexKey)
+	if err != nil {
+		t.Fatalf("NewPIICipher failed: %v", err)
+	}
+	if plaintext, err := rotated.Decrypt(sealed); err != nil || plaintext != "alice@example.com" {
+		t.Errorf("Decrypt = %q, %v", plaintext, err)
+	}
+	if rotated.Current(sealed) || !old.Current(sealed) {
+		t.Error("Expected only the k1 cipher to treat the value as current")
+	}
+	if rotated.BlindIndex("alice@example.com") != old.BlindIndex("alice@example.com") {
+		t.Error("Expected blind indexes to survive key rotation")
+	}
+
+	// Plaintext from before encryption passes through unchanged
+	if plaintext, err := rotated.Decrypt("bob@example.com"); err != nil || plaintext != "bob@example.com" {
+		t.Errorf("Decrypt of legacy value = %q, %v", plaintext, err)
+	}
+
+	unknown, _ := NewPIICipher(testPIIKeys("k3"), indexKey)
+	if _, err := unknown.Decrypt(sealed); err == nil {
+		t.Error("Expected a value sealed with an unknown key to fail")
+	}
+}
+
+func TestParsePIIKeys(t *testing.T) {
+	keys, err := ParsePIIKeys("2:AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA=, 1:ICEiIyQlJicoKSorLC0uLzAxMjM0NTY3ODk6Ozw9Pj8=")
+	if err != nil {
+		t.Fatalf("ParsePIIKeys failed: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "2" || len(keys[0].Key) != 32 || keys[1].ID != "1" {
+		t.Errorf("Unexpected keys %+v", keys)
+	}
+
+	if _, err := ParsePIIKeys("no-separator"); err == nil {
+		t.Error("Expected an entry without an id to fail")
+	}
+	if _, err := NewPIICipher([]PIIKey{{ID: "short", Key: []byte("too short")}}, bytes.Repeat([]byte{9}, 32)); err == nil {
+		t.Error("Expected a short key to be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// PushNotifier delivers notifications to every browser a user subscribed
+type PushNotifier interface {
+	Push(notification *entities.Notification) error
+}
+
+// pushNotifier implements PushNotifier
+type pushNotifier struct {
+	subscriptionRepo repositories.PushSubscriptionRepository
+	sender           WebPushSender
+}
+
+// NewPushNotifier creates a push notifier that forgets subscriptions once
+// they expire or the push service reports them gone
+func NewPushNotifier(subscriptionRepo repositories.PushSubscriptionRepository, sender WebPushSender) PushNotifier {
+	return &pushNotifier{
+		subscriptionRepo: subscriptionRepo,
+		sender:           sender,
+	}
+}
+
+// Push sends the notification to the user's subscriptions. A failing browser
+// does not stop delivery to the others; the first failure is returned.
+func (p *pushNotifier) Push(notification *entities.Notification) error {
+	subscriptions, err := p.subscriptionRepo.ListByUser(notification.UserID)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(entities.PushMessage{
+		Type:    notification.EventType,
+		Message: notification.Message,
+		Link:    notification.Link,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode push message: %w", err)
+	}
+
+	var firstErr error
+	now := time.Now()
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+
+		err := ErrPushSubscriptionGone
+		if !subscription.IsExpired(now) {
+			err = p.sender.Send(subscription, payload)
+		}
+
+		if errors.Is(err, ErrPushSubscriptionGone) {
+			if err := p.subscriptionRepo.DeleteByEndpoint(subscription.Endpoint); err != nil {
+				log.Printf("⚠️  Failed to prune push subscription %d: %v", subscription.ID, err)
+			}
+			continue
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+type fakePushSubscriptionRepo struct {
+	subscriptions []entities.PushSubscription
+	deleted       []string
+}
+
+func (r *fakePushSubscriptionRepo) Save(int64, *entities.PushSubscriptionCreate) (*entities.PushSubscription, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakePushSubscriptionRepo) ListByUser(int64) ([]entities.PushSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *fakePushSubscriptionRepo) Delete(int64, int64) error {
+	return errors.New("not implemented")
+}
+
+func (r *fakePushSubscriptionRepo) DeleteByEndpoint(endpoint string) error {
+	r.deleted = append(r.deleted, endpoint)
+	return nil
+}
+
+type fakePushSender struct {
+	gone map[string]bool
+	sent []string
+}
+
+func (s *fakePushSender) Send(subscription *entities.PushSubscription, payload []byte) error {
+	if s.gone[subscription.Endpoint] {
+		return ErrPushSubscriptionGone
+	}
+	var message entities.PushMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+	s.sent = append(s.sent, subscription.Endpoint)
+	return nil
+}
+
+func TestPushNotifier_PrunesGoneAndExpiredSubscriptions(t *testing.T) {
+	expired := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakePushSubscriptionRepo{subscriptions: []entities.PushSubscription{
+		{ID: 1, Endpoint: "https://push.example/live"},
+		{ID: 2, Endpoint: "https://push.example/gone"},
+		{ID: 3, Endpoint: "https://push.example/expired", ExpiresAt: &expired},
+	}}
+	sender := &fakePushSender{gone: map[string]bool{"https://push.example/gone": true}}
+
+	notifier := NewPushNotifier(repo, sender)
+	if err := notifier.Push(&entities.Notification{UserID: 1, EventType: entities.EventNewFollower, Message: "New follower"}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	if len(sender.sent) != 1 || sender.sent[0] != "https://push.example/live" {
+		t.Errorf("Sent to %v, want only the live endpoint", sender.sent)
+	}
+	if len(repo.deleted) != 2 {
+		t.Errorf("Pruned %v, want the gone and expired endpoints", repo.deleted)
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// quotaWindow is the rolling window daily quotas are counted over
+const quotaWindow = 24 * time.Hour
+
+// QuotaService enforces daily caps on the articles and comments an account creates
+type QuotaService interface {
+	// Check returns the user's cap for kind and how long they must wait before
+	// creating more, or zero if allowed
+	Check(userID int64, kind string) (int, time.Duration, error)
+	// Status reports the user's tier, effective quota and usage
+	Status(user *entities.User) (*entities.QuotaStatus, error)
+}
+
+// quotaService implements QuotaService on top of stored article and comment history
+type quotaService struct {
+	articleRepo    repositories.ArticleRepository
+	commentRepo    repositories.CommentRepository
+	userRepo       repositories.UserRepository
+	quotaRepo      repositories.QuotaRepository
+	newQuota       entities.DailyQuota
+	trustedQuota   entities.DailyQuota
+	trustedAccount time.Duration
+	now            func() time.Time
+}
+
+// NewQuotaService creates a quota service. Accounts older than
+// trustedAccountAge get trustedQuota; newer and rate-limited accounts get
+// newQuota. Moderators and admins have no quota, and an admin's override
+// replaces the tier's caps for one account.
+func NewQuotaService(articleRepo repositories.ArticleRepository, commentRepo repositories.CommentRepository, userRepo repositories.UserRepository, quotaRepo repositories.QuotaRepository, newQuota, trustedQuota entities.DailyQuota, trustedAccountAge time.Duration) QuotaService {
+	return &quotaService{
+		articleRepo:    articleRepo,
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		quotaRepo:      quotaRepo,
+		newQuota:       newQuota,
+		trustedQuota:   trustedQuota,
+		trustedAccount: trustedAccountAge,
+		now:            time.Now,
+	}
+}
+
+// Check returns the remaining wait before the user's next article or comment
+func (s *quotaService) Check(userID int64, kind string) (int, time.Duration, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	quota, _, err := s.quota(user)
+	if err != nil {
+		return 0, 0, err
+	}
+	limit := quota.Limit(kind)
+	if limit == 0 {
+		return 0, 0, nil
+	}
+
+	now := s.now()
+	recent, err := s.recent(user.ID, kind, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(recent) < limit {
+		return limit, 0, nil
+	}
+
+	// Wait until enough of the oldest items leave the window
+	expiring := recent[len(recent)-limit]
+	return limit, expiring.Add(quotaWindow).Sub(now), nil
+}
+
+// Status reports the user's quota and what they created in the last 24 hours
+func (s *quotaService) Status(user *entities.User) (*entities.QuotaStatus, error) {
+	quota, override, err := s.quota(user)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &entities.QuotaStatus{
+		Username: user.Username,
+		Tier:     s.tier(user),
+		Quota:    quota,
+		Override: override,
+	}
+
+	now := s.now()
+	articles, err := s.recent(user.ID, entities.QuotaArticles, now)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.recent(user.ID, entities.QuotaComments, now)
+	if err != nil {
+		return nil, err
+	}
+	status.Used = entities.DailyQuota{Articles: len(articles), Comments: len(comments)}
+
+	return status, nil
+}
+
+// tier places the user in the staff, established or new tier
+func (s *quotaService) tier(user *entities.User) string {
+	if user.IsModerator() {
+		return entities.QuotaTierStaff
+	}
+	if !user.IsRateLimited() && !user.CreatedAt.IsZero() && s.now().Sub(user.CreatedAt) >= s.trustedAccount {
+		return entities.QuotaTierEstablished
+	}
+	return entities.QuotaTierNew
+}
+
+// quota returns the user's effective quota along with their override, if any
+func (s *quotaService) quota(user *entities.User) (entities.DailyQuota, *entities.QuotaOverride, error) {
+	var quota entities.DailyQuota
+	switch s.tier(user) {
+	case entities.QuotaTierEstablished:
+		quota = s.trustedQuota
+	case entities.QuotaTierNew:
+		quota = s.newQuota
+	}
+
+	override, err := s.quotaRepo.GetOverride(user.ID)
+	if err != nil {
+		return entities.DailyQuota{}, nil, err
+	}
+	return override.Apply(quota), override, nil
+}
+
+// recent returns the creation times of the user's articles or comments in the window
+func (s *quotaService) recent(userID int64, kind string, now time.Time) ([]time.Time, error) {
+	if kind == entities.QuotaArticles {
+		return s.articleRepo.ListRecentTimesByAuthor(userID, now.Add(-quotaWindow))
+	}
+	return s.commentRepo.ListRecentTimesByAuthor(userID, now.Add(-quotaWindow))
+}
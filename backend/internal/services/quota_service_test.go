@@ -0,0 +1,114 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+type fakeRecentArticleRepo struct {
+	repositories.ArticleRepository
+	times []time.Time
+}
+
+func (r *fakeRecentArticleRepo) ListRecentTimesByAuthor(authorID int64, since time.Time) ([]time.Time, error) {
+	var recent []time.Time
+	for _, createdAt := range r.times {
+		if createdAt.After(since) {
+			recent = append(recent, createdAt)
+		}
+	}
+	return recent, nil
+}
+
+type fakeQuotaRepo struct {
+	repositories.QuotaRepository
+	override *entities.QuotaOverride
+}
+
+func (r *fakeQuotaRepo) GetOverride(userID int64) (*entities.QuotaOverride, error) {
+	return r.override, nil
+}
+
+func newTestQuotaService(now time.Time, accountAge time.Duration, override *entities.QuotaOverride, articles ...time.Time) QuotaService {
+	quotas := NewQuotaService(
+		&fakeRecentArticleRepo{times: articles},
+		&fakeCommentRepo{},
+		&fakeAccountRepo{createdAt: now.Add(-accountAge)},
+		&fakeQuotaRepo{override: override},
+		entities.DailyQuota{Articles: 2, Comments: 10},
+		entities.DailyQuota{Articles: 5, Comments: 50},
+		30*24*time.Hour,
+	)
+	quotas.(*quotaService).now = func() time.Time { return now }
+	return quotas
+}
+
+func TestQuotaService_NewAccountCap(t *testing.T) {
+	now := time.Now()
+	oldest := now.Add(-20 * time.Hour)
+	quotas := newTestQuotaService(now, time.Hour, nil, oldest, now.Add(-time.Hour))
+
+	limit, retryAfter, err := quotas.Check(1, entities.QuotaArticles)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if limit != 2 {
+		t.Errorf("Expected the new-account cap of 2, got %d", limit)
+	}
+	// The oldest article leaves the 24 hour window in 4 hours
+	if retryAfter != 4*time.Hour {
+		t.Errorf("Expected retry after 4h, got %v", retryAfter)
+	}
+
+	if _, retryAfter, _ := quotas.Check(1, entities.QuotaComments); retryAfter != 0 {
+		t.Errorf("Expected comments to be allowed, got retry after %v", retryAfter)
+	}
+}
+
+func TestQuotaService_EstablishedAccountCap(t *testing.T) {
+	now := time.Now()
+	quotas := newTestQuotaService(now, 60*24*time.Hour, nil, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	limit, retryAfter, err := quotas.Check(1, entities.QuotaArticles)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if limit != 5 || retryAfter != 0 {
+		t.Errorf("Expected cap 5 and no wait, got %d and %v", limit, retryAfter)
+	}
+}
+
+func TestQuotaService_OverrideReplacesTierCap(t *testing.T) {
+	now := time.Now()
+	unlimited := 0
+	quotas := newTestQuotaService(now, time.Hour, &entities.QuotaOverride{Articles: &unlimited},
+		now.Add(-3*time.Hour), now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	if _, retryAfter, _ := quotas.Check(1, entities.QuotaArticles); retryAfter != 0 {
+		t.Errorf("Expected an override of 0 to remove the cap, got retry after %v", retryAfter)
+	}
+
+	status, err := quotas.Status(&entities.User{ID: 1, Username: "writer", CreatedAt: now.Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status.Tier != entities.QuotaTierNew || status.Quota.Articles != 0 || status.Quota.Comments != 10 || status.Used.Articles != 3 {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+}
+
+func TestQuotaService_StaffHaveNoQuota(t *testing.T) {
+	now := time.Now()
+	quotas := newTestQuotaService(now, time.Hour, nil)
+
+	status, err := quotas.Status(&entities.User{ID: 1, Role: entities.RoleModerator, CreatedAt: now})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status.Tier != entities.QuotaTierStaff || status.Quota != (entities.DailyQuota{}) {
+		t.Errorf("Expected staff to have no quota, got %+v", status)
+	}
+}
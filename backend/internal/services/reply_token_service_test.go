@@ -0,0 +1,51 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplyTokenService_RoundTrip(t *testing.T) {
+	service := NewReplyTokenService("test-secret-key", NewTokenPolicy(TokenLifetimes{Reply: 30 * 24 * time.Hour}, 0))
+
+	token := service.GenerateToken(7, 42)
+	if len("reply+"+token) > 64 {
+		t.Errorf("Expected token to fit in an email local part, got %d characters", len(token))
+	}
+
+	userID, articleID, err := service.ParseToken(token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if userID != 7 || articleID != 42 {
+		t.Errorf("Expected user 7 and article 42, got %d and %d", userID, articleID)
+	}
+}
+
+func TestReplyTokenService_RejectsTampering(t *testing.T) {
+	service := NewReplyTokenService("test-secret-key", NewTokenPolicy(TokenLifetimes{Reply: 30 * 24 * time.Hour}, 0))
+	token := service.GenerateToken(7, 42)
+
+	tampered := "8" + strings.TrimPrefix(token, "7")
+	if _, _, err := service.ParseToken(tampered); err == nil {
+		t.Error("Expected error for tampered token")
+	}
+
+	other := NewReplyTokenService("other-secret", NewTokenPolicy(TokenLifetimes{Reply: 30 * 24 * time.Hour}, 0))
+	if _, _, err := other.ParseToken(token); err == nil {
+		t.Error("Expected error for token signed with a different secret")
+	}
+
+	if _, _, err := service.ParseToken("garbage"); err == nil {
+		t.Error("Expected error for malformed token")
+	}
+}
+
+func TestReplyTokenService_Expired(t *testing.T) {
+	service := NewReplyTokenService("test-secret-key", NewTokenPolicy(TokenLifetimes{Reply: -24 * time.Hour}, 0))
+
+	if _, _, err := service.ParseToken(service.GenerateToken(7, 42)); err == nil {
+		t.Error("Expected error for expired token")
+	}
+}
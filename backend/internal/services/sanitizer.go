@@ -0,0 +1,472 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// SanitizerOptions configures how stored user content is cleaned for display
+type SanitizerOptions struct {
+	// AllowHTML keeps allowlisted inline HTML tags; when false all HTML is escaped
+	AllowHTML bool
+	// ExternalLinkRel is set as the rel attribute of HTML links to other sites
+	ExternalLinkRel string
+	// InternalHosts are hosts whose links are not treated as external
+	InternalHosts []string
+	// ImageProxyURL, when set, routes every image through the proxy as
+	// <ImageProxyURL>?url=<escaped image URL>
+	ImageProxyURL string
+	// EmbedProviders names the media sites (see EmbedProviderNames) whose
+	// links, alone on a line, become embedded players; needs AllowHTML
+	EmbedProviders []string
+}
+
+// Sanitizer cleans user-authored Markdown (with optional inline HTML) for display
+type Sanitizer interface {
+	Sanitize(body string) string
+}
+
+// allowedTags lists inline HTML tags kept when AllowHTML is set, with their
+// permitted attributes; everything else is escaped
+var allowedTags = map[string][]string{
+	"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+	"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil, "del": nil,
+	"code": nil, "pre": nil, "kbd": nil, "sub": nil, "sup": nil, "mark": nil,
+	"p": nil, "br": nil, "hr": nil, "blockquote": nil, "ul": nil, "ol": nil, "li": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": nil, "td": nil,
+	"details": nil, "summary": nil,
+}
+
+// embedProvider turns a link to a media site into an embedded player
+type embedProvider struct {
+	title string
+	// pattern matches the link and captures the media ID
+	pattern *regexp.Regexp
+	// player is the iframe source with %s for the media ID
+	player string
+}
+
+// embedProviders lists the media sites whose links can be embedded, by name.
+// Players are sandboxed and load lazily; YouTube's is the no-cookie variant.
+var embedProviders = map[string]embedProvider{
+	"youtube": {
+		title:   "YouTube video",
+		pattern: regexp.MustCompile(`^https?://(?:www\.|m\.)?(?:youtube\.com/watch\?(?:[^#\s]*&)?v=|youtu\.be/)([A-Za-z0-9_-]{11})(?:[?&#][^\s]*)?$`),
+		player:  "https://www.youtube-nocookie.com/embed/%s",
+	},
+	"vimeo": {
+		title:   "Vimeo video",
+		pattern: regexp.MustCompile(`^https?://(?:www\.)?vimeo\.com/([0-9]+)(?:[?#][^\s]*)?$`),
+		player:  "https://player.vimeo.com/video/%s",
+	},
+	"twitter": {
+		title:   "Post on X",
+		pattern: regexp.MustCompile(`^https?://(?:www\.|mobile\.)?(?:twitter|x)\.com/[A-Za-z0-9_]{1,15}/status/([0-9]+)(?:[?#][^\s]*)?$`),
+		player:  "https://platform.twitter.com/embed/Tweet.html?id=%s",
+	},
+}
+
+// EmbedProviderNames lists the media sites that can be embedded
+func EmbedProviderNames() []string {
+	names := make([]string, 0, len(embedProviders))
+	for name := range embedProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// strippedElements are removed together with their content
+var strippedElements = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)<script\b[^>]*>.*?</script\s*>`),
+	regexp.MustCompile(`(?is)<style\b[^>]*>.*?</style\s*>`),
+	regexp.MustCompile(`(?is)<iframe\b[^>]*>.*?</iframe\s*>`),
+	regexp.MustCompile(`(?is)<object\b[^>]*>.*?</object\s*>`),
+	regexp.MustCompile(`(?is)<noscript\b[^>]*>.*?</noscript\s*>`),
+	regexp.MustCompile(`(?is)<template\b[^>]*>.*?</template\s*>`),
+}
+
+var (
+	// codeRegex matches fenced code blocks and inline code spans, which are left verbatim
+	codeRegex = regexp.MustCompile("(?ms)^[ \t]*(?:```|~~~).*?^[ \t]*(?:```|~~~)[ \t]*$|`[^`\n]+`")
+	// tagRegex matches an HTML tag or a Markdown autolink such as <https://example.com>
+	tagRegex = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)([^<>]*)>`)
+	// autolinkRegex matches the inside of a Markdown autolink
+	autolinkRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:[^\s<>]*$`)
+	// attributeRegex matches one HTML attribute with an optional value
+	attributeRegex = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)(?:\s*=\s*("[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?`)
+	// markdownLinkRegex matches Markdown links and images: [text](url "title").
+	// URLs may contain one level of balanced parentheses.
+	markdownLinkRegex = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*<?((?:[^()\s<>]|\([^()\s]*\))*)>?((?:\s+[^)]*)?)\)`)
+	// strayTagRegex matches a "<" that would start markup but is not a complete tag
+	strayTagRegex = regexp.MustCompile(`<([a-zA-Z/!?])`)
+	// standaloneLinkRegex matches a bare link alone on its line, a candidate for embedding
+	standaloneLinkRegex = regexp.MustCompile(`(?m)^[ \t]*(https?://[^\s<>]+)[ \t]*(\r?)$`)
+	// embedRegex matches an embedded player as written by the sanitizer
+	embedRegex = regexp.MustCompile(`<iframe src="([^"]*)"[^>]*></iframe>`)
+)
+
+// sanitizer implements Sanitizer with an allowlist
+type sanitizer struct {
+	options       SanitizerOptions
+	internalHosts map[string]bool
+	embeds        []embedProvider
+}
+
+// NewSanitizer creates a sanitizer with the given options
+func NewSanitizer(options SanitizerOptions) Sanitizer {
+	hosts := make(map[string]bool, len(options.InternalHosts))
+	for _, host := range options.InternalHosts {
+		if host != "" {
+			hosts[strings.ToLower(host)] = true
+		}
+	}
+
+	// Embedded players are HTML, so they are only added where HTML is kept
+	var embeds []embedProvider
+	if options.AllowHTML {
+		for _, name := range options.EmbedProviders {
+			if provider, ok := embedProviders[strings.ToLower(strings.TrimSpace(name))]; ok {
+				embeds = append(embeds, provider)
+			}
+		}
+	}
+
+	return &sanitizer{
+		options:       options,
+		internalHosts: hosts,
+		embeds:        embeds,
+	}
+}
+
+// Sanitize strips scripts and disallowed markup, drops unsafe link schemes,
+// marks external HTML links, proxies images and embeds links to allowed media
+// sites. Code is left untouched.
+// Markdown links cannot carry attributes, so rel is left to the renderer.
+func (s *sanitizer) Sanitize(body string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range codeRegex.FindAllStringIndex(body, -1) {
+		out.WriteString(s.sanitizeText(body[last:loc[0]]))
+		out.WriteString(body[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	out.WriteString(s.sanitizeText(body[last:]))
+	return out.String()
+}
+
+// PlainText turns a sanitized body into readable text for pages that show it
+// without rendering Markdown, such as the print view. Embedded players become
+// their links, other HTML tags are dropped but their content is kept, and
+// entities are decoded. Code is left as written.
+func PlainText(sanitized string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range codeRegex.FindAllStringIndex(sanitized, -1) {
+		out.WriteString(plainText(sanitized[last:loc[0]]))
+		out.WriteString(sanitized[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	out.WriteString(plainText(sanitized[last:]))
+	return out.String()
+}
+
+// plainText converts sanitized Markdown outside of code to text
+func plainText(text string) string {
+	text = embedRegex.ReplaceAllString(text, "$1")
+	text = tagRegex.ReplaceAllStringFunc(text, func(tag string) string {
+		// Autolinks read fine without their brackets
+		if inner := strings.Trim(tag, "<>"); autolinkRegex.MatchString(inner) {
+			return inner
+		}
+		return ""
+	})
+	return html.UnescapeString(text)
+}
+
+// sanitizeText sanitizes Markdown outside of code
+func (s *sanitizer) sanitizeText(text string) string {
+	for _, element := range strippedElements {
+		text = element.ReplaceAllString(text, "")
+	}
+
+	text = markdownLinkRegex.ReplaceAllStringFunc(text, s.sanitizeMarkdownLink)
+
+	var out strings.Builder
+	last := 0
+	for _, loc := range tagRegex.FindAllStringIndex(text, -1) {
+		out.WriteString(escapeStrayTags(text[last:loc[0]]))
+		out.WriteString(s.sanitizeTag(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	out.WriteString(escapeStrayTags(text[last:]))
+
+	if len(s.embeds) == 0 {
+		return out.String()
+	}
+	return standaloneLinkRegex.ReplaceAllStringFunc(out.String(), s.embed)
+}
+
+// embed replaces a standalone link to an allowed media site with its player
+func (s *sanitizer) embed(line string) string {
+	parts := standaloneLinkRegex.FindStringSubmatch(line)
+	for _, provider := range s.embeds {
+		match := provider.pattern.FindStringSubmatch(parts[1])
+		if match == nil {
+			continue
+		}
+		player := fmt.Sprintf(provider.player, match[1])
+		return `<iframe src="` + html.EscapeString(player) + `" title="` + provider.title + `"` +
+			` loading="lazy" sandbox="allow-scripts allow-same-origin allow-popups allow-presentation"` +
+			` allow="fullscreen; picture-in-picture" referrerpolicy="strict-origin-when-cross-origin"></iframe>` + parts[2]
+	}
+	return line
+}
+
+// sanitizeMarkdownLink normalizes the URL of a Markdown link or image
+func (s *sanitizer) sanitizeMarkdownLink(match string) string {
+	parts := markdownLinkRegex.FindStringSubmatch(match)
+	image, text, rawURL, title := parts[1] == "!", parts[2], parts[3], parts[4]
+
+	link, ok := safeURL(rawURL)
+	if !ok {
+		link = "#"
+	} else if image {
+		link = s.proxyImage(link)
+	}
+
+	return parts[1] + "[" + text + "](" + link + title + ")"
+}
+
+// sanitizeTag rebuilds an allowlisted tag with only safe attributes, and escapes any other tag
+func (s *sanitizer) sanitizeTag(tag string) string {
+	parts := tagRegex.FindStringSubmatch(tag)
+	closing, name, rest := parts[1] == "/", strings.ToLower(parts[2]), parts[3]
+
+	// Markdown autolink, e.g. <https://example.com>
+	if !closing && autolinkRegex.MatchString(parts[2]+rest) {
+		if _, ok := safeURL(parts[2] + rest); ok {
+			return tag
+		}
+		return html.EscapeString(tag)
+	}
+
+	allowedAttributes, allowed := allowedTags[name]
+	if !s.options.AllowHTML || !allowed {
+		return html.EscapeString(tag)
+	}
+	if closing {
+		return "</" + name + ">"
+	}
+
+	var out strings.Builder
+	out.WriteString("<" + name)
+
+	external := false
+	for _, attribute := range attributeRegex.FindAllStringSubmatch(rest, -1) {
+		key := strings.ToLower(attribute[1])
+		if !containsAttribute(allowedAttributes, key) {
+			continue
+		}
+
+		value := html.UnescapeString(strings.Trim(attribute[2], `"'`))
+		if key == "href" || key == "src" {
+			link, ok := safeURL(value)
+			if !ok {
+				continue
+			}
+			if key == "href" {
+				external = s.isExternal(link)
+			} else {
+				link = s.proxyImage(link)
+			}
+			value = link
+		}
+
+		out.WriteString(" " + key + `="` + html.EscapeString(value) + `"`)
+	}
+
+	if name == "a" && external && s.options.ExternalLinkRel != "" {
+		out.WriteString(` rel="` + html.EscapeString(s.options.ExternalLinkRel) + `"`)
+	}
+
+	if strings.HasSuffix(strings.TrimSpace(rest), "/") {
+		out.WriteString(" /")
+	}
+	out.WriteString(">")
+	return out.String()
+}
+
+// isExternal reports whether an absolute link points at another site
+func (s *sanitizer) isExternal(link string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	return !s.internalHosts[strings.ToLower(parsed.Host)]
+}
+
+// proxyImage routes an absolute image URL through the configured proxy
+func (s *sanitizer) proxyImage(link string) string {
+	if s.options.ImageProxyURL == "" || !strings.Contains(link, "://") || strings.HasPrefix(link, s.options.ImageProxyURL) {
+		return link
+	}
+	return s.options.ImageProxyURL + "?url=" + url.QueryEscape(link)
+}
+
+// safeURL normalizes a link and reports whether it is safe to keep. Relative
+// links and the http, https and mailto schemes are allowed.
+func safeURL(raw string) (string, bool) {
+	// Browsers ignore control characters and whitespace inside schemes ("java\tscript:")
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(raw))
+
+	parsed, err := url.Parse(cleaned)
+	if err != nil {
+		return "", false
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return parsed.String(), true
+	default:
+		return "", false
+	}
+}
+
+// escapeStrayTags escapes "<" that would open markup in text that contains no complete tag
+func escapeStrayTags(text string) string {
+	return strayTagRegex.ReplaceAllString(text, "&lt;$1")
+}
+
+// containsAttribute reports whether attributes contains name
+func containsAttribute(attributes []string, name string) bool {
+	for _, attribute := range attributes {
+		if attribute == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizingArticleRepository sanitizes article bodies as they are read
+type sanitizingArticleRepository struct {
+	repositories.ArticleRepository
+	sanitizer Sanitizer
+}
+
+// NewSanitizingArticleRepository wraps an article repository so that every
+// article it returns has a sanitized body. Stored bodies are left as written,
+// so rule changes apply to existing content on the next read.
+func NewSanitizingArticleRepository(repo repositories.ArticleRepository, sanitizer Sanitizer) repositories.ArticleRepository {
+	return &sanitizingArticleRepository{
+		ArticleRepository: repo,
+		sanitizer:         sanitizer,
+	}
+}
+
+// Create creates the article and returns it sanitized
+func (r *sanitizingArticleRepository) Create(authorID int64, article *entities.ArticleCreate) (*entities.Article, error) {
+	return r.sanitize(r.ArticleRepository.Create(authorID, article))
+}
+
+// GetBySlug returns the sanitized article
+func (r *sanitizingArticleRepository) GetBySlug(slug string) (*entities.Article, error) {
+	return r.sanitize(r.ArticleRepository.GetBySlug(slug))
+}
+
+// GetByID returns the sanitized article
+func (r *sanitizingArticleRepository) GetByID(id int64) (*entities.Article, error) {
+	return r.sanitize(r.ArticleRepository.GetByID(id))
+}
+
+// Update updates the article and returns it sanitized
+func (r *sanitizingArticleRepository) Update(id int64, updates *entities.ArticleUpdate) (*entities.Article, error) {
+	return r.sanitize(r.ArticleRepository.Update(id, updates))
+}
+
+// List returns sanitized articles
+func (r *sanitizingArticleRepository) List(query *entities.ArticleListQuery) ([]entities.Article, int, error) {
+	articles, total, err := r.ArticleRepository.List(query)
+	for i := range articles {
+		articles[i].Body = r.sanitizer.Sanitize(articles[i].Body)
+	}
+	return articles, total, err
+}
+
+// CreateTranslation creates the translation and returns it sanitized
+func (r *sanitizingArticleRepository) CreateTranslation(source *entities.Article, translation *entities.ArticleTranslationCreate) (*entities.Article, error) {
+	return r.sanitize(r.ArticleRepository.CreateTranslation(source, translation))
+}
+
+// Review records the decision and returns the article with a sanitized body
+func (r *sanitizingArticleRepository) Review(id int64, status string, reviewerID int64, note string) (*entities.Article, error) {
+	return r.sanitize(r.ArticleRepository.Review(id, status, reviewerID, note))
+}
+
+// sanitize cleans the body of a returned article
+func (r *sanitizingArticleRepository) sanitize(article *entities.Article, err error) (*entities.Article, error) {
+	if article != nil {
+		article.Body = r.sanitizer.Sanitize(article.Body)
+	}
+	return article, err
+}
+
+// sanitizingCommentRepository sanitizes comment bodies as they are read
+type sanitizingCommentRepository struct {
+	repositories.CommentRepository
+	sanitizer Sanitizer
+}
+
+// NewSanitizingCommentRepository wraps a comment repository so that every
+// comment it returns has a sanitized body
+func NewSanitizingCommentRepository(repo repositories.CommentRepository, sanitizer Sanitizer) repositories.CommentRepository {
+	return &sanitizingCommentRepository{
+		CommentRepository: repo,
+		sanitizer:         sanitizer,
+	}
+}
+
+// Create creates the comment and returns it sanitized
+func (r *sanitizingCommentRepository) Create(authorID, articleID int64, comment *entities.CommentCreate) (*entities.Comment, error) {
+	return r.sanitize(r.CommentRepository.Create(authorID, articleID, comment))
+}
+
+// GetByArticleSlug returns an article's sanitized comments
+func (r *sanitizingCommentRepository) GetByArticleSlug(slug string) ([]entities.Comment, error) {
+	comments, err := r.CommentRepository.GetByArticleSlug(slug)
+	for i := range comments {
+		comments[i].Body = r.sanitizer.Sanitize(comments[i].Body)
+	}
+	return comments, err
+}
+
+// GetByID returns the sanitized comment
+func (r *sanitizingCommentRepository) GetByID(id int64) (*entities.Comment, error) {
+	return r.sanitize(r.CommentRepository.GetByID(id))
+}
+
+// Update edits the comment and returns it sanitized
+func (r *sanitizingCommentRepository) Update(id int64, update *entities.CommentUpdate) (*entities.Comment, error) {
+	return r.sanitize(r.CommentRepository.Update(id, update))
+}
+
+// sanitize cleans the body of a returned comment
+func (r *sanitizingCommentRepository) sanitize(comment *entities.Comment, err error) (*entities.Comment, error) {
+	if comment != nil {
+		comment.Body = r.sanitizer.Sanitize(comment.Body)
+	}
+	return comment, err
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+func newTestSanitizer() Sanitizer {
+	return NewSanitizer(SanitizerOptions{
+		AllowHTML:       true,
+		ExternalLinkRel: "nofollow ugc",
+		InternalHosts:   []string{"conduit.example"},
+	})
+}
+
+func TestSanitizer_Sanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"plain markdown", "# Title\n\nSome **bold** text", "# Title\n\nSome **bold** text"},
+		{"script removed", "Hi<script>alert(1)</script> there", "Hi there"},
+		{"unclosed script escaped", "Hi <script src=x.js>", "Hi &lt;script src=x.js&gt;"},
+		{"event handler dropped", `<b onclick="steal()">bold</b>`, "<b>bold</b>"},
+		{"unknown tag escaped", `<form action="/x">`, `&lt;form action=&#34;/x&#34;&gt;`},
+		{"javascript link dropped", `<a href="javascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"obfuscated scheme dropped", `<a href="java&#x09;script:alert(1)">x</a>`, "<a>x</a>"},
+		{"external link marked", `<a href="https://other.example/p">x</a>`, `<a href="https://other.example/p" rel="nofollow ugc">x</a>`},
+		{"internal link unmarked", `<a href="https://conduit.example/article/a">x</a>`, `<a href="https://conduit.example/article/a">x</a>`},
+		{"relative link unmarked", `<a href="/article/a">x</a>`, `<a href="/article/a">x</a>`},
+		{"markdown javascript link", "[x](javascript:alert(1))", "[x](#)"},
+		{"markdown link kept", `[x](https://other.example "Title")`, `[x](https://other.example "Title")`},
+		{"autolink kept", "<https://other.example>", "<https://other.example>"},
+		{"autolink javascript escaped", "<javascript:alert(1)>", "&lt;javascript:alert(1)&gt;"},
+		{"code block untouched", "```html\n<script>alert(1)</script>\n```", "```html\n<script>alert(1)</script>\n```"},
+		{"code span untouched", "Use `<b>` for bold", "Use `<b>` for bold"},
+		{"stray tag escaped", "a <img src=x onerror=alert(1)//", "a &lt;img src=x onerror=alert(1)//"},
+		{"comparison untouched", "if a < b && b > c", "if a < b && b > c"},
+	}
+
+	sanitizer := newTestSanitizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizer.Sanitize(tt.body); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizer_DisallowHTML(t *testing.T) {
+	sanitizer := NewSanitizer(SanitizerOptions{})
+
+	got := sanitizer.Sanitize("<b>bold</b> and **bold**")
+	want := "&lt;b&gt;bold&lt;/b&gt; and **bold**"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSanitizer_ImageProxy(t *testing.T) {
+	sanitizer := NewSanitizer(SanitizerOptions{AllowHTML: true, ImageProxyURL: "https://img.example/proxy"})
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"![cat](https://cats.example/a.png)", "![cat](https://img.example/proxy?url=https%3A%2F%2Fcats.example%2Fa.png)"},
+		{`<img src="https://cats.example/a.png" alt="cat">`, `<img src="https://img.example/proxy?url=https%3A%2F%2Fcats.example%2Fa.png" alt="cat">`},
+		{"![local](/media/a.png)", "![local](/media/a.png)"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizer.Sanitize(tt.body); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizer_Embeds(t *testing.T) {
+	sanitizer := NewSanitizer(SanitizerOptions{AllowHTML: true, EmbedProviders: []string{"youtube", "twitter"}})
+	player := func(src, title string) string {
+		return `<iframe src="` + src + `" title="` + title + `" loading="lazy" sandbox="allow-scripts allow-same-origin allow-popups allow-presentation"` +
+			` allow="fullscreen; picture-in-picture" referrerpolicy="strict-origin-when-cross-origin"></iframe>`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"youtube watch link", "Intro\n\nhttps://www.youtube.com/watch?v=dQw4w9WgXcQ&t=10s\n\nOutro",
+			"Intro\n\n" + player("https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", "YouTube video") + "\n\nOutro"},
+		{"youtube short link", "  https://youtu.be/dQw4w9WgXcQ\r\n", "" + player("https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", "YouTube video") + "\r\n"},
+		{"tweet", "https://x.com/golang/status/1234567890", player("https://platform.twitter.com/embed/Tweet.html?id=1234567890", "Post on X")},
+		{"provider not allowed", "https://vimeo.com/76979871", "https://vimeo.com/76979871"},
+		{"link inside a sentence", "Watch https://youtu.be/dQw4w9WgXcQ now", "Watch https://youtu.be/dQw4w9WgXcQ now"},
+		{"lookalike host", "https://youtube.com.evil.example/watch?v=dQw4w9WgXcQ", "https://youtube.com.evil.example/watch?v=dQw4w9WgXcQ"},
+		{"code block untouched", "```\nhttps://youtu.be/dQw4w9WgXcQ\n```", "```\nhttps://youtu.be/dQw4w9WgXcQ\n```"},
+		{"raw iframe still removed", `<iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"></iframe>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizer.Sanitize(tt.body); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+
+	// Without HTML the link stays a link
+	plain := NewSanitizer(SanitizerOptions{EmbedProviders: []string{"youtube"}})
+	if got := plain.Sanitize("https://youtu.be/dQw4w9WgXcQ"); got != "https://youtu.be/dQw4w9WgXcQ" {
+		t.Errorf("Expected no embed without HTML, got %q", got)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	sanitizer := NewSanitizer(SanitizerOptions{AllowHTML: true, EmbedProviders: []string{"youtube", "twitter"}})
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"embed becomes its link", "Intro\n\nhttps://youtu.be/dQw4w9WgXcQ\n\nOutro", "Intro\n\nhttps://www.youtube-nocookie.com/embed/dQw4w9WgXcQ\n\nOutro"},
+		{"embed link decoded", "https://x.com/golang/status/1234567890", "https://platform.twitter.com/embed/Tweet.html?id=1234567890"},
+		{"tags dropped, text kept", `A <strong>bold</strong> <a href="https://other.example">link</a>`, "A bold link"},
+		{"escaped markup reads as written", "if a < b && c <d>", "if a < b && c <d>"},
+		{"autolink unwrapped", "See <https://other.example>", "See https://other.example"},
+		{"code untouched", "```html\n<b>&amp;</b>\n```\n`<i>`", "```html\n<b>&amp;</b>\n```\n`<i>`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(sanitizer.Sanitize(tt.body)); got != tt.want {
+				t.Errorf("PlainText(Sanitize(%q)) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeBodyArticleRepo struct {
+	repositories.ArticleRepository
+}
+
+func (r *fakeBodyArticleRepo) GetBySlug(slug string) (*entities.Article, error) {
+	return &entities.Article{Slug: slug, Body: "Hi<script>alert(1)</script>"}, nil
+}
+
+func (r *fakeBodyArticleRepo) List(query *entities.ArticleListQuery) ([]entities.Article, int, error) {
+	return []entities.Article{{Body: "<iframe src=x></iframe>ok"}}, 1, nil
+}
+
+func TestSanitizingArticleRepository(t *testing.T) {
+	repo := NewSanitizingArticleRepository(&fakeBodyArticleRepo{}, newTestSanitizer())
+
+	article, err := repo.GetBySlug("a")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if article.Body != "Hi" {
+		t.Errorf("Expected sanitized body, got %q", article.Body)
+	}
+
+	articles, _, err := repo.List(&entities.ArticleListQuery{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if articles[0].Body != "ok" {
+		t.Errorf("Expected sanitized list body, got %q", articles[0].Body)
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// searchPingBatchSize caps the pings delivered by one Deliver call
+const searchPingBatchSize = 50
+
+// errSearchPingRejected marks responses that retrying will not change
+var errSearchPingRejected = errors.New("rejected by search engine")
+
+// SearchPingOptions configures which search engines are told about published articles
+type SearchPingOptions struct {
+	// SiteURL is the frontend base URL articles are linked under
+	SiteURL string
+	// SitemapURL is the public address of the sitemap
+	SitemapURL string
+	// IndexNowKey enables IndexNow submissions to IndexNowEndpoint
+	IndexNowKey      string
+	IndexNowEndpoint string
+	// SitemapPingURLs are ping endpoints that take the sitemap as ?sitemap=
+	SitemapPingURLs []string
+}
+
+// SearchPinger queues search engine notifications for published articles
+// and delivers them in the background
+type SearchPinger interface {
+	Enqueue(article *entities.Article) error
+	Deliver() (int, error)
+}
+
+// searchPinger implements SearchPinger
+type searchPinger struct {
+	repo    repositories.SearchPingRepository
+	client  *http.Client
+	options SearchPingOptions
+}
+
+// NewSearchPinger creates a search pinger; with no IndexNow key and no sitemap
+// ping URLs nothing is ever queued
+func NewSearchPinger(repo repositories.SearchPingRepository, client *http.Client, options SearchPingOptions) SearchPinger {
+	return &searchPinger{
+		repo:    repo,
+		client:  client,
+		options: options,
+	}
+}
+
+// Enqueue queues a notification for every configured search engine. Articles
+// awaiting review are skipped until they are published, and noindex articles always.
+func (p *searchPinger) Enqueue(article *entities.Article) error {
+	if article == nil || !article.Indexable() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	if p.options.IndexNowKey != "" {
+		articleURL := p.options.SiteURL + "/article/" + url.PathEscape(article.Slug)
+		if err := p.repo.Enqueue(entities.SearchPingIndexNow, articleURL, now); err != nil {
+			return err
+		}
+	}
+	for _, pingURL := range p.options.SitemapPingURLs {
+		target, err := withQuery(pingURL, "sitemap", p.options.SitemapURL)
+		if err != nil {
+			return err
+		}
+		if err := p.repo.Enqueue(entities.SearchPingSitemap, target, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliver sends the pings that are due and returns how many succeeded. Failed
+// pings are retried with backoff until they run out of attempts; rejections
+// other than rate limiting fail at once.
+func (p *searchPinger) Deliver() (int, error) {
+	pings, err := p.repo.Due(time.Now().UTC(), searchPingBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, ping := range pings {
+		sendErr := p.send(&ping)
+		now := time.Now().UTC()
+		if sendErr == nil {
+			if err := p.repo.MarkDelivered(ping.ID, now); err != nil {
+				return delivered, err
+			}
+			delivered++
+			continue
+		}
+
+		var retryAt *time.Time
+		if attempts := ping.Attempts + 1; attempts < entities.MaxSearchPingAttempts && !errors.Is(sendErr, errSearchPingRejected) {
+			next := now.Add(entities.SearchPingRetryDelay(attempts))
+			retryAt = &next
+		}
+		if err := p.repo.MarkFailed(ping.ID, sendErr.Error(), now, retryAt); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+// send makes one delivery attempt
+func (p *searchPinger) send(ping *entities.SearchPing) error {
+	target := ping.URL
+	if ping.Target == entities.SearchPingIndexNow {
+		u, err := url.Parse(p.options.IndexNowEndpoint)
+		if err != nil {
+			return fmt.Errorf("%w: invalid IndexNow endpoint", errSearchPingRejected)
+		}
+		query := u.Query()
+		query.Set("url", ping.URL)
+		query.Set("key", p.options.IndexNowKey)
+		query.Set("keyLocation", p.options.SiteURL+"/indexnow.txt")
+		u.RawQuery = query.Encode()
+		target = u.String()
+	}
+
+	resp, err := p.client.Get(target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests:
+		return fmt.Errorf("%w: status %d", errSearchPingRejected, resp.StatusCode)
+	default:
+		return fmt.Errorf("search engine responded with status %d", resp.StatusCode)
+	}
+}
+
+// withQuery returns rawURL with the query parameter set
+func withQuery(rawURL, key, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid ping URL %q: %w", rawURL, err)
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// searchPingArticleRepository queues search engine pings when articles are published or updated
+type searchPingArticleRepository struct {
+	repositories.ArticleRepository
+	pinger SearchPinger
+}
+
+// NewSearchPingArticleRepository wraps an article repository so that creating,
+// updating, translating or approving a published article queues search pings.
+// A failure to queue is logged and never fails the write.
+func NewSearchPingArticleRepository(repo repositories.ArticleRepository, pinger SearchPinger) repositories.ArticleRepository {
+	return &searchPingArticleRepository{
+		ArticleRepository: repo,
+		pinger:            pinger,
+	}
+}
+
+// Create creates the article and queues pings if it is published
+func (r *searchPingArticleRepository) Create(authorID int64, article *entities.ArticleCreate) (*entities.Article, error) {
+	created, err := r.ArticleRepository.Create(authorID, article)
+	if err == nil {
+		r.enqueue(created)
+	}
+	return created, err
+}
+
+// Update updates the article and queues pings if it is published
+func (r *searchPingArticleRepository) Update(id int64, updates *entities.ArticleUpdate) (*entities.Article, error) {
+	updated, err := r.ArticleRepository.Update(id, updates)
+	if err == nil {
+		r.enqueue(updated)
+	}
+	return updated, err
+}
+
+// CreateTranslation creates the translation and queues pings if it is published
+func (r *searchPingArticleRepository) CreateTranslation(source *entities.Article, translation *entities.ArticleTranslationCreate) (*entities.Article, error) {
+	created, err := r.ArticleRepository.CreateTranslation(source, translation)
+	if err == nil {
+		r.enqueue(created)
+	}
+	return created, err
+}
+
+// Review records the decision and queues pings if the article was approved
+func (r *searchPingArticleRepository) Review(id int64, status string, reviewerID int64, note string) (*entities.Article, error) {
+	reviewed, err := r.ArticleRepository.Review(id, status, reviewerID, note)
+	if err == nil {
+		r.enqueue(reviewed)
+	}
+	return reviewed, err
+}
+
+// enqueue queues pings for the article, logging failures
+func (r *searchPingArticleRepository) enqueue(article *entities.Article) {
+	if err := r.pinger.Enqueue(article); err != nil {
+		log.Printf("⚠️  Failed to queue search pings: %v", err)
+	}
+}
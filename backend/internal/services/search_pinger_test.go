@@ -0,0 +1,125 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+type fakeSearchPingRepo struct {
+	queued    []entities.SearchPing
+	delivered []int64
+	failed    map[int64]*time.Time
+}
+
+func (r *fakeSearchPingRepo) Enqueue(target, url string, at time.Time) error {
+	r.queued = append(r.queued, entities.SearchPing{ID: int64(len(r.queued) + 1), Target: target, URL: url, Status: entities.SearchPingStatusPending})
+	return nil
+}
+
+func (r *fakeSearchPingRepo) Due(time.Time, int) ([]entities.SearchPing, error) {
+	return r.queued, nil
+}
+
+func (r *fakeSearchPingRepo) MarkDelivered(id int64, at time.Time) error {
+	r.delivered = append(r.delivered, id)
+	return nil
+}
+
+func (r *fakeSearchPingRepo) MarkFailed(id int64, message string, at time.Time, retryAt *time.Time) error {
+	r.failed[id] = retryAt
+	return nil
+}
+
+func (r *fakeSearchPingRepo) List(string, int, int) ([]entities.SearchPing, int, error) {
+	return nil, 0, nil
+}
+
+func (r *fakeSearchPingRepo) PendingCount() (int, error) {
+	return len(r.queued), nil
+}
+
+func TestSearchPinger_QueuesOnlyPublishedArticles(t *testing.T) {
+	repo := &fakeSearchPingRepo{failed: map[int64]*time.Time{}}
+	pinger := NewSearchPinger(repo, http.DefaultClient, SearchPingOptions{
+		SiteURL:         "https://blog.example",
+		SitemapURL:      "https://api.blog.example/sitemap.xml",
+		IndexNowKey:     "0123456789abcdef",
+		SitemapPingURLs: []string{"https://engine.example/ping?source=conduit"},
+	})
+
+	if err := pinger.Enqueue(&entities.Article{Slug: "draft", Status: entities.ArticleStatusPending}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if len(repo.queued) != 0 {
+		t.Fatalf("Queued %v for an unpublished article", repo.queued)
+	}
+
+	if err := pinger.Enqueue(&entities.Article{Slug: "hello world", Status: entities.ArticleStatusPublished}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if len(repo.queued) != 2 {
+		t.Fatalf("Queued %d pings, want 2", len(repo.queued))
+	}
+	if got := repo.queued[0].URL; got != "https://blog.example/article/hello%20world" {
+		t.Errorf("IndexNow URL = %q", got)
+	}
+	ping, _ := url.Parse(repo.queued[1].URL)
+	if ping.Query().Get("sitemap") != "https://api.blog.example/sitemap.xml" || ping.Query().Get("source") != "conduit" {
+		t.Errorf("Sitemap ping URL = %q", repo.queued[1].URL)
+	}
+}
+
+func TestSearchPinger_DeliverTracksStatus(t *testing.T) {
+	var indexNowQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/indexnow":
+			indexNowQuery = r.URL.Query()
+			w.WriteHeader(http.StatusAccepted)
+		case "/busy":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	repo := &fakeSearchPingRepo{failed: map[int64]*time.Time{}, queued: []entities.SearchPing{
+		{ID: 1, Target: entities.SearchPingIndexNow, URL: "https://blog.example/article/a"},
+		{ID: 2, Target: entities.SearchPingSitemap, URL: server.URL + "/busy"},
+		{ID: 3, Target: entities.SearchPingSitemap, URL: server.URL + "/forbidden"},
+		{ID: 4, Target: entities.SearchPingSitemap, URL: server.URL + "/busy", Attempts: entities.MaxSearchPingAttempts - 1},
+	}}
+	pinger := NewSearchPinger(repo, server.Client(), SearchPingOptions{
+		SiteURL:          "https://blog.example",
+		IndexNowKey:      "0123456789abcdef",
+		IndexNowEndpoint: server.URL + "/indexnow",
+	})
+
+	delivered, err := pinger.Deliver()
+	if err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+
+	if delivered != 1 || len(repo.delivered) != 1 || repo.delivered[0] != 1 {
+		t.Errorf("Delivered %v, want only the IndexNow ping", repo.delivered)
+	}
+	if indexNowQuery.Get("url") != "https://blog.example/article/a" || indexNowQuery.Get("key") != "0123456789abcdef" ||
+		indexNowQuery.Get("keyLocation") != "https://blog.example/indexnow.txt" {
+		t.Errorf("IndexNow query = %v", indexNowQuery)
+	}
+	if retryAt, ok := repo.failed[2]; !ok || retryAt == nil {
+		t.Error("Expected the unavailable endpoint to be retried")
+	}
+	if retryAt, ok := repo.failed[3]; !ok || retryAt != nil {
+		t.Error("Expected the rejected ping to fail without retry")
+	}
+	if retryAt, ok := repo.failed[4]; !ok || retryAt != nil {
+		t.Error("Expected the ping out of attempts to fail without retry")
+	}
+}
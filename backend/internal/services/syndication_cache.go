@@ -0,0 +1,193 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+// Syndication cache keys
+const (
+	SyndicationKeySitemap = "sitemap"
+	SyndicationKeyFeed    = "rss"
+)
+
+// SyndicationAuthorFeedKey returns the cache key of an author's RSS feed
+func SyndicationAuthorFeedKey(username string) string {
+	return SyndicationKeyFeed + ":author:" + username
+}
+
+// SyndicationCacheStats reports cache effectiveness since startup
+type SyndicationCacheStats struct {
+	Entries       int `json:"entries"`
+	Hits          int `json:"hits"`
+	Misses        int `json:"misses"`
+	BuildErrors   int `json:"buildErrors"`
+	Invalidations int `json:"invalidations"`
+	Flushes       int `json:"flushes"`
+}
+
+// SyndicationCache holds generated RSS and sitemap documents until the
+// articles they list change
+type SyndicationCache interface {
+	// Get returns the cached document for key, calling build on a miss
+	Get(key string, build func() ([]byte, error)) ([]byte, error)
+	// Invalidate drops the given keys so they are rebuilt on next request
+	Invalidate(keys ...string)
+	// Flush drops every entry and returns how many were removed
+	Flush() int
+	Stats() SyndicationCacheStats
+}
+
+// syndicationEntry is a cached document and when it was built
+type syndicationEntry struct {
+	body    []byte
+	builtAt time.Time
+}
+
+// syndicationCache implements SyndicationCache in memory
+type syndicationCache struct {
+	mu      sync.Mutex
+	entries map[string]syndicationEntry
+	stats   SyndicationCacheStats
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewSyndicationCache creates a syndication cache. Entries are normally
+// invalidated by article events; ttl bounds staleness from changes made
+// elsewhere (e.g. renamed authors). Zero keeps entries until invalidated.
+func NewSyndicationCache(ttl time.Duration) SyndicationCache {
+	return &syndicationCache{
+		entries: make(map[string]syndicationEntry),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached document for key, building and storing it on a miss.
+// Build errors are returned and not cached.
+func (c *syndicationCache) Get(key string, build func() ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && (c.ttl <= 0 || c.now().Sub(entry.builtAt) < c.ttl) {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return entry.body, nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	body, err := build()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.stats.BuildErrors++
+		return nil, err
+	}
+	c.entries[key] = syndicationEntry{body: body, builtAt: c.now()}
+	return body, nil
+}
+
+// Invalidate drops the given keys
+func (c *syndicationCache) Invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := c.entries[key]; ok {
+			delete(c.entries, key)
+			c.stats.Invalidations++
+		}
+	}
+}
+
+// Flush drops every entry
+func (c *syndicationCache) Flush() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	flushed := len(c.entries)
+	c.entries = make(map[string]syndicationEntry)
+	c.stats.Flushes++
+	return flushed
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *syndicationCache) Stats() SyndicationCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
+
+// syndicatedArticleRepository invalidates syndication documents when articles change
+type syndicatedArticleRepository struct {
+	repositories.ArticleRepository
+	cache SyndicationCache
+}
+
+// NewSyndicatedArticleRepository wraps an article repository so that creating,
+// updating or deleting an article invalidates only the documents listing it:
+// the site feed, the sitemap and the author's feed.
+func NewSyndicatedArticleRepository(repo repositories.ArticleRepository, cache SyndicationCache) repositories.ArticleRepository {
+	return &syndicatedArticleRepository{
+		ArticleRepository: repo,
+		cache:             cache,
+	}
+}
+
+// Create creates the article and invalidates the documents listing it
+func (r *syndicatedArticleRepository) Create(authorID int64, article *entities.ArticleCreate) (*entities.Article, error) {
+	created, err := r.ArticleRepository.Create(authorID, article)
+	if err == nil {
+		r.invalidate(created)
+	}
+	return created, err
+}
+
+// Update updates the article and invalidates the documents listing it
+func (r *syndicatedArticleRepository) Update(id int64, updates *entities.ArticleUpdate) (*entities.Article, error) {
+	updated, err := r.ArticleRepository.Update(id, updates)
+	if err == nil {
+		r.invalidate(updated)
+	}
+	return updated, err
+}
+
+// Review records the decision and invalidates the documents that list the article
+func (r *syndicatedArticleRepository) Review(id int64, status string, reviewerID int64, note string) (*entities.Article, error) {
+	reviewed, err := r.ArticleRepository.Review(id, status, reviewerID, note)
+	if err == nil {
+		r.invalidate(reviewed)
+	}
+	return reviewed, err
+}
+
+// Delete deletes the article and invalidates the documents that listed it
+func (r *syndicatedArticleRepository) Delete(id int64) error {
+	// Look the article up first: its author is needed once it is gone
+	article, _ := r.ArticleRepository.GetByID(id)
+
+	if err := r.ArticleRepository.Delete(id); err != nil {
+		return err
+	}
+
+	r.invalidate(article)
+	return nil
+}
+
+// invalidate drops the documents an article appears in; if its author is
+// unknown every document is dropped
+func (r *syndicatedArticleRepository) invalidate(article *entities.Article) {
+	if article == nil || article.Author == nil {
+		r.cache.Flush()
+		return
+	}
+	r.cache.Invalidate(SyndicationKeyFeed, SyndicationKeySitemap, SyndicationAuthorFeedKey(article.Author.Username))
+}
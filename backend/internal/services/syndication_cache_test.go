@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+	"github.com/emotab87/vibe_coding/backend/internal/repositories"
+)
+
+func countingBuild(builds *int, body string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		*builds++
+		return []byte(body), nil
+	}
+}
+
+func TestSyndicationCache_CachesUntilInvalidated(t *testing.T) {
+	cache := NewSyndicationCache(0)
+	builds := 0
+
+	for i := 0; i < 3; i++ {
+		body, err := cache.Get(SyndicationKeyFeed, countingBuild(&builds, "feed"))
+		if err != nil || string(body) != "feed" {
+			t.Fatalf("Expected cached feed, got %q (%v)", body, err)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("Expected 1 build, got %d", builds)
+	}
+
+	cache.Invalidate(SyndicationKeyFeed)
+	cache.Get(SyndicationKeyFeed, countingBuild(&builds, "feed"))
+	if builds != 2 {
+		t.Errorf("Expected a rebuild after invalidation, got %d builds", builds)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 2 || stats.Invalidations != 1 || stats.Entries != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestSyndicationCache_ExpiresAfterTTL(t *testing.T) {
+	now := time.Now()
+	cache := NewSyndicationCache(time.Hour)
+	cache.(*syndicationCache).now = func() time.Time { return now }
+	builds := 0
+
+	cache.Get(SyndicationKeySitemap, countingBuild(&builds, "sitemap"))
+	now = now.Add(59 * time.Minute)
+	cache.Get(SyndicationKeySitemap, countingBuild(&builds, "sitemap"))
+	if builds != 1 {
+		t.Errorf("Expected entry to be fresh within the TTL, got %d builds", builds)
+	}
+
+	now = now.Add(time.Minute)
+	cache.Get(SyndicationKeySitemap, countingBuild(&builds, "sitemap"))
+	if builds != 2 {
+		t.Errorf("Expected entry to be rebuilt after the TTL, got %d builds", builds)
+	}
+}
+
+func TestSyndicationCache_DoesNotCacheErrors(t *testing.T) {
+	cache := NewSyndicationCache(0)
+
+	_, err := cache.Get(SyndicationKeyFeed, func() ([]byte, error) { return nil, errors.New("boom") })
+	if err == nil {
+		t.Fatal("Expected build error to be returned")
+	}
+
+	builds := 0
+	cache.Get(SyndicationKeyFeed, countingBuild(&builds, "feed"))
+	if builds != 1 {
+		t.Errorf("Expected failed build not to be cached, got %d builds", builds)
+	}
+	if stats := cache.Stats(); stats.BuildErrors != 1 {
+		t.Errorf("Expected 1 build error, got %d", stats.BuildErrors)
+	}
+}
+
+func TestSyndicationCache_Flush(t *testing.T) {
+	cache := NewSyndicationCache(0)
+	builds := 0
+	cache.Get(SyndicationKeyFeed, countingBuild(&builds, "feed"))
+	cache.Get(SyndicationKeySitemap, countingBuild(&builds, "sitemap"))
+
+	if flushed := cache.Flush(); flushed != 2 {
+		t.Errorf("Expected 2 entries flushed, got %d", flushed)
+	}
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Flushes != 1 {
+		t.Errorf("Unexpected stats after flush %+v", stats)
+	}
+}
+
+type fakeSyndicatedArticleRepo struct {
+	repositories.ArticleRepository
+	articles map[int64]*entities.Article
+}
+
+func (r *fakeSyndicatedArticleRepo) GetByID(id int64) (*entities.Article, error) {
+	if article, ok := r.articles[id]; ok {
+		return article, nil
+	}
+	return nil, errors.New("article not found")
+}
+
+func (r *fakeSyndicatedArticleRepo) Update(id int64, updates *entities.ArticleUpdate) (*entities.Article, error) {
+	return r.GetByID(id)
+}
+
+func (r *fakeSyndicatedArticleRepo) Delete(id int64) error {
+	if _, ok := r.articles[id]; !ok {
+		return errors.New("article not found")
+	}
+	delete(r.articles, id)
+	return nil
+}
+
+func TestSyndicatedArticleRepository_InvalidatesAffectedDocuments(t *testing.T) {
+	cache := NewSyndicationCache(0)
+	repo := NewSyndicatedArticleRepository(&fakeSyndicatedArticleRepo{articles: map[int64]*entities.Article{
+		1: {ID: 1, Author: &entities.User{Username: "jake"}},
+	}}, cache)
+
+	warm := func() {
+		builds := 0
+		for _, key := range []string{SyndicationKeyFeed, SyndicationKeySitemap, SyndicationAuthorFeedKey("jake"), SyndicationAuthorFeedKey("jane")} {
+			cache.Get(key, countingBuild(&builds, key))
+		}
+	}
+
+	warm()
+	if _, err := repo.Update(1, &entities.ArticleUpdate{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.Invalidations != 3 {
+		t.Errorf("Expected only jane's feed to stay cached after update, got %+v", stats)
+	}
+
+	warm()
+	if err := repo.Delete(1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.Invalidations != 6 {
+		t.Errorf("Expected only jane's feed to stay cached after delete, got %+v", stats)
+	}
+
+	if err := repo.Delete(1); err == nil {
+		t.Error("Expected error deleting a missing article")
+	}
+	if stats := cache.Stats(); stats.Entries != 1 {
+		t.Errorf("Expected failed delete not to invalidate, got %+v", stats)
+	}
+}
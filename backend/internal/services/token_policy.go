@@ -0,0 +1,82 @@
+package services
+
+import "time"
+
+// Kinds of token whose lifetime the token policy decides
+const (
+	TokenAccess        = "access"
+	TokenRefresh       = "refresh"
+	TokenMagicLink     = "magic_link"
+	TokenPasswordReset = "password_reset"
+	TokenReply         = "reply"
+)
+
+// TokenLifetimes sets how long each kind of token stays valid after it is issued
+type TokenLifetimes struct {
+	Access        time.Duration
+	Refresh       time.Duration
+	MagicLink     time.Duration
+	PasswordReset time.Duration
+	Reply         time.Duration
+}
+
+// TokenPolicy decides token lifetimes in one place, and how much clock skew
+// between servers is tolerated when checking a token's nbf and exp claims
+type TokenPolicy interface {
+	// Lifetime returns how long a token of the kind is valid; unknown kinds get zero
+	Lifetime(kind string) time.Duration
+	// ExpiresAt returns when a token of the kind issued at issuedAt expires
+	ExpiresAt(kind string, issuedAt time.Time) time.Time
+	// Expired reports whether a token that expires at expiresAt is no longer
+	// valid at now, allowing for clock skew
+	Expired(expiresAt, now time.Time) bool
+	// ClockSkew returns the tolerance applied to nbf and exp
+	ClockSkew() time.Duration
+}
+
+// tokenPolicy implements TokenPolicy with fixed lifetimes
+type tokenPolicy struct {
+	lifetimes TokenLifetimes
+	clockSkew time.Duration
+}
+
+// NewTokenPolicy creates a token policy
+func NewTokenPolicy(lifetimes TokenLifetimes, clockSkew time.Duration) TokenPolicy {
+	return &tokenPolicy{
+		lifetimes: lifetimes,
+		clockSkew: clockSkew,
+	}
+}
+
+// Lifetime returns how long a token of the kind is valid
+func (p *tokenPolicy) Lifetime(kind string) time.Duration {
+	switch kind {
+	case TokenAccess:
+		return p.lifetimes.Access
+	case TokenRefresh:
+		return p.lifetimes.Refresh
+	case TokenMagicLink:
+		return p.lifetimes.MagicLink
+	case TokenPasswordReset:
+		return p.lifetimes.PasswordReset
+	case TokenReply:
+		return p.lifetimes.Reply
+	default:
+		return 0
+	}
+}
+
+// ExpiresAt returns when a token of the kind issued at issuedAt expires
+func (p *tokenPolicy) ExpiresAt(kind string, issuedAt time.Time) time.Time {
+	return issuedAt.Add(p.Lifetime(kind))
+}
+
+// Expired reports whether a token that expires at expiresAt is no longer valid at now
+func (p *tokenPolicy) Expired(expiresAt, now time.Time) bool {
+	return now.After(expiresAt.Add(p.clockSkew))
+}
+
+// ClockSkew returns the tolerance applied to nbf and exp
+func (p *tokenPolicy) ClockSkew() time.Duration {
+	return p.clockSkew
+}
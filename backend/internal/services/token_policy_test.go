@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTokenPolicy_Lifetimes(t *testing.T) {
+	policy := NewTokenPolicy(TokenLifetimes{
+		Access:        time.Hour,
+		Refresh:       30 * 24 * time.Hour,
+		MagicLink:     15 * time.Minute,
+		PasswordReset: time.Hour,
+		Reply:         7 * 24 * time.Hour,
+	}, time.Minute)
+
+	issuedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		kind string
+		want time.Time
+	}{
+		{TokenAccess, issuedAt.Add(time.Hour)},
+		{TokenRefresh, issuedAt.AddDate(0, 0, 30)},
+		{TokenMagicLink, issuedAt.Add(15 * time.Minute)},
+		{TokenPasswordReset, issuedAt.Add(time.Hour)},
+		{TokenReply, issuedAt.AddDate(0, 0, 7)},
+		{"unknown", issuedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			if got := policy.ExpiresAt(tt.kind, issuedAt); !got.Equal(tt.want) {
+				t.Errorf("Expected expiry %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTokenPolicy_ClockSkew(t *testing.T) {
+	policy := NewTokenPolicy(TokenLifetimes{Access: time.Hour}, time.Minute)
+	expiresAt := time.Now()
+
+	if policy.Expired(expiresAt, expiresAt.Add(30*time.Second)) {
+		t.Error("Expected a token within the skew tolerance to be valid")
+	}
+	if !policy.Expired(expiresAt, expiresAt.Add(2*time.Minute)) {
+		t.Error("Expected a token past the skew tolerance to be expired")
+	}
+}
+
+func TestJWTService_ClockSkew(t *testing.T) {
+	now := time.Now()
+	// Issued by a server whose clock runs 30 seconds fast
+	claims := jwt.MapClaims{
+		"user_id":  1,
+		"username": "testuser",
+		"sub":      "user:1",
+		"iss":      "conduit-api",
+		"aud":      "conduit",
+		"iat":      now.Add(30 * time.Second).Unix(),
+		"nbf":      now.Add(30 * time.Second).Unix(),
+		"exp":      now.Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	strict := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: time.Hour}, 0), "conduit-api", "conduit")
+	if _, err := strict.ValidateToken(token); err == nil {
+		t.Error("Expected a token from the future to be rejected without skew tolerance")
+	}
+
+	tolerant := NewJWTService("test-secret-key", NewTokenPolicy(TokenLifetimes{Access: time.Hour}, time.Minute), "conduit-api", "conduit")
+	if _, err := tolerant.ValidateToken(token); err != nil {
+		t.Errorf("Expected a token within the skew tolerance to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// ErrPushSubscriptionGone is returned when the push service reports that a
+// subscription no longer exists and should be forgotten
+var ErrPushSubscriptionGone = errors.New("push subscription is gone")
+
+const (
+	// pushTTL is how long push services hold a message for an offline browser
+	pushTTL = 24 * time.Hour
+	// vapidTokenLifetime is the validity of the signed VAPID token; push
+	// services reject tokens valid for more than 24 hours
+	vapidTokenLifetime = 12 * time.Hour
+	// pushRecordSize is the aes128gcm record size; payloads fit in one record
+	pushRecordSize = 4096
+)
+
+// WebPushSender delivers an encrypted payload to one browser subscription
+type WebPushSender interface {
+	Send(subscription *entities.PushSubscription, payload []byte) error
+}
+
+// VAPIDKeys is the application server key pair browsers subscribe with
+type VAPIDKeys struct {
+	private *ecdsa.PrivateKey
+	// PublicKey is the uncompressed public key, base64url-encoded as browsers expect
+	PublicKey string
+}
+
+// ParseVAPIDKeys reads a base64url-encoded P-256 private key, the format
+// printed by -generate-vapid-keys and common Web Push tooling
+func ParseVAPIDKeys(encoded string) (*VAPIDKeys, error) {
+	raw, err := entities.DecodePushKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key encoding: %w", err)
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	return newVAPIDKeys(key), nil
+}
+
+// GenerateVAPIDKeys creates a new key pair and returns it with the encoded private key
+func GenerateVAPIDKeys() (*VAPIDKeys, string, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate VAPID key: %w", err)
+	}
+
+	return newVAPIDKeys(key), base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// newVAPIDKeys converts an ECDH key into the ECDSA key used to sign tokens
+func newVAPIDKeys(key *ecdh.PrivateKey) *VAPIDKeys {
+	public := key.PublicKey().Bytes()
+	return &VAPIDKeys{
+		private: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(public[1:33]),
+				Y:     new(big.Int).SetBytes(public[33:]),
+			},
+			D: new(big.Int).SetBytes(key.Bytes()),
+		},
+		PublicKey: base64.RawURLEncoding.EncodeToString(public),
+	}
+}
+
+// vapidPushSender implements WebPushSender with RFC 8291 payload encryption
+// and RFC 8292 (VAPID) authentication
+type vapidPushSender struct {
+	keys    *VAPIDKeys
+	subject string
+	client  *http.Client
+}
+
+// NewWebPushSender creates a sender that identifies itself with the VAPID keys;
+// subject is a mailto: or https: contact for the push service operator
+func NewWebPushSender(keys *VAPIDKeys, subject string) WebPushSender {
+	// Endpoints come from browsers, so refuse internal addresses
+	return newWebPushSender(keys, subject, NewSafeHTTPClient(5*time.Second))
+}
+
+func newWebPushSender(keys *VAPIDKeys, subject string, client *http.Client) *vapidPushSender {
+	return &vapidPushSender{
+		keys:    keys,
+		subject: subject,
+		client:  client,
+	}
+}
+
+// Send encrypts the payload for the subscription and posts it to the push service
+func (s *vapidPushSender) Send(subscription *entities.PushSubscription, payload []byte) error {
+	body, err := encryptPushPayload(subscription, payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(vapidTokenLifetime).Unix(),
+		"sub": s.subject,
+	}).SignedString(s.keys.private)
+	if err != nil {
+		return fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+s.keys.PublicKey)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(pushTTL.Seconds())))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver push: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrPushSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// encryptPushPayload encrypts the payload for the subscription's browser as a
+// single aes128gcm record (RFC 8188) keyed per RFC 8291
+func encryptPushPayload(subscription *entities.PushSubscription, payload []byte) ([]byte, error) {
+	userAgentPublic, err := entities.DecodePushKey(subscription.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	authSecret, err := entities.DecodePushKey(subscription.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription secret: %w", err)
+	}
+
+	userAgentKey, err := ecdh.P256().NewPublicKey(userAgentPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+
+	// A fresh key pair and salt for every message
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push key: %w", err)
+	}
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate push salt: %w", err)
+	}
+
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push secret: %w", err)
+	}
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), userAgentPublic...), serverPublic...)
+	ikm, err := hkdfBytes(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdfBytes(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfBytes(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+
+	// The 0x02 delimiter marks the last (and only) record
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+gcm.Overhead() > pushRecordSize {
+		return nil, fmt.Errorf("push payload is too large")
+	}
+
+	header := make([]byte, 0, 21+len(serverPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(serverPublic)))
+	header = append(header, serverPublic...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// hkdfBytes derives length bytes with HKDF-SHA256
+func hkdfBytes(secret, salt, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out); err != nil {
+		return nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	return out, nil
+}
+
+// logPushSender writes pushes to the application log instead of sending them
+type logPushSender struct{}
+
+// NewLogWebPushSender creates a push sender for development that only logs messages
+func NewLogWebPushSender() WebPushSender {
+	return &logPushSender{}
+}
+
+// Send logs the push
+func (s *logPushSender) Send(subscription *entities.PushSubscription, payload []byte) error {
+	log.Printf("🔔 Push to %s: %s", subscription.Endpoint, payload)
+	return nil
+}
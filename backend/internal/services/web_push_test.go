@@ -0,0 +1,147 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/emotab87/vibe_coding/backend/internal/entities"
+)
+
+// testBrowser holds the keys a browser generates when subscribing
+type testBrowser struct {
+	key        *ecdh.PrivateKey
+	authSecret []byte
+}
+
+func newTestBrowser(t *testing.T) *testBrowser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate browser key: %v", err)
+	}
+	authSecret := make([]byte, 16)
+	rand.Read(authSecret)
+	return &testBrowser{key: key, authSecret: authSecret}
+}
+
+func (b *testBrowser) subscription(endpoint string) *entities.PushSubscription {
+	return &entities.PushSubscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(b.authSecret),
+	}
+}
+
+// decrypt reverses encryptPushPayload the way a browser does
+func (b *testBrowser) decrypt(t *testing.T, body []byte) []byte {
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != pushRecordSize {
+		t.Fatalf("Record size = %d, want %d", rs, pushRecordSize)
+	}
+	keyLength := int(body[20])
+	serverPublic := body[21 : 21+keyLength]
+
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	if err != nil {
+		t.Fatalf("Invalid server key: %v", err)
+	}
+	sharedSecret, err := b.key.ECDH(serverKey)
+	if err != nil {
+		t.Fatalf("ECDH failed: %v", err)
+	}
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), b.key.PublicKey().Bytes()...), serverPublic...)
+	ikm, _ := hkdfBytes(sharedSecret, b.authSecret, keyInfo, 32)
+	contentKey, _ := hkdfBytes(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce, _ := hkdfBytes(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, _ := aes.NewCipher(contentKey)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+keyLength:], nil)
+	if err != nil {
+		t.Fatalf("Failed to decrypt payload: %v", err)
+	}
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("Payload is missing the last record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestWebPushSender_EncryptsAndSigns(t *testing.T) {
+	keys, encoded, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatalf("Failed to generate VAPID keys: %v", err)
+	}
+	parsed, err := ParseVAPIDKeys(encoded)
+	if err != nil || parsed.PublicKey != keys.PublicKey {
+		t.Fatalf("ParseVAPIDKeys = %v, %v; want the generated key pair", parsed, err)
+	}
+
+	browser := newTestBrowser(t)
+	var received []byte
+	var authorization string
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "aes128gcm" || r.Header.Get("TTL") == "" {
+			t.Errorf("Unexpected headers: %v", r.Header)
+		}
+		authorization = r.Header.Get("Authorization")
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer pushService.Close()
+
+	sender := newWebPushSender(parsed, "mailto:admin@example.com", pushService.Client())
+	payload := []byte(`{"type":"new_comment","message":"hi","link":"/article/a"}`)
+	if err := sender.Send(browser.subscription(pushService.URL+"/push/abc"), payload); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if got := browser.decrypt(t, received); string(got) != string(payload) {
+		t.Errorf("Decrypted payload = %s, want %s", got, payload)
+	}
+
+	// Authorization: vapid t=<jwt>, k=<public key>
+	parts := strings.SplitN(strings.TrimPrefix(authorization, "vapid t="), ", k=", 2)
+	if len(parts) != 2 || parts[1] != keys.PublicKey {
+		t.Fatalf("Authorization = %q, want a VAPID token with the public key", authorization)
+	}
+	token, err := jwt.Parse(parts[0], func(*jwt.Token) (interface{}, error) {
+		return &parsed.private.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience(pushService.URL))
+	if err != nil || !token.Valid {
+		t.Errorf("VAPID token did not verify: %v", err)
+	}
+}
+
+func TestWebPushSender_ReportsGoneSubscriptions(t *testing.T) {
+	keys, _, _ := GenerateVAPIDKeys()
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer pushService.Close()
+
+	sender := newWebPushSender(keys, "mailto:admin@example.com", pushService.Client())
+	err := sender.Send(newTestBrowser(t).subscription(pushService.URL), []byte("{}"))
+	if !errors.Is(err, ErrPushSubscriptionGone) {
+		t.Errorf("Send to a gone subscription = %v, want ErrPushSubscriptionGone", err)
+	}
+}
+
+func TestParseVAPIDKeys_RejectsInvalidKeys(t *testing.T) {
+	for _, encoded := range []string{"not base64!", base64.RawURLEncoding.EncodeToString([]byte("short"))} {
+		if _, err := ParseVAPIDKeys(encoded); err == nil {
+			t.Errorf("ParseVAPIDKeys(%q) should fail", encoded)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+-- Migration: 004_add_article_translations.sql
+-- Description: Add language and translation links to articles for localized variants
+
+-- +migrate Up
+ALTER TABLE articles ADD COLUMN language TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE articles ADD COLUMN translation_of INTEGER REFERENCES articles(id) ON DELETE CASCADE;
+
+-- Only one variant per language within a translation group
+CREATE INDEX IF NOT EXISTS idx_articles_translation_of ON articles(translation_of);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_translation_group_language
+    ON articles(COALESCE(translation_of, id), language);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_articles_translation_group_language;
+DROP INDEX IF EXISTS idx_articles_translation_of;
+ALTER TABLE articles DROP COLUMN translation_of;
+ALTER TABLE articles DROP COLUMN language;
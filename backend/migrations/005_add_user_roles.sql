@@ -0,0 +1,11 @@
+-- Migration: 005_add_user_roles.sql
+-- Description: Add role column to users for moderation and administration
+
+-- +migrate Up
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user';
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_users_role;
+ALTER TABLE users DROP COLUMN role;
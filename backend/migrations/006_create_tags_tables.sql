@@ -0,0 +1,40 @@
+-- Migration: 006_create_tags_tables.sql
+-- Description: Create tags, article_tags and tag_aliases tables for the tag system
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT UNIQUE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+
+    PRIMARY KEY (article_id, tag_id),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+-- Aliases resolve alternative spellings (e.g. golang) to a canonical tag (e.g. go)
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    alias TEXT PRIMARY KEY,
+    tag_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags(name);
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_tag_aliases_tag_id ON tag_aliases(tag_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_tag_aliases_tag_id;
+DROP INDEX IF EXISTS idx_article_tags_tag_id;
+DROP INDEX IF EXISTS idx_tags_name;
+DROP TABLE IF EXISTS tag_aliases;
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,10 @@
+-- Migration: 007_add_tag_descriptions.sql
+-- Description: Add editable descriptions to tags for tag landing pages
+
+-- +migrate Up
+ALTER TABLE tags ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE tags ADD COLUMN updated_at DATETIME;
+
+-- +migrate Down
+ALTER TABLE tags DROP COLUMN updated_at;
+ALTER TABLE tags DROP COLUMN description;
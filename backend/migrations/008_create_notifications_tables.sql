@@ -0,0 +1,37 @@
+-- Migration: 008_create_notifications_tables.sql
+-- Description: Create notifications and per-event notification preferences
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS notifications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    message TEXT NOT NULL,
+    link TEXT NOT NULL DEFAULT '',
+    delivery TEXT NOT NULL,
+    digested_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- One row per (user, event type); missing rows fall back to immediate delivery
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL,
+    event_type TEXT NOT NULL,
+    delivery TEXT NOT NULL CHECK (delivery IN ('immediate', 'daily_digest', 'off')),
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, event_type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_pending_digest ON notifications(delivery, digested_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_notifications_pending_digest;
+DROP INDEX IF EXISTS idx_notifications_user_id;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
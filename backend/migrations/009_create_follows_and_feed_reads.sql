@@ -0,0 +1,30 @@
+-- Migration: 009_create_follows_and_feed_reads.sql
+-- Description: Create follows table backing the personal feed and per-user feed read markers
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id INTEGER NOT NULL,
+    following_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (follower_id, following_id),
+    FOREIGN KEY (follower_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (following_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (follower_id != following_id)
+);
+
+-- Newest feed item each user has seen; articles created after it are unread
+CREATE TABLE IF NOT EXISTS feed_reads (
+    user_id INTEGER PRIMARY KEY,
+    last_seen_at DATETIME NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_follows_following_id ON follows(following_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_follows_following_id;
+DROP TABLE IF EXISTS feed_reads;
+DROP TABLE IF EXISTS follows;
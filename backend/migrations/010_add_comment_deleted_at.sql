@@ -0,0 +1,8 @@
+-- Migration: 010_add_comment_deleted_at.sql
+-- Description: Allow comments to be replaced by a "[deleted]" placeholder instead of removed
+
+-- +migrate Up
+ALTER TABLE comments ADD COLUMN deleted_at DATETIME;
+
+-- +migrate Down
+ALTER TABLE comments DROP COLUMN deleted_at;
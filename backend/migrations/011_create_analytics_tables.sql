@@ -0,0 +1,40 @@
+-- Migration: 011_create_analytics_tables.sql
+-- Description: Create first-party analytics events and per-article daily aggregates
+
+-- +migrate Up
+-- Raw events never store IP addresses; visitor_hash is a salted hash that rotates daily
+CREATE TABLE IF NOT EXISTS analytics_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    path TEXT NOT NULL DEFAULT '',
+    article_id INTEGER,
+    referrer_host TEXT NOT NULL DEFAULT '',
+    visitor_hash TEXT NOT NULL,
+    day TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS article_daily_stats (
+    article_id INTEGER NOT NULL,
+    day TEXT NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    interactions INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (article_id, day),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_analytics_events_day ON analytics_events(day);
+CREATE INDEX IF NOT EXISTS idx_analytics_events_visitor ON analytics_events(visitor_hash, article_id, day);
+CREATE INDEX IF NOT EXISTS idx_article_daily_stats_day ON article_daily_stats(day);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_article_daily_stats_day;
+DROP INDEX IF EXISTS idx_analytics_events_visitor;
+DROP INDEX IF EXISTS idx_analytics_events_day;
+DROP TABLE IF EXISTS article_daily_stats;
+DROP TABLE IF EXISTS analytics_events;
@@ -0,0 +1,27 @@
+-- Migration: 012_create_link_previews.sql
+-- Description: Create fetched link previews and their attachment to articles
+
+-- +migrate Up
+-- One row per URL; fetched_at lets previews be refreshed after they go stale
+CREATE TABLE IF NOT EXISTS link_previews (
+    url TEXT PRIMARY KEY,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    site_name TEXT NOT NULL DEFAULT '',
+    fetched_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS article_link_previews (
+    article_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (article_id, url),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    FOREIGN KEY (url) REFERENCES link_previews(url) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS article_link_previews;
+DROP TABLE IF EXISTS link_previews;
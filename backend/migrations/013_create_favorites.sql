@@ -0,0 +1,24 @@
+-- Migration: 013_create_favorites.sql
+-- Description: Create favorites table and the per-user setting to favorite anonymously
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS favorites (
+    user_id INTEGER NOT NULL,
+    article_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, article_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+-- Anonymous favorites still count but are left out of favoriter lists
+ALTER TABLE users ADD COLUMN favorite_anonymously BOOLEAN NOT NULL DEFAULT 0;
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_favorites_article_id ON favorites(article_id, created_at);
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN favorite_anonymously;
+DROP INDEX IF EXISTS idx_favorites_article_id;
+DROP TABLE IF EXISTS favorites;
@@ -0,0 +1,27 @@
+-- Migration: 014_create_user_settings.sql
+-- Description: Create per-user privacy settings, replacing users.favorite_anonymously
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id INTEGER PRIMARY KEY,
+    profile_visibility TEXT NOT NULL DEFAULT 'public',
+    show_favorites BOOLEAN NOT NULL DEFAULT 1,
+    record_reading_history BOOLEAN NOT NULL DEFAULT 1,
+    searchable BOOLEAN NOT NULL DEFAULT 1,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (profile_visibility IN ('public', 'members', 'private'))
+);
+
+-- Carry over anonymous favoriting
+INSERT INTO user_settings (user_id, show_favorites)
+SELECT id, 0 FROM users WHERE favorite_anonymously;
+
+ALTER TABLE users DROP COLUMN favorite_anonymously;
+
+-- +migrate Down
+ALTER TABLE users ADD COLUMN favorite_anonymously BOOLEAN NOT NULL DEFAULT 0;
+UPDATE users SET favorite_anonymously = 1
+WHERE id IN (SELECT user_id FROM user_settings WHERE NOT show_favorites);
+DROP TABLE IF EXISTS user_settings;
@@ -0,0 +1,8 @@
+-- Migration: 015_add_user_deactivated_at.sql
+-- Description: Allow accounts to be temporarily deactivated without deleting their content
+
+-- +migrate Up
+ALTER TABLE users ADD COLUMN deactivated_at DATETIME;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN deactivated_at;
@@ -0,0 +1,49 @@
+-- Migration: 016_create_anomalies.sql
+-- Description: Record suspected abuse for admin review and per-user moderation restrictions
+
+-- +migrate Up
+-- Restrictions admins apply to abusive accounts
+ALTER TABLE users ADD COLUMN moderation_status TEXT NOT NULL DEFAULT 'none'
+    CHECK (moderation_status IN ('none', 'rate_limited', 'shadow_banned', 'banned'));
+
+-- Salted hash of the network a user registered from; raw IPs are never stored
+ALTER TABLE users ADD COLUMN registration_network TEXT NOT NULL DEFAULT '';
+
+-- One row per detected burst; an open anomaly is updated while its burst continues
+CREATE TABLE IF NOT EXISTS anomalies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    user_id INTEGER,
+    network TEXT NOT NULL DEFAULT '',
+    event_count INTEGER NOT NULL,
+    window_start DATETIME NOT NULL,
+    detected_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+    action TEXT NOT NULL DEFAULT '',
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (kind IN ('registration_burst', 'favorite_burst', 'comment_flood'))
+);
+
+-- Accounts involved in an anomaly; several for registrations from one network
+CREATE TABLE IF NOT EXISTS anomaly_actors (
+    anomaly_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+
+    PRIMARY KEY (anomaly_id, user_id),
+    FOREIGN KEY (anomaly_id) REFERENCES anomalies(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_anomalies_subject ON anomalies(kind, user_id, network);
+CREATE INDEX IF NOT EXISTS idx_users_registration_network ON users(registration_network, created_at);
+CREATE INDEX IF NOT EXISTS idx_favorites_user_id ON favorites(user_id, created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_favorites_user_id;
+DROP INDEX IF EXISTS idx_users_registration_network;
+DROP INDEX IF EXISTS idx_anomalies_subject;
+DROP TABLE IF EXISTS anomaly_actors;
+DROP TABLE IF EXISTS anomalies;
+ALTER TABLE users DROP COLUMN registration_network;
+ALTER TABLE users DROP COLUMN moderation_status;
@@ -0,0 +1,34 @@
+-- Migration: 017_add_article_review_and_invites.sql
+-- Description: Hold articles for review before publishing and gate registration behind invites
+
+-- +migrate Up
+-- Only published articles are listed; pending and rejected ones are visible to
+-- their author and reviewers
+ALTER TABLE articles ADD COLUMN status TEXT NOT NULL DEFAULT 'published'
+    CHECK (status IN ('published', 'pending', 'rejected'));
+ALTER TABLE articles ADD COLUMN reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE articles ADD COLUMN reviewed_at DATETIME;
+ALTER TABLE articles ADD COLUMN review_note TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_articles_status ON articles(status, created_at);
+
+-- Single-use registration invites; claimed_at is set before the account is
+-- created so two registrations cannot share a code
+CREATE TABLE IF NOT EXISTS invites (
+    code TEXT PRIMARY KEY,
+    created_by INTEGER,
+    used_by INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    claimed_at DATETIME,
+
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (used_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS invites;
+DROP INDEX IF EXISTS idx_articles_status;
+ALTER TABLE articles DROP COLUMN review_note;
+ALTER TABLE articles DROP COLUMN reviewed_at;
+ALTER TABLE articles DROP COLUMN reviewed_by;
+ALTER TABLE articles DROP COLUMN status;
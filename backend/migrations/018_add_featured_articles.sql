@@ -0,0 +1,19 @@
+-- Migration: 018_add_featured_articles.sql
+-- Description: Editorial picks: admins feature articles with a note and an ordering
+
+-- +migrate Up
+-- featured_at is set while an article is featured; picks are ordered by
+-- featured_position, then most recently featured
+ALTER TABLE articles ADD COLUMN featured_at DATETIME;
+ALTER TABLE articles ADD COLUMN featured_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE articles ADD COLUMN featured_note TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN featured_position INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_articles_featured ON articles(featured_position, featured_at) WHERE featured_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_articles_featured;
+ALTER TABLE articles DROP COLUMN featured_position;
+ALTER TABLE articles DROP COLUMN featured_note;
+ALTER TABLE articles DROP COLUMN featured_by;
+ALTER TABLE articles DROP COLUMN featured_at;
@@ -0,0 +1,14 @@
+-- Migration: 019_add_article_pins.sql
+-- Description: Let authors pin articles to the top of their profile
+
+-- +migrate Up
+-- pinned_at is set while an article is pinned; pins are listed by pin_position
+ALTER TABLE articles ADD COLUMN pinned_at DATETIME;
+ALTER TABLE articles ADD COLUMN pin_position INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_articles_pinned ON articles(author_id, pin_position) WHERE pinned_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_articles_pinned;
+ALTER TABLE articles DROP COLUMN pin_position;
+ALTER TABLE articles DROP COLUMN pinned_at;
@@ -0,0 +1,35 @@
+-- Migration: 020_create_series.sql
+-- Description: Ordered series of articles by one author
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS series (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT UNIQUE NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    author_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- An article belongs to at most one series; positions are 1-based
+CREATE TABLE IF NOT EXISTS series_articles (
+    series_id INTEGER NOT NULL,
+    article_id INTEGER NOT NULL UNIQUE,
+    position INTEGER NOT NULL,
+
+    PRIMARY KEY (series_id, article_id),
+    FOREIGN KEY (series_id) REFERENCES series(id) ON DELETE CASCADE,
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_series_author ON series(author_id);
+CREATE INDEX IF NOT EXISTS idx_series_articles_position ON series_articles(series_id, position);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_series_articles_position;
+DROP INDEX IF EXISTS idx_series_author;
+DROP TABLE IF EXISTS series_articles;
+DROP TABLE IF EXISTS series;
@@ -0,0 +1,49 @@
+-- Migration: 021_create_reading_lists.sql
+-- Description: Public reading lists curated from any authors' articles
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT UNIQUE NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Positions are 1-based
+CREATE TABLE IF NOT EXISTS reading_list_items (
+    list_id INTEGER NOT NULL,
+    article_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    added_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (list_id, article_id),
+    FOREIGN KEY (list_id) REFERENCES reading_lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS reading_list_follows (
+    list_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (list_id, user_id),
+    FOREIGN KEY (list_id) REFERENCES reading_lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reading_lists_owner ON reading_lists(owner_id);
+CREATE INDEX IF NOT EXISTS idx_reading_list_items_position ON reading_list_items(list_id, position);
+CREATE INDEX IF NOT EXISTS idx_reading_list_follows_user ON reading_list_follows(user_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_reading_list_follows_user;
+DROP INDEX IF EXISTS idx_reading_list_items_position;
+DROP INDEX IF EXISTS idx_reading_lists_owner;
+DROP TABLE IF EXISTS reading_list_follows;
+DROP TABLE IF EXISTS reading_list_items;
+DROP TABLE IF EXISTS reading_lists;
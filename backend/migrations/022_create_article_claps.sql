@@ -0,0 +1,26 @@
+-- Migration: 022_create_article_claps.sql
+-- Description: Multi-increment claps with a per-user record and an article total
+
+-- +migrate Up
+ALTER TABLE articles ADD COLUMN claps_count INTEGER NOT NULL DEFAULT 0;
+
+-- last_key is the idempotency key of the user's latest clap, so retries are not counted twice
+CREATE TABLE IF NOT EXISTS article_claps (
+    article_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    last_key TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (article_id, user_id),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_claps_user ON article_claps(user_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_article_claps_user;
+DROP TABLE IF EXISTS article_claps;
+ALTER TABLE articles DROP COLUMN claps_count;
@@ -0,0 +1,8 @@
+-- Migration: 023_add_comment_locks.sql
+-- Description: Let authors and moderators close comments on an article
+
+-- +migrate Up
+ALTER TABLE articles ADD COLUMN comments_locked_at DATETIME;
+
+-- +migrate Down
+ALTER TABLE articles DROP COLUMN comments_locked_at;
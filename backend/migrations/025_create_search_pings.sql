@@ -0,0 +1,28 @@
+-- Migration: 025_create_search_pings.sql
+-- Description: Queue of search engine notifications (IndexNow, sitemap pings) and their delivery status
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS search_pings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    target TEXT NOT NULL,
+    url TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at DATETIME NOT NULL,
+    delivered_at DATETIME,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+
+    CHECK (target IN ('indexnow', 'sitemap')),
+    CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+-- Repeated edits of an article share one pending ping
+CREATE UNIQUE INDEX IF NOT EXISTS idx_search_pings_pending ON search_pings(target, url) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_search_pings_due ON search_pings(status, next_attempt_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_search_pings_due;
+DROP INDEX IF EXISTS idx_search_pings_pending;
+DROP TABLE IF EXISTS search_pings;
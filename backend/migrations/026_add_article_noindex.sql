@@ -0,0 +1,8 @@
+-- Migration: 026_add_article_noindex.sql
+-- Description: Let authors keep individual articles out of search engines
+
+-- +migrate Up
+ALTER TABLE articles ADD COLUMN noindex INTEGER NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE articles DROP COLUMN noindex;
@@ -0,0 +1,10 @@
+-- Migration: 027_add_user_email_index.sql
+-- Description: Blind index for looking up users by encrypted email
+
+-- +migrate Up
+ALTER TABLE users ADD COLUMN email_index TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_index ON users(email_index);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_users_email_index;
+ALTER TABLE users DROP COLUMN email_index;
@@ -0,0 +1,15 @@
+-- Migration: 028_create_deletion_certificates.sql
+-- Description: Proof of account deletion that keeps no personal data
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS deletion_certificates (
+    id TEXT PRIMARY KEY,
+    mode TEXT NOT NULL CHECK (mode IN ('delete', 'anonymize')),
+    subject_hash TEXT NOT NULL,
+    articles INTEGER NOT NULL DEFAULT 0,
+    comments INTEGER NOT NULL DEFAULT 0,
+    issued_at DATETIME NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS deletion_certificates;
@@ -0,0 +1,28 @@
+-- Migration: 029_create_legal_holds.sql
+-- Description: Legal holds that block deleting users and articles until released
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS legal_holds (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subject_type TEXT NOT NULL CHECK (subject_type IN ('user', 'article')),
+    subject_id INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    placed_by INTEGER,
+    placed_at DATETIME NOT NULL,
+    released_by INTEGER,
+    released_at DATETIME,
+    release_reason TEXT NOT NULL DEFAULT '',
+    blocked_attempts INTEGER NOT NULL DEFAULT 0,
+    last_blocked_at DATETIME,
+
+    -- Released holds are kept as the audit trail, even once the subject is gone
+    FOREIGN KEY (placed_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (released_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- A subject has at most one active hold
+CREATE UNIQUE INDEX IF NOT EXISTS idx_legal_holds_active ON legal_holds(subject_type, subject_id) WHERE released_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_legal_holds_active;
+DROP TABLE IF EXISTS legal_holds;
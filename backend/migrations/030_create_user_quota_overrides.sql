@@ -0,0 +1,19 @@
+-- Migration: 030_create_user_quota_overrides.sql
+-- Description: Per-user overrides of the daily article and comment quotas
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS user_quota_overrides (
+    user_id INTEGER PRIMARY KEY,
+    -- NULL keeps the account's default; 0 removes the cap
+    articles_per_day INTEGER CHECK (articles_per_day >= 0),
+    comments_per_day INTEGER CHECK (comments_per_day >= 0),
+    note TEXT NOT NULL DEFAULT '',
+    set_by INTEGER,
+    updated_at DATETIME NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (set_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_quota_overrides;
@@ -0,0 +1,15 @@
+-- Migration: 031_create_quarantine_approvals.sql
+-- Description: Admin approvals that release new accounts from quarantine early
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS quarantine_approvals (
+    user_id INTEGER PRIMARY KEY,
+    approved_by INTEGER,
+    approved_at DATETIME NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (approved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS quarantine_approvals;
@@ -0,0 +1,11 @@
+-- Migration: 032_add_comment_parent_id.sql
+-- Description: Let comments reply to another comment on the same article
+
+-- +migrate Up
+ALTER TABLE comments ADD COLUMN parent_id INTEGER REFERENCES comments(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_comments_parent_id;
+ALTER TABLE comments DROP COLUMN parent_id;
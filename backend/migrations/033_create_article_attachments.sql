@@ -0,0 +1,18 @@
+-- Migration: 033_create_article_attachments.sql
+-- Description: Create named text attachments (code snippets, small files) on articles
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS article_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    article_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    content TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (article_id, name),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS article_attachments;
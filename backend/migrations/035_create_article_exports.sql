@@ -0,0 +1,28 @@
+-- Migration: 035_create_article_exports.sql
+-- Description: Queue of article exports to downloadable documents (PDF) and the rendered results
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS article_exports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    article_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    format TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT '',
+    content BLOB,
+    created_at DATETIME NOT NULL,
+    completed_at DATETIME,
+
+    CHECK (status IN ('pending', 'ready', 'failed')),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_exports_request ON article_exports(article_id, user_id, format);
+CREATE INDEX IF NOT EXISTS idx_article_exports_status ON article_exports(status, id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_article_exports_status;
+DROP INDEX IF EXISTS idx_article_exports_request;
+DROP TABLE IF EXISTS article_exports;
@@ -0,0 +1,30 @@
+-- Migration: 036_create_book_exports.sql
+-- Description: Queue of series and picked articles bundled into books (EPUB) and the rendered results
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS book_exports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    series_id INTEGER,
+    article_ids TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL,
+    format TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT '',
+    content BLOB,
+    created_at DATETIME NOT NULL,
+    completed_at DATETIME,
+
+    CHECK (status IN ('pending', 'ready', 'failed')),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (series_id) REFERENCES series(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_exports_series ON book_exports(series_id, user_id, format);
+CREATE INDEX IF NOT EXISTS idx_book_exports_status ON book_exports(status, id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_book_exports_status;
+DROP INDEX IF EXISTS idx_book_exports_series;
+DROP TABLE IF EXISTS book_exports;
@@ -0,0 +1,18 @@
+-- Migration: 037_add_article_comment_activity.sql
+-- Description: Keep each article's live comment count and latest comment time for activity sorting
+
+-- +migrate Up
+ALTER TABLE articles ADD COLUMN comments_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE articles ADD COLUMN last_comment_at DATETIME;
+
+-- Placeholder-deleted comments keep their row but no longer count
+UPDATE articles SET
+    comments_count = (SELECT COUNT(*) FROM comments c WHERE c.article_id = articles.id AND c.deleted_at IS NULL),
+    last_comment_at = (SELECT MAX(c.created_at) FROM comments c WHERE c.article_id = articles.id AND c.deleted_at IS NULL);
+
+CREATE INDEX IF NOT EXISTS idx_articles_last_comment_at ON articles(last_comment_at DESC);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_articles_last_comment_at;
+ALTER TABLE articles DROP COLUMN last_comment_at;
+ALTER TABLE articles DROP COLUMN comments_count;
@@ -0,0 +1,18 @@
+-- Migration: 038_add_notification_groups.sql
+-- Description: Let bursts of the same notification coalesce into one row with a count
+
+-- +migrate Up
+ALTER TABLE notifications ADD COLUMN group_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE notifications ADD COLUMN count INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE notifications ADD COLUMN updated_at DATETIME;
+
+UPDATE notifications SET updated_at = created_at;
+
+-- Finds the open group a new notification joins
+CREATE INDEX IF NOT EXISTS idx_notifications_group ON notifications(user_id, group_key, created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_notifications_group;
+ALTER TABLE notifications DROP COLUMN updated_at;
+ALTER TABLE notifications DROP COLUMN count;
+ALTER TABLE notifications DROP COLUMN group_key;
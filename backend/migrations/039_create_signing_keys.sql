@@ -0,0 +1,18 @@
+-- Migration: 039_create_signing_keys.sql
+-- Description: Create the rotating key pairs access tokens are signed with
+
+-- +migrate Up
+-- The newest unretired key signs; retired keys verify until their tokens expire
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid TEXT PRIMARY KEY,
+    algorithm TEXT NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key BLOB NOT NULL,
+    created_at DATETIME NOT NULL,
+    retired_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_retired_at ON signing_keys(retired_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_signing_keys_retired_at;
+DROP TABLE IF EXISTS signing_keys;
@@ -0,0 +1,18 @@
+-- Migration: 041_create_notification_quiet_hours.sql
+-- Description: Per-user quiet hours during which immediate notifications are held
+
+-- +migrate Up
+-- Missing rows fall back to quiet hours being off
+CREATE TABLE IF NOT EXISTS notification_quiet_hours (
+    user_id INTEGER PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT 0,
+    start_time TEXT NOT NULL,
+    end_time TEXT NOT NULL,
+    timezone TEXT NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS notification_quiet_hours;
@@ -0,0 +1,24 @@
+-- Migration: 042_add_login_lockout.sql
+-- Description: Track failed logins and lock accounts after repeated failures
+
+-- +migrate Up
+ALTER TABLE users ADD COLUMN locked_until DATETIME;
+
+-- Network is the salted hash of the client IP, as for registrations
+CREATE TABLE IF NOT EXISTS login_failures (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    network TEXT NOT NULL,
+    failed_at DATETIME NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_failures_user ON login_failures(user_id);
+CREATE INDEX IF NOT EXISTS idx_login_failures_network ON login_failures(network, failed_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_login_failures_network;
+DROP INDEX IF EXISTS idx_login_failures_user;
+DROP TABLE IF EXISTS login_failures;
+ALTER TABLE users DROP COLUMN locked_until;
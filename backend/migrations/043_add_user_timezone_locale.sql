@@ -0,0 +1,10 @@
+-- Migration: 043_add_user_timezone_locale.sql
+-- Description: Per-user time zone and locale for digests, quiet hours and emails
+
+-- +migrate Up
+ALTER TABLE user_settings ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+ALTER TABLE user_settings ADD COLUMN locale TEXT NOT NULL DEFAULT 'en';
+
+-- +migrate Down
+ALTER TABLE user_settings DROP COLUMN locale;
+ALTER TABLE user_settings DROP COLUMN timezone;
@@ -0,0 +1,9 @@
+-- Migration: 044_add_user_birthdate.sql
+-- Description: Encrypted birthdates for deployments that verify users' ages
+
+-- +migrate Up
+-- Sealed by the PII cipher; NULL when the user never gave one
+ALTER TABLE users ADD COLUMN birthdate TEXT;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN birthdate;
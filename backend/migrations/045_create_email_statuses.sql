@@ -0,0 +1,18 @@
+-- Migration: 045_create_email_statuses.sql
+-- Description: Bounce and complaint tracking for outbound email
+
+-- +migrate Up
+-- Keyed by user rather than address, so encrypted emails need no plaintext copy
+CREATE TABLE IF NOT EXISTS email_statuses (
+    user_id INTEGER PRIMARY KEY,
+    status TEXT NOT NULL,
+    soft_bounces INTEGER NOT NULL DEFAULT 0,
+    last_event TEXT,
+    last_event_at DATETIME,
+    suppressed_at DATETIME,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_statuses;
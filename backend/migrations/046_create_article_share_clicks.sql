@@ -0,0 +1,16 @@
+-- Migration: 046_create_article_share_clicks.sql
+-- Description: Daily click counts of article share links by medium
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS article_share_clicks (
+    article_id INTEGER NOT NULL,
+    medium TEXT NOT NULL,
+    day TEXT NOT NULL,
+    clicks INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (article_id, medium, day),
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS article_share_clicks;
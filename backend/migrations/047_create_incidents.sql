@@ -0,0 +1,24 @@
+-- Migration: 047_create_incidents.sql
+-- Description: Incident and maintenance notices shown on the status page
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS incidents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL DEFAULT 'incident',
+    severity TEXT NOT NULL DEFAULT 'minor',
+    title TEXT NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    components TEXT NOT NULL DEFAULT '',
+    created_by INTEGER,
+    started_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_resolved_at ON incidents(resolved_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_incidents_resolved_at;
+DROP TABLE IF EXISTS incidents;